@@ -0,0 +1,82 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Query affinities. A query with an affinity reads each shard from a replica
+// on the node it was sent to, or in the given zone, when one holds the shard,
+// rather than from the shard's primary owner. Writes still go to every
+// replica.
+const (
+	AffinityLocal      = "local"
+	AffinityZonePrefix = "zone:"
+)
+
+// queryAffinity is the parsed form of a query affinity. The zero value
+// prefers no replica over another.
+type queryAffinity struct {
+	local bool
+	zone  string
+}
+
+// parseQueryAffinity parses a query affinity: empty, "local", or "zone:"
+// followed by the name of a zone.
+func parseQueryAffinity(s string) (queryAffinity, error) {
+	switch {
+	case s == "":
+		return queryAffinity{}, nil
+	case s == AffinityLocal:
+		return queryAffinity{local: true}, nil
+	case strings.HasPrefix(s, AffinityZonePrefix) && len(s) > len(AffinityZonePrefix):
+		return queryAffinity{zone: strings.TrimPrefix(s, AffinityZonePrefix)}, nil
+	default:
+		return queryAffinity{}, errors.Errorf("invalid affinity: %q", s)
+	}
+}
+
+// prefers returns true if the affinity prefers node, for a query executing on
+// the node with localID.
+func (a queryAffinity) prefers(node *Node, localID string) bool {
+	if a.local {
+		return node.ID == localID
+	}
+	return a.zone != "" && node.Zone == a.zone
+}
+
+// order returns the owners of a shard with the preferred ones first. The
+// owners are otherwise kept in order, so the primary owner is read from when
+// no replica is preferred.
+func (a queryAffinity) order(owners []*Node, localID string) []*Node {
+	if a == (queryAffinity{}) {
+		return owners
+	}
+	ordered := make([]*Node, 0, len(owners))
+	for _, node := range owners {
+		if a.prefers(node, localID) {
+			ordered = append(ordered, node)
+		}
+	}
+	for _, node := range owners {
+		if !a.prefers(node, localID) {
+			ordered = append(ordered, node)
+		}
+	}
+	return ordered
+}
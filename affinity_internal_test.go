@@ -0,0 +1,62 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseQueryAffinity(t *testing.T) {
+	for s, exp := range map[string]queryAffinity{
+		"":        {},
+		"local":   {local: true},
+		"zone:us": {zone: "us"},
+	} {
+		if a, err := parseQueryAffinity(s); err != nil {
+			t.Fatal(err)
+		} else if a != exp {
+			t.Fatalf("%q: expected %+v, got %+v", s, exp, a)
+		}
+	}
+	for _, s := range []string{"remote", "zone:", "us"} {
+		if _, err := parseQueryAffinity(s); err == nil {
+			t.Fatalf("%q: expected error", s)
+		}
+	}
+}
+
+// Ensure preferred replicas are ordered first, and the others keep their
+// order.
+func TestQueryAffinity_Order(t *testing.T) {
+	a := &Node{ID: "a", Zone: "x"}
+	b := &Node{ID: "b", Zone: "y"}
+	c := &Node{ID: "c", Zone: "y"}
+	owners := []*Node{a, b, c}
+
+	for _, tt := range []struct {
+		affinity queryAffinity
+		exp      []*Node
+	}{
+		{queryAffinity{}, []*Node{a, b, c}},
+		{queryAffinity{local: true}, []*Node{c, a, b}},
+		{queryAffinity{zone: "y"}, []*Node{b, c, a}},
+		{queryAffinity{zone: "z"}, []*Node{a, b, c}},
+	} {
+		if got := tt.affinity.order(owners, "c"); !reflect.DeepEqual(got, tt.exp) {
+			t.Fatalf("%+v: unexpected order: %v", tt.affinity, got)
+		}
+	}
+}
@@ -0,0 +1,337 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pilosa/pilosa/v2/pql"
+	"github.com/pkg/errors"
+)
+
+// aliasesFile is the file in the data directory which records the aliases.
+const aliasesFile = ".aliases"
+
+// Aliases are alternative names for indexes, and for fields within an index,
+// which queries may use in place of the names they refer to. Moving an alias
+// from one index or field to another lets clients keep using the same name
+// while the data behind it is rotated.
+//
+// Like Settings, the aliases of the whole cluster are changed at once, and
+// Version orders changes.
+type Aliases struct {
+	Version int64 `json:"version"`
+
+	// Indexes maps each index alias to the name of an index.
+	Indexes map[string]string `json:"indexes,omitempty"`
+
+	// Fields maps the name of an index to its field aliases, each of which
+	// maps to the name of a field in the index.
+	Fields map[string]map[string]string `json:"fields,omitempty"`
+}
+
+// AliasesUpdate is a change to the aliases, applied as a whole so that
+// several aliases may be swapped together. Each alias is set to the name
+// given, or removed if the name is empty.
+type AliasesUpdate struct {
+	Indexes map[string]string            `json:"indexes,omitempty"`
+	Fields  map[string]map[string]string `json:"fields,omitempty"`
+}
+
+// apply returns a with the update applied, or an error if the update is
+// invalid. Aliases must refer to indexes and fields which exist in h, and
+// may not have the name of one. The version of the result is later than
+// that of a.
+func (u AliasesUpdate) apply(a Aliases, h *Holder) (Aliases, error) {
+	a = a.clone()
+	for alias, name := range u.Indexes {
+		if err := validateName(alias); err != nil {
+			return a, err
+		} else if h.Index(alias) != nil {
+			return a, errors.Wrapf(ErrIndexExists, "alias '%s'", alias)
+		}
+		if name == "" {
+			delete(a.Indexes, alias)
+			continue
+		} else if h.Index(name) == nil {
+			return a, errors.Wrapf(ErrIndexNotFound, "'%s'", name)
+		}
+		if a.Indexes == nil {
+			a.Indexes = make(map[string]string)
+		}
+		a.Indexes[alias] = name
+	}
+	for index, aliases := range u.Fields {
+		idx := h.Index(index)
+		if idx == nil {
+			return a, errors.Wrapf(ErrIndexNotFound, "'%s'", index)
+		}
+		for alias, name := range aliases {
+			if err := validateName(alias); err != nil {
+				return a, err
+			} else if idx.Field(alias) != nil {
+				return a, errors.Wrapf(ErrFieldExists, "alias '%s'", alias)
+			}
+			if name == "" {
+				delete(a.Fields[index], alias)
+				if len(a.Fields[index]) == 0 {
+					delete(a.Fields, index)
+				}
+				continue
+			} else if idx.Field(name) == nil {
+				return a, errors.Wrapf(ErrFieldNotFound, "'%s'", name)
+			}
+			if a.Fields == nil {
+				a.Fields = make(map[string]map[string]string)
+			}
+			if a.Fields[index] == nil {
+				a.Fields[index] = make(map[string]string)
+			}
+			a.Fields[index][alias] = name
+		}
+	}
+
+	a.succeed(a.Version)
+	return a, nil
+}
+
+// succeed sets the version of a to one later than version.
+func (a *Aliases) succeed(version int64) {
+	a.Version = nextVersion(version)
+}
+
+// nextVersion returns a version later than version. Versions are times, so
+// that changes made on different nodes are ordered.
+func nextVersion(version int64) int64 {
+	if v := time.Now().UnixNano(); v > version {
+		return v
+	}
+	return version + 1
+}
+
+// clone returns a copy of a which shares no maps with it.
+func (a Aliases) clone() Aliases {
+	other := Aliases{Version: a.Version}
+	if a.Indexes != nil {
+		other.Indexes = make(map[string]string, len(a.Indexes))
+		for alias, name := range a.Indexes {
+			other.Indexes[alias] = name
+		}
+	}
+	if a.Fields != nil {
+		other.Fields = make(map[string]map[string]string, len(a.Fields))
+		for index, aliases := range a.Fields {
+			other.Fields[index] = make(map[string]string, len(aliases))
+			for alias, name := range aliases {
+				other.Fields[index][alias] = name
+			}
+		}
+	}
+	return other
+}
+
+// aliasStore holds a node's copy of the aliases. The aliases are replaced
+// rather than modified, so a copy returned by get may be read without
+// holding the lock.
+type aliasStore struct {
+	mu      sync.RWMutex
+	aliases Aliases
+	path    string
+}
+
+func newAliasStore() *aliasStore {
+	return &aliasStore{}
+}
+
+// open loads the aliases recorded in the file at path, if they are more
+// recent than the current aliases, and records later aliases there.
+func (s *aliasStore) open(path string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.path = path
+
+	buf, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "reading aliases file")
+	}
+	var aliases Aliases
+	if err := json.Unmarshal(buf, &aliases); err != nil {
+		return errors.Wrap(err, "decoding aliases file")
+	}
+	if aliases.Version > s.aliases.Version {
+		s.aliases = aliases
+	}
+	return nil
+}
+
+// get returns the current aliases.
+func (s *aliasStore) get() Aliases {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.aliases
+}
+
+// update replaces the current aliases with aliases, if they are more
+// recent, and returns true if they were replaced.
+func (s *aliasStore) update(aliases Aliases) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if aliases.Version <= s.aliases.Version {
+		return false, nil
+	}
+	s.aliases = aliases
+	return true, s.unprotectedSave()
+}
+
+// apply changes the current aliases by update, and returns the aliases it
+// replaced and the new aliases. The aliases are read, changed and replaced
+// under the lock, so that an update made concurrently isn't lost. An invalid
+// update returns a BadRequestError; other errors are from saving the new
+// aliases.
+func (s *aliasStore) apply(update AliasesUpdate, h *Holder) (previous, aliases Aliases, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	previous = s.aliases
+	if aliases, err = update.apply(previous, h); err != nil {
+		return previous, Aliases{}, NewBadRequestError(err)
+	}
+	s.aliases = aliases
+	return previous, aliases, s.unprotectedSave()
+}
+
+// revert undoes update, which replaced previous, by restoring previous with
+// a later version. Returns the restored aliases, and false if the aliases
+// have been replaced since update, in which case they are kept.
+func (s *aliasStore) revert(previous, update Aliases) (Aliases, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.aliases.Version != update.Version {
+		return Aliases{}, false, nil
+	}
+	previous = previous.clone()
+	previous.succeed(update.Version)
+	s.aliases = previous
+	return previous, true, s.unprotectedSave()
+}
+
+// unprotectedSave writes the aliases to the aliases file, if any.
+func (s *aliasStore) unprotectedSave() error {
+	if s.path == "" {
+		return nil
+	}
+	buf, err := json.Marshal(s.aliases)
+	if err != nil {
+		return errors.Wrap(err, "encoding aliases file")
+	}
+
+	tempPath := s.path + tempExt
+	if err := ioutil.WriteFile(tempPath, buf, 0666); err != nil {
+		return errors.Wrap(err, "writing aliases file")
+	}
+	return errors.Wrap(os.Rename(tempPath, s.path), "renaming aliases file")
+}
+
+// resolveIndex returns the name of the index which name refers to. An index
+// named name takes precedence over an alias, which may have been created
+// after it.
+func (h *Holder) resolveIndex(name string) string {
+	if h.Index(name) != nil {
+		return name
+	}
+	if index, ok := h.aliases.get().Indexes[name]; ok {
+		return index
+	}
+	return name
+}
+
+// resolveFieldAliases replaces the field aliases used by calls with the
+// names of the fields they refer to. The children of Index() calls read
+// another index, and are resolved by resolveCrossIndexAliases.
+func (h *Holder) resolveFieldAliases(idx *Index, calls []*pql.Call) {
+	aliases := h.aliases.get().Fields[idx.Name()]
+	if len(aliases) == 0 {
+		return
+	}
+	resolve := func(name string) (string, bool) {
+		if idx.Field(name) != nil {
+			return name, false
+		}
+		field, ok := aliases[name]
+		return field, ok
+	}
+
+	var walk func(c *pql.Call)
+	walk = func(c *pql.Call) {
+		if c.Name == "Index" {
+			return
+		}
+		for k, v := range c.Args {
+			switch v := v.(type) {
+			case string:
+				if k == "_field" || k == "field" {
+					if field, ok := resolve(v); ok {
+						c.Args[k] = field
+					}
+				}
+			case *pql.Call:
+				walk(v)
+			}
+		}
+		for k, v := range c.Args {
+			if pql.IsReservedArg(k) {
+				continue
+			}
+			if field, ok := resolve(k); ok {
+				if _, exists := c.Args[field]; exists {
+					continue
+				}
+				delete(c.Args, k)
+				c.Args[field] = v
+			}
+		}
+		for _, child := range c.Children {
+			walk(child)
+		}
+	}
+	for _, c := range calls {
+		walk(c)
+	}
+}
+
+// resolveCrossIndexAliases replaces the index aliases named by Index() calls
+// with the names of the indexes they refer to, and the field aliases used by
+// their children with the names of those indexes' fields.
+func (h *Holder) resolveCrossIndexAliases(calls []*pql.Call) {
+	for _, c := range calls {
+		if name, ok := c.Args["name"].(string); ok && c.Name == "Index" {
+			c.Args["name"] = h.resolveIndex(name)
+			if other := h.Index(c.Args["name"].(string)); other != nil {
+				h.resolveFieldAliases(other, c.Children)
+			}
+		}
+		h.resolveCrossIndexAliases(c.Children)
+	}
+}
+
+// AliasesMessage is an internal message for broadcasting the aliases.
+type AliasesMessage struct {
+	Aliases Aliases
+}
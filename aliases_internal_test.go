@@ -0,0 +1,173 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/pilosa/pilosa/v2/pql"
+	"github.com/pkg/errors"
+)
+
+func TestAliasesUpdate_Apply(t *testing.T) {
+	h := newHolder()
+	if err := h.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+	h.MustCreateFieldIfNotExists("i0", "f0")
+	h.MustCreateFieldIfNotExists("i1", "f1")
+
+	a, err := AliasesUpdate{
+		Indexes: map[string]string{"current": "i0"},
+		Fields:  map[string]map[string]string{"i1": {"latest": "f1"}},
+	}.apply(Aliases{}, h.Holder)
+	if err != nil {
+		t.Fatal(err)
+	} else if a.Indexes["current"] != "i0" || a.Fields["i1"]["latest"] != "f1" {
+		t.Fatalf("unexpected aliases: %+v", a)
+	}
+
+	// Aliases are moved and removed together.
+	b, err := AliasesUpdate{
+		Indexes: map[string]string{"current": "i1"},
+		Fields:  map[string]map[string]string{"i1": {"latest": ""}},
+	}.apply(a, h.Holder)
+	if err != nil {
+		t.Fatal(err)
+	} else if b.Indexes["current"] != "i1" || len(b.Fields) != 0 {
+		t.Fatalf("unexpected aliases: %+v", b)
+	} else if b.Version <= a.Version {
+		t.Fatalf("expected later version: %d", b.Version)
+	} else if a.Indexes["current"] != "i0" {
+		t.Fatal("expected original aliases to be unchanged")
+	}
+
+	if _, err := (AliasesUpdate{Indexes: map[string]string{"current": "nope"}}).apply(a, h.Holder); errors.Cause(err) != ErrIndexNotFound {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := (AliasesUpdate{Indexes: map[string]string{"i1": "i0"}}).apply(a, h.Holder); errors.Cause(err) != ErrIndexExists {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := (AliasesUpdate{Fields: map[string]map[string]string{"i1": {"latest": "f0"}}}).apply(a, h.Holder); errors.Cause(err) != ErrFieldNotFound {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// Ensure aliases are kept across restarts.
+func TestAliasStore_Reopen(t *testing.T) {
+	h := newHolder()
+	if err := h.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+	h.MustCreateIndexIfNotExists("i0", IndexOptions{})
+
+	if ok, err := h.aliases.update(Aliases{Version: 2, Indexes: map[string]string{"current": "i0"}}); err != nil {
+		t.Fatal(err)
+	} else if !ok {
+		t.Fatal("expected update")
+	}
+	if ok, err := h.aliases.update(Aliases{Version: 1}); err != nil {
+		t.Fatal(err)
+	} else if ok {
+		t.Fatal("unexpected update with older version")
+	}
+
+	if err := h.Holder.Close(); err != nil {
+		t.Fatal(err)
+	} else if err := h.Reopen(); err != nil {
+		t.Fatal(err)
+	} else if got := h.resolveIndex("current"); got != "i0" {
+		t.Fatalf("unexpected index: %s", got)
+	}
+}
+
+// Ensure concurrent updates are each applied to the result of the others,
+// and that undoing an update doesn't undo one made since.
+func TestAliasStore_Apply(t *testing.T) {
+	h := newHolder()
+	if err := h.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+	h.MustCreateIndexIfNotExists("i0", IndexOptions{})
+
+	var wg sync.WaitGroup
+	for _, alias := range []string{"a", "b", "c"} {
+		wg.Add(1)
+		go func(alias string) {
+			defer wg.Done()
+			if _, _, err := h.aliases.apply(AliasesUpdate{Indexes: map[string]string{alias: "i0"}}, h.Holder); err != nil {
+				t.Error(err)
+			}
+		}(alias)
+	}
+	wg.Wait()
+	if got := h.aliases.get(); len(got.Indexes) != 3 {
+		t.Fatalf("unexpected aliases: %+v", got)
+	}
+
+	previous, update, err := h.aliases.apply(AliasesUpdate{Indexes: map[string]string{"d": "i0"}}, h.Holder)
+	if err != nil {
+		t.Fatal(err)
+	} else if _, _, err := h.aliases.apply(AliasesUpdate{Indexes: map[string]string{"e": "i0"}}, h.Holder); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, err := h.aliases.revert(previous, update); err != nil {
+		t.Fatal(err)
+	} else if ok {
+		t.Fatal("expected later update to be kept")
+	} else if got := h.aliases.get(); len(got.Indexes) != 5 {
+		t.Fatalf("unexpected aliases: %+v", got)
+	}
+
+	if _, _, err := h.aliases.apply(AliasesUpdate{Indexes: map[string]string{"i0": "i0"}}, h.Holder); err == nil {
+		t.Fatal("expected error")
+	} else if _, ok := err.(BadRequestError); !ok {
+		t.Fatalf("unexpected error type: %T", err)
+	}
+}
+
+func TestHolder_ResolveFieldAliases(t *testing.T) {
+	h := newHolder()
+	if err := h.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+	h.MustCreateFieldIfNotExists("i", "f201910")
+	h.MustCreateFieldIfNotExists("i", "g")
+	if _, err := h.aliases.update(Aliases{Version: 1, Fields: map[string]map[string]string{"i": {"f": "f201910", "g": "f201910"}}}); err != nil {
+		t.Fatal(err)
+	}
+
+	q, err := pql.NewParser(strings.NewReader(`Count(Intersect(Row(f=1), Row(g=2))) TopN(f, n=2)`)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.resolveFieldAliases(h.Index("i"), q.Calls)
+
+	// A field named like an alias takes precedence over it.
+	rows := q.Calls[0].Children[0].Children
+	if _, ok := rows[0].Args["f201910"]; !ok {
+		t.Fatalf("unexpected args: %v", rows[0].Args)
+	} else if _, ok := rows[1].Args["g"]; !ok {
+		t.Fatalf("unexpected args: %v", rows[1].Args)
+	} else if field := q.Calls[1].Args["_field"]; field != "f201910" {
+		t.Fatalf("unexpected field: %v", field)
+	}
+}
@@ -20,13 +20,17 @@ import (
 	"context"
 	"encoding/binary"
 	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"math"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/pilosa/pilosa/v2/pql"
@@ -140,24 +144,196 @@ func (api *API) Query(ctx context.Context, req *QueryRequest) (QueryResponse, er
 		return QueryResponse{}, errors.Wrap(err, "validating api method")
 	}
 
+	api.server.load.countQuery()
+
+	start := time.Now()
 	q, err := pql.NewParser(strings.NewReader(req.Query)).Parse()
 	if err != nil {
-		return QueryResponse{}, errors.Wrap(err, "parsing")
+		return QueryResponse{}, NewBadRequestError(errors.Wrap(err, "parsing"))
+	}
+	parseTime := time.Since(start)
+	if err := api.checkQueryNamespace(ctx, req.Index, q); err != nil {
+		return QueryResponse{}, err
+	}
+	if q.WriteCallN() > 0 {
+		if err := api.validateWritable(api.Node()); err != nil {
+			return QueryResponse{}, err
+		} else if err := api.validateIndexWritable(api.holder.resolveIndex(req.Index)); err != nil {
+			return QueryResponse{}, err
+		}
+	}
+	priority, err := parseQueryPriority(req.Priority)
+	if err != nil {
+		return QueryResponse{}, NewBadRequestError(err)
+	}
+	ctx = withQueryPriority(ctx, priority)
+	affinity, err := parseQueryAffinity(req.Affinity)
+	if err != nil {
+		return QueryResponse{}, NewBadRequestError(err)
+	}
+
+	// Batch queries are shed under memory pressure by the node they
+	// originated on, like admission.
+	if priority == priorityBatch && !req.Remote {
+		if err := api.validateNotOverloaded(); err != nil {
+			return QueryResponse{}, err
+		}
+	}
+
+	// Queries are counted against the quota of the node they originated
+	// on, while every node counts the containers it scans.
+	if req.Remote {
+		ctx = WithInternalRequest(ctx)
+	}
+	token := UsageToken(ctx)
+	if !req.Remote {
+		if err := api.server.usage.charge(token, Usage{Queries: 1}); err != nil {
+			return QueryResponse{}, err
+		}
+	}
+	ctx, scan := withQueryScan(ctx)
+	execStart := time.Now()
+	defer func() {
+		api.server.usage.add(token, Usage{Containers: scan.count(), CPUTime: int64(time.Since(execStart))})
+	}()
+
+	// Remote queries are admitted by the node they originated on.
+	if !req.Remote {
+		if err := api.server.admission.acquire(ctx, priority); err != nil {
+			return QueryResponse{}, errors.Wrap(err, "waiting for admission")
+		}
+		defer api.server.admission.release()
+	}
+
+	var profile *queryProfile
+	if req.Profile {
+		ctx, profile = withQueryProfile(ctx, api.server.nodeID)
 	}
+
 	execOpts := &execOptions{
 		Remote:          req.Remote,
 		ExcludeRowAttrs: req.ExcludeRowAttrs, // NOTE: Kept for Pilosa 1.x compat.
 		ExcludeColumns:  req.ExcludeColumns,  // NOTE: Kept for Pilosa 1.x compat.
 		ColumnAttrs:     req.ColumnAttrs,     // NOTE: Kept for Pilosa 1.x compat.
+		ContinueOnError: req.ContinueOnError,
+		Affinity:        affinity,
 	}
+	start = time.Now()
 	resp, err := api.server.executor.Execute(ctx, req.Index, q, req.Shards, execOpts)
 	if err != nil {
 		return QueryResponse{}, errors.Wrap(err, "executing")
 	}
+	if profile != nil {
+		resp.Profile = profile.report(parseTime, time.Since(start))
+	}
 
 	return resp, nil
 }
 
+// QueryAsync starts a background job which executes a query, and returns its
+// status without waiting for the query to finish. The result is fetched with
+// QueryResult.
+func (api *API) QueryAsync(ctx context.Context, req *QueryRequest) (JobStatus, error) {
+	if err := api.validate(apiQuery); err != nil {
+		return JobStatus{}, errors.Wrap(err, "validating api method")
+	}
+
+	// Reject malformed queries up front rather than in the job.
+	if _, err := pql.NewParser(strings.NewReader(req.Query)).Parse(); err != nil {
+		return JobStatus{}, NewBadRequestError(errors.Wrap(err, "parsing"))
+	} else if _, err := parseQueryAffinity(req.Affinity); err != nil {
+		return JobStatus{}, NewBadRequestError(err)
+	} else if priority, err := parseQueryPriority(req.Priority); err != nil {
+		return JobStatus{}, NewBadRequestError(err)
+	} else if priority == priorityBatch {
+		if err := api.validateNotOverloaded(); err != nil {
+			return JobStatus{}, err
+		}
+	}
+
+	token, id := APITokenFromContext(ctx), RequestIDFromContext(ctx)
+	status := JobStatus{Type: JobTypeQuery, Index: req.Index}
+	return api.server.jobs.startResult(status, func(ctx context.Context, progress func(done, total int)) (interface{}, error) {
+		return api.Query(WithRequestID(WithAPIToken(ctx, token), id), req)
+	}), nil
+}
+
+// ValidateQuery checks a query against the schema of its index without
+// executing it, returning a diagnostic for each problem found. A query which
+// can't be parsed has a single diagnostic, with an empty path.
+func (api *API) ValidateQuery(ctx context.Context, req *QueryRequest) ([]QueryDiagnostic, error) {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.ValidateQuery")
+	defer span.Finish()
+
+	if err := api.validate(apiQuery); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+
+	idx := api.holder.Index(api.holder.resolveIndex(req.Index))
+	if idx == nil {
+		return nil, newNotFoundError(ErrIndexNotFound, req.Index)
+	}
+	q, err := pql.NewParser(strings.NewReader(req.Query)).Parse()
+	if err != nil {
+		return []QueryDiagnostic{{
+			Severity:      DiagnosticError,
+			Path:          []int{},
+			ResponseError: *NewResponseError(NewBadRequestError(errors.Wrap(err, "parsing"))),
+		}}, nil
+	}
+
+	// The query is checked as it would be executed.
+	if err := idx.rewriteCalls(q.Calls); err != nil {
+		return []QueryDiagnostic{{
+			Severity:      DiagnosticError,
+			Path:          []int{},
+			ResponseError: *NewResponseError(NewBadRequestError(err)),
+		}}, nil
+	}
+	api.holder.resolveFieldAliases(idx, q.Calls)
+	api.holder.resolveCrossIndexAliases(q.Calls)
+
+	v := &queryValidator{
+		holder: api.holder,
+		customCall: func(name string) bool {
+			_, ok := api.server.executor.customCall(name)
+			return ok
+		},
+	}
+	for i, c := range q.Calls {
+		v.validate(idx, c, []int{i})
+	}
+	return v.diags, nil
+}
+
+// QueryResult returns the status of a job started by QueryAsync, along with
+// the query's response once the job has finished. If wait is true, it waits
+// for the job to finish, or for ctx to be done.
+func (api *API) QueryResult(ctx context.Context, id int64, wait bool) (JobStatus, *QueryResponse, error) {
+	if err := api.validate(apiJobs); err != nil {
+		return JobStatus{}, nil, errors.Wrap(err, "validating api method")
+	}
+
+	job, result, err := api.server.jobs.result(ctx, id, wait)
+	if err != nil {
+		return JobStatus{}, nil, err
+	} else if job.Type != JobTypeQuery {
+		return JobStatus{}, nil, NewBadRequestError(errors.Errorf("job %d is not a query", id))
+	}
+
+	switch job.State {
+	case JobStateRunning:
+		return job, nil, nil
+	case JobStateDone:
+		resp := result.(QueryResponse)
+		return job, &resp, nil
+	case JobStateCanceled:
+		return job, &QueryResponse{Err: errors.New("query canceled")}, nil
+	default:
+		return job, &QueryResponse{Err: errors.New(job.Error)}, nil
+	}
+}
+
 // CreateIndex makes a new Pilosa index.
 func (api *API) CreateIndex(ctx context.Context, indexName string, options IndexOptions) (*Index, error) {
 	span, _ := tracing.StartSpanFromContext(ctx, "API.CreateIndex")
@@ -167,19 +343,28 @@ func (api *API) CreateIndex(ctx context.Context, indexName string, options Index
 		return nil, errors.Wrap(err, "validating api method")
 	}
 
-	// Create index.
-	index, err := api.holder.CreateIndex(indexName, options)
-	if err != nil {
+	// Check the common errors here, so that they keep their types when the
+	// change is made by the coordinator.
+	if err := validateIndexName(indexName); err != nil {
 		return nil, errors.Wrap(err, "creating index")
+	} else if api.holder.Index(indexName) != nil {
+		return nil, errors.Wrap(NewConflictError(ErrIndexExists), "creating index")
+	} else if _, err := loadTimeZone(options.TimeZone); err != nil {
+		return nil, errors.Wrap(NewBadRequestError(err), "creating index")
 	}
-	// Send the create index message to all nodes.
-	err = api.server.SendSync(
+
+	// Create the index on all nodes.
+	err := api.server.changeSchema(
 		&CreateIndexMessage{
 			Index: indexName,
 			Meta:  &options,
 		})
 	if err != nil {
-		return nil, errors.Wrap(err, "sending CreateIndex message")
+		return nil, errors.Wrap(err, "creating index")
+	}
+	index := api.holder.Index(indexName)
+	if index == nil {
+		return nil, newNotFoundError(ErrIndexNotFound, indexName)
 	}
 	api.holder.Stats.Count("createIndex", 1, 1.0)
 	return index, nil
@@ -211,19 +396,17 @@ func (api *API) DeleteIndex(ctx context.Context, indexName string) error {
 		return errors.Wrap(err, "validating api method")
 	}
 
-	// Delete index from the holder.
-	err := api.holder.DeleteIndex(indexName)
-	if err != nil {
-		return errors.Wrap(err, "deleting index")
+	if api.holder.Index(indexName) == nil {
+		return errors.Wrap(newNotFoundError(ErrIndexNotFound, indexName), "deleting index")
 	}
-	// Send the delete index message to all nodes.
-	err = api.server.SendSync(
+
+	// Delete the index from all nodes.
+	err := api.server.changeSchema(
 		&DeleteIndexMessage{
 			Index: indexName,
 		})
 	if err != nil {
-		api.server.logger.Printf("problem sending DeleteIndex message: %s", err)
-		return errors.Wrap(err, "sending DeleteIndex message")
+		return errors.Wrap(err, "deleting index")
 	}
 	api.holder.Stats.Count("deleteIndex", 1, 1.0)
 	return nil
@@ -255,22 +438,27 @@ func (api *API) CreateField(ctx context.Context, indexName string, fieldName str
 		return nil, newNotFoundError(ErrIndexNotFound, indexName)
 	}
 
-	// Create field.
-	field, err := index.CreateField(fieldName, opts...)
-	if err != nil {
+	// Check the common errors here, so that they keep their types when the
+	// change is made by the coordinator.
+	if err := validateName(fieldName); err != nil {
 		return nil, errors.Wrap(err, "creating field")
+	} else if index.Field(fieldName) != nil {
+		return nil, errors.Wrap(NewConflictError(ErrFieldExists), "creating field")
 	}
 
-	// Send the create field message to all nodes.
-	err = api.server.SendSync(
+	// Create the field on all nodes.
+	err := api.server.changeSchema(
 		&CreateFieldMessage{
 			Index: indexName,
 			Field: fieldName,
 			Meta:  &fo,
 		})
 	if err != nil {
-		api.server.logger.Printf("problem sending CreateField message: %s", err)
-		return nil, errors.Wrap(err, "sending CreateField message")
+		return nil, errors.Wrap(err, "creating field")
+	}
+	field := index.Field(fieldName)
+	if field == nil {
+		return nil, newNotFoundError(ErrFieldNotFound, fieldName)
 	}
 	api.holder.Stats.CountWithCustomTags("createField", 1, 1.0, []string{fmt.Sprintf("index:%s", indexName)})
 	return field, nil
@@ -374,7 +562,24 @@ func (api *API) ImportRoaring(ctx context.Context, indexName, fieldName string,
 		return errors.Wrap(err, "validating api method")
 	}
 
-	nodes := api.cluster.shardNodes(indexName, shard)
+	nodes, missed := api.cluster.writeNodes(indexName, shard)
+	if err = api.validateWritable(nodes...); err != nil {
+		return err
+	} else if err = api.validateIndexWritable(indexName); err != nil {
+		return err
+	}
+	if !remote {
+		if err = api.validateNotOverloaded(); err != nil {
+			return err
+		} else if err = api.validateWriteBacklog(); err != nil {
+			return err
+		}
+	}
+	if remote {
+		defer api.server.executor.invalidateTopN(indexName, fieldName)
+	} else {
+		defer api.server.executor.invalidateTopNCluster(indexName, fieldName)
+	}
 
 	field := api.holder.Field(indexName, fieldName)
 	if field == nil {
@@ -386,11 +591,26 @@ func (api *API) ImportRoaring(ctx context.Context, indexName, fieldName string,
 		return NewBadRequestError(errors.New("roaring import is only supported for set and time fields"))
 	}
 
+	// Imports are counted against the quota of the node they were sent to.
+	if !remote {
+		if err = api.server.usage.charge(UsageToken(ctx), Usage{ImportBytes: importRoaringRequestSize(req)}); err != nil {
+			return err
+		}
+		defer api.server.usage.addTime(UsageToken(ctx), time.Now())
+	}
+
+	if !remote {
+		if err = api.cluster.addHint(missed, hint{Type: hintTypeImportRoaring, Index: indexName, Field: fieldName, Shard: shard, Views: req.Views, Clear: req.Clear}); err != nil {
+			return err
+		}
+	}
+
 	errCh := make(chan error, len(nodes))
 
 	for _, node := range nodes {
 		node := node
 		if node.ID == api.server.nodeID {
+			api.holder.shardLoads.countWrite(indexName, shard)
 			api.importWork <- importJob{
 				ctx:     ctx,
 				req:     req,
@@ -401,7 +621,7 @@ func (api *API) ImportRoaring(ctx context.Context, indexName, fieldName string,
 		} else if !remote { // if remote == true we don't forward to other nodes
 			// forward it on
 			go func() {
-				errCh <- api.server.defaultClient.ImportRoaring(ctx, &node.URI, indexName, fieldName, shard, true, req)
+				errCh <- api.server.defaultClient.ImportRoaring(ctx, node.clusterURI(), indexName, fieldName, shard, true, req)
 			}()
 		} else {
 			errCh <- nil
@@ -444,25 +664,215 @@ func (api *API) DeleteField(ctx context.Context, indexName string, fieldName str
 		return newNotFoundError(ErrIndexNotFound, indexName)
 	}
 
-	// Delete field from the index.
-	if err := index.DeleteField(fieldName); err != nil {
-		return errors.Wrap(err, "deleting field")
+	if index.Field(fieldName) == nil {
+		return errors.Wrap(newNotFoundError(ErrFieldNotFound, fieldName), "deleting field")
 	}
 
-	// Send the delete field message to all nodes.
-	err := api.server.SendSync(
+	// Delete the field from all nodes.
+	err := api.server.changeSchema(
 		&DeleteFieldMessage{
 			Index: indexName,
 			Field: fieldName,
 		})
 	if err != nil {
-		api.server.logger.Printf("problem sending DeleteField message: %s", err)
-		return errors.Wrap(err, "sending DeleteField message")
+		return errors.Wrap(err, "deleting field")
 	}
 	api.holder.Stats.CountWithCustomTags("deleteField", 1, 1.0, []string{fmt.Sprintf("index:%s", indexName)})
 	return nil
 }
 
+// DeleteRows clears the rows from start to end, inclusive, in every shard and
+// view of a field across the cluster. It returns true if any bits were
+// cleared.
+func (api *API) DeleteRows(ctx context.Context, indexName, fieldName string, start, end uint64) (bool, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.DeleteRows")
+	defer span.Finish()
+
+	if err := api.validate(apiDeleteRows); err != nil {
+		return false, errors.Wrap(err, "validating api method")
+	}
+
+	if api.holder.Index(indexName) == nil {
+		return false, newNotFoundError(ErrIndexNotFound, indexName)
+	} else if api.holder.Field(indexName, fieldName) == nil {
+		return false, newNotFoundError(ErrFieldNotFound, fieldName)
+	} else if end < start || end > math.MaxInt64 {
+		return false, NewBadRequestError(errors.Errorf("invalid row range: %d-%d", start, end))
+	}
+
+	call := &pql.Call{Name: "ClearRow", Args: map[string]interface{}{fieldName: start}}
+	if end > start {
+		call.Args[fieldName] = &pql.Condition{Op: pql.BETWEEN, Value: []interface{}{int64(start), int64(end)}}
+	}
+	resp, err := api.Query(ctx, &QueryRequest{Index: indexName, Query: call.String()})
+	if err != nil {
+		return false, errors.Wrap(err, "clearing rows")
+	}
+	return resp.Results[0].(bool), nil
+}
+
+// TruncateField removes all of a field's data on every node in the cluster,
+// keeping the field itself. Fragment files are deleted rather than cleared,
+// so the cost depends on the number of fragments, not the number of bits.
+func (api *API) TruncateField(ctx context.Context, indexName, fieldName string) error {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.TruncateField")
+	defer span.Finish()
+
+	if err := api.validate(apiTruncateField); err != nil {
+		return errors.Wrap(err, "validating api method")
+	}
+
+	if api.holder.Index(indexName) == nil {
+		return newNotFoundError(ErrIndexNotFound, indexName)
+	}
+	field := api.holder.Field(indexName, fieldName)
+	if field == nil {
+		return newNotFoundError(ErrFieldNotFound, fieldName)
+	}
+
+	// Nodes which do not handle the message would keep their data.
+	if err := api.cluster.validateFeatures(FeatureTruncateField); err != nil {
+		return errors.Wrap(err, "truncating field")
+	} else if err := api.validateIndexWritable(indexName); err != nil {
+		return err
+	}
+
+	if err := field.truncate(); err != nil {
+		return errors.Wrap(err, "truncating field")
+	}
+	api.holder.changes.append(ChangeEvent{Type: ChangeTypeTruncate, Index: indexName, Field: fieldName})
+	api.server.executor.invalidateTopN(indexName, fieldName)
+
+	// Send the truncate field message to all nodes.
+	if err := api.server.SendSync(&TruncateFieldMessage{Index: indexName, Field: fieldName}); err != nil {
+		return errors.Wrap(err, "sending TruncateField message")
+	}
+	return nil
+}
+
+// FieldHistogram returns the distribution of the cardinalities of a field's
+// rows, and its heaviest rows. The cardinalities of the rows in the field's
+// caches are read from them, and a sample of at most opt.Sample of the other
+// rows is counted, so the histogram of a field with many uncached rows is an
+// estimate.
+func (api *API) FieldHistogram(ctx context.Context, indexName, fieldName string, opt FieldHistogramOptions) (*FieldHistogram, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.FieldHistogram")
+	defer span.Finish()
+
+	if err := api.validate(apiFieldHistogram); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+
+	if api.holder.Index(indexName) == nil {
+		return nil, newNotFoundError(ErrIndexNotFound, indexName)
+	}
+	field := api.holder.Field(indexName, fieldName)
+	if field == nil {
+		return nil, newNotFoundError(ErrFieldNotFound, fieldName)
+	} else if field.Type() == FieldTypeInt {
+		return nil, NewBadRequestError(errors.Errorf("integer field %q has no rows", fieldName))
+	}
+	if opt.Top <= 0 {
+		opt.Top = defaultHistogramTop
+	}
+	if opt.Sample <= 0 {
+		opt.Sample = defaultHistogramSample
+	}
+
+	execute := func(c *pql.Call) (interface{}, error) {
+		results, err := api.server.executor.execute(ctx, indexName, &pql.Query{Calls: []*pql.Call{c}}, nil, &execOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return results[0], nil
+	}
+
+	v, err := execute(&pql.Call{Name: "Rows", Args: map[string]interface{}{"_field": fieldName}})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing rows")
+	}
+	rowIDs, _ := v.(RowIDs)
+
+	var cached []Pair
+	if field.Options().CacheType != CacheTypeNone {
+		v, err := execute(&pql.Call{Name: "TopN", Args: map[string]interface{}{"_field": fieldName, "cached": true}})
+		if err != nil {
+			return nil, errors.Wrap(err, "reading cached rows")
+		}
+		cached, _ = v.([]Pair)
+	}
+
+	// Scan a sample of the rows which are not cached.
+	inCache := make(map[uint64]struct{}, len(cached))
+	for _, p := range cached {
+		inCache[p.ID] = struct{}{}
+	}
+	uncached := make([]uint64, 0, len(rowIDs))
+	for _, id := range rowIDs {
+		if _, ok := inCache[id]; !ok {
+			uncached = append(uncached, id)
+		}
+	}
+	var scanned []Pair
+	if sample := sampleRowIDs(uncached, opt.Sample); len(sample) > 0 {
+		v, err := execute(&pql.Call{Name: "TopN", Args: map[string]interface{}{"_field": fieldName, "ids": sample}})
+		if err != nil {
+			return nil, errors.Wrap(err, "scanning rows")
+		}
+		scanned, _ = v.([]Pair)
+	}
+
+	h := newFieldHistogram(uint64(len(cached)+len(uncached)), cached, scanned, opt.Top)
+	if field.keys() {
+		for i := range h.Heaviest {
+			key, err := field.translateStore.TranslateID(h.Heaviest[i].ID)
+			if err != nil {
+				return nil, errors.Wrap(err, "translating row")
+			}
+			h.Heaviest[i] = Pair{Key: key, Count: h.Heaviest[i].Count}
+		}
+	}
+	return h, nil
+}
+
+// SetIndexReadOnly marks an index read-only, or writable, on every node.
+// Queries which write to a read-only index, and imports into it, fail with
+// ErrIndexReadOnly, while other queries are still served.
+func (api *API) SetIndexReadOnly(ctx context.Context, indexName string, readOnly bool) error {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.SetIndexReadOnly")
+	defer span.Finish()
+
+	if err := api.validate(apiSetIndexReadOnly); err != nil {
+		return errors.Wrap(err, "validating api method")
+	}
+
+	index := api.holder.Index(indexName)
+	if index == nil {
+		return newNotFoundError(ErrIndexNotFound, indexName)
+	}
+
+	// Nodes which do not handle the message would keep accepting writes.
+	if err := api.cluster.validateFeatures(FeatureIndexReadOnly); err != nil {
+		return errors.Wrap(err, "setting index read-only")
+	}
+
+	if err := index.setReadOnly(readOnly); err != nil {
+		return errors.Wrap(err, "setting index read-only")
+	}
+
+	// Send the read-only state to all nodes.
+	if err := api.server.SendSync(&IndexReadOnlyMessage{Index: indexName, ReadOnly: readOnly}); err != nil {
+		return errors.Wrap(err, "sending IndexReadOnly message")
+	}
+	return nil
+}
+
+// validateIndexWritable returns ErrIndexReadOnly if the index has been marked
+// read-only.
+func (api *API) validateIndexWritable(indexName string) error {
+	return api.holder.validateIndexWritable(indexName)
+}
+
 // DeleteAvailableShard a shard ID from the available shard set cache.
 func (api *API) DeleteAvailableShard(_ context.Context, indexName, fieldName string, shardID uint64) error {
 	if err := api.validate(apiDeleteAvailableShard); err != nil {
@@ -505,9 +915,15 @@ func (api *API) ExportCSV(ctx context.Context, indexName string, fieldName strin
 		return errors.Wrap(err, "validating api method")
 	}
 
+	// Exports are admitted as batch work.
+	if err := api.server.admission.acquire(ctx, priorityBatch); err != nil {
+		return errors.Wrap(err, "waiting for admission")
+	}
+	defer api.server.admission.release()
+
 	// Validate that this handler owns the shard.
 	if !api.cluster.ownsShard(api.Node().ID, indexName, shard) {
-		api.server.logger.Printf("node %s does not own shard %d of index %s", api.Node().ID, shard, indexName)
+		RequestLogger(ctx, api.server.logger).Printf("node %s does not own shard %d of index %s", api.Node().ID, shard, indexName)
 		return ErrClusterDoesNotOwnShard
 	}
 
@@ -573,7 +989,142 @@ func (api *API) ExportCSV(ctx context.Context, indexName string, fieldName strin
 	return nil
 }
 
-// ShardNodes returns the node and all replicas which should contain a shard's data.
+// Attribute export formats.
+const (
+	AttrExportJSON = "json"
+	AttrExportCSV  = "csv"
+)
+
+// attrExportRecord is the attributes of a column, or of a row of a field, as
+// written by ExportAttrs in the JSON format.
+type attrExportRecord struct {
+	Field string                 `json:"field,omitempty"`
+	ID    uint64                 `json:"id"`
+	Key   string                 `json:"key,omitempty"`
+	Attrs map[string]interface{} `json:"attrs"`
+}
+
+// ExportAttrs writes the column attributes of an index, followed by the row
+// attributes of each of its fields, to w. In the JSON format each column or
+// row is a line holding a JSON object; in the CSV format each attribute is a
+// record of field, ID, key, attribute name, and value. The field is empty
+// for column attributes, and the key is empty unless the index or field uses
+// keys.
+//
+// Attributes are replicated to every node, so the node serving the export
+// writes them all from its own attribute stores.
+func (api *API) ExportAttrs(ctx context.Context, indexName string, format string, w io.Writer) error {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.ExportAttrs")
+	defer span.Finish()
+
+	if err := api.validate(apiExportAttrs); err != nil {
+		return errors.Wrap(err, "validating api method")
+	}
+
+	index := api.holder.Index(indexName)
+	if index == nil {
+		return newNotFoundError(ErrIndexNotFound, indexName)
+	}
+
+	var write func(rec attrExportRecord) error
+	var flush func() error
+	switch format {
+	case AttrExportJSON, "":
+		enc := json.NewEncoder(w)
+		write = func(rec attrExportRecord) error { return enc.Encode(rec) }
+		flush = func() error { return nil }
+	case AttrExportCSV:
+		cw := csv.NewWriter(w)
+		write = func(rec attrExportRecord) error {
+			names := make([]string, 0, len(rec.Attrs))
+			for name := range rec.Attrs {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			id := strconv.FormatUint(rec.ID, 10)
+			for _, name := range names {
+				if err := cw.Write([]string{rec.Field, id, rec.Key, name, fmt.Sprint(rec.Attrs[name])}); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		flush = func() error {
+			cw.Flush()
+			return cw.Error()
+		}
+	default:
+		return NewBadRequestError(errors.Errorf("invalid attribute export format: %s", format))
+	}
+
+	// Exports are admitted as batch work.
+	if err := api.server.admission.acquire(ctx, priorityBatch); err != nil {
+		return errors.Wrap(err, "waiting for admission")
+	}
+	defer api.server.admission.release()
+
+	// export writes the attributes of one store, in order of ID.
+	var n int
+	export := func(field string, store AttrStore, keys TranslateStore) error {
+		blocks, err := store.Blocks()
+		if err != nil {
+			return errors.Wrap(err, "reading attribute blocks")
+		}
+		for _, block := range blocks {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			data, err := store.BlockData(block.ID)
+			if err != nil {
+				return errors.Wrapf(err, "reading attribute block %d", block.ID)
+			}
+			ids := make([]uint64, 0, len(data))
+			for id := range data {
+				ids = append(ids, id)
+			}
+			sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+			for _, id := range ids {
+				rec := attrExportRecord{Field: field, ID: id, Attrs: data[id]}
+				if keys != nil {
+					if rec.Key, err = keys.TranslateID(id); err != nil {
+						return errors.Wrap(err, "translating id")
+					}
+				}
+				if err := write(rec); err != nil {
+					return errors.Wrap(err, "writing attributes")
+				}
+				n++
+			}
+		}
+		return nil
+	}
+
+	var keys TranslateStore
+	if index.Keys() {
+		keys = index.TranslateStore()
+	}
+	if err := export("", index.ColumnAttrStore(), keys); err != nil {
+		return errors.Wrap(err, "exporting column attributes")
+	}
+	for _, field := range index.Fields() {
+		keys = nil
+		if field.keys() {
+			keys = field.TranslateStore()
+		}
+		if err := export(field.Name(), field.RowAttrStore(), keys); err != nil {
+			return errors.Wrapf(err, "exporting row attributes of field %s", field.Name())
+		}
+	}
+	span.LogKV("n", n)
+
+	return flush()
+}
+
+// ShardNodes returns the node and all replicas which should contain a shard's
+// data. Clients import to each of them, so replicas on nodes which are DOWN
+// are omitted while another replica is up, and are sent the imports they
+// missed once they return.
 func (api *API) ShardNodes(ctx context.Context, indexName string, shard uint64) ([]*Node, error) {
 	span, _ := tracing.StartSpanFromContext(ctx, "API.ShardNodes")
 	defer span.Finish()
@@ -582,7 +1133,53 @@ func (api *API) ShardNodes(ctx context.Context, indexName string, shard uint64)
 		return nil, errors.Wrap(err, "validating api method")
 	}
 
-	return api.cluster.shardNodes(indexName, shard), nil
+	nodes, _ := api.cluster.writeNodes(indexName, shard)
+	return nodes, nil
+}
+
+// ClusterTopology returns the nodes which own each of the available shards of
+// an index, or of every index if indexName is empty. Shards which are not yet
+// available are placed by the same hash, and their owners can be read from
+// ShardNodes.
+func (api *API) ClusterTopology(ctx context.Context, indexName string) (*ClusterTopology, error) {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.ClusterTopology")
+	defer span.Finish()
+
+	if err := api.validate(apiClusterTopology); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+
+	indexes := api.holder.Indexes()
+	if indexName != "" {
+		idx := api.holder.Index(indexName)
+		if idx == nil {
+			return nil, newNotFoundError(ErrIndexNotFound, indexName)
+		}
+		indexes = []*Index{idx}
+	}
+	return api.cluster.topology(indexes), nil
+}
+
+// ShardDistribution returns the replicas of each available shard of each
+// index, and the health of the nodes holding them, or those of one index if
+// indexName is not empty.
+func (api *API) ShardDistribution(ctx context.Context, indexName string) (*ShardDistribution, error) {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.ShardDistribution")
+	defer span.Finish()
+
+	if err := api.validate(apiShardDistribution); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+
+	indexes := api.holder.Indexes()
+	if indexName != "" {
+		idx := api.holder.Index(indexName)
+		if idx == nil {
+			return nil, newNotFoundError(ErrIndexNotFound, indexName)
+		}
+		indexes = []*Index{idx}
+	}
+	return api.cluster.distribution(indexes), nil
 }
 
 // FragmentBlockData is an endpoint for internal usage. It is not guaranteed to
@@ -612,7 +1209,13 @@ func (api *API) FragmentBlockData(ctx context.Context, body io.Reader) ([]byte,
 	}
 
 	var resp = BlockDataResponse{}
-	resp.RowIDs, resp.ColumnIDs = f.blockData(int(req.Block))
+	if req.Checksums {
+		resp.Checksums = f.containerChecksums(int(req.Block))
+	} else if req.Containers != nil {
+		resp.RowIDs, resp.ColumnIDs = f.containerData(int(req.Block), req.Containers)
+	} else {
+		resp.RowIDs, resp.ColumnIDs = f.blockData(int(req.Block))
+	}
 
 	// Encode response.
 	buf, err := api.Serializer.Marshal(&resp)
@@ -726,27 +1329,74 @@ func (api *API) ClusterMessage(ctx context.Context, reqBody io.Reader) error {
 func (api *API) Schema(ctx context.Context) []*IndexInfo {
 	span, _ := tracing.StartSpanFromContext(ctx, "API.Schema")
 	defer span.Finish()
-	return api.holder.limitedSchema()
+
+	// A token bound to a namespace sees only the namespace's indexes.
+	schema := api.holder.limitedSchema()
+	if ns := api.TokenNamespace(ctx); ns != "" {
+		filtered := schema[:0]
+		for _, info := range schema {
+			if IndexNamespace(info.Name) == ns {
+				filtered = append(filtered, info)
+			}
+		}
+		schema = filtered
+	}
+	return schema
 }
 
-// ApplySchema takes the given schema and applies it across the
-// cluster (if remote is false), or just to this node (if remote is
-// true). This is designed for the use case of replicating a schema
-// from one Pilosa cluster to another which is initially empty. It is
-// not officially supported in other scenarios and may produce
-// surprising results.
-func (api *API) ApplySchema(ctx context.Context, s *Schema, remote bool) error {
-	span, _ := tracing.StartSpanFromContext(ctx, "API.ApplySchema")
+// SchemaDiff returns the differences between the schema of this node and that
+// of remote, which is the ID or address of another node in the cluster.
+func (api *API) SchemaDiff(ctx context.Context, remote string) (SchemaDiff, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.SchemaDiff")
 	defer span.Finish()
 
-	if err := api.validate(apiApplySchema); err != nil {
-		return errors.Wrap(err, "validating api method")
+	if err := api.validate(apiSchemaDiff); err != nil {
+		return SchemaDiff{}, errors.Wrap(err, "validating api method")
 	}
 
-	if !remote {
+	// Only nodes in the cluster are contacted.
+	node := api.cluster.nodeByID(remote)
+	if node == nil {
+		uri, err := NewURIFromAddress(remote)
+		if err != nil {
+			return SchemaDiff{}, NewBadRequestError(errors.Wrap(err, "parsing remote"))
+		}
+		for _, n := range api.cluster.Nodes() {
+			if n.URI.HostPort() == uri.HostPort() {
+				node = n
+				break
+			}
+		}
+	}
+	if node == nil {
+		return SchemaDiff{}, NewBadRequestError(errors.Errorf("remote is not a node in the cluster: %s", remote))
+	}
+
+	schema, err := api.server.defaultClient.SchemaNode(ctx, node.clusterURI())
+	if err != nil {
+		return SchemaDiff{}, errors.Wrapf(err, "getting schema of node %s", node.ID)
+	}
+	return SchemaDiff{Remote: node.ID, Differences: diffSchemas(api.holder.limitedSchema(), schema)}, nil
+}
+
+// ApplySchema takes the given schema and applies it across the
+// cluster (if remote is false), or just to this node (if remote is
+// true). This is designed for the use case of replicating a schema
+// from one Pilosa cluster to another which is initially empty. It is
+// not officially supported in other scenarios and may produce
+// surprising results.
+func (api *API) ApplySchema(ctx context.Context, s *Schema, remote bool) error {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.ApplySchema")
+	defer span.Finish()
+
+	if err := api.validate(apiApplySchema); err != nil {
+		return errors.Wrap(err, "validating api method")
+	}
+
+	if !remote {
 		nodes := api.cluster.Nodes()
 		for i, node := range nodes {
-			err := api.server.defaultClient.PostSchema(ctx, &node.URI, s, true)
+			err := api.server.defaultClient.PostSchema(ctx, node.clusterURI(), s, true)
 			if err != nil {
 				return errors.Wrapf(err, "forwarding post schema to node %d of %d", i+1, len(nodes))
 			}
@@ -807,7 +1457,7 @@ func (api *API) DeleteView(ctx context.Context, indexName string, fieldName stri
 			View:  viewName,
 		})
 	if err != nil {
-		api.server.logger.Printf("problem sending DeleteView message: %s", err)
+		RequestLogger(ctx, api.server.logger).Printf("problem sending DeleteView message: %s", err)
 	}
 
 	return errors.Wrap(err, "sending DeleteView message")
@@ -893,6 +1543,14 @@ func (api *API) FieldAttrDiff(ctx context.Context, indexName string, fieldName s
 type ImportOptions struct {
 	Clear          bool
 	IgnoreKeyCheck bool
+
+	// TimeQuantum, if set, limits the time views which bits with
+	// timestamps are imported into to those of its units, which must be
+	// units of the field's time quantum. SkipStandardView imports bits
+	// with timestamps into their time views only. Together they let
+	// backfills write only the views they need.
+	TimeQuantum      TimeQuantum
+	SkipStandardView bool
 }
 
 // ImportOption is a functional option type for API.Import.
@@ -907,6 +1565,29 @@ func OptImportOptionsClear(c bool) ImportOption {
 	}
 }
 
+// OptImportOptionsTimeQuantum is a functional option on ImportOption used to
+// limit the time views imported into to those of the units of q.
+func OptImportOptionsTimeQuantum(q TimeQuantum) ImportOption {
+	return func(o *ImportOptions) error {
+		for _, unit := range q {
+			if !strings.ContainsRune("YMDH", unit) {
+				return errors.Wrapf(ErrInvalidTimeQuantum, "import time quantum %q", q)
+			}
+		}
+		o.TimeQuantum = q
+		return nil
+	}
+}
+
+// OptImportOptionsSkipStandardView is a functional option on ImportOption
+// used to specify whether bits with timestamps skip the standard view.
+func OptImportOptionsSkipStandardView(b bool) ImportOption {
+	return func(o *ImportOptions) error {
+		o.SkipStandardView = b
+		return nil
+	}
+}
+
 // OptImportOptionsIgnoreKeyCheck is a functional option on ImportOption
 // used to specify whether key check should be ignored.
 func OptImportOptionsIgnoreKeyCheck(b bool) ImportOption {
@@ -924,6 +1605,20 @@ func (api *API) Import(ctx context.Context, req *ImportRequest, opts ...ImportOp
 	if err := api.validate(apiImport); err != nil {
 		return errors.Wrap(err, "validating api method")
 	}
+	if err := api.validateWritable(api.Node()); err != nil {
+		return err
+	} else if err := api.validateIndexWritable(req.Index); err != nil {
+		return err
+	} else if err := api.validateNotOverloaded(); err != nil {
+		return err
+	} else if err := api.validateWriteBacklog(); err != nil {
+		return err
+	}
+	defer api.server.executor.invalidateTopNCluster(req.Index, req.Field)
+	if err := api.server.usage.charge(UsageToken(ctx), Usage{ImportBytes: importRequestSize(req)}); err != nil {
+		return err
+	}
+	defer api.server.usage.addTime(UsageToken(ctx), time.Now())
 
 	// Set up import options.
 	options, err := setUpImportOptions(opts...)
@@ -983,6 +1678,13 @@ func (api *API) Import(ctx context.Context, req *ImportRequest, opts ...ImportOp
 			// Signal to the receiving nodes to ignore checking for key translation.
 			opts = append(opts, OptImportOptionsIgnoreKeyCheck(true))
 
+			// Don't send data to nodes which would reject it.
+			for shard := range m {
+				if err := api.validateWritable(api.cluster.shardNodes(req.Index, shard)...); err != nil {
+					return err
+				}
+			}
+
 			var eg errgroup.Group
 			for shard, bits := range m {
 				// TODO: if local node owns this shard we don't need to go through the client
@@ -1001,20 +1703,35 @@ func (api *API) Import(ctx context.Context, req *ImportRequest, opts ...ImportOp
 		return errors.Wrap(err, "validating shard ownership")
 	}
 
-	// Convert timestamps to time.Time.
+	bits := make([]Bit, len(req.ColumnIDs))
+	for i := range bits {
+		bits[i] = Bit{RowID: req.RowIDs[i], ColumnID: req.ColumnIDs[i]}
+		if len(req.Timestamps) > 0 {
+			bits[i].Timestamp = req.Timestamps[i]
+		}
+	}
+	if err := api.hintImport(req.Index, req.Shard, hint{Type: hintTypeImport, Index: req.Index, Field: req.Field, Shard: req.Shard, Bits: bits, Clear: options.Clear, TimeQuantum: options.TimeQuantum, SkipStandardView: options.SkipStandardView}); err != nil {
+		return err
+	}
+
+	// Convert timestamps to time.Time. The values share a single backing
+	// array to avoid an allocation per bit.
 	timestamps := make([]*time.Time, len(req.Timestamps))
-	for i, ts := range req.Timestamps {
-		if ts == 0 {
-			continue
+	if len(req.Timestamps) > 0 {
+		times := make([]time.Time, len(req.Timestamps))
+		for i, ts := range req.Timestamps {
+			if ts == 0 {
+				continue
+			}
+			times[i] = time.Unix(0, ts).UTC()
+			timestamps[i] = &times[i]
 		}
-		t := time.Unix(0, ts).UTC()
-		timestamps[i] = &t
 	}
 
 	// Import columnIDs into existence field.
 	if !options.Clear {
 		if err := importExistenceColumns(index, req.ColumnIDs); err != nil {
-			api.server.logger.Printf("import existence error: index=%s, field=%s, shard=%d, columns=%d, err=%s", req.Index, req.Field, req.Shard, len(req.ColumnIDs), err)
+			RequestLogger(ctx, api.server.logger).Printf("import existence error: index=%s, field=%s, shard=%d, columns=%d, err=%s", req.Index, req.Field, req.Shard, len(req.ColumnIDs), err)
 			return errors.Wrap(err, "importing existence columns")
 		}
 	}
@@ -1022,9 +1739,11 @@ func (api *API) Import(ctx context.Context, req *ImportRequest, opts ...ImportOp
 	// Import into fragment.
 	err = field.Import(req.RowIDs, req.ColumnIDs, timestamps, opts...)
 	if err != nil {
-		api.server.logger.Printf("import error: index=%s, field=%s, shard=%d, columns=%d, err=%s", req.Index, req.Field, req.Shard, len(req.ColumnIDs), err)
+		RequestLogger(ctx, api.server.logger).Printf("import error: index=%s, field=%s, shard=%d, columns=%d, err=%s", req.Index, req.Field, req.Shard, len(req.ColumnIDs), err)
+		return errors.Wrap(err, "importing")
 	}
-	return errors.Wrap(err, "importing")
+	api.holder.shardLoads.countWrite(req.Index, req.Shard)
+	return nil
 }
 
 // ImportValue bulk imports values into a particular field.
@@ -1035,6 +1754,20 @@ func (api *API) ImportValue(ctx context.Context, req *ImportValueRequest, opts .
 	if err := api.validate(apiImportValue); err != nil {
 		return errors.Wrap(err, "validating api method")
 	}
+	if err := api.validateWritable(api.Node()); err != nil {
+		return err
+	} else if err := api.validateIndexWritable(req.Index); err != nil {
+		return err
+	} else if err := api.validateNotOverloaded(); err != nil {
+		return err
+	} else if err := api.validateWriteBacklog(); err != nil {
+		return err
+	}
+	defer api.server.executor.invalidateTopNCluster(req.Index, req.Field)
+	if err := api.server.usage.charge(UsageToken(ctx), Usage{ImportBytes: importValueRequestSize(req)}); err != nil {
+		return err
+	}
+	defer api.server.usage.addTime(UsageToken(ctx), time.Now())
 
 	// Set up import options.
 	options, err := setUpImportOptions(opts...)
@@ -1078,6 +1811,13 @@ func (api *API) ImportValue(ctx context.Context, req *ImportValueRequest, opts .
 			// Signal to the receiving nodes to ignore checking for key translation.
 			opts = append(opts, OptImportOptionsIgnoreKeyCheck(true))
 
+			// Don't send data to nodes which would reject it.
+			for shard := range m {
+				if err := api.validateWritable(api.cluster.shardNodes(req.Index, shard)...); err != nil {
+					return err
+				}
+			}
+
 			var eg errgroup.Group
 			for shard, vals := range m {
 				// TODO: if local node owns this shard we don't need to go through the client
@@ -1096,10 +1836,18 @@ func (api *API) ImportValue(ctx context.Context, req *ImportValueRequest, opts .
 		return errors.Wrap(err, "validating shard ownership")
 	}
 
+	vals := make([]FieldValue, len(req.ColumnIDs))
+	for i := range vals {
+		vals[i] = FieldValue{ColumnID: req.ColumnIDs[i], Value: req.Values[i]}
+	}
+	if err := api.hintImport(req.Index, req.Shard, hint{Type: hintTypeImportValue, Index: req.Index, Field: req.Field, Shard: req.Shard, Values: vals, Clear: options.Clear}); err != nil {
+		return err
+	}
+
 	// Import columnIDs into existence field.
 	if !options.Clear {
 		if err := importExistenceColumns(index, req.ColumnIDs); err != nil {
-			api.server.logger.Printf("import existence error: index=%s, field=%s, shard=%d, columns=%d, err=%s", req.Index, req.Field, req.Shard, len(req.ColumnIDs), err)
+			RequestLogger(ctx, api.server.logger).Printf("import existence error: index=%s, field=%s, shard=%d, columns=%d, err=%s", req.Index, req.Field, req.Shard, len(req.ColumnIDs), err)
 			return errors.Wrap(err, "importing existence columns")
 		}
 	}
@@ -1107,9 +1855,11 @@ func (api *API) ImportValue(ctx context.Context, req *ImportValueRequest, opts .
 	// Import into fragment.
 	err = field.importValue(req.ColumnIDs, req.Values, options)
 	if err != nil {
-		api.server.logger.Printf("import error: index=%s, field=%s, shard=%d, columns=%d, err=%s", req.Index, req.Field, req.Shard, len(req.ColumnIDs), err)
+		RequestLogger(ctx, api.server.logger).Printf("import error: index=%s, field=%s, shard=%d, columns=%d, err=%s", req.Index, req.Field, req.Shard, len(req.ColumnIDs), err)
+		return errors.Wrap(err, "importing")
 	}
-	return errors.Wrap(err, "importing")
+	api.holder.shardLoads.countWrite(req.Index, req.Shard)
+	return nil
 }
 
 func importExistenceColumns(index *Index, columnIDs []uint64) error {
@@ -1122,6 +1872,684 @@ func importExistenceColumns(index *Index, columnIDs []uint64) error {
 	return ef.Import(existenceRowIDs, columnIDs, nil)
 }
 
+// Changes returns up to max mutations recorded on the local node after
+// sequence number since. It blocks until at least one such mutation is
+// available or ctx is done. A max of zero returns all available mutations.
+func (api *API) Changes(ctx context.Context, since uint64, max int) ([]ChangeEvent, error) {
+	if err := api.validate(apiChanges); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+	return api.holder.changes.read(ctx, since, max)
+}
+
+// LoadUDF compiles a user-defined function module and registers it as a PQL
+// call named name on every node in the cluster. Loading a module under an
+// existing name replaces it.
+func (api *API) LoadUDF(ctx context.Context, name string, code []byte) error {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.LoadUDF")
+	defer span.Finish()
+
+	if err := api.validate(apiLoadUDF); err != nil {
+		return errors.Wrap(err, "validating api method")
+	}
+
+	if err := api.server.loadUDF(ctx, name, code, true); err != nil {
+		return errors.Wrap(err, "loading udf")
+	}
+
+	// Send the load udf message to all nodes.
+	err := api.server.SendSync(&LoadUDFMessage{Name: name, Code: code})
+	return errors.Wrap(err, "sending LoadUDF message")
+}
+
+// DeleteUDF removes a user-defined function from every node in the cluster.
+func (api *API) DeleteUDF(ctx context.Context, name string) error {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.DeleteUDF")
+	defer span.Finish()
+
+	if err := api.validate(apiDeleteUDF); err != nil {
+		return errors.Wrap(err, "validating api method")
+	}
+
+	if err := api.server.deleteUDF(ctx, name); err != nil {
+		return errors.Wrap(err, "deleting udf")
+	}
+
+	// Send the delete udf message to all nodes.
+	err := api.server.SendSync(&DeleteUDFMessage{Name: name})
+	return errors.Wrap(err, "sending DeleteUDF message")
+}
+
+// UDFs returns the names of the user-defined functions loaded on this node.
+func (api *API) UDFs(ctx context.Context) []string {
+	return api.server.udfNames()
+}
+
+// CreateMaterializedView defines a materialized view of an index on every
+// node. The query is a single row call, whose result is kept for each shard
+// and recomputed as the fields it reads change.
+func (api *API) CreateMaterializedView(ctx context.Context, indexName, name, query string) error {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.CreateMaterializedView")
+	defer span.Finish()
+
+	if err := api.validate(apiMaterializedViews); err != nil {
+		return errors.Wrap(err, "validating api method")
+	}
+
+	index := api.holder.Index(indexName)
+	if index == nil {
+		return newNotFoundError(ErrIndexNotFound, indexName)
+	} else if index.materializedView(name) != nil {
+		return errors.Wrap(NewConflictError(ErrMaterializedViewExists), "creating materialized view")
+	}
+
+	// Nodes which do not handle the message could not answer queries of
+	// the view.
+	if err := api.cluster.validateFeatures(FeatureMaterializedViews); err != nil {
+		return errors.Wrap(err, "creating materialized view")
+	}
+
+	// Check the definition before translating it, so that a bad definition
+	// creates no keys.
+	mv, err := newMaterializedView(index, name, query, query)
+	if err != nil {
+		return errors.Wrap(err, "creating materialized view")
+	}
+	call := mv.call.Clone()
+	if err := api.server.executor.translateCalls(ctx, indexName, index, []*pql.Call{call}); err != nil {
+		return errors.Wrap(err, "translating materialized view")
+	}
+
+	version, err := index.createMaterializedView(name, query, call.String(), 0)
+	if err != nil {
+		return errors.Wrap(err, "creating materialized view")
+	}
+
+	// Send the materialized view to all nodes. If any node can't be sent
+	// it, it is deleted again, as a later change.
+	if err := api.server.SendSync(&CreateMaterializedViewMessage{Index: indexName, Name: name, Query: query, Call: call.String(), Version: version}); err != nil {
+		api.rollback(&DeleteMaterializedViewMessage{Index: indexName, Name: name, Version: nextVersion(version)})
+		return errors.Wrap(err, "sending CreateMaterializedView message")
+	}
+	return nil
+}
+
+// DeleteMaterializedView removes a materialized view of an index from every
+// node.
+func (api *API) DeleteMaterializedView(ctx context.Context, indexName, name string) error {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.DeleteMaterializedView")
+	defer span.Finish()
+
+	if err := api.validate(apiMaterializedViews); err != nil {
+		return errors.Wrap(err, "validating api method")
+	}
+
+	index := api.holder.Index(indexName)
+	if index == nil {
+		return newNotFoundError(ErrIndexNotFound, indexName)
+	}
+	mv := index.materializedView(name)
+	version, err := index.deleteMaterializedView(name, 0)
+	if err != nil {
+		return errors.Wrap(err, "deleting materialized view")
+	}
+
+	// Send the delete materialized view message to all nodes. If any node
+	// can't be sent it, the view is restored as a later change.
+	if err := api.server.SendSync(&DeleteMaterializedViewMessage{Index: indexName, Name: name, Version: version}); err != nil {
+		if mv != nil {
+			api.rollback(&CreateMaterializedViewMessage{Index: indexName, Name: name, Query: mv.Query, Call: mv.call.String(), Version: nextVersion(version)})
+		}
+		return errors.Wrap(err, "sending DeleteMaterializedView message")
+	}
+	return nil
+}
+
+// MaterializedViewDefinitions returns the definitions of the materialized
+// views of every index, with their keys translated, and the views which were
+// deleted, with their versions, as they are sent to other nodes.
+func (api *API) MaterializedViewDefinitions(ctx context.Context) ([]*CreateMaterializedViewMessage, []*DeleteMaterializedViewMessage) {
+	if err := api.validate(apiMaterializedViews); err != nil {
+		return nil, nil
+	}
+
+	var msgs []*CreateMaterializedViewMessage
+	var deleted []*DeleteMaterializedViewMessage
+	for _, index := range api.holder.Indexes() {
+		m, d := index.materializedViewDefinitions()
+		msgs = append(msgs, m...)
+		deleted = append(deleted, d...)
+	}
+	return msgs, deleted
+}
+
+// MaterializedViews returns the materialized views of an index.
+func (api *API) MaterializedViews(ctx context.Context, indexName string) ([]MaterializedView, error) {
+	if err := api.validate(apiMaterializedViews); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+
+	index := api.holder.Index(indexName)
+	if index == nil {
+		return nil, newNotFoundError(ErrIndexNotFound, indexName)
+	}
+	return index.MaterializedViews(), nil
+}
+
+// CreateRewriteRule adds a rewrite rule to an index on every node, replacing
+// any rule of the same name.
+func (api *API) CreateRewriteRule(ctx context.Context, indexName string, rule RewriteRule) error {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.CreateRewriteRule")
+	defer span.Finish()
+
+	if err := api.validate(apiRewriteRules); err != nil {
+		return errors.Wrap(err, "validating api method")
+	}
+
+	index := api.holder.Index(indexName)
+	if index == nil {
+		return newNotFoundError(ErrIndexNotFound, indexName)
+	}
+
+	// Nodes which do not handle the message would not rewrite queries.
+	if err := api.cluster.validateFeatures(FeatureRewriteRules); err != nil {
+		return errors.Wrap(err, "creating rewrite rule")
+	}
+
+	previous := index.rewriteRule(rule.Name)
+	version, err := index.createRewriteRule(rule, 0)
+	if err != nil {
+		return errors.Wrap(err, "creating rewrite rule")
+	}
+
+	// Send the rewrite rule to all nodes. If any node can't be sent it, the
+	// rule it replaced is restored, or it is deleted again, as a later
+	// change.
+	if err := api.server.SendSync(&CreateRewriteRuleMessage{Index: indexName, Rule: rule, Version: version}); err != nil {
+		if previous != nil {
+			api.rollback(&CreateRewriteRuleMessage{Index: indexName, Rule: previous.RewriteRule, Version: nextVersion(version)})
+		} else {
+			api.rollback(&DeleteRewriteRuleMessage{Index: indexName, Name: rule.Name, Version: nextVersion(version)})
+		}
+		return errors.Wrap(err, "sending CreateRewriteRule message")
+	}
+	return nil
+}
+
+// DeleteRewriteRule removes a rewrite rule of an index from every node.
+func (api *API) DeleteRewriteRule(ctx context.Context, indexName, name string) error {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.DeleteRewriteRule")
+	defer span.Finish()
+
+	if err := api.validate(apiRewriteRules); err != nil {
+		return errors.Wrap(err, "validating api method")
+	}
+
+	index := api.holder.Index(indexName)
+	if index == nil {
+		return newNotFoundError(ErrIndexNotFound, indexName)
+	}
+	previous := index.rewriteRule(name)
+	version, err := index.deleteRewriteRule(name, 0)
+	if err != nil {
+		return errors.Wrap(err, "deleting rewrite rule")
+	}
+
+	// Send the delete rewrite rule message to all nodes. If any node can't
+	// be sent it, the rule is restored as a later change.
+	if err := api.server.SendSync(&DeleteRewriteRuleMessage{Index: indexName, Name: name, Version: version}); err != nil {
+		if previous != nil {
+			api.rollback(&CreateRewriteRuleMessage{Index: indexName, Rule: previous.RewriteRule, Version: nextVersion(version)})
+		}
+		return errors.Wrap(err, "sending DeleteRewriteRule message")
+	}
+	return nil
+}
+
+// RewriteRuleDefinitions returns the rewrite rules of every index, and the
+// rules which were deleted, with their versions, as they are sent to other
+// nodes.
+func (api *API) RewriteRuleDefinitions(ctx context.Context) ([]*CreateRewriteRuleMessage, []*DeleteRewriteRuleMessage) {
+	if err := api.validate(apiRewriteRules); err != nil {
+		return nil, nil
+	}
+
+	var rules []*CreateRewriteRuleMessage
+	var deleted []*DeleteRewriteRuleMessage
+	for _, index := range api.holder.Indexes() {
+		r, d := index.rewriteRuleDefinitions()
+		rules = append(rules, r...)
+		deleted = append(deleted, d...)
+	}
+	return rules, deleted
+}
+
+// RewriteRules returns the rewrite rules of an index.
+func (api *API) RewriteRules(ctx context.Context, indexName string) ([]RewriteRule, error) {
+	if err := api.validate(apiRewriteRules); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+
+	index := api.holder.Index(indexName)
+	if index == nil {
+		return nil, newNotFoundError(ErrIndexNotFound, indexName)
+	}
+	return index.RewriteRules(), nil
+}
+
+// ReindexField starts a background job which rebuilds the data derived from
+// the field's fragments on this node, such as rank caches and column
+// existence. Other nodes are not affected.
+func (api *API) ReindexField(ctx context.Context, indexName, fieldName string) (JobStatus, error) {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.ReindexField")
+	defer span.Finish()
+
+	if err := api.validate(apiReindexField); err != nil {
+		return JobStatus{}, errors.Wrap(err, "validating api method")
+	}
+
+	index := api.holder.Index(indexName)
+	if index == nil {
+		return JobStatus{}, newNotFoundError(ErrIndexNotFound, indexName)
+	}
+	field := index.Field(fieldName)
+	if field == nil {
+		return JobStatus{}, newNotFoundError(ErrFieldNotFound, fieldName)
+	}
+
+	status := JobStatus{Type: JobTypeReindex, Index: indexName, Field: fieldName}
+	return api.server.jobs.start(status, func(ctx context.Context, progress func(done, total int)) error {
+		return reindexField(ctx, index, field, progress)
+	}), nil
+}
+
+// ImportJob starts a background job which imports req in batches, so that
+// its progress can be followed with Job. size is the size of the encoded
+// request, in bytes.
+func (api *API) ImportJob(ctx context.Context, req *ImportRequest, size int64, opts ...ImportOption) (JobStatus, error) {
+	if err := api.validate(apiImport); err != nil {
+		return JobStatus{}, errors.Wrap(err, "validating api method")
+	} else if err := api.validateNotOverloaded(); err != nil {
+		return JobStatus{}, err
+	} else if err := api.validateWriteBacklog(); err != nil {
+		return JobStatus{}, err
+	}
+	if _, _, err := api.indexField(req.Index, req.Field, req.Shard); err != nil {
+		return JobStatus{}, errors.Wrap(err, "getting index and field")
+	}
+
+	n := len(req.ColumnIDs)
+	if len(req.ColumnKeys) > n {
+		n = len(req.ColumnKeys)
+	}
+	if len(req.RowIDs)+len(req.RowKeys) != n || (len(req.Timestamps) != 0 && len(req.Timestamps) != n) {
+		return JobStatus{}, NewBadRequestError(errors.New("import request fields have different lengths"))
+	}
+	token, id := APITokenFromContext(ctx), RequestIDFromContext(ctx)
+	status := JobStatus{Type: JobTypeImport, Index: req.Index, Field: req.Field, Bytes: size}
+	return api.server.jobs.start(status, func(ctx context.Context, progress func(done, total int)) error {
+		ctx = WithRequestID(WithAPIToken(ctx, token), id)
+		return importBatches(ctx, n, progress, func(i, j int) error {
+			batch := &ImportRequest{Index: req.Index, Field: req.Field, Shard: req.Shard}
+			batch.RowIDs = sliceUint64s(req.RowIDs, i, j)
+			batch.ColumnIDs = sliceUint64s(req.ColumnIDs, i, j)
+			batch.RowKeys = sliceStrings(req.RowKeys, i, j)
+			batch.ColumnKeys = sliceStrings(req.ColumnKeys, i, j)
+			batch.Timestamps = sliceInt64s(req.Timestamps, i, j)
+			return api.Import(ctx, batch, opts...)
+		})
+	}), nil
+}
+
+// ImportValueJob starts a background job which imports req in batches, so
+// that its progress can be followed with Job. size is the size of the encoded
+// request, in bytes.
+func (api *API) ImportValueJob(ctx context.Context, req *ImportValueRequest, size int64, opts ...ImportOption) (JobStatus, error) {
+	if err := api.validate(apiImportValue); err != nil {
+		return JobStatus{}, errors.Wrap(err, "validating api method")
+	} else if err := api.validateNotOverloaded(); err != nil {
+		return JobStatus{}, err
+	} else if err := api.validateWriteBacklog(); err != nil {
+		return JobStatus{}, err
+	}
+	if _, _, err := api.indexField(req.Index, req.Field, req.Shard); err != nil {
+		return JobStatus{}, errors.Wrap(err, "getting index and field")
+	}
+
+	n := len(req.ColumnIDs)
+	if len(req.ColumnKeys) > n {
+		n = len(req.ColumnKeys)
+	}
+	if len(req.Values) != n {
+		return JobStatus{}, NewBadRequestError(errors.New("import request fields have different lengths"))
+	}
+	token, id := APITokenFromContext(ctx), RequestIDFromContext(ctx)
+	status := JobStatus{Type: JobTypeImport, Index: req.Index, Field: req.Field, Bytes: size}
+	return api.server.jobs.start(status, func(ctx context.Context, progress func(done, total int)) error {
+		ctx = WithRequestID(WithAPIToken(ctx, token), id)
+		return importBatches(ctx, n, progress, func(i, j int) error {
+			batch := &ImportValueRequest{Index: req.Index, Field: req.Field, Shard: req.Shard}
+			batch.ColumnIDs = sliceUint64s(req.ColumnIDs, i, j)
+			batch.ColumnKeys = sliceStrings(req.ColumnKeys, i, j)
+			batch.Values = sliceInt64s(req.Values, i, j)
+			return api.ImportValue(ctx, batch, opts...)
+		})
+	}), nil
+}
+
+// Settings returns the cluster settings.
+func (api *API) Settings(ctx context.Context) (Settings, error) {
+	if err := api.validate(apiSettings); err != nil {
+		return Settings{}, errors.Wrap(err, "validating api method")
+	}
+	return api.server.settings.get(), nil
+}
+
+// UpdateSettings changes the cluster settings on every node, and returns the
+// new settings.
+func (api *API) UpdateSettings(ctx context.Context, update SettingsUpdate) (Settings, error) {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.UpdateSettings")
+	defer span.Finish()
+
+	if err := api.validate(apiSettings); err != nil {
+		return Settings{}, errors.Wrap(err, "validating api method")
+	}
+
+	// Nodes which do not handle the message would keep the old settings.
+	if err := api.cluster.validateFeatures(FeatureSettings); err != nil {
+		return Settings{}, errors.Wrap(err, "updating settings")
+	}
+
+	settings, err := api.server.changeSettings(update)
+	if err != nil {
+		return Settings{}, err
+	}
+
+	// Send the settings to all nodes. Nodes which miss the message receive
+	// the settings along with the status of other nodes.
+	if err := api.server.SendSync(&SettingsMessage{Settings: settings}); err != nil {
+		return Settings{}, errors.Wrap(err, "sending Settings message")
+	}
+	return settings, nil
+}
+
+// Aliases returns the index and field aliases.
+func (api *API) Aliases(ctx context.Context) (Aliases, error) {
+	if err := api.validate(apiAliases); err != nil {
+		return Aliases{}, errors.Wrap(err, "validating api method")
+	}
+	return api.holder.aliases.get(), nil
+}
+
+// UpdateAliases changes the aliases on every node, and returns the new
+// aliases. All of the aliases in the update are changed together, so an
+// alias may be moved to a new index or field without queries seeing a
+// partial change.
+func (api *API) UpdateAliases(ctx context.Context, update AliasesUpdate) (Aliases, error) {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.UpdateAliases")
+	defer span.Finish()
+
+	if err := api.validate(apiAliases); err != nil {
+		return Aliases{}, errors.Wrap(err, "validating api method")
+	}
+
+	// Nodes which do not handle the message would keep the old aliases.
+	if err := api.cluster.validateFeatures(FeatureAliases); err != nil {
+		return Aliases{}, errors.Wrap(err, "updating aliases")
+	}
+
+	previous, aliases, err := api.holder.aliases.apply(update, api.holder)
+	if _, ok := err.(BadRequestError); ok {
+		return Aliases{}, err
+	} else if err != nil {
+		return Aliases{}, errors.Wrap(err, "updating aliases")
+	}
+
+	// Send the aliases to all nodes. If any node can't be sent them, the
+	// update fails, and is undone on the nodes which received it.
+	if err := api.server.SendSync(&AliasesMessage{Aliases: aliases}); err != nil {
+		api.rollbackAliases(previous, aliases)
+		return Aliases{}, errors.Wrap(err, "sending Aliases message")
+	}
+	return aliases, nil
+}
+
+// rollback undoes a change which couldn't be sent to every node, by applying
+// m, which reverses it, locally and sending m to the nodes which may have
+// received the change. Nodes which can't be sent m either are left as they
+// are.
+func (api *API) rollback(m Message) {
+	if err := api.server.receiveMessage(m); err != nil {
+		api.server.logger.Printf("undoing change: %v", err)
+	}
+	if err := api.server.SendSync(m); err != nil {
+		api.server.logger.Printf("sending undone change: %v", err)
+	}
+}
+
+// rollbackAliases restores the aliases from before an update which couldn't
+// be sent to every node. The restored aliases are later than the update, so
+// they replace it on the nodes which received it, including those which
+// only receive them along with the status of other nodes.
+func (api *API) rollbackAliases(previous, update Aliases) {
+	previous, ok, err := api.holder.aliases.revert(previous, update)
+	if err != nil {
+		api.server.logger.Printf("restoring aliases: %v", err)
+	} else if !ok {
+		// A later update replaced it, and is sent to the other nodes
+		// instead.
+		return
+	}
+	if err := api.server.SendSync(&AliasesMessage{Aliases: previous}); err != nil {
+		api.server.logger.Printf("sending restored aliases: %v", err)
+	}
+}
+
+// NamedQueries returns the named queries.
+func (api *API) NamedQueries(ctx context.Context) ([]NamedQuery, error) {
+	if err := api.validate(apiNamedQueries); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+	return api.holder.namedQueries.all(), nil
+}
+
+// NamedQueryDefinitions returns the named queries, and the queries which
+// were deleted, with their versions, as they are sent to other nodes.
+func (api *API) NamedQueryDefinitions(ctx context.Context) ([]*CreateNamedQueryMessage, []*DeleteNamedQueryMessage) {
+	if err := api.validate(apiNamedQueries); err != nil {
+		return nil, nil
+	}
+	return api.holder.namedQueries.definitions()
+}
+
+// CreateNamedQuery stores a named query on every node, replacing any query of
+// the same name.
+func (api *API) CreateNamedQuery(ctx context.Context, q NamedQuery) error {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.CreateNamedQuery")
+	defer span.Finish()
+
+	if err := api.validate(apiNamedQueries); err != nil {
+		return errors.Wrap(err, "validating api method")
+	}
+
+	// Nodes which do not handle the message could not execute the query.
+	if err := api.cluster.validateFeatures(FeatureNamedQueries); err != nil {
+		return errors.Wrap(err, "creating named query")
+	}
+
+	if err := q.validate(api.holder); err != nil {
+		return errors.Wrap(err, "creating named query")
+	}
+	previous, replaced := api.holder.namedQueries.get(q.Name)
+	version, err := api.holder.namedQueries.create(q, 0)
+	if err != nil {
+		return errors.Wrap(err, "creating named query")
+	}
+
+	// Send the named query to all nodes. If any node can't be sent it, the
+	// query it replaced is restored, or it is deleted again, as a later
+	// change.
+	if err := api.server.SendSync(&CreateNamedQueryMessage{Query: q, Version: version}); err != nil {
+		if replaced {
+			api.rollback(&CreateNamedQueryMessage{Query: previous, Version: nextVersion(version)})
+		} else {
+			api.rollback(&DeleteNamedQueryMessage{Name: q.Name, Version: nextVersion(version)})
+		}
+		return errors.Wrap(err, "sending CreateNamedQuery message")
+	}
+	return nil
+}
+
+// DeleteNamedQuery removes a named query from every node.
+func (api *API) DeleteNamedQuery(ctx context.Context, name string) error {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.DeleteNamedQuery")
+	defer span.Finish()
+
+	if err := api.validate(apiNamedQueries); err != nil {
+		return errors.Wrap(err, "validating api method")
+	}
+
+	previous, ok := api.holder.namedQueries.get(name)
+	version, err := api.holder.namedQueries.delete(name, 0)
+	if err != nil {
+		return errors.Wrap(err, "deleting named query")
+	}
+
+	// Send the delete named query message to all nodes. If any node can't be
+	// sent it, the query is restored as a later change.
+	if err := api.server.SendSync(&DeleteNamedQueryMessage{Name: name, Version: version}); err != nil {
+		if ok {
+			api.rollback(&CreateNamedQueryMessage{Query: previous, Version: nextVersion(version)})
+		}
+		return errors.Wrap(err, "sending DeleteNamedQuery message")
+	}
+	return nil
+}
+
+// QueryNamed executes the named query named name, with its parameters
+// replaced by params.
+func (api *API) QueryNamed(ctx context.Context, name string, params map[string]string) (QueryResponse, error) {
+	if err := api.validate(apiQuery); err != nil {
+		return QueryResponse{}, errors.Wrap(err, "validating api method")
+	}
+
+	q, ok := api.holder.namedQueries.get(name)
+	if !ok {
+		return QueryResponse{}, newNotFoundError(ErrNamedQueryNotFound, name)
+	}
+	query, err := q.bind(params)
+	if err != nil {
+		return QueryResponse{}, NewBadRequestError(errors.Wrapf(err, "binding named query %s", name))
+	}
+	return api.Query(ctx, &QueryRequest{Index: q.Index, Query: query})
+}
+
+// Schedules returns the schedules.
+func (api *API) Schedules(ctx context.Context) ([]Schedule, error) {
+	if err := api.validate(apiSchedules); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+	return api.holder.schedules.all(), nil
+}
+
+// ScheduleStatus returns the schedule named name, when it is next due, and
+// its recent runs.
+func (api *API) ScheduleStatus(ctx context.Context, name string) (ScheduleStatus, error) {
+	if err := api.validate(apiSchedules); err != nil {
+		return ScheduleStatus{}, errors.Wrap(err, "validating api method")
+	}
+
+	sched, ok := api.holder.schedules.get(name)
+	if !ok {
+		return ScheduleStatus{}, newNotFoundError(ErrScheduleNotFound, name)
+	}
+	status := ScheduleStatus{Schedule: sched, Runs: api.holder.schedules.recentRuns(name)}
+	if c, err := parseCron(sched.Cron); err == nil {
+		status.Next = c.next(time.Now())
+	}
+	return status, nil
+}
+
+// CreateSchedule stores a schedule on every node, replacing any schedule of
+// the same name. The coordinator runs it from then on.
+func (api *API) CreateSchedule(ctx context.Context, sched Schedule) error {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.CreateSchedule")
+	defer span.Finish()
+
+	if err := api.validate(apiSchedules); err != nil {
+		return errors.Wrap(err, "validating api method")
+	}
+
+	// Nodes which do not handle the message could not run the schedule
+	// when they become the coordinator.
+	if err := api.cluster.validateFeatures(FeatureSchedules); err != nil {
+		return errors.Wrap(err, "creating schedule")
+	}
+
+	if err := sched.validate(api.holder); err != nil {
+		return errors.Wrap(err, "creating schedule")
+	}
+	if err := api.holder.schedules.create(sched); err != nil {
+		return errors.Wrap(err, "creating schedule")
+	}
+
+	// Send the schedule to all nodes.
+	err := api.server.SendSync(&CreateScheduleMessage{Schedule: sched})
+	return errors.Wrap(err, "sending CreateSchedule message")
+}
+
+// DeleteSchedule removes a schedule from every node.
+func (api *API) DeleteSchedule(ctx context.Context, name string) error {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.DeleteSchedule")
+	defer span.Finish()
+
+	if err := api.validate(apiSchedules); err != nil {
+		return errors.Wrap(err, "validating api method")
+	}
+
+	if err := api.holder.schedules.delete(name); err != nil {
+		return errors.Wrap(err, "deleting schedule")
+	}
+
+	// Send the delete schedule message to all nodes.
+	err := api.server.SendSync(&DeleteScheduleMessage{Name: name})
+	return errors.Wrap(err, "sending DeleteSchedule message")
+}
+
+// Usage returns the usage of each API token on this node in the current
+// quota period.
+func (api *API) Usage(ctx context.Context) ([]Usage, error) {
+	if err := api.validate(apiUsage); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+	return api.server.usage.all(), nil
+}
+
+// Jobs returns the status of the background jobs on this node.
+func (api *API) Jobs(ctx context.Context) ([]JobStatus, error) {
+	if err := api.validate(apiJobs); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+	return api.server.jobs.list(), nil
+}
+
+// Job returns the status of a background job on this node.
+func (api *API) Job(ctx context.Context, id int64) (JobStatus, error) {
+	if err := api.validate(apiJobs); err != nil {
+		return JobStatus{}, errors.Wrap(err, "validating api method")
+	}
+	return api.server.jobs.status(id)
+}
+
+// CancelJob stops a background job on this node. The returned status may
+// still show the job as running while it winds down.
+func (api *API) CancelJob(ctx context.Context, id int64) (JobStatus, error) {
+	if err := api.validate(apiJobs); err != nil {
+		return JobStatus{}, errors.Wrap(err, "validating api method")
+	}
+	return api.server.jobs.cancel(id)
+}
+
 // MaxShards returns the maximum shard number for each index in a map.
 // TODO (2.0): This method has been deprecated. Instead, use
 // AvailableShardsByIndex.
@@ -1136,6 +2564,25 @@ func (api *API) MaxShards(ctx context.Context) map[string]uint64 {
 	return m
 }
 
+// MaxShardsByNode returns the maximum shard number for each index, keyed by
+// the ID of each node in the cluster. Remote nodes report their maximum shards
+// in the status they gossip, so this makes no requests to them; a node which
+// has not reported yet is omitted.
+func (api *API) MaxShardsByNode(ctx context.Context) map[string]map[string]uint64 {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.MaxShardsByNode")
+	defer span.Finish()
+
+	m := map[string]map[string]uint64{api.server.nodeID: api.MaxShards(ctx)}
+	api.server.peersMu.RLock()
+	defer api.server.peersMu.RUnlock()
+	for _, node := range api.cluster.Nodes() {
+		if shards, ok := api.server.peerMaxShards[node.ID]; ok {
+			m[node.ID] = shards
+		}
+	}
+	return m
+}
+
 // AvailableShardsByIndex returns bitmaps of shards with available by index name.
 func (api *API) AvailableShardsByIndex(ctx context.Context) map[string]*roaring.Bitmap {
 	span, _ := tracing.StartSpanFromContext(ctx, "API.AvailableShardsByIndex")
@@ -1155,10 +2602,10 @@ func (api *API) StatsWithTags(tags []string) stats.StatsClient {
 // LongQueryTime returns the configured threshold for logging/statting
 // long running queries.
 func (api *API) LongQueryTime() time.Duration {
-	if api.cluster == nil {
+	if api.server == nil {
 		return 0
 	}
-	return api.cluster.longQueryTime
+	return time.Duration(api.server.settings.get().LongQueryTime)
 }
 
 func (api *API) validateShardOwnership(indexName string, shard uint64) error {
@@ -1263,6 +2710,17 @@ func (api *API) State() string {
 	return api.cluster.State()
 }
 
+// StartupProgress returns how far this node has got opening its fragments.
+func (api *API) StartupProgress() StartupProgress {
+	return api.holder.progress.status()
+}
+
+// SchemaVersion returns the version of the last schema change this node has
+// seen, or zero if it has seen none since it started.
+func (api *API) SchemaVersion() uint64 {
+	return atomic.LoadUint64(&api.server.schemaVersion)
+}
+
 // Version returns the Pilosa version.
 func (api *API) Version() string {
 	return strings.TrimPrefix(Version, "v")
@@ -1374,6 +2832,27 @@ const (
 	//apiVersion // not implemented
 	apiViews
 	apiApplySchema
+	apiChanges
+	apiLoadUDF
+	apiDeleteUDF
+	apiReindexField
+	apiJobs
+	apiDeleteRows
+	apiTruncateField
+	apiUsage
+	apiSettings
+	apiSetIndexReadOnly
+	apiSchemaDiff
+	apiMaterializedViews
+	apiExportAttrs
+	apiAliases
+	apiRewriteRules
+	apiNamedQueries
+	apiSchedules
+	apiFieldHistogram
+	apiClusterTopology
+	apiRebalanceShards
+	apiShardDistribution
 )
 
 var methodsCommon = map[apiMethod]struct{}{
@@ -1396,6 +2875,7 @@ var methodsNormal = map[apiMethod]struct{}{
 	apiExportCSV:            {},
 	apiFragmentBlockData:    {},
 	apiFragmentBlocks:       {},
+	apiFragmentData:         {},
 	apiField:                {},
 	apiFieldAttrDiff:        {},
 	apiImport:               {},
@@ -1408,4 +2888,25 @@ var methodsNormal = map[apiMethod]struct{}{
 	apiShardNodes:           {},
 	apiViews:                {},
 	apiApplySchema:          {},
+	apiChanges:              {},
+	apiLoadUDF:              {},
+	apiDeleteUDF:            {},
+	apiReindexField:         {},
+	apiJobs:                 {},
+	apiDeleteRows:           {},
+	apiTruncateField:        {},
+	apiUsage:                {},
+	apiSettings:             {},
+	apiSetIndexReadOnly:     {},
+	apiSchemaDiff:           {},
+	apiMaterializedViews:    {},
+	apiExportAttrs:          {},
+	apiAliases:              {},
+	apiRewriteRules:         {},
+	apiNamedQueries:         {},
+	apiSchedules:            {},
+	apiFieldHistogram:       {},
+	apiClusterTopology:      {},
+	apiRebalanceShards:      {},
+	apiShardDistribution:    {},
 }
@@ -15,19 +15,31 @@
 package pilosa_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"math"
+	gohttp "net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"reflect"
+	"sort"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/pilosa/pilosa/v2"
 	"github.com/pilosa/pilosa/v2/boltdb"
 	"github.com/pilosa/pilosa/v2/http"
+	"github.com/pilosa/pilosa/v2/pql"
+	"github.com/pilosa/pilosa/v2/roaring"
 	"github.com/pilosa/pilosa/v2/server"
 	"github.com/pilosa/pilosa/v2/test"
+	"github.com/pkg/errors"
 )
 
 func TestAPI_Import(t *testing.T) {
@@ -260,9 +272,1888 @@ func TestAPI_ImportValue(t *testing.T) {
 	})
 }
 
+func TestAPI_Changes(t *testing.T) {
+	c := test.MustRunCluster(t, 1,
+		[]server.CommandOption{
+			server.OptCommandServerOptions(
+				pilosa.OptServerChangeLogSize(3),
+			)},
+	)
+	defer c.Close()
+
+	m := c[0]
+	ctx := context.Background()
+	if _, err := m.API.CreateIndex(ctx, "i", pilosa.IndexOptions{}); err != nil {
+		t.Fatalf("creating index: %v", err)
+	} else if _, err := m.API.CreateField(ctx, "i", "f"); err != nil {
+		t.Fatalf("creating field: %v", err)
+	}
+
+	if _, err := m.API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: `Set(10, f=1) Set(10, f=1) SetRowAttrs(f, 1, x=5) Clear(10, f=1)`}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The duplicate Set() is not a change and is not recorded.
+	events, err := m.API.Changes(ctx, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(events) != 3 {
+		t.Fatalf("unexpected events: %+v", events)
+	} else if ev := events[0]; ev.Seq != 1 || ev.Type != pilosa.ChangeTypeSet || ev.Field != "f" || ev.RowID != 1 || ev.ColumnID != 10 {
+		t.Fatalf("unexpected set event: %+v", ev)
+	} else if ev := events[1]; ev.Type != pilosa.ChangeTypeRowAttrs || !reflect.DeepEqual(ev.Attrs, map[string]interface{}{"x": int64(5)}) {
+		t.Fatalf("unexpected attrs event: %+v", ev)
+	} else if ev := events[2]; ev.Seq != 3 || ev.Type != pilosa.ChangeTypeClear {
+		t.Fatalf("unexpected clear event: %+v", ev)
+	}
+
+	// Resume after the second event with a limit.
+	if events, err := m.API.Changes(ctx, 1, 1); err != nil {
+		t.Fatal(err)
+	} else if len(events) != 1 || events[0].Seq != 2 {
+		t.Fatalf("unexpected events: %+v", events)
+	}
+
+	// Block until the next change arrives.
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		if err := m.API.Import(ctx, &pilosa.ImportRequest{Index: "i", Field: "f", RowIDs: []uint64{2}, ColumnIDs: []uint64{20}}); err != nil {
+			t.Error(err)
+		}
+	}()
+	if events, err := m.API.Changes(ctx, 3, 0); err != nil {
+		t.Fatal(err)
+	} else if len(events) != 1 || events[0].Type != pilosa.ChangeTypeImport || !reflect.DeepEqual(events[0].ColumnIDs, []uint64{20}) {
+		t.Fatalf("unexpected events: %+v", events)
+	}
+
+	// The first event has been evicted from the log.
+	if _, err := m.API.Changes(ctx, 0, 0); err != pilosa.ErrChangeLogTruncated {
+		t.Fatalf("expected truncated error, got %v", err)
+	}
+
+	// Values are recorded with the field's base added back, and roaring
+	// imports with the bits they import.
+	if _, err := m.API.CreateField(ctx, "i", "v", pilosa.OptFieldTypeInt(100, 200)); err != nil {
+		t.Fatalf("creating field: %v", err)
+	} else if _, err := m.API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: `Set(10, v=150)`}); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if _, err := roaring.NewBitmap(pilosa.ShardWidth + 5).WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	} else if err := m.API.ImportRoaring(ctx, "i", "f", 2, false, &pilosa.ImportRoaringRequest{
+		Views: map[string][]byte{"": buf.Bytes()},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if events, err := m.API.Changes(ctx, 4, 0); err != nil {
+		t.Fatal(err)
+	} else if len(events) != 2 {
+		t.Fatalf("unexpected events: %+v", events)
+	} else if ev := events[0]; ev.Type != pilosa.ChangeTypeValue || ev.Field != "v" || ev.ColumnID != 10 || ev.Value != 150 {
+		t.Fatalf("unexpected value event: %+v", ev)
+	} else if ev := events[1]; ev.Type != pilosa.ChangeTypeImportRoaring || ev.View != "standard" || ev.Shard != 2 {
+		t.Fatalf("unexpected import event: %+v", ev)
+	} else if !reflect.DeepEqual(ev.RowIDs, []uint64{1}) || !reflect.DeepEqual(ev.ColumnIDs, []uint64{2*pilosa.ShardWidth + 5}) {
+		t.Fatalf("unexpected imported bits: %v, %v", ev.RowIDs, ev.ColumnIDs)
+	}
+}
+
+func TestAPI_SchemaWebhooks(t *testing.T) {
+	events := make(chan pilosa.SchemaEvent, 10)
+	ts := httptest.NewServer(gohttp.HandlerFunc(func(w gohttp.ResponseWriter, r *gohttp.Request) {
+		var ev pilosa.SchemaEvent
+		if err := json.NewDecoder(r.Body).Decode(&ev); err != nil {
+			t.Errorf("decoding event: %v", err)
+		}
+		events <- ev
+	}))
+	defer ts.Close()
+
+	c := test.MustRunCluster(t, 2,
+		[]server.CommandOption{
+			server.OptCommandServerOptions(
+				pilosa.OptServerNodeID("node0"),
+				pilosa.OptServerSchemaWebhooks([]string{ts.URL}),
+			)},
+		[]server.CommandOption{
+			server.OptCommandServerOptions(
+				pilosa.OptServerNodeID("node1"),
+				pilosa.OptServerSchemaWebhooks([]string{ts.URL}),
+			)},
+	)
+	defer c.Close()
+
+	// recv returns the next two events, one per node, ordered by node.
+	recv := func() []pilosa.SchemaEvent {
+		var evs []pilosa.SchemaEvent
+		for len(evs) < 2 {
+			select {
+			case ev := <-events:
+				evs = append(evs, ev)
+			case <-time.After(5 * time.Second):
+				t.Fatalf("timed out waiting for webhook, got %+v", evs)
+			}
+		}
+		if evs[0].NodeID > evs[1].NodeID {
+			evs[0], evs[1] = evs[1], evs[0]
+		}
+		return evs
+	}
+
+	ctx := context.Background()
+	if _, err := c[0].API.CreateIndex(ctx, "i", pilosa.IndexOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if evs := recv(); evs[0].Type != pilosa.SchemaEventCreateIndex || evs[0].Index != "i" || evs[0].NodeID != "node0" || evs[1].NodeID != "node1" {
+		t.Fatalf("unexpected events: %+v", evs)
+	}
+
+	if _, err := c[1].API.CreateField(ctx, "i", "f"); err != nil {
+		t.Fatal(err)
+	}
+	if evs := recv(); evs[0].Type != pilosa.SchemaEventCreateField || evs[1].Field != "f" {
+		t.Fatalf("unexpected events: %+v", evs)
+	}
+
+	if err := c[0].API.DeleteField(ctx, "i", "f"); err != nil {
+		t.Fatal(err)
+	}
+	if evs := recv(); evs[0].Type != pilosa.SchemaEventDeleteField || evs[1].Type != pilosa.SchemaEventDeleteField {
+		t.Fatalf("unexpected events: %+v", evs)
+	}
+
+	if err := c[0].API.DeleteIndex(ctx, "i"); err != nil {
+		t.Fatal(err)
+	}
+	if evs := recv(); evs[0].Type != pilosa.SchemaEventDeleteIndex || evs[1].Type != pilosa.SchemaEventDeleteIndex {
+		t.Fatalf("unexpected events: %+v", evs)
+	}
+}
+
+func TestAPI_EventHandler(t *testing.T) {
+	h := &eventRecorder{}
+	c := test.MustRunCluster(t, 1,
+		[]server.CommandOption{
+			server.OptCommandServerOptions(
+				pilosa.OptServerNodeID("node0"),
+				pilosa.OptServerEventHandler(h),
+			)},
+	)
+	defer c.Close()
+	m := c[0]
+
+	ctx := context.Background()
+	if _, err := m.API.CreateIndex(ctx, "i", pilosa.IndexOptions{}); err != nil {
+		t.Fatal(err)
+	} else if _, err := m.API.CreateField(ctx, "i", "f"); err != nil {
+		t.Fatal(err)
+	} else if _, err := m.API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: fmt.Sprintf("Set(%d, f=1)", pilosa.ShardWidth*2)}); err != nil {
+		t.Fatal(err)
+	} else if err := m.Server.SyncData(); err != nil {
+		t.Fatal(err)
+	}
+
+	evs := h.Events()
+	if ev := evs[0]; ev != (pilosa.NodeStateEvent{NodeID: "node0", State: "READY"}) {
+		t.Fatalf("unexpected node state event: %#v", ev)
+	}
+	evs = evs[1:]
+	for i := range evs {
+		if ev, ok := evs[i].(pilosa.SchemaEvent); ok {
+			ev.Time = time.Time{}
+			evs[i] = ev
+		} else if ev, ok := evs[i].(pilosa.SyncEvent); ok {
+			ev.Duration = 0
+			evs[i] = ev
+		}
+	}
+	if exp := []pilosa.Event{
+		pilosa.SchemaEvent{Type: pilosa.SchemaEventCreateIndex, Index: "i", NodeID: "node0"},
+		pilosa.SchemaEvent{Type: pilosa.SchemaEventCreateField, Index: "i", Field: "f", NodeID: "node0"},
+		pilosa.ShardEvent{Index: "i", Field: "f", Shard: 2},
+		pilosa.SyncEvent{},
+	}; !reflect.DeepEqual(evs, exp) {
+		t.Fatalf("unexpected events:\n%#v\nexpected:\n%#v", evs, exp)
+	}
+}
+
+// Ensure nodes reject writes while low on disk space.
+func TestAPI_InsufficientDiskSpace(t *testing.T) {
+	// No file system has this much free space.
+	c := test.MustRunCluster(t, 2, []server.CommandOption{
+		server.OptCommandServerOptions(
+			pilosa.OptServerMinDiskFree(math.MaxUint64),
+			pilosa.OptServerDiskCheckInterval(50*time.Millisecond),
+		),
+	})
+	defer c.Close()
+	m0, m1 := c[0], c[1]
+
+	ctx := context.Background()
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+
+	// Wait for each node to learn of the other's state.
+	exp := []string{m0.API.Node().ID, m1.API.Node().ID}
+	sort.Strings(exp)
+	for _, m := range c {
+		for i := 0; !reflect.DeepEqual(m.API.ReadOnlyNodes(), exp); i++ {
+			if i == 100 {
+				t.Fatalf("unexpected read-only nodes: %v", m.API.ReadOnlyNodes())
+			}
+			time.Sleep(50 * time.Millisecond)
+		}
+	}
+
+	if _, err := m0.API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: "Set(1, f=1)"}); errors.Cause(err) != pilosa.ErrInsufficientDiskSpace {
+		t.Fatalf("unexpected query error: %v", err)
+	}
+	req := &pilosa.ImportRequest{Index: "i", Field: "f", RowIDs: []uint64{1}, ColumnIDs: []uint64{1}}
+	if err := m1.API.Import(ctx, req); errors.Cause(err) != pilosa.ErrInsufficientDiskSpace {
+		t.Fatalf("unexpected import error: %v", err)
+	}
+
+	// Writes are rejected with a distinct status code.
+	resp, err := gohttp.Post(m0.URL()+"/index/i/query", "text/plain", strings.NewReader("Set(1, f=1)"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != gohttp.StatusInsufficientStorage {
+		t.Fatalf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	// Reads are unaffected.
+	if res := c.Query(t, "i", "Count(Row(f=1))").Results[0]; res != uint64(0) {
+		t.Fatalf("unexpected count: %v", res)
+	}
+}
+
+// Ensure nodes shed batch queries and imports while their heap is above the
+// high-water mark.
+func TestAPI_Overloaded(t *testing.T) {
+	// Every heap is above this mark.
+	c := test.MustRunCluster(t, 1, []server.CommandOption{
+		server.OptCommandServerOptions(pilosa.OptServerHeapHighWater(1)),
+	})
+	defer c.Close()
+	m := c[0]
+
+	ctx := context.Background()
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+
+	if _, err := m.API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: "Count(Row(f=1))", Priority: pilosa.PriorityBatch}); errors.Cause(err) != pilosa.ErrOverloaded {
+		t.Fatalf("unexpected query error: %v", err)
+	}
+	req := &pilosa.ImportRequest{Index: "i", Field: "f", RowIDs: []uint64{1}, ColumnIDs: []uint64{1}}
+	if err := m.API.Import(ctx, req); errors.Cause(err) != pilosa.ErrOverloaded {
+		t.Fatalf("unexpected import error: %v", err)
+	}
+
+	// Clients are told when to retry.
+	resp, err := gohttp.Post(m.URL()+"/index/i/query?priority=batch", "text/plain", strings.NewReader("Count(Row(f=1))"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != gohttp.StatusServiceUnavailable {
+		t.Fatalf("unexpected status code: %d", resp.StatusCode)
+	} else if resp.Header.Get("Retry-After") == "" {
+		t.Fatal("expected Retry-After header")
+	}
+
+	// Interactive queries are unaffected.
+	if res := c.Query(t, "i", "Count(Row(f=1))").Results[0]; res != uint64(0) {
+		t.Fatalf("unexpected count: %v", res)
+	}
+}
+
+func TestAPI_QueryPriority(t *testing.T) {
+	c := test.MustRunCluster(t, 2, []server.CommandOption{
+		server.OptCommandServerOptions(pilosa.OptServerMaxConcurrentQueries(1)),
+	})
+	defer c.Close()
+	m0 := c[0]
+
+	ctx := context.Background()
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+	c.ImportBits(t, "i", "f", [][2]uint64{
+		{1, 1}, {1, pilosa.ShardWidth}, {1, pilosa.ShardWidth*2 + 1},
+	})
+
+	// Batch queries span nodes without being held back by remote admission.
+	resp, err := m0.API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: "Count(Row(f=1))", Priority: pilosa.PriorityBatch})
+	if err != nil {
+		t.Fatal(err)
+	} else if resp.Results[0] != uint64(3) {
+		t.Fatalf("unexpected count: %v", resp.Results[0])
+	}
+
+	resp2, err := gohttp.Post(m0.URL()+"/index/i/query?priority=batch", "text/plain", strings.NewReader("Count(Row(f=1))"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp2.Body.Close()
+	if resp2.StatusCode != gohttp.StatusOK {
+		t.Fatalf("unexpected status code: %d", resp2.StatusCode)
+	}
+
+	if _, err := m0.API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: "Count(Row(f=1))", Priority: "urgent"}); err == nil {
+		t.Fatal("expected error")
+	} else if _, ok := errors.Cause(err).(pilosa.BadRequestError); !ok {
+		t.Fatalf("unexpected error type: %T", errors.Cause(err))
+	}
+}
+
+func TestAPI_QueryProfile(t *testing.T) {
+	c := test.MustRunCluster(t, 2)
+	defer c.Close()
+	m0 := c[0]
+
+	ctx := context.Background()
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+	c.ImportBits(t, "i", "f", [][2]uint64{
+		{1, 1}, {1, pilosa.ShardWidth}, {1, pilosa.ShardWidth*2 + 1}, {1, pilosa.ShardWidth*3 + 1},
+	})
+
+	if resp, err := m0.API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: "Count(Row(f=1))"}); err != nil {
+		t.Fatal(err)
+	} else if resp.Profile != nil {
+		t.Fatal("expected no profile unless requested")
+	}
+
+	// The row cache is filled by the first query, so the second hits it.
+	var resp pilosa.QueryResponse
+	for i := 0; i < 2; i++ {
+		var err error
+		if resp, err = m0.API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: "Count(Row(f=1))", Profile: true}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	p := resp.Profile
+	if p == nil {
+		t.Fatal("expected profile")
+	} else if len(p.Shards) != 4 {
+		t.Fatalf("unexpected shard profiles: %+v", p.Shards)
+	} else if p.ContainersScanned != 4 {
+		t.Fatalf("unexpected containers scanned: %d", p.ContainersScanned)
+	} else if p.CacheHits != 4 {
+		t.Fatalf("unexpected cache hits: %d", p.CacheHits)
+	} else if p.Execute <= 0 {
+		t.Fatalf("unexpected execution time: %s", p.Execute)
+	}
+	remote := map[string]bool{}
+	for _, sp := range p.Shards {
+		if sp.Node != m0.API.Node().ID {
+			remote[sp.Node] = true
+		}
+	}
+	if len(p.Nodes) != len(remote) {
+		t.Fatalf("unexpected node profiles: %+v", p.Nodes)
+	}
+	for _, np := range p.Nodes {
+		if !remote[np.Node] || np.Requests != 1 {
+			t.Fatalf("unexpected node profile: %+v", np)
+		}
+	}
+
+	resp2, err := gohttp.Post(m0.URL()+"/index/i/query?profile=true", "text/plain", strings.NewReader("Count(Row(f=1))"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp2.Body.Close()
+	var body struct {
+		Profile *pilosa.QueryProfile `json:"profile"`
+	}
+	if err := json.NewDecoder(resp2.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	} else if body.Profile == nil || len(body.Profile.Shards) != 4 {
+		t.Fatalf("unexpected profile: %+v", body.Profile)
+	}
+}
+
+func TestAPI_ReindexField(t *testing.T) {
+	c := test.MustRunCluster(t, 1)
+	defer c.Close()
+	m := c[0]
+
+	ctx := context.Background()
+	c.CreateField(t, "i", pilosa.IndexOptions{TrackExistence: true}, "f")
+
+	// Roaring imports do not track existence, so Not() misses the columns.
+	var buf bytes.Buffer
+	if _, err := roaring.NewBitmap(pilosa.ShardWidth+1, pilosa.ShardWidth+2, 2*pilosa.ShardWidth+3).WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.API.ImportRoaring(ctx, "i", "f", 0, false, &pilosa.ImportRoaringRequest{
+		Views: map[string][]byte{"": buf.Bytes()},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if n := c.Query(t, "i", "Count(Not(Row(f=1)))").Results[0]; n != uint64(0) {
+		t.Fatalf("unexpected count before reindex: %v", n)
+	}
+
+	job, err := m.API.ReindexField(ctx, "i", "f")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; job.State == pilosa.JobStateRunning; i++ {
+		if i == 100 {
+			t.Fatal("job did not finish")
+		}
+		time.Sleep(10 * time.Millisecond)
+		if job, err = m.API.Job(ctx, job.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if job.State != pilosa.JobStateDone || job.Done != 1 || job.Total != 1 {
+		t.Fatalf("unexpected job status: %+v", job)
+	}
+	if n := c.Query(t, "i", "Count(Not(Row(f=1)))").Results[0]; n != uint64(1) {
+		t.Fatalf("unexpected count after reindex: %v", n)
+	}
+
+	if jobs, err := m.API.Jobs(ctx); err != nil {
+		t.Fatal(err)
+	} else if len(jobs) != 1 || jobs[0].ID != job.ID {
+		t.Fatalf("unexpected jobs: %+v", jobs)
+	}
+
+	if _, err := m.API.ReindexField(ctx, "i", "nope"); err == nil {
+		t.Fatal("expected error")
+	} else if _, ok := errors.Cause(err).(pilosa.NotFoundError); !ok {
+		t.Fatalf("unexpected error type: %T", errors.Cause(err))
+	}
+	if _, err := m.API.CancelJob(ctx, job.ID+1); err == nil {
+		t.Fatal("expected error")
+	} else if _, ok := errors.Cause(err).(pilosa.NotFoundError); !ok {
+		t.Fatalf("unexpected error type: %T", errors.Cause(err))
+	}
+}
+
+func TestAPI_ImportJob(t *testing.T) {
+	c := test.MustRunCluster(t, 1)
+	defer c.Close()
+	m := c[0]
+
+	ctx := context.Background()
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "v", pilosa.OptFieldTypeInt(0, 1000))
+
+	// Enough records for several batches.
+	const n = 150000
+	req := &pilosa.ImportRequest{Index: "i", Field: "f"}
+	vreq := &pilosa.ImportValueRequest{Index: "i", Field: "v"}
+	for col := uint64(0); col < n; col++ {
+		req.RowIDs = append(req.RowIDs, col%3)
+		req.ColumnIDs = append(req.ColumnIDs, col)
+		vreq.ColumnIDs = append(vreq.ColumnIDs, col)
+		vreq.Values = append(vreq.Values, 1)
+	}
+
+	wait := func(job pilosa.JobStatus) pilosa.JobStatus {
+		t.Helper()
+		for i := 0; job.State == pilosa.JobStateRunning; i++ {
+			if i == 500 {
+				t.Fatal("job did not finish")
+			}
+			time.Sleep(10 * time.Millisecond)
+			var err error
+			if job, err = m.API.Job(ctx, job.ID); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return job
+	}
+
+	job, err := m.API.ImportJob(ctx, req, 1234)
+	if err != nil {
+		t.Fatal(err)
+	} else if job.Type != pilosa.JobTypeImport || job.Bytes != 1234 {
+		t.Fatalf("unexpected job status: %+v", job)
+	}
+	if job = wait(job); job.State != pilosa.JobStateDone || job.Done != n || job.Total != n || job.ETA != nil {
+		t.Fatalf("unexpected job status: %+v", job)
+	}
+	if cnt := c.Query(t, "i", "Count(Row(f=1))").Results[0]; cnt != uint64(n/3) {
+		t.Fatalf("unexpected count: %v", cnt)
+	}
+
+	job, err = m.API.ImportValueJob(ctx, vreq, 1234)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job = wait(job); job.State != pilosa.JobStateDone || job.Done != n {
+		t.Fatalf("unexpected job status: %+v", job)
+	}
+	if sum := c.Query(t, "i", "Sum(field=v)").Results[0].(pilosa.ValCount); sum.Val != n {
+		t.Fatalf("unexpected sum: %+v", sum)
+	}
+
+	// Records are checked before the job starts.
+	req.RowIDs = req.RowIDs[:1]
+	if _, err := m.API.ImportJob(ctx, req, 0); err == nil {
+		t.Fatal("expected error")
+	} else if _, ok := errors.Cause(err).(pilosa.BadRequestError); !ok {
+		t.Fatalf("unexpected error type: %T", errors.Cause(err))
+	}
+}
+
+func TestAPI_QueryAsync(t *testing.T) {
+	c := test.MustRunCluster(t, 1)
+	defer c.Close()
+	m := c[0]
+
+	ctx := context.Background()
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+	c.ImportBits(t, "i", "f", [][2]uint64{{1, 1}, {1, 2}, {1, pilosa.ShardWidth}})
+
+	job, err := m.API.QueryAsync(ctx, &pilosa.QueryRequest{Index: "i", Query: "Count(Row(f=1))"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job, resp, err := m.API.QueryResult(ctx, job.ID, true); err != nil {
+		t.Fatal(err)
+	} else if job.State != pilosa.JobStateDone {
+		t.Fatalf("unexpected job status: %+v", job)
+	} else if !reflect.DeepEqual(resp.Results, []interface{}{uint64(3)}) {
+		t.Fatalf("unexpected results: %+v", resp.Results)
+	}
+
+	// Execution errors are reported in the response.
+	job, err = m.API.QueryAsync(ctx, &pilosa.QueryRequest{Index: "i", Query: "Count(Row(nope=1))"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job, resp, err := m.API.QueryResult(ctx, job.ID, true); err != nil {
+		t.Fatal(err)
+	} else if job.State != pilosa.JobStateFailed || resp.Err == nil {
+		t.Fatalf("unexpected result: %+v, %+v", job, resp)
+	}
+
+	if _, err := m.API.QueryAsync(ctx, &pilosa.QueryRequest{Index: "i", Query: "Count("}); err == nil {
+		t.Fatal("expected error")
+	} else if _, ok := errors.Cause(err).(pilosa.BadRequestError); !ok {
+		t.Fatalf("unexpected error type: %T", errors.Cause(err))
+	}
+
+	// Only query jobs have results.
+	job, err = m.API.ReindexField(ctx, "i", "f")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := m.API.QueryResult(ctx, job.ID, false); err == nil {
+		t.Fatal("expected error")
+	} else if _, ok := errors.Cause(err).(pilosa.BadRequestError); !ok {
+		t.Fatalf("unexpected error type: %T", errors.Cause(err))
+	}
+}
+
+func TestAPI_Usage(t *testing.T) {
+	quota := pilosa.Quota{Queries: 2, ImportBytes: 16}
+	c := test.MustRunCluster(t, 2, []server.CommandOption{
+		server.OptCommandServerOptions(pilosa.OptServerQuota(quota)),
+	}, []server.CommandOption{
+		server.OptCommandServerOptions(pilosa.OptServerQuota(quota)),
+	})
+	defer c.Close()
+	m0, m1 := c[0], c[1]
+
+	// usage returns the usage of a token on a node.
+	usage := func(m *test.Command, token string) pilosa.Usage {
+		t.Helper()
+		a, err := m.API.Usage(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, u := range a {
+			if u.Token == token {
+				return u
+			}
+		}
+		return pilosa.Usage{}
+	}
+
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+	setup := pilosa.WithAPIToken(context.Background(), "setup")
+	if _, err := m0.API.Query(setup, &pilosa.QueryRequest{Index: "i", Query: fmt.Sprintf("Set(1, f=1) Set(2, f=1) Set(%d, f=1)", pilosa.ShardWidth)}); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := pilosa.WithAPIToken(context.Background(), "team-a")
+	if _, err := m0.API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: "Count(Row(f=1))"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Queries count on the node they were sent to, while containers count
+	// on the node which scanned them.
+	var containers int64
+	for i, m := range []*test.Command{m0, m1} {
+		u := usage(m, "team-a")
+		if want := int64(1 - i); u.Queries != want {
+			t.Fatalf("unexpected queries on node %d: %d", i, u.Queries)
+		}
+		containers += u.Containers
+	}
+	if containers != 2 {
+		t.Fatalf("unexpected containers: %d", containers)
+	}
+
+	// The quota applies to each token separately, and to the requests
+	// without a token together.
+	if _, err := m0.API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: "Count(Row(f=1))"}); err != nil {
+		t.Fatal(err)
+	} else if _, err := m0.API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: "Count(Row(f=1))"}); errors.Cause(err) != pilosa.ErrQuotaExceeded {
+		t.Fatalf("unexpected error: %v", err)
+	} else if _, err := m0.API.Query(pilosa.WithAPIToken(ctx, "team-b"), &pilosa.QueryRequest{Index: "i", Query: "Count(Row(f=1))"}); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := m0.API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: "Count(Row(f=1))"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := m0.API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: "Count(Row(f=1))"}); errors.Cause(err) != pilosa.ErrQuotaExceeded {
+		t.Fatalf("unexpected error without a token: %v", err)
+	} else if u := usage(m0, "anonymous"); u.Queries != 2 {
+		t.Fatalf("unexpected usage without a token: %+v", u)
+	} else if n := usage(m0, "anonymous").Containers + usage(m1, "anonymous").Containers; n != 4 {
+		t.Fatalf("unexpected containers without a token: %d", n)
+	}
+
+	// Imports are metered by size.
+	req := &pilosa.ImportRequest{Index: "i", Field: "f", RowIDs: []uint64{2}, ColumnIDs: []uint64{3}}
+	if err := m0.API.Import(ctx, req); err != nil {
+		t.Fatal(err)
+	} else if err := m0.API.Import(ctx, req); errors.Cause(err) != pilosa.ErrQuotaExceeded {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if u := usage(m0, "team-a"); u.Queries != 2 || u.ImportBytes != 16 {
+		t.Fatalf("unexpected usage: %+v", u)
+	} else if u := usage(m0, "team-b"); u.Queries != 1 {
+		t.Fatalf("unexpected usage: %+v", u)
+	}
+
+	// Internal requests, such as replays of writes a node missed, aren't
+	// metered unless they pass a token on.
+	internal := pilosa.WithInternalRequest(context.Background())
+	for i := 0; i < 2; i++ {
+		if err := m0.API.Import(internal, req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if u := usage(m0, "anonymous"); u.ImportBytes != 0 {
+		t.Fatalf("unexpected usage of internal requests: %+v", u)
+	}
+}
+
+func TestAPI_SchemaCoordinator(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+	ctx := context.Background()
+
+	// Changes made through any node are versioned by the coordinator.
+	if _, err := c[1].API.CreateIndex(ctx, "i", pilosa.IndexOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	version := c[0].API.SchemaVersion()
+	if version == 0 {
+		t.Fatal("expected schema version")
+	}
+	for i, m := range c {
+		if m.Server.Holder().Index("i") == nil {
+			t.Fatalf("index missing on node %d", i)
+		} else if v := m.API.SchemaVersion(); v != version {
+			t.Fatalf("unexpected schema version on node %d: %d", i, v)
+		}
+	}
+
+	// Creating the same field concurrently with different options through
+	// different nodes succeeds once, and every node agrees on the options.
+	errs := make([]error, 2)
+	var wg sync.WaitGroup
+	for i, opt := range []pilosa.FieldOption{pilosa.OptFieldTypeInt(0, 10), pilosa.OptFieldTypeInt(0, 20)} {
+		i, opt := i, opt
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = c[i+1].API.CreateField(ctx, "i", "f", opt)
+		}()
+	}
+	wg.Wait()
+	if (errs[0] == nil) == (errs[1] == nil) {
+		t.Fatalf("expected exactly one error: %v", errs)
+	}
+	for _, err := range errs {
+		if _, ok := errors.Cause(err).(pilosa.ConflictError); err != nil && !ok {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	max := c[0].Server.Holder().Field("i", "f").Options().Max
+	for i, m := range c {
+		if f := m.Server.Holder().Field("i", "f"); f == nil || f.Options().Max != max {
+			t.Fatalf("unexpected field on node %d: %+v", i, f)
+		}
+	}
+	if v := c[2].API.SchemaVersion(); v <= version {
+		t.Fatalf("expected later schema version: %d", v)
+	}
+
+	if err := c[2].API.DeleteField(ctx, "i", "f"); err != nil {
+		t.Fatal(err)
+	} else if err := c[1].API.DeleteIndex(ctx, "i"); err != nil {
+		t.Fatal(err)
+	}
+	for i, m := range c {
+		if m.Server.Holder().Index("i") != nil {
+			t.Fatalf("index remains on node %d", i)
+		}
+	}
+}
+
+func TestAPI_IndexReadOnly(t *testing.T) {
+	c := test.MustRunCluster(t, 2)
+	defer c.Close()
+	ctx := context.Background()
+
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+	c.Query(t, "i", "Set(1, f=1)")
+	if err := c[0].API.SetIndexReadOnly(ctx, "i", true); err != nil {
+		t.Fatal(err)
+	}
+
+	// Writes are rejected on every node, while reads are still served.
+	for i, m := range c {
+		if !m.Server.Holder().Index("i").ReadOnly() {
+			t.Fatalf("index not read-only on node %d", i)
+		}
+		if _, err := m.API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: "Set(2, f=1)"}); errors.Cause(err) != pilosa.ErrIndexReadOnly {
+			t.Fatalf("unexpected error on node %d: %v", i, err)
+		}
+		if err := m.API.Import(ctx, &pilosa.ImportRequest{Index: "i", Field: "f", RowIDs: []uint64{1}, ColumnIDs: []uint64{3}}); errors.Cause(err) != pilosa.ErrIndexReadOnly {
+			t.Fatalf("unexpected import error on node %d: %v", i, err)
+		}
+		if resp, err := m.API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: "Count(Row(f=1))"}); err != nil {
+			t.Fatal(err)
+		} else if resp.Results[0] != uint64(1) {
+			t.Fatalf("unexpected count on node %d: %v", i, resp.Results[0])
+		}
+	}
+	if schema := c[1].API.Schema(ctx); len(schema) != 1 || !schema[0].ReadOnly {
+		t.Fatalf("unexpected schema: %+v", schema)
+	}
+
+	if err := c[1].API.SetIndexReadOnly(ctx, "i", false); err != nil {
+		t.Fatal(err)
+	} else if _, err := c[0].API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: "Set(2, f=1)"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c[0].API.SetIndexReadOnly(ctx, "nope", true); err == nil {
+		t.Fatal("expected error")
+	} else if _, ok := errors.Cause(err).(pilosa.NotFoundError); !ok {
+		t.Fatalf("unexpected error type: %T", errors.Cause(err))
+	}
+}
+
+// Ensure the max shards and load gossiped by other nodes are reported.
+func TestAPI_RemoteNodeStatus(t *testing.T) {
+	c := test.MustRunCluster(t, 2)
+	defer c.Close()
+	ctx := context.Background()
+
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+	c.Query(t, "i", fmt.Sprintf("Set(%d, f=1)", 2*pilosa.ShardWidth))
+
+	// Report a later shard from the first node, as its gossiped status would.
+	node0, node1 := c[0].API.Node().ID, c[1].API.Node().ID
+	load := pilosa.NodeLoad{Time: time.Unix(0, 1), MemoryUsed: 100, QueriesPerSecond: 2.5}
+	buf, err := pilosa.MarshalInternalMessage(&pilosa.NodeStatus{
+		Node:   c[0].API.Node(),
+		Schema: &pilosa.Schema{Indexes: c[0].API.Schema(ctx)},
+		Indexes: []*pilosa.IndexStatus{{
+			Name:   "i",
+			Fields: []*pilosa.FieldStatus{{Name: "f", AvailableShards: roaring.NewBitmap(2, 5)}},
+		}},
+		Load: &load,
+	}, c[1].API.Serializer)
+	if err != nil {
+		t.Fatal(err)
+	} else if err := c[1].API.ClusterMessage(ctx, bytes.NewReader(buf)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Remote statuses are merged asynchronously.
+	var m map[string]map[string]uint64
+	for i := 0; i < 100; i++ {
+		if m = c[1].API.MaxShardsByNode(ctx); m[node0]["i"] == 5 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if m[node0]["i"] != 5 {
+		t.Fatalf("unexpected max shards: %v", m)
+	} else if m[node1]["i"] != 5 {
+		t.Fatalf("expected reported shards to be merged: %v", m)
+	}
+
+	if loads := c[1].API.ClusterLoad(); !reflect.DeepEqual(loads[node0], load) {
+		t.Fatalf("unexpected load: %+v", loads[node0])
+	} else if _, ok := loads[node1]; !ok {
+		t.Fatalf("expected local load: %+v", loads)
+	}
+}
+
+// Ensure a node which missed the broadcast of the aliases takes them from
+// the gossiped status of another node, unless its own are later.
+func TestAPI_RemoteNodeStatusAliases(t *testing.T) {
+	c := test.MustRunCluster(t, 2)
+	defer c.Close()
+	ctx := context.Background()
+
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+	sendStatus := func(aliases pilosa.Aliases) {
+		buf, err := pilosa.MarshalInternalMessage(&pilosa.NodeStatus{
+			Node:    c[0].API.Node(),
+			Schema:  &pilosa.Schema{Indexes: c[0].API.Schema(ctx)},
+			Aliases: &aliases,
+		}, c[1].API.Serializer)
+		if err != nil {
+			t.Fatal(err)
+		} else if err := c[1].API.ClusterMessage(ctx, bytes.NewReader(buf)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sendStatus(pilosa.Aliases{Version: 2, Indexes: map[string]string{"a": "i"}})
+	if err := test.RetryUntil(time.Second, func() error {
+		if aliases, err := c[1].API.Aliases(ctx); err != nil {
+			return err
+		} else if aliases.Version != 2 || aliases.Indexes["a"] != "i" {
+			return errors.Errorf("unexpected aliases: %+v", aliases)
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	sendStatus(pilosa.Aliases{Version: 1, Fields: map[string]map[string]string{"i": {"g": "f"}}})
+	time.Sleep(50 * time.Millisecond)
+	if aliases, err := c[1].API.Aliases(ctx); err != nil {
+		t.Fatal(err)
+	} else if aliases.Version != 2 || len(aliases.Fields) != 0 {
+		t.Fatalf("expected earlier aliases to be ignored: %+v", aliases)
+	}
+}
+
+// Ensure nodes judge each other from the heartbeats they exchange.
+func TestAPI_NodeHealth(t *testing.T) {
+	c := test.MustRunCluster(t, 2, []server.CommandOption{
+		server.OptCommandServerOptions(pilosa.OptServerHeartbeat(10*time.Millisecond, 8, 16)),
+	})
+	defer c.Close()
+
+	node1 := c[1].API.Node().ID
+	if err := test.RetryUntil(5*time.Second, func() error {
+		if state := c[0].API.NodeHealth()[node1]; state != "READY" {
+			return errors.Errorf("unexpected state: %q", state)
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAPI_SchemaDiff(t *testing.T) {
+	c := test.MustRunCluster(t, 2)
+	defer c.Close()
+	ctx := context.Background()
+
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+	remote := c[1].API.Node()
+	if diff, err := c[0].API.SchemaDiff(ctx, remote.ID); err != nil {
+		t.Fatal(err)
+	} else if diff.Remote != remote.ID || len(diff.Differences) != 0 {
+		t.Fatalf("unexpected diff: %+v", diff)
+	}
+
+	// Diverge the schema of one node.
+	if _, err := c[1].Server.Holder().Index("i").CreateField("g"); err != nil {
+		t.Fatal(err)
+	}
+	exp := []pilosa.SchemaDifference{{Type: pilosa.SchemaDiffExtra, Index: "i", Field: "g"}}
+	if diff, err := c[0].API.SchemaDiff(ctx, remote.URI.HostPort()); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(diff.Differences, exp) {
+		t.Fatalf("unexpected differences: %+v", diff.Differences)
+	}
+
+	if _, err := c[0].API.SchemaDiff(ctx, "localhost:1"); err == nil {
+		t.Fatal("expected error")
+	} else if _, ok := errors.Cause(err).(pilosa.BadRequestError); !ok {
+		t.Fatalf("unexpected error type: %T", errors.Cause(err))
+	}
+}
+
+func TestAPI_UpdateSettings(t *testing.T) {
+	c := test.MustRunCluster(t, 2)
+	defer c.Close()
+
+	n, cacheType := 1, pilosa.CacheTypeLRU
+	settings, err := c[0].API.UpdateSettings(context.Background(), pilosa.SettingsUpdate{MaxWritesPerRequest: &n, DefaultCacheType: &cacheType})
+	if err != nil {
+		t.Fatal(err)
+	} else if settings.MaxWritesPerRequest != 1 || settings.DefaultCacheType != pilosa.CacheTypeLRU {
+		t.Fatalf("unexpected settings: %+v", settings)
+	}
+
+	// Settings apply on every node.
+	if got, err := c[1].API.Settings(context.Background()); err != nil {
+		t.Fatal(err)
+	} else if got != settings {
+		t.Fatalf("unexpected settings on node 1: %+v", got)
+	}
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+	if _, err := c[1].API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: "Set(1, f=1) Set(2, f=1)"}); errors.Cause(err) != pilosa.ErrTooManyWrites {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	invalid := "bogus"
+	if _, err := c[0].API.UpdateSettings(context.Background(), pilosa.SettingsUpdate{DefaultCacheType: &invalid}); err == nil {
+		t.Fatal("expected error")
+	} else if _, ok := errors.Cause(err).(pilosa.BadRequestError); !ok {
+		t.Fatalf("unexpected error type: %T", errors.Cause(err))
+	}
+}
+
+func TestAPI_UpdateAliases(t *testing.T) {
+	c := test.MustRunCluster(t, 2)
+	defer c.Close()
+
+	c.CreateField(t, "events_201910", pilosa.IndexOptions{}, "f")
+	c.CreateField(t, "events_201911", pilosa.IndexOptions{}, "f")
+	c.Query(t, "events_201910", "Set(1, f=1)")
+	c.Query(t, "events_201911", "Set(1, f=1) Set(2, f=1)")
+
+	count := func(n int) uint64 {
+		t.Helper()
+		resp, err := c[n].API.Query(context.Background(), &pilosa.QueryRequest{Index: "events_current", Query: "Count(Row(f=1))"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.Results[0].(uint64)
+	}
+
+	if _, err := c[0].API.UpdateAliases(context.Background(), pilosa.AliasesUpdate{Indexes: map[string]string{"events_current": "events_201910"}}); err != nil {
+		t.Fatal(err)
+	} else if n := count(1); n != 1 {
+		t.Fatalf("unexpected count: %d", n)
+	}
+
+	// Moving the alias applies on every node.
+	aliases, err := c[1].API.UpdateAliases(context.Background(), pilosa.AliasesUpdate{Indexes: map[string]string{"events_current": "events_201911"}})
+	if err != nil {
+		t.Fatal(err)
+	} else if got, err := c[0].API.Aliases(context.Background()); err != nil {
+		t.Fatal(err)
+	} else if got.Version != aliases.Version {
+		t.Fatalf("unexpected aliases on node 0: %+v", got)
+	} else if n := count(0); n != 2 {
+		t.Fatalf("unexpected count: %d", n)
+	}
+
+	if _, err := c[0].API.UpdateAliases(context.Background(), pilosa.AliasesUpdate{Indexes: map[string]string{"events_current": "bogus"}}); err == nil {
+		t.Fatal("expected error")
+	} else if _, ok := errors.Cause(err).(pilosa.BadRequestError); !ok {
+		t.Fatalf("unexpected error type: %T", errors.Cause(err))
+	}
+}
+
+func TestAPI_DeleteRows(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+	m := c[0]
+
+	ctx := context.Background()
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+	var bits [][2]uint64
+	for row := uint64(0); row < 5; row++ {
+		for shard := uint64(0); shard < 4; shard++ {
+			bits = append(bits, [2]uint64{row, shard*pilosa.ShardWidth + row})
+		}
+	}
+	c.ImportBits(t, "i", "f", bits)
+
+	if changed, err := m.API.DeleteRows(ctx, "i", "f", 0, 0); err != nil {
+		t.Fatal(err)
+	} else if !changed {
+		t.Fatal("expected row 0 to change")
+	}
+	if changed, err := m.API.DeleteRows(ctx, "i", "f", 2, 3); err != nil {
+		t.Fatal(err)
+	} else if !changed {
+		t.Fatal("expected rows 2-3 to change")
+	}
+	if changed, err := m.API.DeleteRows(ctx, "i", "f", 2, 3); err != nil {
+		t.Fatal(err)
+	} else if changed {
+		t.Fatal("expected rows 2-3 to be unchanged")
+	}
+
+	// Every node sees the rows removed from every shard.
+	for _, node := range c {
+		resp, err := node.API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: "Rows(f)"})
+		if err != nil {
+			t.Fatal(err)
+		} else if rows := resp.Results[0].(pilosa.RowIdentifiers).Rows; !reflect.DeepEqual(rows, []uint64{1, 4}) {
+			t.Fatalf("unexpected rows: %v", rows)
+		}
+	}
+
+	if _, err := m.API.DeleteRows(ctx, "i", "f", 3, 2); err == nil {
+		t.Fatal("expected error")
+	} else if _, ok := errors.Cause(err).(pilosa.BadRequestError); !ok {
+		t.Fatalf("unexpected error type: %T", errors.Cause(err))
+	}
+	if _, err := m.API.DeleteRows(ctx, "i", "nope", 0, 0); err == nil {
+		t.Fatal("expected error")
+	} else if _, ok := errors.Cause(err).(pilosa.NotFoundError); !ok {
+		t.Fatalf("unexpected error type: %T", errors.Cause(err))
+	}
+}
+
+func TestAPI_TruncateField(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+
+	ctx := context.Background()
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "f", pilosa.OptFieldTypeTime("YMD"))
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "g")
+	c.Query(t, "i", fmt.Sprintf(`
+		Set(1, f=1, 2019-01-02T00:00)
+		Set(%d, f=2, 2019-01-02T00:00)
+		Set(%d, f=3, 2019-01-02T00:00)
+		Set(1, g=1)`, pilosa.ShardWidth+1, 2*pilosa.ShardWidth+1))
+
+	if err := c[1].API.TruncateField(ctx, "i", "f"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Every node has removed the field's views, and nothing else.
+	for _, node := range c {
+		field := node.Server.Holder().Field("i", "f")
+		if field == nil {
+			t.Fatal("expected field to exist")
+		}
+		if fis, err := ioutil.ReadDir(filepath.Join(field.Path(), "views")); err != nil {
+			t.Fatal(err)
+		} else if len(fis) != 0 {
+			t.Fatalf("unexpected views: %d", len(fis))
+		}
+	}
+	if resp := c.Query(t, "i", "Rows(f)"); !reflect.DeepEqual(resp.Results[0].(pilosa.RowIdentifiers).Rows, []uint64{}) {
+		t.Fatalf("unexpected rows: %+v", resp.Results[0])
+	} else if resp := c.Query(t, "i", "Count(Row(g=1))"); resp.Results[0] != uint64(1) {
+		t.Fatalf("unexpected count: %v", resp.Results[0])
+	}
+
+	// The field can be written again.
+	c.Query(t, "i", "Set(2, f=1, 2019-01-03T00:00)")
+	if resp := c.Query(t, "i", "Row(f=1, from=2019-01-01T00:00, to=2019-02-01T00:00)"); !reflect.DeepEqual(resp.Results[0].(*pilosa.Row).Columns(), []uint64{2}) {
+		t.Fatalf("unexpected columns: %v", resp.Results[0].(*pilosa.Row).Columns())
+	}
+
+	if err := c[0].API.TruncateField(ctx, "i", "nope"); err == nil {
+		t.Fatal("expected error")
+	} else if _, ok := errors.Cause(err).(pilosa.NotFoundError); !ok {
+		t.Fatalf("unexpected error type: %T", errors.Cause(err))
+	}
+}
+
+func TestAPI_ClusterTopology(t *testing.T) {
+	c := test.MustRunCluster(t, 3, []server.CommandOption{
+		server.OptCommandServerOptions(pilosa.OptServerReplicaN(2)),
+	})
+	defer c.Close()
+
+	ctx := context.Background()
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+	c.CreateField(t, "j", pilosa.IndexOptions{}, "f")
+	c.ImportBits(t, "i", "f", [][2]uint64{{1, 1}, {1, 2 * pilosa.ShardWidth}, {1, 5*pilosa.ShardWidth + 3}})
+
+	topology, err := c[1].API.ClusterTopology(ctx, "")
+	if err != nil {
+		t.Fatal(err)
+	} else if topology.ShardWidth != pilosa.ShardWidth || topology.ReplicaN != 2 || len(topology.Nodes) != 3 {
+		t.Fatalf("unexpected topology: %+v", topology)
+	} else if len(topology.Indexes) != 2 || topology.Indexes[0].Name != "i" || topology.Indexes[1].Name != "j" {
+		t.Fatalf("unexpected indexes: %+v", topology.Indexes)
+	} else if len(topology.Indexes[1].Shards) != 0 {
+		t.Fatalf("unexpected shards of empty index: %+v", topology.Indexes[1].Shards)
+	}
+
+	// The owners of each shard are its replicas, primary first.
+	shards := topology.Indexes[0].Shards
+	if len(shards) != 3 || shards[0].Shard != 0 || shards[1].Shard != 2 || shards[2].Shard != 5 {
+		t.Fatalf("unexpected shards: %+v", shards)
+	}
+	for _, owners := range shards {
+		nodes, err := c[0].API.ShardNodes(ctx, "i", owners.Shard)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ids []string
+		for _, node := range nodes {
+			ids = append(ids, node.ID)
+		}
+		if !reflect.DeepEqual(owners.Nodes, ids) {
+			t.Fatalf("shard %d: unexpected owners %v, expected %v", owners.Shard, owners.Nodes, ids)
+		}
+	}
+
+	if topology, err := c[0].API.ClusterTopology(ctx, "j"); err != nil {
+		t.Fatal(err)
+	} else if len(topology.Indexes) != 1 || topology.Indexes[0].Name != "j" {
+		t.Fatalf("unexpected indexes: %+v", topology.Indexes)
+	}
+	if _, err := c[0].API.ClusterTopology(ctx, "nosuch"); errors.Cause(err) != pilosa.ErrIndexNotFound {
+		t.Fatalf("expected index not found, got %v", err)
+	}
+}
+
+func TestAPI_ShardDistribution(t *testing.T) {
+	c := test.MustRunCluster(t, 3, []server.CommandOption{
+		server.OptCommandServerOptions(pilosa.OptServerReplicaN(2)),
+	})
+	defer c.Close()
+
+	ctx := context.Background()
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+	c.CreateField(t, "j", pilosa.IndexOptions{}, "f")
+	c.ImportBits(t, "i", "f", [][2]uint64{{1, 1}, {1, 2 * pilosa.ShardWidth}, {1, 5*pilosa.ShardWidth + 3}})
+
+	dist, err := c[1].API.ShardDistribution(ctx, "")
+	if err != nil {
+		t.Fatal(err)
+	} else if dist.ReplicaN != 2 || len(dist.Nodes) != 3 || len(dist.Indexes) != 2 {
+		t.Fatalf("unexpected distribution: %+v", dist)
+	} else if len(dist.Indexes["j"].Shards) != 0 {
+		t.Fatalf("unexpected shards of empty index: %+v", dist.Indexes["j"].Shards)
+	}
+
+	// Every replica is READY, and counted once for its node.
+	var primaries, replicas int
+	for id, ns := range dist.Nodes {
+		if ns.Health != "READY" {
+			t.Fatalf("node %s: unexpected health %s", id, ns.Health)
+		}
+		primaries += ns.Primaries
+		replicas += ns.Replicas
+	}
+	if primaries != 3 || replicas != 3 {
+		t.Fatalf("unexpected counts: primaries=%d, replicas=%d", primaries, replicas)
+	}
+
+	idx := dist.Indexes["i"]
+	if len(idx.Shards) != 3 || len(idx.UnderReplicated) != 0 {
+		t.Fatalf("unexpected shards: %+v", idx)
+	}
+	for _, shard := range []uint64{0, 2, 5} {
+		nodes, err := c[0].API.ShardNodes(ctx, "i", shard)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var exp []pilosa.ShardReplica
+		for _, node := range nodes {
+			exp = append(exp, pilosa.ShardReplica{Node: node.ID, Health: "READY"})
+		}
+		if !reflect.DeepEqual(idx.Shards[shard], exp) {
+			t.Fatalf("shard %d: unexpected replicas %v, expected %v", shard, idx.Shards[shard], exp)
+		}
+	}
+
+	if _, err := c[0].API.ShardDistribution(ctx, "nosuch"); errors.Cause(err) != pilosa.ErrIndexNotFound {
+		t.Fatalf("expected index not found, got %v", err)
+	}
+}
+
+func TestAPI_FieldHistogram(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+
+	ctx := context.Background()
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "g", pilosa.OptFieldTypeSet(pilosa.CacheTypeNone, 0))
+	for _, field := range []string{"f", "g"} {
+		c.Query(t, "i", fmt.Sprintf(`
+			Set(1, %[1]s=1)
+			Set(1, %[1]s=2) Set(%[2]d, %[1]s=2) Set(%[3]d, %[1]s=2)
+			Set(1, %[1]s=3) Set(2, %[1]s=3) Set(3, %[1]s=3) Set(%[2]d, %[1]s=3) Set(%[3]d, %[1]s=3) Set(%[4]d, %[1]s=3)`,
+			field, pilosa.ShardWidth+1, 2*pilosa.ShardWidth+1, 2*pilosa.ShardWidth+2))
+	}
+
+	// The counts of every row of a ranked field are read from its caches.
+	if h, err := c[1].API.FieldHistogram(ctx, "i", "f", pilosa.FieldHistogramOptions{Top: 2}); err != nil {
+		t.Fatal(err)
+	} else if h.Rows != 3 || h.Cached != 3 || h.Scanned != 0 {
+		t.Fatalf("unexpected histogram: %+v", h)
+	} else if exp := []pilosa.HistogramBucket{{Min: 1, Max: 1, Rows: 1}, {Min: 2, Max: 3, Rows: 1}, {Min: 4, Max: 7, Rows: 1}}; !reflect.DeepEqual(h.Buckets, exp) {
+		t.Fatalf("unexpected buckets: %+v", h.Buckets)
+	} else if exp := []pilosa.Pair{{ID: 3, Count: 6}, {ID: 2, Count: 3}}; !reflect.DeepEqual(h.Heaviest, exp) {
+		t.Fatalf("unexpected heaviest rows: %+v", h.Heaviest)
+	}
+
+	// The rows of a field without a cache are sampled, and each sampled row
+	// stands for a share of the rows.
+	if h, err := c[0].API.FieldHistogram(ctx, "i", "g", pilosa.FieldHistogramOptions{Sample: 2}); err != nil {
+		t.Fatal(err)
+	} else if h.Rows != 3 || h.Cached != 0 || h.Scanned != 2 {
+		t.Fatalf("unexpected histogram: %+v", h)
+	} else if exp := []pilosa.HistogramBucket{{Min: 1, Max: 1, Rows: 2}, {Min: 2, Max: 3, Rows: 2}}; !reflect.DeepEqual(h.Buckets, exp) {
+		t.Fatalf("unexpected buckets: %+v", h.Buckets)
+	} else if exp := []pilosa.Pair{{ID: 2, Count: 3}, {ID: 1, Count: 1}}; !reflect.DeepEqual(h.Heaviest, exp) {
+		t.Fatalf("unexpected heaviest rows: %+v", h.Heaviest)
+	}
+
+	if _, err := c[0].API.FieldHistogram(ctx, "i", "nope", pilosa.FieldHistogramOptions{}); err == nil {
+		t.Fatal("expected error")
+	} else if _, ok := errors.Cause(err).(pilosa.NotFoundError); !ok {
+		t.Fatalf("unexpected error type: %T", errors.Cause(err))
+	}
+}
+
+func TestAPI_UDF(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+	m0, m1 := c[0], c[1]
+
+	ctx := context.Background()
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+	c.ImportBits(t, "i", "f", [][2]uint64{
+		{1, 1}, {1, 2}, {1, 3}, {1, pilosa.ShardWidth}, {1, pilosa.ShardWidth*2 + 1},
+	})
+
+	for _, name := range []string{"evens", "colsum"} {
+		code, err := ioutil.ReadFile(filepath.Join("wasm", "testdata", name+".wasm"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := m0.API.LoadUDF(ctx, strings.Title(name), code); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if names := m1.API.UDFs(ctx); !reflect.DeepEqual(names, []string{"Colsum", "Evens"}) {
+		t.Fatalf("unexpected udfs: %v", names)
+	}
+
+	// Filter modules can be used as rows.
+	if res := c.Query(t, "i", "Count(Evens(Row(f=1)))").Results[0]; res != uint64(2) {
+		t.Fatalf("unexpected count: %v", res)
+	}
+
+	// Map/reduce modules are evaluated across shards on every node.
+	exp := pilosa.ValCount{Val: 1 + 2 + 3 + pilosa.ShardWidth + pilosa.ShardWidth*2 + 1, Count: 5}
+	resp, err := m1.API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: "Colsum(Row(f=1))"})
+	if err != nil {
+		t.Fatal(err)
+	} else if resp.Results[0] != exp {
+		t.Fatalf("unexpected sum: %v", resp.Results[0])
+	}
+
+	// Without a row argument, modules read every column of the index.
+	c.CreateField(t, "j", pilosa.IndexOptions{TrackExistence: true}, "f")
+	c.Query(t, "j", fmt.Sprintf("Set(1, f=1) Set(2, f=2) Set(%d, f=1)", pilosa.ShardWidth+4))
+	if res := c.Query(t, "j", "Count(Evens())").Results[0]; res != uint64(2) {
+		t.Fatalf("unexpected count: %v", res)
+	}
+	exp = pilosa.ValCount{Val: 1 + 2 + pilosa.ShardWidth + 4, Count: 3}
+	if res := c.Query(t, "j", "Colsum()").Results[0]; res != exp {
+		t.Fatalf("unexpected sum: %v", res)
+	}
+	if _, err := m0.API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: "Colsum()"}); err == nil {
+		t.Fatal("expected error without existence tracking")
+	}
+
+	if err := m1.API.DeleteUDF(ctx, "Evens"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m0.API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: "Count(Evens(Row(f=1)))"}); err == nil {
+		t.Fatal("expected error after delete")
+	}
+	if names := m0.API.UDFs(ctx); !reflect.DeepEqual(names, []string{"Colsum"}) {
+		t.Fatalf("unexpected udfs: %v", names)
+	}
+
+	// Invalid modules are rejected.
+	if err := m0.API.LoadUDF(ctx, "Bad", []byte("not wasm")); err == nil {
+		t.Fatal("expected error")
+	} else if _, ok := errors.Cause(err).(pilosa.BadRequestError); !ok {
+		t.Fatalf("unexpected error type: %#v", err)
+	}
+}
+
+// Ensure a module which can't be registered isn't stored, so it doesn't
+// fail to load when the server opens.
+func TestAPI_LoadUDF_Conflict(t *testing.T) {
+	def := pilosa.CallDefinition{
+		Map: func(ctx context.Context, shard *pilosa.CallShard, c *pql.Call) (interface{}, error) {
+			return nil, nil
+		},
+		Reduce: func(prev, v interface{}) (interface{}, error) {
+			return v, nil
+		},
+	}
+	c := test.MustRunCluster(t, 1, []server.CommandOption{
+		server.OptCommandServerOptions(pilosa.OptServerCall("Evens", def)),
+	})
+	defer c.Close()
+	m0 := c[0]
+
+	code, err := ioutil.ReadFile(filepath.Join("wasm", "testdata", "evens.wasm"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m0.API.LoadUDF(context.Background(), "Evens", code); err == nil {
+		t.Fatal("expected error")
+	} else if _, ok := errors.Cause(err).(pilosa.ConflictError); !ok {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+	if _, err := os.Stat(filepath.Join(m0.Config.DataDir, ".udf", "Evens.wasm")); !os.IsNotExist(err) {
+		t.Fatalf("expected no stored module, got %v", err)
+	}
+}
+
+// eventRecorder is an EventHandler which records all events it receives.
+type eventRecorder struct {
+	mu     sync.Mutex
+	events []pilosa.Event
+}
+
+func (r *eventRecorder) HandleEvent(ev pilosa.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, ev)
+}
+
+func (r *eventRecorder) Events() []pilosa.Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]pilosa.Event(nil), r.events...)
+}
+
 // offsetModHasher represents a simple, mod-based hashing offset by 1.
 type offsetModHasher struct{}
 
 func (*offsetModHasher) Hash(key uint64, n int) int {
 	return int(key+1) % n
 }
+
+func TestAPI_ValidateQuery(t *testing.T) {
+	c := test.MustRunCluster(t, 1)
+	defer c.Close()
+	m0 := c[0]
+
+	iopts := pilosa.IndexOptions{TrackExistence: true}
+	c.CreateField(t, "i", iopts, "f")
+	c.CreateField(t, "i", iopts, "n", pilosa.OptFieldTypeInt(0, 100))
+	c.CreateField(t, "i", iopts, "b", pilosa.OptFieldTypeBool())
+	c.CreateField(t, "i", iopts, "k", pilosa.OptFieldKeys())
+	c.ImportBits(t, "i", "f", [][2]uint64{{1, 1}})
+
+	type diag struct {
+		severity string
+		path     []int
+		code     string
+		field    string
+	}
+	for _, tt := range []struct {
+		query string
+		diags []diag
+	}{
+		{query: `Count(Intersect(Row(f=1), Row(n > 5), Row(b=true), Row(k="a")))`},
+		{query: `Not(Row(f=1)) GroupBy(Rows(f), limit=10)`},
+		{query: `Row(nosuch=1)`, diags: []diag{{"error", []int{0}, "FieldNotFound", "nosuch"}}},
+		{query: `Row(f=1) Union(Row(f=1), Row(n > 200))`, diags: []diag{{"warning", []int{1, 1}, "BadRequest", ""}}},
+		{query: `Row(b=1)`, diags: []diag{{"error", []int{0}, "BadRequest", ""}}},
+		{query: `Row(k=1)`, diags: []diag{{"error", []int{0}, "BadRequest", ""}}},
+		{query: `Row(f < 10)`, diags: []diag{{"error", []int{0}, "BSIGroupNotFound", ""}}},
+		{query: `Row(f=1, from="2010-01-01T00:00", to="2011-01-01T00:00")`, diags: []diag{{"warning", []int{0}, "BadRequest", ""}}},
+		{query: `Set(1, n=500)`, diags: []diag{{"error", []int{0}, "BSIGroupValueTooHigh", ""}}},
+		{query: `TopN(n) Count(Foo())`, diags: []diag{{"error", []int{0}, "BadRequest", ""}, {"error", []int{1, 0}, "BadRequest", ""}}},
+		{query: `Index(Row(f=1), name=nosuch)`, diags: []diag{{"error", []int{0}, "IndexNotFound", ""}}},
+		{query: `Row(f=1`, diags: []diag{{"error", []int{}, "BadRequest", ""}}},
+	} {
+		t.Run(tt.query, func(t *testing.T) {
+			diags, err := m0.API.ValidateQuery(context.Background(), &pilosa.QueryRequest{Index: "i", Query: tt.query})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := make([]diag, len(diags))
+			for i, d := range diags {
+				got[i] = diag{d.Severity, d.Path, d.Code, d.Field}
+			}
+			if len(got) != len(tt.diags) || (len(got) > 0 && !reflect.DeepEqual(got, tt.diags)) {
+				t.Fatalf("unexpected diagnostics: %+v", diags)
+			}
+		})
+	}
+
+	if _, err := m0.API.ValidateQuery(context.Background(), &pilosa.QueryRequest{Index: "nosuch", Query: "Row(f=1)"}); errors.Cause(err) != pilosa.ErrIndexNotFound {
+		t.Fatalf("expected index not found, got %v", err)
+	}
+
+	resp, err := gohttp.Post(m0.URL()+"/index/i/query/validate", "text/plain", strings.NewReader("Row(nosuch=1)"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var body struct {
+		Valid       bool                     `json:"valid"`
+		Diagnostics []pilosa.QueryDiagnostic `json:"diagnostics"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	} else if resp.StatusCode != gohttp.StatusOK || body.Valid || len(body.Diagnostics) != 1 {
+		t.Fatalf("unexpected response: %d %+v", resp.StatusCode, body)
+	} else if d := body.Diagnostics[0]; d.Code != "FieldNotFound" || d.Field != "nosuch" || d.Call != "Row(nosuch=1)" {
+		t.Fatalf("unexpected diagnostic: %+v", d)
+	}
+
+	// Nothing was written while validating.
+	if r, err := m0.API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: "Count(Row(n > 0))"}); err != nil {
+		t.Fatal(err)
+	} else if r.Results[0] != uint64(0) {
+		t.Fatalf("unexpected count: %v", r.Results[0])
+	}
+}
+
+func TestAPI_RewriteRules(t *testing.T) {
+	c := test.MustRunCluster(t, 2)
+	defer c.Close()
+	m0, m1 := c[0], c[1]
+	ctx := context.Background()
+
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+	c.Query(t, "i", "Set(1, f=1) Set(2, f=1) Set(2, f=2) Set(3, f=2)")
+
+	rule := pilosa.RewriteRule{Name: "vip", Match: "Segment(name=vip)", Replace: "Intersect(Row(f=1), Row(f=2))"}
+	if err := m0.API.CreateRewriteRule(ctx, "i", rule); err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range []*test.Command{m0, m1} {
+		resp := m.MustQuery(t, &pilosa.QueryRequest{Index: "i", Query: "Count(Segment(name=vip)) Union(Segment(name=vip), Row(f=1))"})
+		if resp.Results[0] != uint64(1) {
+			t.Fatalf("unexpected count: %v", resp.Results[0])
+		} else if got, exp := resp.Results[1].(*pilosa.Row).Columns(), []uint64{1, 2}; !reflect.DeepEqual(got, exp) {
+			t.Fatalf("unexpected columns: %v", got)
+		}
+	}
+
+	// Rules may not change the meaning of other calls, or match the same
+	// call as another rule.
+	if err := m0.API.CreateRewriteRule(ctx, "i", pilosa.RewriteRule{Name: "row", Match: "Row(f=1)", Replace: "Row(f=2)"}); err == nil {
+		t.Fatal("expected error for known call")
+	} else if err := m0.API.CreateRewriteRule(ctx, "i", pilosa.RewriteRule{Name: "other", Match: "Segment(name=vip)", Replace: "Row(f=2)"}); err == nil {
+		t.Fatal("expected conflict")
+	} else if _, ok := errors.Cause(err).(pilosa.ConflictError); !ok {
+		t.Fatalf("unexpected error: %v", err)
+	} else if err := m0.API.CreateRewriteRule(ctx, "i", pilosa.RewriteRule{Name: "w", Match: "W()", Replace: "Set(1, f=3)"}); err == nil {
+		t.Fatal("expected error for write call")
+	}
+
+	if rules, err := m1.API.RewriteRules(ctx, "i"); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(rules, []pilosa.RewriteRule{rule}) {
+		t.Fatalf("unexpected rules: %+v", rules)
+	}
+	if err := m1.API.DeleteRewriteRule(ctx, "i", "vip"); err != nil {
+		t.Fatal(err)
+	} else if rules, err := m0.API.RewriteRules(ctx, "i"); err != nil {
+		t.Fatal(err)
+	} else if len(rules) != 0 {
+		t.Fatalf("unexpected rules: %+v", rules)
+	}
+
+	// A node which missed the broadcast of a change to a rule applies it
+	// from the gossiped status of another node, if it is later than the
+	// node's own changes.
+	gossip := func(rules []*pilosa.CreateRewriteRuleMessage, deleted []*pilosa.DeleteRewriteRuleMessage) {
+		buf, err := pilosa.MarshalInternalMessage(&pilosa.NodeStatus{
+			Node:                m0.API.Node(),
+			Schema:              &pilosa.Schema{Indexes: m0.API.Schema(ctx)},
+			RewriteRules:        rules,
+			DeletedRewriteRules: deleted,
+		}, m1.API.Serializer)
+		if err != nil {
+			t.Fatal(err)
+		} else if err := m1.API.ClusterMessage(ctx, bytes.NewReader(buf)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expectRules := func(exp []pilosa.RewriteRule) {
+		t.Helper()
+		if err := test.RetryUntil(time.Second, func() error {
+			if rules, err := m1.API.RewriteRules(ctx, "i"); err != nil {
+				return err
+			} else if !reflect.DeepEqual(rules, exp) {
+				return errors.Errorf("unexpected rules: %+v", rules)
+			}
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The rule was deleted later than an unversioned definition of it.
+	gossip([]*pilosa.CreateRewriteRuleMessage{{Index: "i", Rule: rule}}, nil)
+	expectRules([]pilosa.RewriteRule{})
+
+	version := time.Now().UnixNano()
+	gossip([]*pilosa.CreateRewriteRuleMessage{{Index: "i", Rule: rule, Version: version}}, nil)
+	expectRules([]pilosa.RewriteRule{rule})
+
+	replaced := pilosa.RewriteRule{Name: "vip", Match: "Segment(name=vip)", Replace: "Row(f=1)"}
+	gossip([]*pilosa.CreateRewriteRuleMessage{{Index: "i", Rule: replaced, Version: version + 1}}, nil)
+	expectRules([]pilosa.RewriteRule{replaced})
+
+	gossip(nil, []*pilosa.DeleteRewriteRuleMessage{{Index: "i", Name: "vip", Version: version}})
+	expectRules([]pilosa.RewriteRule{replaced})
+	gossip(nil, []*pilosa.DeleteRewriteRuleMessage{{Index: "i", Name: "vip", Version: version + 2}})
+	expectRules([]pilosa.RewriteRule{})
+}
+
+func TestAPI_NamedQueries(t *testing.T) {
+	c := test.MustRunCluster(t, 2)
+	defer c.Close()
+	m0, m1 := c[0], c[1]
+	ctx := context.Background()
+
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+	c.Query(t, "i", "Set(1, f=1) Set(2, f=1) Set(2, f=2)")
+
+	q := pilosa.NamedQuery{Name: "count", Index: "i", Query: `Count(Row(f="$row"))`}
+	if err := m0.API.CreateNamedQuery(ctx, q); err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range []*test.Command{m0, m1} {
+		if resp, err := m.API.QueryNamed(ctx, "count", map[string]string{"row": "1"}); err != nil {
+			t.Fatal(err)
+		} else if resp.Results[0] != uint64(2) {
+			t.Fatalf("unexpected count: %v", resp.Results[0])
+		}
+	}
+
+	if _, err := m0.API.QueryNamed(ctx, "count", nil); err == nil {
+		t.Fatal("expected error for missing parameter")
+	} else if _, err := m0.API.QueryNamed(ctx, "nope", nil); errors.Cause(err) != pilosa.ErrNamedQueryNotFound {
+		t.Fatalf("unexpected error: %v", err)
+	} else if err := m0.API.CreateNamedQuery(ctx, pilosa.NamedQuery{Name: "w", Index: "i", Query: "Set(3, f=1)"}); err == nil {
+		t.Fatal("expected error for write call")
+	}
+
+	if queries, err := m1.API.NamedQueries(ctx); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(queries, []pilosa.NamedQuery{q}) {
+		t.Fatalf("unexpected queries: %+v", queries)
+	}
+	if err := m1.API.DeleteNamedQuery(ctx, "count"); err != nil {
+		t.Fatal(err)
+	} else if queries, err := m0.API.NamedQueries(ctx); err != nil {
+		t.Fatal(err)
+	} else if len(queries) != 0 {
+		t.Fatalf("unexpected queries: %+v", queries)
+	}
+
+	// A node which missed the broadcast of a change to a query applies it
+	// from the gossiped status of another node, if it is later than the
+	// node's own changes.
+	gossip := func(queries []*pilosa.CreateNamedQueryMessage, deleted []*pilosa.DeleteNamedQueryMessage) {
+		buf, err := pilosa.MarshalInternalMessage(&pilosa.NodeStatus{
+			Node:                m0.API.Node(),
+			Schema:              &pilosa.Schema{Indexes: m0.API.Schema(ctx)},
+			NamedQueries:        queries,
+			DeletedNamedQueries: deleted,
+		}, m1.API.Serializer)
+		if err != nil {
+			t.Fatal(err)
+		} else if err := m1.API.ClusterMessage(ctx, bytes.NewReader(buf)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expectQueries := func(exp []pilosa.NamedQuery) {
+		t.Helper()
+		if err := test.RetryUntil(time.Second, func() error {
+			if queries, err := m1.API.NamedQueries(ctx); err != nil {
+				return err
+			} else if !reflect.DeepEqual(queries, exp) {
+				return errors.Errorf("unexpected queries: %+v", queries)
+			}
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The query was deleted later than an unversioned definition of it.
+	gossip([]*pilosa.CreateNamedQueryMessage{{Query: q}}, nil)
+	expectQueries([]pilosa.NamedQuery{})
+
+	version := time.Now().UnixNano()
+	gossip([]*pilosa.CreateNamedQueryMessage{{Query: q, Version: version}}, nil)
+	expectQueries([]pilosa.NamedQuery{q})
+
+	replaced := pilosa.NamedQuery{Name: "count", Index: "i", Query: "Count(Row(f=2))"}
+	gossip([]*pilosa.CreateNamedQueryMessage{{Query: replaced, Version: version + 1}}, nil)
+	expectQueries([]pilosa.NamedQuery{replaced})
+
+	gossip(nil, []*pilosa.DeleteNamedQueryMessage{{Name: "count", Version: version}})
+	expectQueries([]pilosa.NamedQuery{replaced})
+	gossip(nil, []*pilosa.DeleteNamedQueryMessage{{Name: "count", Version: version + 2}})
+	expectQueries([]pilosa.NamedQuery{})
+}
+
+func TestAPI_Schedules(t *testing.T) {
+	c := test.MustRunCluster(t, 2)
+	defer c.Close()
+	m0, m1 := c[0], c[1]
+	ctx := context.Background()
+
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+	if err := m0.API.CreateNamedQuery(ctx, pilosa.NamedQuery{Name: "row", Index: "i", Query: `Row(f="$row")`}); err != nil {
+		t.Fatal(err)
+	}
+
+	sched := pilosa.Schedule{
+		Name:   "hourly",
+		Query:  "row",
+		Params: map[string]string{"row": "1"},
+		Cron:   "0 * * * *",
+		Sink:   pilosa.ScheduleSink{Type: pilosa.ScheduleSinkField, Field: "f", Row: 2},
+	}
+	if err := m0.API.CreateSchedule(ctx, sched); err != nil {
+		t.Fatal(err)
+	}
+	if status, err := m1.API.ScheduleStatus(ctx, "hourly"); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(status.Schedule, sched) {
+		t.Fatalf("unexpected schedule: %+v", status.Schedule)
+	} else if status.Next.IsZero() || status.Next.Minute() != 0 {
+		t.Fatalf("unexpected next time: %s", status.Next)
+	}
+
+	// Schedules must name a named query, and give its parameters.
+	if err := m0.API.CreateSchedule(ctx, pilosa.Schedule{Name: "x", Query: "nope", Cron: "* * * * *", Sink: sched.Sink}); errors.Cause(err) != pilosa.ErrNamedQueryNotFound {
+		t.Fatalf("unexpected error: %v", err)
+	} else if err := m0.API.CreateSchedule(ctx, pilosa.Schedule{Name: "x", Query: "row", Cron: "* * * * *", Sink: sched.Sink}); err == nil {
+		t.Fatal("expected error for missing parameter")
+	}
+
+	if err := m1.API.DeleteSchedule(ctx, "hourly"); err != nil {
+		t.Fatal(err)
+	} else if schedules, err := m0.API.Schedules(ctx); err != nil {
+		t.Fatal(err)
+	} else if len(schedules) != 0 {
+		t.Fatalf("unexpected schedules: %+v", schedules)
+	} else if _, err := m0.API.ScheduleStatus(ctx, "hourly"); errors.Cause(err) != pilosa.ErrScheduleNotFound {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestAPI_MaterializedViews(t *testing.T) {
+	c := test.MustRunCluster(t, 2)
+	defer c.Close()
+	m0, m1 := c[0], c[1]
+	ctx := context.Background()
+
+	iopts := pilosa.IndexOptions{TrackExistence: true}
+	c.CreateField(t, "i", iopts, "f")
+	c.CreateField(t, "i", iopts, "k", pilosa.OptFieldKeys())
+	c.ImportBits(t, "i", "f", [][2]uint64{
+		{1, 1}, {1, 3}, {1, pilosa.ShardWidth + 2},
+		{2, 3}, {2, pilosa.ShardWidth + 2},
+	})
+
+	columns := func(m *test.Command, query string) []uint64 {
+		t.Helper()
+		resp := m.MustQuery(t, &pilosa.QueryRequest{Index: "i", Query: query})
+		return resp.Results[0].(*pilosa.Row).Columns()
+	}
+
+	if err := m0.API.CreateMaterializedView(ctx, "i", "both", "Intersect(Row(f=1), Row(f=2))"); err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range []*test.Command{m0, m1} {
+		if got, exp := columns(m, "Materialized(name=both)"), []uint64{3, pilosa.ShardWidth + 2}; !reflect.DeepEqual(got, exp) {
+			t.Fatalf("unexpected columns: %v", got)
+		}
+	}
+
+	// The view follows changes to the fields it reads, in every shard.
+	c.Query(t, "i", fmt.Sprintf("Set(5, f=1) Set(5, f=2) Clear(%d, f=2)", pilosa.ShardWidth+2))
+	if got, exp := columns(m1, "Materialized(name=both)"), []uint64{3, 5}; !reflect.DeepEqual(got, exp) {
+		t.Fatalf("unexpected columns: %v", got)
+	}
+	if resp := c.Query(t, "i", "Count(Materialized(name=both))"); resp.Results[0] != uint64(2) {
+		t.Fatalf("unexpected count: %v", resp.Results[0])
+	}
+
+	// Keys are translated when the view is defined.
+	c.Query(t, "i", `Set(7, k="a")`)
+	if err := m1.API.CreateMaterializedView(ctx, "i", "ka", `Union(Row(k="a"), Materialized(name=both))`); err == nil {
+		t.Fatal("expected error for nested view")
+	} else if err := m1.API.CreateMaterializedView(ctx, "i", "ka", `Union(Row(k="a"), Row(f=2))`); err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := columns(m0, "Materialized(name=ka)"), []uint64{3, 5, 7}; !reflect.DeepEqual(got, exp) {
+		t.Fatalf("unexpected columns: %v", got)
+	}
+
+	if views, err := m1.API.MaterializedViews(ctx, "i"); err != nil {
+		t.Fatal(err)
+	} else if exp := []pilosa.MaterializedView{
+		{Name: "both", Query: "Intersect(Row(f=1), Row(f=2))"},
+		{Name: "ka", Query: `Union(Row(k="a"), Row(f=2))`},
+	}; !reflect.DeepEqual(views, exp) {
+		t.Fatalf("unexpected views: %+v", views)
+	}
+
+	for _, tt := range []struct {
+		name, query, code string
+	}{
+		{"both", "Row(f=1)", "MaterializedViewExists"},
+		{"count", "Count(Row(f=1))", "BadRequest"},
+		{"nosuch", "Row(nosuch=1)", "FieldNotFound"},
+		{"now", `Row(f=1, from="now-1d")`, "BadRequest"},
+		{"Bad", "Row(f=1)", "InvalidName"},
+	} {
+		err := m0.API.CreateMaterializedView(ctx, "i", tt.name, tt.query)
+		if err == nil {
+			t.Fatalf("expected error defining %s", tt.query)
+		} else if code := pilosa.NewResponseError(err).Code; code != tt.code {
+			t.Fatalf("unexpected error defining %s: %s %v", tt.query, code, err)
+		}
+	}
+
+	// Deleting a view removes it from every node.
+	if err := m0.API.DeleteMaterializedView(ctx, "i", "both"); err != nil {
+		t.Fatal(err)
+	} else if err := m0.API.DeleteMaterializedView(ctx, "i", "both"); errors.Cause(err) != pilosa.ErrMaterializedViewNotFound {
+		t.Fatalf("expected not found, got %v", err)
+	}
+	if _, err := m1.API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: "Materialized(name=both)"}); errors.Cause(err) != pilosa.ErrMaterializedViewNotFound {
+		t.Fatalf("expected not found, got %v", err)
+	}
+
+	// A node which missed the broadcast of a change to a view applies it
+	// from the gossiped status of another node, if it is later than the
+	// node's own changes.
+	gossip := func(views []*pilosa.CreateMaterializedViewMessage, deleted []*pilosa.DeleteMaterializedViewMessage) {
+		buf, err := pilosa.MarshalInternalMessage(&pilosa.NodeStatus{
+			Node:                     m0.API.Node(),
+			Schema:                   &pilosa.Schema{Indexes: m0.API.Schema(ctx)},
+			MaterializedViews:        views,
+			DeletedMaterializedViews: deleted,
+		}, m1.API.Serializer)
+		if err != nil {
+			t.Fatal(err)
+		} else if err := m1.API.ClusterMessage(ctx, bytes.NewReader(buf)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expectView := func(name, query string) {
+		t.Helper()
+		if err := test.RetryUntil(time.Second, func() error {
+			views, err := m1.API.MaterializedViews(ctx, "i")
+			if err != nil {
+				return err
+			}
+			var got string
+			for _, v := range views {
+				if v.Name == name {
+					got = v.Query
+				}
+			}
+			if got != query {
+				return errors.Errorf("unexpected query of view %s: %q", name, got)
+			}
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	gossip([]*pilosa.CreateMaterializedViewMessage{{Index: "i", Name: "one", Query: "Row(f=1)", Call: "Row(f=1)"}}, nil)
+	expectView("one", "Row(f=1)")
+
+	// The view was deleted later than an unversioned definition of it.
+	gossip([]*pilosa.CreateMaterializedViewMessage{{Index: "i", Name: "both", Query: "Row(f=1)", Call: "Row(f=1)"}}, nil)
+	expectView("both", "")
+
+	version := time.Now().UnixNano()
+	gossip([]*pilosa.CreateMaterializedViewMessage{{Index: "i", Name: "one", Query: "Row(f=2)", Call: "Row(f=2)", Version: version}}, nil)
+	expectView("one", "Row(f=2)")
+
+	gossip(nil, []*pilosa.DeleteMaterializedViewMessage{{Index: "i", Name: "one", Version: version}})
+	expectView("one", "Row(f=2)")
+	gossip(nil, []*pilosa.DeleteMaterializedViewMessage{{Index: "i", Name: "one", Version: version + 1}})
+	expectView("one", "")
+}
+
+func TestAPI_ExportAttrs(t *testing.T) {
+	c := test.MustRunCluster(t, 2)
+	defer c.Close()
+	m0, m1 := c[0], c[1]
+	ctx := context.Background()
+
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "k", pilosa.OptFieldKeys())
+	m0.MustQuery(t, &pilosa.QueryRequest{Index: "i", Query: `
+		SetColumnAttrs(2, name="two", n=2)
+		SetColumnAttrs(1, name="one")
+		SetRowAttrs(f, 3, active=true)
+		SetRowAttrs(k, "a", score=1.5)`})
+
+	// Attributes are replicated, so any node exports them all.
+	var buf bytes.Buffer
+	if err := m1.API.ExportAttrs(ctx, "i", pilosa.AttrExportJSON, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := buf.String(), `{"id":1,"attrs":{"name":"one"}}
+{"id":2,"attrs":{"n":2,"name":"two"}}
+{"field":"f","id":3,"attrs":{"active":true}}
+{"field":"k","id":1,"key":"a","attrs":{"score":1.5}}
+`; got != exp {
+		t.Fatalf("unexpected JSON export:\n%s", got)
+	}
+
+	resp, err := gohttp.Get(m1.URL() + "/index/i/attrs/export?format=csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	} else if resp.StatusCode != gohttp.StatusOK || resp.Header.Get("Content-Type") != "text/csv" {
+		t.Fatalf("unexpected response: %d %s", resp.StatusCode, body)
+	}
+	if got, exp := string(body), `,1,,name,one
+,2,,n,2
+,2,,name,two
+f,3,,active,true
+k,1,a,score,1.5
+`; got != exp {
+		t.Fatalf("unexpected CSV export:\n%s", got)
+	}
+
+	if err := m0.API.ExportAttrs(ctx, "i", "xml", &buf); errors.Cause(err) == nil {
+		t.Fatal("expected error for unknown format")
+	} else if _, ok := errors.Cause(err).(pilosa.BadRequestError); !ok {
+		t.Fatalf("expected bad request, got %v", err)
+	}
+	if err := m0.API.ExportAttrs(ctx, "nosuch", "", &buf); errors.Cause(err) != pilosa.ErrIndexNotFound {
+		t.Fatalf("expected index not found, got %v", err)
+	}
+}
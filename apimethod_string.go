@@ -33,11 +33,32 @@ func _() {
 	_ = x[apiShardNodes-22]
 	_ = x[apiViews-23]
 	_ = x[apiApplySchema-24]
+	_ = x[apiChanges-25]
+	_ = x[apiLoadUDF-26]
+	_ = x[apiDeleteUDF-27]
+	_ = x[apiReindexField-28]
+	_ = x[apiJobs-29]
+	_ = x[apiDeleteRows-30]
+	_ = x[apiTruncateField-31]
+	_ = x[apiUsage-32]
+	_ = x[apiSettings-33]
+	_ = x[apiSetIndexReadOnly-34]
+	_ = x[apiSchemaDiff-35]
+	_ = x[apiMaterializedViews-36]
+	_ = x[apiExportAttrs-37]
+	_ = x[apiAliases-38]
+	_ = x[apiRewriteRules-39]
+	_ = x[apiNamedQueries-40]
+	_ = x[apiSchedules-41]
+	_ = x[apiFieldHistogram-42]
+	_ = x[apiClusterTopology-43]
+	_ = x[apiRebalanceShards-44]
+	_ = x[apiShardDistribution-45]
 }
 
-const _apiMethod_name = "apiClusterMessageapiCreateFieldapiCreateIndexapiDeleteFieldapiDeleteAvailableShardapiDeleteIndexapiDeleteViewapiExportCSVapiFragmentBlockDataapiFragmentBlocksapiFragmentDataapiFieldapiFieldAttrDiffapiImportapiImportValueapiIndexapiIndexAttrDiffapiQueryapiRecalculateCachesapiRemoveNodeapiResizeAbortapiSetCoordinatorapiShardNodesapiViewsapiApplySchema"
+const _apiMethod_name = "apiClusterMessageapiCreateFieldapiCreateIndexapiDeleteFieldapiDeleteAvailableShardapiDeleteIndexapiDeleteViewapiExportCSVapiFragmentBlockDataapiFragmentBlocksapiFragmentDataapiFieldapiFieldAttrDiffapiImportapiImportValueapiIndexapiIndexAttrDiffapiQueryapiRecalculateCachesapiRemoveNodeapiResizeAbortapiSetCoordinatorapiShardNodesapiViewsapiApplySchemaapiChangesapiLoadUDFapiDeleteUDFapiReindexFieldapiJobsapiDeleteRowsapiTruncateFieldapiUsageapiSettingsapiSetIndexReadOnlyapiSchemaDiffapiMaterializedViewsapiExportAttrsapiAliasesapiRewriteRulesapiNamedQueriesapiSchedulesapiFieldHistogramapiClusterTopologyapiRebalanceShardsapiShardDistribution"
 
-var _apiMethod_index = [...]uint16{0, 17, 31, 45, 59, 82, 96, 109, 121, 141, 158, 173, 181, 197, 206, 220, 228, 244, 252, 272, 285, 299, 316, 329, 337, 351}
+var _apiMethod_index = [...]uint16{0, 17, 31, 45, 59, 82, 96, 109, 121, 141, 158, 173, 181, 197, 206, 220, 228, 244, 252, 272, 285, 299, 316, 329, 337, 351, 361, 371, 383, 398, 405, 418, 434, 442, 453, 472, 485, 505, 519, 529, 544, 559, 571, 588, 606, 624, 644}
 
 func (i apiMethod) String() string {
 	if i < 0 || i >= apiMethod(len(_apiMethod_index)-1) {
@@ -16,10 +16,16 @@ package pilosa
 
 import (
 	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash"
 	"sort"
+	"sync"
 
+	"github.com/cespare/xxhash"
 	"github.com/gogo/protobuf/proto"
 	"github.com/pilosa/pilosa/v2/internal"
+	"github.com/pkg/errors"
 )
 
 // Attribute data type enum.
@@ -76,6 +82,139 @@ func (s nopAttrStore) Blocks() ([]AttrBlock, error) { return nil, nil }
 // BlockData is a no-op implementation of AttrStore BlockData method.
 func (s nopAttrStore) BlockData(i uint64) (map[uint64]map[string]interface{}, error) { return nil, nil }
 
+// attrBlockSize is the number of IDs in each block of an in-memory attribute
+// store, which is the same as for stores on disk.
+const attrBlockSize = 100
+
+// memAttrStore is an AttrStore held in memory, used by in-memory indexes.
+type memAttrStore struct {
+	mu    sync.RWMutex
+	attrs map[uint64]map[string]interface{}
+}
+
+// newMemAttrStore returns an empty in-memory attribute store.
+func newMemAttrStore(string) AttrStore {
+	return &memAttrStore{attrs: make(map[uint64]map[string]interface{})}
+}
+
+// Path returns an empty string, as the store has no data file.
+func (s *memAttrStore) Path() string { return "" }
+
+// Open does nothing.
+func (s *memAttrStore) Open() error { return nil }
+
+// Close does nothing. The attributes are kept until the store is dropped.
+func (s *memAttrStore) Close() error { return nil }
+
+// Attrs returns the attributes of id.
+func (s *memAttrStore) Attrs(id uint64) (map[string]interface{}, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if m := s.attrs[id]; m != nil {
+		return cloneAttrs(m), nil
+	}
+	return nil, nil
+}
+
+// SetAttrs merges m into the attributes of id. Nil values remove attributes.
+func (s *memAttrStore) SetAttrs(id uint64, m map[string]interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.unprotectedSetAttrs(id, m)
+}
+
+// SetBulkAttrs merges the attributes of a set of IDs.
+func (s *memAttrStore) SetBulkAttrs(m map[uint64]map[string]interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, attrs := range m {
+		if err := s.unprotectedSetAttrs(id, attrs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *memAttrStore) unprotectedSetAttrs(id uint64, m map[string]interface{}) error {
+	attr := cloneAttrs(s.attrs[id])
+	for k, v := range m {
+		switch v := v.(type) {
+		case nil:
+			delete(attr, k)
+		case int:
+			attr[k] = int64(v)
+		case uint:
+			attr[k] = int64(v)
+		case uint64:
+			attr[k] = int64(v)
+		case string, int64, bool, float64:
+			attr[k] = v
+		default:
+			return fmt.Errorf("invalid attr type: %T", v)
+		}
+	}
+	if len(attr) == 0 {
+		delete(s.attrs, id)
+	} else {
+		s.attrs[id] = attr
+	}
+	return nil
+}
+
+// sortedIDs returns the IDs with attributes, in order.
+func (s *memAttrStore) sortedIDs() []uint64 {
+	ids := make([]uint64, 0, len(s.attrs))
+	for id := range s.attrs {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// Blocks returns the checksum of each block of IDs with attributes, computed
+// as by stores on disk.
+func (s *memAttrStore) Blocks() ([]AttrBlock, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var blocks []AttrBlock
+	var h hash.Hash64
+	key := make([]byte, 8)
+	for _, id := range s.sortedIDs() {
+		if n := len(blocks); n == 0 || blocks[n-1].ID != id/attrBlockSize {
+			if n > 0 {
+				blocks[n-1].Checksum = h.Sum(nil)
+			}
+			blocks = append(blocks, AttrBlock{ID: id / attrBlockSize})
+			h = xxhash.New()
+		}
+		buf, err := EncodeAttrs(s.attrs[id])
+		if err != nil {
+			return nil, errors.Wrap(err, "encoding attrs")
+		}
+		binary.BigEndian.PutUint64(key, id)
+		_, _ = h.Write(key)
+		_, _ = h.Write(buf)
+	}
+	if n := len(blocks); n > 0 {
+		blocks[n-1].Checksum = h.Sum(nil)
+	}
+	return blocks, nil
+}
+
+// BlockData returns the attributes of the IDs in block i.
+func (s *memAttrStore) BlockData(i uint64) (map[uint64]map[string]interface{}, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	m := make(map[uint64]map[string]interface{})
+	for id, attrs := range s.attrs {
+		if id/attrBlockSize == i {
+			m[id] = cloneAttrs(attrs)
+		}
+	}
+	return m, nil
+}
+
 // AttrBlock represents a checksummed block of the attribute store.
 type AttrBlock struct {
 	ID       uint64 `json:"id"`
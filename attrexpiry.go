@@ -0,0 +1,154 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// defaultAttrExpiryInterval is the interval at which expired attributes are
+// removed.
+const defaultAttrExpiryInterval = time.Minute
+
+// attrExpiresArg is the argument of SetRowAttrs() and SetColumnAttrs() giving
+// the time at which the attributes they set expire.
+const attrExpiresArg = "_expires"
+
+// attrExpiresPrefix prefixes the name of the attribute holding the time at
+// which another attribute expires, in seconds since the Unix epoch. It is
+// stored with the attribute it applies to so that it is replicated, and
+// synchronized by anti-entropy, along with it.
+const attrExpiresPrefix = "_expires."
+
+// withAttrExpiry removes the _expires argument from the attributes of a
+// SetRowAttrs() or SetColumnAttrs() call, and returns the attributes to
+// store: those of the call along with the expiration of each. Attributes set
+// or removed without an expiration lose any they had.
+func withAttrExpiry(attrs map[string]interface{}, loc *time.Location) (map[string]interface{}, error) {
+	v, ok := attrs[attrExpiresArg]
+	delete(attrs, attrExpiresArg)
+
+	var expires interface{}
+	if ok {
+		t, err := parseTime(v, loc)
+		if err != nil {
+			return nil, NewBadRequestError(errors.Wrapf(err, "parsing %s", attrExpiresArg))
+		}
+		expires = t.Unix()
+	}
+
+	stored := make(map[string]interface{}, 2*len(attrs))
+	for name, value := range attrs {
+		stored[name] = value
+		if value == nil {
+			stored[attrExpiresPrefix+name] = nil
+		} else {
+			stored[attrExpiresPrefix+name] = expires
+		}
+	}
+	return stored, nil
+}
+
+// expiredAttrs returns the changes removing the attributes in m which expire
+// at or before now, or nil if there are none.
+func expiredAttrs(m map[string]interface{}, now time.Time) map[string]interface{} {
+	var changes map[string]interface{}
+	for k, v := range m {
+		if !strings.HasPrefix(k, attrExpiresPrefix) {
+			continue
+		}
+		if expires, ok := v.(int64); ok && expires > now.Unix() {
+			continue
+		}
+		if changes == nil {
+			changes = make(map[string]interface{})
+		}
+		changes[k] = nil
+		changes[strings.TrimPrefix(k, attrExpiresPrefix)] = nil
+	}
+	return changes
+}
+
+// sweepExpiredAttrs removes the attributes in store which have expired by
+// now, returning the number of IDs changed.
+func sweepExpiredAttrs(store AttrStore, now time.Time) (int, error) {
+	blocks, err := store.Blocks()
+	if err != nil {
+		return 0, errors.Wrap(err, "reading attribute blocks")
+	}
+
+	var n int
+	for _, block := range blocks {
+		data, err := store.BlockData(block.ID)
+		if err != nil {
+			return n, errors.Wrapf(err, "reading attribute block %d", block.ID)
+		}
+		changes := make(map[uint64]map[string]interface{})
+		for id, m := range data {
+			if c := expiredAttrs(m, now); c != nil {
+				changes[id] = c
+			}
+		}
+		if len(changes) == 0 {
+			continue
+		}
+		if err := store.SetBulkAttrs(changes); err != nil {
+			return n, errors.Wrapf(err, "removing expired attributes of block %d", block.ID)
+		}
+		n += len(changes)
+	}
+	return n, nil
+}
+
+// sweepExpiredAttrs removes the column and row attributes of every index
+// which have expired by now. Every node removes expired attributes from its
+// own replicas.
+func (h *Holder) sweepExpiredAttrs(now time.Time) error {
+	for _, idx := range h.Indexes() {
+		if n, err := sweepExpiredAttrs(idx.ColumnAttrStore(), now); err != nil {
+			return errors.Wrapf(err, "sweeping column attributes of index %s", idx.Name())
+		} else if n > 0 {
+			idx.Stats.Count("expiredColumnAttrs", int64(n), 1.0)
+		}
+		for _, f := range idx.Fields() {
+			if n, err := sweepExpiredAttrs(f.RowAttrStore(), now); err != nil {
+				return errors.Wrapf(err, "sweeping row attributes of field %s/%s", idx.Name(), f.Name())
+			} else if n > 0 {
+				f.Stats.Count("expiredRowAttrs", int64(n), 1.0)
+			}
+		}
+	}
+	return nil
+}
+
+// monitorAttrExpiry periodically removes expired attributes.
+func (s *Server) monitorAttrExpiry() {
+	ticker := time.NewTicker(s.attrExpiryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.closing:
+			return
+		case now := <-ticker.C:
+			if err := s.holder.sweepExpiredAttrs(now); err != nil {
+				s.logger.Printf("removing expired attributes: %s", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Import backpressure. Writes block while the queue of fragments waiting to
+// be snapshotted is full, so imports are refused once it is backpressureRatio
+// full, and clients are told to retry after a delay which grows with the
+// backlog. Roaring imports are also refused while every import worker is busy
+// and the queue of imports waiting for one is full.
+const (
+	backpressureRatio    = 0.75
+	backpressureMinDelay = time.Second
+	backpressureMaxDelay = 30 * time.Second
+)
+
+// ErrBackpressure is the cause of a BackpressureError.
+var ErrBackpressure = errors.New("write backlog is full, try again later")
+
+// BackpressureError is returned for imports received by a node whose write
+// backlog is too large. Clients should wait RetryAfter before retrying.
+type BackpressureError struct {
+	RetryAfter time.Duration
+}
+
+func (e BackpressureError) Error() string {
+	return fmt.Sprintf("%s: retry after %s", ErrBackpressure, e.RetryAfter)
+}
+
+// Cause returns ErrBackpressure, so that errors.Cause identifies the error.
+func (e BackpressureError) Cause() error {
+	return ErrBackpressure
+}
+
+// RetryAfter returns the delay suggested by a BackpressureError in err's
+// chain of causes, and true, or false if there is none.
+func RetryAfter(err error) (time.Duration, bool) {
+	for err != nil {
+		if e, ok := err.(BackpressureError); ok {
+			return e.RetryAfter, true
+		}
+		cause, ok := err.(interface{ Cause() error })
+		if !ok {
+			return 0, false
+		}
+		err = cause.Cause()
+	}
+	return 0, false
+}
+
+// backpressureDelay returns the delay to suggest to clients when the snapshot
+// queue is fill full, or zero if imports should be accepted. The delay
+// grows linearly from the minimum at backpressureRatio to the maximum when the
+// queue is full.
+func backpressureDelay(fill float64) time.Duration {
+	if fill < backpressureRatio {
+		return 0
+	} else if fill > 1 {
+		fill = 1
+	}
+	extra := float64(backpressureMaxDelay-backpressureMinDelay) * (fill - backpressureRatio) / (1 - backpressureRatio)
+	return backpressureMinDelay + time.Duration(extra).Round(time.Second)
+}
+
+// validateWriteBacklog returns a BackpressureError if the node's write
+// backlog is too large to accept imports.
+func (api *API) validateWriteBacklog() error {
+	var delay time.Duration
+	if q := api.holder.snapshotQueue; q != nil && cap(q) > 0 {
+		delay = backpressureDelay(float64(len(q)) / float64(cap(q)))
+	}
+	if delay == 0 && cap(api.importWork) > 0 && len(api.importWork) == cap(api.importWork) {
+		delay = backpressureMinDelay
+	}
+	if delay == 0 {
+		return nil
+	}
+	api.holder.Stats.Count("importBackpressure", 1, 1.0)
+	return BackpressureError{RetryAfter: delay}
+}
@@ -0,0 +1,83 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestBackpressureDelay(t *testing.T) {
+	for _, tt := range []struct {
+		fill float64
+		exp  time.Duration
+	}{
+		{0, 0},
+		{0.5, 0},
+		{backpressureRatio, backpressureMinDelay},
+		{1, backpressureMaxDelay},
+		{2, backpressureMaxDelay},
+	} {
+		if delay := backpressureDelay(tt.fill); delay != tt.exp {
+			t.Fatalf("fill %v: expected %s, got %s", tt.fill, tt.exp, delay)
+		}
+	}
+}
+
+// Ensure a backpressure error is identified by its cause and code, and its
+// delay is found through wrapping.
+func TestBackpressureError(t *testing.T) {
+	err := errors.Wrap(BackpressureError{RetryAfter: 3 * time.Second}, "importing")
+	if errors.Cause(err) != ErrBackpressure {
+		t.Fatalf("unexpected cause: %v", errors.Cause(err))
+	} else if delay, ok := RetryAfter(err); !ok || delay != 3*time.Second {
+		t.Fatalf("unexpected delay: %s, %v", delay, ok)
+	} else if code := NewResponseError(err).Code; code != "Backpressure" {
+		t.Fatalf("unexpected code: %s", code)
+	}
+	if _, ok := RetryAfter(errors.Wrap(ErrOverloaded, "importing")); ok {
+		t.Fatal("expected no delay")
+	}
+}
+
+// Ensure imports are refused once the snapshot queue fills.
+func TestAPI_ValidateWriteBacklog(t *testing.T) {
+	api := &API{holder: NewHolder(), importWork: make(chan importJob, 2)}
+	api.holder.snapshotQueue = make(chan *fragment, 4)
+	if err := api.validateWriteBacklog(); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		api.holder.snapshotQueue <- &fragment{}
+	}
+	if delay, ok := RetryAfter(api.validateWriteBacklog()); !ok || delay != backpressureMinDelay {
+		t.Fatalf("unexpected delay: %s, %v", delay, ok)
+	}
+	api.holder.snapshotQueue <- &fragment{}
+	if delay, ok := RetryAfter(api.validateWriteBacklog()); !ok || delay != backpressureMaxDelay {
+		t.Fatalf("unexpected delay: %s, %v", delay, ok)
+	}
+
+	// A full queue of roaring imports is also refused.
+	api.holder.snapshotQueue = nil
+	api.importWork <- importJob{}
+	api.importWork <- importJob{}
+	if delay, ok := RetryAfter(api.validateWriteBacklog()); !ok || delay != backpressureMinDelay {
+		t.Fatalf("unexpected delay: %s, %v", delay, ok)
+	}
+}
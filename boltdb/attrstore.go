@@ -344,11 +344,15 @@ func btou64(b []byte) uint64 { return binary.BigEndian.Uint64(b) }
 // emptyMap is a reusable map that contains no keys.
 var emptyMap = make(map[string]interface{})
 
-// mapContains returns true if all keys & values of subset are in m.
+// mapContains returns true if all keys & values of subset are in m. A nil
+// value in subset also matches a key absent from m.
 func mapContains(m, subset map[string]interface{}) bool {
 	for k, v := range subset {
 		value, ok := m[k]
-		if !ok || value != v {
+		if !ok && v == nil {
+			// Removing an absent attribute changes nothing.
+			continue
+		} else if !ok || value != v {
 			return false
 		}
 	}
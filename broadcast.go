@@ -69,6 +69,26 @@ const (
 	messageTypeRecalculateCaches
 	messageTypeNodeEvent
 	messageTypeNodeStatus
+	messageTypeLoadUDF
+	messageTypeDeleteUDF
+	messageTypeNodeReadOnly
+	messageTypeTruncateField
+	messageTypeSettings
+	messageTypeSchemaChangeRequest
+	messageTypeIndexReadOnly
+	messageTypeHeartbeat
+	messageTypeCreateMaterializedView
+	messageTypeDeleteMaterializedView
+	messageTypeAliases
+	messageTypeCreateRewriteRule
+	messageTypeDeleteRewriteRule
+	messageTypeCreateNamedQuery
+	messageTypeDeleteNamedQuery
+	messageTypeCreateSchedule
+	messageTypeDeleteSchedule
+	messageTypeScheduleRun
+	messageTypeReadOwners
+	messageTypeInvalidateTopN
 )
 
 // MarshalInternalMessage serializes the pilosa message and adds pilosa internal
@@ -116,6 +136,46 @@ func getMessage(typ byte) Message {
 		return &NodeEvent{}
 	case messageTypeNodeStatus:
 		return &NodeStatus{}
+	case messageTypeLoadUDF:
+		return &LoadUDFMessage{}
+	case messageTypeDeleteUDF:
+		return &DeleteUDFMessage{}
+	case messageTypeNodeReadOnly:
+		return &NodeReadOnlyMessage{}
+	case messageTypeTruncateField:
+		return &TruncateFieldMessage{}
+	case messageTypeSettings:
+		return &SettingsMessage{}
+	case messageTypeSchemaChangeRequest:
+		return &SchemaChangeRequest{}
+	case messageTypeIndexReadOnly:
+		return &IndexReadOnlyMessage{}
+	case messageTypeHeartbeat:
+		return &HeartbeatMessage{}
+	case messageTypeCreateMaterializedView:
+		return &CreateMaterializedViewMessage{}
+	case messageTypeDeleteMaterializedView:
+		return &DeleteMaterializedViewMessage{}
+	case messageTypeAliases:
+		return &AliasesMessage{}
+	case messageTypeCreateRewriteRule:
+		return &CreateRewriteRuleMessage{}
+	case messageTypeDeleteRewriteRule:
+		return &DeleteRewriteRuleMessage{}
+	case messageTypeCreateNamedQuery:
+		return &CreateNamedQueryMessage{}
+	case messageTypeDeleteNamedQuery:
+		return &DeleteNamedQueryMessage{}
+	case messageTypeCreateSchedule:
+		return &CreateScheduleMessage{}
+	case messageTypeDeleteSchedule:
+		return &DeleteScheduleMessage{}
+	case messageTypeScheduleRun:
+		return &ScheduleRunMessage{}
+	case messageTypeReadOwners:
+		return &ReadOwnersMessage{}
+	case messageTypeInvalidateTopN:
+		return &InvalidateTopNMessage{}
 	default:
 		panic(fmt.Sprintf("unknown message type %d", typ))
 	}
@@ -155,6 +215,46 @@ func getMessageType(m Message) byte {
 		return messageTypeNodeEvent
 	case *NodeStatus:
 		return messageTypeNodeStatus
+	case *LoadUDFMessage:
+		return messageTypeLoadUDF
+	case *DeleteUDFMessage:
+		return messageTypeDeleteUDF
+	case *NodeReadOnlyMessage:
+		return messageTypeNodeReadOnly
+	case *TruncateFieldMessage:
+		return messageTypeTruncateField
+	case *SettingsMessage:
+		return messageTypeSettings
+	case *SchemaChangeRequest:
+		return messageTypeSchemaChangeRequest
+	case *IndexReadOnlyMessage:
+		return messageTypeIndexReadOnly
+	case *HeartbeatMessage:
+		return messageTypeHeartbeat
+	case *CreateMaterializedViewMessage:
+		return messageTypeCreateMaterializedView
+	case *DeleteMaterializedViewMessage:
+		return messageTypeDeleteMaterializedView
+	case *AliasesMessage:
+		return messageTypeAliases
+	case *CreateRewriteRuleMessage:
+		return messageTypeCreateRewriteRule
+	case *DeleteRewriteRuleMessage:
+		return messageTypeDeleteRewriteRule
+	case *CreateNamedQueryMessage:
+		return messageTypeCreateNamedQuery
+	case *DeleteNamedQueryMessage:
+		return messageTypeDeleteNamedQuery
+	case *CreateScheduleMessage:
+		return messageTypeCreateSchedule
+	case *DeleteScheduleMessage:
+		return messageTypeDeleteSchedule
+	case *ScheduleRunMessage:
+		return messageTypeScheduleRun
+	case *ReadOwnersMessage:
+		return messageTypeReadOwners
+	case *InvalidateTopNMessage:
+		return messageTypeInvalidateTopN
 	default:
 		panic(fmt.Sprintf("don't have type for message %#v", m))
 	}
@@ -435,6 +435,9 @@ func (p uint64Slice) merge(other []uint64) []uint64 {
 type bitmapCache interface {
 	Fetch(id uint64) (*Row, bool)
 	Add(id uint64, b *Row)
+
+	// Returns the approximate number of bytes used by the cache.
+	Size() int
 }
 
 // simpleCache implements BitmapCache
@@ -462,6 +465,26 @@ func (s *simpleCache) Add(id uint64, b *Row) {
 	}
 }
 
+// Approximate heap usage of a cached row and of each container it references.
+const (
+	simpleCacheRowSize       = 128
+	simpleCacheContainerSize = 16
+)
+
+// Size returns the approximate number of bytes used by the cached rows.
+// Cached rows share container data with the fragment's storage, so only the
+// rows themselves and their container indexes are counted.
+func (s *simpleCache) Size() int {
+	n := 0
+	for _, row := range s.cache {
+		n += simpleCacheRowSize
+		for _, seg := range row.segments {
+			n += seg.data.Containers.Size() * simpleCacheContainerSize
+		}
+	}
+	return n
+}
+
 // nopCache represents a no-op Cache implementation.
 type nopCache struct {
 	stats stats.StatsClient
@@ -0,0 +1,181 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/pilosa/pilosa/v2/pql"
+	"github.com/pkg/errors"
+)
+
+// builtinCalls are the names of calls implemented by the executor, which may
+// not be overridden by custom calls. They are the cases of the switches on
+// the call name in executeCall and executeBitmapCallShard, which
+// TestBuiltinCalls checks them against.
+var builtinCalls = map[string]struct{}{
+	"Clear": {}, "ClearRow": {}, "Count": {}, "Difference": {}, "GroupBy": {},
+	"Index": {}, "Intersect": {}, "Limit": {}, "Materialized": {}, "Max": {}, "MaxRow": {},
+	"Min": {}, "MinRow": {}, "Not": {}, "Options": {}, "Range": {}, "Row": {}, "Rows": {},
+	"Set": {}, "Sample": {}, "SetColumnAttrs": {}, "SetRowAttrs": {}, "Shift": {},
+	"Store": {}, "Sum": {}, "TopN": {}, "Union": {}, "Xor": {},
+}
+
+// callNameRegexp matches names which the PQL parser accepts as call names.
+var callNameRegexp = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*$`)
+
+// CallDefinition defines a custom PQL call. Custom calls are executed like
+// built-in calls: Map is run against each shard on the node which owns it,
+// and the results are merged on the coordinating node with Reduce.
+//
+// Because shards may be evaluated on remote nodes, the value returned by Map
+// must be a type the Serializer can encode (such as *Row, uint64, []Pair,
+// ValCount, or bool), and the call must be registered on every node.
+//
+// A custom call whose Map returns *Row may also be used as the child of
+// built-in calls such as Count() or Intersect().
+type CallDefinition struct {
+	// Map executes the call against a single shard.
+	Map func(ctx context.Context, shard *CallShard, c *pql.Call) (interface{}, error)
+
+	// Reduce merges a shard result, v, into the accumulated result, prev.
+	// The prev argument is nil for the first result. An error fails the call.
+	Reduce func(prev, v interface{}) (interface{}, error)
+}
+
+// CallShard is passed to a custom call's Map function and describes the
+// shard being evaluated.
+type CallShard struct {
+	Index *Index
+	Shard uint64
+
+	e *executor
+}
+
+// Row evaluates a bitmap call, typically one of the custom call's children,
+// against the shard.
+func (s *CallShard) Row(ctx context.Context, c *pql.Call) (*Row, error) {
+	return s.e.executeBitmapCallShard(ctx, s.Index.Name(), c, s.Shard)
+}
+
+// All returns every column of the shard, as tracked by the index's existence
+// field.
+func (s *CallShard) All() (*Row, error) {
+	if s.Index.existenceField() == nil {
+		return nil, errors.Errorf("index does not support existence tracking: %s", s.Index.Name())
+	}
+	if frag := s.e.Holder.fragment(s.Index.Name(), existenceFieldName, viewStandard, s.Shard); frag != nil {
+		return frag.row(0), nil
+	}
+	return NewRow(), nil
+}
+
+// validateCallDefinition returns an error if def cannot be registered as name.
+func validateCallDefinition(name string, def CallDefinition) error {
+	if !callNameRegexp.MatchString(name) {
+		return fmt.Errorf("invalid call name: %q", name)
+	} else if _, ok := builtinCalls[name]; ok {
+		return fmt.Errorf("cannot override built-in call: %s", name)
+	} else if def.Map == nil || def.Reduce == nil {
+		return errors.New("call definition requires Map and Reduce")
+	}
+	return nil
+}
+
+// optExecutorCall registers a custom call on the executor.
+func optExecutorCall(name string, def CallDefinition) executorOption {
+	return func(e *executor) error {
+		return e.registerCall(name, def)
+	}
+}
+
+// registerCall adds a custom call to the executor.
+func (e *executor) registerCall(name string, def CallDefinition) error {
+	if err := validateCallDefinition(name, def); err != nil {
+		return err
+	}
+
+	e.callsMu.Lock()
+	defer e.callsMu.Unlock()
+	if _, ok := e.calls[name]; ok {
+		return fmt.Errorf("call already registered: %s", name)
+	}
+	if e.calls == nil {
+		e.calls = make(map[string]CallDefinition)
+	}
+	e.calls[name] = def
+	return nil
+}
+
+// unregisterCall removes a custom call from the executor.
+func (e *executor) unregisterCall(name string) {
+	e.callsMu.Lock()
+	defer e.callsMu.Unlock()
+	delete(e.calls, name)
+}
+
+// customCall returns the definition of a registered custom call.
+func (e *executor) customCall(name string) (CallDefinition, bool) {
+	e.callsMu.RLock()
+	defer e.callsMu.RUnlock()
+	def, ok := e.calls[name]
+	return def, ok
+}
+
+// executeCustomCall executes a registered custom call across shards.
+func (e *executor) executeCustomCall(ctx context.Context, index string, c *pql.Call, def CallDefinition, shards []uint64, opt *execOptions) (interface{}, error) {
+	idx := e.Holder.Index(index)
+	if idx == nil {
+		return nil, newNotFoundError(ErrIndexNotFound, index)
+	}
+
+	mapFn := func(shard uint64) (interface{}, error) {
+		return def.Map(ctx, &CallShard{Index: idx, Shard: shard, e: e}, c)
+	}
+	reduceFn := func(prev, v interface{}) interface{} {
+		result, err := def.Reduce(prev, v)
+		if err != nil {
+			return reduceError{err}
+		}
+		return result
+	}
+
+	result, err := e.mapReduce(ctx, index, shards, c, opt, mapFn, reduceFn)
+	if err != nil {
+		return nil, errors.Wrapf(err, "executing %s()", c.Name)
+	}
+	return result, nil
+}
+
+// executeCustomCallShard executes a registered custom call which returns a
+// row against a single shard.
+func (e *executor) executeCustomCallShard(ctx context.Context, index string, c *pql.Call, def CallDefinition, shard uint64) (*Row, error) {
+	idx := e.Holder.Index(index)
+	if idx == nil {
+		return nil, newNotFoundError(ErrIndexNotFound, index)
+	}
+
+	v, err := def.Map(ctx, &CallShard{Index: idx, Shard: shard, e: e}, c)
+	if err != nil {
+		return nil, errors.Wrapf(err, "executing %s()", c.Name)
+	}
+	row, ok := v.(*Row)
+	if !ok {
+		return nil, fmt.Errorf("%s() does not return a row", c.Name)
+	}
+	return row, nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"context"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/pilosa/pilosa/v2/pql"
+)
+
+// Ensure builtinCalls holds every call name the executor switches on, so
+// that custom calls can't be registered under a name the executor would
+// never dispatch to them.
+func TestBuiltinCalls(t *testing.T) {
+	file, err := parser.ParseFile(token.NewFileSet(), "executor.go", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names := make(map[string]struct{})
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || (fn.Name.Name != "executeCall" && fn.Name.Name != "executeBitmapCallShard") {
+			continue
+		}
+		for _, stmt := range fn.Body.List {
+			sw, ok := stmt.(*ast.SwitchStmt)
+			if !ok {
+				continue
+			} else if sel, ok := sw.Tag.(*ast.SelectorExpr); !ok || sel.Sel.Name != "Name" {
+				continue
+			}
+			for _, clause := range sw.Body.List {
+				for _, expr := range clause.(*ast.CaseClause).List {
+					name, err := strconv.Unquote(expr.(*ast.BasicLit).Value)
+					if err != nil {
+						t.Fatal(err)
+					}
+					names[name] = struct{}{}
+				}
+			}
+		}
+	}
+	if !reflect.DeepEqual(names, builtinCalls) {
+		t.Fatalf("builtinCalls differs from the calls the executor implements: %v", names)
+	}
+}
+
+// Ensure a custom call can't be registered twice, or under a built-in name.
+func TestOptServerCall(t *testing.T) {
+	def := CallDefinition{
+		Map:    func(ctx context.Context, shard *CallShard, c *pql.Call) (interface{}, error) { return nil, nil },
+		Reduce: func(prev, v interface{}) (interface{}, error) { return nil, nil },
+	}
+	s := &Server{}
+	if err := OptServerCall("Custom", def)(s); err != nil {
+		t.Fatal(err)
+	} else if err := OptServerCall("Custom", def)(s); err == nil {
+		t.Fatal("expected error registering duplicate call")
+	} else if err := OptServerCall("Materialized", def)(s); err == nil {
+		t.Fatal("expected error registering built-in call")
+	}
+}
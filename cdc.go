@@ -0,0 +1,263 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pilosa/pilosa/v2/roaring"
+	"github.com/pkg/errors"
+)
+
+// Change log errors.
+var (
+	ErrChangeLogDisabled  = errors.New("change log is disabled")
+	ErrChangeLogTruncated = errors.New("change log truncated, requested sequence is no longer available")
+)
+
+// Change event types.
+const (
+	ChangeTypeSet           = "set"
+	ChangeTypeClear         = "clear"
+	ChangeTypeClearRow      = "clearRow"
+	ChangeTypeSetRow        = "setRow"
+	ChangeTypeValue         = "value"
+	ChangeTypeRowAttrs      = "rowAttrs"
+	ChangeTypeColumnAttrs   = "columnAttrs"
+	ChangeTypeImport        = "import"
+	ChangeTypeImportValue   = "importValue"
+	ChangeTypeImportRoaring = "importRoaring"
+	ChangeTypeTruncate      = "truncate"
+)
+
+// ChangeEvent represents a single mutation applied to the local holder.
+// Single-bit changes use RowID/ColumnID, while bulk imports carry the
+// affected ids in the slice fields.
+//
+// Changes to data are recorded by each fragment they apply to, so a write to
+// a field with time or inverse views is recorded once for each view. Changes
+// to BSI views are recorded as values, except for roaring imports, which
+// carry the bits imported to the view.
+type ChangeEvent struct {
+	Seq       uint64                 `json:"seq"`
+	Time      time.Time              `json:"time"`
+	Type      string                 `json:"type"`
+	Index     string                 `json:"index"`
+	Field     string                 `json:"field,omitempty"`
+	View      string                 `json:"view,omitempty"`
+	Shard     uint64                 `json:"shard,omitempty"`
+	RowID     uint64                 `json:"rowID,omitempty"`
+	ColumnID  uint64                 `json:"columnID,omitempty"`
+	Value     int64                  `json:"value,omitempty"`
+	Attrs     map[string]interface{} `json:"attrs,omitempty"`
+	RowIDs    []uint64               `json:"rowIDs,omitempty"`
+	ColumnIDs []uint64               `json:"columnIDs,omitempty"`
+	Values    []int64                `json:"values,omitempty"`
+	Clear     bool                   `json:"clear,omitempty"`
+}
+
+// changeLog is a bounded, in-memory log of mutations. Each event is assigned
+// a monotonically increasing sequence number so that readers can resume the
+// stream from the last event they observed. Once the log is full the oldest
+// events are discarded.
+//
+// The log is kept in a ring buffer which is not persisted: events are lost
+// when the node restarts, and sequence numbers start over from zero. Readers
+// must resynchronize after a restart, as they do after falling behind.
+//
+// A nil changeLog is valid and discards all events.
+type changeLog struct {
+	mu     sync.Mutex
+	events []ChangeEvent // ring buffer
+	start  int           // index of the oldest event
+	n      int           // number of buffered events
+	seq    uint64        // sequence of the last appended event
+
+	// notify is closed and replaced each time an event is appended.
+	notify chan struct{}
+}
+
+// newChangeLog returns a change log which retains up to size events.
+// A size of zero or less returns nil, which disables the log.
+func newChangeLog(size int) *changeLog {
+	if size <= 0 {
+		return nil
+	}
+	return &changeLog{
+		events: make([]ChangeEvent, size),
+		notify: make(chan struct{}),
+	}
+}
+
+// append assigns the next sequence number to ev and adds it to the log.
+// Slices in ev are copied, so callers may reuse them after append returns.
+func (l *changeLog) append(ev ChangeEvent) {
+	if l == nil {
+		return
+	}
+	ev.RowIDs = copyUint64s(ev.RowIDs)
+	ev.ColumnIDs = copyUint64s(ev.ColumnIDs)
+	ev.Values = copyInt64s(ev.Values)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.seq++
+	ev.Seq = l.seq
+	if ev.Time.IsZero() {
+		ev.Time = time.Now().UTC()
+	}
+
+	if l.n < len(l.events) {
+		l.events[(l.start+l.n)%len(l.events)] = ev
+		l.n++
+	} else {
+		l.events[l.start] = ev
+		l.start = (l.start + 1) % len(l.events)
+	}
+
+	close(l.notify)
+	l.notify = make(chan struct{})
+}
+
+// since returns up to max buffered events with a sequence greater than seq.
+// The returned channel is closed when further events become available.
+func (l *changeLog) since(seq uint64, max int) ([]ChangeEvent, <-chan struct{}, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// The oldest sequence still buffered is seq-n+1; the caller must have
+	// observed everything before it.
+	if oldest := l.seq - uint64(l.n) + 1; seq+1 < oldest {
+		return nil, nil, ErrChangeLogTruncated
+	}
+	if seq >= l.seq {
+		return nil, l.notify, nil
+	}
+
+	cnt := int(l.seq - seq)
+	if max > 0 && cnt > max {
+		cnt = max
+	}
+	offset := l.n - int(l.seq-seq)
+	events := make([]ChangeEvent, cnt)
+	for i := range events {
+		events[i] = l.events[(l.start+offset+i)%len(l.events)]
+	}
+	return events, l.notify, nil
+}
+
+// read blocks until at least one event after seq is available and returns up
+// to max of them, or until ctx is done.
+func (l *changeLog) read(ctx context.Context, seq uint64, max int) ([]ChangeEvent, error) {
+	if l == nil {
+		return nil, ErrChangeLogDisabled
+	}
+	for {
+		events, notify, err := l.since(seq, max)
+		if err != nil || len(events) > 0 {
+			return events, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-notify:
+		}
+	}
+}
+
+func copyUint64s(a []uint64) []uint64 {
+	if a == nil {
+		return nil
+	}
+	return append(make([]uint64, 0, len(a)), a...)
+}
+
+func copyInt64s(a []int64) []int64 {
+	if a == nil {
+		return nil
+	}
+	return append(make([]int64, 0, len(a)), a...)
+}
+
+// recordChange appends an event for a change to the fragment to the change
+// log. It must be called while the fragment is locked, so that events are
+// recorded in the order their changes are applied to the fragment's storage.
+func (f *fragment) recordChange(ev ChangeEvent) {
+	if f.changes == nil {
+		return
+	}
+	ev.Index, ev.Field, ev.View, ev.Shard = f.index, f.field, f.view, f.shard
+	f.changes.append(ev)
+}
+
+// recordsBits returns true if changes to the fragment's bits are recorded.
+// The bits of BSI views encode values, so their changes are recorded as the
+// values set instead.
+func (f *fragment) recordsBits() bool {
+	return f.changes != nil && !strings.HasPrefix(f.view, viewBSIGroupPrefix)
+}
+
+// recordPositions records the positions imported to the fragment.
+func (f *fragment) recordPositions(set, clear []uint64) {
+	if !f.recordsBits() {
+		return
+	}
+	if len(set) > 0 {
+		rowIDs, columnIDs := f.positionBits(set)
+		f.recordChange(ChangeEvent{Type: ChangeTypeImport, RowIDs: rowIDs, ColumnIDs: columnIDs})
+	}
+	if len(clear) > 0 {
+		rowIDs, columnIDs := f.positionBits(clear)
+		f.recordChange(ChangeEvent{Type: ChangeTypeImport, RowIDs: rowIDs, ColumnIDs: columnIDs, Clear: true})
+	}
+}
+
+// recordValues records the values imported to a BSI view's fragment. Values
+// are stored relative to the field's base, which is added back.
+func (f *fragment) recordValues(columnIDs []uint64, values []int64, clear bool) {
+	if f.changes == nil {
+		return
+	}
+	a := make([]int64, len(values))
+	for i, v := range values {
+		a[i] = v + f.valueBase
+	}
+	f.recordChange(ChangeEvent{Type: ChangeTypeImportValue, ColumnIDs: columnIDs, Values: a, Clear: clear})
+}
+
+// roaringPositions returns the positions set in roaring data. The data is
+// copied, since decoding may map the bitmap onto it.
+func roaringPositions(data []byte) ([]uint64, error) {
+	b := roaring.NewBitmap()
+	if err := b.UnmarshalBinary(append([]byte(nil), data...)); err != nil {
+		return nil, err
+	}
+	return b.Slice(), nil
+}
+
+// positionBits returns the rows and columns of positions in the fragment.
+func (f *fragment) positionBits(positions []uint64) (rowIDs, columnIDs []uint64) {
+	rowIDs = make([]uint64, len(positions))
+	columnIDs = make([]uint64, len(positions))
+	for i, pos := range positions {
+		rowIDs[i] = pos / ShardWidth
+		columnIDs[i] = f.shard*ShardWidth + pos%ShardWidth
+	}
+	return rowIDs, columnIDs
+}
@@ -46,6 +46,7 @@ type FieldValue struct {
 type InternalClient interface {
 	MaxShardByIndex(ctx context.Context) (map[string]uint64, error)
 	Schema(ctx context.Context) ([]*IndexInfo, error)
+	SchemaNode(ctx context.Context, uri *URI) ([]*IndexInfo, error)
 	PostSchema(ctx context.Context, uri *URI, s *Schema, remote bool) error
 	CreateIndex(ctx context.Context, index string, opt IndexOptions) error
 	FragmentNodes(ctx context.Context, index string, shard uint64) ([]*Node, error)
@@ -58,12 +59,16 @@ type InternalClient interface {
 	EnsureField(ctx context.Context, indexName string, fieldName string) error
 	EnsureFieldWithOptions(ctx context.Context, index, field string, opt FieldOptions) error
 	ImportValue(ctx context.Context, index, field string, shard uint64, vals []FieldValue, opts ...ImportOption) error
+	ImportNode(ctx context.Context, uri *URI, index, field string, shard uint64, bits []Bit, opts ...ImportOption) error
+	ImportValueNode(ctx context.Context, uri *URI, index, field string, shard uint64, vals []FieldValue, opts ...ImportOption) error
 	ImportValueK(ctx context.Context, index, field string, vals []FieldValue, opts ...ImportOption) error
 	ExportCSV(ctx context.Context, index, field string, shard uint64, w io.Writer) error
 	CreateField(ctx context.Context, index, field string) error
 	CreateFieldWithOptions(ctx context.Context, index, field string, opt FieldOptions) error
 	FragmentBlocks(ctx context.Context, uri *URI, index, field, view string, shard uint64) ([]FragmentBlock, error)
 	BlockData(ctx context.Context, uri *URI, index, field, view string, shard uint64, block int) ([]uint64, []uint64, error)
+	BlockChecksums(ctx context.Context, uri *URI, index, field, view string, shard uint64, block int) ([]ContainerChecksum, error)
+	BlockContainerData(ctx context.Context, uri *URI, index, field, view string, shard uint64, block int, containers []uint64) ([]uint64, []uint64, error)
 	ColumnAttrDiff(ctx context.Context, uri *URI, index string, blks []AttrBlock) (map[uint64]map[string]interface{}, error)
 	RowAttrDiff(ctx context.Context, uri *URI, index, field string, blks []AttrBlock) (map[uint64]map[string]interface{}, error)
 	SendMessage(ctx context.Context, uri *URI, msg []byte) error
@@ -104,6 +109,9 @@ func (n nopInternalClient) MaxShardByIndex(context.Context) (map[string]uint64,
 	return nil, nil
 }
 func (n nopInternalClient) Schema(ctx context.Context) ([]*IndexInfo, error) { return nil, nil }
+func (n nopInternalClient) SchemaNode(ctx context.Context, uri *URI) ([]*IndexInfo, error) {
+	return nil, nil
+}
 func (n nopInternalClient) PostSchema(ctx context.Context, uri *URI, s *Schema, remote bool) error {
 	return nil
 }
@@ -144,6 +152,12 @@ func (n nopInternalClient) EnsureFieldWithOptions(ctx context.Context, index, fi
 func (n nopInternalClient) ImportValue(ctx context.Context, index, field string, shard uint64, vals []FieldValue, opts ...ImportOption) error {
 	return nil
 }
+func (n nopInternalClient) ImportNode(ctx context.Context, uri *URI, index, field string, shard uint64, bits []Bit, opts ...ImportOption) error {
+	return nil
+}
+func (n nopInternalClient) ImportValueNode(ctx context.Context, uri *URI, index, field string, shard uint64, vals []FieldValue, opts ...ImportOption) error {
+	return nil
+}
 func (n nopInternalClient) ImportValueK(ctx context.Context, index, field string, vals []FieldValue, opts ...ImportOption) error {
 	return nil
 }
@@ -160,6 +174,12 @@ func (n nopInternalClient) FragmentBlocks(ctx context.Context, uri *URI, index,
 func (n nopInternalClient) BlockData(ctx context.Context, uri *URI, index, field, view string, shard uint64, block int) ([]uint64, []uint64, error) {
 	return nil, nil, nil
 }
+func (n nopInternalClient) BlockChecksums(ctx context.Context, uri *URI, index, field, view string, shard uint64, block int) ([]ContainerChecksum, error) {
+	return nil, nil
+}
+func (n nopInternalClient) BlockContainerData(ctx context.Context, uri *URI, index, field, view string, shard uint64, block int, containers []uint64) ([]uint64, []uint64, error) {
+	return nil, nil, nil
+}
 func (n nopInternalClient) ColumnAttrDiff(ctx context.Context, uri *URI, index string, blks []AttrBlock) (map[uint64]map[string]interface{}, error) {
 	return nil, nil
 }
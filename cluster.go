@@ -50,8 +50,9 @@ const (
 	ClusterStateResizing = "RESIZING"
 
 	// NodeState represents the state of a node during startup.
-	nodeStateReady = "READY"
-	nodeStateDown  = "DOWN"
+	nodeStateReady   = "READY"
+	nodeStateSuspect = "SUSPECT"
+	nodeStateDown    = "DOWN"
 
 	// resizeJob states.
 	resizeJobStateRunning = "RUNNING"
@@ -73,6 +74,20 @@ type Node struct {
 	URI           URI    `json:"uri"`
 	IsCoordinator bool   `json:"isCoordinator"`
 	State         string `json:"state"`
+
+	// Zone is the failure domain, such as a rack or availability zone,
+	// which the node is in. Replicas are placed in different zones where
+	// possible.
+	Zone string `json:"zone,omitempty"`
+
+	// Version is the version of Pilosa the node is running, and Features
+	// is the set of features it supports.
+	Version  string `json:"version,omitempty"`
+	Features uint64 `json:"features,omitempty"`
+
+	// InternalURI is the address of the node's internal listener, if it
+	// listens for requests from other nodes separately from the public API.
+	InternalURI *URI `json:"internalUri,omitempty"`
 }
 
 func (n *Node) Clone() *Node {
@@ -80,9 +95,31 @@ func (n *Node) Clone() *Node {
 		return nil
 	}
 	other := *n
+	if n.InternalURI != nil {
+		uri := *n.InternalURI
+		other.InternalURI = &uri
+	}
 	return &other
 }
 
+// clusterURI returns the address which other nodes send the node internal
+// requests on.
+func (n *Node) clusterURI() *URI {
+	if n.InternalURI != nil {
+		return n.InternalURI
+	}
+	return &n.URI
+}
+
+// sameInternalURI returns true if two nodes have the same internal listener
+// address, or neither has one.
+func (n *Node) sameInternalURI(other *Node) bool {
+	if n.InternalURI == nil || other.InternalURI == nil {
+		return n.InternalURI == other.InternalURI
+	}
+	return *n.InternalURI == *other.InternalURI
+}
+
 func (n Node) String() string {
 	return fmt.Sprintf("Node:%s:%s:%s", n.URI, n.State, n.ID)
 }
@@ -214,6 +251,7 @@ type cluster struct { // nolint: maligned
 	Coordinator string
 	holder      *Holder
 	broadcaster broadcaster
+	events      EventHandler
 
 	joiningLeavingNodes chan nodeAction
 
@@ -228,6 +266,19 @@ type cluster struct { // nolint: maligned
 	jobs       map[int64]*resizeJob
 	currentJob *resizeJob
 
+	// IDs of nodes which have disabled writes because they are low on
+	// disk space.
+	readOnlyNodes map[string]struct{}
+
+	// Replicas which serve the reads of hot shards in place of their
+	// primary owners, set by the rebalancer.
+	readOwners map[shardKey]string
+
+	// failures judges whether other nodes are up from their heartbeats,
+	// and hints keeps the writes missed by nodes which were DOWN.
+	failures *failureDetector
+	hints    *hintLog
+
 	// Close management
 	wg      sync.WaitGroup
 	closing chan struct{}
@@ -246,11 +297,15 @@ func newCluster() *cluster {
 
 		joiningLeavingNodes: make(chan nodeAction, 10), // buffered channel
 		jobs:                make(map[int64]*resizeJob),
+		readOnlyNodes:       make(map[string]struct{}),
+		failures:            newFailureDetector(),
+		hints:               newHintLog(),
 		closing:             make(chan struct{}),
 		joining:             make(chan struct{}),
 
 		InternalClient: newNopInternalClient(),
 
+		events: eventHandlers(nil),
 		logger: logger.NopLogger,
 	}
 }
@@ -519,6 +574,7 @@ func (c *cluster) setMyNodeState(state string) {
 
 func (c *cluster) setNodeState(state string) error { // nolint: unparam
 	c.setMyNodeState(state)
+	c.events.HandleEvent(NodeStateEvent{NodeID: c.Node.ID, State: state})
 	if c.isCoordinator() {
 		return c.receiveNodeState(c.Node.ID, state)
 	}
@@ -561,6 +617,9 @@ func (c *cluster) receiveNodeState(nodeID string, state string) error {
 	c.Topology.mu.Unlock()
 	c.logger.Printf("received state %s (%s)", state, nodeID)
 
+	if changed && nodeID != c.Node.ID {
+		c.events.HandleEvent(NodeStateEvent{NodeID: nodeID, State: state})
+	}
 	if changed {
 		return c.unprotectedSetStateAndBroadcast(c.determineClusterState())
 	}
@@ -632,10 +691,12 @@ func (c *cluster) nodePositionByID(nodeID string) int {
 func (c *cluster) addNodeBasicSorted(node *Node) bool {
 	n := c.unprotectedNodeByID(node.ID)
 	if n != nil {
-		if n.State != node.State || n.IsCoordinator != node.IsCoordinator || n.URI != node.URI {
+		if n.State != node.State || n.IsCoordinator != node.IsCoordinator || n.URI != node.URI || !n.sameInternalURI(node) || n.Zone != node.Zone || n.Version != node.Version || n.Features != node.Features {
 			n.State = node.State
 			n.IsCoordinator = node.IsCoordinator
-			n.URI = node.URI
+			n.URI, n.InternalURI = node.URI, node.InternalURI
+			n.Zone = node.Zone
+			n.Version, n.Features = node.Version, node.Features
 			return true
 		}
 		return false
@@ -898,6 +959,133 @@ func (c *cluster) ownsShard(nodeID string, index string, shard uint64) bool {
 	return Nodes(c.shardNodes(index, shard)).ContainsID(nodeID)
 }
 
+// ClusterTopology is the placement of the shards of each index on the
+// cluster's nodes. Clients use it to send imports, and queries of a single
+// shard, straight to the nodes which own the shard.
+type ClusterTopology struct {
+	ShardWidth uint64           `json:"shardWidth"`
+	ReplicaN   int              `json:"replicaN"`
+	Nodes      []*Node          `json:"nodes"`
+	Indexes    []*IndexTopology `json:"indexes"`
+}
+
+// IndexTopology lists the nodes which own each of an index's shards.
+type IndexTopology struct {
+	Name   string        `json:"name"`
+	Shards []ShardOwners `json:"shards"`
+}
+
+// ShardOwners are the IDs of the nodes which hold the replicas of a shard, the
+// primary owner first.
+type ShardOwners struct {
+	Shard uint64   `json:"shard"`
+	Nodes []string `json:"nodes"`
+}
+
+// topology returns the owners of the available shards of each index.
+func (c *cluster) topology(indexes []*Index) *ClusterTopology {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	t := &ClusterTopology{
+		ShardWidth: ShardWidth,
+		ReplicaN:   c.ReplicaN,
+		Nodes:      Nodes(c.nodes).Clone(),
+		Indexes:    make([]*IndexTopology, 0, len(indexes)),
+	}
+	for _, idx := range indexes {
+		it := &IndexTopology{Name: idx.Name(), Shards: []ShardOwners{}}
+		for _, shard := range idx.AvailableShards().Slice() {
+			owners := ShardOwners{Shard: shard}
+			for _, node := range c.shardNodes(idx.Name(), shard) {
+				owners.Nodes = append(owners.Nodes, node.ID)
+			}
+			it.Shards = append(it.Shards, owners)
+		}
+		t.Indexes = append(t.Indexes, it)
+	}
+	return t
+}
+
+// ShardDistribution is the placement of the shards of each index on the
+// cluster's nodes along with the health of their replicas, for spotting nodes
+// which hold more shards than others, and shards with replicas on failed
+// nodes.
+type ShardDistribution struct {
+	ReplicaN int                     `json:"replicaN"`
+	Nodes    map[string]*NodeShards  `json:"nodes"`
+	Indexes  map[string]*IndexShards `json:"indexes"`
+}
+
+// NodeShards is the number of available shards for which a node is the
+// primary owner, and for which it holds another replica, and its health.
+type NodeShards struct {
+	Health    string `json:"health"`
+	Primaries int    `json:"primaries"`
+	Replicas  int    `json:"replicas"`
+}
+
+// IndexShards maps each of an index's available shards to its replicas, the
+// primary first. UnderReplicated are the shards with fewer READY replicas
+// than the cluster's replica count.
+type IndexShards struct {
+	Shards          map[uint64][]ShardReplica `json:"shards"`
+	UnderReplicated []uint64                  `json:"underReplicated"`
+}
+
+// ShardReplica is a node holding a replica of a shard, and its health.
+type ShardReplica struct {
+	Node   string `json:"node"`
+	Health string `json:"health"`
+}
+
+// distribution returns the replicas of the available shards of each index,
+// with the health of each node according to this node's failure detector.
+// Nodes which have left the cluster's membership are DOWN.
+func (c *cluster) distribution(indexes []*Index) *ShardDistribution {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	d := &ShardDistribution{
+		ReplicaN: c.ReplicaN,
+		Nodes:    make(map[string]*NodeShards, len(c.nodes)),
+		Indexes:  make(map[string]*IndexShards, len(indexes)),
+	}
+	for _, node := range c.nodes {
+		health := c.failures.state(node.ID)
+		if node.State == nodeStateDown {
+			health = nodeStateDown
+		}
+		d.Nodes[node.ID] = &NodeShards{Health: health}
+	}
+
+	for _, idx := range indexes {
+		is := &IndexShards{Shards: make(map[uint64][]ShardReplica), UnderReplicated: []uint64{}}
+		for _, shard := range idx.AvailableShards().Slice() {
+			var ready int
+			replicas := make([]ShardReplica, 0, c.ReplicaN)
+			for i, node := range c.shardNodes(idx.Name(), shard) {
+				ns := d.Nodes[node.ID]
+				if i == 0 {
+					ns.Primaries++
+				} else {
+					ns.Replicas++
+				}
+				if ns.Health == nodeStateReady {
+					ready++
+				}
+				replicas = append(replicas, ShardReplica{Node: node.ID, Health: ns.Health})
+			}
+			is.Shards[shard] = replicas
+			if ready < c.ReplicaN {
+				is.UnderReplicated = append(is.UnderReplicated, shard)
+			}
+		}
+		d.Indexes[idx.Name()] = is
+	}
+	return d
+}
+
 // partitionNodes returns a list of nodes that own a partition. unprotected.
 func (c *cluster) partitionNodes(partitionID int) []*Node {
 
@@ -913,10 +1101,28 @@ func (c *cluster) partitionNodes(partitionID int) []*Node {
 	// Determine primary owner node.
 	nodeIndex := c.Hasher.Hash(uint64(partitionID), len(c.nodes))
 
-	// Collect nodes around the ring.
-	nodes := make([]*Node, replicaN)
-	for i := 0; i < replicaN; i++ {
-		nodes[i] = c.nodes[(nodeIndex+i)%len(c.nodes)]
+	// Collect nodes around the ring, skipping nodes in a zone which already
+	// holds a replica. Nodes without a zone are never skipped, so without
+	// zones the replicas are simply the next nodes around the ring.
+	nodes := make([]*Node, 0, replicaN)
+	zones := make(map[string]struct{}, replicaN)
+	var skipped []*Node
+	for i := 0; i < len(c.nodes) && len(nodes) < replicaN; i++ {
+		node := c.nodes[(nodeIndex+i)%len(c.nodes)]
+		if _, ok := zones[node.Zone]; ok {
+			skipped = append(skipped, node)
+			continue
+		}
+		if node.Zone != "" {
+			zones[node.Zone] = struct{}{}
+		}
+		nodes = append(nodes, node)
+	}
+
+	// If there are fewer zones than replicas, place the remaining replicas
+	// on the skipped nodes, in ring order.
+	for i := 0; len(nodes) < replicaN; i++ {
+		nodes = append(nodes, skipped[i])
 	}
 
 	return nodes
@@ -1351,7 +1557,7 @@ func (c *cluster) followResizeInstruction(instr *ResizeInstruction) error {
 			for _, src := range instr.Sources {
 				c.logger.Printf("get shard %d for index %s from host %s", src.Shard, src.Index, src.Node.URI)
 
-				srcURI := src.Node.URI
+				srcURI := *src.Node.clusterURI()
 
 				// Retrieve field.
 				f := c.holder.Field(src.Index, src.Field)
@@ -1549,8 +1755,9 @@ func (j *resizeJob) distributeResizeInstructions() error {
 		// Because the node may not be in the cluster yet, create
 		// a dummy node object to use in the SendTo() method.
 		node := &Node{
-			ID:  instr.Node.ID,
-			URI: instr.Node.URI,
+			ID:          instr.Node.ID,
+			URI:         instr.Node.URI,
+			InternalURI: instr.Node.InternalURI,
 		}
 		j.Logger.Printf("send resize instructions: %v", instr)
 		if err := j.Broadcaster.SendTo(node, instr); err != nil {
@@ -1773,7 +1980,7 @@ func (c *cluster) ReceiveEvent(e *NodeEvent) (err error) {
 			// not already removed by a removeNode request. We treat this as the
 			// host being temporarily unavailable, and expect it to come back
 			// up.
-			if confirmNodeDown(e.Node.URI, c.logger) {
+			if confirmNodeDown(*e.Node.clusterURI(), c.logger) {
 				if c.removeNodeBasicSorted(e.Node.ID) {
 					c.Topology.nodeStates[e.Node.ID] = nodeStateDown
 					// put the cluster into STARTING if we've lost a number of nodes
@@ -1796,7 +2003,15 @@ func (c *cluster) ReceiveEvent(e *NodeEvent) (err error) {
 func (c *cluster) nodeJoin(node *Node) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	c.logger.Printf("node join event on coordinator, node: %s, id: %s", node.URI, node.ID)
+	c.logger.Printf("node join event on coordinator, node: %s, id: %s, version: %s", node.URI, node.ID, node.Version)
+
+	// Refuse nodes which lack a feature the cluster relies on, such as
+	// nodes running an older version during a rolling upgrade.
+	if missing := c.unprotectedRequiredFeatures(node) &^ node.Features; missing != 0 {
+		err := errors.Wrapf(ErrFeatureUnsupported, "refusing node %s (version %q), missing features %#x", node.ID, node.Version, missing)
+		c.logger.Printf("%v", err)
+		return err
+	}
 	if c.needTopologyAgreement() {
 		// A host that is not part of the topology can't be added to the STARTING cluster.
 		if !c.Topology.ContainsID(node.ID) {
@@ -1839,6 +2054,11 @@ func (c *cluster) nodeJoin(node *Node) error {
 			c.logger.Printf("node: %v changed URI from %s to %s", cnode.ID, cnode.URI, node.URI)
 			cnode.URI = node.URI
 		}
+		cnode.InternalURI = node.InternalURI
+		if cnode.Version != node.Version {
+			c.logger.Printf("node: %v changed version from %q to %q", cnode.ID, cnode.Version, node.Version)
+		}
+		cnode.Version, cnode.Features = node.Version, node.Features
 		return c.unprotectedSetStateAndBroadcast(c.determineClusterState())
 	}
 
@@ -1925,14 +2145,17 @@ func (c *cluster) nodeStatus() *NodeStatus {
 	for _, idx := range ns.Schema.Indexes {
 		is := &IndexStatus{Name: idx.Name}
 		for _, f := range idx.Fields {
+			var lastAccess time.Time
 			if field := c.holder.Field(idx.Name, f.Name); field != nil {
 				availableShards = field.AvailableShards()
+				lastAccess = field.LastAccess()
 			} else {
 				availableShards = roaring.NewBitmap()
 			}
 			is.Fields = append(is.Fields, &FieldStatus{
 				Name:            f.Name,
 				AvailableShards: availableShards,
+				LastAccess:      lastAccess,
 			})
 		}
 		ns.Indexes = append(ns.Indexes, is)
@@ -2116,26 +2339,39 @@ type CreateShardMessage struct {
 
 // CreateIndexMessage is an internal message indicating index creation.
 type CreateIndexMessage struct {
-	Index string
-	Meta  *IndexOptions
+	Index         string
+	Meta          *IndexOptions
+	SchemaVersion uint64
 }
 
 // DeleteIndexMessage is an internal message indicating index deletion.
 type DeleteIndexMessage struct {
-	Index string
+	Index         string
+	SchemaVersion uint64
 }
 
 // CreateFieldMessage is an internal message indicating field creation.
 type CreateFieldMessage struct {
-	Index string
-	Field string
-	Meta  *FieldOptions
+	Index         string
+	Field         string
+	Meta          *FieldOptions
+	SchemaVersion uint64
 }
 
 // DeleteFieldMessage is an internal message indicating field deletion.
 type DeleteFieldMessage struct {
-	Index string
-	Field string
+	Index         string
+	Field         string
+	SchemaVersion uint64
+}
+
+// SchemaChangeRequest is an internal message asking the coordinator to make a
+// schema change. Exactly one of its fields is set.
+type SchemaChangeRequest struct {
+	CreateIndex *CreateIndexMessage
+	DeleteIndex *DeleteIndexMessage
+	CreateField *CreateFieldMessage
+	DeleteField *DeleteFieldMessage
 }
 
 // DeleteAvailableShardMessage is an internal message indicating available shard deletion.
@@ -2159,6 +2395,20 @@ type DeleteViewMessage struct {
 	View  string
 }
 
+// TruncateFieldMessage is an internal message indicating a field's data
+// should be removed.
+type TruncateFieldMessage struct {
+	Index string
+	Field string
+}
+
+// IndexReadOnlyMessage is an internal message indicating an index has been
+// marked read-only, or writable.
+type IndexReadOnlyMessage struct {
+	Index    string
+	ReadOnly bool
+}
+
 // ResizeInstructionComplete is an internal message to the coordinator indicating
 // that the resize instructions performed on a single node have completed.
 type ResizeInstructionComplete struct {
@@ -2185,9 +2435,38 @@ type NodeStateMessage struct {
 
 // NodeStatus is an internal message representing the contents of a node.
 type NodeStatus struct {
-	Node    *Node
-	Indexes []*IndexStatus
-	Schema  *Schema
+	Node     *Node
+	Indexes  []*IndexStatus
+	Schema   *Schema
+	Settings *Settings
+	Load     *NodeLoad
+	Aliases  *Aliases
+
+	// The definitions of the materialized views and rewrite rules of every
+	// index, and those which were deleted.
+	MaterializedViews        []*CreateMaterializedViewMessage
+	DeletedMaterializedViews []*DeleteMaterializedViewMessage
+	RewriteRules             []*CreateRewriteRuleMessage
+	DeletedRewriteRules      []*DeleteRewriteRuleMessage
+
+	// The named queries, and those which were deleted.
+	NamedQueries        []*CreateNamedQueryMessage
+	DeletedNamedQueries []*DeleteNamedQueryMessage
+}
+
+// maxShards returns the maximum available shard of each index in the status.
+func (ns *NodeStatus) maxShards() map[string]uint64 {
+	m := make(map[string]uint64, len(ns.Indexes))
+	for _, is := range ns.Indexes {
+		var max uint64
+		for _, fs := range is.Fields {
+			if fs.AvailableShards != nil && fs.AvailableShards.Max() > max {
+				max = fs.AvailableShards.Max()
+			}
+		}
+		m[is.Name] = max
+	}
+	return m
 }
 
 // IndexStatus is an internal message representing the contents of an index.
@@ -2200,6 +2479,10 @@ type IndexStatus struct {
 type FieldStatus struct {
 	Name            string
 	AvailableShards *roaring.Bitmap
+
+	// LastAccess is the time the field was last queried or imported to,
+	// as far as the node knows.
+	LastAccess time.Time
 }
 
 // RecalculateCaches is an internal message for recalculating all caches
@@ -345,6 +345,33 @@ func TestCluster_Owners(t *testing.T) {
 	}
 }
 
+// Ensure replicas are placed in different zones where possible.
+func TestCluster_Owners_Zones(t *testing.T) {
+	c := cluster{
+		nodes: []*Node{
+			{URI: NewTestURIFromHostPort("serverA", 1000), Zone: "a"},
+			{URI: NewTestURIFromHostPort("serverB", 1000), Zone: "a"},
+			{URI: NewTestURIFromHostPort("serverC", 1000), Zone: "b"},
+			{URI: NewTestURIFromHostPort("serverD", 1000), Zone: "b"},
+		},
+		Hasher:   NewTestModHasher(),
+		ReplicaN: 2,
+	}
+
+	// Verify the next node in the same zone is skipped.
+	if a := c.partitionNodes(0); !reflect.DeepEqual(a, []*Node{c.nodes[0], c.nodes[2]}) {
+		t.Fatalf("unexpected owners: %s", spew.Sdump(a))
+	} else if a := c.partitionNodes(3); !reflect.DeepEqual(a, []*Node{c.nodes[3], c.nodes[0]}) {
+		t.Fatalf("unexpected owners: %s", spew.Sdump(a))
+	}
+
+	// Verify that with more replicas than zones, the skipped nodes are used.
+	c.ReplicaN = 3
+	if a := c.partitionNodes(1); !reflect.DeepEqual(a, []*Node{c.nodes[1], c.nodes[2], c.nodes[3]}) {
+		t.Fatalf("unexpected owners: %s", spew.Sdump(a))
+	}
+}
+
 // Ensure the partitioner can assign a fragment to a partition.
 func TestCluster_Partition(t *testing.T) {
 	if err := quick.Check(func(index string, shard uint64, partitionN int) bool {
@@ -63,6 +63,8 @@ omitted. If it is present then its format should be YYYY-MM-DDTHH:MM.
 	flags.BoolVarP(&Importer.Sort, "sort", "", false, "Enables sorting before import.")
 	flags.BoolVarP(&Importer.CreateSchema, "create", "e", false, "Create the schema if it does not exist before import.")
 	flags.BoolVarP(&Importer.Clear, "clear", "", false, "Clear the data provided in the import.")
+	flags.Var(&Importer.TimeQuantum, "time-quantum", "Import timestamped bits into the time views of only these units of the field's time quantum, such as M for monthly views.")
+	flags.BoolVar(&Importer.SkipStandardView, "skip-standard-view", false, "Import timestamped bits into their time views only, skipping the standard view.")
 	ctl.SetTLSConfig(flags, &Importer.TLS.CertificatePath, &Importer.TLS.CertificateKeyPath, &Importer.TLS.CACertPath, &Importer.TLS.SkipVerify, &Importer.TLS.EnableClientVerification)
 
 	return importCmd
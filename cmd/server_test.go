@@ -132,6 +132,7 @@ func TestServerConfig(t *testing.T) {
 	[metric]
 		service = "statsd"
 		host = "127.0.0.1:8125"
+		sample-rate = 0.25
 	[profile]
 		block-rate = 5352
 		mutex-fraction = 91
@@ -144,6 +145,7 @@ func TestServerConfig(t *testing.T) {
 				v.Check(cmd.Server.Config.LogPath, logFile.Name())
 				v.Check(cmd.Server.Config.Metric.Service, "statsd")
 				v.Check(cmd.Server.Config.Metric.Host, "127.0.0.1:8125")
+				v.Check(cmd.Server.Config.Metric.SampleRate, 0.25)
 				v.Check(cmd.Server.Config.Profile.BlockRate, 5352)
 				v.Check(cmd.Server.Config.Profile.MutexFraction, 91)
 				if v.Error() != nil {
@@ -98,8 +98,12 @@ func (cmd *CheckCommand) checkBitmapFile(path string) (err error) {
 		}
 	}()
 	// Attach the mmap file to the bitmap.
+	body, err := pilosa.FragmentFileBody(data)
+	if err != nil {
+		return errors.Wrap(err, "reading header")
+	}
 	bm := roaring.NewBitmap()
-	if err := bm.UnmarshalBinary(data); err != nil {
+	if err := bm.UnmarshalBinary(body); err != nil {
 		return errors.Wrap(err, "unmarshalling")
 	}
 
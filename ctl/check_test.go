@@ -93,7 +93,7 @@ func TestCheckCommand_Run(t *testing.T) {
 		t.Fatalf("copy: %v", err)
 	}
 
-	expectedPrefix := "checking bitmap: unmarshalling: "
+	expectedPrefix := "checking bitmap: reading header: not a fragment file"
 	if !strings.HasPrefix(err.Error(), expectedPrefix) {
 		t.Fatalf("expect error: '%s...', actual: '%s'", expectedPrefix, err)
 	}
@@ -52,6 +52,12 @@ type ImportCommand struct { // nolint: maligned
 	// Clear clears the import data as opposed to setting it.
 	Clear bool
 
+	// TimeQuantum limits the time views which timestamped bits are
+	// imported into, and SkipStandardView skips their standard view, so
+	// that backfills write only the views they need.
+	TimeQuantum      pilosa.TimeQuantum
+	SkipStandardView bool
+
 	// Filenames to import from.
 	Paths []string `json:"paths"`
 
@@ -260,7 +266,9 @@ func (cmd *ImportCommand) importBits(ctx context.Context, useColumnKeys, useRowK
 	// If keys are used, all bits are sent to the primary translate store (i.e. coordinator).
 	if useColumnKeys || useRowKeys {
 		logger.Printf("importing keys: n=%d", len(bits))
-		if err := cmd.client.ImportK(ctx, cmd.Index, cmd.Field, bits, pilosa.OptImportOptionsClear(cmd.Clear)); err != nil {
+		if err := retryBackpressure(ctx, logger, func() error {
+			return cmd.client.ImportK(ctx, cmd.Index, cmd.Field, bits, cmd.importOptions()...)
+		}); err != nil {
 			return errors.Wrap(err, "importing keys")
 		}
 		return nil
@@ -277,7 +285,9 @@ func (cmd *ImportCommand) importBits(ctx context.Context, useColumnKeys, useRowK
 		}
 
 		logger.Printf("importing shard: %d, n=%d", shard, len(chunk))
-		if err := cmd.client.Import(ctx, cmd.Index, cmd.Field, shard, chunk, pilosa.OptImportOptionsClear(cmd.Clear)); err != nil {
+		if err := retryBackpressure(ctx, logger, func() error {
+			return cmd.client.Import(ctx, cmd.Index, cmd.Field, shard, chunk, cmd.importOptions()...)
+		}); err != nil {
 			return errors.Wrap(err, "importing")
 		}
 	}
@@ -285,6 +295,38 @@ func (cmd *ImportCommand) importBits(ctx context.Context, useColumnKeys, useRowK
 	return nil
 }
 
+// importOptions returns the options of the command's imports of bits.
+func (cmd *ImportCommand) importOptions() []pilosa.ImportOption {
+	opts := []pilosa.ImportOption{
+		pilosa.OptImportOptionsClear(cmd.Clear),
+		pilosa.OptImportOptionsSkipStandardView(cmd.SkipStandardView),
+	}
+	if cmd.TimeQuantum != "" {
+		opts = append(opts, pilosa.OptImportOptionsTimeQuantum(cmd.TimeQuantum))
+	}
+	return opts
+}
+
+// retryBackpressure calls fn until it succeeds, or fails for a reason other
+// than the server's write backlog, waiting as long as the server suggests
+// before each retry, so that imports slow down to the rate the server can
+// keep up with.
+func retryBackpressure(ctx context.Context, logger *log.Logger, fn func() error) error {
+	for {
+		err := fn()
+		delay, ok := pilosa.RetryAfter(err)
+		if !ok {
+			return err
+		}
+		logger.Printf("server is backlogged, retrying in %s", delay)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
 // bufferValues buffers slices of FieldValues to be imported as a batch.
 func (cmd *ImportCommand) bufferValues(ctx context.Context, useColumnKeys bool, path string) error {
 	a := make([]pilosa.FieldValue, 0, cmd.BufferSize)
@@ -365,7 +407,9 @@ func (cmd *ImportCommand) importValues(ctx context.Context, useColumnKeys bool,
 	// If keys are used, all values are sent to the primary translate store (i.e. coordinator).
 	if useColumnKeys {
 		logger.Printf("importing keyed values: n=%d", len(vals))
-		if err := cmd.client.ImportValueK(ctx, cmd.Index, cmd.Field, vals); err != nil {
+		if err := retryBackpressure(ctx, logger, func() error {
+			return cmd.client.ImportValueK(ctx, cmd.Index, cmd.Field, vals)
+		}); err != nil {
 			return errors.Wrap(err, "importing keys")
 		}
 		return nil
@@ -382,7 +426,9 @@ func (cmd *ImportCommand) importValues(ctx context.Context, useColumnKeys bool,
 		}
 
 		logger.Printf("importing shard: %d, n=%d", shard, len(vals))
-		if err := cmd.client.ImportValue(ctx, cmd.Index, cmd.Field, shard, vals, pilosa.OptImportOptionsClear(cmd.Clear)); err != nil {
+		if err := retryBackpressure(ctx, logger, func() error {
+			return cmd.client.ImportValue(ctx, cmd.Index, cmd.Field, shard, vals, pilosa.OptImportOptionsClear(cmd.Clear))
+		}); err != nil {
 			return errors.Wrap(err, "importing values")
 		}
 	}
@@ -73,8 +73,12 @@ func (cmd *InspectCommand) Run(_ context.Context) error {
 	// Attach the mmap file to the bitmap.
 	t := time.Now()
 	fmt.Fprintf(cmd.Stderr, "unmarshalling bitmap...")
+	body, err := pilosa.FragmentFileBody(data)
+	if err != nil {
+		return errors.Wrap(err, "reading header")
+	}
 	bm := roaring.NewBitmap()
-	if err := bm.UnmarshalBinary(data); err != nil {
+	if err := bm.UnmarshalBinary(body); err != nil {
 		return errors.Wrap(err, "unmarshalling")
 	}
 	fmt.Fprintf(cmd.Stderr, " (%s)\n", time.Since(t))
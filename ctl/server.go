@@ -27,7 +27,26 @@ func BuildServerFlags(cmd *cobra.Command, srv *server.Command) {
 	flags.StringVarP(&srv.Config.DataDir, "data-dir", "d", srv.Config.DataDir, "Directory to store pilosa data files.")
 	flags.StringVarP(&srv.Config.Bind, "bind", "b", srv.Config.Bind, "Default URI on which pilosa should listen.")
 	flags.StringVar(&srv.Config.Advertise, "advertise", srv.Config.Advertise, "Address to advertise externally.")
+	flags.StringVar(&srv.Config.BindInternal, "bind-internal", srv.Config.BindInternal, "URI on which pilosa should listen for requests from other nodes, separately from the public API.")
+	flags.StringVar(&srv.Config.AdvertiseInternal, "advertise-internal", srv.Config.AdvertiseInternal, "Address of the internal listener to advertise to other nodes.")
 	flags.IntVarP(&srv.Config.MaxWritesPerRequest, "max-writes-per-request", "", srv.Config.MaxWritesPerRequest, "Number of write commands per request.")
+	flags.IntVar(&srv.Config.ChangeLogSize, "change-log-size", srv.Config.ChangeLogSize, "Number of recent mutations retained for the change data capture stream. 0 disables it.")
+	flags.IntVar(&srv.Config.WorkerPoolSize, "worker-pool-size", srv.Config.WorkerPoolSize, "Number of goroutines which execute queries against local shards.")
+	flags.IntVar(&srv.Config.MaxQueryFanOut, "max-query-fan-out", srv.Config.MaxQueryFanOut, "Maximum number of shards a single query may execute concurrently on a node. 0 means no limit.")
+	flags.IntVar(&srv.Config.MaxRemoteFanOut, "max-remote-fan-out", srv.Config.MaxRemoteFanOut, "Maximum number of requests to other nodes a single query may have outstanding at once. 0 means no limit.")
+	flags.IntVar(&srv.Config.ScanConcurrency, "scan-concurrency", srv.Config.ScanConcurrency, "Number of goroutines used to scan a single shard for Sum(), Rows(), and exact TopN().")
+	flags.DurationVar((*time.Duration)(&srv.Config.TopNCacheTTL), "topn-cache-ttl", time.Duration(srv.Config.TopNCacheTTL), "Duration for which merged TopN() results are reused for identical queries. 0 disables the cache.")
+	flags.Int64Var(&srv.Config.MaxQueryMemory, "max-query-memory", srv.Config.MaxQueryMemory, "Maximum bytes a single query may allocate for intermediate rows on a node. 0 means no limit.")
+	flags.IntVar(&srv.Config.MaxConcurrentQueries, "max-concurrent-queries", srv.Config.MaxConcurrentQueries, "Maximum number of queries which may execute on a node at once. 0 means no limit.")
+	flags.Int64Var(&srv.Config.MemoryLimit, "memory-limit", srv.Config.MemoryLimit, "Approximate bytes of heap memory fragments may use before idle fragments are released. 0 means no limit.")
+	flags.Uint64Var(&srv.Config.HeapHighWater, "heap-high-water", srv.Config.HeapHighWater, "Bytes of heap in use above which batch queries and imports are rejected. 0 disables the check.")
+	flags.BoolVar(&srv.Config.LazyFragments, "lazy-fragments", srv.Config.LazyFragments, "Load the data of each fragment when it is first accessed, rather than on startup.")
+	flags.IntVar(&srv.Config.MaxOpenFragmentFiles, "max-open-fragment-files", srv.Config.MaxOpenFragmentFiles, "Number of fragment files which may be held open at once, beyond which the least recently written are closed. 0 means no limit.")
+	flags.DurationVar((*time.Duration)(&srv.Config.GroupCommitWindow), "group-commit-window", time.Duration(srv.Config.GroupCommitWindow), "Window within which concurrent single-bit writes to a fragment are applied together. 0 disables grouping.")
+	flags.StringVar(&srv.Config.Fsync, "fsync", srv.Config.Fsync, "When writes to fragment files are flushed to disk: always, interval or never.")
+	flags.DurationVar((*time.Duration)(&srv.Config.FsyncInterval), "fsync-interval", time.Duration(srv.Config.FsyncInterval), "Interval at which writes to fragment files are flushed to disk, under the interval fsync policy.")
+	flags.Uint64Var(&srv.Config.MinDiskFree, "min-disk-free", srv.Config.MinDiskFree, "Free bytes on the data directory's file system below which writes are rejected. 0 disables the check.")
+	flags.DurationVar((*time.Duration)(&srv.Config.AttrExpiryInterval), "attr-expiry-interval", time.Duration(srv.Config.AttrExpiryInterval), "Interval at which expired row and column attributes are removed. 0 disables their removal.")
 	flags.StringVar(&srv.Config.LogPath, "log-path", srv.Config.LogPath, "Log path")
 	flags.BoolVar(&srv.Config.Verbose, "verbose", srv.Config.Verbose, "Enable verbose logging")
 	flags.Uint64Var(&srv.Config.MaxMapCount, "max-map-count", srv.Config.MaxMapCount, "Limits the maximum number of active mmaps. Pilosa will fall back to reading files once this is exhausted. Set below your system's vm.max_map_count.")
@@ -38,11 +57,25 @@ func BuildServerFlags(cmd *cobra.Command, srv *server.Command) {
 
 	// Handler
 	flags.StringSliceVarP(&srv.Config.Handler.AllowedOrigins, "handler.allowed-origins", "", []string{}, "Comma separated list of allowed origin URIs (for CORS/WebUI).")
+	flags.Uint64Var(&srv.Config.Handler.StreamThreshold, "handler.stream-threshold", srv.Config.Handler.StreamThreshold, "Number of columns of a row above which query responses are streamed. 0 disables streaming.")
+
+	// Secondary listener
+	flags.StringVar(&srv.Config.Secondary.Bind, "secondary.bind", srv.Config.Secondary.Bind, "Additional URI on which pilosa should serve the API, such as a plaintext one alongside TLS.")
+	flags.StringSliceVar(&srv.Config.Secondary.AllowedOrigins, "secondary.allowed-origins", []string{}, "Comma separated list of allowed origin URIs (for CORS/WebUI) of the secondary listener.")
+
+	// Webhooks
+	flags.StringSliceVar(&srv.Config.SchemaWebhooks, "schema-webhooks", srv.Config.SchemaWebhooks, "Comma separated list of URLs notified when indexes or fields are created or deleted.")
 
 	// Cluster
 	flags.BoolVarP(&srv.Config.Cluster.Disabled, "cluster.disabled", "", srv.Config.Cluster.Disabled, "Disabled multi-node cluster communication (used for testing)")
 	flags.BoolVarP(&srv.Config.Cluster.Coordinator, "cluster.coordinator", "", srv.Config.Cluster.Coordinator, "Host that will act as cluster coordinator during startup and resizing.")
 	flags.IntVarP(&srv.Config.Cluster.ReplicaN, "cluster.replicas", "", 1, "Number of hosts each piece of data should be stored on.")
+	flags.StringVarP(&srv.Config.Cluster.Zone, "cluster.zone", "", srv.Config.Cluster.Zone, "Zone, such as a rack or availability zone, which this host is in. Replicas are placed in different zones where possible.")
+	flags.DurationVarP((*time.Duration)(&srv.Config.Cluster.HeartbeatInterval), "cluster.heartbeat-interval", "", time.Duration(srv.Config.Cluster.HeartbeatInterval), "Interval at which hosts send each other heartbeats. Zero disables failure detection.")
+	flags.Float64VarP(&srv.Config.Cluster.SuspectThreshold, "cluster.suspect-threshold", "", srv.Config.Cluster.SuspectThreshold, "Suspicion (phi) that a host has failed at which it is considered SUSPECT.")
+	flags.Float64VarP(&srv.Config.Cluster.DownThreshold, "cluster.down-threshold", "", srv.Config.Cluster.DownThreshold, "Suspicion (phi) that a host has failed at which it is considered DOWN.")
+	flags.StringVarP(&srv.Config.Cluster.Compression, "cluster.compression", "", srv.Config.Cluster.Compression, "Codec which hosts compress the data they send each other with: zstd, snappy, or none.")
+	flags.DurationVarP((*time.Duration)(&srv.Config.Cluster.RebalanceInterval), "cluster.rebalance-interval", "", time.Duration(srv.Config.Cluster.RebalanceInterval), "Interval at which the coordinator moves the reads of hot shards to other replicas. Zero disables rebalancing.")
 	flags.StringSliceVarP(&srv.Config.Cluster.Hosts, "cluster.hosts", "", []string{}, "Comma separated list of hosts in cluster. Only used for testing.")
 	flags.DurationVarP((*time.Duration)(&srv.Config.Cluster.LongQueryTime), "cluster.long-query-time", "", time.Minute, "Duration that will trigger log and stat messages for slow queries.")
 
@@ -69,12 +102,23 @@ func BuildServerFlags(cmd *cobra.Command, srv *server.Command) {
 	// AntiEntropy
 	flags.DurationVarP((*time.Duration)(&srv.Config.AntiEntropy.Interval), "anti-entropy.interval", "", (time.Duration)(srv.Config.AntiEntropy.Interval), "Interval at which to run anti-entropy routine.")
 
+	// CacheWarmup
+	flags.DurationVar((*time.Duration)(&srv.Config.CacheWarmup.Timeout), "cache-warmup.timeout", (time.Duration)(srv.Config.CacheWarmup.Timeout), "Time to spend warming fragment caches for TopN() on startup. 0 disables warming.")
+	flags.IntVar(&srv.Config.CacheWarmup.Concurrency, "cache-warmup.concurrency", srv.Config.CacheWarmup.Concurrency, "Number of fragments whose caches are warmed at once on startup.")
+
 	// Metric
 	flags.StringVarP(&srv.Config.Metric.Service, "metric.service", "", srv.Config.Metric.Service, "Where to send stats: can be expvar (in-memory served at /debug/vars), statsd or none.")
 	flags.StringVarP(&srv.Config.Metric.Host, "metric.host", "", srv.Config.Metric.Host, "URI to send metrics when metric.service is statsd.")
+	flags.Float64VarP(&srv.Config.Metric.SampleRate, "metric.sample-rate", "", srv.Config.Metric.SampleRate, "Fraction of events to send when metric.service is statsd.")
 	flags.DurationVarP((*time.Duration)(&srv.Config.Metric.PollInterval), "metric.poll-interval", "", (time.Duration)(srv.Config.Metric.PollInterval), "Polling interval metrics.")
 	flags.BoolVarP((&srv.Config.Metric.Diagnostics), "metric.diagnostics", "", srv.Config.Metric.Diagnostics, "Enabled diagnostics reporting.")
 
+	// Quota
+	flags.StringVarP(&srv.Config.Quota.Period, "quota.period", "", srv.Config.Quota.Period, "Period after which API token usage is reset: month or 182d.")
+	flags.Int64VarP(&srv.Config.Quota.Queries, "quota.queries", "", srv.Config.Quota.Queries, "Number of queries each API token may issue per period. 0 means no limit.")
+	flags.Int64VarP(&srv.Config.Quota.Containers, "quota.containers", "", srv.Config.Quota.Containers, "Number of containers the queries of each API token may scan per period. 0 means no limit.")
+	flags.Int64VarP(&srv.Config.Quota.ImportBytes, "quota.import-bytes", "", srv.Config.Quota.ImportBytes, "Number of bytes each API token may import per period. 0 means no limit.")
+
 	// Tracing
 	flags.StringVarP(&srv.Config.Tracing.AgentHostPort, "tracing.agent-host-port", "", srv.Config.Tracing.AgentHostPort, "Jaeger agent host:port.")
 	flags.StringVarP(&srv.Config.Tracing.SamplerType, "tracing.sampler-type", "", srv.Config.Tracing.SamplerType, "Jaeger sampler type or 'off' to disable tracing completely.")
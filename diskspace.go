@@ -0,0 +1,168 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"sort"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// defaultDiskCheckInterval is the interval at which free disk space is
+// checked when a minimum is configured.
+const defaultDiskCheckInterval = 10 * time.Second
+
+// ErrInsufficientDiskSpace is returned for writes to a node which has
+// disabled writes because it is low on disk space.
+var ErrInsufficientDiskSpace = errors.New("insufficient disk space, writes are disabled")
+
+// NodeReadOnlyMessage is an internal message broadcast when a node disables
+// or re-enables writes because of the free space on its data directory.
+type NodeReadOnlyMessage struct {
+	NodeID   string
+	ReadOnly bool
+}
+
+// diskFree returns the number of bytes available to unprivileged users on
+// the file system containing path.
+func diskFree(path string) (uint64, error) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(path, &st); err != nil {
+		return 0, err
+	}
+	return st.Bavail * uint64(st.Bsize), nil
+}
+
+// diskUsed returns the number of bytes used on the file system containing
+// path.
+func diskUsed(path string) (uint64, error) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(path, &st); err != nil {
+		return 0, err
+	}
+	return (st.Blocks - st.Bfree) * uint64(st.Bsize), nil
+}
+
+// monitorDiskSpace periodically checks the free space under the data
+// directory. This is run in a goroutine.
+func (s *Server) monitorDiskSpace() {
+	ticker := time.NewTicker(s.diskCheckInterval)
+	defer ticker.Stop()
+
+	// Always broadcast the initial state, in case other nodes remember this
+	// node as read-only from before it restarted.
+	s.checkDiskSpace(true)
+	for {
+		select {
+		case <-s.closing:
+			return
+		case <-ticker.C:
+			s.checkDiskSpace(false)
+		}
+	}
+}
+
+// checkDiskSpace disables writes to this node if free disk space is below
+// the configured minimum, and re-enables them once it has recovered. Changes
+// are broadcast so that other nodes stop forwarding writes to this node. The
+// read-only state is rebroadcast on every check so that nodes which joined
+// since it changed learn of it.
+func (s *Server) checkDiskSpace(broadcast bool) {
+	free, err := s.diskFree(s.holder.Path)
+	if err != nil {
+		s.logger.Printf("checking free disk space: %s", err)
+		return
+	}
+	s.holder.Stats.Gauge("diskFree", float64(free), 1.0)
+
+	readOnly := free < s.minDiskFree
+	if s.cluster.setNodeReadOnly(s.cluster.Node.ID, readOnly) {
+		if readOnly {
+			s.logger.Printf("free disk space %d bytes is below %d bytes, disabling writes", free, s.minDiskFree)
+		} else {
+			s.logger.Printf("free disk space %d bytes has recovered, enabling writes", free)
+		}
+		broadcast = true
+	}
+	if !broadcast && !readOnly {
+		return
+	}
+
+	if err := s.SendSync(&NodeReadOnlyMessage{NodeID: s.cluster.Node.ID, ReadOnly: readOnly}); err != nil {
+		s.logger.Printf("broadcasting read-only state: %s", err)
+	}
+}
+
+// setNodeReadOnly records whether the node has disabled writes. Returns
+// true if the state changed.
+func (c *cluster) setNodeReadOnly(nodeID string, readOnly bool) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.readOnlyNodes[nodeID]
+	if ok == readOnly {
+		return false
+	}
+	if readOnly {
+		c.readOnlyNodes[nodeID] = struct{}{}
+	} else {
+		delete(c.readOnlyNodes, nodeID)
+	}
+	return true
+}
+
+// nodeReadOnly returns true if the node has disabled writes.
+func (c *cluster) nodeReadOnly(nodeID string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	_, ok := c.readOnlyNodes[nodeID]
+	return ok
+}
+
+// readOnlyNodeIDs returns the sorted IDs of nodes which have disabled writes.
+func (c *cluster) readOnlyNodeIDs() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	ids := make([]string, 0, len(c.readOnlyNodes))
+	for id := range c.readOnlyNodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// validateWritable returns ErrInsufficientDiskSpace if any of nodes has
+// disabled writes.
+func (api *API) validateWritable(nodes ...*Node) error {
+	return api.cluster.validateWritable(nodes...)
+}
+
+// validateWritable returns ErrInsufficientDiskSpace if any of nodes has
+// disabled writes.
+func (c *cluster) validateWritable(nodes ...*Node) error {
+	for _, node := range nodes {
+		if c.nodeReadOnly(node.ID) {
+			return errors.Wrapf(ErrInsufficientDiskSpace, "node %s", node.ID)
+		}
+	}
+	return nil
+}
+
+// ReadOnlyNodes returns the IDs of nodes which have disabled writes because
+// they are low on disk space.
+func (api *API) ReadOnlyNodes() []string {
+	return api.cluster.readOnlyNodeIDs()
+}
@@ -0,0 +1,305 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proto
+
+import (
+	"io"
+
+	"github.com/pilosa/pilosa/v2"
+	"github.com/pkg/errors"
+)
+
+// Import requests can hold millions of ids, so they are decoded directly
+// into the pilosa types rather than through the generated internal types.
+// Repeated fields are sized before they are filled, and the capacity of
+// any slices already in the destination is reused, so decoding into a
+// pooled request does not allocate per id.
+
+// Protobuf wire types.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+var errInvalidWireType = errors.New("invalid wire type")
+
+// unmarshalImportRequest decodes an internal.ImportRequest from buf into m.
+func unmarshalImportRequest(buf []byte, m *pilosa.ImportRequest) error {
+	m.Shard = 0
+	m.RowIDs = m.RowIDs[:0]
+	m.ColumnIDs = m.ColumnIDs[:0]
+	m.RowKeys = m.RowKeys[:0]
+	m.ColumnKeys = m.ColumnKeys[:0]
+	m.Timestamps = m.Timestamps[:0]
+
+	d := decoder{buf: buf}
+	var index, field []byte
+	for !d.done() {
+		num, typ, err := d.key()
+		if err != nil {
+			return err
+		}
+		switch num {
+		case 1:
+			index, err = d.bytes(typ)
+		case 2:
+			field, err = d.bytes(typ)
+		case 3:
+			m.Shard, err = d.uint64(typ)
+		case 4:
+			m.RowIDs, err = d.uint64s(typ, m.RowIDs)
+		case 5:
+			m.ColumnIDs, err = d.uint64s(typ, m.ColumnIDs)
+		case 6:
+			m.Timestamps, err = d.int64s(typ, m.Timestamps)
+		case 7:
+			m.RowKeys, err = d.appendString(typ, m.RowKeys)
+		case 8:
+			m.ColumnKeys, err = d.appendString(typ, m.ColumnKeys)
+		default:
+			err = d.skip(typ)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "decoding field %d", num)
+		}
+	}
+
+	// Comparing before converting avoids allocating when a pooled request
+	// is reused for the same index and field.
+	if m.Index != string(index) {
+		m.Index = string(index)
+	}
+	if m.Field != string(field) {
+		m.Field = string(field)
+	}
+	return nil
+}
+
+// unmarshalImportValueRequest decodes an internal.ImportValueRequest from
+// buf into m.
+func unmarshalImportValueRequest(buf []byte, m *pilosa.ImportValueRequest) error {
+	m.Shard = 0
+	m.ColumnIDs = m.ColumnIDs[:0]
+	m.ColumnKeys = m.ColumnKeys[:0]
+	m.Values = m.Values[:0]
+
+	d := decoder{buf: buf}
+	var index, field []byte
+	for !d.done() {
+		num, typ, err := d.key()
+		if err != nil {
+			return err
+		}
+		switch num {
+		case 1:
+			index, err = d.bytes(typ)
+		case 2:
+			field, err = d.bytes(typ)
+		case 3:
+			m.Shard, err = d.uint64(typ)
+		case 5:
+			m.ColumnIDs, err = d.uint64s(typ, m.ColumnIDs)
+		case 6:
+			m.Values, err = d.int64s(typ, m.Values)
+		case 7:
+			m.ColumnKeys, err = d.appendString(typ, m.ColumnKeys)
+		default:
+			err = d.skip(typ)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "decoding field %d", num)
+		}
+	}
+
+	if m.Index != string(index) {
+		m.Index = string(index)
+	}
+	if m.Field != string(field) {
+		m.Field = string(field)
+	}
+	return nil
+}
+
+// decoder reads protobuf wire format values from buf.
+type decoder struct {
+	buf []byte
+	i   int
+}
+
+func (d *decoder) done() bool { return d.i >= len(d.buf) }
+
+// varint reads a base 128 varint.
+func (d *decoder) varint() (uint64, error) {
+	var v uint64
+	for shift := uint(0); ; shift += 7 {
+		if shift >= 64 {
+			return 0, errors.New("varint overflow")
+		} else if d.i >= len(d.buf) {
+			return 0, io.ErrUnexpectedEOF
+		}
+		b := d.buf[d.i]
+		d.i++
+		v |= uint64(b&0x7F) << shift
+		if b < 0x80 {
+			return v, nil
+		}
+	}
+}
+
+// key reads a field key.
+func (d *decoder) key() (num uint64, typ int, err error) {
+	v, err := d.varint()
+	if err != nil {
+		return 0, 0, err
+	}
+	num, typ = v>>3, int(v&0x7)
+	if num == 0 {
+		return 0, 0, errors.New("illegal field number 0")
+	}
+	return num, typ, nil
+}
+
+// bytes reads a length-delimited value. The returned slice refers to buf.
+func (d *decoder) bytes(typ int) ([]byte, error) {
+	if typ != wireBytes {
+		return nil, errInvalidWireType
+	}
+	n, err := d.varint()
+	if err != nil {
+		return nil, err
+	} else if n > uint64(len(d.buf)-d.i) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	b := d.buf[d.i : d.i+int(n)]
+	d.i += int(n)
+	return b, nil
+}
+
+func (d *decoder) uint64(typ int) (uint64, error) {
+	if typ != wireVarint {
+		return 0, errInvalidWireType
+	}
+	return d.varint()
+}
+
+// uint64s appends a packed or unpacked repeated varint field to a.
+func (d *decoder) uint64s(typ int, a []uint64) ([]uint64, error) {
+	if typ == wireVarint {
+		v, err := d.varint()
+		return append(a, v), err
+	}
+
+	b, err := d.bytes(typ)
+	if err != nil {
+		return a, err
+	}
+	a = growUint64s(a, varintCount(b))
+	sub := decoder{buf: b}
+	for !sub.done() {
+		v, err := sub.varint()
+		if err != nil {
+			return a, err
+		}
+		a = append(a, v)
+	}
+	return a, nil
+}
+
+// int64s appends a packed or unpacked repeated int64 field to a.
+func (d *decoder) int64s(typ int, a []int64) ([]int64, error) {
+	if typ == wireVarint {
+		v, err := d.varint()
+		return append(a, int64(v)), err
+	}
+
+	b, err := d.bytes(typ)
+	if err != nil {
+		return a, err
+	}
+	a = growInt64s(a, varintCount(b))
+	sub := decoder{buf: b}
+	for !sub.done() {
+		v, err := sub.varint()
+		if err != nil {
+			return a, err
+		}
+		a = append(a, int64(v))
+	}
+	return a, nil
+}
+
+// appendString appends a string field to a.
+func (d *decoder) appendString(typ int, a []string) ([]string, error) {
+	b, err := d.bytes(typ)
+	if err != nil {
+		return a, err
+	}
+	return append(a, string(b)), nil
+}
+
+// skip discards a value of an unknown field.
+func (d *decoder) skip(typ int) error {
+	switch typ {
+	case wireVarint:
+		_, err := d.varint()
+		return err
+	case wireFixed64:
+		d.i += 8
+	case wireBytes:
+		_, err := d.bytes(typ)
+		return err
+	case wireFixed32:
+		d.i += 4
+	default:
+		return errInvalidWireType
+	}
+	if d.i > len(d.buf) {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
+// varintCount returns the number of varints encoded in b.
+func varintCount(b []byte) int {
+	n := 0
+	for _, c := range b {
+		if c < 0x80 {
+			n++
+		}
+	}
+	return n
+}
+
+// growUint64s ensures a has capacity for n more elements.
+func growUint64s(a []uint64, n int) []uint64 {
+	if len(a)+n <= cap(a) {
+		return a
+	}
+	other := make([]uint64, len(a), len(a)+n)
+	copy(other, a)
+	return other
+}
+
+// growInt64s ensures a has capacity for n more elements.
+func growInt64s(a []int64, n int) []int64 {
+	if len(a)+n <= cap(a) {
+		return a
+	}
+	other := make([]int64, len(a), len(a)+n)
+	copy(other, a)
+	return other
+}
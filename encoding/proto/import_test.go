@@ -0,0 +1,120 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proto_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pilosa/pilosa/v2"
+	"github.com/pilosa/pilosa/v2/encoding/proto"
+)
+
+func TestSerializer_ImportRequest(t *testing.T) {
+	s := proto.Serializer{}
+
+	exp := &pilosa.ImportRequest{
+		Index:      "i",
+		Field:      "f",
+		Shard:      3,
+		RowIDs:     []uint64{1, 1 << 40, 0},
+		ColumnIDs:  []uint64{3<<20 + 1, 3<<20 + 200, 3 << 20},
+		Timestamps: []int64{0, 1546300800000000000, -1},
+		RowKeys:    []string{"a", ""},
+		ColumnKeys: []string{"x"},
+	}
+	buf, err := s.Marshal(exp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := &pilosa.ImportRequest{}
+	if err := s.Unmarshal(buf, got); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(got, exp) {
+		t.Fatalf("unexpected request: %#v", got)
+	}
+
+	// Decoding into a used request must replace its contents.
+	other := &pilosa.ImportRequest{Index: "j", Field: "g", Shard: 1, ColumnIDs: []uint64{1}}
+	buf, err = s.Marshal(other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Unmarshal(buf, got); err != nil {
+		t.Fatal(err)
+	} else if got.Index != "j" || got.Field != "g" || got.Shard != 1 {
+		t.Fatalf("unexpected request: %#v", got)
+	} else if len(got.RowIDs) != 0 || len(got.Timestamps) != 0 || len(got.RowKeys) != 0 || len(got.ColumnKeys) != 0 {
+		t.Fatalf("unexpected stale values: %#v", got)
+	} else if !reflect.DeepEqual(got.ColumnIDs, []uint64{1}) {
+		t.Fatalf("unexpected columns: %v", got.ColumnIDs)
+	}
+
+	// Truncated input is an error.
+	if err := s.Unmarshal(buf[:len(buf)-1], got); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+// Ensure unpacked repeated fields, which other encoders may produce, are
+// decoded.
+func TestSerializer_ImportValueRequest_Unpacked(t *testing.T) {
+	buf := []byte{
+		0x0a, 0x01, 'i', // index
+		0x12, 0x01, 'f', // field
+		0x18, 0x02, // shard
+		0x28, 0x05, 0x28, 0x07, // column ids
+		0x30, 0x7f, // values
+		0x30, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01,
+		0xa0, 0x06, 0x01, // unknown field 100
+	}
+
+	got := &pilosa.ImportValueRequest{}
+	if err := (proto.Serializer{}).Unmarshal(buf, got); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(got, &pilosa.ImportValueRequest{
+		Index:     "i",
+		Field:     "f",
+		Shard:     2,
+		ColumnIDs: []uint64{5, 7},
+		Values:    []int64{127, -1},
+	}) {
+		t.Fatalf("unexpected request: %#v", got)
+	}
+}
+
+func BenchmarkSerializer_Unmarshal_ImportRequest(b *testing.B) {
+	s := proto.Serializer{}
+	req := &pilosa.ImportRequest{Index: "i", Field: "f"}
+	for i := 0; i < 100000; i++ {
+		req.RowIDs = append(req.RowIDs, uint64(i%100))
+		req.ColumnIDs = append(req.ColumnIDs, uint64(i*7))
+	}
+	buf, err := s.Marshal(req)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	dst := &pilosa.ImportRequest{}
+	b.ReportAllocs()
+	b.SetBytes(int64(len(buf)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := s.Unmarshal(buf, dst); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -17,11 +17,13 @@ package proto
 import (
 	"fmt"
 	"sort"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/pilosa/pilosa/v2"
 	"github.com/pilosa/pilosa/v2/internal"
 	"github.com/pilosa/pilosa/v2/roaring"
+	"github.com/pilosa/pilosa/v2/toml"
 	"github.com/pkg/errors"
 )
 
@@ -105,6 +107,170 @@ func (Serializer) Unmarshal(buf []byte, m pilosa.Message) error {
 		}
 		decodeDeleteViewMessage(msg, mt)
 		return nil
+	case *pilosa.TruncateFieldMessage:
+		msg := &internal.TruncateFieldMessage{}
+		err := proto.Unmarshal(buf, msg)
+		if err != nil {
+			return errors.Wrap(err, "unmarshaling TruncateFieldMessage")
+		}
+		decodeTruncateFieldMessage(msg, mt)
+		return nil
+	case *pilosa.IndexReadOnlyMessage:
+		msg := &internal.IndexReadOnlyMessage{}
+		err := proto.Unmarshal(buf, msg)
+		if err != nil {
+			return errors.Wrap(err, "unmarshaling IndexReadOnlyMessage")
+		}
+		decodeIndexReadOnlyMessage(msg, mt)
+		return nil
+	case *pilosa.HeartbeatMessage:
+		msg := &internal.HeartbeatMessage{}
+		err := proto.Unmarshal(buf, msg)
+		if err != nil {
+			return errors.Wrap(err, "unmarshaling HeartbeatMessage")
+		}
+		mt.NodeID = msg.NodeID
+		return nil
+	case *pilosa.CreateMaterializedViewMessage:
+		msg := &internal.CreateMaterializedViewMessage{}
+		err := proto.Unmarshal(buf, msg)
+		if err != nil {
+			return errors.Wrap(err, "unmarshaling CreateMaterializedViewMessage")
+		}
+		decodeCreateMaterializedViewMessage(msg, mt)
+		return nil
+	case *pilosa.DeleteMaterializedViewMessage:
+		msg := &internal.DeleteMaterializedViewMessage{}
+		err := proto.Unmarshal(buf, msg)
+		if err != nil {
+			return errors.Wrap(err, "unmarshaling DeleteMaterializedViewMessage")
+		}
+		decodeDeleteMaterializedViewMessage(msg, mt)
+		return nil
+	case *pilosa.SettingsMessage:
+		msg := &internal.SettingsMessage{}
+		err := proto.Unmarshal(buf, msg)
+		if err != nil {
+			return errors.Wrap(err, "unmarshaling SettingsMessage")
+		}
+		decodeSettings(msg.Settings, &mt.Settings)
+		return nil
+	case *pilosa.AliasesMessage:
+		msg := &internal.AliasesMessage{}
+		err := proto.Unmarshal(buf, msg)
+		if err != nil {
+			return errors.Wrap(err, "unmarshaling AliasesMessage")
+		}
+		decodeAliasesMessage(msg, mt)
+		return nil
+	case *pilosa.CreateRewriteRuleMessage:
+		msg := &internal.CreateRewriteRuleMessage{}
+		err := proto.Unmarshal(buf, msg)
+		if err != nil {
+			return errors.Wrap(err, "unmarshaling CreateRewriteRuleMessage")
+		}
+		decodeCreateRewriteRuleMessage(msg, mt)
+		return nil
+	case *pilosa.DeleteRewriteRuleMessage:
+		msg := &internal.DeleteRewriteRuleMessage{}
+		err := proto.Unmarshal(buf, msg)
+		if err != nil {
+			return errors.Wrap(err, "unmarshaling DeleteRewriteRuleMessage")
+		}
+		decodeDeleteRewriteRuleMessage(msg, mt)
+		return nil
+	case *pilosa.CreateNamedQueryMessage:
+		msg := &internal.CreateNamedQueryMessage{}
+		err := proto.Unmarshal(buf, msg)
+		if err != nil {
+			return errors.Wrap(err, "unmarshaling CreateNamedQueryMessage")
+		}
+		decodeCreateNamedQueryMessage(msg, mt)
+		return nil
+	case *pilosa.DeleteNamedQueryMessage:
+		msg := &internal.DeleteNamedQueryMessage{}
+		err := proto.Unmarshal(buf, msg)
+		if err != nil {
+			return errors.Wrap(err, "unmarshaling DeleteNamedQueryMessage")
+		}
+		decodeDeleteNamedQueryMessage(msg, mt)
+		return nil
+	case *pilosa.CreateScheduleMessage:
+		msg := &internal.CreateScheduleMessage{}
+		err := proto.Unmarshal(buf, msg)
+		if err != nil {
+			return errors.Wrap(err, "unmarshaling CreateScheduleMessage")
+		}
+		if msg.Schedule != nil {
+			decodeSchedule(msg.Schedule, &mt.Schedule)
+		}
+		return nil
+	case *pilosa.DeleteScheduleMessage:
+		msg := &internal.DeleteScheduleMessage{}
+		err := proto.Unmarshal(buf, msg)
+		if err != nil {
+			return errors.Wrap(err, "unmarshaling DeleteScheduleMessage")
+		}
+		mt.Name = msg.Name
+		return nil
+	case *pilosa.ScheduleRunMessage:
+		msg := &internal.ScheduleRunMessage{}
+		err := proto.Unmarshal(buf, msg)
+		if err != nil {
+			return errors.Wrap(err, "unmarshaling ScheduleRunMessage")
+		}
+		mt.Name = msg.Name
+		mt.Run = pilosa.ScheduleRun{Start: time.Unix(0, msg.Start).UTC(), Duration: time.Duration(msg.Duration), Error: msg.Error}
+		return nil
+	case *pilosa.SchemaChangeRequest:
+		msg := &internal.SchemaChangeRequest{}
+		err := proto.Unmarshal(buf, msg)
+		if err != nil {
+			return errors.Wrap(err, "unmarshaling SchemaChangeRequest")
+		}
+		decodeSchemaChangeRequest(msg, mt)
+		return nil
+	case *pilosa.LoadUDFMessage:
+		msg := &internal.LoadUDFMessage{}
+		err := proto.Unmarshal(buf, msg)
+		if err != nil {
+			return errors.Wrap(err, "unmarshaling LoadUDFMessage")
+		}
+		decodeLoadUDFMessage(msg, mt)
+		return nil
+	case *pilosa.DeleteUDFMessage:
+		msg := &internal.DeleteUDFMessage{}
+		err := proto.Unmarshal(buf, msg)
+		if err != nil {
+			return errors.Wrap(err, "unmarshaling DeleteUDFMessage")
+		}
+		decodeDeleteUDFMessage(msg, mt)
+		return nil
+	case *pilosa.NodeReadOnlyMessage:
+		msg := &internal.NodeReadOnlyMessage{}
+		err := proto.Unmarshal(buf, msg)
+		if err != nil {
+			return errors.Wrap(err, "unmarshaling NodeReadOnlyMessage")
+		}
+		decodeNodeReadOnlyMessage(msg, mt)
+		return nil
+	case *pilosa.ReadOwnersMessage:
+		msg := &internal.ReadOwnersMessage{}
+		err := proto.Unmarshal(buf, msg)
+		if err != nil {
+			return errors.Wrap(err, "unmarshaling ReadOwnersMessage")
+		}
+		decodeReadOwnersMessage(msg, mt)
+		return nil
+	case *pilosa.InvalidateTopNMessage:
+		msg := &internal.InvalidateTopNMessage{}
+		err := proto.Unmarshal(buf, msg)
+		if err != nil {
+			return errors.Wrap(err, "unmarshaling InvalidateTopNMessage")
+		}
+		mt.Index = msg.Index
+		mt.Fields = msg.Fields
+		return nil
 	case *pilosa.ClusterStatus:
 		msg := &internal.ClusterStatus{}
 		err := proto.Unmarshal(buf, msg)
@@ -202,21 +368,11 @@ func (Serializer) Unmarshal(buf []byte, m pilosa.Message) error {
 		decodeQueryResponse(msg, mt)
 		return nil
 	case *pilosa.ImportRequest:
-		msg := &internal.ImportRequest{}
-		err := proto.Unmarshal(buf, msg)
-		if err != nil {
-			return errors.Wrap(err, "unmarshaling ImportRequest")
-		}
-		decodeImportRequest(msg, mt)
-		return nil
+		err := unmarshalImportRequest(buf, mt)
+		return errors.Wrap(err, "unmarshaling ImportRequest")
 	case *pilosa.ImportValueRequest:
-		msg := &internal.ImportValueRequest{}
-		err := proto.Unmarshal(buf, msg)
-		if err != nil {
-			return errors.Wrap(err, "unmarshaling ImportValueRequest")
-		}
-		decodeImportValueRequest(msg, mt)
-		return nil
+		err := unmarshalImportValueRequest(buf, mt)
+		return errors.Wrap(err, "unmarshaling ImportValueRequest")
 	case *pilosa.ImportRoaringRequest:
 		msg := &internal.ImportRoaringRequest{}
 		err := proto.Unmarshal(buf, msg)
@@ -288,6 +444,46 @@ func encodeToProto(m pilosa.Message) proto.Message {
 		return encodeCreateViewMessage(mt)
 	case *pilosa.DeleteViewMessage:
 		return encodeDeleteViewMessage(mt)
+	case *pilosa.LoadUDFMessage:
+		return encodeLoadUDFMessage(mt)
+	case *pilosa.TruncateFieldMessage:
+		return encodeTruncateFieldMessage(mt)
+	case *pilosa.IndexReadOnlyMessage:
+		return encodeIndexReadOnlyMessage(mt)
+	case *pilosa.HeartbeatMessage:
+		return &internal.HeartbeatMessage{NodeID: mt.NodeID}
+	case *pilosa.CreateMaterializedViewMessage:
+		return encodeCreateMaterializedViewMessage(mt)
+	case *pilosa.DeleteMaterializedViewMessage:
+		return encodeDeleteMaterializedViewMessage(mt)
+	case *pilosa.SettingsMessage:
+		return &internal.SettingsMessage{Settings: encodeSettings(&mt.Settings)}
+	case *pilosa.AliasesMessage:
+		return encodeAliasesMessage(mt)
+	case *pilosa.CreateRewriteRuleMessage:
+		return encodeCreateRewriteRuleMessage(mt)
+	case *pilosa.DeleteRewriteRuleMessage:
+		return encodeDeleteRewriteRuleMessage(mt)
+	case *pilosa.CreateNamedQueryMessage:
+		return encodeCreateNamedQueryMessage(mt)
+	case *pilosa.DeleteNamedQueryMessage:
+		return encodeDeleteNamedQueryMessage(mt)
+	case *pilosa.CreateScheduleMessage:
+		return &internal.CreateScheduleMessage{Schedule: encodeSchedule(&mt.Schedule)}
+	case *pilosa.DeleteScheduleMessage:
+		return &internal.DeleteScheduleMessage{Name: mt.Name}
+	case *pilosa.ScheduleRunMessage:
+		return &internal.ScheduleRunMessage{Name: mt.Name, Start: mt.Run.Start.UnixNano(), Duration: int64(mt.Run.Duration), Error: mt.Run.Error}
+	case *pilosa.SchemaChangeRequest:
+		return encodeSchemaChangeRequest(mt)
+	case *pilosa.DeleteUDFMessage:
+		return encodeDeleteUDFMessage(mt)
+	case *pilosa.NodeReadOnlyMessage:
+		return encodeNodeReadOnlyMessage(mt)
+	case *pilosa.ReadOwnersMessage:
+		return encodeReadOwnersMessage(mt)
+	case *pilosa.InvalidateTopNMessage:
+		return &internal.InvalidateTopNMessage{Index: mt.Index, Fields: mt.Fields}
 	case *pilosa.ClusterStatus:
 		return encodeClusterStatus(mt)
 	case *pilosa.ResizeInstruction:
@@ -334,18 +530,24 @@ func encodeToProto(m pilosa.Message) proto.Message {
 
 func encodeBlockDataRequest(m *pilosa.BlockDataRequest) *internal.BlockDataRequest {
 	return &internal.BlockDataRequest{
-		Index: m.Index,
-		Field: m.Field,
-		View:  m.View,
-		Shard: m.Shard,
-		Block: m.Block,
+		Index:      m.Index,
+		Field:      m.Field,
+		View:       m.View,
+		Shard:      m.Shard,
+		Block:      m.Block,
+		Checksums:  m.Checksums,
+		Containers: m.Containers,
 	}
 }
 func encodeBlockDataResponse(m *pilosa.BlockDataResponse) *internal.BlockDataResponse {
-	return &internal.BlockDataResponse{
+	pb := &internal.BlockDataResponse{
 		RowIDs:    m.RowIDs,
 		ColumnIDs: m.ColumnIDs,
 	}
+	for _, c := range m.Checksums {
+		pb.Checksums = append(pb.Checksums, &internal.ContainerChecksum{Key: c.Key, Checksum: c.Checksum})
+	}
+	return pb
 }
 
 func encodeImportResponse(m *pilosa.ImportResponse) *internal.ImportResponse {
@@ -402,6 +604,10 @@ func encodeQueryRequest(m *pilosa.QueryRequest) *internal.QueryRequest {
 		Remote:          m.Remote,
 		ExcludeRowAttrs: m.ExcludeRowAttrs,
 		ExcludeColumns:  m.ExcludeColumns,
+		Priority:        m.Priority,
+		Profile:         m.Profile,
+		ContinueOnError: m.ContinueOnError,
+		Affinity:        m.Affinity,
 	}
 }
 
@@ -442,6 +648,9 @@ func encodeQueryResponse(m *pilosa.QueryResponse) *internal.QueryResponse {
 		case pilosa.Pair:
 			pb.Results[i].Type = queryResultTypePair
 			pb.Results[i].Pairs = []*internal.Pair{encodePair(result)}
+		case pilosa.CallError:
+			pb.Results[i].Type = queryResultTypeError
+			pb.Results[i].Error = encodeResponseError(pilosa.NewResponseError(result.Err))
 		case nil:
 			pb.Results[i].Type = queryResultTypeNil
 		default:
@@ -451,11 +660,34 @@ func encodeQueryResponse(m *pilosa.QueryResponse) *internal.QueryResponse {
 
 	if m.Err != nil {
 		pb.Err = m.Err.Error()
+		pb.Error = encodeResponseError(pilosa.NewResponseError(m.Err))
 	}
+	pb.Profile = encodeQueryProfile(m.Profile)
 
 	return pb
 }
 
+func encodeQueryProfile(m *pilosa.QueryProfile) *internal.QueryProfile {
+	if m == nil {
+		return nil
+	}
+	pb := &internal.QueryProfile{
+		Parse:             int64(m.Parse),
+		Execute:           int64(m.Execute),
+		Shards:            make([]*internal.ShardProfile, len(m.Shards)),
+		Nodes:             make([]*internal.NodeProfile, len(m.Nodes)),
+		ContainersScanned: m.ContainersScanned,
+		CacheHits:         m.CacheHits,
+	}
+	for i, sp := range m.Shards {
+		pb.Shards[i] = &internal.ShardProfile{Shard: sp.Shard, Node: sp.Node, Execute: int64(sp.Execute)}
+	}
+	for i, np := range m.Nodes {
+		pb.Nodes[i] = &internal.NodeProfile{Node: np.Node, Requests: int64(np.Requests), Network: int64(np.Network), Execute: int64(np.Execute)}
+	}
+	return pb
+}
+
 func encodeResizeInstruction(m *pilosa.ResizeInstruction) *internal.ResizeInstruction {
 	return &internal.ResizeInstruction{
 		JobID:         m.JobID,
@@ -501,8 +733,9 @@ func encodeIndexInfos(idxs []*pilosa.IndexInfo) []*internal.Index {
 
 func encodeIndexInfo(idx *pilosa.IndexInfo) *internal.Index {
 	return &internal.Index{
-		Name:   idx.Name,
-		Fields: encodeFieldInfos(idx.Fields),
+		Name:     idx.Name,
+		Fields:   encodeFieldInfos(idx.Fields),
+		ReadOnly: idx.ReadOnly,
 	}
 }
 
@@ -541,6 +774,12 @@ func encodeFieldOptions(o *pilosa.FieldOptions) *internal.FieldOptions {
 		BitDepth:    uint64(o.BitDepth),
 		TimeQuantum: string(o.TimeQuantum),
 		Keys:        o.Keys,
+
+		TimeViewCacheType: o.TimeViewCacheType,
+		TimeViewCacheSize: o.TimeViewCacheSize,
+		TimeViewStorage:   o.TimeViewStorage,
+		Inverse:           o.Inverse,
+		IdleTTL:           int64(o.IdleTTL),
 	}
 }
 
@@ -555,12 +794,19 @@ func encodeNodes(a []*pilosa.Node) []*internal.Node {
 
 // encodeNode converts a Node into its internal representation.
 func encodeNode(n *pilosa.Node) *internal.Node {
-	return &internal.Node{
+	pb := &internal.Node{
 		ID:            n.ID,
 		URI:           encodeURI(n.URI),
 		IsCoordinator: n.IsCoordinator,
 		State:         n.State,
+		Zone:          n.Zone,
+		Version:       n.Version,
+		Features:      n.Features,
 	}
+	if n.InternalURI != nil {
+		pb.InternalURI = encodeURI(*n.InternalURI)
+	}
+	return pb
 }
 
 func encodeURI(u pilosa.URI) *internal.URI {
@@ -589,8 +835,9 @@ func encodeCreateShardMessage(m *pilosa.CreateShardMessage) *internal.CreateShar
 
 func encodeCreateIndexMessage(m *pilosa.CreateIndexMessage) *internal.CreateIndexMessage {
 	return &internal.CreateIndexMessage{
-		Index: m.Index,
-		Meta:  encodeIndexMeta(m.Meta),
+		Index:         m.Index,
+		Meta:          encodeIndexMeta(m.Meta),
+		SchemaVersion: m.SchemaVersion,
 	}
 }
 
@@ -598,30 +845,52 @@ func encodeIndexMeta(m *pilosa.IndexOptions) *internal.IndexMeta {
 	return &internal.IndexMeta{
 		Keys:           m.Keys,
 		TrackExistence: m.TrackExistence,
+		TimeZone:       m.TimeZone,
+		InMemory:       m.InMemory,
 	}
 }
 
 func encodeDeleteIndexMessage(m *pilosa.DeleteIndexMessage) *internal.DeleteIndexMessage {
 	return &internal.DeleteIndexMessage{
-		Index: m.Index,
+		Index:         m.Index,
+		SchemaVersion: m.SchemaVersion,
 	}
 }
 
 func encodeCreateFieldMessage(m *pilosa.CreateFieldMessage) *internal.CreateFieldMessage {
 	return &internal.CreateFieldMessage{
-		Index: m.Index,
-		Field: m.Field,
-		Meta:  encodeFieldOptions(m.Meta),
+		Index:         m.Index,
+		Field:         m.Field,
+		Meta:          encodeFieldOptions(m.Meta),
+		SchemaVersion: m.SchemaVersion,
 	}
 }
 
 func encodeDeleteFieldMessage(m *pilosa.DeleteFieldMessage) *internal.DeleteFieldMessage {
 	return &internal.DeleteFieldMessage{
-		Index: m.Index,
-		Field: m.Field,
+		Index:         m.Index,
+		Field:         m.Field,
+		SchemaVersion: m.SchemaVersion,
 	}
 }
 
+func encodeSchemaChangeRequest(m *pilosa.SchemaChangeRequest) *internal.SchemaChangeRequest {
+	pb := &internal.SchemaChangeRequest{}
+	if m.CreateIndex != nil {
+		pb.CreateIndex = encodeCreateIndexMessage(m.CreateIndex)
+	}
+	if m.DeleteIndex != nil {
+		pb.DeleteIndex = encodeDeleteIndexMessage(m.DeleteIndex)
+	}
+	if m.CreateField != nil {
+		pb.CreateField = encodeCreateFieldMessage(m.CreateField)
+	}
+	if m.DeleteField != nil {
+		pb.DeleteField = encodeDeleteFieldMessage(m.DeleteField)
+	}
+	return pb
+}
+
 func encodeDeleteAvailableShardMessage(m *pilosa.DeleteAvailableShardMessage) *internal.DeleteAvailableShardMessage {
 	return &internal.DeleteAvailableShardMessage{
 		Index:   m.Index,
@@ -646,6 +915,152 @@ func encodeDeleteViewMessage(m *pilosa.DeleteViewMessage) *internal.DeleteViewMe
 	}
 }
 
+func encodeTruncateFieldMessage(m *pilosa.TruncateFieldMessage) *internal.TruncateFieldMessage {
+	return &internal.TruncateFieldMessage{
+		Index: m.Index,
+		Field: m.Field,
+	}
+}
+
+func encodeIndexReadOnlyMessage(m *pilosa.IndexReadOnlyMessage) *internal.IndexReadOnlyMessage {
+	return &internal.IndexReadOnlyMessage{
+		Index:    m.Index,
+		ReadOnly: m.ReadOnly,
+	}
+}
+
+func encodeCreateMaterializedViewMessage(m *pilosa.CreateMaterializedViewMessage) *internal.CreateMaterializedViewMessage {
+	return &internal.CreateMaterializedViewMessage{
+		Index:   m.Index,
+		Name:    m.Name,
+		Query:   m.Query,
+		Call:    m.Call,
+		Version: m.Version,
+	}
+}
+
+func encodeDeleteMaterializedViewMessage(m *pilosa.DeleteMaterializedViewMessage) *internal.DeleteMaterializedViewMessage {
+	return &internal.DeleteMaterializedViewMessage{
+		Index:   m.Index,
+		Name:    m.Name,
+		Version: m.Version,
+	}
+}
+
+func encodeCreateRewriteRuleMessage(m *pilosa.CreateRewriteRuleMessage) *internal.CreateRewriteRuleMessage {
+	return &internal.CreateRewriteRuleMessage{
+		Index:   m.Index,
+		Name:    m.Rule.Name,
+		Match:   m.Rule.Match,
+		Replace: m.Rule.Replace,
+		Version: m.Version,
+	}
+}
+
+func encodeDeleteRewriteRuleMessage(m *pilosa.DeleteRewriteRuleMessage) *internal.DeleteRewriteRuleMessage {
+	return &internal.DeleteRewriteRuleMessage{
+		Index:   m.Index,
+		Name:    m.Name,
+		Version: m.Version,
+	}
+}
+
+func encodeCreateNamedQueryMessage(m *pilosa.CreateNamedQueryMessage) *internal.CreateNamedQueryMessage {
+	return &internal.CreateNamedQueryMessage{
+		Name:    m.Query.Name,
+		Index:   m.Query.Index,
+		Query:   m.Query.Query,
+		Version: m.Version,
+	}
+}
+
+func encodeDeleteNamedQueryMessage(m *pilosa.DeleteNamedQueryMessage) *internal.DeleteNamedQueryMessage {
+	return &internal.DeleteNamedQueryMessage{
+		Name:    m.Name,
+		Version: m.Version,
+	}
+}
+
+func encodeSettings(m *pilosa.Settings) *internal.Settings {
+	if m == nil {
+		return nil
+	}
+	return &internal.Settings{
+		Version:             m.Version,
+		AntiEntropyInterval: int64(m.AntiEntropyInterval),
+		LongQueryTime:       int64(m.LongQueryTime),
+		MaxWritesPerRequest: int64(m.MaxWritesPerRequest),
+		MaxQueryMemory:      m.MaxQueryMemory,
+		DefaultCacheType:    m.DefaultCacheType,
+		DefaultCacheSize:    m.DefaultCacheSize,
+	}
+}
+
+// encodeAliasesMessage encodes each alias separately, with an empty field
+// for index aliases.
+func encodeAliasesMessage(m *pilosa.AliasesMessage) *internal.AliasesMessage {
+	pb := &internal.AliasesMessage{Version: m.Aliases.Version}
+	for alias, index := range m.Aliases.Indexes {
+		pb.Aliases = append(pb.Aliases, &internal.Alias{Alias: alias, Index: index})
+	}
+	for index, aliases := range m.Aliases.Fields {
+		for alias, field := range aliases {
+			pb.Aliases = append(pb.Aliases, &internal.Alias{Alias: alias, Index: index, Field: field})
+		}
+	}
+	return pb
+}
+
+func encodeSchedule(m *pilosa.Schedule) *internal.Schedule {
+	pb := &internal.Schedule{
+		Name:      m.Name,
+		Query:     m.Query,
+		Cron:      m.Cron,
+		SinkType:  m.Sink.Type,
+		SinkURL:   m.Sink.URL,
+		SinkPath:  m.Sink.Path,
+		SinkField: m.Sink.Field,
+		SinkRow:   m.Sink.Row,
+		AlertURL:  m.AlertURL,
+	}
+	for k, v := range m.Params {
+		pb.Params = append(pb.Params, &internal.ScheduleParam{Key: k, Value: v})
+	}
+	return pb
+}
+
+func encodeLoadUDFMessage(m *pilosa.LoadUDFMessage) *internal.LoadUDFMessage {
+	return &internal.LoadUDFMessage{
+		Name: m.Name,
+		Code: m.Code,
+	}
+}
+
+func encodeDeleteUDFMessage(m *pilosa.DeleteUDFMessage) *internal.DeleteUDFMessage {
+	return &internal.DeleteUDFMessage{
+		Name: m.Name,
+	}
+}
+
+func encodeNodeReadOnlyMessage(m *pilosa.NodeReadOnlyMessage) *internal.NodeReadOnlyMessage {
+	return &internal.NodeReadOnlyMessage{
+		NodeID:   m.NodeID,
+		ReadOnly: m.ReadOnly,
+	}
+}
+
+func encodeReadOwnersMessage(m *pilosa.ReadOwnersMessage) *internal.ReadOwnersMessage {
+	owners := make([]*internal.ShardReadOwner, len(m.Owners))
+	for i, o := range m.Owners {
+		owners[i] = &internal.ShardReadOwner{
+			Index: o.Index,
+			Shard: o.Shard,
+			Node:  o.Node,
+		}
+	}
+	return &internal.ReadOwnersMessage{Owners: owners}
+}
+
 func encodeResizeInstructionComplete(m *pilosa.ResizeInstructionComplete) *internal.ResizeInstructionComplete {
 	return &internal.ResizeInstructionComplete{
 		JobID: m.JobID,
@@ -681,11 +1096,63 @@ func encodeNodeEventMessage(m *pilosa.NodeEvent) *internal.NodeEventMessage {
 }
 
 func encodeNodeStatus(m *pilosa.NodeStatus) *internal.NodeStatus {
-	return &internal.NodeStatus{
-		Node:    encodeNode(m.Node),
-		Indexes: encodeIndexStatuses(m.Indexes),
-		Schema:  encodeSchema(m.Schema),
+	pb := &internal.NodeStatus{
+		Node:     encodeNode(m.Node),
+		Indexes:  encodeIndexStatuses(m.Indexes),
+		Schema:   encodeSchema(m.Schema),
+		Settings: encodeSettings(m.Settings),
+		Load:     encodeNodeLoad(m.Load),
+	}
+	if m.Aliases != nil {
+		pb.Aliases = encodeAliasesMessage(&pilosa.AliasesMessage{Aliases: *m.Aliases})
+	}
+	for _, mv := range m.MaterializedViews {
+		pb.MaterializedViews = append(pb.MaterializedViews, encodeCreateMaterializedViewMessage(mv))
+	}
+	for _, mv := range m.DeletedMaterializedViews {
+		pb.DeletedMaterializedViews = append(pb.DeletedMaterializedViews, encodeDeleteMaterializedViewMessage(mv))
+	}
+	for _, r := range m.RewriteRules {
+		pb.RewriteRules = append(pb.RewriteRules, encodeCreateRewriteRuleMessage(r))
 	}
+	for _, r := range m.DeletedRewriteRules {
+		pb.DeletedRewriteRules = append(pb.DeletedRewriteRules, encodeDeleteRewriteRuleMessage(r))
+	}
+	for _, q := range m.NamedQueries {
+		pb.NamedQueries = append(pb.NamedQueries, encodeCreateNamedQueryMessage(q))
+	}
+	for _, q := range m.DeletedNamedQueries {
+		pb.DeletedNamedQueries = append(pb.DeletedNamedQueries, encodeDeleteNamedQueryMessage(q))
+	}
+	return pb
+}
+
+func encodeNodeLoad(m *pilosa.NodeLoad) *internal.NodeLoad {
+	if m == nil {
+		return nil
+	}
+	return &internal.NodeLoad{
+		Time:             m.Time.UnixNano(),
+		MemoryUsed:       m.MemoryUsed,
+		DiskUsed:         m.DiskUsed,
+		DiskFree:         m.DiskFree,
+		QueriesPerSecond: m.QueriesPerSecond,
+		ImportLag:        int64(m.ImportLag),
+		HotShards:        encodeShardLoads(m.HotShards),
+	}
+}
+
+func encodeShardLoads(a []pilosa.ShardLoad) []*internal.ShardLoad {
+	m := make([]*internal.ShardLoad, len(a))
+	for i, l := range a {
+		m[i] = &internal.ShardLoad{
+			Index:  l.Index,
+			Shard:  l.Shard,
+			Reads:  l.Reads,
+			Writes: l.Writes,
+		}
+	}
+	return m
 }
 
 func encodeIndexStatus(m *pilosa.IndexStatus) *internal.IndexStatus {
@@ -704,10 +1171,14 @@ func encodeIndexStatuses(a []*pilosa.IndexStatus) []*internal.IndexStatus {
 }
 
 func encodeFieldStatus(m *pilosa.FieldStatus) *internal.FieldStatus {
-	return &internal.FieldStatus{
+	pb := &internal.FieldStatus{
 		Name:            m.Name,
 		AvailableShards: m.AvailableShards.Slice(),
 	}
+	if !m.LastAccess.IsZero() {
+		pb.LastAccess = m.LastAccess.UnixNano()
+	}
+	return pb
 }
 
 func encodeFieldStatuses(a []*pilosa.FieldStatus) []*internal.FieldStatus {
@@ -782,6 +1253,7 @@ func decodeIndex(idx *internal.Index, m *pilosa.IndexInfo) {
 	m.Name = idx.Name
 	m.Fields = make([]*pilosa.FieldInfo, len(idx.Fields))
 	decodeFields(idx.Fields, m.Fields)
+	m.ReadOnly = idx.ReadOnly
 }
 
 func decodeFields(fs []*internal.Field, m []*pilosa.FieldInfo) {
@@ -811,6 +1283,11 @@ func decodeFieldOptions(options *internal.FieldOptions, m *pilosa.FieldOptions)
 	m.BitDepth = uint(options.BitDepth)
 	m.TimeQuantum = pilosa.TimeQuantum(options.TimeQuantum)
 	m.Keys = options.Keys
+	m.TimeViewCacheType = options.TimeViewCacheType
+	m.TimeViewCacheSize = options.TimeViewCacheSize
+	m.TimeViewStorage = options.TimeViewStorage
+	m.Inverse = options.Inverse
+	m.IdleTTL = toml.Duration(options.IdleTTL)
 }
 
 func decodeNodes(a []*internal.Node, m []*pilosa.Node) {
@@ -832,6 +1309,14 @@ func decodeNode(node *internal.Node, m *pilosa.Node) {
 	decodeURI(node.URI, &m.URI)
 	m.IsCoordinator = node.IsCoordinator
 	m.State = node.State
+	m.Zone = node.Zone
+	m.Version = node.Version
+	m.Features = node.Features
+	m.InternalURI = nil
+	if node.InternalURI != nil {
+		m.InternalURI = &pilosa.URI{}
+		decodeURI(node.InternalURI, m.InternalURI)
+	}
 }
 
 func decodeURI(i *internal.URI, m *pilosa.URI) {
@@ -850,15 +1335,19 @@ func decodeCreateIndexMessage(pb *internal.CreateIndexMessage, m *pilosa.CreateI
 	m.Index = pb.Index
 	m.Meta = &pilosa.IndexOptions{}
 	decodeIndexMeta(pb.Meta, m.Meta)
+	m.SchemaVersion = pb.SchemaVersion
 }
 
 func decodeIndexMeta(pb *internal.IndexMeta, m *pilosa.IndexOptions) {
 	m.Keys = pb.Keys
 	m.TrackExistence = pb.TrackExistence
+	m.TimeZone = pb.TimeZone
+	m.InMemory = pb.InMemory
 }
 
 func decodeDeleteIndexMessage(pb *internal.DeleteIndexMessage, m *pilosa.DeleteIndexMessage) {
 	m.Index = pb.Index
+	m.SchemaVersion = pb.SchemaVersion
 }
 
 func decodeCreateFieldMessage(pb *internal.CreateFieldMessage, m *pilosa.CreateFieldMessage) {
@@ -866,11 +1355,32 @@ func decodeCreateFieldMessage(pb *internal.CreateFieldMessage, m *pilosa.CreateF
 	m.Field = pb.Field
 	m.Meta = &pilosa.FieldOptions{}
 	decodeFieldOptions(pb.Meta, m.Meta)
+	m.SchemaVersion = pb.SchemaVersion
 }
 
 func decodeDeleteFieldMessage(pb *internal.DeleteFieldMessage, m *pilosa.DeleteFieldMessage) {
 	m.Index = pb.Index
 	m.Field = pb.Field
+	m.SchemaVersion = pb.SchemaVersion
+}
+
+func decodeSchemaChangeRequest(pb *internal.SchemaChangeRequest, m *pilosa.SchemaChangeRequest) {
+	if pb.CreateIndex != nil {
+		m.CreateIndex = &pilosa.CreateIndexMessage{}
+		decodeCreateIndexMessage(pb.CreateIndex, m.CreateIndex)
+	}
+	if pb.DeleteIndex != nil {
+		m.DeleteIndex = &pilosa.DeleteIndexMessage{}
+		decodeDeleteIndexMessage(pb.DeleteIndex, m.DeleteIndex)
+	}
+	if pb.CreateField != nil {
+		m.CreateField = &pilosa.CreateFieldMessage{}
+		decodeCreateFieldMessage(pb.CreateField, m.CreateField)
+	}
+	if pb.DeleteField != nil {
+		m.DeleteField = &pilosa.DeleteFieldMessage{}
+		decodeDeleteFieldMessage(pb.DeleteField, m.DeleteField)
+	}
 }
 
 func decodeDeleteAvailableShardMessage(pb *internal.DeleteAvailableShardMessage, m *pilosa.DeleteAvailableShardMessage) {
@@ -891,6 +1401,132 @@ func decodeDeleteViewMessage(pb *internal.DeleteViewMessage, m *pilosa.DeleteVie
 	m.View = pb.View
 }
 
+func decodeTruncateFieldMessage(pb *internal.TruncateFieldMessage, m *pilosa.TruncateFieldMessage) {
+	m.Index = pb.Index
+	m.Field = pb.Field
+}
+
+func decodeIndexReadOnlyMessage(pb *internal.IndexReadOnlyMessage, m *pilosa.IndexReadOnlyMessage) {
+	m.Index = pb.Index
+	m.ReadOnly = pb.ReadOnly
+}
+
+func decodeCreateMaterializedViewMessage(pb *internal.CreateMaterializedViewMessage, m *pilosa.CreateMaterializedViewMessage) {
+	m.Index = pb.Index
+	m.Name = pb.Name
+	m.Query = pb.Query
+	m.Call = pb.Call
+	m.Version = pb.Version
+}
+
+func decodeDeleteMaterializedViewMessage(pb *internal.DeleteMaterializedViewMessage, m *pilosa.DeleteMaterializedViewMessage) {
+	m.Index = pb.Index
+	m.Name = pb.Name
+	m.Version = pb.Version
+}
+
+func decodeCreateRewriteRuleMessage(pb *internal.CreateRewriteRuleMessage, m *pilosa.CreateRewriteRuleMessage) {
+	m.Index = pb.Index
+	m.Rule = pilosa.RewriteRule{Name: pb.Name, Match: pb.Match, Replace: pb.Replace}
+	m.Version = pb.Version
+}
+
+func decodeDeleteRewriteRuleMessage(pb *internal.DeleteRewriteRuleMessage, m *pilosa.DeleteRewriteRuleMessage) {
+	m.Index = pb.Index
+	m.Name = pb.Name
+	m.Version = pb.Version
+}
+
+func decodeCreateNamedQueryMessage(pb *internal.CreateNamedQueryMessage, m *pilosa.CreateNamedQueryMessage) {
+	m.Query = pilosa.NamedQuery{Name: pb.Name, Index: pb.Index, Query: pb.Query}
+	m.Version = pb.Version
+}
+
+func decodeDeleteNamedQueryMessage(pb *internal.DeleteNamedQueryMessage, m *pilosa.DeleteNamedQueryMessage) {
+	m.Name = pb.Name
+	m.Version = pb.Version
+}
+
+func decodeSettings(pb *internal.Settings, m *pilosa.Settings) {
+	if pb == nil {
+		return
+	}
+	m.Version = pb.Version
+	m.AntiEntropyInterval = toml.Duration(pb.AntiEntropyInterval)
+	m.LongQueryTime = toml.Duration(pb.LongQueryTime)
+	m.MaxWritesPerRequest = int(pb.MaxWritesPerRequest)
+	m.MaxQueryMemory = pb.MaxQueryMemory
+	m.DefaultCacheType = pb.DefaultCacheType
+	m.DefaultCacheSize = pb.DefaultCacheSize
+}
+
+func decodeAliasesMessage(pb *internal.AliasesMessage, m *pilosa.AliasesMessage) {
+	m.Aliases = pilosa.Aliases{Version: pb.Version}
+	for _, a := range pb.Aliases {
+		if a.Field == "" {
+			if m.Aliases.Indexes == nil {
+				m.Aliases.Indexes = make(map[string]string)
+			}
+			m.Aliases.Indexes[a.Alias] = a.Index
+			continue
+		}
+		if m.Aliases.Fields == nil {
+			m.Aliases.Fields = make(map[string]map[string]string)
+		}
+		if m.Aliases.Fields[a.Index] == nil {
+			m.Aliases.Fields[a.Index] = make(map[string]string)
+		}
+		m.Aliases.Fields[a.Index][a.Alias] = a.Field
+	}
+}
+
+func decodeSchedule(pb *internal.Schedule, m *pilosa.Schedule) {
+	*m = pilosa.Schedule{
+		Name:  pb.Name,
+		Query: pb.Query,
+		Cron:  pb.Cron,
+		Sink: pilosa.ScheduleSink{
+			Type:  pb.SinkType,
+			URL:   pb.SinkURL,
+			Path:  pb.SinkPath,
+			Field: pb.SinkField,
+			Row:   pb.SinkRow,
+		},
+		AlertURL: pb.AlertURL,
+	}
+	if len(pb.Params) > 0 {
+		m.Params = make(map[string]string, len(pb.Params))
+		for _, p := range pb.Params {
+			m.Params[p.Key] = p.Value
+		}
+	}
+}
+
+func decodeLoadUDFMessage(pb *internal.LoadUDFMessage, m *pilosa.LoadUDFMessage) {
+	m.Name = pb.Name
+	m.Code = pb.Code
+}
+
+func decodeDeleteUDFMessage(pb *internal.DeleteUDFMessage, m *pilosa.DeleteUDFMessage) {
+	m.Name = pb.Name
+}
+
+func decodeNodeReadOnlyMessage(pb *internal.NodeReadOnlyMessage, m *pilosa.NodeReadOnlyMessage) {
+	m.NodeID = pb.NodeID
+	m.ReadOnly = pb.ReadOnly
+}
+
+func decodeReadOwnersMessage(pb *internal.ReadOwnersMessage, m *pilosa.ReadOwnersMessage) {
+	m.Owners = make([]pilosa.ShardReadOwner, len(pb.Owners))
+	for i, o := range pb.Owners {
+		m.Owners[i] = pilosa.ShardReadOwner{
+			Index: o.Index,
+			Shard: o.Shard,
+			Node:  o.Node,
+		}
+	}
+}
+
 func decodeResizeInstructionComplete(pb *internal.ResizeInstructionComplete, m *pilosa.ResizeInstructionComplete) {
 	m.JobID = pb.JobID
 	m.Node = &pilosa.Node{}
@@ -921,9 +1557,81 @@ func decodeNodeEventMessage(pb *internal.NodeEventMessage, m *pilosa.NodeEvent)
 
 func decodeNodeStatus(pb *internal.NodeStatus, m *pilosa.NodeStatus) {
 	m.Node = &pilosa.Node{}
+	if pb.Node != nil {
+		decodeNode(pb.Node, m.Node)
+	}
 	m.Indexes = decodeIndexStatuses(pb.Indexes)
 	m.Schema = &pilosa.Schema{}
 	decodeSchema(pb.Schema, m.Schema)
+	if pb.Settings != nil {
+		m.Settings = &pilosa.Settings{}
+		decodeSettings(pb.Settings, m.Settings)
+	}
+	if pb.Load != nil {
+		m.Load = &pilosa.NodeLoad{}
+		decodeNodeLoad(pb.Load, m.Load)
+	}
+	if pb.Aliases != nil {
+		var msg pilosa.AliasesMessage
+		decodeAliasesMessage(pb.Aliases, &msg)
+		m.Aliases = &msg.Aliases
+	}
+	for _, pbmv := range pb.MaterializedViews {
+		mv := &pilosa.CreateMaterializedViewMessage{}
+		decodeCreateMaterializedViewMessage(pbmv, mv)
+		m.MaterializedViews = append(m.MaterializedViews, mv)
+	}
+	for _, pbmv := range pb.DeletedMaterializedViews {
+		mv := &pilosa.DeleteMaterializedViewMessage{}
+		decodeDeleteMaterializedViewMessage(pbmv, mv)
+		m.DeletedMaterializedViews = append(m.DeletedMaterializedViews, mv)
+	}
+	for _, pbr := range pb.RewriteRules {
+		r := &pilosa.CreateRewriteRuleMessage{}
+		decodeCreateRewriteRuleMessage(pbr, r)
+		m.RewriteRules = append(m.RewriteRules, r)
+	}
+	for _, pbr := range pb.DeletedRewriteRules {
+		r := &pilosa.DeleteRewriteRuleMessage{}
+		decodeDeleteRewriteRuleMessage(pbr, r)
+		m.DeletedRewriteRules = append(m.DeletedRewriteRules, r)
+	}
+	for _, pbq := range pb.NamedQueries {
+		q := &pilosa.CreateNamedQueryMessage{}
+		decodeCreateNamedQueryMessage(pbq, q)
+		m.NamedQueries = append(m.NamedQueries, q)
+	}
+	for _, pbq := range pb.DeletedNamedQueries {
+		q := &pilosa.DeleteNamedQueryMessage{}
+		decodeDeleteNamedQueryMessage(pbq, q)
+		m.DeletedNamedQueries = append(m.DeletedNamedQueries, q)
+	}
+}
+
+func decodeNodeLoad(pb *internal.NodeLoad, m *pilosa.NodeLoad) {
+	m.Time = time.Unix(0, pb.Time)
+	m.MemoryUsed = pb.MemoryUsed
+	m.DiskUsed = pb.DiskUsed
+	m.DiskFree = pb.DiskFree
+	m.QueriesPerSecond = pb.QueriesPerSecond
+	m.ImportLag = toml.Duration(pb.ImportLag)
+	m.HotShards = decodeShardLoads(pb.HotShards)
+}
+
+func decodeShardLoads(a []*internal.ShardLoad) []pilosa.ShardLoad {
+	if len(a) == 0 {
+		return nil
+	}
+	m := make([]pilosa.ShardLoad, len(a))
+	for i, l := range a {
+		m[i] = pilosa.ShardLoad{
+			Index:  l.Index,
+			Shard:  l.Shard,
+			Reads:  l.Reads,
+			Writes: l.Writes,
+		}
+	}
+	return m
 }
 
 func decodeIndexStatuses(a []*internal.IndexStatus) []*pilosa.IndexStatus {
@@ -952,6 +1660,9 @@ func decodeFieldStatuses(a []*internal.FieldStatus) []*pilosa.FieldStatus {
 func decodeFieldStatus(pb *internal.FieldStatus, m *pilosa.FieldStatus) {
 	m.Name = pb.Name
 	m.AvailableShards = roaring.NewBitmap(pb.AvailableShards...)
+	if pb.LastAccess != 0 {
+		m.LastAccess = time.Unix(0, pb.LastAccess)
+	}
 }
 
 func decodeRecalculateCaches(pb *internal.RecalculateCaches, m *pilosa.RecalculateCaches) {}
@@ -963,26 +1674,10 @@ func decodeQueryRequest(pb *internal.QueryRequest, m *pilosa.QueryRequest) {
 	m.Remote = pb.Remote
 	m.ExcludeRowAttrs = pb.ExcludeRowAttrs
 	m.ExcludeColumns = pb.ExcludeColumns
-}
-
-func decodeImportRequest(pb *internal.ImportRequest, m *pilosa.ImportRequest) {
-	m.Index = pb.Index
-	m.Field = pb.Field
-	m.Shard = pb.Shard
-	m.RowIDs = pb.RowIDs
-	m.ColumnIDs = pb.ColumnIDs
-	m.RowKeys = pb.RowKeys
-	m.ColumnKeys = pb.ColumnKeys
-	m.Timestamps = pb.Timestamps
-}
-
-func decodeImportValueRequest(pb *internal.ImportValueRequest, m *pilosa.ImportValueRequest) {
-	m.Index = pb.Index
-	m.Field = pb.Field
-	m.Shard = pb.Shard
-	m.ColumnIDs = pb.ColumnIDs
-	m.ColumnKeys = pb.ColumnKeys
-	m.Values = pb.Values
+	m.Priority = pb.Priority
+	m.Profile = pb.Profile
+	m.ContinueOnError = pb.ContinueOnError
+	m.Affinity = pb.Affinity
 }
 
 func decodeImportRoaringRequest(pb *internal.ImportRoaringRequest, m *pilosa.ImportRoaringRequest) {
@@ -1004,23 +1699,71 @@ func decodeBlockDataRequest(pb *internal.BlockDataRequest, m *pilosa.BlockDataRe
 	m.View = pb.View
 	m.Shard = pb.Shard
 	m.Block = pb.Block
+	m.Checksums = pb.Checksums
+	m.Containers = pb.Containers
 }
 
 func decodeBlockDataResponse(pb *internal.BlockDataResponse, m *pilosa.BlockDataResponse) {
 	m.RowIDs = pb.RowIDs
 	m.ColumnIDs = pb.ColumnIDs
+	m.Checksums = nil
+	for _, c := range pb.Checksums {
+		m.Checksums = append(m.Checksums, pilosa.ContainerChecksum{Key: c.Key, Checksum: c.Checksum})
+	}
+}
+
+func encodeResponseError(e *pilosa.ResponseError) *internal.ResponseError {
+	return &internal.ResponseError{
+		Code:    e.Code,
+		Message: e.Message,
+		Index:   e.Index,
+		Field:   e.Field,
+	}
+}
+
+func decodeResponseError(pb *internal.ResponseError) *pilosa.ResponseError {
+	return &pilosa.ResponseError{
+		Code:    pb.Code,
+		Message: pb.Message,
+		Index:   pb.Index,
+		Field:   pb.Field,
+	}
 }
 
 func decodeQueryResponse(pb *internal.QueryResponse, m *pilosa.QueryResponse) {
 	m.ColumnAttrSets = make([]*pilosa.ColumnAttrSet, len(pb.ColumnAttrSets))
 	decodeColumnAttrSets(pb.ColumnAttrSets, m.ColumnAttrSets)
-	if pb.Err == "" {
-		m.Err = nil
-	} else {
+	if pb.Error != nil {
+		m.Err = decodeResponseError(pb.Error).Err()
+	} else if pb.Err != "" {
 		m.Err = errors.New(pb.Err)
+	} else {
+		m.Err = nil
 	}
 	m.Results = make([]interface{}, len(pb.Results))
 	decodeQueryResults(pb.Results, m.Results)
+	m.Profile = decodeQueryProfile(pb.Profile)
+}
+
+func decodeQueryProfile(pb *internal.QueryProfile) *pilosa.QueryProfile {
+	if pb == nil {
+		return nil
+	}
+	m := &pilosa.QueryProfile{
+		Parse:             toml.Duration(pb.Parse),
+		Execute:           toml.Duration(pb.Execute),
+		Shards:            make([]pilosa.ShardProfile, len(pb.Shards)),
+		Nodes:             make([]pilosa.NodeProfile, len(pb.Nodes)),
+		ContainersScanned: pb.ContainersScanned,
+		CacheHits:         pb.CacheHits,
+	}
+	for i, sp := range pb.Shards {
+		m.Shards[i] = pilosa.ShardProfile{Shard: sp.Shard, Node: sp.Node, Execute: toml.Duration(sp.Execute)}
+	}
+	for i, np := range pb.Nodes {
+		m.Nodes[i] = pilosa.NodeProfile{Node: np.Node, Requests: int(np.Requests), Network: toml.Duration(np.Network), Execute: toml.Duration(np.Execute)}
+	}
+	return m
 }
 
 func decodeColumnAttrSets(pb []*internal.ColumnAttrSet, m []*pilosa.ColumnAttrSet) {
@@ -1064,6 +1807,7 @@ const (
 	queryResultTypeGroupCounts
 	queryResultTypeRowIdentifiers
 	queryResultTypePair
+	queryResultTypeError
 )
 
 func decodeQueryResult(pb *internal.QueryResult) interface{} {
@@ -1088,6 +1832,8 @@ func decodeQueryResult(pb *internal.QueryResult) interface{} {
 		return decodeGroupCounts(pb.GroupCounts)
 	case queryResultTypePair:
 		return decodePair(pb.Pairs[0])
+	case queryResultTypeError:
+		return pilosa.CallError{Err: decodeResponseError(pb.Error).Err()}
 	}
 	panic(fmt.Sprintf("unknown type: %d", pb.Type))
 }
@@ -0,0 +1,126 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// A field with an idle TTL is ephemeral: it is deleted from the cluster once
+// it has not been queried or imported to for the TTL. Each node records when
+// it last used each field, and shares it in its node status, so every node
+// learns when the field was last used anywhere in the cluster. The
+// coordinator deletes the fields which have been idle for their TTL.
+
+// ephemeralFieldInterval is the interval at which the coordinator deletes
+// idle ephemeral fields.
+const ephemeralFieldInterval = time.Minute
+
+// touch records that the field has been queried or imported to.
+func (f *Field) touch() {
+	atomic.StoreInt64(&f.lastAccess, time.Now().UnixNano())
+}
+
+// LastAccess returns the time the field was last queried or imported to on
+// any node, as far as this node knows, or the time it was opened if later.
+func (f *Field) LastAccess() time.Time {
+	return time.Unix(0, atomic.LoadInt64(&f.lastAccess))
+}
+
+// mergeLastAccess records that the field was used at t on another node.
+func (f *Field) mergeLastAccess(t time.Time) {
+	if t.IsZero() {
+		return
+	}
+	v := t.UnixNano()
+	for {
+		old := atomic.LoadInt64(&f.lastAccess)
+		if v <= old || atomic.CompareAndSwapInt64(&f.lastAccess, old, v) {
+			return
+		}
+	}
+}
+
+// idle returns true if the field is ephemeral and has not been used for its
+// idle TTL as of now.
+func (f *Field) idle(now time.Time) bool {
+	ttl := time.Duration(f.Options().IdleTTL)
+	return ttl > 0 && now.Sub(f.LastAccess()) >= ttl
+}
+
+// touchFields records that the fields of an index have been queried. Names
+// which are not fields are ignored.
+func (h *Holder) touchFields(index string, names []string) {
+	idx := h.Index(index)
+	if idx == nil {
+		return
+	}
+	for _, name := range names {
+		if f := idx.Field(name); f != nil {
+			f.touch()
+		}
+	}
+}
+
+// idleFields returns the ephemeral fields which have been idle for their TTL
+// as of now.
+func (h *Holder) idleFields(now time.Time) []*Field {
+	var fields []*Field
+	for _, idx := range h.Indexes() {
+		for _, f := range idx.Fields() {
+			if f.idle(now) {
+				fields = append(fields, f)
+			}
+		}
+	}
+	return fields
+}
+
+// deleteIdleFields deletes the ephemeral fields which have been idle for
+// their TTL as of now from the cluster.
+func (s *Server) deleteIdleFields(now time.Time) error {
+	for _, f := range s.holder.idleFields(now) {
+		s.logger.Printf("deleting idle ephemeral field %s/%s, last used %s", f.Index(), f.Name(), f.LastAccess().Format(time.RFC3339))
+		if err := s.changeSchema(&DeleteFieldMessage{Index: f.Index(), Field: f.Name()}); err != nil {
+			return errors.Wrapf(err, "deleting field %s/%s", f.Index(), f.Name())
+		}
+		s.holder.Stats.CountWithCustomTags("deleteIdleField", 1, 1.0, []string{"index:" + f.Index()})
+	}
+	return nil
+}
+
+// monitorEphemeralFields periodically deletes idle ephemeral fields, while
+// the node is the coordinator of a cluster in a normal state.
+func (s *Server) monitorEphemeralFields() {
+	ticker := time.NewTicker(ephemeralFieldInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.closing:
+			return
+		case now := <-ticker.C:
+			if !s.cluster.isCoordinator() || s.cluster.State() != ClusterStateNormal {
+				continue
+			}
+			if err := s.deleteIdleFields(now); err != nil {
+				s.logger.Printf("deleting idle ephemeral fields: %s", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// Codes of errors which are not one of the errors with a code of their own.
+const (
+	ErrorCodeInternal         = "Internal"
+	ErrorCodeBadRequest       = "BadRequest"
+	ErrorCodeConflict         = "Conflict"
+	ErrorCodeMethodNotAllowed = "MethodNotAllowed"
+)
+
+// errorCodes are the codes identifying errors in responses. A code must not
+// be changed once it has been released, since clients depend on it.
+var errorCodes = []struct {
+	err  error
+	code string
+}{
+	{ErrIndexRequired, "IndexRequired"},
+	{ErrIndexExists, "IndexExists"},
+	{ErrIndexNotFound, "IndexNotFound"},
+	{ErrIndexReadOnly, "IndexReadOnly"},
+	{ErrFieldRequired, "FieldRequired"},
+	{ErrFieldExists, "FieldExists"},
+	{ErrFieldNotFound, "FieldNotFound"},
+	{ErrFieldsArgumentRequired, "FieldsArgumentRequired"},
+	{ErrExpectedFieldListArgument, "ExpectedFieldListArgument"},
+	{ErrBSIGroupNotFound, "BSIGroupNotFound"},
+	{ErrBSIGroupExists, "BSIGroupExists"},
+	{ErrBSIGroupNameRequired, "BSIGroupNameRequired"},
+	{ErrInvalidBSIGroupType, "InvalidBSIGroupType"},
+	{ErrInvalidBSIGroupRange, "InvalidBSIGroupRange"},
+	{ErrInvalidBSIGroupValueType, "InvalidBSIGroupValueType"},
+	{ErrBSIGroupValueTooLow, "BSIGroupValueTooLow"},
+	{ErrBSIGroupValueTooHigh, "BSIGroupValueTooHigh"},
+	{ErrInvalidRangeOperation, "InvalidRangeOperation"},
+	{ErrInvalidBetweenValue, "InvalidBetweenValue"},
+	{ErrInvalidView, "InvalidView"},
+	{ErrInvalidCacheType, "InvalidCacheType"},
+	{ErrInvalidViewStorage, "InvalidViewStorage"},
+	{ErrName, "InvalidName"},
+	{ErrLabel, "InvalidLabel"},
+	{ErrFragmentNotFound, "FragmentNotFound"},
+	{ErrQueryRequired, "QueryRequired"},
+	{ErrQueryCancelled, "QueryCancelled"},
+	{ErrQueryTimeout, "QueryTimeout"},
+	{ErrQueryMemoryExceeded, "QueryMemoryExceeded"},
+	{ErrTooManyWrites, "TooManyWrites"},
+	{ErrQuotaExceeded, "QuotaExceeded"},
+	{ErrOverloaded, "Overloaded"},
+	{ErrBackpressure, "Backpressure"},
+	{ErrInsufficientDiskSpace, "InsufficientDiskSpace"},
+	{ErrFeatureUnsupported, "FeatureUnsupported"},
+	{ErrClusterDoesNotOwnShard, "ShardNotOwned"},
+	{ErrNodeIDNotExists, "NodeNotFound"},
+	{ErrNodeNotCoordinator, "NodeNotCoordinator"},
+	{ErrResizeNotRunning, "ResizeNotRunning"},
+	{ErrNotImplemented, "NotImplemented"},
+	{ErrAPIVersionUnsupported, "APIVersionUnsupported"},
+	{ErrJobNotFound, "JobNotFound"},
+	{ErrJobInterrupted, "JobInterrupted"},
+	{ErrChangeLogDisabled, "ChangeLogDisabled"},
+	{ErrChangeLogTruncated, "ChangeLogTruncated"},
+	{ErrTranslateStoreReadOnly, "TranslateStoreReadOnly"},
+	{ErrUDFNotFound, "UDFNotFound"},
+	{ErrUDFRuntimeNotConfigured, "UDFRuntimeNotConfigured"},
+	{ErrMaterializedViewNotFound, "MaterializedViewNotFound"},
+	{ErrMaterializedViewExists, "MaterializedViewExists"},
+	{ErrRewriteRuleNotFound, "RewriteRuleNotFound"},
+	{ErrRewriteRuleExists, "RewriteRuleExists"},
+	{ErrNamedQueryNotFound, "NamedQueryNotFound"},
+	{ErrScheduleNotFound, "ScheduleNotFound"},
+	{ErrNamespaceForbidden, "NamespaceForbidden"},
+}
+
+// ErrorCodes returns every code which may identify an error in a response.
+func ErrorCodes() []string {
+	codes := []string{ErrorCodeInternal, ErrorCodeBadRequest, ErrorCodeConflict, ErrorCodeMethodNotAllowed}
+	for _, ec := range errorCodes {
+		codes = append(codes, ec.code)
+	}
+	return codes
+}
+
+// ResponseError describes an error in a response, so that clients can handle
+// it by its code rather than by matching its message. Index and Field are the
+// index and field the error concerns, if known.
+type ResponseError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Index   string `json:"index,omitempty"`
+	Field   string `json:"field,omitempty"`
+}
+
+// NewResponseError returns the description of err.
+func NewResponseError(err error) *ResponseError {
+	if e, ok := err.(*ResponseError); ok {
+		return e
+	}
+	e := &ResponseError{Code: ErrorCodeInternal, Message: err.Error()}
+	var generic, name string
+	for err != nil {
+		for _, ec := range errorCodes {
+			if err == ec.err {
+				e.Code = ec.code
+				switch err {
+				case ErrIndexExists, ErrIndexNotFound:
+					e.Index = name
+				case ErrFieldExists, ErrFieldNotFound:
+					e.Field = name
+				}
+				return e
+			}
+		}
+
+		switch cause := err.(type) {
+		case remoteError:
+			e.Code, e.Index, e.Field = cause.Code, cause.Index, cause.Field
+			return e
+		case resourceError:
+			if name == "" {
+				name = cause.name
+			}
+			err = cause.error
+		case BadRequestError:
+			if generic == "" {
+				generic = ErrorCodeBadRequest
+			}
+			err = cause.error
+		case ConflictError:
+			if generic == "" {
+				generic = ErrorCodeConflict
+			}
+			err = cause.error
+		case apiMethodNotAllowedError:
+			if generic == "" {
+				generic = ErrorCodeMethodNotAllowed
+			}
+			err = cause.error
+		case interface{ Cause() error }:
+			err = cause.Cause()
+		default:
+			err = nil
+		}
+	}
+	if generic != "" {
+		e.Code = generic
+	}
+	return e
+}
+
+// Error returns the message of the error.
+func (e *ResponseError) Error() string {
+	return e.Message
+}
+
+// Err returns an error with the error's message. Its cause is the error
+// identified by the error's code, so that errors returned by other nodes can
+// be handled like those returned locally.
+func (e *ResponseError) Err() error {
+	for _, ec := range errorCodes {
+		if e.Code == ec.code {
+			return remoteError{ResponseError: e, cause: ec.err}
+		}
+	}
+	err := remoteError{ResponseError: e, cause: errors.New(e.Message)}
+	switch e.Code {
+	case ErrorCodeBadRequest:
+		return NewBadRequestError(err)
+	case ErrorCodeConflict:
+		return NewConflictError(err)
+	}
+	return err
+}
+
+// CallError is the result of a call which failed in a query executed with
+// ContinueOnError, in place of the call's result.
+type CallError struct {
+	Err error
+}
+
+// Error returns the message of the call's error.
+func (e CallError) Error() string { return e.Err.Error() }
+
+// MarshalJSON encodes the call's error as {"error": ...}, like the error of
+// a whole query.
+func (e CallError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Err *ResponseError `json:"error"`
+	}{Err: NewResponseError(e.Err)})
+}
+
+// remoteError is an error decoded from a response. It keeps the description
+// of the error, so that it is passed on unchanged.
+type remoteError struct {
+	*ResponseError
+	cause error
+}
+
+// Cause returns the error identified by the error's code.
+func (e remoteError) Cause() error { return e.cause }
+
+// resourceError is an error concerning the named index or field.
+type resourceError struct {
+	error
+	name string
+}
+
+// Cause returns the underlying cause of the error.
+func (e resourceError) Cause() error { return errors.Cause(e.error) }
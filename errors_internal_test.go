@@ -0,0 +1,78 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+// Ensure errors are described by their codes, and the resources they concern.
+func TestNewResponseError(t *testing.T) {
+	tests := []struct {
+		err error
+		exp ResponseError
+	}{
+		{
+			err: errors.Wrap(newNotFoundError(ErrFieldNotFound, "f"), "executing"),
+			exp: ResponseError{Code: "FieldNotFound", Message: "executing: f: field not found", Field: "f"},
+		},
+		{
+			err: newNotFoundError(ErrIndexNotFound, "i"),
+			exp: ResponseError{Code: "IndexNotFound", Message: "i: index not found", Index: "i"},
+		},
+		{
+			err: errors.Wrap(ErrQuotaExceeded, "charging"),
+			exp: ResponseError{Code: "QuotaExceeded", Message: "charging: quota exceeded"},
+		},
+		{
+			err: NewConflictError(ErrIndexExists),
+			exp: ResponseError{Code: "IndexExists", Message: "index already exists"},
+		},
+		{
+			err: NewBadRequestError(errors.New("bad")),
+			exp: ResponseError{Code: ErrorCodeBadRequest, Message: "bad"},
+		},
+		{
+			err: errors.New("boom"),
+			exp: ResponseError{Code: ErrorCodeInternal, Message: "boom"},
+		},
+	}
+	for i, test := range tests {
+		if got := NewResponseError(test.err); !reflect.DeepEqual(*got, test.exp) {
+			t.Errorf("%d. unexpected error: %#v", i, got)
+		}
+	}
+}
+
+// Ensure errors decoded from responses keep their causes and descriptions.
+func TestResponseError_Err(t *testing.T) {
+	e := &ResponseError{Code: "FieldNotFound", Message: "f: field not found", Index: "i", Field: "f"}
+	err := errors.Wrap(e.Err(), "mapping")
+	if errors.Cause(err) != ErrFieldNotFound {
+		t.Fatalf("unexpected cause: %v", errors.Cause(err))
+	} else if got := NewResponseError(err); !reflect.DeepEqual(*got, ResponseError{Code: "FieldNotFound", Message: "mapping: f: field not found", Index: "i", Field: "f"}) {
+		t.Fatalf("unexpected error: %#v", got)
+	}
+
+	if _, ok := (&ResponseError{Code: ErrorCodeBadRequest, Message: "bad"}).Err().(BadRequestError); !ok {
+		t.Fatal("expected bad request error")
+	}
+	if got := NewResponseError((&ResponseError{Code: "SomethingNew", Message: "new"}).Err()); got.Code != "SomethingNew" {
+		t.Fatalf("expected unknown code to be kept, got %q", got.Code)
+	}
+}
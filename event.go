@@ -14,6 +14,8 @@
 
 package pilosa
 
+import "time"
+
 // NodeEventType are the types of node events.
 type NodeEventType int
 
@@ -29,3 +31,73 @@ type NodeEvent struct {
 	Event NodeEventType
 	Node  *Node
 }
+
+// Event is an internal server event delivered to an EventHandler. It is one
+// of SchemaEvent, ShardEvent, NodeStateEvent, NodeHealthEvent, or SyncEvent.
+type Event interface{}
+
+// EventHandler is implemented by embedders which want to be notified of
+// internal server events. HandleEvent is called synchronously from the
+// goroutine which caused the event, so it should not block.
+type EventHandler interface {
+	HandleEvent(ev Event)
+}
+
+// eventHandlers is an EventHandler which delivers each event to all of its
+// members in order.
+type eventHandlers []EventHandler
+
+// HandleEvent implements EventHandler.
+func (hs eventHandlers) HandleEvent(ev Event) {
+	for _, h := range hs {
+		h.HandleEvent(ev)
+	}
+}
+
+// Schema change event types.
+const (
+	SchemaEventCreateIndex = "createIndex"
+	SchemaEventDeleteIndex = "deleteIndex"
+	SchemaEventCreateField = "createField"
+	SchemaEventDeleteField = "deleteField"
+)
+
+// SchemaEvent is sent when an index or field is created or deleted on the
+// local node, either directly or via a broadcast from another node.
+type SchemaEvent struct {
+	Type   string    `json:"type"`
+	Index  string    `json:"index"`
+	Field  string    `json:"field,omitempty"`
+	NodeID string    `json:"nodeID"`
+	Time   time.Time `json:"time"`
+}
+
+// ShardEvent is sent when a new shard is created in a field, either on the
+// local node or on a remote node.
+type ShardEvent struct {
+	Index string
+	Field string
+	Shard uint64
+}
+
+// NodeStateEvent is sent when the state of a node changes. Only the
+// coordinator observes state changes of remote nodes.
+type NodeStateEvent struct {
+	NodeID string
+	State  string
+}
+
+// NodeHealthEvent is sent when this node's failure detector changes its
+// judgement of another node: READY, SUSPECT, or DOWN. Phi is the suspicion
+// that the node has failed at the time.
+type NodeHealthEvent struct {
+	NodeID string
+	State  string
+	Phi    float64
+}
+
+// SyncEvent is sent when an anti-entropy sync of the holder completes.
+type SyncEvent struct {
+	Duration time.Duration
+	Err      error
+}
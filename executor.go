@@ -15,11 +15,15 @@
 package pilosa
 
 import (
+	"container/heap"
 	"context"
 	"encoding/json"
 	"fmt"
+	"hash/fnv"
 	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/pilosa/pilosa/v2/pql"
@@ -51,12 +55,51 @@ type executor struct {
 	// Client used for remote requests.
 	client InternalQueryClient
 
-	// Maximum number of Set() or Clear() commands per request.
-	MaxWritesPerRequest int
+	// Maximum number of Set() or Clear() commands per request. Accessed
+	// atomically, since it may be changed by the cluster settings.
+	maxWritesPerRequest int64
 
 	workersWG      sync.WaitGroup
 	workerPoolSize int
 	work           chan job
+	batchWork      chan job // work for batch priority queries
+
+	// Maximum number of shards a single query may have queued or running
+	// in the worker pool at once. Zero means no limit.
+	maxQueryFanOut int
+
+	// Maximum number of requests to other nodes a single query may have
+	// outstanding at once. Zero means no limit.
+	maxRemoteFanOut int
+
+	// Number of goroutines used to scan a single fragment for large
+	// operations such as Sum(), Rows(), and exact TopN().
+	scanConcurrency int
+
+	// Maximum number of bytes a single query may allocate for intermediate
+	// rows on this node. Zero means no limit. Accessed atomically.
+	maxQueryMemory int64
+
+	// Custom calls registered by embedders.
+	callsMu sync.RWMutex
+	calls   map[string]CallDefinition
+
+	// Rankings of paged TopN() calls, by call.
+	topNPagesMu sync.Mutex
+	topNPages   map[string]*topNPage
+
+	// Merged results of TopN() calls coordinated by this node, which are
+	// reused for topNCacheTTL unless a field they read is written first.
+	// A zero TTL disables the cache. The generation is incremented by each
+	// invalidation, so that results computed across one aren't cached.
+	topNCacheTTL time.Duration
+	topNCacheMu  sync.Mutex
+	topNCache    map[topNCacheKey]*topNResult
+	topNCacheGen uint64
+
+	// Fields written to on this node, whose cached TopN() results are yet
+	// to be invalidated on the other nodes.
+	topNInvalidations *topNInvalidations
 }
 
 // executorOption is a functional option type for pilosa.Executor
@@ -76,11 +119,54 @@ func optExecutorWorkerPoolSize(size int) executorOption {
 	}
 }
 
+func optExecutorMaxQueryFanOut(n int) executorOption {
+	return func(e *executor) error {
+		e.maxQueryFanOut = n
+		return nil
+	}
+}
+
+func optExecutorMaxRemoteFanOut(n int) executorOption {
+	return func(e *executor) error {
+		e.maxRemoteFanOut = n
+		return nil
+	}
+}
+
+func optExecutorScanConcurrency(n int) executorOption {
+	return func(e *executor) error {
+		e.scanConcurrency = n
+		return nil
+	}
+}
+
+func optExecutorTopNCacheTTL(d time.Duration) executorOption {
+	return func(e *executor) error {
+		e.topNCacheTTL = d
+		return nil
+	}
+}
+
+func optExecutorMaxQueryMemory(n int64) executorOption {
+	return func(e *executor) error {
+		e.maxQueryMemory = n
+		return nil
+	}
+}
+
+// setLimits sets the limits on the writes and memory of each query, which
+// may be changed while queries are executing.
+func (e *executor) setLimits(maxWritesPerRequest int, maxQueryMemory int64) {
+	atomic.StoreInt64(&e.maxWritesPerRequest, int64(maxWritesPerRequest))
+	atomic.StoreInt64(&e.maxQueryMemory, maxQueryMemory)
+}
+
 // newExecutor returns a new instance of Executor.
 func newExecutor(opts ...executorOption) *executor {
 	e := &executor{
-		client:         newNopInternalQueryClient(),
-		workerPoolSize: 2,
+		client:            newNopInternalQueryClient(),
+		workerPoolSize:    2,
+		topNInvalidations: newTopNInvalidations(),
 	}
 	for _, opt := range opts {
 		err := opt(e)
@@ -93,11 +179,12 @@ func newExecutor(opts ...executorOption) *executor {
 	// the few tests we've done at scale with concurrent query
 	// workloads. Possible that it could be smaller.
 	e.work = make(chan job, e.workerPoolSize)
+	e.batchWork = make(chan job, e.workerPoolSize)
 	for i := 0; i < e.workerPoolSize; i++ {
 		e.workersWG.Add(1)
 		go func() {
 			defer e.workersWG.Done()
-			worker(e.work)
+			worker(e.work, e.batchWork)
 		}()
 	}
 	return e
@@ -105,6 +192,7 @@ func newExecutor(opts ...executorOption) *executor {
 
 func (e *executor) Close() error {
 	close(e.work)
+	close(e.batchWork)
 	e.workersWG.Wait()
 	return nil
 }
@@ -126,25 +214,55 @@ func (e *executor) Execute(ctx context.Context, index string, q *pql.Query, shar
 		return resp, ErrIndexRequired
 	}
 
+	// Resolve aliases once, so that the whole query reads the same index
+	// and fields even if an alias is moved while it runs.
+	index = e.Holder.resolveIndex(index)
 	idx := e.Holder.Index(index)
 	if idx == nil {
 		return resp, newNotFoundError(ErrIndexNotFound, index)
 	}
 
 	// Verify that the number of writes do not exceed the maximum.
-	if e.MaxWritesPerRequest > 0 && q.WriteCallN() > e.MaxWritesPerRequest {
+	if n := atomic.LoadInt64(&e.maxWritesPerRequest); n > 0 && int64(q.WriteCallN()) > n {
 		return resp, ErrTooManyWrites
 	}
 
+	// Track memory allocated for intermediate rows.
+	if n := atomic.LoadInt64(&e.maxQueryMemory); n > 0 {
+		ctx = withQueryMemory(ctx, n)
+	}
+
 	// Default options.
 	if opt == nil {
 		opt = &execOptions{}
 	}
 
-	// Translate query keys to ids, if necessary.
+	// Remote calls were rewritten and resolved by the node they originated
+	// on.
+	if !opt.Remote {
+		if err := idx.rewriteCalls(q.Calls); err != nil {
+			return resp, NewBadRequestError(err)
+		}
+		e.Holder.resolveFieldAliases(idx, q.Calls)
+		e.Holder.resolveCrossIndexAliases(q.Calls)
+
+		// Other nodes only see the writes to the shards they own, so the
+		// node which writes are sent to has every node invalidate its
+		// cached TopN() results.
+		defer e.invalidateTopNWrites(index, q.Calls)
+	}
+
+	if opt.ContinueOnError && len(q.Calls) > 1 {
+		return e.executeEach(ctx, index, q, shards, opt)
+	}
+
+	// Translate query keys to ids, if necessary, and fix times relative to
+	// now, so that every node reads the same range.
 	// No need to translate a remote call.
 	if !opt.Remote {
-		if err := e.translateCalls(ctx, index, idx, q.Calls); err != nil {
+		if err := resolveRelativeTimes(q.Calls, time.Now(), idx.Location()); err != nil {
+			return resp, NewBadRequestError(err)
+		} else if err := e.translateCalls(ctx, index, idx, q.Calls); err != nil {
 			return resp, err
 		} else if err := validateQueryContext(ctx); err != nil {
 			return resp, err
@@ -205,6 +323,42 @@ func (e *executor) Execute(ctx context.Context, index string, q *pql.Query, shar
 	return resp, nil
 }
 
+// executeEach executes each call of a query on its own, so that calls which
+// fail don't fail the others. The result of a call which failed is a
+// CallError. The query still fails if it is cancelled or times out.
+func (e *executor) executeEach(ctx context.Context, index string, q *pql.Query, shards []uint64, opt *execOptions) (QueryResponse, error) {
+	callOpt := *opt
+	callOpt.ContinueOnError = false
+
+	resp := QueryResponse{Results: make([]interface{}, len(q.Calls))}
+	type column struct {
+		id  uint64
+		key string
+	}
+	seen := make(map[column]bool)
+	for i, call := range q.Calls {
+		callResp, err := e.Execute(ctx, index, &pql.Query{Calls: []*pql.Call{call}}, shards, &callOpt)
+		if err != nil {
+			if err := validateQueryContext(ctx); err != nil {
+				return QueryResponse{}, err
+			}
+			resp.Results[i] = CallError{Err: err}
+			continue
+		}
+		resp.Results[i] = callResp.Results[0]
+
+		// Columns in the results of several calls have their attributes
+		// returned once.
+		for _, set := range callResp.ColumnAttrSets {
+			if col := (column{id: set.ID, key: set.Key}); !seen[col] {
+				seen[col] = true
+				resp.ColumnAttrSets = append(resp.ColumnAttrSets, set)
+			}
+		}
+	}
+	return resp, nil
+}
+
 // readColumnAttrSets returns a list of column attribute objects by id.
 func (e *executor) readColumnAttrSets(index *Index, ids []uint64) ([]*ColumnAttrSet, error) {
 	if index == nil {
@@ -243,17 +397,34 @@ func (e *executor) execute(ctx context.Context, index string, q *pql.Query, shar
 		if idx == nil {
 			return nil, newNotFoundError(ErrIndexNotFound, index)
 		}
-		shards = idx.AvailableShards().Slice()
+		available := idx.AvailableShards()
+		for _, name := range crossIndexNames(q.Calls) {
+			if other := e.Holder.Index(name); other != nil {
+				available = available.Union(other.AvailableShards())
+			}
+		}
+		shards = available.Slice()
 		if len(shards) == 0 {
 			shards = []uint64{0}
 		}
 	}
 
+	// The rows of Index() calls are fetched from other nodes for all of the
+	// shards mapped on this node at once.
+	if len(crossIndexNames(q.Calls)) > 0 {
+		ctx, _ = withCrossIndexRows(ctx)
+	}
+
 	// Optimize handling for bulk attribute insertion.
 	if hasOnlySetRowAttrs(q.Calls) {
 		return e.executeBulkSetRowAttrs(ctx, index, q.Calls, opt)
 	}
 
+	// Forward the writes for each other node in one request.
+	if !opt.Remote && len(q.Calls) > 1 && hasOnlySetClear(q.Calls) {
+		return e.executeBulkWrites(ctx, index, q.Calls, opt)
+	}
+
 	// Execute each call serially.
 	results := make([]interface{}, 0, len(q.Calls))
 	for _, call := range q.Calls {
@@ -289,6 +460,16 @@ func (e *executor) executeCall(ctx context.Context, index string, c *pql.Call, s
 		e.Holder.Logger.Printf("DEPRECATED: Range() is deprecated, please use Row() instead.")
 	}
 
+	// Record the use of the fields of the call, so ephemeral fields which
+	// are in use are kept.
+	e.Holder.touchFields(index, callFields(c))
+
+	// Writes invalidate the cached TopN() results which read the fields
+	// they write to.
+	if topNWriteCalls[c.Name] {
+		defer e.invalidateTopN(index, callFields(c)...)
+	}
+
 	// Special handling for mutation and top-n calls.
 	switch c.Name {
 	case "Sum":
@@ -332,8 +513,17 @@ func (e *executor) executeCall(ctx context.Context, index string, c *pql.Call, s
 		return e.executeGroupBy(ctx, index, c, shards, opt)
 	case "Options":
 		return e.executeOptionsCall(ctx, index, c, shards, opt)
+	case "Sample":
+		e.Holder.Stats.CountWithCustomTags(c.Name, 1, 1.0, []string{indexTag})
+		return e.executeSample(ctx, index, c, shards, opt)
+	case "Limit":
+		e.Holder.Stats.CountWithCustomTags(c.Name, 1, 1.0, []string{indexTag})
+		return e.executeLimit(ctx, index, c, shards, opt)
 	default:
 		e.Holder.Stats.CountWithCustomTags(c.Name, 1, 1.0, []string{indexTag})
+		if def, ok := e.customCall(c.Name); ok {
+			return e.executeCustomCall(ctx, index, c, def, shards, opt)
+		}
 		return e.executeBitmapCall(ctx, index, c, shards, opt)
 	}
 }
@@ -658,7 +848,9 @@ func (e *executor) executeBitmapCallShard(ctx context.Context, index string, c *
 
 	switch c.Name {
 	case "Row", "Range":
-		return e.executeRowShard(ctx, index, c, shard)
+		row, err := e.executeRowShard(ctx, index, c, shard)
+		queryScanFromContext(ctx).add(row)
+		return row, err
 	case "Difference":
 		return e.executeDifferenceShard(ctx, index, c, shard)
 	case "Intersect":
@@ -671,11 +863,234 @@ func (e *executor) executeBitmapCallShard(ctx context.Context, index string, c *
 		return e.executeNotShard(ctx, index, c, shard)
 	case "Shift":
 		return e.executeShiftShard(ctx, index, c, shard)
+	case "Index":
+		return e.executeIndexShard(ctx, index, c, shard)
+	case "Materialized":
+		return e.executeMaterializedShard(ctx, index, c, shard)
+	case "Sample":
+		return nil, errors.New("Sample() must be the outermost call")
+	case "Limit":
+		return nil, errors.New("Limit() must be the outermost call")
 	default:
+		if def, ok := e.customCall(c.Name); ok {
+			return e.executeCustomCallShard(ctx, index, c, def, shard)
+		}
 		return nil, fmt.Errorf("unknown call: %s", c.Name)
 	}
 }
 
+// executeMaterializedShard returns the result of a materialized view for a
+// single shard, which is only recomputed if the fields it reads have changed.
+func (e *executor) executeMaterializedShard(ctx context.Context, index string, c *pql.Call, shard uint64) (*Row, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.executeMaterializedShard")
+	defer span.Finish()
+
+	idx := e.Holder.Index(index)
+	if idx == nil {
+		return nil, newNotFoundError(ErrIndexNotFound, index)
+	}
+	name := callArgString(c, "name")
+	if name == "" {
+		return nil, errors.New("Materialized(): name required")
+	}
+	mv := idx.materializedView(name)
+	if mv == nil {
+		return nil, newNotFoundError(ErrMaterializedViewNotFound, name)
+	}
+	return mv.shardRow(idx, shard, func(c *pql.Call) (*Row, error) {
+		return e.executeBitmapCallShard(ctx, index, c, shard)
+	})
+}
+
+// executeIndexShard executes the child of an Index() call against another
+// index for a single shard. The other index's shard may be owned by a
+// different node, in which case its row was fetched from there along with
+// those of the other shards mapped on this node.
+func (e *executor) executeIndexShard(ctx context.Context, index string, c *pql.Call, shard uint64) (*Row, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.executeIndexShard")
+	defer span.Finish()
+
+	idx := e.Holder.Index(index)
+	if idx == nil {
+		return nil, newNotFoundError(ErrIndexNotFound, index)
+	}
+	other, err := e.crossIndex(idx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	if e.Cluster.ownsShard(e.Node.ID, other.Name(), shard) {
+		return e.executeBitmapCallShard(ctx, other.Name(), c.Children[0], shard)
+	}
+
+	rows := crossIndexRowsFromContext(ctx)
+	if rows == nil {
+		ctx, rows = withCrossIndexRows(ctx)
+	}
+	if row, ok := rows.shard(c, shard); ok {
+		return row, nil
+	}
+	// The shard wasn't mapped by this node's mapper, such as when the call
+	// is executed by a materialized view.
+	if err := e.fetchCrossIndexRows(ctx, c, []uint64{shard}, &execOptions{}); err != nil {
+		return nil, err
+	}
+	row, _ := rows.shard(c, shard)
+	return row, nil
+}
+
+// fetchCrossIndexRows executes the children of the Index() calls in c against
+// those of shards which this node doesn't own in the other indexes. They are
+// mapped and reduced across the nodes which do, so that each is sent one
+// request for all of its shards. The rows are kept in the context's
+// crossIndexRows for executeIndexShard.
+func (e *executor) fetchCrossIndexRows(ctx context.Context, c *pql.Call, shards []uint64, opt *execOptions) error {
+	rows := crossIndexRowsFromContext(ctx)
+	if rows == nil {
+		return nil
+	}
+	for _, child := range c.Children {
+		if err := e.fetchCrossIndexRows(ctx, child, shards, opt); err != nil {
+			return err
+		}
+	}
+
+	// Invalid calls are reported when they are executed.
+	name, _ := c.Args["name"].(string)
+	if c.Name != "Index" || len(c.Children) != 1 || e.Holder.Index(name) == nil {
+		return nil
+	}
+	var remote []uint64
+	for _, shard := range shards {
+		if !e.Cluster.ownsShard(e.Node.ID, name, shard) && !rows.fetched(c, shard) {
+			remote = append(remote, shard)
+		}
+	}
+	if len(remote) == 0 {
+		return nil
+	}
+
+	mapFn := func(shard uint64) (interface{}, error) {
+		return e.executeBitmapCallShard(ctx, name, c.Children[0], shard)
+	}
+	reduceFn := func(prev, v interface{}) interface{} {
+		other, _ := prev.(*Row)
+		if other == nil {
+			other = NewRow()
+		}
+		other.Merge(v.(*Row))
+		return other
+	}
+	v, err := e.mapReduce(ctx, name, remote, c.Children[0], &execOptions{Affinity: opt.Affinity}, mapFn, reduceFn)
+	if err != nil {
+		return errors.Wrapf(err, "executing Index() against index %s", name)
+	}
+	row, _ := v.(*Row)
+	rows.add(c, remote, row)
+	return nil
+}
+
+// crossIndexRows holds the rows of Index() calls fetched from the nodes which
+// own the other indexes' shards, for the shards of a query mapped on this
+// node.
+type crossIndexRows struct {
+	mu     sync.Mutex
+	rows   map[*pql.Call]*Row
+	shards map[*pql.Call]map[uint64]struct{}
+}
+
+type crossIndexRowsKey struct{}
+
+// withCrossIndexRows returns a context which holds the rows of the Index()
+// calls of a query.
+func withCrossIndexRows(ctx context.Context) (context.Context, *crossIndexRows) {
+	rows := &crossIndexRows{
+		rows:   make(map[*pql.Call]*Row),
+		shards: make(map[*pql.Call]map[uint64]struct{}),
+	}
+	return context.WithValue(ctx, crossIndexRowsKey{}, rows), rows
+}
+
+// crossIndexRowsFromContext returns the rows held by the context, if any.
+func crossIndexRowsFromContext(ctx context.Context) *crossIndexRows {
+	rows, _ := ctx.Value(crossIndexRowsKey{}).(*crossIndexRows)
+	return rows
+}
+
+// add records the row of an Index() call fetched for shards.
+func (r *crossIndexRows) add(c *pql.Call, shards []uint64, row *Row) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.shards[c] == nil {
+		r.shards[c] = make(map[uint64]struct{})
+	}
+	for _, shard := range shards {
+		r.shards[c][shard] = struct{}{}
+	}
+	if row == nil {
+		return
+	} else if prev := r.rows[c]; prev != nil {
+		prev.Merge(row)
+	} else {
+		r.rows[c] = row
+	}
+}
+
+// fetched returns true if the row of an Index() call was fetched for shard.
+func (r *crossIndexRows) fetched(c *pql.Call, shard uint64) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.shards[c][shard]
+	return ok
+}
+
+// shard returns the row of an Index() call in shard, and false if it wasn't
+// fetched.
+func (r *crossIndexRows) shard(c *pql.Call, shard uint64) (*Row, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.shards[c][shard]; !ok {
+		return nil, false
+	}
+	return r.rows[c].shardRow(shard), true
+}
+
+// crossIndex returns the index referenced by an Index() call, which must
+// share the column space of idx. Column IDs of indexes with column keys are
+// assigned per index, so such indexes cannot be combined. Shards are the
+// same width in every index.
+func (e *executor) crossIndex(idx *Index, c *pql.Call) (*Index, error) {
+	name, ok := c.Args["name"].(string)
+	if !ok || name == "" {
+		return nil, errors.New("Index(): name required")
+	} else if len(c.Children) != 1 {
+		return nil, errors.New("Index(): exactly one row query required")
+	}
+
+	other := e.Holder.Index(name)
+	if other == nil {
+		return nil, newNotFoundError(ErrIndexNotFound, name)
+	} else if idx.Keys() || other.Keys() {
+		return nil, errors.New("Index(): indexes with column keys do not share column IDs")
+	}
+	return other, nil
+}
+
+// crossIndexNames returns the names of the indexes referenced by Index()
+// calls in calls and their children.
+func crossIndexNames(calls []*pql.Call) []string {
+	var names []string
+	for _, c := range calls {
+		if c.Name == "Index" {
+			if name, ok := c.Args["name"].(string); ok {
+				names = append(names, name)
+			}
+		}
+		names = append(names, crossIndexNames(c.Children)...)
+	}
+	return names
+}
+
 // executeSumCountShard calculates the sum and count for bsiGroups on a shard.
 func (e *executor) executeSumCountShard(ctx context.Context, index string, c *pql.Call, shard uint64) (ValCount, error) {
 	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.executeSumCountShard")
@@ -707,7 +1122,7 @@ func (e *executor) executeSumCountShard(ctx context.Context, index string, c *pq
 		return ValCount{}, nil
 	}
 
-	vsum, vcount, err := fragment.sum(filter, bsig.BitDepth)
+	vsum, vcount, err := fragment.sumParallel(filter, bsig.BitDepth, e.scanConcurrency)
 	if err != nil {
 		return ValCount{}, errors.Wrap(err, "computing sum")
 	}
@@ -869,7 +1284,39 @@ func (e *executor) executeTopN(ctx context.Context, index string, c *pql.Call, s
 	if err != nil {
 		return nil, fmt.Errorf("executeTopN: %v", err)
 	}
+	offset, paged, err := c.UintArg("offset")
+	if err != nil {
+		return nil, fmt.Errorf("executeTopN: %v", err)
+	} else if paged {
+		return e.executeTopNPage(ctx, index, c, shards, opt, n, offset)
+	}
 
+	// The counts held by the rank caches are merged as they are, without
+	// a threshold or refetching.
+	if readCache, _ := c.Args["cached"].(bool); readCache {
+		return e.executeTopNShards(ctx, index, c, shards, opt)
+	}
+
+	// Only the original caller merges the full result, so only it caches it.
+	if e.topNCacheTTL == 0 || opt.Remote {
+		return e.executeTopNMerged(ctx, index, c, shards, opt, idsArg, n)
+	}
+	key := newTopNCacheKey(index, c, shards, n)
+	pairs, gen, ok := e.cachedTopN(key)
+	if ok {
+		return pairs, nil
+	}
+	pairs, err = e.executeTopNMerged(ctx, index, c, shards, opt, idsArg, n)
+	if err != nil {
+		return nil, err
+	}
+	e.cacheTopN(key, c, pairs, gen)
+	return pairs, nil
+}
+
+// executeTopNMerged executes a TopN() call across shards, and refetches the
+// full counts of the top pairs if it is the original caller.
+func (e *executor) executeTopNMerged(ctx context.Context, index string, c *pql.Call, shards []uint64, opt *execOptions, idsArg []uint64, n uint64) ([]Pair, error) {
 	// Execute original query.
 	pairs, err := e.executeTopNShards(ctx, index, c, shards, opt)
 	if err != nil {
@@ -899,83 +1346,414 @@ func (e *executor) executeTopN(ctx context.Context, index string, c *pql.Call, s
 	return trimmedList, nil
 }
 
-func (e *executor) executeTopNShards(ctx context.Context, index string, c *pql.Call, shards []uint64, opt *execOptions) ([]Pair, error) {
-	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.executeTopNShards")
-	defer span.Finish()
+// topNPageTTL is how long the ranking computed for a paged TopN() call is
+// reused, so that consecutive pages are consistent with each other.
+const topNPageTTL = time.Minute
 
-	// Execute calls in bulk on each remote node and merge.
-	mapFn := func(shard uint64) (interface{}, error) {
-		return e.executeTopNShard(ctx, index, c, shard)
+// topNPage is the merged ranking of a paged TopN() call.
+type topNPage struct {
+	pairs   []Pair
+	depth   uint64 // number of pairs ranked; zero if the ranking is complete
+	expires time.Time
+}
+
+// executeTopNPage executes a TopN() call with an offset, returning n pairs
+// starting at offset. The ranking is kept briefly so that later pages of the
+// same call are drawn from it.
+func (e *executor) executeTopNPage(ctx context.Context, index string, c *pql.Call, shards []uint64, opt *execOptions, n, offset uint64) ([]Pair, error) {
+	other := c.Clone()
+	delete(other.Args, "offset")
+	delete(other.Args, "n")
+	key := fmt.Sprintf("%s/%v/%s", index, shards, other)
+
+	depth := n + offset
+	if n == 0 {
+		depth = 0
 	}
 
-	// Merge returned results at coordinating node.
-	reduceFn := func(prev, v interface{}) interface{} {
-		other, _ := prev.([]Pair)
-		return Pairs(other).Add(v.([]Pair))
+	pairs, ok := e.topNPage(key, depth)
+	if !ok {
+		if depth > 0 {
+			other.Args["n"] = depth
+		}
+		var err error
+		if pairs, err = e.executeTopN(ctx, index, other, shards, opt); err != nil {
+			return nil, err
+		}
+		if uint64(len(pairs)) < depth {
+			depth = 0
+		}
+		e.setTopNPage(key, pairs, depth)
 	}
 
-	other, err := e.mapReduce(ctx, index, shards, c, opt, mapFn, reduceFn)
-	if err != nil {
-		return nil, err
+	if offset >= uint64(len(pairs)) {
+		return []Pair{}, nil
 	}
-	results, _ := other.([]Pair)
+	pairs = pairs[offset:]
+	if n > 0 && n < uint64(len(pairs)) {
+		pairs = pairs[:n]
+	}
+	return pairs, nil
+}
 
-	// Sort final merged results.
-	sort.Sort(Pairs(results))
+// topNPage returns the cached ranking for a paged TopN() call if it has not
+// expired and ranks at least depth pairs.
+func (e *executor) topNPage(key string, depth uint64) ([]Pair, bool) {
+	e.topNPagesMu.Lock()
+	defer e.topNPagesMu.Unlock()
 
-	return results, nil
+	p := e.topNPages[key]
+	if p == nil || time.Now().After(p.expires) {
+		return nil, false
+	} else if p.depth != 0 && (depth == 0 || depth > p.depth) {
+		return nil, false
+	}
+	return p.pairs, true
 }
 
-// executeTopNShard executes a TopN call for a single shard.
-func (e *executor) executeTopNShard(ctx context.Context, index string, c *pql.Call, shard uint64) ([]Pair, error) {
-	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.executeTopNShard")
-	defer span.Finish()
+// setTopNPage caches the ranking for a paged TopN() call, and discards
+// expired rankings.
+func (e *executor) setTopNPage(key string, pairs []Pair, depth uint64) {
+	e.topNPagesMu.Lock()
+	defer e.topNPagesMu.Unlock()
 
-	fieldName, _ := c.Args["_field"].(string)
-	n, _, err := c.UintArg("n")
-	if err != nil {
-		return nil, fmt.Errorf("executeTopNShard: %v", err)
-	} else if f := e.Holder.Field(index, fieldName); f != nil && f.Type() == FieldTypeInt {
-		return nil, fmt.Errorf("cannot compute TopN() on integer field: %q", fieldName)
+	now := time.Now()
+	if e.topNPages == nil {
+		e.topNPages = make(map[string]*topNPage)
 	}
-
-	attrName, _ := c.Args["attrName"].(string)
-	rowIDs, _, err := c.UintSliceArg("ids")
-	if err != nil {
-		return nil, fmt.Errorf("executeTopNShard: %v", err)
+	for k, p := range e.topNPages {
+		if now.After(p.expires) {
+			delete(e.topNPages, k)
+		}
 	}
-	minThreshold, _, err := c.UintArg("threshold")
-	if err != nil {
-		return nil, fmt.Errorf("executeTopNShard: %v", err)
+	e.topNPages[key] = &topNPage{pairs: pairs, depth: depth, expires: now.Add(topNPageTTL)}
+}
+
+// topNCacheKey identifies a TopN() call whose merged result is cached.
+type topNCacheKey struct {
+	index  string
+	field  string
+	filter uint64 // hash of the rest of the call and its shards
+	n      uint64
+}
+
+// topNResult is the cached merged result of a TopN() call.
+type topNResult struct {
+	pairs   []Pair
+	fields  []string // fields the call reads
+	expires time.Time
+}
+
+func newTopNCacheKey(index string, c *pql.Call, shards []uint64, n uint64) topNCacheKey {
+	other := c.Clone()
+	delete(other.Args, "n")
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%v/%s", shards, other)
+	return topNCacheKey{
+		index:  index,
+		field:  callArgString(c, "_field"),
+		filter: h.Sum64(),
+		n:      n,
 	}
-	attrValues, _ := c.Args["attrValues"].([]interface{})
-	tanimotoThreshold, _, err := c.UintArg("tanimotoThreshold")
-	if err != nil {
-		return nil, fmt.Errorf("executeTopNShard: %v", err)
+}
+
+// cachedTopN returns the cached merged result of a TopN() call if it has not
+// expired. Otherwise it returns the generation of the cache, which the result
+// computed in its place is cached with.
+func (e *executor) cachedTopN(key topNCacheKey) ([]Pair, uint64, bool) {
+	e.topNCacheMu.Lock()
+	defer e.topNCacheMu.Unlock()
+
+	r := e.topNCache[key]
+	if r == nil || time.Now().After(r.expires) {
+		return nil, e.topNCacheGen, false
 	}
+	return r.pairs, e.topNCacheGen, true
+}
 
-	// Retrieve bitmap used to intersect.
-	var src *Row
-	if len(c.Children) == 1 {
-		row, err := e.executeBitmapCallShard(ctx, index, c.Children[0], shard)
-		if err != nil {
-			return nil, err
+// cacheTopN caches the merged result of a TopN() call, and discards expired
+// results. The result isn't cached if the cache has been invalidated since
+// generation gen, when it began to be computed, since it may not include the
+// write which invalidated it.
+func (e *executor) cacheTopN(key topNCacheKey, c *pql.Call, pairs []Pair, gen uint64) {
+	e.topNCacheMu.Lock()
+	defer e.topNCacheMu.Unlock()
+
+	if gen != e.topNCacheGen {
+		return
+	}
+	now := time.Now()
+	if e.topNCache == nil {
+		e.topNCache = make(map[topNCacheKey]*topNResult)
+	}
+	for k, r := range e.topNCache {
+		if now.After(r.expires) {
+			delete(e.topNCache, k)
 		}
-		src = row
-	} else if len(c.Children) > 1 {
-		return nil, errors.New("TopN() can only have one input bitmap")
 	}
+	e.topNCache[key] = &topNResult{pairs: pairs, fields: callFields(c), expires: now.Add(e.topNCacheTTL)}
+}
 
-	// Set default field.
-	if fieldName == "" {
-		fieldName = defaultField
+// invalidateTopN discards the cached TopN() results of an index which read
+// any of the given fields, or all of them if no fields are given.
+func (e *executor) invalidateTopN(index string, fields ...string) {
+	e.topNCacheMu.Lock()
+	defer e.topNCacheMu.Unlock()
+
+	e.topNCacheGen++
+	for k, r := range e.topNCache {
+		if k.index != index {
+			continue
+		} else if len(fields) == 0 || stringSlicesOverlap(r.fields, fields) {
+			delete(e.topNCache, k)
+		}
 	}
+}
 
-	f := e.Holder.fragment(index, fieldName, viewStandard, shard)
-	if f == nil {
+// invalidateTopNCluster discards the cached TopN() results of an index which
+// read any of the given fields on every node. Other nodes are sent an
+// InvalidateTopNMessage in the background by sendTopNInvalidations, so that
+// writes don't wait for them. This is done whether or not this node caches
+// results itself, since other nodes may.
+func (e *executor) invalidateTopNCluster(index string, fields ...string) {
+	e.invalidateTopN(index, fields...)
+	e.topNInvalidations.add(index, fields)
+}
+
+// invalidateTopNWrites discards the cached TopN() results on every node which
+// read the fields written to by the write calls among calls.
+func (e *executor) invalidateTopNWrites(index string, calls []*pql.Call) {
+	var fields []string
+	for _, c := range calls {
+		if topNWriteCalls[c.Name] {
+			fields = append(fields, callFields(c)...)
+		}
+	}
+	if len(fields) > 0 {
+		e.invalidateTopNCluster(index, fields...)
+	}
+}
+
+// topNInvalidations collects the fields of each index whose cached TopN()
+// results are to be invalidated on other nodes, so that the invalidations
+// of writes made while a message is being sent are sent together in the
+// next one.
+type topNInvalidations struct {
+	mu sync.Mutex
+
+	// The fields of each index, or nil for all of them.
+	pending map[string]map[string]struct{}
+
+	// Signalled when an invalidation is added.
+	notify chan struct{}
+}
+
+func newTopNInvalidations() *topNInvalidations {
+	return &topNInvalidations{
+		pending: make(map[string]map[string]struct{}),
+		notify:  make(chan struct{}, 1),
+	}
+}
+
+// add records the fields of an index to invalidate, or all of its fields if
+// none are given.
+func (t *topNInvalidations) add(index string, fields []string) {
+	t.mu.Lock()
+	prev, ok := t.pending[index]
+	if len(fields) == 0 {
+		t.pending[index] = nil
+	} else if !ok || prev != nil {
+		if prev == nil {
+			prev = make(map[string]struct{}, len(fields))
+			t.pending[index] = prev
+		}
+		for _, field := range fields {
+			prev[field] = struct{}{}
+		}
+	}
+	t.mu.Unlock()
+
+	select {
+	case t.notify <- struct{}{}:
+	default:
+	}
+}
+
+// take returns a message for each index with invalidations pending since it
+// was last called.
+func (t *topNInvalidations) take() []*InvalidateTopNMessage {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	msgs := make([]*InvalidateTopNMessage, 0, len(t.pending))
+	for index, fields := range t.pending {
+		m := &InvalidateTopNMessage{Index: index}
+		for field := range fields {
+			m.Fields = append(m.Fields, field)
+		}
+		sort.Strings(m.Fields)
+		msgs = append(msgs, m)
+	}
+	sort.Slice(msgs, func(i, j int) bool { return msgs[i].Index < msgs[j].Index })
+	t.pending = make(map[string]map[string]struct{})
+	return msgs
+}
+
+// sendTopNInvalidations sends the other nodes the TopN() invalidations of
+// writes made on this node, as they are made. This is run in a goroutine.
+func (s *Server) sendTopNInvalidations() {
+	t := s.executor.topNInvalidations
+	for {
+		select {
+		case <-s.closing:
+			return
+		case <-t.notify:
+		}
+
+		msgs := t.take()
+		if err := s.cluster.validateFeatures(FeatureTopNInvalidation); err != nil {
+			continue
+		}
+		for _, m := range msgs {
+			if err := s.SendSync(m); err != nil {
+				s.logger.Printf("sending InvalidateTopN message: %v", err)
+			}
+		}
+	}
+}
+
+// topNWriteCalls are the calls which write to the fields they name.
+var topNWriteCalls = map[string]bool{
+	"Set":         true,
+	"Clear":       true,
+	"ClearRow":    true,
+	"Store":       true,
+	"SetRowAttrs": true,
+}
+
+// InvalidateTopNMessage is an internal message telling a node to discard its
+// cached TopN() results of an index which read any of the fields, since they
+// were written to on other nodes.
+type InvalidateTopNMessage struct {
+	Index  string
+	Fields []string
+}
+
+// callFields returns the names of the fields which a call and its children
+// may read or write. Other argument names may be included too, which only
+// makes invalidation more conservative.
+func callFields(c *pql.Call) []string {
+	var fields []string
+	var walk func(c *pql.Call)
+	walk = func(c *pql.Call) {
+		for k, v := range c.Args {
+			switch v := v.(type) {
+			case string:
+				if k == "_field" || k == "field" {
+					fields = append(fields, v)
+				}
+			case *pql.Call:
+				walk(v)
+			}
+			if !pql.IsReservedArg(k) {
+				fields = append(fields, k)
+			}
+		}
+		for _, child := range c.Children {
+			walk(child)
+		}
+	}
+	walk(c)
+	return fields
+}
+
+// stringSlicesOverlap returns true if a and b have an element in common.
+func stringSlicesOverlap(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (e *executor) executeTopNShards(ctx context.Context, index string, c *pql.Call, shards []uint64, opt *execOptions) ([]Pair, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.executeTopNShards")
+	defer span.Finish()
+
+	// Execute calls in bulk on each remote node and merge.
+	mapFn := func(shard uint64) (interface{}, error) {
+		return e.executeTopNShard(ctx, index, c, shard)
+	}
+
+	// Merge returned results at coordinating node.
+	reduceFn := func(prev, v interface{}) interface{} {
+		other, _ := prev.([]Pair)
+		return Pairs(other).Add(v.([]Pair))
+	}
+
+	other, err := e.mapReduce(ctx, index, shards, c, opt, mapFn, reduceFn)
+	if err != nil {
+		return nil, err
+	}
+	results, _ := other.([]Pair)
+
+	// Sort final merged results.
+	sort.Sort(Pairs(results))
+
+	return results, nil
+}
+
+// executeTopNShard executes a TopN call for a single shard.
+func (e *executor) executeTopNShard(ctx context.Context, index string, c *pql.Call, shard uint64) ([]Pair, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.executeTopNShard")
+	defer span.Finish()
+
+	fieldName, _ := c.Args["_field"].(string)
+	n, _, err := c.UintArg("n")
+	if err != nil {
+		return nil, fmt.Errorf("executeTopNShard: %v", err)
+	} else if f := e.Holder.Field(index, fieldName); f != nil && f.Type() == FieldTypeInt {
+		return nil, fmt.Errorf("cannot compute TopN() on integer field: %q", fieldName)
+	}
+	if readCache, _ := c.Args["cached"].(bool); readCache {
+		if f := e.Holder.fragment(index, fieldName, viewStandard, shard); f != nil {
+			return f.cachedCounts(), nil
+		}
 		return nil, nil
-	} else if f.CacheType == CacheTypeNone {
-		return nil, fmt.Errorf("cannot compute TopN(), field has no cache: %q", fieldName)
+	}
+
+	attrName, _ := c.Args["attrName"].(string)
+	rowIDs, _, err := c.UintSliceArg("ids")
+	if err != nil {
+		return nil, fmt.Errorf("executeTopNShard: %v", err)
+	}
+	minThreshold, _, err := c.UintArg("threshold")
+	if err != nil {
+		return nil, fmt.Errorf("executeTopNShard: %v", err)
+	}
+	attrValues, _ := c.Args["attrValues"].([]interface{})
+	tanimotoThreshold, _, err := c.UintArg("tanimotoThreshold")
+	if err != nil {
+		return nil, fmt.Errorf("executeTopNShard: %v", err)
+	}
+
+	// Retrieve bitmap used to intersect.
+	var src *Row
+	if len(c.Children) == 1 {
+		row, err := e.executeBitmapCallShard(ctx, index, c.Children[0], shard)
+		if err != nil {
+			return nil, err
+		}
+		src = row
+	} else if len(c.Children) > 1 {
+		return nil, errors.New("TopN() can only have one input bitmap")
+	}
+
+	// Set default field.
+	if fieldName == "" {
+		fieldName = defaultField
 	}
 
 	if minThreshold == 0 {
@@ -985,6 +1763,36 @@ func (e *executor) executeTopNShard(ctx context.Context, index string, c *pql.Ca
 	if tanimotoThreshold > 100 {
 		return nil, errors.New("Tanimoto Threshold is from 1 to 100 only")
 	}
+
+	// Restrict evaluation to the time views in range, if a range is set.
+	fromTime, toTime, err := callTimeRange(c, e.indexLocation(index))
+	if err != nil {
+		return nil, err
+	} else if !fromTime.IsZero() || !toTime.IsZero() {
+		if tanimotoThreshold > 0 {
+			return nil, errors.New("TopN(): tanimotoThreshold cannot be combined with from/to")
+		}
+		return e.executeTopNTimeShard(index, fieldName, fromTime, toTime, shard, topOptions{
+			N:            int(n),
+			Src:          src,
+			RowIDs:       rowIDs,
+			FilterName:   attrName,
+			FilterValues: attrValues,
+			MinThreshold: minThreshold,
+		})
+	}
+
+	f := e.Holder.fragment(index, fieldName, viewStandard, shard)
+	if f == nil {
+		return nil, nil
+	} else if f.CacheType == CacheTypeNone {
+		// Specific rows can still be counted from storage.
+		if len(rowIDs) == 0 || attrName != "" || tanimotoThreshold > 0 {
+			return nil, fmt.Errorf("cannot compute TopN(), field has no cache: %q", fieldName)
+		}
+		return f.rowCounts(rowIDs, src, minThreshold), nil
+	}
+
 	return f.top(topOptions{
 		N:                 int(n),
 		Src:               src,
@@ -993,9 +1801,87 @@ func (e *executor) executeTopNShard(ctx context.Context, index string, c *pql.Ca
 		FilterValues:      attrValues,
 		MinThreshold:      minThreshold,
 		TanimotoThreshold: tanimotoThreshold,
+		Concurrency:       e.scanConcurrency,
 	})
 }
 
+// executeTopNTimeShard executes a TopN call for a single shard over the time
+// views of a field which fall within the given range. Time views do not keep
+// ranked caches so counts are computed directly from the view fragments.
+func (e *executor) executeTopNTimeShard(index, fieldName string, fromTime, toTime time.Time, shard uint64, opt topOptions) ([]Pair, error) {
+	f := e.Holder.Field(index, fieldName)
+	if f == nil {
+		return nil, nil
+	}
+
+	views, err := timeRangeViews(f, fromTime, timeRangeEnd(toTime))
+	if err != nil {
+		return nil, err
+	}
+	frags := make([]*fragment, 0, len(views))
+	for _, view := range views {
+		if frag := e.Holder.fragment(index, fieldName, view, shard); frag != nil {
+			frags = append(frags, frag)
+		}
+	}
+	if len(frags) == 0 {
+		return nil, nil
+	}
+
+	// Determine candidate rows from the requested ids or from all views.
+	rowIDs := opt.RowIDs
+	if len(rowIDs) == 0 {
+		for _, frag := range frags {
+			rowIDs = uint64Slice(rowIDs).merge(frag.rows(0))
+		}
+	}
+
+	// Create a fast lookup of filter values.
+	var filters map[interface{}]struct{}
+	if opt.FilterName != "" && len(opt.FilterValues) > 0 {
+		filters = make(map[interface{}]struct{})
+		for _, v := range opt.FilterValues {
+			filters[v] = struct{}{}
+		}
+	}
+
+	pairs := make([]Pair, 0, len(rowIDs))
+	for _, rowID := range rowIDs {
+		// Apply filter, if set.
+		if filters != nil {
+			attr, err := f.RowAttrStore().Attrs(rowID)
+			if err != nil {
+				return nil, errors.Wrap(err, "getting attrs")
+			} else if _, ok := filters[attr[opt.FilterName]]; !ok {
+				continue
+			}
+		}
+
+		rows := make([]*Row, len(frags))
+		for i, frag := range frags {
+			rows[i] = frag.row(rowID)
+		}
+		row := rows[0].Union(rows[1:]...)
+
+		var count uint64
+		if opt.Src != nil {
+			count = opt.Src.intersectionCount(row)
+		} else {
+			count = row.Count()
+		}
+		if count == 0 || count < opt.MinThreshold {
+			continue
+		}
+		pairs = append(pairs, Pair{ID: rowID, Count: count})
+	}
+
+	sort.Sort(Pairs(pairs))
+	if len(opt.RowIDs) == 0 && opt.N > 0 && opt.N < len(pairs) {
+		pairs = pairs[:opt.N]
+	}
+	return pairs, nil
+}
+
 // executeDifferenceShard executes a difference() call for a local shard.
 func (e *executor) executeDifferenceShard(ctx context.Context, index string, c *pql.Call, shard uint64) (*Row, error) {
 	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.executeDifferenceShard")
@@ -1005,7 +1891,11 @@ func (e *executor) executeDifferenceShard(ctx context.Context, index string, c *
 	if len(c.Children) == 0 {
 		return nil, fmt.Errorf("empty Difference query is currently not supported")
 	}
-	for i, input := range c.Children {
+
+	// Subtract the largest operands first, so the result shrinks as quickly
+	// as possible, and stop once it is empty.
+	children := append([]*pql.Call{c.Children[0]}, e.sortCallsByEstimate(index, c.Children[1:], shard, true)...)
+	for i, input := range children {
 		row, err := e.executeBitmapCallShard(ctx, index, input, shard)
 		if err != nil {
 			return nil, err
@@ -1015,12 +1905,122 @@ func (e *executor) executeDifferenceShard(ctx context.Context, index string, c *
 			other = row
 		} else {
 			other = other.Difference(row)
+			if err := queryMemoryFromContext(ctx).alloc(other); err != nil {
+				return nil, err
+			}
+		}
+		if !other.Any() {
+			break
 		}
 	}
 	other.invalidateCount()
 	return other, nil
 }
 
+// estimateCallShard returns an upper bound on the number of columns in the
+// result of a bitmap call in a shard, from the counts of the rows it reads,
+// or false if the call's result can't be estimated.
+func (e *executor) estimateCallShard(index string, c *pql.Call, shard uint64) (uint64, bool) {
+	switch c.Name {
+	case "Row":
+		if c.HasConditionArg() {
+			return 0, false
+		}
+		fieldName, err := c.FieldArg()
+		if err != nil {
+			return 0, false
+		}
+		f := e.Holder.Field(index, fieldName)
+		if f == nil {
+			return 0, false
+		}
+		rowID, ok, err := c.UintArg(fieldName)
+		if err != nil || !ok {
+			return 0, false
+		}
+
+		fromTime, toTime, err := callTimeRange(c, e.indexLocation(index))
+		if err != nil {
+			return 0, false
+		}
+		views := []string{viewStandard}
+		if !fromTime.IsZero() || !toTime.IsZero() {
+			if views, err = timeRangeViews(f, fromTime, timeRangeEnd(toTime)); err != nil {
+				return 0, false
+			}
+		}
+		var n uint64
+		for _, view := range views {
+			if frag := e.Holder.fragment(index, fieldName, view, shard); frag != nil {
+				n += frag.rowCount(rowID)
+			}
+		}
+		return n, true
+
+	case "Intersect":
+		var min uint64
+		var known bool
+		for _, child := range c.Children {
+			if n, ok := e.estimateCallShard(index, child, shard); ok && (!known || n < min) {
+				min, known = n, true
+			}
+		}
+		return min, known
+
+	case "Union":
+		var sum uint64
+		for _, child := range c.Children {
+			n, ok := e.estimateCallShard(index, child, shard)
+			if !ok {
+				return 0, false
+			}
+			sum += n
+		}
+		return sum, true
+
+	case "Difference":
+		if len(c.Children) == 0 {
+			return 0, false
+		}
+		return e.estimateCallShard(index, c.Children[0], shard)
+	}
+	return 0, false
+}
+
+// sortCallsByEstimate returns the calls ordered by their estimated results in
+// a shard, smallest first, or largest first if descending is set. Calls which
+// can't be estimated keep their order after the others.
+func (e *executor) sortCallsByEstimate(index string, calls []*pql.Call, shard uint64, descending bool) []*pql.Call {
+	if len(calls) < 2 {
+		return calls
+	}
+	type estimate struct {
+		call  *pql.Call
+		n     uint64
+		known bool
+	}
+	estimates := make([]estimate, len(calls))
+	for i, call := range calls {
+		n, ok := e.estimateCallShard(index, call, shard)
+		estimates[i] = estimate{call: call, n: n, known: ok}
+	}
+	sort.SliceStable(estimates, func(i, j int) bool {
+		a, b := estimates[i], estimates[j]
+		if a.known != b.known {
+			return a.known
+		} else if descending {
+			return a.n > b.n
+		}
+		return a.n < b.n
+	})
+
+	sorted := make([]*pql.Call, len(calls))
+	for i := range estimates {
+		sorted[i] = estimates[i].call
+	}
+	return sorted
+}
+
 // RowIdentifiers is a return type for a list of
 // row ids or row keys. The names `Rows` and `Keys`
 // are meant to follow the same convention as the
@@ -1337,65 +2337,22 @@ func (e *executor) executeRowsShard(_ context.Context, index string, fieldName s
 
 	// Handle `time` fields.
 	if f.Type() == FieldTypeTime {
-		var err error
-
-		// Parse "from" time, if set.
-		var fromTime time.Time
-		if v, ok := c.Args["from"]; ok {
-			if fromTime, err = parseTime(v); err != nil {
-				return nil, errors.Wrap(err, "parsing from time")
-			}
-		}
-
-		// Parse "to" time, if set.
-		var toTime time.Time
-		if v, ok := c.Args["to"]; ok {
-			if toTime, err = parseTime(v); err != nil {
-				return nil, errors.Wrap(err, "parsing to time")
-			}
+		fromTime, toTime, err := callTimeRange(c, idx.Location())
+		if err != nil {
+			return nil, err
 		}
 
 		// Calculate the views for a range as long as some piece of the range
 		// (from/to) are specified, or if there's no standard view to represent
 		// all dates.
 		if !fromTime.IsZero() || !toTime.IsZero() || f.options.NoStandardView {
-			// If no quantum exists then return an empty result set.
-			q := f.TimeQuantum()
-			if q == "" {
+			// If no quantum exists, or there are no time views, then
+			// return an empty result set.
+			if views, err = timeRangeViews(f, fromTime, toTime); err != nil {
+				return rowIDs, err
+			} else if len(views) == 0 {
 				return rowIDs, nil
 			}
-
-			// Get min/max based on existing views.
-			var vs []string
-			for _, v := range f.views() {
-				vs = append(vs, v.name)
-			}
-			min, max := minMaxViews(vs, q)
-
-			// If min/max are empty, there were no time views.
-			if min == "" || max == "" {
-				return rowIDs, nil
-			}
-
-			// Convert min/max from string to time.Time.
-			minTime, err := timeOfView(min, false)
-			if err != nil {
-				return rowIDs, errors.Wrapf(err, "getting min time from view: %s", min)
-			}
-			if fromTime.IsZero() || fromTime.Before(minTime) {
-				fromTime = minTime
-			}
-
-			maxTime, err := timeOfView(max, true)
-			if err != nil {
-				return rowIDs, errors.Wrapf(err, "getting max time from view: %s", max)
-			}
-			if toTime.IsZero() || toTime.After(maxTime) {
-				toTime = maxTime
-			}
-
-			// Determine the views based on the specified time range.
-			views = viewsByTimeRange(viewStandard, fromTime, toTime, q)
 		}
 	}
 
@@ -1407,9 +2364,10 @@ func (e *executor) executeRowsShard(_ context.Context, index string, fieldName s
 	}
 
 	filters := []rowFilter{}
-	if columnID, ok, err := c.UintArg("column"); err != nil {
+	columnID, hasColumn, err := c.UintArg("column")
+	if err != nil {
 		return nil, err
-	} else if ok {
+	} else if hasColumn {
 		colShard := columnID >> shardwidth.Exponent
 		if colShard != shard {
 			return rowIDs, nil
@@ -1418,20 +2376,40 @@ func (e *executor) executeRowsShard(_ context.Context, index string, fieldName s
 	}
 
 	limit := int(^uint(0) >> 1)
-	if lim, hasLimit, err := c.UintArg("limit"); err != nil {
+	lim, hasLimit, err := c.UintArg("limit")
+	if err != nil {
 		return nil, errors.Wrap(err, "getting limit")
 	} else if hasLimit {
 		filters = append(filters, filterWithLimit(lim))
 		limit = int(lim)
 	}
 
+	// A field with an inverse view reads the rows of a column from it,
+	// rather than scanning every row of the standard view.
+	if hasColumn && f.options.Inverse && len(views) == 1 && views[0] == viewStandard {
+		for _, rowID := range f.inverseRows(columnID) {
+			if len(rowIDs) == limit {
+				break
+			} else if rowID >= start {
+				rowIDs = append(rowIDs, rowID)
+			}
+		}
+		return rowIDs, nil
+	}
+
 	for _, view := range views {
 		frag := e.Holder.fragment(index, fieldName, view, shard)
 		if frag == nil {
 			continue
 		}
 
-		viewRows := frag.rows(start, filters...)
+		// The limit filter is stateful, so limited scans must be serial.
+		var viewRows []uint64
+		if hasLimit {
+			viewRows = frag.rows(start, filters...)
+		} else {
+			viewRows = frag.rowsParallel(start, e.scanConcurrency, filters...)
+		}
 		rowIDs = rowIDs.merge(viewRows, limit)
 	}
 
@@ -1470,20 +2448,9 @@ func (e *executor) executeRowShard(ctx context.Context, index string, c *pql.Cal
 		return nil, fmt.Errorf("Row() must specify %v", rowLabel)
 	}
 
-	// Parse "from" time, if set.
-	var fromTime time.Time
-	if v, ok := c.Args["from"]; ok {
-		if fromTime, err = parseTime(v); err != nil {
-			return nil, errors.Wrap(err, "parsing from time")
-		}
-	}
-
-	// Parse "to" time, if set.
-	var toTime time.Time
-	if v, ok := c.Args["to"]; ok {
-		if toTime, err = parseTime(v); err != nil {
-			return nil, errors.Wrap(err, "parsing to time")
-		}
+	fromTime, toTime, err := callTimeRange(c, e.indexLocation(index))
+	if err != nil {
+		return nil, err
 	}
 
 	// Simply return row if times are not set.
@@ -1492,31 +2459,25 @@ func (e *executor) executeRowShard(ctx context.Context, index string, c *pql.Cal
 		if frag == nil {
 			return NewRow(), nil
 		}
-		return frag.row(rowID), nil
-	}
-
-	// If no quantum exists then return an empty bitmap.
-	q := f.TimeQuantum()
-	if q == "" {
-		return &Row{}, nil
+		row, cached := frag.rowCached(rowID)
+		queryProfileFromContext(ctx).rowRead(cached)
+		return row, nil
 	}
 
-	// Set maximum "to" value if only "from" is set. We don't need to worry
-	// about setting the minimum "from" since it is the zero value if omitted.
-	if toTime.IsZero() {
-		// Set the end timestamp to current time + 1 day, in order to account for timezone differences.
-		toTime = time.Now().AddDate(0, 0, 1)
+	// Union bitmaps across the time-based views in range.
+	views, err := timeRangeViews(f, fromTime, timeRangeEnd(toTime))
+	if err != nil {
+		return nil, err
 	}
-
-	// Union bitmaps across all time-based views.
-	views := viewsByTimeRange(viewStandard, fromTime, toTime, q)
 	rows := make([]*Row, 0, len(views))
 	for _, view := range views {
 		f := e.Holder.fragment(index, fieldName, view, shard)
 		if f == nil {
 			continue
 		}
-		rows = append(rows, f.row(rowID))
+		row, cached := f.rowCached(rowID)
+		queryProfileFromContext(ctx).rowRead(cached)
+		rows = append(rows, row)
 	}
 	if len(rows) == 0 {
 		return &Row{}, nil
@@ -1670,7 +2631,10 @@ func (e *executor) executeIntersectShard(ctx context.Context, index string, c *p
 	if len(c.Children) == 0 {
 		return nil, fmt.Errorf("empty Intersect query is currently not supported")
 	}
-	for i, input := range c.Children {
+
+	// Intersect the most selective operands first, so the result shrinks as
+	// quickly as possible, and stop once it is empty.
+	for i, input := range e.sortCallsByEstimate(index, c.Children, shard, false) {
 		row, err := e.executeBitmapCallShard(ctx, index, input, shard)
 		if err != nil {
 			return nil, err
@@ -1680,6 +2644,12 @@ func (e *executor) executeIntersectShard(ctx context.Context, index string, c *p
 			other = row
 		} else {
 			other = other.Intersect(row)
+			if err := queryMemoryFromContext(ctx).alloc(other); err != nil {
+				return nil, err
+			}
+		}
+		if !other.Any() {
+			break
 		}
 	}
 	other.invalidateCount()
@@ -1702,6 +2672,9 @@ func (e *executor) executeUnionShard(ctx context.Context, index string, c *pql.C
 			other = row
 		} else {
 			other = other.Union(row)
+			if err := queryMemoryFromContext(ctx).alloc(other); err != nil {
+				return nil, err
+			}
 		}
 	}
 	other.invalidateCount()
@@ -1724,6 +2697,9 @@ func (e *executor) executeXorShard(ctx context.Context, index string, c *pql.Cal
 			other = row
 		} else {
 			other = other.Xor(row)
+			if err := queryMemoryFromContext(ctx).alloc(other); err != nil {
+				return nil, err
+			}
 		}
 	}
 	other.invalidateCount()
@@ -1749,20 +2725,211 @@ func (e *executor) executeNotShard(ctx context.Context, index string, c *pql.Cal
 		return nil, errors.Errorf("index does not support existence tracking: %s", index)
 	}
 
-	var existenceRow *Row
-	existenceFrag := e.Holder.fragment(index, existenceFieldName, viewStandard, shard)
-	if existenceFrag == nil {
-		existenceRow = NewRow()
-	} else {
-		existenceRow = existenceFrag.row(0)
+	var existenceRow *Row
+	existenceFrag := e.Holder.fragment(index, existenceFieldName, viewStandard, shard)
+	if existenceFrag == nil {
+		existenceRow = NewRow()
+	} else {
+		existenceRow = existenceFrag.row(0)
+	}
+
+	row, err := e.executeBitmapCallShard(ctx, index, c.Children[0], shard)
+	if err != nil {
+		return nil, err
+	}
+
+	row = existenceRow.Difference(row)
+	if err := queryMemoryFromContext(ctx).alloc(row); err != nil {
+		return nil, err
+	}
+	return row, nil
+}
+
+// executeSample executes a Sample() call, which returns a uniform random
+// subset of n columns from its input.
+//
+// Columns are ranked by a hash of their ID and the seed, and the n lowest
+// ranked columns are kept. Each shard therefore only returns its n lowest
+// ranked columns, and contributes to the sample in proportion to its size.
+// The same seed always returns the same sample.
+func (e *executor) executeSample(ctx context.Context, index string, c *pql.Call, shards []uint64, opt *execOptions) (*Row, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.executeSample")
+	defer span.Finish()
+
+	if len(c.Children) != 1 {
+		return nil, errors.New("Sample() requires a single bitmap input")
+	}
+	n, ok, err := c.UintArg("n")
+	if err != nil {
+		return nil, errors.Wrap(err, "Sample() n")
+	} else if !ok || n == 0 {
+		return nil, errors.New("Sample() requires n greater than zero")
+	}
+	seed, _, err := c.UintArg("seed")
+	if err != nil {
+		return nil, errors.Wrap(err, "Sample() seed")
+	}
+
+	mapFn := func(shard uint64) (interface{}, error) {
+		row, err := e.executeBitmapCallShard(ctx, index, c.Children[0], shard)
+		if err != nil {
+			return nil, err
+		}
+		return sampleRow(row, int(n), seed), nil
+	}
+
+	reduceFn := func(prev, v interface{}) interface{} {
+		other, _ := prev.(*Row)
+		if other == nil {
+			return v
+		}
+		return sampleRow(other.Union(v.(*Row)), int(n), seed)
+	}
+
+	result, err := e.mapReduce(ctx, index, shards, c, opt, mapFn, reduceFn)
+	if err != nil {
+		return nil, errors.Wrap(err, "map reduce")
+	}
+	row, _ := result.(*Row)
+	if row == nil {
+		row = NewRow()
+	}
+	if opt.ExcludeColumns {
+		row.segments = []rowSegment{}
+	}
+	return row, nil
+}
+
+// executeLimit executes a Limit() call, which returns at most limit columns
+// of its input, after the column after if given, so that clients can page
+// through a row by passing the last column of each page as the next after.
+//
+// Shards are read in order, in batches which double in size, so that a page
+// of a dense row reads few shards. Each shard seeks to after within its
+// containers, and only the page of each shard is sent between nodes.
+func (e *executor) executeLimit(ctx context.Context, index string, c *pql.Call, shards []uint64, opt *execOptions) (*Row, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.executeLimit")
+	defer span.Finish()
+
+	if len(c.Children) != 1 {
+		return nil, errors.New("Limit() requires a single bitmap input")
+	}
+	limit, ok, err := c.UintArg("limit")
+	if err != nil {
+		return nil, errors.Wrap(err, "Limit() limit")
+	} else if !ok || limit == 0 {
+		return nil, errors.New("Limit() requires limit greater than zero")
+	}
+	after, hasAfter, err := c.UintArg("after")
+	if err != nil {
+		return nil, errors.Wrap(err, "Limit() after")
+	}
+	if idx := e.Holder.Index(index); idx != nil && idx.Keys() {
+		return nil, errors.New("Limit() is not supported on indexes with keys")
+	}
+	var start uint64
+	if hasAfter {
+		start = after + 1
+	}
+
+	mapFn := func(shard uint64) (interface{}, error) {
+		row, err := e.executeBitmapCallShard(ctx, index, c.Children[0], shard)
+		if err != nil {
+			return nil, err
+		}
+		return row.page(start, limit), nil
+	}
+
+	reduceFn := func(prev, v interface{}) interface{} {
+		other, _ := prev.(*Row)
+		if other == nil {
+			return v
+		}
+		return other.Union(v.(*Row)).page(start, limit)
+	}
+
+	remaining := make([]uint64, 0, len(shards))
+	for _, shard := range shards {
+		if shard >= start/ShardWidth {
+			remaining = append(remaining, shard)
+		}
+	}
+	sort.Slice(remaining, func(i, j int) bool { return remaining[i] < remaining[j] })
+
+	row := NewRow()
+	for n := 1; len(remaining) > 0 && row.Count() < limit; n *= 2 {
+		if n > len(remaining) {
+			n = len(remaining)
+		}
+		result, err := e.mapReduce(ctx, index, remaining[:n], c, opt, mapFn, reduceFn)
+		if err != nil {
+			return nil, errors.Wrap(err, "map reduce")
+		}
+		if other, ok := result.(*Row); ok {
+			row = row.Union(other).page(start, limit)
+		}
+		remaining = remaining[n:]
+	}
+	if opt.ExcludeColumns {
+		row.segments = []rowSegment{}
+	}
+	return row, nil
+}
+
+// sampleRow returns the n columns of row with the lowest sample rank.
+func sampleRow(row *Row, n int, seed uint64) *Row {
+	if row.Count() <= uint64(n) {
+		return row
+	}
+
+	// Keep the lowest ranks in a max-heap.
+	h := make(sampleHeap, 0, n)
+	for i := range row.segments {
+		itr := row.segments[i].data.Iterator()
+		for col, eof := itr.Next(); !eof; col, eof = itr.Next() {
+			rank := sampleRank(seed, col)
+			if len(h) < n {
+				heap.Push(&h, sampledColumn{rank: rank, id: col})
+			} else if rank < h[0].rank {
+				h[0] = sampledColumn{rank: rank, id: col}
+				heap.Fix(&h, 0)
+			}
+		}
 	}
 
-	row, err := e.executeBitmapCallShard(ctx, index, c.Children[0], shard)
-	if err != nil {
-		return nil, err
+	other := NewRow()
+	for _, c := range h {
+		other.SetBit(c.id)
 	}
+	return other
+}
 
-	return existenceRow.Difference(row), nil
+// sampleRank returns a pseudorandom rank for a column. It is the SplitMix64
+// finalizer, which is a bijection, so no two columns share a rank.
+func sampleRank(seed, id uint64) uint64 {
+	z := id ^ (seed * 0x9e3779b97f4a7c15)
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+type sampledColumn struct {
+	rank uint64
+	id   uint64
+}
+
+// sampleHeap is a max-heap of sampled columns by rank.
+type sampleHeap []sampledColumn
+
+func (h sampleHeap) Len() int            { return len(h) }
+func (h sampleHeap) Less(i, j int) bool  { return h[i].rank > h[j].rank }
+func (h sampleHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *sampleHeap) Push(x interface{}) { *h = append(*h, x.(sampledColumn)) }
+func (h *sampleHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
 }
 
 // executeShiftShard executes a shift() call for a local shard.
@@ -1783,7 +2950,14 @@ func (e *executor) executeShiftShard(ctx context.Context, index string, c *pql.C
 		return nil, err
 	}
 
-	return row.Shift(n)
+	row, err = row.Shift(n)
+	if err != nil {
+		return nil, err
+	}
+	if err := queryMemoryFromContext(ctx).alloc(row); err != nil {
+		return nil, err
+	}
+	return row, nil
 }
 
 // executeCount executes a count() call.
@@ -1796,14 +2970,15 @@ func (e *executor) executeCount(ctx context.Context, index string, c *pql.Call,
 	} else if len(c.Children) > 1 {
 		return 0, errors.New("Count() only accepts a single bitmap input")
 	}
+	limit, hasLimit, err := c.UintArg("limit")
+	if err != nil {
+		return 0, errors.Wrap(err, "Count() limit")
+	}
 
-	// Execute calls in bulk on each remote node and merge.
+	// Execute calls in bulk on each remote node and merge. Only counts
+	// are returned by each shard.
 	mapFn := func(shard uint64) (interface{}, error) {
-		row, err := e.executeBitmapCallShard(ctx, index, c.Children[0], shard)
-		if err != nil {
-			return 0, err
-		}
-		return row.Count(), nil
+		return e.executeCountShard(ctx, index, c.Children[0], shard)
 	}
 
 	// Merge returned results at coordinating node.
@@ -1812,15 +2987,84 @@ func (e *executor) executeCount(ctx context.Context, index string, c *pql.Call,
 		return other + v.(uint64)
 	}
 
-	result, err := e.mapReduce(ctx, index, shards, c, opt, mapFn, reduceFn)
-	if err != nil {
-		return 0, err
+	if !hasLimit {
+		result, err := e.mapReduce(ctx, index, shards, c, opt, mapFn, reduceFn)
+		if err != nil {
+			return 0, err
+		}
+		n, _ := result.(uint64)
+		return n, nil
 	}
-	n, _ := result.(uint64)
 
+	// With a limit, only as many shards are counted as it takes to reach it,
+	// in batches which double in size.
+	var n uint64
+	for batch := 1; len(shards) > 0 && n < limit; batch *= 2 {
+		if batch > len(shards) {
+			batch = len(shards)
+		}
+		result, err := e.mapReduce(ctx, index, shards[:batch], c, opt, mapFn, reduceFn)
+		if err != nil {
+			return 0, err
+		}
+		other, _ := result.(uint64)
+		n += other
+		shards = shards[batch:]
+	}
+	if n > limit {
+		n = limit
+	}
 	return n, nil
 }
 
+// executeCountShard returns the number of columns in a bitmap call for a
+// single shard. Binary set operations are counted from the popcounts of
+// their operands' intersecting containers, without building the result.
+func (e *executor) executeCountShard(ctx context.Context, index string, c *pql.Call, shard uint64) (uint64, error) {
+	switch c.Name {
+	case "Intersect", "Union", "Difference", "Xor":
+		if len(c.Children) != 2 {
+			break
+		}
+		children := c.Children
+		if c.Name == "Intersect" {
+			children = e.sortCallsByEstimate(index, children, shard, false)
+		}
+		a, err := e.executeBitmapCallShard(ctx, index, children[0], shard)
+		if err != nil {
+			return 0, err
+		}
+
+		// Nothing intersects with, or remains of, an empty first operand, so
+		// the second isn't evaluated.
+		if !a.Any() && (c.Name == "Intersect" || c.Name == "Difference") {
+			return 0, nil
+		}
+		b, err := e.executeBitmapCallShard(ctx, index, children[1], shard)
+		if err != nil {
+			return 0, err
+		}
+
+		n := a.intersectionCount(b)
+		switch c.Name {
+		case "Intersect":
+			return n, nil
+		case "Union":
+			return a.Count() + b.Count() - n, nil
+		case "Difference":
+			return a.Count() - n, nil
+		default: // Xor
+			return a.Count() + b.Count() - 2*n, nil
+		}
+	}
+
+	row, err := e.executeBitmapCallShard(ctx, index, c, shard)
+	if err != nil {
+		return 0, err
+	}
+	return row.Count(), nil
+}
+
 // executeClearBit executes a Clear() call.
 func (e *executor) executeClearBit(ctx context.Context, index string, c *pql.Call, opt *execOptions) (bool, error) {
 	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.executeClearBit")
@@ -1866,9 +3110,16 @@ func (e *executor) executeClearBitField(ctx context.Context, index string, c *pq
 
 	shard := colID / ShardWidth
 	ret := false
-	for _, node := range e.Cluster.shardNodes(index, shard) {
+	nodes, missed := e.Cluster.writeNodes(index, shard)
+	if !opt.Remote {
+		if err := e.Cluster.addHint(missed, queryHint(index, shard, c)); err != nil {
+			return false, err
+		}
+	}
+	for _, node := range nodes {
 		// Update locally if host matches.
 		if node.ID == e.Node.ID {
+			e.Holder.shardLoads.countWrite(index, shard)
 			val, err := f.ClearBit(rowID, colID)
 			if err != nil {
 				return false, err
@@ -1946,11 +3197,9 @@ func (e *executor) executeClearRowShard(ctx context.Context, index string, c *pq
 	}
 
 	// Read fields using labels.
-	rowID, ok, err := c.UintArg(fieldName)
+	start, end, err := clearRowRange(c, fieldName)
 	if err != nil {
-		return false, fmt.Errorf("reading ClearRow() row: %v", err)
-	} else if !ok {
-		return false, fmt.Errorf("ClearRow() row argument '%v' required", rowLabel)
+		return false, err
 	}
 
 	field := e.Holder.Field(index, fieldName)
@@ -1958,21 +3207,71 @@ func (e *executor) executeClearRowShard(ctx context.Context, index string, c *pq
 		return false, newNotFoundError(ErrFieldNotFound, fieldName)
 	}
 
-	// Remove the row from all views.
-	changed := false
+	// Remove the rows from all views. The inverse view's rows are columns,
+	// so it is rebuilt instead.
+	cleared := make(map[uint64]struct{})
 	for _, view := range field.views() {
+		if view.name == viewInverse {
+			continue
+		}
 		fragment := e.Holder.fragment(index, fieldName, view.name, shard)
 		if fragment == nil {
 			continue
 		}
-		cleared, err := fragment.clearRow(rowID)
+		if start == end {
+			changed, err := fragment.clearRow(start)
+			if err != nil {
+				return false, errors.Wrapf(err, "clearing row %d on view %s shard %d", start, view.name, shard)
+			} else if changed {
+				cleared[start] = struct{}{}
+			}
+			continue
+		}
+		rowIDs, err := fragment.clearRows(start, end)
 		if err != nil {
-			return false, errors.Wrapf(err, "clearing row %d on view %s shard %d", rowID, view.name, shard)
+			return false, errors.Wrapf(err, "clearing rows %d-%d on view %s shard %d", start, end, view.name, shard)
+		}
+		for _, rowID := range rowIDs {
+			cleared[rowID] = struct{}{}
 		}
-		changed = changed || cleared
 	}
 
-	return changed, nil
+	if len(cleared) > 0 {
+		if err := field.rebuildInverse(shard); err != nil {
+			return false, errors.Wrapf(err, "rebuilding inverse view shard %d", shard)
+		}
+	}
+
+	return len(cleared) > 0, nil
+}
+
+// clearRowRange returns the first and last row cleared by a ClearRow() call,
+// which takes either a single row or an inclusive range of rows, as in
+// ClearRow(f >< [10, 20]).
+func clearRowRange(c *pql.Call, fieldName string) (start, end uint64, err error) {
+	cond, ok := c.Args[fieldName].(*pql.Condition)
+	if !ok {
+		rowID, ok, err := c.UintArg(fieldName)
+		if err != nil {
+			return 0, 0, fmt.Errorf("reading ClearRow() row: %v", err)
+		} else if !ok {
+			return 0, 0, fmt.Errorf("ClearRow() row argument '%v' required", rowLabel)
+		}
+		return rowID, rowID, nil
+	}
+
+	if cond.Op != pql.BETWEEN {
+		return 0, 0, fmt.Errorf("ClearRow() row range must use the %s operator", pql.BETWEEN)
+	}
+	predicates, err := cond.IntSliceValue()
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "reading ClearRow() row range")
+	} else if len(predicates) != 2 {
+		return 0, 0, errors.New("ClearRow() row range requires two rows")
+	} else if predicates[0] < 0 || predicates[1] < predicates[0] {
+		return 0, 0, fmt.Errorf("ClearRow() row range is invalid: [%d, %d]", predicates[0], predicates[1])
+	}
+	return uint64(predicates[0]), uint64(predicates[1]), nil
 }
 
 // executeSetRow executes a Store() call.
@@ -2065,6 +3364,12 @@ func (e *executor) executeSetRowShard(ctx context.Context, index string, c *pql.
 	}
 	changed = changed || set
 
+	if set {
+		if err := field.rebuildInverse(shard); err != nil {
+			return false, errors.Wrapf(err, "rebuilding inverse view shard %d", shard)
+		}
+	}
+
 	return changed, nil
 }
 
@@ -2128,7 +3433,7 @@ func (e *executor) executeSet(ctx context.Context, index string, c *pql.Call, op
 	var timestamp *time.Time
 	sTimestamp, ok := c.Args["_timestamp"].(string)
 	if ok {
-		t, err := time.Parse(TimeFormat, sTimestamp)
+		t, err := parseTime(sTimestamp, e.indexLocation(index))
 		if err != nil {
 			return false, fmt.Errorf("invalid date: %s", sTimestamp)
 		}
@@ -2138,6 +3443,87 @@ func (e *executor) executeSet(ctx context.Context, index string, c *pql.Call, op
 	return e.executeSetBitField(ctx, index, c, f, colID, rowID, timestamp, opt)
 }
 
+// executeBulkWrites executes a query of Set() and Clear() calls. Each call is
+// applied to this node, if it holds the column's shard, and the calls for
+// each other node are sent to it together in one request, rather than in a
+// request per call. A call's result is true if any node changed a bit.
+func (e *executor) executeBulkWrites(ctx context.Context, index string, calls []*pql.Call, opt *execOptions) ([]interface{}, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.executeBulkWrites")
+	defer span.Finish()
+
+	// Apply the calls locally as a node they were forwarded to would.
+	localOpt := *opt
+	localOpt.Remote = true
+
+	results := make([]interface{}, len(calls))
+	forward := make(map[*Node][]int)
+	for i, c := range calls {
+		if i%10 == 0 {
+			if err := validateQueryContext(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		colID, ok, err := c.UintArg("_" + columnLabel)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s() column: %v", c.Name, err)
+		} else if !ok {
+			return nil, fmt.Errorf("%s() column argument '%v' required", c.Name, columnLabel)
+		}
+		nodes, missed := e.Cluster.writeNodes(index, colID/ShardWidth)
+		if err := e.Cluster.addHint(missed, queryHint(index, colID/ShardWidth, c)); err != nil {
+			return nil, err
+		}
+		for _, node := range nodes {
+			if node.ID != e.Node.ID {
+				forward[node] = append(forward[node], i)
+			}
+		}
+
+		if results[i], err = e.executeCall(ctx, index, c, nil, &localOpt); err != nil {
+			return nil, err
+		}
+	}
+
+	// Execute on remote nodes in parallel.
+	type nodeResponse struct {
+		calls   []int
+		results []interface{}
+		err     error
+	}
+	resp := make(chan nodeResponse, len(forward))
+	for node, idxs := range forward {
+		go func(node *Node, idxs []int) {
+			q := &pql.Query{Calls: make([]*pql.Call, len(idxs))}
+			for j, i := range idxs {
+				q.Calls[j] = calls[i]
+			}
+			results, err := e.remoteExec(ctx, node, index, q, nil)
+			resp <- nodeResponse{calls: idxs, results: results, err: err}
+		}(node, idxs)
+	}
+
+	var firstErr error
+	for range forward {
+		r := <-resp
+		if r.err != nil {
+			if firstErr == nil {
+				firstErr = r.err
+			}
+			continue
+		}
+		for j, i := range r.calls {
+			if changed, _ := r.results[j].(bool); changed {
+				results[i] = true
+			}
+		}
+	}
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return results, nil
+}
+
 // executeSetBitField executes a Set() call for a specific field.
 func (e *executor) executeSetBitField(ctx context.Context, index string, c *pql.Call, f *Field, colID, rowID uint64, timestamp *time.Time, opt *execOptions) (bool, error) {
 	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.executeSetBitField")
@@ -2146,9 +3532,16 @@ func (e *executor) executeSetBitField(ctx context.Context, index string, c *pql.
 	shard := colID / ShardWidth
 	ret := false
 
-	for _, node := range e.Cluster.shardNodes(index, shard) {
+	nodes, missed := e.Cluster.writeNodes(index, shard)
+	if !opt.Remote {
+		if err := e.Cluster.addHint(missed, queryHint(index, shard, c)); err != nil {
+			return false, err
+		}
+	}
+	for _, node := range nodes {
 		// Update locally if host matches.
 		if node.ID == e.Node.ID {
+			e.Holder.shardLoads.countWrite(index, shard)
 			val, err := f.SetBit(rowID, colID, timestamp)
 			if err != nil {
 				return false, err
@@ -2181,9 +3574,16 @@ func (e *executor) executeSetValueField(ctx context.Context, index string, c *pq
 	shard := colID / ShardWidth
 	ret := false
 
-	for _, node := range e.Cluster.shardNodes(index, shard) {
+	nodes, missed := e.Cluster.writeNodes(index, shard)
+	if !opt.Remote {
+		if err := e.Cluster.addHint(missed, queryHint(index, shard, c)); err != nil {
+			return false, err
+		}
+	}
+	for _, node := range nodes {
 		// Update locally if host matches.
 		if node.ID == e.Node.ID {
+			e.Holder.shardLoads.countWrite(index, shard)
 			val, err := f.SetValue(colID, value)
 			if err != nil {
 				return false, err
@@ -2236,11 +3636,16 @@ func (e *executor) executeSetRowAttrs(ctx context.Context, index string, c *pql.
 	attrs := pql.CopyArgs(c.Args)
 	delete(attrs, "_field")
 	delete(attrs, "_"+rowLabel)
+	stored, err := withAttrExpiry(attrs, e.indexLocation(index))
+	if err != nil {
+		return err
+	}
 
 	// Set attributes.
-	if err := field.RowAttrStore().SetAttrs(rowID, attrs); err != nil {
+	if err := field.RowAttrStore().SetAttrs(rowID, stored); err != nil {
 		return err
 	}
+	e.Holder.changes.append(ChangeEvent{Type: ChangeTypeRowAttrs, Index: index, Field: fieldName, RowID: rowID, Attrs: attrs})
 	field.Stats.Count("SetRowAttrs", 1, 1.0)
 
 	// Do not forward call if this is already being forwarded.
@@ -2304,6 +3709,10 @@ func (e *executor) executeBulkSetRowAttrs(ctx context.Context, index string, cal
 		attrs := pql.CopyArgs(c.Args)
 		delete(attrs, "_field")
 		delete(attrs, "_"+rowLabel)
+		attrs, err = withAttrExpiry(attrs, e.indexLocation(index))
+		if err != nil {
+			return nil, err
+		}
 
 		// Create field group, if not exists.
 		fieldMap := m[field]
@@ -2384,11 +3793,16 @@ func (e *executor) executeSetColumnAttrs(ctx context.Context, index string, c *p
 	attrs := pql.CopyArgs(c.Args)
 	delete(attrs, "_"+columnLabel)
 	delete(attrs, "field")
+	stored, err := withAttrExpiry(attrs, idx.Location())
+	if err != nil {
+		return err
+	}
 
 	// Set attributes.
-	if err := idx.ColumnAttrStore().SetAttrs(col, attrs); err != nil {
+	if err := idx.ColumnAttrStore().SetAttrs(col, stored); err != nil {
 		return err
 	}
+	e.Holder.changes.append(ChangeEvent{Type: ChangeTypeColumnAttrs, Index: index, ColumnID: col, Attrs: attrs})
 	idx.Stats.Count("SetProfileAttrs", 1, 1.0)
 	// Do not forward call if this is already being forwarded.
 	if opt.Remote {
@@ -2421,34 +3835,62 @@ func (e *executor) remoteExec(ctx context.Context, node *Node, index string, q *
 	defer span.Finish()
 
 	// Encode request object.
+	profile := queryProfileFromContext(ctx)
 	pbreq := &QueryRequest{
-		Query:  q.String(),
-		Shards: shards,
-		Remote: true,
+		Query:    q.String(),
+		Shards:   shards,
+		Remote:   true,
+		Priority: queryPriorityFromContext(ctx).String(),
+		Profile:  profile != nil,
 	}
 
-	pb, err := e.client.QueryNode(ctx, &node.URI, index, pbreq)
+	start := time.Now()
+	pb, err := e.client.QueryNode(ctx, node.clusterURI(), index, pbreq)
 	if err != nil {
 		return nil, err
 	}
+	profile.remoteExecuted(node.ID, time.Since(start), pb.Profile)
 
 	return pb.Results, pb.Err
 }
 
 // shardsByNode returns a mapping of nodes to shards.
 // Returns errShardUnavailable if a shard cannot be allocated to a node.
-func (e *executor) shardsByNode(nodes []*Node, index string, shards []uint64) (map[*Node][]uint64, error) {
+// Replicas on nodes which the failure detector considers down are only used
+// if there is no other replica, so that queries don't wait for them to time
+// out. Nor is the local replica of a shard which has quarantined fragments of
+// the fields the call reads, unless it is the only one.
+func (e *executor) shardsByNode(nodes []*Node, index string, shards []uint64, c *pql.Call, affinity queryAffinity) (map[*Node][]uint64, error) {
 	m := make(map[*Node][]uint64)
+	fields := callFields(c)
 
 loop:
 	for _, shard := range shards {
-		for _, node := range e.Cluster.ShardNodes(index, shard) {
-			if Nodes(nodes).Contains(node) {
-				m[node] = append(m[node], shard)
-				continue loop
+		var down, local *Node
+		for _, node := range affinity.order(e.Cluster.readNodes(index, shard), e.Node.ID) {
+			if !Nodes(nodes).Contains(node) {
+				continue
+			} else if node.ID == e.Node.ID && e.Holder.quarantine.has(index, shard, fields) {
+				// The local copy of the shard is incomplete until its
+				// quarantined fragments are repaired.
+				local = node
+				continue
+			} else if e.Cluster.failures.state(node.ID) == nodeStateDown {
+				if down == nil {
+					down = node
+				}
+				continue
 			}
+			m[node] = append(m[node], shard)
+			continue loop
+		}
+		if down == nil {
+			down = local
 		}
-		return nil, errShardUnavailable
+		if down == nil {
+			return nil, errShardUnavailable
+		}
+		m[down] = append(m[down], shard)
 	}
 	return m, nil
 }
@@ -2457,6 +3899,10 @@ loop:
 //
 // If a mapping of shards to a node fails then the shards are resplit across
 // secondary nodes and retried. This continues to occur until all nodes are exhausted.
+//
+// Each node's result is reduced as soon as it arrives, rather than once all
+// have. If maxRemoteFanOut is set, at most that many requests to other nodes
+// are outstanding at once, which bounds the results held at the coordinator.
 func (e *executor) mapReduce(ctx context.Context, index string, shards []uint64, c *pql.Call, opt *execOptions, mapFn mapFunc, reduceFn reduceFunc) (interface{}, error) {
 	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.mapReduce")
 	defer span.Finish()
@@ -2478,8 +3924,14 @@ func (e *executor) mapReduce(ctx context.Context, index string, shards []uint64,
 		nodes = []*Node{e.Cluster.nodeByID(e.Node.ID)}
 	}
 
+	// Limit the requests to other nodes in flight at once.
+	var remoteSem chan struct{}
+	if e.maxRemoteFanOut > 0 {
+		remoteSem = make(chan struct{}, e.maxRemoteFanOut)
+	}
+
 	// Start mapping across all primary owners.
-	if err := e.mapper(ctx, ch, nodes, index, shards, c, opt, mapFn, reduceFn); err != nil {
+	if err := e.mapper(ctx, ch, remoteSem, nodes, index, shards, c, opt, mapFn, reduceFn); err != nil {
 		return nil, errors.Wrap(err, "starting mapper")
 	}
 
@@ -2494,12 +3946,14 @@ func (e *executor) mapReduce(ctx context.Context, index string, shards []uint64,
 			// On error retry against remaining nodes. If an error returns then
 			// the context will cancel and cause all open goroutines to return.
 
-			if resp.err != nil {
+			if re, ok := resp.err.(reduceError); ok {
+				return nil, re.err
+			} else if resp.err != nil {
 				// Filter out unavailable nodes.
 				nodes = Nodes(nodes).Filter(resp.node)
 
 				// Begin mapper against secondary nodes.
-				if err := e.mapper(ctx, ch, nodes, index, resp.shards, c, opt, mapFn, reduceFn); errors.Cause(err) == errShardUnavailable {
+				if err := e.mapper(ctx, ch, remoteSem, nodes, index, resp.shards, c, opt, mapFn, reduceFn); errors.Cause(err) == errShardUnavailable {
 					return nil, resp.err
 				} else if err != nil {
 					return nil, errors.Wrap(err, "calling mapper")
@@ -2509,6 +3963,9 @@ func (e *executor) mapReduce(ctx context.Context, index string, shards []uint64,
 
 			// Reduce value.
 			result = reduceFn(result, resp.result)
+			if re, ok := result.(reduceError); ok {
+				return nil, re.err
+			}
 
 			// If all shards have been processed then return.
 			shardN += len(resp.shards)
@@ -2519,12 +3976,12 @@ func (e *executor) mapReduce(ctx context.Context, index string, shards []uint64,
 	}
 }
 
-func (e *executor) mapper(ctx context.Context, ch chan mapResponse, nodes []*Node, index string, shards []uint64, c *pql.Call, opt *execOptions, mapFn mapFunc, reduceFn reduceFunc) error {
+func (e *executor) mapper(ctx context.Context, ch chan mapResponse, remoteSem chan struct{}, nodes []*Node, index string, shards []uint64, c *pql.Call, opt *execOptions, mapFn mapFunc, reduceFn reduceFunc) error {
 	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.mapper")
 	defer span.Finish()
 
 	// Group shards together by nodes.
-	m, err := e.shardsByNode(nodes, index, shards)
+	m, err := e.shardsByNode(nodes, index, shards, c, opt.Affinity)
 	if err != nil {
 		return errors.Wrap(err, "shards by node")
 	}
@@ -2536,9 +3993,24 @@ func (e *executor) mapper(ctx context.Context, ch chan mapResponse, nodes []*Nod
 
 			// Send local shards to mapper, otherwise remote exec.
 			if n.ID == e.Node.ID {
-				resp.result, resp.err = e.mapperLocal(ctx, nodeShards, mapFn, reduceFn)
+				e.Holder.shardLoads.countReads(index, nodeShards)
+				if err := e.fetchCrossIndexRows(ctx, c, nodeShards, opt); err != nil {
+					resp.err = err
+				} else {
+					resp.result, resp.err = e.mapperLocal(ctx, nodeShards, mapFn, reduceFn)
+				}
 			} else if !opt.Remote {
+				if remoteSem != nil {
+					select {
+					case <-ctx.Done():
+						return
+					case remoteSem <- struct{}{}:
+					}
+				}
 				results, err := e.remoteExec(ctx, n, index, &pql.Query{Calls: []*pql.Call{c}}, nodeShards)
+				if remoteSem != nil {
+					<-remoteSem
+				}
 				if len(results) > 0 {
 					resp.result = results[0]
 				}
@@ -2563,9 +4035,36 @@ type job struct {
 	resultChan chan mapResponse
 }
 
-func worker(work chan job) {
-	for j := range work {
+// worker runs jobs until both work channels are closed. Jobs on work are
+// always taken ahead of jobs on batchWork.
+func worker(work, batchWork chan job) {
+	for work != nil || batchWork != nil {
+		var j job
+		var ok bool
+		select {
+		case j, ok = <-work:
+			if !ok {
+				work = nil
+				continue
+			}
+		default:
+			select {
+			case j, ok = <-work:
+				if !ok {
+					work = nil
+					continue
+				}
+			case j, ok = <-batchWork:
+				if !ok {
+					batchWork = nil
+					continue
+				}
+			}
+		}
+
+		start := time.Now()
 		result, err := j.mapFn(j.shard)
+		queryProfileFromContext(j.ctx).shardExecuted(j.shard, time.Since(start))
 
 		select {
 		case <-j.ctx.Done():
@@ -2575,25 +4074,55 @@ func worker(work chan job) {
 }
 
 // mapperLocal performs map & reduce entirely on the local node.
+//
+// Shards are submitted to the shared worker pool. If maxQueryFanOut is set,
+// at most that many shards are outstanding at once so that a query over a
+// large number of shards cannot monopolize the pool and starve other queries.
+// Shards of batch priority queries are only run when no interactive work is
+// queued.
 func (e *executor) mapperLocal(ctx context.Context, shards []uint64, mapFn mapFunc, reduceFn reduceFunc) (interface{}, error) {
 	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.mapperLocal")
 	defer span.Finish()
 
-	ch := make(chan mapResponse, len(shards))
+	fanOut := len(shards)
+	if e.maxQueryFanOut > 0 && e.maxQueryFanOut < fanOut {
+		fanOut = e.maxQueryFanOut
+	}
+
+	ch := make(chan mapResponse, fanOut)
 
-	for _, shard := range shards {
-		e.work <- job{
-			shard:      shard,
+	work := e.work
+	if queryPriorityFromContext(ctx) == priorityBatch {
+		work = e.batchWork
+	}
+
+	// submit queues the next shard, returning false if ctx is done first.
+	var next int
+	submit := func() bool {
+		select {
+		case <-ctx.Done():
+			return false
+		case work <- job{
+			shard:      shards[next],
 			mapFn:      mapFn,
 			ctx:        ctx,
 			resultChan: ch,
+		}:
+			next++
+			return true
 		}
 	}
 
-	// Reduce results
+	for next < fanOut {
+		if !submit() {
+			return nil, ctx.Err()
+		}
+	}
+
+	// Reduce results, submitting another shard as each one completes.
 	var maxShard int
 	var result interface{}
-	for {
+	for maxShard < len(shards) {
 		select {
 		case <-ctx.Done():
 			return nil, ctx.Err()
@@ -2602,14 +4131,17 @@ func (e *executor) mapperLocal(ctx context.Context, shards []uint64, mapFn mapFu
 				return nil, resp.err
 			}
 			result = reduceFn(result, resp.result)
+			if re, ok := result.(reduceError); ok {
+				return nil, re
+			}
 			maxShard++
 		}
 
-		// Exit once all shards are processed.
-		if maxShard == len(shards) {
-			return result, nil
+		if next < len(shards) && !submit() {
+			return nil, ctx.Err()
 		}
 	}
+	return result, nil
 }
 
 func (e *executor) translateCalls(ctx context.Context, index string, idx *Index, calls []*pql.Call) error {
@@ -2642,6 +4174,13 @@ func (e *executor) translateCall(index string, idx *Index, c *pql.Call) error {
 		colKey = "column"
 	case "GroupBy":
 		return errors.Wrap(e.translateGroupByCall(index, idx, c), "translating GroupBy")
+	case "Index":
+		// The child is translated using the other index's keys.
+		other, err := e.crossIndex(idx, c)
+		if err != nil {
+			return err
+		}
+		return e.translateCall(other.Name(), other, c.Children[0])
 	default:
 		colKey = "col"
 		fieldName = callArgString(c, "field")
@@ -2924,6 +4463,39 @@ func (e *executor) detectRangeCall(c *pql.Call) bool {
 	return false
 }
 
+// queryMemory tracks the number of bytes allocated for intermediate rows by
+// a query, which may be executing on many shards concurrently.
+type queryMemory struct {
+	limit int64
+	used  int64 // accessed atomically
+}
+
+type queryMemoryKey struct{}
+
+// withQueryMemory returns a context which tracks the memory allocated by a
+// query, up to limit bytes.
+func withQueryMemory(ctx context.Context, limit int64) context.Context {
+	return context.WithValue(ctx, queryMemoryKey{}, &queryMemory{limit: limit})
+}
+
+// queryMemoryFromContext returns the query's memory tracker, if any.
+func queryMemoryFromContext(ctx context.Context) *queryMemory {
+	m, _ := ctx.Value(queryMemoryKey{}).(*queryMemory)
+	return m
+}
+
+// alloc records the allocation of row and returns ErrQueryMemoryExceeded
+// if the query has exceeded its limit. A nil queryMemory tracks nothing.
+func (m *queryMemory) alloc(row *Row) error {
+	if m == nil || row == nil {
+		return nil
+	}
+	if used := atomic.AddInt64(&m.used, int64(row.size())); used > m.limit {
+		return errors.Wrapf(ErrQueryMemoryExceeded, "allocated %d bytes, limit is %d bytes", used, m.limit)
+	}
+	return nil
+}
+
 // validateQueryContext returns a query-appropriate error if the context is done.
 func validateQueryContext(ctx context.Context) error {
 	select {
@@ -2948,6 +4520,14 @@ type mapFunc func(shard uint64) (interface{}, error)
 
 type reduceFunc func(prev, v interface{}) interface{}
 
+// reduceError is returned by a reduceFunc which fails to merge a result. It
+// fails the map/reduce with its error, which is not retried on other nodes.
+type reduceError struct {
+	err error
+}
+
+func (e reduceError) Error() string { return e.err.Error() }
+
 type mapResponse struct {
 	node   *Node
 	shards []uint64
@@ -2962,6 +4542,10 @@ type execOptions struct {
 	ExcludeRowAttrs bool
 	ExcludeColumns  bool
 	ColumnAttrs     bool
+	ContinueOnError bool
+
+	// Replicas to read shards from in preference to their primary owners.
+	Affinity queryAffinity
 }
 
 // hasOnlySetRowAttrs returns true if calls only contains SetRowAttrs() calls.
@@ -2978,6 +4562,21 @@ func hasOnlySetRowAttrs(calls []*pql.Call) bool {
 	return true
 }
 
+// hasOnlySetClear returns true if calls only contains Set() and Clear()
+// calls.
+func hasOnlySetClear(calls []*pql.Call) bool {
+	if len(calls) == 0 {
+		return false
+	}
+
+	for _, call := range calls {
+		if call.Name != "Set" && call.Name != "Clear" {
+			return false
+		}
+	}
+	return true
+}
+
 func needsShards(calls []*pql.Call) bool {
 	if len(calls) == 0 {
 		return false
@@ -3043,6 +4642,60 @@ func callArgBool(call *pql.Call, key string) (bool, error) {
 	return b, nil
 }
 
+// callTimeRange parses the optional "from" and "to" time arguments of a call,
+// which are in loc unless they give an offset. Unset arguments are returned
+// as zero times.
+func callTimeRange(c *pql.Call, loc *time.Location) (from, to time.Time, err error) {
+	if v, ok := c.Args["from"]; ok {
+		if from, err = parseTime(v, loc); err != nil {
+			return from, to, errors.Wrap(err, "parsing from time")
+		}
+	}
+	if v, ok := c.Args["to"]; ok {
+		if to, err = parseTime(v, loc); err != nil {
+			return from, to, errors.Wrap(err, "parsing to time")
+		}
+	}
+	return from, to, nil
+}
+
+// resolveRelativeTimes replaces the times relative to now of the "from",
+// "to" and "_expires" arguments of calls, such as "now-7d", with the times
+// they refer to.
+func resolveRelativeTimes(calls []*pql.Call, now time.Time, loc *time.Location) error {
+	for _, c := range calls {
+		for _, key := range []string{"from", "to", attrExpiresArg} {
+			if s, ok := c.Args[key].(string); ok && strings.HasPrefix(s, "now") {
+				t, err := parseRelativeTime(s, now, loc)
+				if err != nil {
+					return errors.Wrapf(err, "parsing %s time", key)
+				}
+				c.Args[key] = t.Format(time.RFC3339Nano)
+			}
+		}
+		for _, arg := range c.Args {
+			if child, ok := arg.(*pql.Call); ok {
+				if err := resolveRelativeTimes([]*pql.Call{child}, now, loc); err != nil {
+					return err
+				}
+			}
+		}
+		if err := resolveRelativeTimes(c.Children, now, loc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// indexLocation returns the time zone of timestamps without an offset in
+// calls on an index.
+func (e *executor) indexLocation(index string) *time.Location {
+	if idx := e.Holder.Index(index); idx != nil {
+		return idx.Location()
+	}
+	return time.UTC
+}
+
 func callArgString(call *pql.Call, key string) string {
 	value, ok := call.Args[key]
 	if !ok {
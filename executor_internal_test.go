@@ -16,10 +16,13 @@ package pilosa
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/pilosa/pilosa/v2/pql"
 )
@@ -222,3 +225,147 @@ func TestFieldRowMarshalJSON(t *testing.T) {
 		t.Fatalf("unexpected json: %s", b)
 	}
 }
+
+// Ensure operands are ordered by the estimated size of their results.
+func TestExecutor_SortCallsByEstimate(t *testing.T) {
+	e := &executor{
+		Holder: NewHolder(),
+	}
+	e.Holder.Path, _ = ioutil.TempDir(*TempDir, "")
+	if err := e.Holder.Open(); err != nil {
+		t.Fatalf("opening holder: %v", err)
+	}
+	defer e.Holder.Close()
+
+	idx, err := e.Holder.CreateIndex("i", IndexOptions{})
+	if err != nil {
+		t.Fatalf("creating index: %v", err)
+	}
+	f, err := idx.CreateField("f")
+	if err != nil {
+		t.Fatalf("creating field: %v", err)
+	}
+	for _, bit := range [][2]uint64{{1, 1}, {1, 2}, {1, 3}, {2, 1}, {3, ShardWidth + 1}} {
+		if _, err := f.SetBit(bit[0], bit[1], nil); err != nil {
+			t.Fatalf("setting bit: %v", err)
+		}
+	}
+
+	query, err := pql.ParseString(`Not(Row(f=1)) Row(f=1) Union(Row(f=1), Row(f=2)) Row(f=3) Row(f=2)`)
+	if err != nil {
+		t.Fatalf("parsing query: %v", err)
+	}
+	for _, test := range []struct {
+		descending bool
+		exp        string
+	}{
+		{false, "[Row(f=3) Row(f=2) Row(f=1) Union(Row(f=1), Row(f=2)) Not(Row(f=1))]"},
+		{true, "[Union(Row(f=1), Row(f=2)) Row(f=1) Row(f=2) Row(f=3) Not(Row(f=1))]"},
+	} {
+		if got := fmt.Sprint(e.sortCallsByEstimate("i", query.Calls, 0, test.descending)); got != test.exp {
+			t.Errorf("descending=%v: got %s, expected %s", test.descending, got, test.exp)
+		}
+	}
+}
+
+// Ensure a TopN() result isn't cached if the cache was invalidated while it
+// was being computed, since it may not include the write.
+func TestExecutor_CacheTopN_Invalidated(t *testing.T) {
+	e := &executor{topNCacheTTL: time.Minute}
+	query, err := pql.ParseString(`TopN(f, n=2)`)
+	if err != nil {
+		t.Fatalf("parsing query: %v", err)
+	}
+	c := query.Calls[0]
+	key := newTopNCacheKey("i", c, []uint64{0}, 2)
+	pairs := []Pair{{ID: 1, Count: 2}}
+
+	_, gen, ok := e.cachedTopN(key)
+	if ok {
+		t.Fatal("expected no cached result")
+	}
+	e.invalidateTopN("i", "f")
+	e.cacheTopN(key, c, pairs, gen)
+	if _, gen, ok = e.cachedTopN(key); ok {
+		t.Fatal("expected result computed across an invalidation not to be cached")
+	}
+
+	e.cacheTopN(key, c, pairs, gen)
+	if got, _, ok := e.cachedTopN(key); !ok || len(got) != 1 {
+		t.Fatalf("expected cached result, got %v", got)
+	}
+	e.invalidateTopN("i", "g")
+	if _, _, ok := e.cachedTopN(key); !ok {
+		t.Fatal("expected result to be kept after a write to another field")
+	}
+	e.invalidateTopN("i", "f")
+	if _, _, ok := e.cachedTopN(key); ok {
+		t.Fatal("expected result to be discarded")
+	}
+}
+
+// Ensure the invalidations of several writes are sent together, and that
+// invalidating every field of an index covers invalidating some.
+func TestTopNInvalidations(t *testing.T) {
+	inv := newTopNInvalidations()
+	inv.add("i", []string{"f"})
+	inv.add("i", []string{"g", "f"})
+	inv.add("j", []string{"f"})
+	inv.add("j", nil)
+	inv.add("j", []string{"g"})
+	select {
+	case <-inv.notify:
+	default:
+		t.Fatal("expected notification")
+	}
+
+	exp := []*InvalidateTopNMessage{{Index: "i", Fields: []string{"f", "g"}}, {Index: "j"}}
+	if msgs := inv.take(); !reflect.DeepEqual(msgs, exp) {
+		t.Fatalf("unexpected messages: %+v", msgs)
+	} else if msgs := inv.take(); len(msgs) != 0 {
+		t.Fatalf("unexpected messages: %+v", msgs)
+	}
+}
+
+// Ensure the local replica of a shard with quarantined fragments isn't read
+// from by calls which read them, even when preferred, unless the shard has no
+// other replica.
+func TestExecutor_ShardsByNode_Quarantined(t *testing.T) {
+	h := newHolder()
+	if err := h.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	c := NewTestCluster(2)
+	c.ReplicaN = 2
+	e := &executor{Holder: h.Holder, Cluster: c, Node: c.Node}
+	other := c.nodes[1]
+	local := queryAffinity{local: true}
+	rowF := &pql.Call{Name: "Row", Args: map[string]interface{}{"f": int64(1)}}
+	rowG := &pql.Call{Name: "Row", Args: map[string]interface{}{"g": int64(1)}}
+
+	h.quarantine.add(quarantinedFragment{Index: "i", Field: "f", View: viewStandard, Shard: 0}, errors.New("marker"))
+	if m, err := e.shardsByNode(c.nodes, "i", []uint64{0}, rowF, local); err != nil {
+		t.Fatal(err)
+	} else if len(m) != 1 || len(m[other]) != 1 {
+		t.Fatalf("unexpected shards by node: %v", m)
+	}
+	if m, err := e.shardsByNode(c.nodes, "i", []uint64{0}, rowG, local); err != nil {
+		t.Fatal(err)
+	} else if len(m) != 1 || len(m[c.Node]) != 1 {
+		t.Fatalf("unexpected shards by node for other field: %v", m)
+	}
+	if m, err := e.shardsByNode([]*Node{c.Node}, "i", []uint64{0}, rowF, local); err != nil {
+		t.Fatal(err)
+	} else if len(m[c.Node]) != 1 {
+		t.Fatalf("unexpected shards by node without other replica: %v", m)
+	}
+
+	h.quarantine.repaired(h.quarantine.take()[0])
+	if m, err := e.shardsByNode(c.nodes, "i", []uint64{0}, rowF, local); err != nil {
+		t.Fatal(err)
+	} else if len(m[c.Node]) != 1 {
+		t.Fatalf("unexpected shards by node after repair: %v", m)
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -34,6 +35,7 @@ import (
 	"github.com/pilosa/pilosa/v2"
 	"github.com/pilosa/pilosa/v2/boltdb"
 	"github.com/pilosa/pilosa/v2/http"
+	"github.com/pilosa/pilosa/v2/pql"
 	"github.com/pilosa/pilosa/v2/server"
 	"github.com/pilosa/pilosa/v2/test"
 	"github.com/pkg/errors"
@@ -466,6 +468,35 @@ func TestExecutor_Execute_Count(t *testing.T) {
 		}
 	})
 
+	t.Run("Limit", func(t *testing.T) {
+		c := test.MustRunCluster(t, 2)
+		defer c.Close()
+		c.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+
+		// Write through the cluster, so each shard is on the node owning it.
+		bits := [][2]uint64{{11, 1}}
+		for shard := uint64(0); shard < 4; shard++ {
+			bits = append(bits, [2]uint64{10, shard*ShardWidth + 1}, [2]uint64{10, shard*ShardWidth + 2})
+		}
+		c.ImportBits(t, "i", "f", bits)
+
+		for query, exp := range map[string]uint64{
+			`Count(Row(f=10), limit=3)`:                         3,
+			`Count(Row(f=10), limit=100)`:                       8,
+			`Count(Row(f=10), limit=0)`:                         0,
+			`Count(Intersect(Row(f=10), Row(f=12)))`:            0,
+			`Count(Intersect(Row(f=10), Row(f=11)))`:            1,
+			`Count(Intersect(Row(f=12), Row(f=10), Row(f=11)))`: 0,
+			`Count(Difference(Row(f=12), Row(f=10)))`:           0,
+		} {
+			if res, err := c[1].API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: query}); err != nil {
+				t.Fatal(err)
+			} else if res.Results[0] != exp {
+				t.Fatalf("%s: unexpected n: %d", query, res.Results[0])
+			}
+		}
+	})
+
 	t.Run("RowIDColumnKey", func(t *testing.T) {
 		writeQuery := `
 			Set("three", f=10)
@@ -506,6 +537,36 @@ func TestExecutor_Execute_Count(t *testing.T) {
 		}
 	})
 
+	// Counts of set operations which are computed without building the
+	// result must match the size of the result.
+	t.Run("SetOperations", func(t *testing.T) {
+		c := test.MustRunCluster(t, 1)
+		defer c.Close()
+		c.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+
+		// Row 1 is sparse, row 2 is dense, and row 3 is a run.
+		var bits [][2]uint64
+		for col := uint64(0); col < 2*ShardWidth; col += 7 {
+			bits = append(bits, [2]uint64{1, col})
+		}
+		for col := uint64(0); col < 70000; col += 2 {
+			bits = append(bits, [2]uint64{2, col})
+		}
+		for col := uint64(60000); col < ShardWidth+100; col++ {
+			bits = append(bits, [2]uint64{3, col})
+		}
+		c.ImportBits(t, "i", "f", bits)
+
+		for _, op := range []string{"Intersect", "Union", "Difference", "Xor"} {
+			for _, args := range []string{"Row(f=1), Row(f=2)", "Row(f=2), Row(f=3)", "Row(f=3), Row(f=1)"} {
+				q := fmt.Sprintf("%s(%s)", op, args)
+				exp := uint64(len(c.Query(t, "i", q).Results[0].(*pilosa.Row).Columns()))
+				if n := c.Query(t, "i", "Count("+q+")").Results[0]; n != exp {
+					t.Fatalf("Count(%s): expected %d, got %v", q, exp, n)
+				}
+			}
+		}
+	})
 }
 
 // Ensure a set query can be executed.
@@ -795,6 +856,36 @@ func TestExecutor_Execute_SetBool(t *testing.T) {
 	})
 }
 
+// Ensure calls which fail don't fail the other calls of a query executed
+// with ContinueOnError.
+func TestExecutor_Execute_ContinueOnError(t *testing.T) {
+	c := test.MustRunCluster(t, 1)
+	defer c.Close()
+	hldr := test.Holder{Holder: c[0].Server.Holder()}
+	hldr.SetBit("i", "f", 10, 3)
+
+	query := `Count(Row(f=10)) Row(nosuchfield=1) Set(5, f=10) Count(Row(f=10))`
+	if _, err := c[0].API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: query}); errors.Cause(err) != pilosa.ErrFieldNotFound {
+		t.Fatalf("expected field not found, got %v", err)
+	}
+
+	res, err := c[0].API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: query, ContinueOnError: true})
+	if err != nil {
+		t.Fatal(err)
+	} else if len(res.Results) != 4 {
+		t.Fatalf("unexpected results: %#v", res.Results)
+	} else if res.Results[0] != uint64(1) || res.Results[2] != true || res.Results[3] != uint64(2) {
+		t.Fatalf("unexpected results: %#v", res.Results)
+	}
+	if e, ok := res.Results[1].(pilosa.CallError); !ok || errors.Cause(e.Err) != pilosa.ErrFieldNotFound {
+		t.Fatalf("expected call error, got %#v", res.Results[1])
+	} else if buf, err := json.Marshal(e); err != nil {
+		t.Fatal(err)
+	} else if string(buf) != `{"error":{"code":"FieldNotFound","message":"map reduce: nosuchfield: field not found","field":"nosuchfield"}}` {
+		t.Fatalf("unexpected JSON: %s", buf)
+	}
+}
+
 // Ensure old PQL syntax doesn't break anything too badly.
 func TestExecutor_Execute_OldPQL(t *testing.T) {
 	c := test.MustRunCluster(t, 1)
@@ -1167,6 +1258,161 @@ func TestExecutor_Execute_TopN(t *testing.T) {
 	})
 }
 
+// Ensure a TopN() query can page through its ranking with an offset.
+func TestExecutor_Execute_TopN_Offset(t *testing.T) {
+	c := test.MustRunCluster(t, 1)
+	defer c.Close()
+	hldr := test.Holder{Holder: c[0].Server.Holder()}
+
+	// Row i has 10-i columns, split across two shards.
+	for row := uint64(0); row < 10; row++ {
+		for col := uint64(0); col < 10-row; col++ {
+			hldr.SetBit("i", "f", row, col*(ShardWidth/4))
+		}
+	}
+	c[0].RecalculateCaches()
+
+	topN := func(q string) []pilosa.Pair {
+		t.Helper()
+		result, err := c[0].API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: q})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result.Results[0].([]pilosa.Pair)
+	}
+
+	for _, tt := range []struct {
+		q   string
+		exp []pilosa.Pair
+	}{
+		{q: `TopN(f, n=3, offset=0)`, exp: []pilosa.Pair{{ID: 0, Count: 10}, {ID: 1, Count: 9}, {ID: 2, Count: 8}}},
+		{q: `TopN(f, n=3, offset=3)`, exp: []pilosa.Pair{{ID: 3, Count: 7}, {ID: 4, Count: 6}, {ID: 5, Count: 5}}},
+		{q: `TopN(f, n=3, offset=9)`, exp: []pilosa.Pair{{ID: 9, Count: 1}}},
+		{q: `TopN(f, n=3, offset=10)`, exp: []pilosa.Pair{}},
+		{q: `TopN(f, offset=8)`, exp: []pilosa.Pair{{ID: 8, Count: 2}, {ID: 9, Count: 1}}},
+	} {
+		if pairs := topN(tt.q); !reflect.DeepEqual(pairs, tt.exp) {
+			t.Fatalf("%s: unexpected result: %s", tt.q, spew.Sdump(pairs))
+		}
+	}
+
+	// Promote row 9 to the top; later pages still come from the first ranking.
+	for col := uint64(0); col < 20; col++ {
+		hldr.SetBit("i", "f", 9, col+1)
+	}
+	c[0].RecalculateCaches()
+	if pairs := topN(`TopN(f, n=3, offset=3)`); !reflect.DeepEqual(pairs, []pilosa.Pair{{ID: 3, Count: 7}, {ID: 4, Count: 6}, {ID: 5, Count: 5}}) {
+		t.Fatalf("unexpected result: %s", spew.Sdump(pairs))
+	}
+	if pairs := topN(`TopN(f, n=1)`); !reflect.DeepEqual(pairs, []pilosa.Pair{{ID: 9, Count: 21}}) {
+		t.Fatalf("unexpected result: %s", spew.Sdump(pairs))
+	}
+}
+
+func TestExecutor_Execute_TopN_Cache(t *testing.T) {
+	c := test.MustRunCluster(t, 1, []server.CommandOption{
+		server.OptCommandServerOptions(pilosa.OptServerTopNCacheTTL(time.Minute)),
+	})
+	defer c.Close()
+	hldr := test.Holder{Holder: c[0].Server.Holder()}
+
+	hldr.SetBit("i", "f", 0, 1)
+	hldr.SetBit("i", "f", 0, 2)
+	hldr.SetBit("i", "f", 1, 1)
+	hldr.SetBit("i", "g", 0, 1)
+	hldr.SetBit("i", "g", 0, 2)
+	c[0].RecalculateCaches()
+
+	query := func(q string) interface{} {
+		t.Helper()
+		result, err := c[0].API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: q})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result.Results[0]
+	}
+	topN := func(q string, exp []pilosa.Pair) {
+		t.Helper()
+		if pairs := query(q); !reflect.DeepEqual(pairs, exp) {
+			t.Fatalf("%s: unexpected result: %s", q, spew.Sdump(pairs))
+		}
+	}
+
+	topN(`TopN(f, n=1)`, []pilosa.Pair{{ID: 0, Count: 2}})
+	topN(`TopN(f, Row(g=0), n=2)`, []pilosa.Pair{{ID: 0, Count: 2}, {ID: 1, Count: 1}})
+
+	// Bits set directly in the holder bypass invalidation, so the cached
+	// results are returned.
+	for col := uint64(3); col < 6; col++ {
+		hldr.SetBit("i", "f", 1, col)
+	}
+	hldr.SetBit("i", "g", 0, 3)
+	c[0].RecalculateCaches()
+	topN(`TopN(f, n=1)`, []pilosa.Pair{{ID: 0, Count: 2}})
+	topN(`TopN(f, Row(g=0), n=2)`, []pilosa.Pair{{ID: 0, Count: 2}, {ID: 1, Count: 1}})
+
+	// A different n is a different call.
+	topN(`TopN(f, n=2)`, []pilosa.Pair{{ID: 1, Count: 4}, {ID: 0, Count: 2}})
+
+	// A write to the filter's field only invalidates the filtered call.
+	query(`Set(4, g=0)`)
+	topN(`TopN(f, n=1)`, []pilosa.Pair{{ID: 0, Count: 2}})
+	topN(`TopN(f, Row(g=0), n=2)`, []pilosa.Pair{{ID: 1, Count: 3}, {ID: 0, Count: 2}})
+
+	// A write to the ranked field invalidates the unfiltered call.
+	query(`Set(6, f=1)`)
+	c[0].RecalculateCaches()
+	topN(`TopN(f, n=1)`, []pilosa.Pair{{ID: 1, Count: 5}})
+}
+
+// Ensure a write coordinated by one node discards the TopN() results cached
+// by the others, even if it only writes to shards the first node owns.
+func TestExecutor_Execute_TopN_Cache_Cluster(t *testing.T) {
+	c := test.MustRunCluster(t, 2, []server.CommandOption{
+		server.OptCommandServerOptions(pilosa.OptServerTopNCacheTTL(time.Minute)),
+	})
+	defer c.Close()
+	ctx := context.Background()
+
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+	var shard uint64
+	for ; ; shard++ {
+		nodes, err := c[0].API.ShardNodes(ctx, "i", shard)
+		if err != nil {
+			t.Fatal(err)
+		} else if nodes[0].ID == c[0].API.Node().ID {
+			break
+		}
+	}
+	col := shard * pilosa.ShardWidth
+	c.Query(t, "i", fmt.Sprintf("Set(%d, f=0) Set(%d, f=0) Set(%d, f=1)", col, col+1, col))
+
+	// Other nodes are told of writes in the background, so wait for them.
+	topN := func(exp []pilosa.Pair) {
+		t.Helper()
+		var pairs interface{}
+		for i := 0; i < 50; i++ {
+			for _, m := range c {
+				if err := m.RecalculateCaches(); err != nil {
+					t.Fatal(err)
+				}
+			}
+			resp := c[1].MustQuery(t, &pilosa.QueryRequest{Index: "i", Query: "TopN(f, n=1)"})
+			if pairs = resp.Results[0]; reflect.DeepEqual(pairs, exp) {
+				return
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+		t.Fatalf("unexpected result: %s", spew.Sdump(pairs))
+	}
+	topN([]pilosa.Pair{{ID: 0, Count: 2}})
+
+	if _, err := c[0].API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: fmt.Sprintf("Set(%d, f=1) Set(%d, f=1)", col+1, col+2)}); err != nil {
+		t.Fatal(err)
+	}
+	topN([]pilosa.Pair{{ID: 1, Count: 3}})
+}
+
 func TestExecutor_Execute_TopN_fill(t *testing.T) {
 	c := test.MustRunCluster(t, 1)
 	defer c.Close()
@@ -1262,6 +1508,48 @@ func TestExecutor_Execute_TopN_Src(t *testing.T) {
 	}
 }
 
+// Ensure TopN can be restricted to the time views of a time field.
+func TestExecutor_Execute_TopN_Time(t *testing.T) {
+	writeQuery := fmt.Sprintf(`
+		Set(1, f=1, 2000-01-01T00:00)
+		Set(2, f=1, 2000-02-01T00:00)
+		Set(%d, f=1, 2000-03-01T00:00)
+		Set(1, f=2, 2000-01-01T00:00)
+		Set(2, f=2, 2000-01-02T00:00)
+		Set(3, f=2, 2000-01-03T00:00)
+		Set(4, f=2, 2001-01-01T00:00)
+		Set(5, f=2, 2000-01-01T00:00)`, ShardWidth+1)
+	readQueries := []string{
+		`TopN(f, to=2000-01-02T00:00)`,
+		`TopN(f, to=2000-02-02T00:00)`,
+		`TopN(f, from=2000-01-02T00:00, n=1)`,
+		`Count(Row(f=2, to=2000-02-01T00:00))`,
+	}
+	responses := runCallTest(t, writeQuery, readQueries,
+		nil, pilosa.OptFieldTypeTime(pilosa.TimeQuantum("YMD")))
+
+	t.Run("AsOf", func(t *testing.T) {
+		if pairs := responses[0].Results[0]; !reflect.DeepEqual(pairs, []pilosa.Pair{{ID: 2, Count: 2}, {ID: 1, Count: 1}}) {
+			t.Fatalf("unexpected pairs: %+v", pairs)
+		}
+		if pairs := responses[1].Results[0]; !reflect.DeepEqual(pairs, []pilosa.Pair{{ID: 2, Count: 4}, {ID: 1, Count: 2}}) {
+			t.Fatalf("unexpected pairs: %+v", pairs)
+		}
+	})
+
+	t.Run("From", func(t *testing.T) {
+		if pairs := responses[2].Results[0]; !reflect.DeepEqual(pairs, []pilosa.Pair{{ID: 2, Count: 3}}) {
+			t.Fatalf("unexpected pairs: %+v", pairs)
+		}
+	})
+
+	t.Run("Count", func(t *testing.T) {
+		if n := responses[3].Results[0]; n != uint64(4) {
+			t.Fatalf("unexpected count: %v", n)
+		}
+	})
+}
+
 //Ensure TopN handles Attribute filters
 func TestExecutor_Execute_TopN_Attr(t *testing.T) {
 	c := test.MustRunCluster(t, 1)
@@ -1766,6 +2054,53 @@ func TestExecutor_Execute_Row_Range(t *testing.T) {
 		})
 	})
 
+	// Timestamps without an offset are in the index's time zone, which is
+	// five hours behind UTC in January.
+	t.Run("TimeZone", func(t *testing.T) {
+		writeQuery := `
+		Set(1, f=1, 2000-01-01T20:00)
+		Set(2, f=1, 2000-01-01T20:00:00Z)
+		Set(3, f=1, 2000-01-02T05:00:00+05:00)`
+		readQueries := []string{
+			`Row(f=1, from=2000-01-02T00:00:00Z, to=2000-01-03T00:00:00Z)`,
+			`Row(f=1, from=2000-01-01T19:00)`,
+			`Row(f=1, to=2000-01-01T19:00)`,
+		}
+		responses := runCallTest(t, writeQuery, readQueries,
+			&pilosa.IndexOptions{TrackExistence: true, TimeZone: "America/New_York"},
+			pilosa.OptFieldTypeTime(pilosa.TimeQuantum("YMDH")))
+
+		for i, exp := range [][]uint64{{1, 3}, {1, 3}, {2}} {
+			if columns := responses[i].Results[0].(*pilosa.Row).Columns(); !reflect.DeepEqual(columns, exp) {
+				t.Fatalf("unexpected columns of %s: %+v", readQueries[i], columns)
+			}
+		}
+	})
+
+	// Open ranges only read the existing views of an hourly field, rather
+	// than every hour since year one.
+	t.Run("RelativeAndOpen", func(t *testing.T) {
+		now := time.Now().UTC()
+		writeQuery := fmt.Sprintf(`
+		Set(1, f=1, %s)
+		Set(2, f=1, %s)
+		Set(3, f=1, 2010-01-01T05:00)`, now.Add(-2*time.Hour).Format(pilosa.TimeFormat), now.AddDate(0, 0, -10).Format(pilosa.TimeFormat))
+		readQueries := []string{
+			`Row(f=1, from=now-1d)`,
+			`Row(f=1, from=now-12d, to="now-5d")`,
+			`Row(f=1, to=2010-01-02T00:00)`,
+			`Row(f=1, from=2010-01-01T05:59)`,
+		}
+		responses := runCallTest(t, writeQuery, readQueries,
+			nil, pilosa.OptFieldTypeTime(pilosa.TimeQuantum("H")))
+
+		for i, exp := range [][]uint64{{1}, {2}, {3}, {1, 2, 3}} {
+			if columns := responses[i].Results[0].(*pilosa.Row).Columns(); !reflect.DeepEqual(columns, exp) {
+				t.Fatalf("unexpected columns of %s: %+v", readQueries[i], columns)
+			}
+		}
+	})
+
 	t.Run("RowKeyColumnID", func(t *testing.T) {
 		writeQuery := `
 		Set(2, f="foo", 1999-12-31T00:00)
@@ -2576,6 +2911,56 @@ func TestExecutor_SetColumnAttrs_ExcludeField(t *testing.T) {
 
 }
 
+// Ensure attributes set with _expires are removed once they expire.
+func TestExecutor_SetAttrs_Expires(t *testing.T) {
+	c := test.MustRunCluster(t, 1, []server.CommandOption{
+		server.OptCommandServerOptions(pilosa.OptServerAttrExpiryInterval(10 * time.Millisecond)),
+	})
+	defer c.Close()
+	hldr := test.Holder{Holder: c[0].Server.Holder()}
+
+	index := hldr.MustCreateIndexIfNotExists("i", pilosa.IndexOptions{})
+	f, err := index.CreateField("f", pilosa.OptFieldTypeDefault())
+	if err != nil {
+		t.Fatalf("creating field: %v", err)
+	}
+
+	future := time.Now().Add(time.Hour).Unix()
+	for _, q := range []string{
+		`SetColumnAttrs(10, foo="bar", _expires="now-1m")`,
+		`SetColumnAttrs(10, baz=123, _expires=` + strconv.FormatInt(future, 10) + `)`,
+		`SetColumnAttrs(10, bat=true)`,
+		`SetRowAttrs(f, 1, foo="bar", _expires="2000-01-01T00:00")`,
+	} {
+		if _, err := c[0].API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: q}); err != nil {
+			t.Fatalf("%s: %v", q, err)
+		}
+	}
+
+	if _, err := c[0].API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: `SetColumnAttrs(10, foo="bar", _expires="tomorrow")`}); err == nil || !strings.Contains(err.Error(), "parsing _expires") {
+		t.Fatalf("expected parsing error, got %v", err)
+	}
+
+	// Wait for the expired attributes to be removed.
+	var colAttrs, rowAttrs map[string]interface{}
+	for i := 0; i < 200; i++ {
+		if colAttrs, err = index.ColumnAttrStore().Attrs(10); err != nil {
+			t.Fatal(err)
+		} else if rowAttrs, err = f.RowAttrStore().Attrs(1); err != nil {
+			t.Fatal(err)
+		}
+		if colAttrs["foo"] == nil && len(rowAttrs) == 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if exp := map[string]interface{}{"baz": int64(123), "_expires.baz": future, "bat": true}; !reflect.DeepEqual(colAttrs, exp) {
+		t.Fatalf("unexpected column attrs: %#v", colAttrs)
+	} else if len(rowAttrs) != 0 {
+		t.Fatalf("unexpected row attrs: %#v", rowAttrs)
+	}
+}
+
 func TestExecutor_Time_Clear_Quantums(t *testing.T) {
 	c := test.MustRunCluster(t, 1)
 	defer c.Close()
@@ -3007,6 +3392,49 @@ func TestExecutor_Execute_ClearRow(t *testing.T) {
 
 	})
 
+	t.Run("Range", func(t *testing.T) {
+		writeQuery := `
+			Set(1, f=1, 2000-01-01T00:00)
+			Set(2, f=2, 2000-01-01T00:00)
+			Set(3, f=3, 2001-01-01T00:00)
+			Set(` + strconv.Itoa(ShardWidth+3) + `, f=3, 2001-01-01T00:00)
+			Set(4, f=4, 2002-01-01T00:00)
+			Set(5, f=5, 2002-01-01T00:00)`
+		readQueries := []string{
+			`ClearRow(f >< [2, 4])`,
+			`ClearRow(2 <= f < 5)`,
+			`Rows(f)`,
+			`Row(f=3, from=1999-01-01T00:00, to=2003-01-01T00:00)`,
+		}
+		responses := runCallTest(t, writeQuery, readQueries,
+			&pilosa.IndexOptions{TrackExistence: true},
+			pilosa.OptFieldTypeTime(pilosa.TimeQuantum("YMD")))
+
+		// Clear the rows and ensure we get a `true` response, then `false`
+		// once they are empty.
+		if res := responses[0].Results[0].(bool); !res {
+			t.Fatalf("unexpected clear row result: %+v", res)
+		} else if res := responses[1].Results[0].(bool); res {
+			t.Fatalf("unexpected clear row result: %+v", res)
+		}
+
+		// Ensure only rows outside the range remain, in every view.
+		if rows := responses[2].Results[0].(pilosa.RowIdentifiers).Rows; !reflect.DeepEqual(rows, []uint64{1, 5}) {
+			t.Fatalf("unexpected rows: %+v", rows)
+		} else if columns := responses[3].Results[0].(*pilosa.Row).Columns(); !reflect.DeepEqual(columns, []uint64{}) {
+			t.Fatalf("unexpected columns: %+v", columns)
+		}
+
+		c := test.MustRunCluster(t, 1)
+		defer c.Close()
+		c.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+		for _, q := range []string{`ClearRow(f >< [4, 2])`, `ClearRow(f > 2)`} {
+			if _, err := c[0].API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: q}); err == nil {
+				t.Fatalf("%s: expected error", q)
+			}
+		}
+	})
+
 	t.Run("Int", func(t *testing.T) {
 		c := test.MustRunCluster(t, 1)
 		defer c.Close()
@@ -3294,6 +3722,265 @@ func benchmarkExistence(nn bool, b *testing.B) {
 func BenchmarkExecutor_Existence_True(b *testing.B)  { benchmarkExistence(true, b) }
 func BenchmarkExecutor_Existence_False(b *testing.B) { benchmarkExistence(false, b) }
 
+func TestExecutor_Execute_CustomCall(t *testing.T) {
+	// Evens() returns the even columns of its child row.
+	evens := pilosa.CallDefinition{
+		Map: func(ctx context.Context, shard *pilosa.CallShard, c *pql.Call) (interface{}, error) {
+			if len(c.Children) != 1 {
+				return nil, errors.New("Evens() requires a single child")
+			}
+			row, err := shard.Row(ctx, c.Children[0])
+			if err != nil {
+				return nil, err
+			}
+			other := pilosa.NewRow()
+			for _, col := range row.Columns() {
+				if col%2 == 0 {
+					other.SetBit(col)
+				}
+			}
+			return other, nil
+		},
+		Reduce: func(prev, v interface{}) (interface{}, error) {
+			other, _ := prev.(*pilosa.Row)
+			if other == nil {
+				other = pilosa.NewRow()
+			}
+			other.Merge(v.(*pilosa.Row))
+			return other, nil
+		},
+	}
+
+	// Shards() counts the shards on which it was evaluated.
+	shards := pilosa.CallDefinition{
+		Map: func(ctx context.Context, shard *pilosa.CallShard, c *pql.Call) (interface{}, error) {
+			return uint64(1), nil
+		},
+		Reduce: func(prev, v interface{}) (interface{}, error) {
+			n, _ := prev.(uint64)
+			return n + v.(uint64), nil
+		},
+	}
+
+	c := test.MustRunCluster(t, 3, []server.CommandOption{
+		server.OptCommandServerOptions(
+			pilosa.OptServerCall("Evens", evens),
+			pilosa.OptServerCall("Shards", shards),
+		)},
+	)
+	defer c.Close()
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+	c.ImportBits(t, "i", "f", [][2]uint64{
+		{10, 0},
+		{10, 3},
+		{10, ShardWidth + 2},
+		{10, 2*ShardWidth + 4},
+		{10, 2*ShardWidth + 5},
+		{11, 0},
+	})
+
+	t.Run("Row", func(t *testing.T) {
+		if columns := c.Query(t, "i", `Evens(Row(f=10))`).Results[0].(*pilosa.Row).Columns(); !reflect.DeepEqual(columns, []uint64{0, ShardWidth + 2, 2*ShardWidth + 4}) {
+			t.Fatalf("unexpected columns: %+v", columns)
+		}
+	})
+
+	t.Run("Child", func(t *testing.T) {
+		if n := c.Query(t, "i", `Count(Evens(Row(f=10)))`).Results[0]; n != uint64(3) {
+			t.Fatalf("unexpected count: %v", n)
+		}
+		if columns := c.Query(t, "i", `Intersect(Evens(Row(f=10)), Row(f=11))`).Results[0].(*pilosa.Row).Columns(); !reflect.DeepEqual(columns, []uint64{0}) {
+			t.Fatalf("unexpected columns: %+v", columns)
+		}
+	})
+
+	t.Run("Count", func(t *testing.T) {
+		if n := c.Query(t, "i", `Shards()`).Results[0]; n != uint64(3) {
+			t.Fatalf("unexpected count: %v", n)
+		}
+	})
+
+	t.Run("NotRow", func(t *testing.T) {
+		if _, err := c[0].API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: `Count(Shards())`}); err == nil || !strings.Contains(err.Error(), "does not return a row") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Builtin", func(t *testing.T) {
+		if err := c[0].Server.RegisterCall("Count", shards); err == nil {
+			t.Fatal("expected error registering built-in call")
+		} else if err := c[0].Server.RegisterCall("Shards", shards); err == nil {
+			t.Fatal("expected error registering duplicate call")
+		}
+	})
+}
+
+func TestExecutor_Execute_MaxQueryFanOut(t *testing.T) {
+	// Concurrency() records the maximum number of shards evaluated at once.
+	var mu sync.Mutex
+	var running, max int
+	concurrency := pilosa.CallDefinition{
+		Map: func(ctx context.Context, shard *pilosa.CallShard, c *pql.Call) (interface{}, error) {
+			mu.Lock()
+			running++
+			if running > max {
+				max = running
+			}
+			mu.Unlock()
+
+			time.Sleep(5 * time.Millisecond)
+
+			mu.Lock()
+			running--
+			mu.Unlock()
+			return uint64(1), nil
+		},
+		Reduce: func(prev, v interface{}) (interface{}, error) {
+			n, _ := prev.(uint64)
+			return n + v.(uint64), nil
+		},
+	}
+
+	c := test.MustRunCluster(t, 1, []server.CommandOption{
+		server.OptCommandServerOptions(
+			pilosa.OptServerExecutorPoolSize(8),
+			pilosa.OptServerMaxQueryFanOut(2),
+			pilosa.OptServerCall("Concurrency", concurrency),
+		)},
+	)
+	defer c.Close()
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+
+	bits := make([][2]uint64, 20)
+	for i := range bits {
+		bits[i] = [2]uint64{1, uint64(i) * ShardWidth}
+	}
+	c.ImportBits(t, "i", "f", bits)
+
+	if n := c.Query(t, "i", `Concurrency()`).Results[0]; n != uint64(len(bits)) {
+		t.Fatalf("unexpected shard count: %v", n)
+	} else if max > 2 {
+		t.Fatalf("expected at most 2 concurrent shards, got %d", max)
+	}
+	if n := c.Query(t, "i", `Count(Row(f=1))`).Results[0]; n != uint64(len(bits)) {
+		t.Fatalf("unexpected count: %v", n)
+	}
+}
+
+func TestExecutor_Execute_MaxRemoteFanOut(t *testing.T) {
+	c := test.MustRunCluster(t, 3, []server.CommandOption{
+		server.OptCommandServerOptions(pilosa.OptServerMaxRemoteFanOut(1)),
+	})
+	defer c.Close()
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+
+	var bits [][2]uint64
+	for shard := uint64(0); shard < 12; shard++ {
+		bits = append(bits, [2]uint64{1, shard * ShardWidth}, [2]uint64{2, shard*ShardWidth + 1})
+	}
+	bits = append(bits, [2]uint64{1, 1})
+	c.ImportBits(t, "i", "f", bits)
+
+	// Results from every node are merged, one remote node at a time.
+	for i := range c {
+		if n := c[i].MustQuery(t, &pilosa.QueryRequest{Index: "i", Query: `Count(Row(f=1))`}).Results[0]; n != uint64(13) {
+			t.Fatalf("node %d: unexpected count: %v", i, n)
+		}
+		pairs := c[i].MustQuery(t, &pilosa.QueryRequest{Index: "i", Query: `TopN(f, n=1)`}).Results[0].([]pilosa.Pair)
+		if exp := []pilosa.Pair{{ID: 1, Count: 13}}; !reflect.DeepEqual(pairs, exp) {
+			t.Fatalf("node %d: unexpected pairs: %+v", i, pairs)
+		}
+	}
+}
+
+// Ensure queries preferring replicas read every shard once.
+func TestExecutor_Execute_Affinity(t *testing.T) {
+	c := test.MustRunCluster(t, 3,
+		[]server.CommandOption{server.OptCommandServerOptions(pilosa.OptServerReplicaN(2), pilosa.OptServerZone("a"))},
+		[]server.CommandOption{server.OptCommandServerOptions(pilosa.OptServerReplicaN(2), pilosa.OptServerZone("b"))},
+		[]server.CommandOption{server.OptCommandServerOptions(pilosa.OptServerReplicaN(2), pilosa.OptServerZone("b"))},
+	)
+	defer c.Close()
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+
+	var bits [][2]uint64
+	for shard := uint64(0); shard < 12; shard++ {
+		bits = append(bits, [2]uint64{1, shard * ShardWidth})
+	}
+	c.ImportBits(t, "i", "f", bits)
+
+	for i := range c {
+		for _, affinity := range []string{"", "local", "zone:a", "zone:b", "zone:c"} {
+			req := &pilosa.QueryRequest{Index: "i", Query: `Count(Row(f=1))`, Affinity: affinity}
+			if n := c[i].MustQuery(t, req).Results[0]; n != uint64(12) {
+				t.Fatalf("node %d, affinity %q: unexpected count: %v", i, affinity, n)
+			}
+		}
+	}
+
+	if _, err := c[0].API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: `Count(Row(f=1))`, Affinity: "nearby"}); err == nil {
+		t.Fatal("expected invalid affinity error")
+	}
+}
+
+// Ensure the writes of a query are forwarded to each replica, and their
+// results merged.
+func TestExecutor_Execute_BulkWrites(t *testing.T) {
+	c := test.MustRunCluster(t, 3, []server.CommandOption{
+		server.OptCommandServerOptions(pilosa.OptServerReplicaN(2)),
+	})
+	defer c.Close()
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+
+	var sets, clears string
+	for shard := uint64(0); shard < 6; shard++ {
+		sets += fmt.Sprintf("Set(%d, f=1) Set(%d, f=2) ", shard*ShardWidth, shard*ShardWidth+1)
+		clears += fmt.Sprintf("Clear(%d, f=1) ", shard*ShardWidth)
+	}
+
+	for _, r := range c[0].MustQuery(t, &pilosa.QueryRequest{Index: "i", Query: sets}).Results {
+		if r != true {
+			t.Fatalf("unexpected set results: %v", r)
+		}
+	}
+	for _, r := range c[1].MustQuery(t, &pilosa.QueryRequest{Index: "i", Query: sets}).Results {
+		if r != false {
+			t.Fatalf("unexpected repeated set results: %v", r)
+		}
+	}
+	for i := range c {
+		if n := c[i].MustQuery(t, &pilosa.QueryRequest{Index: "i", Query: `Count(Union(Row(f=1), Row(f=2)))`}).Results[0]; n != uint64(12) {
+			t.Fatalf("node %d: unexpected count: %v", i, n)
+		}
+	}
+
+	// Each shard's bits are held by both of its replicas.
+	for shard := uint64(0); shard < 6; shard++ {
+		nodes, err := c[0].API.ShardNodes(context.Background(), "i", shard)
+		if err != nil {
+			t.Fatal(err)
+		} else if len(nodes) != 2 {
+			t.Fatalf("shard %d: unexpected nodes: %v", shard, nodes)
+		}
+		for i := range c {
+			if !pilosa.Nodes(nodes).ContainsID(c[i].API.Node().ID) {
+				continue
+			}
+			req := &pilosa.QueryRequest{Index: "i", Query: `Count(Row(f=1))`, Shards: []uint64{shard}, Remote: true}
+			if n := c[i].MustQuery(t, req).Results[0]; n != uint64(1) {
+				t.Fatalf("node %d, shard %d: unexpected count: %v", i, shard, n)
+			}
+		}
+	}
+
+	c[2].MustQuery(t, &pilosa.QueryRequest{Index: "i", Query: clears})
+	for i := range c {
+		if n := c[i].MustQuery(t, &pilosa.QueryRequest{Index: "i", Query: `Count(Row(f=1))`}).Results[0]; n != uint64(0) {
+			t.Fatalf("node %d: unexpected count after clear: %v", i, n)
+		}
+	}
+}
+
 func TestExecutor_Execute_Rows(t *testing.T) {
 	c := test.MustRunCluster(t, 3)
 	defer c.Close()
@@ -3336,6 +4023,54 @@ func TestExecutor_Execute_Rows(t *testing.T) {
 	}
 }
 
+func TestExecutor_Execute_RowsInverse(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "general", pilosa.OptFieldTypeDefault(), pilosa.OptFieldInverse())
+	c.ImportBits(t, "i", "general", [][2]uint64{
+		{10, 0},
+		{10, ShardWidth + 1},
+		{11, 2},
+		{11, ShardWidth + 2},
+		{12, 2},
+		{ShardWidth + 12, 2},
+		{13, 3},
+	})
+	c.Query(t, "i", `Set(2, general=14) Clear(2, general=12)`)
+
+	for _, tt := range []struct {
+		q   string
+		exp []uint64
+	}{
+		{`Rows(general, column=2)`, []uint64{11, 14, ShardWidth + 12}},
+		{`Rows(general, column=2, limit=2)`, []uint64{11, 14}},
+		{`Rows(general, column=2, previous=11)`, []uint64{14, ShardWidth + 12}},
+		{fmt.Sprintf(`Rows(general, column=%d)`, ShardWidth+2), []uint64{11}},
+		{`Rows(general, column=4)`, []uint64{}},
+	} {
+		rows := c.Query(t, "i", tt.q).Results[0].(pilosa.RowIdentifiers)
+		if !reflect.DeepEqual(rows, pilosa.RowIdentifiers{Rows: tt.exp}) {
+			t.Fatalf("%s: unexpected rows: %+v", tt.q, rows)
+		}
+	}
+
+	// Whole-row writes keep the inverse view up to date.
+	c.Query(t, "i", `ClearRow(general=11) Store(Row(general=10), general=15)`)
+	for _, tt := range []struct {
+		q   string
+		exp []uint64
+	}{
+		{`Rows(general, column=2)`, []uint64{14, ShardWidth + 12}},
+		{`Rows(general, column=0)`, []uint64{10, 15}},
+		{fmt.Sprintf(`Rows(general, column=%d)`, ShardWidth+1), []uint64{10, 15}},
+	} {
+		rows := c.Query(t, "i", tt.q).Results[0].(pilosa.RowIdentifiers)
+		if !reflect.DeepEqual(rows, pilosa.RowIdentifiers{Rows: tt.exp}) {
+			t.Fatalf("%s: unexpected rows: %+v", tt.q, rows)
+		}
+	}
+}
+
 func TestExecutor_Execute_RowsTime(t *testing.T) {
 	writeQuery := fmt.Sprintf(`
 		Set(9, f=1, 2001-01-01T00:00)
@@ -4136,3 +4871,194 @@ func TestExecutor_Execute_Shift(t *testing.T) {
 		}
 	})
 }
+
+func TestExecutor_Execute_MaxQueryMemory(t *testing.T) {
+	c := test.MustRunCluster(t, 1, []server.CommandOption{
+		server.OptCommandServerOptions(pilosa.OptServerMaxQueryMemory(64 << 10))},
+	)
+	defer c.Close()
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+
+	// Rows are interleaved so that their union is dense, and sparse
+	// enough that snapshots cannot shrink them into run containers.
+	var bits [][2]uint64
+	for row := uint64(0); row < 20; row++ {
+		for col := uint64(0); col < 5000; col++ {
+			bits = append(bits, [2]uint64{row, col*20 + row})
+		}
+	}
+	c.ImportBits(t, "i", "f", bits)
+
+	// Small intermediate results are within the limit.
+	if n := c.Query(t, "i", `Count(Union(Row(f=0), Row(f=1)))`).Results[0]; n != uint64(10000) {
+		t.Fatalf("unexpected count: %v", n)
+	}
+
+	// Each step of a large union is counted.
+	var rows []string
+	for row := 0; row < 20; row++ {
+		rows = append(rows, fmt.Sprintf("Row(f=%d)", row))
+	}
+	_, err := c[0].API.Query(context.Background(), &pilosa.QueryRequest{
+		Index: "i",
+		Query: "Count(Union(" + strings.Join(rows, ", ") + "))",
+	})
+	if errors.Cause(err) != pilosa.ErrQueryMemoryExceeded {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestExecutor_Execute_Index(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+	c.CreateField(t, "u", pilosa.IndexOptions{}, "f")
+	c.CreateField(t, "e", pilosa.IndexOptions{}, "g")
+	c.CreateField(t, "k", pilosa.IndexOptions{Keys: true}, "h")
+
+	const sw = pilosa.ShardWidth
+	c.ImportBits(t, "u", "f", [][2]uint64{{1, 1}, {1, sw + 1}, {1, 2*sw + 1}, {1, 3*sw + 1}, {1, 4*sw + 1}})
+	c.ImportBits(t, "e", "g", [][2]uint64{{2, 1}, {2, 2*sw + 1}, {2, 4*sw + 1}, {2, 5*sw + 1}})
+
+	// Shards of the other index may be owned by any node.
+	for _, m := range c {
+		resp := m.MustQuery(t, &pilosa.QueryRequest{Index: "u", Query: `Intersect(Row(f=1), Index(Row(g=2), name=e))`})
+		if columns := resp.Results[0].(*pilosa.Row).Columns(); !reflect.DeepEqual(columns, []uint64{1, 2*sw + 1, 4*sw + 1}) {
+			t.Fatalf("unexpected columns: %v", columns)
+		}
+	}
+
+	// Shards which only exist in the other index are included.
+	if n := c.Query(t, "u", `Count(Union(Row(f=1), Index(Row(g=2), name=e)))`).Results[0]; n != uint64(6) {
+		t.Fatalf("unexpected count: %v", n)
+	}
+
+	// Aliases of the other index and of its fields are resolved.
+	if _, err := c[0].API.UpdateAliases(context.Background(), pilosa.AliasesUpdate{
+		Indexes: map[string]string{"e_current": "e"},
+		Fields:  map[string]map[string]string{"e": {"g_current": "g"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	resp := c[1].MustQuery(t, &pilosa.QueryRequest{Index: "u", Query: `Intersect(Row(f=1), Index(Row(g_current=2), name=e_current))`})
+	if columns := resp.Results[0].(*pilosa.Row).Columns(); !reflect.DeepEqual(columns, []uint64{1, 2*sw + 1, 4*sw + 1}) {
+		t.Fatalf("unexpected columns: %v", columns)
+	}
+
+	for _, q := range []string{
+		`Index(Row(g=2))`,
+		`Index(Row(g=2), name=nope)`,
+		`Index(Row(h=1), name=k)`,
+	} {
+		if _, err := c[0].API.Query(context.Background(), &pilosa.QueryRequest{Index: "u", Query: q}); err == nil {
+			t.Fatalf("%s: expected error", q)
+		}
+	}
+}
+
+func TestExecutor_Execute_Sample(t *testing.T) {
+	c := test.MustRunCluster(t, 2)
+	defer c.Close()
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+
+	// Shard 0 has nine times as many columns as shard 1.
+	var bits [][2]uint64
+	for col := uint64(0); col < 9000; col++ {
+		bits = append(bits, [2]uint64{1, col * 3})
+	}
+	for col := uint64(0); col < 1000; col++ {
+		bits = append(bits, [2]uint64{1, ShardWidth + col*5})
+	}
+	c.ImportBits(t, "i", "f", bits)
+	input := make(map[uint64]struct{})
+	for _, bit := range bits {
+		input[bit[1]] = struct{}{}
+	}
+
+	sample := func(q string) []uint64 {
+		t.Helper()
+		return c.Query(t, "i", q).Results[0].(*pilosa.Row).Columns()
+	}
+
+	columns := sample(`Sample(Row(f=1), n=1000, seed=7)`)
+	if len(columns) != 1000 {
+		t.Fatalf("unexpected sample size: %d", len(columns))
+	}
+	var shard1 int
+	for _, col := range columns {
+		if _, ok := input[col]; !ok {
+			t.Fatalf("unexpected column: %d", col)
+		} else if col >= ShardWidth {
+			shard1++
+		}
+	}
+	if shard1 < 50 || shard1 > 150 {
+		t.Fatalf("expected about 100 columns from shard 1, got %d", shard1)
+	}
+
+	// Samples are repeatable from any node, and vary by seed.
+	resp := c[1].MustQuery(t, &pilosa.QueryRequest{Index: "i", Query: `Sample(Row(f=1), n=1000, seed=7)`})
+	if other := resp.Results[0].(*pilosa.Row).Columns(); !reflect.DeepEqual(other, columns) {
+		t.Fatal("expected the same sample for the same seed")
+	}
+	if other := sample(`Sample(Row(f=1), n=1000, seed=8)`); reflect.DeepEqual(other, columns) {
+		t.Fatal("expected a different sample for a different seed")
+	}
+
+	// Small inputs are returned whole.
+	if columns := sample(`Sample(Row(f=1), n=20000)`); len(columns) != len(bits) {
+		t.Fatalf("unexpected sample size: %d", len(columns))
+	}
+
+	for _, q := range []string{`Sample(Row(f=1))`, `Sample(Row(f=1), n=0)`, `Count(Sample(Row(f=1), n=1))`} {
+		if _, err := c[0].API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: q}); err == nil {
+			t.Fatalf("%s: expected error", q)
+		}
+	}
+}
+
+func TestExecutor_Execute_Limit(t *testing.T) {
+	c := test.MustRunCluster(t, 2)
+	defer c.Close()
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+
+	// Columns in shards 0, 1, and 3, with none in shard 2.
+	var bits [][2]uint64
+	var exp []uint64
+	for _, shard := range []uint64{0, 1, 3} {
+		for col := uint64(0); col < 5; col++ {
+			bits = append(bits, [2]uint64{1, shard*ShardWidth + col*7})
+			exp = append(exp, shard*ShardWidth+col*7)
+		}
+	}
+	c.ImportBits(t, "i", "f", bits)
+
+	// Page through the row four columns at a time, from either node.
+	var got []uint64
+	var after string
+	for i := 0; ; i++ {
+		resp := c[i%2].MustQuery(t, &pilosa.QueryRequest{Index: "i", Query: fmt.Sprintf(`Limit(Row(f=1), limit=4%s)`, after)})
+		page := resp.Results[0].(*pilosa.Row).Columns()
+		if len(page) > 4 {
+			t.Fatalf("unexpected page size: %d", len(page))
+		} else if len(page) == 0 {
+			break
+		}
+		got = append(got, page...)
+		after = fmt.Sprintf(", after=%d", page[len(page)-1])
+	}
+	if !reflect.DeepEqual(got, exp) {
+		t.Fatalf("unexpected columns: %v", got)
+	}
+
+	// A page may start within a shard.
+	resp := c.Query(t, "i", fmt.Sprintf(`Limit(Row(f=1), limit=3, after=%d)`, ShardWidth+8))
+	if got, exp := resp.Results[0].(*pilosa.Row).Columns(), []uint64{ShardWidth + 14, ShardWidth + 21, ShardWidth + 28}; !reflect.DeepEqual(got, exp) {
+		t.Fatalf("unexpected columns: %v", got)
+	}
+
+	for _, q := range []string{`Limit(Row(f=1))`, `Limit(Row(f=1), limit=0)`, `Count(Limit(Row(f=1), limit=1))`} {
+		if _, err := c[0].API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: q}); err == nil {
+			t.Fatalf("%s: expected error", q)
+		}
+	}
+}
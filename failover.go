@@ -0,0 +1,399 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/pilosa/pilosa/v2/pql"
+	"github.com/pkg/errors"
+)
+
+// hintsDir is the directory in the data directory which keeps the writes
+// missed by nodes which were DOWN, in a log for each node named by its ID.
+const hintsDir = ".hints"
+
+// Types of hint.
+const (
+	hintTypeQuery         = "query"
+	hintTypeImport        = "import"
+	hintTypeImportValue   = "importValue"
+	hintTypeImportRoaring = "importRoaring"
+)
+
+// hint is a write to a shard which a node missed while it was DOWN, kept to
+// be replayed to it once it returns. It is a write call, such as Set() or
+// Clear(), or an import of bits, values or roaring data.
+type hint struct {
+	Type  string `json:"type"`
+	Index string `json:"index"`
+	Field string `json:"field,omitempty"`
+	Shard uint64 `json:"shard"`
+
+	Query string `json:"query,omitempty"`
+
+	Bits             []Bit             `json:"bits,omitempty"`
+	Values           []FieldValue      `json:"values,omitempty"`
+	Views            map[string][]byte `json:"views,omitempty"`
+	Clear            bool              `json:"clear,omitempty"`
+	TimeQuantum      TimeQuantum       `json:"timeQuantum,omitempty"`
+	SkipStandardView bool              `json:"skipStandardView,omitempty"`
+}
+
+// queryHint returns the hint of a write call to a shard.
+func queryHint(index string, shard uint64, c *pql.Call) hint {
+	return hint{Type: hintTypeQuery, Index: index, Shard: shard, Query: c.String()}
+}
+
+// importOptions returns the options of an import hint. Keys are translated
+// before hints are recorded, so they aren't checked again.
+func (h hint) importOptions() []ImportOption {
+	return []ImportOption{
+		OptImportOptionsClear(h.Clear),
+		OptImportOptionsIgnoreKeyCheck(true),
+		OptImportOptionsTimeQuantum(h.TimeQuantum),
+		OptImportOptionsSkipStandardView(h.SkipStandardView),
+	}
+}
+
+// replayingExt is the extension of the file holding the hints of a node which
+// are being replayed. They are kept there until they have all been sent, so
+// that a crash during the replay doesn't lose them.
+const replayingExt = ".replaying"
+
+// hintLog keeps the hints of each node on disk until they are replayed.
+type hintLog struct {
+	mu   sync.Mutex
+	path string
+
+	// The nodes with hints yet to be replayed, and those whose hints are
+	// being replayed.
+	pending   map[string]bool
+	replaying map[string]bool
+}
+
+func newHintLog() *hintLog {
+	return &hintLog{
+		pending:   make(map[string]bool),
+		replaying: make(map[string]bool),
+	}
+}
+
+// open creates the log directory at path, and finds the nodes which have
+// hints left from before a restart.
+func (l *hintLog) open(path string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.path = path
+	if err := os.MkdirAll(path, 0750); err != nil {
+		return errors.Wrap(err, "creating directory")
+	}
+	fis, err := ioutil.ReadDir(path)
+	if err != nil {
+		return errors.Wrap(err, "reading directory")
+	}
+	for _, fi := range fis {
+		if fi.IsDir() || fi.Size() == 0 || filepath.Ext(fi.Name()) == tempExt {
+			continue
+		}
+		l.pending[strings.TrimSuffix(fi.Name(), replayingExt)] = true
+	}
+	return nil
+}
+
+func (l *hintLog) nodePath(nodeID string) string { return filepath.Join(l.path, nodeID) }
+
+func (l *hintLog) replayingPath(nodeID string) string { return l.nodePath(nodeID) + replayingExt }
+
+// add appends a hint to the log of a node.
+func (l *hintLog) add(nodeID string, h hint) error {
+	buf, err := json.Marshal(h)
+	if err != nil {
+		return errors.Wrap(err, "marshaling")
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	file, err := os.OpenFile(l.nodePath(nodeID), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0640)
+	if err != nil {
+		return errors.Wrap(err, "opening")
+	}
+	if _, err := file.Write(append(buf, '\n')); err != nil {
+		file.Close()
+		return errors.Wrap(err, "writing")
+	} else if err := file.Close(); err != nil {
+		return errors.Wrap(err, "closing")
+	}
+	l.pending[nodeID] = true
+	return nil
+}
+
+// has returns true if a node has hints yet to be replayed.
+func (l *hintLog) has(nodeID string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.pending[nodeID]
+}
+
+// take returns the hints of a node to replay, oldest first. They are moved
+// out of its log, which collects the hints added meanwhile, and kept until
+// replayed is called, so that they are taken again if the replay fails or
+// the node crashes. Once there are none, the node no longer has hints
+// pending.
+func (l *hintLog) take(nodeID string) ([]hint, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Hints left from a replay which didn't finish are replayed first.
+	if _, err := os.Stat(l.replayingPath(nodeID)); os.IsNotExist(err) {
+		if err := os.Rename(l.nodePath(nodeID), l.replayingPath(nodeID)); err != nil && !os.IsNotExist(err) {
+			return nil, errors.Wrap(err, "renaming")
+		}
+	} else if err != nil {
+		return nil, errors.Wrap(err, "checking replay")
+	}
+	hints, err := readHints(l.replayingPath(nodeID))
+	if err != nil {
+		return nil, err
+	}
+	if len(hints) == 0 {
+		if err := os.Remove(l.replayingPath(nodeID)); err != nil && !os.IsNotExist(err) {
+			return nil, errors.Wrap(err, "removing")
+		}
+		delete(l.pending, nodeID)
+	}
+	return hints, nil
+}
+
+// replayed discards the hints of a node taken by take, once they have all
+// been replayed.
+func (l *hintLog) replayed(nodeID string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := os.Remove(l.replayingPath(nodeID)); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "removing")
+	}
+	return nil
+}
+
+// restore keeps the hints taken from a node which couldn't be replayed, so
+// that they are taken again, ahead of those added since, and discards the
+// rest, which were replayed.
+func (l *hintLog) restore(nodeID string, hints []hint) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var buf []byte
+	for _, h := range hints {
+		b, err := json.Marshal(h)
+		if err != nil {
+			return errors.Wrap(err, "marshaling")
+		}
+		buf = append(append(buf, b...), '\n')
+	}
+	tempPath := l.replayingPath(nodeID) + tempExt
+	if err := ioutil.WriteFile(tempPath, buf, 0640); err != nil {
+		return errors.Wrap(err, "writing")
+	} else if err := os.Rename(tempPath, l.replayingPath(nodeID)); err != nil {
+		return errors.Wrap(err, "renaming")
+	}
+	l.pending[nodeID] = true
+	return nil
+}
+
+// readHints returns the hints in the log file at path. A hint which was only
+// partly written, because the node crashed, is dropped, since its write
+// failed.
+func readHints(path string) ([]hint, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "opening")
+	}
+	defer file.Close()
+
+	var hints []hint
+	dec := json.NewDecoder(file)
+	for {
+		var h hint
+		if err := dec.Decode(&h); err == io.EOF || err == io.ErrUnexpectedEOF {
+			return hints, nil
+		} else if err != nil {
+			return nil, errors.Wrap(err, "decoding")
+		}
+		hints = append(hints, h)
+	}
+}
+
+// beginReplay returns true if the hints of a node aren't already being
+// replayed, and marks them as being replayed until endReplay is called.
+func (l *hintLog) beginReplay(nodeID string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.replaying[nodeID] {
+		return false
+	}
+	l.replaying[nodeID] = true
+	return true
+}
+
+func (l *hintLog) endReplay(nodeID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.replaying, nodeID)
+}
+
+// writeNodes returns the nodes which writes to a shard should be sent to,
+// and the owners of the shard which miss them. Owners which the failure
+// detector considers DOWN, or which have yet to be replayed the writes they
+// missed, are skipped while another owner is not, so that writes continue on
+// the remaining replicas. The node which routes a write must record it for
+// the owners which miss it with addHint.
+func (c *cluster) writeNodes(index string, shard uint64) (nodes, missed []*Node) {
+	c.mu.RLock()
+	owners := c.shardNodes(index, shard)
+	c.mu.RUnlock()
+
+	for _, node := range owners {
+		if c.failures.state(node.ID) == nodeStateDown || c.hints.has(node.ID) {
+			missed = append(missed, node)
+		} else {
+			nodes = append(nodes, node)
+		}
+	}
+	if len(nodes) == 0 {
+		return owners, nil
+	}
+	return nodes, missed
+}
+
+// addHint records a write for the nodes which missed it.
+func (c *cluster) addHint(missed []*Node, h hint) error {
+	for _, node := range missed {
+		if err := c.hints.add(node.ID, h); err != nil {
+			return errors.Wrapf(err, "recording write missed by node %s", node.ID)
+		}
+	}
+	return nil
+}
+
+// replayHints sends the nodes which are up the writes they missed while they
+// were DOWN, starting a goroutine for each which isn't already being sent
+// them.
+func (s *Server) replayHints() {
+	for _, node := range s.cluster.Nodes() {
+		if node.ID == s.nodeID || !s.cluster.hints.has(node.ID) {
+			continue
+		} else if s.cluster.failures.state(node.ID) == nodeStateDown {
+			continue
+		} else if !s.cluster.hints.beginReplay(node.ID) {
+			continue
+		}
+		s.wg.Add(1)
+		go func(node *Node) {
+			defer s.wg.Done()
+			defer s.cluster.hints.endReplay(node.ID)
+			s.replayNodeHints(node)
+		}(node)
+	}
+}
+
+// replayNodeHints sends a node the writes it missed, in the order they were
+// made, until it has none left. Writes made while they are sent are recorded
+// for the node too, and sent after them. Hints which can't be sent are kept
+// until the next attempt, and those being sent are kept until they all have
+// been, so a crash resends them rather than losing them.
+func (s *Server) replayNodeHints(node *Node) {
+	for {
+		hints, err := s.cluster.hints.take(node.ID)
+		if err != nil {
+			s.logger.Printf("reading writes missed by node %s: %v", node.ID, err)
+			return
+		} else if len(hints) == 0 {
+			s.logger.Printf("node %s has caught up on the writes it missed", node.ID)
+			return
+		}
+
+		s.logger.Printf("sending node %s %d writes it missed", node.ID, len(hints))
+		for i, h := range hints {
+			select {
+			case <-s.closing:
+				err = errors.New("server closing")
+			default:
+				err = s.replayHint(node, h)
+			}
+			if err != nil {
+				s.logger.Printf("sending node %s a write it missed: %v", node.ID, err)
+				if err := s.cluster.hints.restore(node.ID, hints[i:]); err != nil {
+					s.logger.Printf("keeping writes missed by node %s: %v", node.ID, err)
+				}
+				return
+			}
+		}
+		if err := s.cluster.hints.replayed(node.ID); err != nil {
+			s.logger.Printf("discarding writes sent to node %s: %v", node.ID, err)
+			return
+		}
+	}
+}
+
+// replayHint sends a node a write it missed. Writes to indexes and fields
+// which have since been deleted are dropped.
+func (s *Server) replayHint(node *Node, h hint) error {
+	if s.holder.Index(h.Index) == nil {
+		return nil
+	} else if h.Field != "" && s.holder.Field(h.Index, h.Field) == nil {
+		return nil
+	}
+
+	ctx := WithInternalRequest(context.Background())
+	uri := node.clusterURI()
+	switch h.Type {
+	case hintTypeQuery:
+		resp, err := s.defaultClient.QueryNode(ctx, uri, h.Index, &QueryRequest{Query: h.Query, Shards: []uint64{h.Shard}, Remote: true})
+		if err != nil {
+			return err
+		}
+		return resp.Err
+	case hintTypeImport:
+		return s.defaultClient.ImportNode(ctx, uri, h.Index, h.Field, h.Shard, h.Bits, h.importOptions()...)
+	case hintTypeImportValue:
+		return s.defaultClient.ImportValueNode(ctx, uri, h.Index, h.Field, h.Shard, h.Values, h.importOptions()...)
+	case hintTypeImportRoaring:
+		return s.defaultClient.ImportRoaring(ctx, uri, h.Index, h.Field, h.Shard, true, &ImportRoaringRequest{Clear: h.Clear, Views: h.Views})
+	default:
+		s.logger.Printf("dropping write missed by node %s of unknown type %q", node.ID, h.Type)
+		return nil
+	}
+}
+
+// hintImport records an import to a shard for the owners which miss it. The
+// client sends the import to each of the nodes writes go to, so only the
+// first of them records it.
+func (api *API) hintImport(index string, shard uint64, h hint) error {
+	nodes, missed := api.cluster.writeNodes(index, shard)
+	if len(missed) == 0 || nodes[0].ID != api.server.nodeID {
+		return nil
+	}
+	return api.cluster.addHint(missed, h)
+}
@@ -0,0 +1,230 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pilosa/pilosa/v2/logger"
+	"github.com/pilosa/pilosa/v2/pql"
+)
+
+// Ensure writes skip replicas on nodes which are DOWN, or which have yet to
+// be sent the writes they missed.
+func TestCluster_WriteNodes(t *testing.T) {
+	c := NewTestCluster(3)
+	c.ReplicaN = 2
+	if err := c.hints.open(filepath.Join(c.Path, hintsDir)); err != nil {
+		t.Fatal(err)
+	}
+
+	owners := c.shardNodes("i", 5)
+	if nodes, missed := c.writeNodes("i", 5); !reflect.DeepEqual(nodes, owners) || len(missed) != 0 {
+		t.Fatalf("unexpected nodes: %v, missed: %v", nodes, missed)
+	}
+
+	c.failures.nodes[owners[0].ID] = &heartbeatHistory{state: nodeStateDown}
+	if nodes, missed := c.writeNodes("i", 5); len(nodes) != 1 || nodes[0] != owners[1] {
+		t.Fatalf("unexpected nodes: %v", nodes)
+	} else if len(missed) != 1 || missed[0] != owners[0] {
+		t.Fatalf("unexpected missed nodes: %v", missed)
+	} else if err := c.addHint(missed, hint{Type: hintTypeQuery, Index: "i", Shard: 5, Query: "Clear(5242880, f=1)"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The node is still skipped once it's up, until it has been sent the
+	// writes it missed.
+	c.failures.nodes[owners[0].ID].state = nodeStateReady
+	if nodes, _ := c.writeNodes("i", 5); len(nodes) != 1 {
+		t.Fatalf("unexpected nodes: %v", nodes)
+	} else if hints, err := c.hints.take(owners[0].ID); err != nil {
+		t.Fatal(err)
+	} else if len(hints) != 1 {
+		t.Fatalf("unexpected hints: %v", hints)
+	} else if err := c.hints.replayed(owners[0].ID); err != nil {
+		t.Fatal(err)
+	} else if hints, err := c.hints.take(owners[0].ID); err != nil || len(hints) != 0 {
+		t.Fatalf("unexpected hints: %v, err: %v", hints, err)
+	}
+	if nodes, _ := c.writeNodes("i", 5); !reflect.DeepEqual(nodes, owners) {
+		t.Fatalf("unexpected nodes: %v", nodes)
+	}
+
+	// Writes go to every owner when none is up.
+	c.failures.nodes[owners[0].ID].state = nodeStateDown
+	c.failures.nodes[owners[1].ID] = &heartbeatHistory{state: nodeStateDown}
+	if nodes, missed := c.writeNodes("i", 5); !reflect.DeepEqual(nodes, owners) || len(missed) != 0 {
+		t.Fatalf("unexpected nodes: %v, missed: %v", nodes, missed)
+	}
+}
+
+// Ensure hints are kept in order across restarts, including those being
+// replayed, and that hints which couldn't be replayed are kept ahead of those
+// added since.
+func TestHintLog(t *testing.T) {
+	path := filepath.Join(NewTestCluster(1).Path, hintsDir)
+	l := newHintLog()
+	if err := l.open(path); err != nil {
+		t.Fatal(err)
+	}
+	for _, q := range []string{"Set(1, f=1)", "Clear(1, f=1)", "Set(2, f=1)"} {
+		if err := l.add("n1", hint{Type: hintTypeQuery, Index: "i", Query: q}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	l = newHintLog()
+	if err := l.open(path); err != nil {
+		t.Fatal(err)
+	} else if !l.has("n1") || l.has("n2") {
+		t.Fatal("expected only n1 to have hints")
+	}
+	if _, err := l.take("n1"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Hints being replayed when the node crashes are taken again.
+	l = newHintLog()
+	if err := l.open(path); err != nil {
+		t.Fatal(err)
+	} else if !l.has("n1") {
+		t.Fatal("expected n1 to have hints")
+	}
+	hints, err := l.take("n1")
+	if err != nil {
+		t.Fatal(err)
+	} else if len(hints) != 3 || hints[0].Query != "Set(1, f=1)" || hints[2].Query != "Set(2, f=1)" {
+		t.Fatalf("unexpected hints: %v", hints)
+	}
+
+	if err := l.add("n1", hint{Type: hintTypeQuery, Index: "i", Query: "Set(3, f=1)"}); err != nil {
+		t.Fatal(err)
+	} else if err := l.restore("n1", hints[1:]); err != nil {
+		t.Fatal(err)
+	}
+	take := func() []string {
+		t.Helper()
+		hints, err := l.take("n1")
+		if err != nil {
+			t.Fatal(err)
+		} else if err := l.replayed("n1"); err != nil {
+			t.Fatal(err)
+		}
+		var queries []string
+		for _, h := range hints {
+			queries = append(queries, h.Query)
+		}
+		return queries
+	}
+	if queries, exp := take(), []string{"Clear(1, f=1)", "Set(2, f=1)"}; !reflect.DeepEqual(queries, exp) {
+		t.Fatalf("unexpected queries: %v", queries)
+	} else if queries, exp := take(), []string{"Set(3, f=1)"}; !reflect.DeepEqual(queries, exp) {
+		t.Fatalf("unexpected queries: %v", queries)
+	} else if queries := take(); len(queries) != 0 || l.has("n1") {
+		t.Fatalf("unexpected queries: %v", queries)
+	}
+}
+
+// hintClient records the queries sent to each node, and fails while err is
+// set.
+type hintClient struct {
+	nopInternalClient
+	queries map[string][]*QueryRequest
+	err     error
+}
+
+func (c *hintClient) QueryNode(ctx context.Context, uri *URI, index string, req *QueryRequest) (*QueryResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	c.queries[uri.String()] = append(c.queries[uri.String()], req)
+	return &QueryResponse{}, nil
+}
+
+// Ensure that a bit cleared while its other replica is DOWN is cleared on
+// that replica once it returns, by replaying the Clear() to it rather than
+// merging the replicas, which would restore the bit.
+func TestServer_ReplayHints(t *testing.T) {
+	h := newHolder()
+	if err := h.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+	h.MustCreateFieldIfNotExists("i", "f")
+
+	c := NewTestCluster(2)
+	c.ReplicaN = 2
+	if err := c.hints.open(filepath.Join(c.Path, hintsDir)); err != nil {
+		t.Fatal(err)
+	}
+	client := &hintClient{queries: make(map[string][]*QueryRequest)}
+	s := &Server{
+		cluster:       c,
+		holder:        h.Holder,
+		defaultClient: client,
+		nodeID:        c.Node.ID,
+		logger:        logger.NopLogger,
+		closing:       make(chan struct{}),
+	}
+	replica := c.nodes[1]
+
+	// Clear the bit while the replica is DOWN.
+	c.failures.nodes[replica.ID] = &heartbeatHistory{state: nodeStateDown}
+	call, err := pql.ParseString("Clear(1, f=1)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	nodes, missed := c.writeNodes("i", 0)
+	if len(nodes) != 1 || nodes[0] != c.Node {
+		t.Fatalf("unexpected nodes: %v", nodes)
+	} else if err := c.addHint(missed, queryHint("i", 0, call.Calls[0])); err != nil {
+		t.Fatal(err)
+	}
+
+	// Nothing is replayed while it's DOWN.
+	s.replayHints()
+	s.wg.Wait()
+	if len(client.queries) != 0 {
+		t.Fatalf("unexpected queries: %v", client.queries)
+	}
+
+	// Hints which fail are kept.
+	c.failures.nodes[replica.ID].state = nodeStateReady
+	client.err = errors.New("marker")
+	s.replayHints()
+	s.wg.Wait()
+	if !c.hints.has(replica.ID) {
+		t.Fatal("expected hints to be kept")
+	}
+
+	client.err = nil
+	s.replayHints()
+	s.wg.Wait()
+	reqs := client.queries[replica.clusterURI().String()]
+	if len(reqs) != 1 {
+		t.Fatalf("unexpected queries: %v", client.queries)
+	} else if req := reqs[0]; !strings.HasPrefix(req.Query, "Clear(") || !req.Remote || !reflect.DeepEqual(req.Shards, []uint64{0}) {
+		t.Fatalf("unexpected query: %+v", req)
+	} else if c.hints.has(replica.ID) {
+		t.Fatal("expected hints to be replayed")
+	} else if nodes, _ := c.writeNodes("i", 0); len(nodes) != 2 {
+		t.Fatalf("expected writes to go to both replicas: %v", nodes)
+	}
+}
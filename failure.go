@@ -0,0 +1,255 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// Failure detector defaults.
+const (
+	defaultHeartbeatInterval = time.Second
+	defaultSuspectThreshold  = 8.0
+	defaultDownThreshold     = 16.0
+)
+
+// heartbeatWindow is the number of intervals between heartbeats which the
+// failure detector keeps for each node.
+const heartbeatWindow = 100
+
+// HeartbeatMessage is an internal message which each node sends to every
+// other node at the heartbeat interval, so that they can detect its failure.
+type HeartbeatMessage struct {
+	NodeID string
+}
+
+// failureDetector is a phi-accrual failure detector. Rather than judging a
+// node to have failed once a fixed timeout passes without a heartbeat from
+// it, it keeps the distribution of the intervals between the node's
+// heartbeats, and computes phi, the suspicion that the node has failed given
+// the time since its last heartbeat. A phi of 1 means the chance that the
+// node is still alive is 10%, 2 means 1%, and so on.
+//
+// A node is SUSPECT once its phi reaches the suspect threshold, and DOWN once
+// it reaches the down threshold. Nodes which have never sent a heartbeat,
+// such as those running an older version, are never suspected.
+type failureDetector struct {
+	mu    sync.Mutex
+	nodes map[string]*heartbeatHistory
+
+	interval         time.Duration
+	suspectThreshold float64
+	downThreshold    float64
+}
+
+// heartbeatHistory is the record of heartbeats received from a node.
+type heartbeatHistory struct {
+	last      time.Time
+	intervals []float64 // seconds
+	state     string
+}
+
+func newFailureDetector() *failureDetector {
+	return &failureDetector{
+		nodes:            make(map[string]*heartbeatHistory),
+		interval:         defaultHeartbeatInterval,
+		suspectThreshold: defaultSuspectThreshold,
+		downThreshold:    defaultDownThreshold,
+	}
+}
+
+// heartbeat records a heartbeat received from a node at t.
+func (d *failureDetector) heartbeat(nodeID string, t time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	// The first heartbeat from a node, or the first since it was judged
+	// down, starts a new history, which assumes heartbeats arrive at the
+	// configured interval until there are enough to go on.
+	h := d.nodes[nodeID]
+	if h == nil || h.state == nodeStateDown {
+		if h == nil {
+			h = &heartbeatHistory{state: nodeStateReady}
+			d.nodes[nodeID] = h
+		}
+		h.last = t
+		h.intervals = []float64{d.interval.Seconds()}
+		return
+	}
+
+	if t.After(h.last) {
+		h.intervals = append(h.intervals, t.Sub(h.last).Seconds())
+		if len(h.intervals) > heartbeatWindow {
+			h.intervals = h.intervals[len(h.intervals)-heartbeatWindow:]
+		}
+		h.last = t
+	}
+}
+
+// phi returns the suspicion that the node has failed at t, based on its
+// history. Heartbeats are allowed to be late by up to twice the interval
+// before the suspicion rises quickly, so that short pauses, such as for
+// garbage collection, are tolerated.
+func (h *heartbeatHistory) phi(t time.Time, interval time.Duration) float64 {
+	var mean, variance float64
+	for _, v := range h.intervals {
+		mean += v
+	}
+	mean /= float64(len(h.intervals))
+	for _, v := range h.intervals {
+		variance += (v - mean) * (v - mean)
+	}
+	variance /= float64(len(h.intervals))
+
+	mean += 2 * interval.Seconds()
+	stdDev := math.Max(math.Sqrt(variance), interval.Seconds()/10)
+
+	// Approximate the cumulative distribution function of the normal
+	// distribution with a logistic function.
+	elapsed := t.Sub(h.last).Seconds()
+	y := (elapsed - mean) / stdDev
+	e := math.Exp(-y * (1.5976 + 0.070566*y*y))
+	if elapsed > mean {
+		return -math.Log10(e / (1 + e))
+	}
+	return -math.Log10(1 - 1/(1+e))
+}
+
+// check updates the state of each of the nodes at t, and returns the nodes
+// whose state changed. The history of nodes which are not listed is
+// discarded.
+func (d *failureDetector) check(nodeIDs []string, t time.Time) []NodeHealthEvent {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var events []NodeHealthEvent
+	listed := make(map[string]struct{}, len(nodeIDs))
+	for _, id := range nodeIDs {
+		listed[id] = struct{}{}
+		h := d.nodes[id]
+		if h == nil {
+			continue
+		}
+
+		phi, state := h.phi(t, d.interval), nodeStateReady
+		if phi >= d.downThreshold {
+			state = nodeStateDown
+		} else if phi >= d.suspectThreshold {
+			state = nodeStateSuspect
+		}
+		if state != h.state {
+			h.state = state
+			events = append(events, NodeHealthEvent{NodeID: id, State: state, Phi: phi})
+		}
+	}
+	for id := range d.nodes {
+		if _, ok := listed[id]; !ok {
+			delete(d.nodes, id)
+		}
+	}
+	return events
+}
+
+// state returns the state of a node, which is READY for nodes the detector
+// knows nothing about.
+func (d *failureDetector) state(nodeID string) string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if h := d.nodes[nodeID]; h != nil {
+		return h.state
+	}
+	return nodeStateReady
+}
+
+// states returns the state of each node the detector has heard from.
+func (d *failureDetector) states() map[string]string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	m := make(map[string]string, len(d.nodes))
+	for id, h := range d.nodes {
+		m[id] = h.state
+	}
+	return m
+}
+
+// monitorHeartbeats sends a heartbeat to every other node at the heartbeat
+// interval, and updates the state of each node according to the heartbeats
+// received from it. This is run in a goroutine.
+func (s *Server) monitorHeartbeats() {
+	d := s.cluster.failures
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.closing:
+			return
+		case <-ticker.C:
+		}
+
+		s.sendHeartbeats()
+
+		var ids []string
+		for _, node := range s.cluster.Nodes() {
+			if node.ID != s.nodeID {
+				ids = append(ids, node.ID)
+			}
+		}
+		for _, ev := range d.check(ids, time.Now()) {
+			s.logger.Printf("node %s is %s (phi %.1f)", ev.NodeID, ev.State, ev.Phi)
+			s.events.HandleEvent(ev)
+		}
+		s.replayHints()
+	}
+}
+
+// sendHeartbeats sends a heartbeat to every other node which supports them,
+// without waiting longer than the heartbeat interval for any.
+func (s *Server) sendHeartbeats() {
+	msg, err := s.serializer.Marshal(&HeartbeatMessage{NodeID: s.nodeID})
+	if err != nil {
+		s.logger.Printf("marshaling heartbeat: %v", err)
+		return
+	}
+	msg = append([]byte{getMessageType(&HeartbeatMessage{})}, msg...)
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.cluster.failures.interval)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for _, node := range s.cluster.Nodes() {
+		if node.ID == s.nodeID || !node.HasFeatures(FeatureHeartbeat) {
+			continue
+		}
+		wg.Add(1)
+		go func(node *Node) {
+			defer wg.Done()
+			// Failures are detected by the receiving side, so there is
+			// nothing to do if this fails.
+			_ = s.defaultClient.SendMessage(ctx, node.clusterURI(), msg)
+		}(node)
+	}
+	wg.Wait()
+}
+
+// NodeHealth returns the state of each other node in the cluster, according
+// to this node's failure detector: READY, SUSPECT, or DOWN. Nodes which have
+// never sent this node a heartbeat are omitted.
+func (api *API) NodeHealth() map[string]string {
+	return api.cluster.failures.states()
+}
@@ -0,0 +1,70 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// Ensure a node which stops sending heartbeats becomes SUSPECT and then
+// DOWN, and recovers once it resumes.
+func TestFailureDetector(t *testing.T) {
+	d := newFailureDetector()
+	now := time.Now()
+	for i := 0; i < 10; i++ {
+		now = now.Add(time.Second)
+		d.heartbeat("n1", now)
+		d.heartbeat("n2", now)
+	}
+	if events := d.check([]string{"n1", "n2", "n3"}, now.Add(time.Second)); len(events) != 0 {
+		t.Fatalf("unexpected events: %v", events)
+	} else if d.state("n3") != nodeStateReady {
+		t.Fatalf("unexpected state for unknown node: %s", d.state("n3"))
+	}
+
+	// n2 keeps sending heartbeats, while n1 stops.
+	check := func(dt time.Duration) []NodeHealthEvent {
+		d.heartbeat("n2", now.Add(dt))
+		return d.check([]string{"n1", "n2"}, now.Add(dt))
+	}
+	if events := check(3500 * time.Millisecond); len(events) != 0 {
+		t.Fatalf("unexpected events: %v", events)
+	}
+	if events := check(3600 * time.Millisecond); len(events) != 1 || events[0].NodeID != "n1" || events[0].State != nodeStateSuspect {
+		t.Fatalf("unexpected events: %v", events)
+	}
+	if events := check(5 * time.Second); len(events) != 1 || events[0].State != nodeStateDown {
+		t.Fatalf("unexpected events: %v", events)
+	} else if d.state("n1") != nodeStateDown || d.state("n2") != nodeStateReady {
+		t.Fatalf("unexpected states: %v", d.states())
+	}
+
+	// A heartbeat after a long gap starts a new history, rather than
+	// counting the gap as a usual interval.
+	now = now.Add(time.Minute)
+	d.heartbeat("n1", now)
+	if events := d.check([]string{"n1"}, now.Add(time.Second)); len(events) != 1 || events[0].State != nodeStateReady {
+		t.Fatalf("unexpected events: %v", events)
+	} else if h := d.nodes["n1"]; len(h.intervals) != 1 {
+		t.Fatalf("unexpected history: %v", h.intervals)
+	}
+
+	// Nodes which leave the cluster are forgotten.
+	if got := d.states(); !reflect.DeepEqual(got, map[string]string{"n1": nodeStateReady}) {
+		t.Fatalf("unexpected states: %v", got)
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"github.com/pkg/errors"
+)
+
+// Features which a node may support. Each node advertises the features it
+// supports to its peers, so that while a cluster runs mixed versions during a
+// rolling upgrade, it only relies on features which every node supports.
+const (
+	// FeatureZonePlacement is supported by nodes which place replicas in
+	// different zones.
+	FeatureZonePlacement uint64 = 1 << iota
+
+	// FeatureTruncateField is supported by nodes which handle
+	// TruncateFieldMessage.
+	FeatureTruncateField
+
+	// FeatureSettings is supported by nodes which handle SettingsMessage.
+	FeatureSettings
+
+	// FeatureSchemaCoordinator is supported by nodes which handle
+	// SchemaChangeRequest.
+	FeatureSchemaCoordinator
+
+	// FeatureIndexReadOnly is supported by nodes which handle
+	// IndexReadOnlyMessage.
+	FeatureIndexReadOnly
+
+	// FeatureHeartbeat is supported by nodes which handle HeartbeatMessage.
+	FeatureHeartbeat
+
+	// FeatureContainerSync is supported by nodes which return the
+	// checksums and data of individual containers of a fragment block.
+	FeatureContainerSync
+
+	// FeatureMaterializedViews is supported by nodes which handle
+	// CreateMaterializedViewMessage and DeleteMaterializedViewMessage.
+	FeatureMaterializedViews
+
+	// FeatureAliases is supported by nodes which handle AliasesMessage.
+	FeatureAliases
+
+	// FeatureRewriteRules is supported by nodes which handle
+	// CreateRewriteRuleMessage and DeleteRewriteRuleMessage.
+	FeatureRewriteRules
+
+	// FeatureNamedQueries is supported by nodes which handle
+	// CreateNamedQueryMessage and DeleteNamedQueryMessage.
+	FeatureNamedQueries
+
+	// FeatureSchedules is supported by nodes which handle
+	// CreateScheduleMessage, DeleteScheduleMessage, and ScheduleRunMessage.
+	FeatureSchedules
+
+	// FeatureReadRebalance is supported by nodes which handle
+	// ReadOwnersMessage.
+	FeatureReadRebalance
+
+	// FeatureTopNInvalidation is supported by nodes which handle
+	// InvalidateTopNMessage.
+	FeatureTopNInvalidation
+)
+
+// supportedFeatures are the features supported by this build.
+const supportedFeatures = FeatureZonePlacement | FeatureTruncateField | FeatureSettings | FeatureSchemaCoordinator | FeatureIndexReadOnly | FeatureHeartbeat | FeatureContainerSync | FeatureMaterializedViews | FeatureAliases | FeatureRewriteRules | FeatureNamedQueries |
+	FeatureSchedules | FeatureReadRebalance | FeatureTopNInvalidation
+
+// ErrFeatureUnsupported is returned when an operation relies on a feature
+// which some node in the cluster does not support.
+var ErrFeatureUnsupported = errors.New("feature not supported by every node")
+
+// HasFeatures returns true if the node supports all of the features f.
+func (n *Node) HasFeatures(f uint64) bool {
+	return n.Features&f == f
+}
+
+// unprotectedRequiredFeatures returns the features which a node must support
+// to join the cluster. Zone placement is required once any node has a zone,
+// since nodes without it would disagree on which nodes own each shard.
+func (c *cluster) unprotectedRequiredFeatures(joining *Node) uint64 {
+	if joining.Zone != "" {
+		return FeatureZonePlacement
+	}
+	for _, n := range c.nodes {
+		if n.Zone != "" {
+			return FeatureZonePlacement
+		}
+	}
+	return 0
+}
+
+// validateFeatures returns ErrFeatureUnsupported if any node in the cluster
+// does not support all of the features f. The nodes of a static cluster do
+// not exchange their features, so they are assumed to support them.
+func (c *cluster) validateFeatures(f uint64) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.Static {
+		return nil
+	}
+	for _, n := range c.nodes {
+		if !n.HasFeatures(f) {
+			return errors.Wrapf(ErrFeatureUnsupported, "node %s (version %q)", n.ID, n.Version)
+		}
+	}
+	return nil
+}
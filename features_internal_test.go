@@ -0,0 +1,46 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestCluster_RequiredFeatures(t *testing.T) {
+	c := newCluster()
+	c.nodes = []*Node{{ID: "a", Features: supportedFeatures}, {ID: "b"}}
+
+	// Without zones, any node may join.
+	if f := c.unprotectedRequiredFeatures(&Node{ID: "c"}); f != 0 {
+		t.Fatalf("unexpected required features: %#x", f)
+	} else if f := c.unprotectedRequiredFeatures(&Node{ID: "c", Zone: "z"}); f != FeatureZonePlacement {
+		t.Fatalf("unexpected required features: %#x", f)
+	}
+	c.nodes[0].Zone = "z"
+	if f := c.unprotectedRequiredFeatures(&Node{ID: "c"}); f != FeatureZonePlacement {
+		t.Fatalf("unexpected required features: %#x", f)
+	}
+
+	// Operations relying on a feature fail while a node lacks it.
+	if err := c.validateFeatures(FeatureTruncateField); errors.Cause(err) != ErrFeatureUnsupported {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c.nodes[1].Features = supportedFeatures
+	if err := c.validateFeatures(FeatureTruncateField); err != nil {
+		t.Fatal(err)
+	}
+}
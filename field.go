@@ -33,6 +33,7 @@ import (
 	"github.com/pilosa/pilosa/v2/pql"
 	"github.com/pilosa/pilosa/v2/roaring"
 	"github.com/pilosa/pilosa/v2/stats"
+	"github.com/pilosa/pilosa/v2/toml"
 	"github.com/pilosa/pilosa/v2/tracing"
 	"github.com/pkg/errors"
 	"golang.org/x/sync/errgroup"
@@ -87,9 +88,30 @@ type Field struct {
 	// Shards with data on any node in the cluster, according to this node.
 	remoteAvailableShards *roaring.Bitmap
 
+	// In-memory fields have no directory, and keep their views in memory.
+	inMemory bool
+
+	// Time of the last query of or import to the field on any node, as far
+	// as this node knows, in nanoseconds since the epoch. Ephemeral fields
+	// are deleted once it is older than their idle TTL. Accessed atomically.
+	lastAccess int64
+
 	logger logger.Logger
 
 	snapshotQueue chan *fragment
+	lazyFragments bool
+	progress      *openProgress
+	filePool      *filePool
+
+	// Passed to fragments, to group their writes and flush their files.
+	groupCommitWindow time.Duration
+	fsyncer           *fsyncer
+
+	// Directory corrupt fragment files are moved to.
+	quarantine *quarantine
+
+	// Records local mutations for change data capture, if set.
+	changes *changeLog
 
 	// Instantiates new translation store on open.
 	OpenTranslateStore OpenTranslateStoreFunc
@@ -174,6 +196,72 @@ func OptFieldTypeTime(timeQuantum TimeQuantum, opt ...bool) FieldOption {
 	}
 }
 
+// OptFieldTimeCache is a functional option on FieldOptions used to give a
+// time field a cache. It applies to the field's standard view and, unless
+// overridden with OptFieldTimeViews, to its time views. Time fields have no
+// cache by default.
+func OptFieldTimeCache(cacheType string, cacheSize uint32) FieldOption {
+	return func(fo *FieldOptions) error {
+		if fo.Type != FieldTypeTime {
+			return errors.New("cache options only apply to time fields")
+		}
+		fo.CacheType = cacheType
+		fo.CacheSize = cacheSize
+		return nil
+	}
+}
+
+// OptFieldTimeViews is a functional option on FieldOptions used to override
+// the cache and storage of a time field's time views, such as standard_2019.
+// Old time views are rarely queried by rank, so their caches can often be
+// dropped. An empty cacheType or storage, or a zero cacheSize, is inherited
+// from the field.
+func OptFieldTimeViews(cacheType string, cacheSize uint32, storage string) FieldOption {
+	return func(fo *FieldOptions) error {
+		if fo.Type != FieldTypeTime {
+			return errors.New("time view options only apply to time fields")
+		}
+		fo.TimeViewCacheType = cacheType
+		fo.TimeViewCacheSize = cacheSize
+		fo.TimeViewStorage = storage
+		return nil
+	}
+}
+
+// OptFieldInverse is a functional option on FieldOptions used to maintain
+// an inverse view of the field, in which columns are rows. It lets the rows
+// of a column be read without scanning every row of the field, at the cost of
+// a second write for every bit. It applies to set, mutex, and time fields
+// with a standard view, and must follow the option setting the field type.
+func OptFieldInverse() FieldOption {
+	return func(fo *FieldOptions) error {
+		switch fo.Type {
+		case FieldTypeSet, FieldTypeMutex, FieldTypeTime:
+		default:
+			return errors.Errorf("inverse view does not apply to field type %s", fo.Type)
+		}
+		if fo.NoStandardView {
+			return errors.New("inverse view requires the standard view")
+		}
+		fo.Inverse = true
+		return nil
+	}
+}
+
+// OptFieldIdleTTL is a functional option on FieldOptions used to make the
+// field ephemeral: it is deleted from the cluster once it has not been queried
+// or imported to for ttl. It suits scratch fields created programmatically,
+// such as for a session or an experiment.
+func OptFieldIdleTTL(ttl time.Duration) FieldOption {
+	return func(fo *FieldOptions) error {
+		if ttl < 0 {
+			return errors.New("idle TTL must not be negative")
+		}
+		fo.IdleTTL = toml.Duration(ttl)
+		return nil
+	}
+}
+
 // OptFieldTypeMutex is a functional option on FieldOptions
 // used to specify the field as being type `mutex` and to
 // provide any respective configuration values.
@@ -239,6 +327,8 @@ func newField(path, index, name string, opts FieldOption) (*Field, error) {
 
 		logger: logger.NopLogger,
 
+		lastAccess: time.Now().UnixNano(),
+
 		OpenTranslateStore: OpenInMemTranslateStore,
 	}
 	return f, nil
@@ -315,6 +405,9 @@ func (f *Field) saveAvailableShards() error {
 }
 
 func (f *Field) unprotectedSaveAvailableShards() error {
+	if f.inMemory {
+		return nil
+	}
 	path := filepath.Join(f.path, ".available.shards")
 	// Create a temporary file to save to.
 	tempPath := path + tempExt
@@ -402,20 +495,22 @@ func (f *Field) Options() FieldOptions {
 // Open opens and initializes the field.
 func (f *Field) Open() error {
 	if err := func() (err error) {
-		// Ensure the field's path exists.
-		f.logger.Debugf("ensure field path exists: %s", f.path)
-		if err := os.MkdirAll(f.path, 0777); err != nil {
-			return errors.Wrap(err, "creating field dir")
-		}
+		if !f.inMemory {
+			// Ensure the field's path exists.
+			f.logger.Debugf("ensure field path exists: %s", f.path)
+			if err := os.MkdirAll(f.path, 0777); err != nil {
+				return errors.Wrap(err, "creating field dir")
+			}
 
-		f.logger.Debugf("load meta file for index/field: %s/%s", f.index, f.name)
-		if err := f.loadMeta(); err != nil {
-			return errors.Wrap(err, "loading meta")
-		}
+			f.logger.Debugf("load meta file for index/field: %s/%s", f.index, f.name)
+			if err := f.loadMeta(); err != nil {
+				return errors.Wrap(err, "loading meta")
+			}
 
-		f.logger.Debugf("load available shards for index/field: %s/%s", f.index, f.name)
-		if err := f.loadAvailableShards(); err != nil {
-			return errors.Wrap(err, "loading available shards")
+			f.logger.Debugf("load available shards for index/field: %s/%s", f.index, f.name)
+			if err := f.loadAvailableShards(); err != nil {
+				return errors.Wrap(err, "loading available shards")
+			}
 		}
 
 		// Apply the field options loaded from meta.
@@ -453,6 +548,9 @@ var fieldQueue = make(chan struct{}, 16)
 
 // openViews opens and initializes the views inside the field.
 func (f *Field) openViews() error {
+	if f.inMemory {
+		return nil
+	}
 	file, err := os.Open(filepath.Join(f.path, "views"))
 	if os.IsNotExist(err) {
 		return nil
@@ -554,12 +652,20 @@ func (f *Field) loadMeta() error {
 	f.options.TimeQuantum = TimeQuantum(pb.TimeQuantum)
 	f.options.Keys = pb.Keys
 	f.options.NoStandardView = pb.NoStandardView
+	f.options.TimeViewCacheType = pb.TimeViewCacheType
+	f.options.TimeViewCacheSize = pb.TimeViewCacheSize
+	f.options.TimeViewStorage = pb.TimeViewStorage
+	f.options.Inverse = pb.Inverse
+	f.options.IdleTTL = toml.Duration(pb.IdleTTL)
 
 	return nil
 }
 
 // saveMeta writes meta data for the field.
 func (f *Field) saveMeta() error {
+	if f.inMemory {
+		return nil
+	}
 	path := filepath.Join(f.path, ".meta")
 	// Create a temporary file to marshal to.
 	tempPath := f.path + tempExt
@@ -607,6 +713,7 @@ func (f *Field) applyOptions(opt FieldOptions) error {
 		f.options.BitDepth = 0
 		f.options.TimeQuantum = ""
 		f.options.Keys = opt.Keys
+		f.options.Inverse = opt.Inverse
 	case FieldTypeInt:
 		f.options.Type = opt.Type
 		f.options.CacheType = CacheTypeNone
@@ -638,12 +745,23 @@ func (f *Field) applyOptions(opt FieldOptions) error {
 		f.options.Type = opt.Type
 		f.options.CacheType = CacheTypeNone
 		f.options.CacheSize = 0
+		if opt.CacheType != "" && opt.CacheType != CacheTypeNone {
+			f.options.CacheType = opt.CacheType
+			f.options.CacheSize = opt.CacheSize
+			if f.options.CacheSize == 0 {
+				f.options.CacheSize = DefaultCacheSize
+			}
+		}
+		f.options.TimeViewCacheType = opt.TimeViewCacheType
+		f.options.TimeViewCacheSize = opt.TimeViewCacheSize
+		f.options.TimeViewStorage = opt.TimeViewStorage
 		f.options.Min = 0
 		f.options.Max = 0
 		f.options.Base = 0
 		f.options.BitDepth = 0
 		f.options.Keys = opt.Keys
 		f.options.NoStandardView = opt.NoStandardView
+		f.options.Inverse = opt.Inverse && !opt.NoStandardView
 		// Set the time quantum.
 		if err := f.setTimeQuantum(opt.TimeQuantum); err != nil {
 			f.Close()
@@ -662,6 +780,7 @@ func (f *Field) applyOptions(opt FieldOptions) error {
 	default:
 		return errors.New("invalid field type")
 	}
+	f.options.IdleTTL = opt.IdleTTL
 
 	return nil
 }
@@ -882,6 +1001,38 @@ func (f *Field) newView(path, name string) *view {
 	view.stats = f.Stats
 	view.broadcaster = f.broadcaster
 	view.snapshotQueue = f.snapshotQueue
+	view.lazyFragments = f.lazyFragments
+	view.inMemory = f.inMemory
+
+	// Time views may override the field's cache and storage.
+	if strings.HasPrefix(name, viewStandard+"_") {
+		if f.options.TimeViewCacheType != "" {
+			view.cacheType = f.options.TimeViewCacheType
+		}
+		if f.options.TimeViewCacheSize != 0 {
+			view.cacheSize = f.options.TimeViewCacheSize
+		}
+		switch f.options.TimeViewStorage {
+		case ViewStorageEager:
+			view.lazyFragments = false
+		case ViewStorageLazy:
+			view.lazyFragments = true
+		}
+	}
+	// The inverse view's rows are columns, so it has no rank cache, and it
+	// is never mutually exclusive.
+	if name == viewInverse {
+		view.fieldType = FieldTypeSet
+		view.cacheType = CacheTypeNone
+		view.cacheSize = 0
+	}
+	view.progress = f.progress
+	view.filePool = f.filePool
+	view.groupCommitWindow = f.groupCommitWindow
+	view.fsyncer = f.fsyncer
+	view.quarantine = f.quarantine
+	view.changes = f.changes
+	view.valueBase = f.options.Base
 	return view
 }
 
@@ -898,8 +1049,10 @@ func (f *Field) deleteView(name string) error {
 	}
 
 	// Delete view directory.
-	if err := os.RemoveAll(view.path); err != nil {
-		return errors.Wrap(err, "deleting directory")
+	if !view.inMemory {
+		if err := os.RemoveAll(view.path); err != nil {
+			return errors.Wrap(err, "deleting directory")
+		}
 	}
 
 	delete(f.viewMap, name)
@@ -907,6 +1060,26 @@ func (f *Field) deleteView(name string) error {
 	return nil
 }
 
+// truncate removes every view of the field, along with its fragments and
+// caches. The field's schema, options, keys, and row attributes are kept.
+func (f *Field) truncate() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for name, view := range f.viewMap {
+		if err := view.close(); err != nil {
+			return errors.Wrapf(err, "closing view: %s", name)
+		}
+		if !view.inMemory {
+			if err := os.RemoveAll(view.path); err != nil {
+				return errors.Wrapf(err, "deleting view directory: %s", name)
+			}
+		}
+		delete(f.viewMap, name)
+	}
+	return nil
+}
+
 // Row returns a row of the standard view.
 // It seems this method is only being used by the test
 // package, and the fact that it's only allowed on
@@ -939,6 +1112,12 @@ func (f *Field) SetBit(rowID, colID uint64, t *time.Time) (changed bool, err err
 		} else if v {
 			changed = v
 		}
+
+		if f.options.Inverse {
+			if err := f.setInverseBit(rowID, colID); err != nil {
+				return changed, err
+			}
+		}
 	}
 
 	// Exit early if no timestamp is specified.
@@ -980,6 +1159,11 @@ func (f *Field) ClearBit(rowID, colID uint64) (changed bool, err error) {
 	} else if v {
 		changed = v
 	}
+	if f.options.Inverse {
+		if err := f.clearInverseBit(rowID, colID); err != nil {
+			return changed, err
+		}
+	}
 	if len(f.viewMap) == 1 { // assuming no time views
 		return changed, nil
 	}
@@ -1031,6 +1215,9 @@ func (f *Field) allTimeViewsSortedByQuantum() (me []*view) {
 		}
 	}
 	me = me[:i]
+	if len(me) == 0 {
+		return me
+	}
 	year := strings.Index(me[0].name, "_") + 4
 	month := year + 2
 	day := month + 2
@@ -1202,6 +1389,7 @@ func (f *Field) Range(name string, op pql.Token, predicate int64) (*Row, error)
 
 // Import bulk imports data.
 func (f *Field) Import(rowIDs, columnIDs []uint64, timestamps []*time.Time, opts ...ImportOption) error {
+	f.touch()
 
 	// Set up import options.
 	options := &ImportOptions{}
@@ -1222,10 +1410,22 @@ func (f *Field) Import(rowIDs, columnIDs []uint64, timestamps []*time.Time, opts
 		}
 	}
 
+	// Import into the time views of only some of the field's units.
+	if options.TimeQuantum != "" {
+		for _, unit := range options.TimeQuantum {
+			if !strings.ContainsRune(string(q), unit) {
+				return errors.Errorf("import time quantum %s is not within the field's time quantum %q", options.TimeQuantum, q)
+			}
+		}
+		q = options.TimeQuantum
+	}
+	skipStandard := f.options.NoStandardView || options.SkipStandardView
+
 	fieldType := f.Type()
 
 	// Split import data by fragment.
 	dataByFragment := make(map[importKey]importData)
+	standardOnly := []string{viewStandard}
 	for i := range rowIDs {
 		rowID, columnID := rowIDs[i], columnIDs[i]
 
@@ -1241,10 +1441,10 @@ func (f *Field) Import(rowIDs, columnIDs []uint64, timestamps []*time.Time, opts
 
 		var standard []string
 		if timestamp == nil {
-			standard = []string{viewStandard}
+			standard = standardOnly
 		} else {
 			standard = viewsByTime(viewStandard, *timestamp, q)
-			if !f.options.NoStandardView {
+			if !skipStandard {
 				// In order to match the logic of `SetBit()`, we want bits
 				// with timestamps to write to both time and standard views.
 				standard = append(standard, viewStandard)
@@ -1273,9 +1473,24 @@ func (f *Field) Import(rowIDs, columnIDs []uint64, timestamps []*time.Time, opts
 			return errors.Wrap(err, "creating fragment")
 		}
 
+		// The import overwrites its data, so the inverse is imported first.
+		if f.options.Inverse && key.View == viewStandard && fieldType != FieldTypeMutex {
+			if err := f.importInverse(key.Shard, data.RowIDs, data.ColumnIDs, options); err != nil {
+				return err
+			}
+		}
+
 		if err := frag.bulkImport(data.RowIDs, data.ColumnIDs, options); err != nil {
 			return err
 		}
+
+		// A mutex import clears each column's previous row, which the
+		// inverse view can only learn from the standard view.
+		if f.options.Inverse && key.View == viewStandard && fieldType == FieldTypeMutex {
+			if err := f.rebuildInverse(key.Shard); err != nil {
+				return errors.Wrap(err, "rebuilding inverse view")
+			}
+		}
 	}
 
 	return nil
@@ -1283,6 +1498,7 @@ func (f *Field) Import(rowIDs, columnIDs []uint64, timestamps []*time.Time, opts
 
 // importValue bulk imports range-encoded value data.
 func (f *Field) importValue(columnIDs []uint64, values []int64, options *ImportOptions) error {
+	f.touch()
 	viewName := viewBSIGroupPrefix + f.name
 	// Get the bsiGroup so we know bitDepth.
 	bsig := f.bsiGroup(f.name)
@@ -1374,6 +1590,7 @@ func (f *Field) importValue(columnIDs []uint64, values []int64, options *ImportO
 func (f *Field) importRoaring(ctx context.Context, data []byte, shard uint64, viewName string, clear bool) error {
 	span, ctx := tracing.StartSpanFromContext(ctx, "Field.importRoaring")
 	defer span.Finish()
+	f.touch()
 
 	if viewName == "" {
 		viewName = viewStandard
@@ -1393,6 +1610,12 @@ func (f *Field) importRoaring(ctx context.Context, data []byte, shard uint64, vi
 		return err
 	}
 
+	if viewName == viewStandard {
+		if err := f.rebuildInverse(shard); err != nil {
+			return errors.Wrap(err, "rebuilding inverse view")
+		}
+	}
+
 	return nil
 }
 
@@ -1427,6 +1650,20 @@ type FieldOptions struct {
 	CacheType      string      `json:"cacheType,omitempty"`
 	Type           string      `json:"type,omitempty"`
 	TimeQuantum    TimeQuantum `json:"timeQuantum,omitempty"`
+
+	// Overrides of the cache and storage options of a time field's time
+	// views. Empty values are inherited from the field.
+	TimeViewCacheType string `json:"timeViewCacheType,omitempty"`
+	TimeViewCacheSize uint32 `json:"timeViewCacheSize,omitempty"`
+	TimeViewStorage   string `json:"timeViewStorage,omitempty"`
+
+	// Inverse is true if the field maintains an inverse view, in which
+	// columns are rows.
+	Inverse bool `json:"inverse,omitempty"`
+
+	// IdleTTL makes the field ephemeral, if set: it is deleted once it has
+	// not been queried or imported to for the duration.
+	IdleTTL toml.Duration `json:"idleTTL,omitempty"`
 }
 
 // applyDefaultOptions returns a new FieldOptions object
@@ -1462,6 +1699,12 @@ func encodeFieldOptions(o *FieldOptions) *internal.FieldOptions {
 		TimeQuantum:    string(o.TimeQuantum),
 		Keys:           o.Keys,
 		NoStandardView: o.NoStandardView,
+
+		TimeViewCacheType: o.TimeViewCacheType,
+		TimeViewCacheSize: o.TimeViewCacheSize,
+		TimeViewStorage:   o.TimeViewStorage,
+		Inverse:           o.Inverse,
+		IdleTTL:           int64(o.IdleTTL),
 	}
 }
 
@@ -1472,24 +1715,29 @@ func (o *FieldOptions) MarshalJSON() ([]byte, error) {
 	switch o.Type {
 	case FieldTypeSet:
 		return json.Marshal(struct {
-			Type      string `json:"type"`
-			CacheType string `json:"cacheType"`
-			CacheSize uint32 `json:"cacheSize"`
-			Keys      bool   `json:"keys"`
+			Type      string        `json:"type"`
+			CacheType string        `json:"cacheType"`
+			CacheSize uint32        `json:"cacheSize"`
+			Keys      bool          `json:"keys"`
+			Inverse   bool          `json:"inverse,omitempty"`
+			IdleTTL   toml.Duration `json:"idleTTL,omitempty"`
 		}{
 			o.Type,
 			o.CacheType,
 			o.CacheSize,
 			o.Keys,
+			o.Inverse,
+			o.IdleTTL,
 		})
 	case FieldTypeInt:
 		return json.Marshal(struct {
-			Type     string `json:"type"`
-			Base     int64  `json:"base"`
-			BitDepth uint   `json:"bitDepth"`
-			Min      int64  `json:"min"`
-			Max      int64  `json:"max"`
-			Keys     bool   `json:"keys"`
+			Type     string        `json:"type"`
+			Base     int64         `json:"base"`
+			BitDepth uint          `json:"bitDepth"`
+			Min      int64         `json:"min"`
+			Max      int64         `json:"max"`
+			Keys     bool          `json:"keys"`
+			IdleTTL  toml.Duration `json:"idleTTL,omitempty"`
 		}{
 			o.Type,
 			o.Base,
@@ -1497,36 +1745,57 @@ func (o *FieldOptions) MarshalJSON() ([]byte, error) {
 			o.Min,
 			o.Max,
 			o.Keys,
+			o.IdleTTL,
 		})
 	case FieldTypeTime:
 		return json.Marshal(struct {
-			Type           string      `json:"type"`
-			TimeQuantum    TimeQuantum `json:"timeQuantum"`
-			Keys           bool        `json:"keys"`
-			NoStandardView bool        `json:"noStandardView"`
+			Type              string        `json:"type"`
+			TimeQuantum       TimeQuantum   `json:"timeQuantum"`
+			Keys              bool          `json:"keys"`
+			NoStandardView    bool          `json:"noStandardView"`
+			CacheType         string        `json:"cacheType"`
+			CacheSize         uint32        `json:"cacheSize"`
+			TimeViewCacheType string        `json:"timeViewCacheType,omitempty"`
+			TimeViewCacheSize uint32        `json:"timeViewCacheSize,omitempty"`
+			TimeViewStorage   string        `json:"timeViewStorage,omitempty"`
+			Inverse           bool          `json:"inverse,omitempty"`
+			IdleTTL           toml.Duration `json:"idleTTL,omitempty"`
 		}{
 			o.Type,
 			o.TimeQuantum,
 			o.Keys,
 			o.NoStandardView,
+			o.CacheType,
+			o.CacheSize,
+			o.TimeViewCacheType,
+			o.TimeViewCacheSize,
+			o.TimeViewStorage,
+			o.Inverse,
+			o.IdleTTL,
 		})
 	case FieldTypeMutex:
 		return json.Marshal(struct {
-			Type      string `json:"type"`
-			CacheType string `json:"cacheType"`
-			CacheSize uint32 `json:"cacheSize"`
-			Keys      bool   `json:"keys"`
+			Type      string        `json:"type"`
+			CacheType string        `json:"cacheType"`
+			CacheSize uint32        `json:"cacheSize"`
+			Keys      bool          `json:"keys"`
+			Inverse   bool          `json:"inverse,omitempty"`
+			IdleTTL   toml.Duration `json:"idleTTL,omitempty"`
 		}{
 			o.Type,
 			o.CacheType,
 			o.CacheSize,
 			o.Keys,
+			o.Inverse,
+			o.IdleTTL,
 		})
 	case FieldTypeBool:
 		return json.Marshal(struct {
-			Type string `json:"type"`
+			Type    string        `json:"type"`
+			IdleTTL toml.Duration `json:"idleTTL,omitempty"`
 		}{
 			o.Type,
+			o.IdleTTL,
 		})
 	}
 	return nil, errors.New("invalid field type")
@@ -1649,6 +1918,24 @@ const (
 	CacheTypeNone   = "none"
 )
 
+// Storage modes of views. Eager views load the data of their fragments when
+// they are opened, and lazy views when each fragment is first accessed. Views
+// without a storage mode use the server's lazy-fragments setting.
+const (
+	ViewStorageEager = "eager"
+	ViewStorageLazy  = "lazy"
+)
+
+// isValidViewStorage returns true if v is a valid view storage mode, or empty.
+func isValidViewStorage(v string) bool {
+	switch v {
+	case "", ViewStorageEager, ViewStorageLazy:
+		return true
+	default:
+		return false
+	}
+}
+
 // isValidCacheType returns true if v is a valid cache type.
 func isValidCacheType(v string) bool {
 	switch v {
@@ -25,6 +25,7 @@ import (
 
 	"github.com/pilosa/pilosa/v2/pql"
 	"github.com/pilosa/pilosa/v2/roaring"
+	"github.com/pkg/errors"
 )
 
 // Ensure a bsiGroup can adjust to its baseValue.
@@ -519,3 +520,175 @@ func TestBSIGroup_importValue(t *testing.T) {
 		}
 	}
 }
+
+// Ensure that time views use the field's cache unless overridden, and that
+// the overrides are kept across reopens.
+func TestField_TimeViewOptions(t *testing.T) {
+	index := mustOpenIndex(IndexOptions{})
+	defer index.Close()
+
+	f, err := index.CreateField("f", OptFieldTypeTime("YM"), OptFieldTimeCache(CacheTypeRanked, 100), OptFieldTimeViews(CacheTypeNone, 0, ViewStorageLazy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := time.Date(2010, time.January, 5, 12, 0, 0, 0, time.UTC)
+	if _, err := f.SetBit(1, 1, &ts); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		f = index.Field("f")
+		for _, tt := range []struct {
+			view      string
+			cacheType string
+			cacheSize uint32
+			lazy      bool
+		}{
+			{viewStandard, CacheTypeRanked, 100, false},
+			{"standard_2010", CacheTypeNone, 100, true},
+			{"standard_201001", CacheTypeNone, 100, true},
+		} {
+			v := f.view(tt.view)
+			if v == nil {
+				t.Fatalf("expected view %s", tt.view)
+			} else if v.cacheType != tt.cacheType || v.cacheSize != tt.cacheSize || v.lazyFragments != tt.lazy {
+				t.Fatalf("unexpected options of view %s: %s %d %v", tt.view, v.cacheType, v.cacheSize, v.lazyFragments)
+			}
+		}
+		if err := index.reopen(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := index.CreateField("g", OptFieldTypeTime("YM"), OptFieldTimeViews("nosuch", 0, "")); err != ErrInvalidCacheType {
+		t.Fatalf("expected invalid cache type, got %v", err)
+	} else if _, err := index.CreateField("g", OptFieldTypeTime("YM"), OptFieldTimeViews("", 0, "nosuch")); err != ErrInvalidViewStorage {
+		t.Fatalf("expected invalid view storage, got %v", err)
+	} else if _, err := index.CreateField("g", OptFieldTimeCache(CacheTypeRanked, 100)); err == nil {
+		t.Fatal("expected error giving time cache options to a set field")
+	}
+}
+
+// Ensure imports can be limited to some of the time views of a field.
+func TestField_Import_TimeViews(t *testing.T) {
+	index := mustOpenIndex(IndexOptions{})
+	defer index.Close()
+
+	f, err := index.CreateField("f", OptFieldTypeTime("YMD"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := time.Date(2010, time.January, 5, 12, 0, 0, 0, time.UTC)
+	if err := f.Import([]uint64{1, 1}, []uint64{2, 3}, []*time.Time{&ts, nil}, OptImportOptionsTimeQuantum("M"), OptImportOptionsSkipStandardView(true)); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		view    string
+		columns []uint64
+	}{
+		{viewStandard, []uint64{3}},
+		{"standard_201001", []uint64{2}},
+		{"standard_2010", nil},
+		{"standard_20100105", nil},
+	} {
+		var columns []uint64
+		if v := f.view(tt.view); v != nil {
+			if frag := v.Fragment(0); frag != nil {
+				columns = frag.row(1).Columns()
+			}
+		}
+		if len(columns) == 0 {
+			columns = nil
+		}
+		if !reflect.DeepEqual(columns, tt.columns) {
+			t.Fatalf("view %s: unexpected columns %v", tt.view, columns)
+		}
+	}
+
+	if err := f.Import([]uint64{1}, []uint64{2}, []*time.Time{&ts}, OptImportOptionsTimeQuantum("H")); err == nil {
+		t.Fatal("expected error importing into units not in the field's time quantum")
+	} else if err := f.Import([]uint64{1}, []uint64{2}, []*time.Time{&ts}, OptImportOptionsTimeQuantum("X")); errors.Cause(err) != ErrInvalidTimeQuantum {
+		t.Fatalf("expected invalid time quantum, got %v", err)
+	}
+}
+
+func TestField_Inverse(t *testing.T) {
+	index := mustOpenIndex(IndexOptions{})
+	defer index.Close()
+
+	f, err := index.CreateField("f", OptFieldTypeDefault(), OptFieldInverse())
+	if err != nil {
+		t.Fatal(err)
+	}
+	rowsOf := func(f *Field, columnID uint64, exp ...uint64) {
+		t.Helper()
+		if got := f.inverseRows(columnID); !reflect.DeepEqual(got, exp) {
+			t.Fatalf("rows of column %d: expected %v, got %v", columnID, exp, got)
+		}
+	}
+
+	for _, bit := range [][2]uint64{{1, 5}, {3, 5}, {ShardWidth + 2, 5}, {3, ShardWidth + 7}} {
+		if _, err := f.SetBit(bit[0], bit[1], nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rowsOf(f, 5, 1, 3, ShardWidth+2)
+	rowsOf(f, ShardWidth+7, 3)
+	rowsOf(f, 6)
+
+	if _, err := f.ClearBit(3, 5); err != nil {
+		t.Fatal(err)
+	}
+	rowsOf(f, 5, 1, ShardWidth+2)
+
+	if err := f.Import([]uint64{4, 4}, []uint64{5, 9}, nil); err != nil {
+		t.Fatal(err)
+	} else if err := f.Import([]uint64{1}, []uint64{5}, nil, OptImportOptionsClear(true)); err != nil {
+		t.Fatal(err)
+	}
+	rowsOf(f, 5, 4, ShardWidth+2)
+	rowsOf(f, 9, 4)
+
+	// Whole-row changes are caught up by rebuilding the shard.
+	if _, err := f.view(viewStandard).Fragment(0).clearRow(4); err != nil {
+		t.Fatal(err)
+	} else if err := f.rebuildInverse(0); err != nil {
+		t.Fatal(err)
+	}
+	rowsOf(f, 5, ShardWidth+2)
+	rowsOf(f, 9)
+
+	if err := index.reopen(); err != nil {
+		t.Fatal(err)
+	}
+	f = index.Field("f")
+	if !f.Options().Inverse {
+		t.Fatal("expected inverse option after reopen")
+	}
+	rowsOf(f, 5, ShardWidth+2)
+	rowsOf(f, ShardWidth+7, 3)
+
+	// A mutex field keeps one row for each column.
+	m, err := index.CreateField("m", OptFieldTypeMutex(CacheTypeNone, 0), OptFieldInverse())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.SetBit(1, 5, nil); err != nil {
+		t.Fatal(err)
+	} else if _, err := m.SetBit(2, 5, nil); err != nil {
+		t.Fatal(err)
+	}
+	rowsOf(m, 5, 2)
+	if err := m.Import([]uint64{3, 3}, []uint64{5, 6}, nil); err != nil {
+		t.Fatal(err)
+	}
+	rowsOf(m, 5, 3)
+	rowsOf(m, 6, 3)
+
+	if _, err := index.CreateField("i", OptFieldTypeInt(0, 10), OptFieldInverse()); err == nil {
+		t.Fatal("expected error giving an int field an inverse view")
+	} else if _, err := index.CreateField("t", OptFieldTypeTime("YM", true), OptFieldInverse()); err == nil {
+		t.Fatal("expected error giving a field without a standard view an inverse view")
+	}
+}
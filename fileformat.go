@@ -0,0 +1,166 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"os"
+
+	"github.com/pilosa/pilosa/v2/roaring"
+	"github.com/pkg/errors"
+)
+
+// Fragment and cache files begin with a header identifying the kind of file
+// and the version of its format, so that files written in a later format, or
+// which aren't Pilosa files at all, are rejected rather than misread:
+//
+//	0-3    magic
+//	4-5    format version
+//	6-7    flags, reserved for format options such as compression
+//	8-15   length of the body covered by the checksum
+//	16-19  CRC-32C checksum of the body
+//	20-31  reserved
+//
+// The header is a multiple of 8 bytes, so that the alignment of the data
+// mapped from a fragment file is unchanged. A fragment file's body is
+// followed by the operations appended since it was written, which the
+// checksum does not cover.
+//
+// Files written before the header was added have no header. They are read
+// as they are, and rewritten with a header when next written.
+const fileHeaderSize = 32
+
+// Magic numbers of fragment, cache, and statistics files.
+var (
+	fragmentFileMagic = [4]byte{'P', 'F', 'R', 'G'}
+	cacheFileMagic    = [4]byte{'P', 'C', 'A', 'C'}
+	statsFileMagic    = [4]byte{'P', 'S', 'T', 'A'}
+)
+
+// Current versions of the fragment, cache, and statistics file formats.
+const (
+	fragmentFileVersion = 1
+	cacheFileVersion    = 1
+	statsFileVersion    = 1
+)
+
+var crc32c = crc32.MakeTable(crc32.Castagnoli)
+
+// fileHeader is the header of a fragment or cache file.
+type fileHeader struct {
+	magic    [4]byte
+	version  uint16
+	flags    uint16
+	length   uint64
+	checksum uint32
+}
+
+// marshal returns the encoded header.
+func (h *fileHeader) marshal() []byte {
+	buf := make([]byte, fileHeaderSize)
+	copy(buf[0:4], h.magic[:])
+	binary.LittleEndian.PutUint16(buf[4:6], h.version)
+	binary.LittleEndian.PutUint16(buf[6:8], h.flags)
+	binary.LittleEndian.PutUint64(buf[8:16], h.length)
+	binary.LittleEndian.PutUint32(buf[16:20], h.checksum)
+	return buf
+}
+
+// newFileHeader returns the header of a file of the current version whose
+// body is body.
+func newFileHeader(magic [4]byte, version uint16, body []byte) *fileHeader {
+	return &fileHeader{
+		magic:    magic,
+		version:  version,
+		length:   uint64(len(body)),
+		checksum: crc32.Checksum(body, crc32c),
+	}
+}
+
+// hasFileHeader returns true if data begins with a header with magic.
+func hasFileHeader(data []byte, magic [4]byte) bool {
+	return len(data) >= fileHeaderSize && bytes.Equal(data[:4], magic[:])
+}
+
+// readFileHeader verifies the header at the beginning of data, and returns
+// the rest of data following it.
+func readFileHeader(data []byte, magic [4]byte, version uint16) ([]byte, error) {
+	h := fileHeader{
+		version:  binary.LittleEndian.Uint16(data[4:6]),
+		flags:    binary.LittleEndian.Uint16(data[6:8]),
+		length:   binary.LittleEndian.Uint64(data[8:16]),
+		checksum: binary.LittleEndian.Uint32(data[16:20]),
+	}
+	body := data[fileHeaderSize:]
+	if h.version > version {
+		return nil, errors.Errorf("unsupported file format version %d, expected at most %d", h.version, version)
+	} else if h.flags != 0 {
+		return nil, errors.Errorf("unsupported file format flags: %#x", h.flags)
+	} else if h.length > uint64(len(body)) {
+		return nil, errors.Errorf("file truncated: %d bytes, expected at least %d", len(body), h.length)
+	} else if crc32.Checksum(body[:h.length], crc32c) != h.checksum {
+		return nil, errors.New("checksum mismatch")
+	}
+	return body, nil
+}
+
+// FragmentFileBody returns the roaring data in the contents of a fragment
+// file, after verifying its header. Files without a header must begin with
+// a Pilosa roaring bitmap.
+func FragmentFileBody(data []byte) ([]byte, error) {
+	if hasFileHeader(data, fragmentFileMagic) {
+		return readFileHeader(data, fragmentFileMagic, fragmentFileVersion)
+	}
+	if len(data) < 2 || uint32(binary.LittleEndian.Uint16(data)) != roaring.MagicNumber {
+		return nil, errors.New("not a fragment file")
+	}
+	return data, nil
+}
+
+// cacheFileBody returns the body of the contents of a cache file, after
+// verifying its header, if it has one.
+func cacheFileBody(data []byte) ([]byte, error) {
+	if hasFileHeader(data, cacheFileMagic) {
+		return readFileHeader(data, cacheFileMagic, cacheFileVersion)
+	}
+	return data, nil
+}
+
+// writeFragmentFile writes a fragment file containing bm to file, which must
+// be empty and not opened for appending.
+func writeFragmentFile(file *os.File, bm *roaring.Bitmap) (int64, error) {
+	// The body is written after space for the header, which is written once
+	// the body's length and checksum are known.
+	h := crc32.New(crc32c)
+	bw := bufio.NewWriter(file)
+	if _, err := bw.Write(make([]byte, fileHeaderSize)); err != nil {
+		return 0, err
+	}
+	n, err := bm.WriteTo(io.MultiWriter(bw, h))
+	if err != nil {
+		return n, err
+	} else if err := bw.Flush(); err != nil {
+		return n, errors.Wrap(err, "flushing")
+	}
+	hdr := &fileHeader{magic: fragmentFileMagic, version: fragmentFileVersion, length: uint64(n), checksum: h.Sum32()}
+	if _, err := file.WriteAt(hdr.marshal(), 0); err != nil {
+		return n, errors.Wrap(err, "writing header")
+	}
+	return fileHeaderSize + n, nil
+}
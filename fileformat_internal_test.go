@@ -0,0 +1,146 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/pilosa/pilosa/v2/roaring"
+)
+
+// Ensure a fragment file's header is written and verified.
+func TestFragmentFile_Header(t *testing.T) {
+	file, err := ioutil.TempFile(*TempDir, "pilosa-fragment-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	bm := roaring.NewFileBitmap(1, 2, 3, 1<<20)
+	if n, err := writeFragmentFile(file, bm); err != nil {
+		t.Fatal(err)
+	} else if fi, err := file.Stat(); err != nil {
+		t.Fatal(err)
+	} else if n != fi.Size() {
+		t.Fatalf("wrote %d bytes, file has %d", n, fi.Size())
+	}
+
+	data, err := ioutil.ReadFile(file.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := FragmentFileBody(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other := roaring.NewFileBitmap()
+	if err := other.UnmarshalBinary(body); err != nil {
+		t.Fatal(err)
+	} else if equal, reason := other.BitwiseEqual(bm); !equal {
+		t.Fatalf("unmarshalled bitmap different: %v", reason)
+	}
+
+	t.Run("ChecksumMismatch", func(t *testing.T) {
+		corrupt := append([]byte(nil), data...)
+		corrupt[len(corrupt)-1] ^= 0xff
+		if _, err := FragmentFileBody(corrupt); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		if _, err := FragmentFileBody(data[:len(data)-1]); err == nil || !strings.Contains(err.Error(), "file truncated") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("NewerVersion", func(t *testing.T) {
+		newer := append([]byte(nil), data...)
+		binary.LittleEndian.PutUint16(newer[4:6], fragmentFileVersion+1)
+		if _, err := FragmentFileBody(newer); err == nil || !strings.Contains(err.Error(), "unsupported file format version") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("ForeignFile", func(t *testing.T) {
+		if _, err := FragmentFileBody([]byte("PK\x03\x04 not a fragment")); err == nil || err.Error() != "not a fragment file" {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("CacheFile", func(t *testing.T) {
+		if _, err := cacheFileBody(data); err != nil {
+			t.Fatalf("expected fragment file to be read as legacy cache file: %v", err)
+		}
+		body := []byte("cache")
+		cache := append(newFileHeader(cacheFileMagic, cacheFileVersion, body).marshal(), body...)
+		if got, err := cacheFileBody(cache); err != nil {
+			t.Fatal(err)
+		} else if !bytes.Equal(got, body) {
+			t.Fatalf("unexpected body: %q", got)
+		}
+	})
+}
+
+// Ensure a fragment file written without a header can be opened, and is
+// rewritten with a header by the next snapshot.
+func TestFragmentFile_Legacy(t *testing.T) {
+	file, err := ioutil.TempFile(*TempDir, "pilosa-fragment-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(file.Name())
+	if _, err := roaring.NewFileBitmap(100).WriteTo(file); err != nil {
+		t.Fatal(err)
+	} else if err := file.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f := newFragment(file.Name(), "i", "f", viewStandard, 0, 0)
+	f.CacheType = DefaultCacheType
+	f.snapshotQueue = newSnapshotQueue(1, 1, nil)
+	if err := f.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer f.Clean(t)
+
+	if ok, err := f.bit(0, 100); err != nil {
+		t.Fatal(err)
+	} else if !ok {
+		t.Fatal("expected bit set in legacy fragment file")
+	}
+	if err := f.Snapshot(); err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadFile(file.Name())
+	if err != nil {
+		t.Fatal(err)
+	} else if !hasFileHeader(data, fragmentFileMagic) {
+		t.Fatal("expected snapshot to write fragment file header")
+	}
+	if err := f.Reopen(); err != nil {
+		t.Fatal(err)
+	} else if ok, err := f.bit(0, 100); err != nil {
+		t.Fatal(err)
+	} else if !ok {
+		t.Fatal("expected bit set after reopening")
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/pilosa/pilosa/v2/logger"
+	"github.com/pilosa/pilosa/v2/stats"
+)
+
+// filePool limits the number of fragment data files held open at once. A
+// fragment's data is mapped from its file, which only needs to stay open to
+// append to the fragment's op log, so once the limit is exceeded the files of
+// the least recently written fragments are closed. They are reopened when the
+// fragments are next written to.
+//
+// Files are closed by a goroutine rather than by the fragment whose file was
+// opened, since it holds its own lock, and taking another fragment's lock
+// could deadlock. The limit may be exceeded briefly as a result.
+//
+// The methods of a nil filePool do nothing.
+type filePool struct {
+	mu       sync.Mutex
+	capacity int
+	lru      *list.List // of *fragment, most recently used first
+	elems    map[*fragment]*list.Element
+
+	evict chan struct{}
+
+	stats  stats.StatsClient
+	logger logger.Logger
+}
+
+func newFilePool(capacity int) *filePool {
+	return &filePool{
+		capacity: capacity,
+		lru:      list.New(),
+		elems:    make(map[*fragment]*list.Element),
+		evict:    make(chan struct{}, 1),
+		stats:    stats.NopStatsClient,
+		logger:   logger.NopLogger,
+	}
+}
+
+// opened records that the file of f was opened.
+func (p *filePool) opened(f *fragment) {
+	if p == nil {
+		return
+	}
+	p.stats.Count("fragmentFileOpens", 1, 1.0)
+	p.used(f)
+}
+
+// used records that the file of f was written to.
+func (p *filePool) used(f *fragment) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if e := p.elems[f]; e != nil {
+		p.lru.MoveToFront(e)
+		return
+	}
+	p.elems[f] = p.lru.PushFront(f)
+	if p.lru.Len() > p.capacity {
+		select {
+		case p.evict <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// closed records that the file of f was closed.
+func (p *filePool) closed(f *fragment) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if e := p.elems[f]; e != nil {
+		p.lru.Remove(e)
+		delete(p.elems, f)
+	}
+}
+
+// len returns the number of files open.
+func (p *filePool) len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.lru.Len()
+}
+
+// run closes the files of the least recently used fragments whenever the
+// pool is over capacity, until closing is closed. This is run in a goroutine.
+func (p *filePool) run(closing <-chan struct{}) {
+	for {
+		select {
+		case <-closing:
+			return
+		case <-p.evict:
+		}
+		p.evictFiles()
+	}
+}
+
+// evictFiles closes files until the pool is within its capacity.
+func (p *filePool) evictFiles() {
+	for {
+		p.mu.Lock()
+		if p.lru.Len() <= p.capacity {
+			p.stats.Gauge("fragmentFilesOpen", float64(p.lru.Len()), 1.0)
+			p.mu.Unlock()
+			return
+		}
+		f := p.lru.Back().Value.(*fragment)
+		p.mu.Unlock()
+
+		if err := f.closeFile(); err != nil {
+			p.logger.Printf("closing fragment file: %s, err=%s", f.path, err)
+		}
+		p.closed(f)
+		p.stats.Count("fragmentFileEvictions", 1, 1.0)
+	}
+}
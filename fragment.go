@@ -30,6 +30,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 	"unsafe"
@@ -74,6 +75,9 @@ const (
 	// cacheExt is the file extension for persisted cache ids.
 	cacheExt = ".cache"
 
+	// statsExt is the file extension for persisted fragment statistics.
+	statsExt = ".stats"
+
 	// tempExt is the file extension for temporary files.
 	tempExt = ".temp"
 
@@ -109,6 +113,7 @@ type fragment struct {
 	// File-backed storage
 	path               string
 	flags              byte // user-defined flags passed to roaring
+	inMemory           bool // no data or cache file; never snapshotted
 	file               *os.File
 	storage            *roaring.Bitmap
 	storageData        []byte
@@ -131,12 +136,22 @@ type fragment struct {
 	// Stats reporting.
 	maxRowID uint64
 
+	// Statistics of the data used to plan queries, as of the last snapshot.
+	// Computed when first needed if they weren't saved.
+	planStats *fragmentStatistics
+
 	// Cache containing full rows (not just counts).
 	rowCache bitmapCache
 
 	// Cached checksums for each block.
 	checksums map[int][]byte
 
+	// Number of writes to the fragment, and the number of writes and the
+	// checksum as of its last anti-entropy sync.
+	writeN         uint64
+	syncedWriteN   uint64
+	syncedChecksum []byte
+
 	// Number of operations performed before performing a snapshot.
 	// This limits the size of fragments on the heap and flushes them to disk
 	// so that they can be mmapped and heap utilization can be kept low.
@@ -156,6 +171,49 @@ type fragment struct {
 	stats stats.StatsClient
 
 	snapshotQueue chan *fragment
+
+	// Limits the number of fragment files held open, if set.
+	filePool *filePool
+
+	// Flushes the file to disk after writes, as set by the fsync policy.
+	fsyncer *fsyncer
+
+	// Single-bit writes arriving within this window of each other are
+	// applied together, if it is positive.
+	groupCommitWindow time.Duration
+	commits           groupCommit
+
+	// Records the changes made to the fragment for change data capture, if
+	// set. The values of BSI views are stored relative to valueBase.
+	changes   *changeLog
+	valueBase int64
+
+	// Time of the last access, in nanoseconds since the epoch. Used to
+	// choose which fragments to release when over the memory limit.
+	// Accessed atomically.
+	lastAccess int64
+
+	// Set to 1 while the fragment is known to its view but its data has not
+	// been loaded, when fragments are opened lazily. Accessed atomically.
+	unopened int32
+
+	// Generation of the fragment's data, replaced on every change. Values are
+	// unique across fragments so readers can detect a recreated fragment.
+	// Accessed atomically.
+	gen uint64
+}
+
+// fragmentGen is the last generation handed out to a fragment.
+var fragmentGen uint64
+
+// newGeneration records that the fragment's data has changed.
+func (f *fragment) newGeneration() {
+	atomic.StoreUint64(&f.gen, atomic.AddUint64(&fragmentGen, 1))
+}
+
+// generation returns the current generation of the fragment's data.
+func (f *fragment) generation() uint64 {
+	return atomic.LoadUint64(&f.gen)
 }
 
 // newFragment returns a new instance of Fragment.
@@ -176,12 +234,16 @@ func newFragment(path, index, field, view string, shard uint64, flags byte) *fra
 		stats: stats.NopStatsClient,
 	}
 	f.snapshotCond = sync.Cond{L: &f.mu}
+	f.newGeneration()
 	return f
 }
 
 // cachePath returns the path to the fragment's cache data.
 func (f *fragment) cachePath() string { return f.path + cacheExt }
 
+// statsPath returns the path to the fragment's statistics file.
+func (f *fragment) statsPath() string { return f.path + statsExt }
+
 // newSnapshotQueue makes a new snapshot queue, of depth N, and spawns a
 // goroutine for it.
 func newSnapshotQueue(n int, w int, l logger.Logger) chan *fragment {
@@ -206,6 +268,11 @@ func snapshotQueueWorker(snapshotQueue chan *fragment, l logger.Logger) {
 // in the future, if this has not already been requested. Call this only when
 // the mutex is held.
 func (f *fragment) enqueueSnapshot() {
+	// In-memory fragments have no data file to snapshot to.
+	if f.inMemory {
+		f.opN = 0
+		return
+	}
 	f.snapshotsRequested++
 	if f.snapshotting {
 		return
@@ -242,7 +309,10 @@ func (f *fragment) enqueueSnapshot() {
 func (f *fragment) Open() error {
 	f.mu.Lock()
 	defer f.mu.Unlock()
+	return f.unprotectedOpen()
+}
 
+func (f *fragment) unprotectedOpen() error {
 	if err := func() error {
 		// Initialize storage in a function so we can close if anything goes wrong.
 		f.Logger.Debugf("open storage for index/field/view/fragment: %s/%s/%s/%d", f.index, f.field, f.view, f.shard)
@@ -256,12 +326,16 @@ func (f *fragment) Open() error {
 			return errors.Wrap(err, "opening cache")
 		}
 
+		f.openStatistics()
+
 		// Clear checksums.
 		f.checksums = make(map[int][]byte)
 
 		// Read last bit to determine max row.
 		f.maxRowID = f.storage.Max() / ShardWidth
 		f.stats.Gauge("rows", float64(f.maxRowID), 1.0)
+		f.touch()
+		atomic.StoreInt32(&f.unopened, 0)
 		return nil
 	}(); err != nil {
 		f.close()
@@ -272,7 +346,29 @@ func (f *fragment) Open() error {
 	return nil
 }
 
+// ensureOpen opens a fragment whose data has not been loaded yet. It does
+// nothing if the fragment is already open.
+func (f *fragment) ensureOpen() error {
+	if f.loaded() {
+		return nil
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.loaded() {
+		return nil
+	}
+	return f.unprotectedOpen()
+}
+
+// loaded returns false if the fragment's data has not been loaded yet.
+func (f *fragment) loaded() bool {
+	return atomic.LoadInt32(&f.unopened) == 0
+}
+
 func (f *fragment) reopen() (mustClose bool, err error) {
+	if f.inMemory {
+		return false, nil
+	}
 	if f.file == nil {
 		// Open the data file to be mmap'd and used as an ops log.
 		f.file, mustClose, err = syswrap.OpenFile(f.path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0666)
@@ -280,6 +376,9 @@ func (f *fragment) reopen() (mustClose bool, err error) {
 			return mustClose, fmt.Errorf("open file: %s", err)
 		}
 		f.storage.OpWriter = f.file
+		f.filePool.opened(f)
+	} else {
+		f.filePool.used(f)
 	}
 	return mustClose, nil
 }
@@ -322,12 +421,19 @@ func (f *fragment) openStorage(unmarshalData bool) error {
 		// unmarshal this data in order to have any.
 		unmarshalData = true
 	}
+	// In-memory storage starts out empty, and is never written to a file.
+	if f.inMemory {
+		f.rowCache = &simpleCache{make(map[uint64]*Row)}
+		f.newGeneration()
+		return nil
+	}
 	// Open the data file to be mmap'd and used as an ops log.
 	file, mustClose, err := syswrap.OpenFile(f.path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0666)
 	if err != nil {
 		return fmt.Errorf("open file: %s", err)
 	}
 	f.file = file
+	f.filePool.opened(f)
 	if mustClose {
 		defer f.safeClose()
 	}
@@ -352,12 +458,16 @@ func (f *fragment) openStorage(unmarshalData bool) error {
 	if err != nil {
 		return errors.Wrap(err, "statting file before")
 	} else if fi.Size() == 0 {
+		var buf bytes.Buffer
+		if _, err := f.storage.WriteTo(&buf); err != nil {
+			return fmt.Errorf("init storage file: %s", err)
+		}
 		bi := bufio.NewWriter(f.file)
-		var err error
-		if _, err = f.storage.WriteTo(bi); err != nil {
+		_, _ = bi.Write(newFileHeader(fragmentFileMagic, fragmentFileVersion, buf.Bytes()).marshal())
+		_, _ = bi.Write(buf.Bytes())
+		if err := bi.Flush(); err != nil {
 			return fmt.Errorf("init storage file: %s", err)
 		}
-		bi.Flush()
 		_, err = f.file.Stat()
 		if err != nil {
 			return errors.Wrap(err, "statting file after")
@@ -365,6 +475,7 @@ func (f *fragment) openStorage(unmarshalData bool) error {
 		// there's nothing here, we're not going to try to unmarshal it.
 		unmarshalData = false
 		f.rowCache = &simpleCache{make(map[uint64]*Row)}
+		f.newGeneration()
 	} else {
 		// Mmap the underlying file so it can be zero copied.
 		data, err = syswrap.Mmap(int(f.file.Fd()), 0, int(fi.Size()), syscall.PROT_READ, syscall.MAP_SHARED)
@@ -401,17 +512,24 @@ func (f *fragment) openStorage(unmarshalData bool) error {
 		}
 		// set the preference for mapping based on whether the data's mmapped
 		f.storage.PreferMapping(newStorageData != nil)
+		// The roaring data follows the file's header.
+		body, err := FragmentFileBody(data)
+		if err != nil {
+			_, _ = f.storage.RemapRoaringStorage(nil)
+			return corruptFragmentError{fmt.Errorf("invalid fragment file: file=%s, err=%s", f.file.Name(), err)}
+		}
 		// so we have a problem here: if this fails, it's unclear whether
 		// *either* or *both* of old and new storage data might be in use.
 		// So we call the thing that should unconditionally unmap both of them...
-		if err := f.storage.UnmarshalBinary(data); err != nil {
+		if err := f.storage.UnmarshalBinary(body); err != nil {
 			_, e2 := f.storage.RemapRoaringStorage(nil)
 			if e2 != nil {
 				return fmt.Errorf("unmarshal storage: file=%s, err=%s, clearing old mapping also failed: %v", f.file.Name(), err, e2)
 			}
-			return fmt.Errorf("unmarshal storage: file=%s, err=%s", f.file.Name(), err)
+			return corruptFragmentError{fmt.Errorf("unmarshal storage: file=%s, err=%s", f.file.Name(), err)}
 		}
 		f.rowCache = &simpleCache{make(map[uint64]*Row)}
+		f.newGeneration()
 		f.ops, f.opN = f.storage.Ops()
 	} else {
 		// we're moving to new storage, so instead of using the OpN
@@ -424,7 +542,11 @@ func (f *fragment) openStorage(unmarshalData bool) error {
 		// are currently mapped. otherwise, it will point them at this
 		// storage (if the containers match).
 		var mappedAny bool
-		mappedAny, lastError = f.storage.RemapRoaringStorage(newStorageData)
+		body := newStorageData
+		if hasFileHeader(body, fragmentFileMagic) {
+			body = body[fileHeaderSize:]
+		}
+		mappedAny, lastError = f.storage.RemapRoaringStorage(body)
 		if oldStorageData != nil {
 			unmapErr := syswrap.Munmap(oldStorageData)
 			if unmapErr != nil {
@@ -459,17 +581,13 @@ func (f *fragment) openStorage(unmarshalData bool) error {
 
 // openCache initializes the cache from row ids persisted to disk.
 func (f *fragment) openCache() error {
-	// Determine cache type from field name.
-	switch f.CacheType {
-	case CacheTypeRanked:
-		f.cache = NewRankCache(f.CacheSize)
-	case CacheTypeLRU:
-		f.cache = newLRUCache(f.CacheSize)
-	case CacheTypeNone:
-		f.cache = globalNopCache
+	c, err := f.newCache()
+	if err != nil {
+		return err
+	}
+	f.cache = c
+	if f.CacheType == CacheTypeNone || f.inMemory {
 		return nil
-	default:
-		return ErrInvalidCacheType
 	}
 
 	// Read cache data from disk.
@@ -483,7 +601,10 @@ func (f *fragment) openCache() error {
 
 	// Unmarshal cache data.
 	var pb internal.Cache
-	if err := proto.Unmarshal(buf, &pb); err != nil {
+	if buf, err = cacheFileBody(buf); err != nil {
+		f.Logger.Printf("invalid cache file, skipping: path=%s, err=%s", path, err)
+		return nil
+	} else if err := proto.Unmarshal(buf, &pb); err != nil {
 		f.Logger.Printf("error unmarshaling cache data, skipping: path=%s, err=%s", path, err)
 		return nil
 	}
@@ -499,6 +620,20 @@ func (f *fragment) openCache() error {
 	return nil
 }
 
+// newCache returns an empty cache of the fragment's cache type.
+func (f *fragment) newCache() (cache, error) {
+	switch f.CacheType {
+	case CacheTypeRanked:
+		return NewRankCache(f.CacheSize), nil
+	case CacheTypeLRU:
+		return newLRUCache(f.CacheSize), nil
+	case CacheTypeNone:
+		return globalNopCache, nil
+	default:
+		return nil, ErrInvalidCacheType
+	}
+}
+
 // Close flushes the underlying storage, closes the file and unlocks it.
 func (f *fragment) Close() error {
 	f.mu.Lock()
@@ -506,6 +641,9 @@ func (f *fragment) Close() error {
 	for f.snapshotting {
 		f.snapshotCond.Wait()
 	}
+	if !f.loaded() {
+		return nil
+	}
 	return f.close()
 }
 
@@ -563,10 +701,23 @@ func (f *fragment) safeClose() error {
 	}
 	f.file = nil
 	f.storage.OpWriter = nil
+	f.filePool.closed(f)
+	f.fsyncer.closed(f)
 
 	return nil
 }
 
+// closeFile closes the data file, if it is open, leaving the storage mapped
+// from it. The file is reopened when the fragment is next written to.
+func (f *fragment) closeFile() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file == nil {
+		return nil
+	}
+	return f.safeClose()
+}
+
 // closeStorage attempts to close storage, including unmapping the old
 // storage if includeMap is true. This would normally make sense if you're
 // expecting to be done using the fragment, or to reload it. But it's also
@@ -605,6 +756,17 @@ func (f *fragment) row(rowID uint64) *Row {
 	return f.unprotectedRow(rowID)
 }
 
+// rowCached is like row, but also returns whether the row was found in the
+// row cache.
+func (f *fragment) rowCached(rowID uint64) (*Row, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if r, ok := f.rowCache.Fetch(rowID); ok && r != nil {
+		return r, true
+	}
+	return f.unprotectedRow(rowID), false
+}
+
 // unprotectedRow returns a row from the row cache if available or from storage
 // (updating the cache).
 func (f *fragment) unprotectedRow(rowID uint64) *Row {
@@ -645,6 +807,11 @@ func (f *fragment) rowFromStorage(rowID uint64) *Row {
 // setBit sets a bit for a given column & row within the fragment.
 // This updates both the on-disk storage and the in-cache bitmap.
 func (f *fragment) setBit(rowID, columnID uint64) (changed bool, err error) {
+	// Writes to mutex fragments also clear bits, so they aren't grouped.
+	if f.groupCommitWindow > 0 && f.mutexVector == nil {
+		return f.setBitGrouped(rowID, columnID)
+	}
+
 	f.mu.Lock()
 	defer f.mu.Unlock()
 	mustClose, err := f.reopen()
@@ -700,9 +867,6 @@ func (f *fragment) unprotectedSetBit(rowID, columnID uint64) (changed bool, err
 	// Invalidate block checksum.
 	delete(f.checksums, int(rowID/HashBlockSize))
 
-	// Increment number of operations until snapshot is required.
-	f.incrementOpN(1)
-
 	// If we're using a cache, update it. Otherwise skip the
 	// possibly-expensive count operation.
 	if f.CacheType != CacheTypeNone {
@@ -712,6 +876,7 @@ func (f *fragment) unprotectedSetBit(rowID, columnID uint64) (changed bool, err
 	// Drop the rowCache entry; it's wrong, and we don't want to force
 	// a new copy if no one's reading it.
 	f.rowCache.Add(rowID, nil)
+	f.newGeneration()
 
 	f.stats.Count("setBit", 1, 0.001)
 
@@ -721,6 +886,16 @@ func (f *fragment) unprotectedSetBit(rowID, columnID uint64) (changed bool, err
 		f.stats.Gauge("rows", float64(f.maxRowID), 1.0)
 	}
 
+	if f.recordsBits() {
+		f.recordChange(ChangeEvent{Type: ChangeTypeSet, RowID: rowID, ColumnID: columnID})
+	}
+
+	// Increment number of operations until snapshot is required. This is
+	// done once the caches reflect the write, since it fails if the write
+	// can't be flushed.
+	if err := f.incrementOpN(1); err != nil {
+		return false, err
+	}
 	return changed, nil
 }
 
@@ -762,9 +937,6 @@ func (f *fragment) unprotectedClearBit(rowID, columnID uint64) (changed bool, er
 	// Invalidate block checksum.
 	delete(f.checksums, int(rowID/HashBlockSize))
 
-	// Increment number of operations until snapshot is required.
-	f.incrementOpN(1)
-
 	// If we're using a cache, update it. Otherwise skip the
 	// possibly-expensive count operation.
 	if f.CacheType != CacheTypeNone {
@@ -774,9 +946,19 @@ func (f *fragment) unprotectedClearBit(rowID, columnID uint64) (changed bool, er
 	// Drop the rowCache entry; it's wrong, and we don't want to force
 	// a new copy if no one's reading it.
 	f.rowCache.Add(rowID, nil)
+	f.newGeneration()
 
 	f.stats.Count("clearBit", 1, 1.0)
 
+	if f.recordsBits() {
+		f.recordChange(ChangeEvent{Type: ChangeTypeClear, RowID: rowID, ColumnID: columnID})
+	}
+
+	// Increment number of operations until snapshot is required, as for
+	// setBit.
+	if err := f.incrementOpN(1); err != nil {
+		return false, err
+	}
 	return changed, nil
 }
 
@@ -812,6 +994,13 @@ func (f *fragment) unprotectedSetRow(row *Row, rowID uint64) (changed bool, err
 
 	// From the given row, get the rowSegment for this shard.
 	seg := row.segment(f.shard)
+	if f.recordsBits() {
+		ev := ChangeEvent{Type: ChangeTypeSetRow, RowID: rowID}
+		if seg != nil {
+			ev.ColumnIDs = seg.Columns()
+		}
+		f.recordChange(ev)
+	}
 	if seg == nil {
 		return changed, nil
 	}
@@ -831,8 +1020,10 @@ func (f *fragment) unprotectedSetRow(row *Row, rowID uint64) (changed bool, err
 
 	// invalidate rowCache for this row.
 	f.rowCache.Add(rowID, nil)
+	f.newGeneration()
 
 	// Snapshot storage.
+	f.writeN++
 	f.enqueueSnapshot()
 	f.stats.Count("setRow", 1, 1.0)
 
@@ -854,6 +1045,33 @@ func (f *fragment) clearRow(rowID uint64) (bool, error) {
 	return f.unprotectedClearRow(rowID)
 }
 
+// clearRows clears every row from start to end, inclusive, within the
+// fragment, and returns the IDs of the rows which had bits set.
+func (f *fragment) clearRows(start, end uint64) ([]uint64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	mustClose, err := f.reopen()
+	if err != nil {
+		return nil, errors.Wrap(err, "reopening")
+	}
+	if mustClose {
+		defer f.safeClose()
+	}
+
+	var rowIDs []uint64
+	if end < math.MaxUint64 {
+		rowIDs = f.unprotectedRowsRange(start, end+1)
+	} else {
+		rowIDs = f.unprotectedRows(start)
+	}
+	for _, rowID := range rowIDs {
+		if _, err := f.unprotectedClearRow(rowID); err != nil {
+			return nil, errors.Wrapf(err, "clearing row %d", rowID)
+		}
+	}
+	return rowIDs, nil
+}
+
 func (f *fragment) unprotectedClearRow(rowID uint64) (changed bool, err error) {
 	changed = false
 
@@ -875,12 +1093,18 @@ func (f *fragment) unprotectedClearRow(rowID uint64) (changed bool, err error) {
 	// Clear the row in cache.
 	f.cache.Add(rowID, 0)
 	f.rowCache.Add(rowID, nil)
+	f.newGeneration()
 
 	// Snapshot storage.
+	f.writeN++
 	f.enqueueSnapshot()
 
 	f.stats.Count("clearRow", 1, 1.0)
 
+	if changed && f.recordsBits() {
+		f.recordChange(ChangeEvent{Type: ChangeTypeClearRow, RowID: rowID})
+	}
+
 	return changed, nil
 }
 
@@ -1037,6 +1261,10 @@ func (f *fragment) setValueBase(columnID uint64, bitDepth uint, value int64, cle
 		}
 	}
 
+	if changed {
+		f.recordChange(ChangeEvent{Type: ChangeTypeValue, ColumnID: columnID, Value: value + f.valueBase, Clear: clear})
+	}
+
 	return changed, nil
 }
 
@@ -1109,6 +1337,11 @@ func (f *fragment) importSetValue(columnID uint64, bitDepth uint, value int64, c
 // sum returns the sum of a given bsiGroup as well as the number of columns involved.
 // A bitmap can be passed in to optionally filter the computed columns.
 func (f *fragment) sum(filter *Row, bitDepth uint) (sum int64, count uint64, err error) {
+	return f.sumParallel(filter, bitDepth, 1)
+}
+
+// sumParallel is like sum, but counts up to concurrency bit planes at once.
+func (f *fragment) sumParallel(filter *Row, bitDepth uint, concurrency int) (sum int64, count uint64, err error) {
 	// Compute count based on the existence row.
 	consider := f.row(bsiExistsBit)
 	if filter != nil {
@@ -1129,14 +1362,18 @@ func (f *fragment) sum(filter *Row, bitDepth uint) (sum int64, count uint64, err
 	//
 	// Execute once for positive numbers and once for negative. Subtract the
 	// negative sum from the positive sum.
-	for i := uint(0); i < bitDepth; i++ {
-		row := f.row(uint64(bsiOffsetBit + i))
+	sums := make([]int64, bitDepth)
+	parallelize(int(bitDepth), concurrency, func(i int) {
+		row := f.row(uint64(bsiOffsetBit + uint(i)))
 
-		psum := int64((1 << i) * row.intersectionCount(prow))
-		nsum := int64((1 << i) * row.intersectionCount(nrow))
+		psum := int64((1 << uint(i)) * row.intersectionCount(prow))
+		nsum := int64((1 << uint(i)) * row.intersectionCount(nrow))
 
 		// Squash to reduce the possibility of overflow.
-		sum += psum - nsum
+		sums[i] = psum - nsum
+	})
+	for _, v := range sums {
+		sum += v
 	}
 
 	return sum, count, nil
@@ -1596,9 +1833,21 @@ func (f *fragment) top(opt topOptions) ([]Pair, error) {
 		maxTanimoto = float64(srcCount*100) / float64(tanimotoThreshold)
 	}
 
+	// When every row must be considered, compute the intersection counts
+	// up front so they can be computed in parallel.
+	var counts []uint64
+	if opt.Src != nil && opt.N == 0 && opt.Concurrency > 1 {
+		counts = make([]uint64, len(pairs))
+		parallelize(len(pairs), opt.Concurrency, func(i int) {
+			if pairs[i].Count > 0 {
+				counts[i] = opt.Src.intersectionCount(f.row(pairs[i].ID))
+			}
+		})
+	}
+
 	// Iterate over rankings and add to results until we have enough.
 	results := &pairHeap{}
-	for _, pair := range pairs {
+	for i, pair := range pairs {
 		rowID, cnt := pair.ID, pair.Count
 
 		// Ignore empty rows.
@@ -1637,7 +1886,9 @@ func (f *fragment) top(opt topOptions) ([]Pair, error) {
 		if opt.N == 0 || results.Len() < opt.N {
 			// Calculate count and append.
 			count := cnt
-			if opt.Src != nil {
+			if counts != nil {
+				count = counts[i]
+			} else if opt.Src != nil {
 				count = opt.Src.intersectionCount(f.row(rowID))
 			}
 			if count == 0 {
@@ -1701,6 +1952,22 @@ func (f *fragment) top(opt topOptions) ([]Pair, error) {
 	return r, nil
 }
 
+// cachedCounts returns the count of every row in the fragment's cache. Unlike
+// the cache's ranking, which is only recalculated periodically, the counts are
+// current.
+func (f *fragment) cachedCounts() []Pair {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ids := f.cache.IDs()
+	pairs := make([]Pair, 0, len(ids))
+	for _, id := range ids {
+		if n := f.cache.Get(id); n > 0 {
+			pairs = append(pairs, Pair{ID: id, Count: n})
+		}
+	}
+	return pairs
+}
+
 func (f *fragment) topBitmapPairs(rowIDs []uint64) []bitmapPair {
 	// Don't retrieve from storage if CacheTypeNone.
 	if f.CacheType == CacheTypeNone {
@@ -1739,6 +2006,25 @@ func (f *fragment) topBitmapPairs(rowIDs []uint64) []bitmapPair {
 	return pairs
 }
 
+// rowCounts returns the counts of the given rows, read from storage, which
+// are at least minThreshold, sorted by count. If src is set then the counts
+// are of the columns in src.
+func (f *fragment) rowCounts(rowIDs []uint64, src *Row, minThreshold uint64) []Pair {
+	pairs := make([]Pair, 0, len(rowIDs))
+	for _, rowID := range rowIDs {
+		row := f.row(rowID)
+		count := row.Count()
+		if src != nil {
+			count = src.intersectionCount(row)
+		}
+		if count > 0 && count >= minThreshold {
+			pairs = append(pairs, Pair{ID: rowID, Count: count})
+		}
+	}
+	sort.Sort(Pairs(pairs))
+	return pairs
+}
+
 // topOptions represents options passed into the Top() function.
 type topOptions struct {
 	// Number of rows to return.
@@ -1755,6 +2041,9 @@ type topOptions struct {
 	FilterName        string
 	FilterValues      []interface{}
 	TanimotoThreshold uint64
+
+	// Maximum number of rows to intersect with Src at once.
+	Concurrency int
 }
 
 // Checksum returns a checksum for the entire fragment.
@@ -1866,6 +2155,59 @@ func (f *fragment) blockData(id int) (rowIDs, columnIDs []uint64) {
 	return rowIDs, columnIDs
 }
 
+// blockContainerRange returns the keys of the first container of a block, and
+// of the first container after it.
+func blockContainerRange(id int) (start, end uint64) {
+	return (uint64(id) * HashBlockSize * ShardWidth) >> 16, (uint64(id+1) * HashBlockSize * ShardWidth) >> 16
+}
+
+// containerChecksums returns the checksums of each of the block's containers
+// which contain data, ordered by key.
+func (f *fragment) containerChecksums(id int) []ContainerChecksum {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var a []ContainerChecksum
+	h := newBlockHasher()
+	start, end := blockContainerRange(id)
+	citer, _ := f.storage.Containers.Iterator(start)
+	for citer.Next() {
+		key, c := citer.Value()
+		if key >= end {
+			break
+		} else if c.N() == 0 {
+			continue
+		}
+		h.Reset()
+		f.storage.ForEachRange(key<<16, (key+1)<<16, h.WriteValue)
+		a = append(a, ContainerChecksum{Key: key, Checksum: h.Sum()})
+	}
+	return a
+}
+
+// containerData returns the row & column ID pairs of the bits in the block's
+// containers with the given keys.
+func (f *fragment) containerData(id int, keys []uint64) (rowIDs, columnIDs []uint64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ps := f.unprotectedContainerData(id, keys)
+	return ps.rowIDs, ps.columnIDs
+}
+
+func (f *fragment) unprotectedContainerData(id int, keys []uint64) (ps pairSet) {
+	start, end := blockContainerRange(id)
+	for _, key := range keys {
+		if key < start || key >= end {
+			continue
+		}
+		f.storage.ForEachRange(key<<16, (key+1)<<16, func(i uint64) {
+			ps.rowIDs = append(ps.rowIDs, i/ShardWidth)
+			ps.columnIDs = append(ps.columnIDs, i%ShardWidth)
+		})
+	}
+	return ps
+}
+
 // mergeBlock compares the block's bits and computes a diff with another set of block bits.
 // The state of a bit is determined by consensus from all blocks being considered.
 //
@@ -1882,7 +2224,46 @@ func (f *fragment) mergeBlock(id int, data []pairSet) (sets, clears []pairSet, e
 
 	f.mu.Lock()
 	defer f.mu.Unlock()
+	mustClose, err := f.reopen()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "reopening")
+	}
+	if mustClose {
+		defer f.safeClose()
+	}
+	return f.unprotectedMergeBlock(id, newRoaringIterator(f.storage.Iterator()), data)
+}
+
+// mergeBlockContainers is like mergeBlock, but only compares the block's
+// containers with the given keys, and data only contains the bits in those
+// containers.
+func (f *fragment) mergeBlockContainers(id int, keys []uint64, data []pairSet) (sets, clears []pairSet, err error) {
+	for i := range data {
+		if len(data[i].rowIDs) != len(data[i].columnIDs) {
+			return nil, nil, fmt.Errorf("pair set mismatch(idx=%d): %d != %d", i, len(data[i].rowIDs), len(data[i].columnIDs))
+		}
+	}
+
+	// Container keys are sorted so that their bits are in order.
+	keys = append([]uint64(nil), keys...)
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	mustClose, err := f.reopen()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "reopening")
+	}
+	if mustClose {
+		defer f.safeClose()
+	}
+	local := f.unprotectedContainerData(id, keys)
+	return f.unprotectedMergeBlock(id, newSliceIterator(local.rowIDs, local.columnIDs), data)
+}
 
+// unprotectedMergeBlock merges the block's bits from the local iterator with
+// the bits of the other blocks in data, as described by mergeBlock.
+func (f *fragment) unprotectedMergeBlock(id int, local iterator, data []pairSet) (sets, clears []pairSet, err error) {
 	// Track sets and clears for all blocks (including local).
 	sets = make([]pairSet, len(data)+1)
 	clears = make([]pairSet, len(data)+1)
@@ -1893,11 +2274,7 @@ func (f *fragment) mergeBlock(id int, data []pairSet) (sets, clears []pairSet, e
 
 	// Create buffered iterator for local block.
 	itrs := make([]*bufIterator, 1, len(data)+1)
-	itrs[0] = newBufIterator(
-		newLimitIterator(
-			newRoaringIterator(f.storage.Iterator()), maxRowID, maxColumnID,
-		),
-	)
+	itrs[0] = newBufIterator(newLimitIterator(local, maxRowID, maxColumnID))
 
 	// Append buffered iterators for each incoming block.
 	for i := range data {
@@ -2059,24 +2436,23 @@ func (f *fragment) importPositions(set, clear []uint64, rowSet map[uint64]struct
 		defer f.safeClose()
 	}
 
+	var setN, clearN int
 	if len(set) > 0 {
 		f.stats.Count("ImportingN", int64(len(set)), 1)
-		changedN, err := f.storage.AddN(set...) // TODO benchmark Add/RemoveN behavior with sorted/unsorted positions
+		setN, err = f.storage.AddN(set...) // TODO benchmark Add/RemoveN behavior with sorted/unsorted positions
 		if err != nil {
 			return errors.Wrap(err, "adding positions")
 		}
-		f.stats.Count("ImportedN", int64(changedN), 1)
-		f.incrementOpN(changedN)
+		f.stats.Count("ImportedN", int64(setN), 1)
 	}
 
 	if len(clear) > 0 {
 		f.stats.Count("ClearingN", int64(len(clear)), 1)
-		changedN, err := f.storage.RemoveN(clear...)
+		clearN, err = f.storage.RemoveN(clear...)
 		if err != nil {
 			return errors.Wrap(err, "clearing positions")
 		}
-		f.stats.Count("ClearedN", int64(changedN), 1)
-		f.incrementOpN(changedN)
+		f.stats.Count("ClearedN", int64(clearN), 1)
 	}
 
 	// Update cache counts for all affected rows.
@@ -2090,13 +2466,21 @@ func (f *fragment) importPositions(set, clear []uint64, rowSet map[uint64]struct
 		}
 
 		f.rowCache.Add(rowID, nil)
+		f.newGeneration()
 	}
 
 	if f.CacheType != CacheTypeNone {
 		f.cache.Recalculate()
 	}
 
-	return nil
+	f.recordPositions(set, clear)
+
+	// Count the operations once the caches reflect them, since this fails
+	// if they can't be flushed.
+	if err := f.incrementOpN(setN); err != nil {
+		return err
+	}
+	return f.incrementOpN(clearN)
 }
 
 // bulkImportMutex performs a bulk import on a fragment while ensuring
@@ -2197,6 +2581,7 @@ func (f *fragment) importValueSmallWrite(columnIDs []uint64, values []int64, bit
 
 	// Reset the rowCache.
 	f.rowCache = &simpleCache{make(map[uint64]*Row)}
+	f.newGeneration()
 
 	return nil
 }
@@ -2205,6 +2590,13 @@ func (f *fragment) importValueSmallWrite(columnIDs []uint64, values []int64, bit
 func (f *fragment) importValue(columnIDs []uint64, values []int64, bitDepth uint, clear bool) error {
 	f.mu.Lock()
 	defer f.mu.Unlock()
+	mustClose, err := f.reopen()
+	if err != nil {
+		return errors.Wrap(err, "reopening")
+	}
+	if mustClose {
+		defer f.safeClose()
+	}
 
 	// Verify that there are an equal number of column ids and values.
 	if len(columnIDs) != len(values) {
@@ -2212,7 +2604,11 @@ func (f *fragment) importValue(columnIDs []uint64, values []int64, bitDepth uint
 	}
 
 	if len(columnIDs)*int(bitDepth+1)+f.opN < f.MaxOpN {
-		return errors.Wrap(f.importValueSmallWrite(columnIDs, values, bitDepth, clear), "import small write")
+		if err := f.importValueSmallWrite(columnIDs, values, bitDepth, clear); err != nil {
+			return errors.Wrap(err, "import small write")
+		}
+		f.recordValues(columnIDs, values, clear)
+		return nil
 	}
 
 	// Process every value.
@@ -2234,11 +2630,13 @@ func (f *fragment) importValue(columnIDs []uint64, values []int64, bitDepth uint
 		_ = f.openStorage(true)
 		return err
 	}
-	// We don't actually care, except we want our stats to be accurate.
-	f.incrementOpN(totalChanges)
+	// We don't actually care, except we want our stats to be accurate. A
+	// failure to flush is returned once the values are snapshotted.
+	syncErr := f.incrementOpN(totalChanges)
 
 	// Reset the rowCache.
 	f.rowCache = &simpleCache{make(map[uint64]*Row)}
+	f.newGeneration()
 
 	// in theory, this should probably have happened anyway, but if enough
 	// of the bits matched existing bits, we'll be under our opN estimate, and
@@ -2246,7 +2644,9 @@ func (f *fragment) importValue(columnIDs []uint64, values []int64, bitDepth uint
 	f.enqueueSnapshot()
 	f.unprotectedAwaitSnapshot()
 
-	return nil
+	f.recordValues(columnIDs, values, clear)
+
+	return syncErr
 }
 
 // importRoaring imports from the official roaring data format defined at
@@ -2260,6 +2660,21 @@ func (f *fragment) importRoaring(ctx context.Context, data []byte, clear bool) e
 	f.mu.Lock()
 	defer f.mu.Unlock()
 	span.Finish()
+	mustClose, err := f.reopen()
+	if err != nil {
+		return errors.Wrap(err, "reopening")
+	}
+	if mustClose {
+		defer f.safeClose()
+	}
+	// Decode the bits to record before the import, which may change the data.
+	var positions []uint64
+	if f.changes != nil {
+		if positions, err = roaringPositions(data); err != nil {
+			return errors.Wrap(err, "decoding bits")
+		}
+	}
+
 	span, ctx = tracing.StartSpanFromContext(ctx, "importRoaring.ImportRoaringBits")
 	changed, rowSet, err := f.storage.ImportRoaringBits(data, clear, true, rowSize)
 	span.Finish()
@@ -2275,6 +2690,7 @@ func (f *fragment) importRoaring(ctx context.Context, data []byte, clear bool) e
 			continue
 		}
 		f.rowCache.Add(rowID, nil)
+		f.newGeneration()
 		if updateCache {
 			anyChanged = true
 			f.cache.BulkAdd(rowID, f.cache.Get(rowID)+uint64(changes))
@@ -2285,23 +2701,31 @@ func (f *fragment) importRoaring(ctx context.Context, data []byte, clear bool) e
 		f.cache.Recalculate()
 	}
 
+	if changed > 0 {
+		rowIDs, columnIDs := f.positionBits(positions)
+		f.recordChange(ChangeEvent{Type: ChangeTypeImportRoaring, RowIDs: rowIDs, ColumnIDs: columnIDs, Clear: clear})
+	}
+
 	span, _ = tracing.StartSpanFromContext(ctx, "importRoaring.incrementOpN")
-	f.incrementOpN(changed)
-	span.Finish()
-	return nil
+	defer span.Finish()
+	return f.incrementOpN(changed)
 }
 
 // incrementOpN increase the operation count by one.
 // If the count exceeds the maximum allowed then a snapshot is performed.
-func (f *fragment) incrementOpN(changed int) {
+// The op log is flushed as the fsync policy requires, and an error flushing
+// it is returned so that the write fails.
+func (f *fragment) incrementOpN(changed int) error {
 	if changed <= 0 {
-		return
+		return nil
 	}
 	f.opN += changed
 	f.ops++
+	f.writeN++
 	if f.opN > f.MaxOpN {
 		f.enqueueSnapshot()
 	}
+	return f.fsyncer.wrote(f)
 }
 
 // Snapshot writes the storage bitmap to disk and reopens it. This may
@@ -2314,6 +2738,86 @@ func (f *fragment) Snapshot() error {
 	return f.snapshot()
 }
 
+// writeCount returns the number of writes to the fragment since it was
+// opened.
+func (f *fragment) writeCount() uint64 {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.writeN
+}
+
+// markSynced records that the fragment was synced with its replicas after
+// writeN writes, and that its checksum after the sync was checksum.
+func (f *fragment) markSynced(writeN uint64, checksum []byte) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.syncedWriteN, f.syncedChecksum = writeN, checksum
+}
+
+// dirty returns true if the fragment may differ from its replicas as far as
+// it knows: it has never been synced, it has been written to since it was
+// last synced, or its checksum no longer matches the one after that sync.
+func (f *fragment) dirty() bool {
+	f.mu.RLock()
+	writeN, syncedWriteN, checksum := f.writeN, f.syncedWriteN, f.syncedChecksum
+	f.mu.RUnlock()
+	if checksum == nil || writeN != syncedWriteN {
+		return true
+	}
+	return !bytes.Equal(f.Checksum(), checksum)
+}
+
+// touch records an access to the fragment.
+func (f *fragment) touch() {
+	atomic.StoreInt64(&f.lastAccess, time.Now().UnixNano())
+}
+
+// lastAccessed returns the time of the last access to the fragment.
+func (f *fragment) lastAccessed() time.Time {
+	return time.Unix(0, atomic.LoadInt64(&f.lastAccess))
+}
+
+// heapSize returns the approximate number of bytes of heap memory used by
+// the fragment's storage and row cache. Storage mapped from the data file is
+// not included.
+func (f *fragment) heapSize() int {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.unprotectedHeapSize()
+}
+
+func (f *fragment) unprotectedHeapSize() int {
+	if f.storage == nil || f.rowCache == nil {
+		return 0
+	}
+	return f.storage.HeapSize() + f.rowCache.Size()
+}
+
+// release frees the heap memory held by the fragment. The row cache is
+// cleared and, if any containers have been modified since the data file was
+// last written, the fragment is snapshotted so that its storage is mapped
+// from the data file again. Both are reloaded from disk as they are next
+// accessed. Returns the number of bytes released.
+func (f *fragment) release() (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// A queued snapshot will release the storage anyway. The storage of an
+	// in-memory fragment cannot be released.
+	if f.snapshotting || f.storage == nil || f.inMemory {
+		return 0, nil
+	}
+
+	before := f.unprotectedHeapSize()
+	f.rowCache = &simpleCache{make(map[uint64]*Row)}
+	if f.storage.HeapSize() > 0 {
+		if err := f.snapshot(); err != nil {
+			return 0, errors.Wrap(err, "snapshotting")
+		}
+	}
+	return before - f.unprotectedHeapSize(), nil
+}
+
 func track(start time.Time, message string, stats stats.StatsClient, logger logger.Logger) {
 	elapsed := time.Since(start)
 	logger.Printf("%s took %s", message, elapsed)
@@ -2335,6 +2839,9 @@ func (f *fragment) protectedSnapshot(fromQueue bool) error {
 // snapshot does the actual snapshot operation. it does not check or care
 // about f.snapshotting.
 func (f *fragment) snapshot() error {
+	if f.inMemory {
+		return nil
+	}
 	f.totalOpN += int64(f.opN)
 	f.totalOps += int64(f.ops)
 	f.snapshotsTaken++
@@ -2358,15 +2865,10 @@ func unprotectedWriteToFragment(f *fragment, bm *roaring.Bitmap) (n int64, err e
 	defer file.Close()
 
 	// Write storage to snapshot.
-	bw := bufio.NewWriter(file)
-	if n, err = bm.WriteTo(bw); err != nil {
+	if n, err = writeFragmentFile(file, bm); err != nil {
 		return n, fmt.Errorf("snapshot write to: %s", err)
 	}
 
-	if err := bw.Flush(); err != nil {
-		return n, fmt.Errorf("flush: %s", err)
-	}
-
 	// Close current storage.
 	if err := f.closeStorage(false); err != nil {
 		return n, fmt.Errorf("close storage: %s", err)
@@ -2385,6 +2887,17 @@ func unprotectedWriteToFragment(f *fragment, bm *roaring.Bitmap) (n int64, err e
 	if err := f.openStorage(false); err != nil {
 		return n, fmt.Errorf("open storage: %s", err)
 	}
+	if err := f.fsyncer.wrote(f); err != nil {
+		return n, fmt.Errorf("sync snapshot: %s", err)
+	}
+
+	// Save the statistics of the data as written, since writing it may
+	// convert its containers to runs.
+	stats := newFragmentStatistics(f.storage)
+	f.planStats = &stats
+	if err := f.writeStatistics(stats); err != nil {
+		f.Logger.Printf("writing fragment statistics: path=%s, err=%s", f.statsPath(), err)
+	}
 
 	// Reset operation count.
 	f.opN = 0
@@ -2399,6 +2912,75 @@ func (f *fragment) RecalculateCache() {
 	f.mu.Unlock()
 }
 
+// cacheWarmupRows is the number of top ranked rows of a fragment which are
+// loaded when its cache is warmed.
+const cacheWarmupRows = 100
+
+// warmCache prepares the fragment for TopN() queries after it is opened. The
+// rank cache is rebuilt from storage if it was not saved, its rankings are
+// computed, and the rows at the top of them are loaded into the row cache.
+func (f *fragment) warmCache() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.CacheType == CacheTypeNone || f.storage == nil {
+		return nil
+	}
+	if f.cache.Len() == 0 && f.storage.Any() {
+		for _, rowID := range f.unprotectedRows(0) {
+			f.cache.BulkAdd(rowID, f.storage.CountRange(rowID*ShardWidth, (rowID+1)*ShardWidth))
+		}
+	}
+	f.cache.Recalculate()
+
+	for i, pair := range f.cache.Top() {
+		if i >= cacheWarmupRows {
+			break
+		}
+		// Counting the row reads its containers, which pages in the
+		// parts of the data file it is mapped from.
+		f.unprotectedRow(pair.ID).Count()
+	}
+	return nil
+}
+
+// rebuildCaches discards the row cache and block checksums, and rebuilds
+// the rank cache from storage rather than from the saved cache file.
+func (f *fragment) rebuildCaches() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	c, err := f.newCache()
+	if err != nil {
+		return err
+	}
+	f.cache = c
+	f.rowCache = &simpleCache{make(map[uint64]*Row)}
+	f.newGeneration()
+	f.checksums = make(map[int][]byte)
+	if f.CacheType == CacheTypeNone {
+		return nil
+	}
+
+	for _, rowID := range f.unprotectedRows(0) {
+		f.cache.BulkAdd(rowID, f.storage.CountRange(rowID*ShardWidth, (rowID+1)*ShardWidth))
+	}
+	f.cache.Recalculate()
+	return f.flushCache()
+}
+
+// columns returns a row containing every column with a bit set in any row.
+func (f *fragment) columns() *Row {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	row := NewRow()
+	for _, rowID := range f.unprotectedRows(0) {
+		row = row.Union(f.rowFromStorage(rowID))
+	}
+	return row
+}
+
 // FlushCache writes the cache data to disk.
 func (f *fragment) FlushCache() error {
 	f.mu.Lock()
@@ -2411,7 +2993,7 @@ func (f *fragment) flushCache() error {
 		return nil
 	}
 
-	if f.CacheType == CacheTypeNone {
+	if f.CacheType == CacheTypeNone || f.inMemory {
 		return nil
 	}
 
@@ -2425,6 +3007,7 @@ func (f *fragment) flushCache() error {
 	}
 
 	// Write to disk.
+	buf = append(newFileHeader(cacheFileMagic, cacheFileVersion, buf).marshal(), buf...)
 	if err := ioutil.WriteFile(f.cachePath(), buf, 0666); err != nil {
 		return errors.Wrap(err, "writing")
 	}
@@ -2451,6 +3034,10 @@ func (f *fragment) WriteTo(w io.Writer) (n int64, err error) {
 }
 
 func (f *fragment) writeStorageToArchive(tw *tar.Writer) error {
+	if f.inMemory {
+		return f.writeMemStorageToArchive(tw)
+	}
+
 	// Open separate file descriptor to read from.
 	file, err := os.Open(f.path)
 	if err != nil {
@@ -2476,6 +3063,15 @@ func (f *fragment) writeStorageToArchive(tw *tar.Writer) error {
 		return err
 	}
 
+	// Archives contain the data without the file's header, which is how
+	// nodes which predate it expect them.
+	hdr := make([]byte, fileHeaderSize)
+	if _, err := io.ReadFull(file, hdr); err == nil && hasFileHeader(hdr, fragmentFileMagic) {
+		sz -= fileHeaderSize
+	} else if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return errors.Wrap(err, "seeking")
+	}
+
 	// Write archive header.
 	if err := tw.WriteHeader(&tar.Header{
 		Name:    "data",
@@ -2494,16 +3090,48 @@ func (f *fragment) writeStorageToArchive(tw *tar.Writer) error {
 	return nil
 }
 
+// writeMemStorageToArchive writes the storage of an in-memory fragment to an
+// archive, in the format of a data file without its header.
+func (f *fragment) writeMemStorageToArchive(tw *tar.Writer) error {
+	var buf bytes.Buffer
+	f.mu.Lock()
+	_, err := f.storage.WriteTo(&buf)
+	f.mu.Unlock()
+	if err != nil {
+		return errors.Wrap(err, "writing storage")
+	}
+
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    "data",
+		Mode:    0600,
+		Size:    int64(buf.Len()),
+		ModTime: time.Now(),
+	}); err != nil {
+		return errors.Wrap(err, "writing header")
+	}
+	if _, err := buf.WriteTo(tw); err != nil {
+		return errors.Wrap(err, "copying")
+	}
+	return nil
+}
+
 func (f *fragment) writeCacheToArchive(tw *tar.Writer) error {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
+	// In-memory fragments rebuild their cache from the archived data.
+	if f.inMemory {
+		return nil
+	}
+
 	// Read cache into buffer.
 	buf, err := ioutil.ReadFile(f.cachePath())
 	if os.IsNotExist(err) {
 		return nil
 	} else if err != nil {
 		return errors.Wrap(err, "reading cache")
+	} else if buf, err = cacheFileBody(buf); err != nil {
+		return errors.Wrap(err, "reading cache")
 	}
 
 	// Write archive header.
@@ -2557,6 +3185,10 @@ func (f *fragment) ReadFrom(r io.Reader) (n int64, err error) {
 }
 
 func (f *fragment) readStorageFromArchive(r io.Reader) error {
+	if f.inMemory {
+		return f.readMemStorageFromArchive(r)
+	}
+
 	// Create a temporary file to copy into.
 	path := f.path + copyExt
 	file, err := os.Create(path)
@@ -2579,6 +3211,8 @@ func (f *fragment) readStorageFromArchive(r io.Reader) error {
 	if err := os.Rename(path, f.path); err != nil {
 		return errors.Wrap(err, "renaming")
 	}
+	f.planStats = nil
+	_ = os.Remove(f.statsPath())
 
 	// Reopen storage.
 	if err := f.openStorage(true); err != nil {
@@ -2588,7 +3222,46 @@ func (f *fragment) readStorageFromArchive(r io.Reader) error {
 	return nil
 }
 
+// readMemStorageFromArchive replaces the storage of an in-memory fragment
+// with the data in an archive, and rebuilds its rank cache from it.
+func (f *fragment) readMemStorageFromArchive(r io.Reader) error {
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		return errors.Wrap(err, "reading")
+	}
+	storage := roaring.NewFileBitmap()
+	if err := storage.UnmarshalBinary(buf); err != nil {
+		return errors.Wrap(err, "unmarshalling storage")
+	}
+	storage.Flags = f.flags
+	f.storage = storage
+	f.planStats = nil
+	f.rowCache = &simpleCache{make(map[uint64]*Row)}
+	f.newGeneration()
+	f.checksums = make(map[int][]byte)
+	f.maxRowID = f.storage.Max() / ShardWidth
+
+	c, err := f.newCache()
+	if err != nil {
+		return err
+	}
+	f.cache = c
+	if f.CacheType != CacheTypeNone {
+		for _, rowID := range f.unprotectedRows(0) {
+			f.cache.BulkAdd(rowID, f.storage.CountRange(rowID*ShardWidth, (rowID+1)*ShardWidth))
+		}
+		f.cache.Recalculate()
+	}
+	return nil
+}
+
 func (f *fragment) readCacheFromArchive(r io.Reader) error {
+	// In-memory fragments rebuilt their cache along with their storage.
+	if f.inMemory {
+		_, err := io.Copy(ioutil.Discard, r)
+		return errors.Wrap(err, "reading")
+	}
+
 	// Slurp data from reader and write to disk.
 	buf, err := ioutil.ReadAll(r)
 	if err != nil {
@@ -2685,6 +3358,51 @@ func (f *fragment) rows(start uint64, filters ...rowFilter) []uint64 {
 
 // unprotectedRows calls rows without grabbing the mutex.
 func (f *fragment) unprotectedRows(start uint64, filters ...rowFilter) []uint64 {
+	return f.unprotectedRowsRange(start, math.MaxUint64, filters...)
+}
+
+// rowsParallel is like rows, but splits the rows into up to concurrency
+// ranges which are scanned at once. Because each range is filtered
+// independently, filters must not keep state between calls, so
+// filterWithLimit and filterWithRows may not be used.
+func (f *fragment) rowsParallel(start uint64, concurrency int, filters ...rowFilter) []uint64 {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if concurrency <= 1 || !f.storage.Any() {
+		return f.unprotectedRows(start, filters...)
+	}
+	end := f.storage.Max()/ShardWidth + 1
+	if start >= end {
+		return []uint64{}
+	}
+
+	// Split the row range evenly between goroutines.
+	n := end - start
+	if uint64(concurrency) > n {
+		concurrency = int(n)
+	}
+	step := (n + uint64(concurrency) - 1) / uint64(concurrency)
+	results := make([][]uint64, concurrency)
+	parallelize(concurrency, concurrency, func(i int) {
+		lo := start + uint64(i)*step
+		hi := lo + step
+		if hi > end {
+			hi = end
+		}
+		results[i] = f.unprotectedRowsRange(lo, hi, filters...)
+	})
+
+	rows := make([]uint64, 0)
+	for _, a := range results {
+		rows = append(rows, a...)
+	}
+	return rows
+}
+
+// unprotectedRowsRange returns the rows in [start, end) which pass filters,
+// as described by rows.
+func (f *fragment) unprotectedRowsRange(start, end uint64, filters ...rowFilter) []uint64 {
 	startKey := rowToKey(start)
 	i, _ := f.storage.Containers.Iterator(startKey)
 	rows := make([]uint64, 0)
@@ -2696,6 +3414,9 @@ func (f *fragment) unprotectedRows(start uint64, filters ...rowFilter) []uint64
 
 		// virtual row for the current container
 		vRow := key >> shardVsContainerExponent
+		if vRow >= end {
+			break
+		}
 
 		// skip dups
 		if vRow == lastRow {
@@ -2723,6 +3444,37 @@ func (f *fragment) unprotectedRows(start uint64, filters ...rowFilter) []uint64
 	return rows
 }
 
+// parallelize calls fn for each i in [0, n), using up to concurrency
+// goroutines, and waits for them to complete.
+func parallelize(n, concurrency int, fn func(i int)) {
+	if concurrency <= 1 || n <= 1 {
+		for i := 0; i < n; i++ {
+			fn(i)
+		}
+		return
+	}
+	if concurrency > n {
+		concurrency = n
+	}
+
+	var wg sync.WaitGroup
+	next := make(chan int, n)
+	for i := 0; i < n; i++ {
+		next <- i
+	}
+	close(next)
+	for j := 0; j < concurrency; j++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				fn(i)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
 // upgradeRoaringBSIv2 upgrades a fragment that contains old BSI formatting
 // to a new BSI format (v2). The new format moves the "exists" bit to the
 // beginning & adds a negative sign bit.
@@ -2811,6 +3563,13 @@ type FragmentBlock struct {
 	Checksum []byte `json:"checksum"`
 }
 
+// ContainerChecksum represents the checksum of a single roaring container in
+// a fragment block, identified by its key.
+type ContainerChecksum struct {
+	Key      uint64 `json:"key"`
+	Checksum []byte `json:"checksum"`
+}
+
 type blockHasher struct {
 	blockID int
 	buf     [8]byte
@@ -2836,6 +3595,11 @@ func (h *blockHasher) WriteValue(v uint64) {
 	_, _ = h.hash.Write(h.buf[:])
 }
 
+// maxContainerSyncRatio is the largest fraction of the containers in a block
+// which may differ between replicas for the block to be synced by exchanging
+// only those containers. Past this, the whole block is exchanged.
+const maxContainerSyncRatio = 0.5
+
 // fragmentSyncer syncs a local fragment to one on a remote host.
 type fragmentSyncer struct {
 	Fragment *fragment
@@ -2879,7 +3643,7 @@ func (s *fragmentSyncer) syncFragment() error {
 		}
 
 		// Retrieve remote blocks.
-		blocks, err := s.Cluster.InternalClient.FragmentBlocks(ctx, &node.URI, s.Fragment.index, s.Fragment.field, s.Fragment.view, s.Fragment.shard)
+		blocks, err := s.Cluster.InternalClient.FragmentBlocks(ctx, node.clusterURI(), s.Fragment.index, s.Fragment.field, s.Fragment.view, s.Fragment.shard)
 		if err != nil && err != ErrFragmentNotFound {
 			return errors.Wrap(err, "getting blocks")
 		}
@@ -2944,6 +3708,13 @@ func (s *fragmentSyncer) syncBlock(id int) error {
 
 	f := s.Fragment
 
+	// Exchange only the containers which differ, if possible.
+	if synced, err := s.syncBlockContainers(ctx, id); err != nil {
+		return errors.Wrap(err, "syncing containers")
+	} else if synced {
+		return nil
+	}
+
 	// Read pairs from each remote block.
 	var uris []*URI
 	var pairSets []pairSet
@@ -2957,11 +3728,11 @@ func (s *fragmentSyncer) syncBlock(id int) error {
 			return nil
 		}
 
-		uri := &node.URI
+		uri := node.clusterURI()
 		uris = append(uris, uri)
 
 		// Only sync the standard block.
-		rowIDs, columnIDs, err := s.Cluster.InternalClient.BlockData(ctx, &node.URI, f.index, f.field, f.view, f.shard, id)
+		rowIDs, columnIDs, err := s.Cluster.InternalClient.BlockData(ctx, node.clusterURI(), f.index, f.field, f.view, f.shard, id)
 		if err != nil {
 			return errors.Wrap(err, "getting block")
 		}
@@ -2983,6 +3754,98 @@ func (s *fragmentSyncer) syncBlock(id int) error {
 		return errors.Wrap(err, "merging")
 	}
 
+	return s.writeBlockDiffs(ctx, uris, sets, clears)
+}
+
+// syncBlockContainers syncs a block by comparing the checksums of its
+// containers on each replica, and exchanging the data of only those
+// containers which differ. It returns false without syncing if any replica
+// doesn't support this, or if more than maxContainerSyncRatio of the
+// containers differ, in which case the whole block should be synced instead.
+func (s *fragmentSyncer) syncBlockContainers(ctx context.Context, id int) (bool, error) {
+	f := s.Fragment
+
+	var uris []*URI
+	for _, node := range s.Cluster.shardNodes(f.index, f.shard) {
+		if s.Node.ID == node.ID {
+			continue
+		} else if !node.HasFeatures(FeatureContainerSync) {
+			return false, nil
+		}
+		uris = append(uris, node.clusterURI())
+	}
+
+	// Collect the checksums of each container on each replica, with the
+	// local one first.
+	checksums := make(map[uint64][][]byte)
+	add := func(i int, a []ContainerChecksum) {
+		for _, c := range a {
+			if checksums[c.Key] == nil {
+				checksums[c.Key] = make([][]byte, len(uris)+1)
+			}
+			checksums[c.Key][i] = c.Checksum
+		}
+	}
+	add(0, f.containerChecksums(id))
+	for i, uri := range uris {
+		// Verify sync is not prematurely closing.
+		if s.isClosing() {
+			return true, nil
+		}
+
+		a, err := s.Cluster.InternalClient.BlockChecksums(ctx, uri, f.index, f.field, f.view, f.shard, id)
+		if err != nil {
+			return false, errors.Wrap(err, "getting container checksums")
+		}
+		add(i+1, a)
+	}
+
+	// Find the containers which differ.
+	var keys []uint64
+	for key, a := range checksums {
+		if !byteSlicesEqual(a) {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return true, nil
+	} else if float64(len(keys)) > maxContainerSyncRatio*float64(len(checksums)) {
+		return false, nil
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	// Read pairs from the differing containers of each remote block.
+	pairSets := make([]pairSet, 0, len(uris))
+	for _, uri := range uris {
+		// Verify sync is not prematurely closing.
+		if s.isClosing() {
+			return true, nil
+		}
+
+		rowIDs, columnIDs, err := s.Cluster.InternalClient.BlockContainerData(ctx, uri, f.index, f.field, f.view, f.shard, id, keys)
+		if err != nil {
+			return false, errors.Wrap(err, "getting containers")
+		}
+		pairSets = append(pairSets, pairSet{
+			columnIDs: columnIDs,
+			rowIDs:    rowIDs,
+		})
+	}
+
+	// Merge containers together.
+	sets, clears, err := f.mergeBlockContainers(id, keys, pairSets)
+	if err != nil {
+		return false, errors.Wrap(err, "merging")
+	}
+
+	return true, s.writeBlockDiffs(ctx, uris, sets, clears)
+}
+
+// writeBlockDiffs sends the bits which each remote block needs to set and
+// clear to be in sync.
+func (s *fragmentSyncer) writeBlockDiffs(ctx context.Context, uris []*URI, sets, clears []pairSet) error {
+	f := s.Fragment
+
 	// Write updates to remote blocks.
 	for i := 0; i < len(uris); i++ {
 		set, clear := sets[i], clears[i]
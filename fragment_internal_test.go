@@ -407,6 +407,16 @@ func TestFragment_Sum(t *testing.T) {
 		}
 	})
 
+	t.Run("Parallel", func(t *testing.T) {
+		if sum, n, err := f.sumParallel(NewRow(2000, 3000, 4000, 5000), bitDepth, 4); err != nil {
+			t.Fatal(err)
+		} else if n != 3 {
+			t.Fatalf("unexpected count: %d", n)
+		} else if sum != 3418 {
+			t.Fatalf("unexpected sum: %d", sum)
+		}
+	})
+
 	// verify that clearValue clears values
 	if _, err := f.clearValue(1000, bitDepth, 23); err != nil {
 		t.Fatal(err)
@@ -1113,6 +1123,36 @@ func TestFragment_TopN_IDs(t *testing.T) {
 	}
 }
 
+// Ensure a fragment can compute exact top rows in parallel.
+func TestFragment_TopN_IDs_Parallel(t *testing.T) {
+	f := mustOpenFragment("i", "f", viewStandard, 0, CacheTypeRanked)
+	defer f.Clean(t)
+
+	// Set bits on various rows.
+	for rowID := uint64(100); rowID < 200; rowID++ {
+		for col := uint64(0); col < rowID%10; col++ {
+			f.mustSetBits(rowID, col*3)
+		}
+	}
+	src := NewRow(0, 3, 6, 7)
+
+	rowIDs := make([]uint64, 0)
+	for rowID := uint64(90); rowID < 210; rowID++ {
+		rowIDs = append(rowIDs, rowID)
+	}
+	exp, err := f.top(topOptions{RowIDs: rowIDs, Src: src})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pairs, err := f.top(topOptions{RowIDs: rowIDs, Src: src, Concurrency: 4}); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(pairs, exp) {
+		t.Fatalf("unexpected pairs: %s\nexpected: %s", spew.Sdump(pairs), spew.Sdump(exp))
+	} else if len(pairs) != 90 {
+		t.Fatalf("unexpected pair count: %d", len(pairs))
+	}
+}
+
 // Ensure a fragment return none if CacheTypeNone is set
 func TestFragment_TopN_NopCache(t *testing.T) {
 	f := mustOpenFragment("i", "f", viewStandard, 0, CacheTypeNone)
@@ -1213,6 +1253,50 @@ func TestFragment_Checksum(t *testing.T) {
 	}
 }
 
+// Ensure a fragment is dirty until it is synced, and again once written to.
+func TestFragment_Dirty(t *testing.T) {
+	f := mustOpenFragment("i", "f", viewStandard, 0, "")
+	defer f.Clean(t)
+
+	if !f.dirty() {
+		t.Fatal("expected fragment which was never synced to be dirty")
+	}
+	f.markSynced(f.writeCount(), f.Checksum())
+	if f.dirty() {
+		t.Fatal("expected synced fragment to be clean")
+	}
+
+	// Writes which don't change anything leave the fragment clean.
+	if _, err := f.clearBit(1, 200); err != nil {
+		t.Fatal(err)
+	} else if f.dirty() {
+		t.Fatal("expected fragment to be clean after no-op write")
+	}
+
+	writeN := f.writeCount()
+	if _, err := f.setBit(1, 200); err != nil {
+		t.Fatal(err)
+	} else if !f.dirty() {
+		t.Fatal("expected fragment to be dirty after write")
+	}
+
+	// A sync which started before the write doesn't cover it.
+	f.markSynced(writeN, f.Checksum())
+	if !f.dirty() {
+		t.Fatal("expected fragment to be dirty after sync which started before write")
+	}
+	f.markSynced(f.writeCount(), f.Checksum())
+	if f.dirty() {
+		t.Fatal("expected fragment to be clean after sync")
+	}
+
+	if _, err := f.clearRow(1); err != nil {
+		t.Fatal(err)
+	} else if !f.dirty() {
+		t.Fatal("expected fragment to be dirty after clearing row")
+	}
+}
+
 // Ensure fragment can return a checksum for a given block.
 func TestFragment_Blocks(t *testing.T) {
 	f := mustOpenFragment("i", "f", viewStandard, 0, "")
@@ -1269,6 +1353,61 @@ func TestFragment_Blocks_Empty(t *testing.T) {
 	}
 }
 
+// Ensure fragments can be compared and merged by container.
+func TestFragment_MergeBlockContainers(t *testing.T) {
+	f0 := mustOpenFragment("i", "f", viewStandard, 0, "")
+	defer f0.Clean(t)
+	f1 := mustOpenFragment("i", "f", viewStandard, 0, "")
+	defer f1.Clean(t)
+
+	for _, f := range []*fragment{f0, f1} {
+		if _, err := f.setBit(1, 1); err != nil {
+			t.Fatal(err)
+		} else if _, err := f.setBit(1, 70000); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := f0.setBit(2, 5); err != nil {
+		t.Fatal(err)
+	} else if _, err := f1.setBit(2, 6); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the container of row 2 differs.
+	c0, c1 := f0.containerChecksums(0), f1.containerChecksums(0)
+	if len(c0) != 3 || len(c1) != 3 {
+		t.Fatalf("unexpected container counts: %d, %d", len(c0), len(c1))
+	}
+	var keys []uint64
+	for i := range c0 {
+		if c0[i].Key != c1[i].Key {
+			t.Fatalf("unexpected keys: %d, %d", c0[i].Key, c1[i].Key)
+		} else if !bytes.Equal(c0[i].Checksum, c1[i].Checksum) {
+			keys = append(keys, c0[i].Key)
+		}
+	}
+	if !reflect.DeepEqual(keys, []uint64{2 * ShardWidth >> 16}) {
+		t.Fatalf("unexpected differing keys: %v", keys)
+	}
+
+	rowIDs, columnIDs := f1.containerData(0, keys)
+	if !reflect.DeepEqual(rowIDs, []uint64{2}) || !reflect.DeepEqual(columnIDs, []uint64{6}) {
+		t.Fatalf("unexpected container data: %v, %v", rowIDs, columnIDs)
+	}
+
+	sets, clears, err := f0.mergeBlockContainers(0, keys, []pairSet{{rowIDs: rowIDs, columnIDs: columnIDs}})
+	if err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(sets, []pairSet{{rowIDs: []uint64{2}, columnIDs: []uint64{5}}}) {
+		t.Fatalf("unexpected sets: %+v", sets)
+	} else if len(clears[0].columnIDs) != 0 {
+		t.Fatalf("unexpected clears: %+v", clears)
+	}
+	if a := f0.row(2).Columns(); !reflect.DeepEqual(a, []uint64{5, 6}) {
+		t.Fatalf("unexpected columns: %v", a)
+	}
+}
+
 // Ensure a fragment's cache can be persisted between restarts.
 func TestFragment_LRUCache_Persistence(t *testing.T) {
 	f := mustOpenFragment("i", "f", viewStandard, 0, CacheTypeLRU)
@@ -2502,6 +2641,10 @@ func (f *fragment) sanityCheck(t testing.TB) {
 	if err != nil {
 		t.Fatalf("sanityCheck couldn't read fragment %s: %v", f.path, err)
 	}
+	data, err = FragmentFileBody(data)
+	if err != nil {
+		t.Fatalf("sanityCheck couldn't read fragment header %s: %v", f.path, err)
+	}
 	err = newBM.UnmarshalBinary(data)
 	if err != nil {
 		t.Fatalf("sanityCheck couldn't unmarshal fragment %s: %v", f.path, err)
@@ -2517,6 +2660,7 @@ func (f *fragment) Clean(t testing.TB) {
 	errc := f.Close()
 	errf := os.Remove(f.path)
 	errp := os.Remove(f.cachePath())
+	_ = os.Remove(f.statsPath())
 	if errc != nil || errf != nil {
 		t.Fatal("cleaning up fragment: ", errc, errf, errp)
 	}
@@ -2540,6 +2684,7 @@ func (f *fragment) importRoaringT(data []byte, clear bool) error {
 func (f *fragment) CleanKeep(t testing.TB) {
 	errc := f.Close()
 	errp := os.Remove(f.cachePath())
+	_ = os.Remove(f.statsPath())
 	if errc != nil {
 		t.Fatal("closing fragment: ", errc, errp)
 	}
@@ -2576,9 +2721,7 @@ func mustOpenFragmentFlags(index, field, view string, shard uint64, cacheType st
 
 	f := newFragment(file.Name(), index, field, view, shard, flags)
 	f.CacheType = cacheType
-	f.RowAttrStore = &memAttrStore{
-		store: make(map[uint64]map[string]interface{}),
-	}
+	f.RowAttrStore = newMemAttrStore("")
 	f.snapshotQueue = newSnapshotQueue(1, 1, nil)
 
 	if err := f.Open(); err != nil {
@@ -2657,6 +2800,16 @@ func TestFragment_RowsIteration(t *testing.T) {
 		if !reflect.DeepEqual(expectedOdd, ids) {
 			t.Fatalf("Do not match %v %v", expectedOdd, ids)
 		}
+
+		ids = f.rowsParallel(0, 3)
+		if !reflect.DeepEqual(expectedAll, ids) {
+			t.Fatalf("Do not match %v %v", expectedAll, ids)
+		}
+
+		ids = f.rowsParallel(150, 7, filterColumn(1))
+		if !reflect.DeepEqual(expectedOdd[25:], ids) {
+			t.Fatalf("Do not match %v %v", expectedOdd[25:], ids)
+		}
 	})
 
 	t.Run("secondRow", func(t *testing.T) {
@@ -0,0 +1,158 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pilosa/pilosa/v2/logger"
+	"github.com/pilosa/pilosa/v2/stats"
+	"github.com/pkg/errors"
+)
+
+// Fsync policies, which set when the writes appended to the op logs of
+// fragment data files are flushed to disk. Under every policy a file is
+// flushed when it is closed.
+const (
+	// FsyncAlways flushes a fragment's file after every write to it, so a
+	// write acknowledged to the client survives a crash of the host.
+	FsyncAlways = "always"
+
+	// FsyncInterval flushes the files of the fragments written to at a
+	// fixed interval, so at most that interval's writes are lost.
+	FsyncInterval = "interval"
+
+	// FsyncNever leaves flushing the files to the operating system. Writes
+	// survive a crash of the server, but not of the host.
+	FsyncNever = "never"
+)
+
+// validateFsyncPolicy returns an error if policy and interval aren't a valid
+// fsync policy. An empty policy is FsyncNever.
+func validateFsyncPolicy(policy string, interval time.Duration) error {
+	switch policy {
+	case "", FsyncAlways, FsyncNever:
+		return nil
+	case FsyncInterval:
+		if interval <= 0 {
+			return errors.New("fsync interval must be positive")
+		}
+		return nil
+	default:
+		return errors.Errorf("invalid fsync policy: %q", policy)
+	}
+}
+
+// fsyncer flushes the files of fragments according to an fsync policy.
+// Under FsyncInterval it records the fragments written to, and a goroutine
+// flushes their files at each interval.
+//
+// The methods of a nil fsyncer do nothing, as under FsyncNever.
+type fsyncer struct {
+	policy   string
+	interval time.Duration
+
+	mu    sync.Mutex
+	dirty map[*fragment]struct{}
+
+	stats  stats.StatsClient
+	logger logger.Logger
+}
+
+func newFsyncer(policy string, interval time.Duration) *fsyncer {
+	return &fsyncer{
+		policy:   policy,
+		interval: interval,
+		dirty:    make(map[*fragment]struct{}),
+		stats:    stats.NopStatsClient,
+		logger:   logger.NopLogger,
+	}
+}
+
+// wrote records that the op log of f was appended to. f.mu must be locked.
+// Under FsyncAlways it returns the error flushing the file, so that the write
+// fails rather than being acknowledged without being durable.
+func (s *fsyncer) wrote(f *fragment) error {
+	if s == nil {
+		return nil
+	}
+	switch s.policy {
+	case FsyncAlways:
+		if err := f.syncFile(); err != nil {
+			return errors.Wrapf(err, "syncing fragment file %s", f.path)
+		}
+		s.stats.Count("fragmentFsyncs", 1, 1.0)
+	case FsyncInterval:
+		s.mu.Lock()
+		s.dirty[f] = struct{}{}
+		s.mu.Unlock()
+	}
+	return nil
+}
+
+// closed records that the file of f was closed, and so flushed.
+func (s *fsyncer) closed(f *fragment) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	delete(s.dirty, f)
+	s.mu.Unlock()
+}
+
+// run flushes the files written to at each interval, until closing is
+// closed. This is run in a goroutine.
+func (s *fsyncer) run(closing <-chan struct{}) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-closing:
+			s.flush()
+			return
+		case <-ticker.C:
+		}
+		s.flush()
+	}
+}
+
+// flush flushes the files of the fragments written to since the last flush.
+func (s *fsyncer) flush() {
+	s.mu.Lock()
+	dirty := s.dirty
+	s.dirty = make(map[*fragment]struct{})
+	s.mu.Unlock()
+
+	for f := range dirty {
+		f.mu.Lock()
+		err := f.syncFile()
+		f.mu.Unlock()
+		if err != nil {
+			s.logger.Printf("syncing fragment file: %s, err=%s", f.path, err)
+			continue
+		}
+		s.stats.Count("fragmentFsyncs", 1, 1.0)
+	}
+}
+
+// syncFile flushes the fragment's file to disk, if it is open. f.mu must be
+// locked.
+func (f *fragment) syncFile() error {
+	if f.file == nil {
+		return nil
+	}
+	return f.file.Sync()
+}
@@ -0,0 +1,106 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestValidateFsyncPolicy(t *testing.T) {
+	for _, tt := range []struct {
+		policy   string
+		interval time.Duration
+		valid    bool
+	}{
+		{"", 0, true},
+		{FsyncAlways, 0, true},
+		{FsyncNever, 0, true},
+		{FsyncInterval, 100 * time.Millisecond, true},
+		{FsyncInterval, 0, false},
+		{"sometimes", time.Second, false},
+	} {
+		if err := validateFsyncPolicy(tt.policy, tt.interval); (err == nil) != tt.valid {
+			t.Errorf("policy %q, interval %s: unexpected error: %v", tt.policy, tt.interval, err)
+		}
+	}
+}
+
+// Ensure fragments written to are flushed at the next interval, and forgotten
+// when their files are closed.
+func TestFsyncer_Interval(t *testing.T) {
+	f := mustOpenFragment("i", "f", viewStandard, 0, "")
+	defer f.Clean(t)
+	s := newFsyncer(FsyncInterval, time.Second)
+	f.fsyncer = s
+
+	f.mustSetBits(1, 1)
+	if _, ok := s.dirty[f]; !ok {
+		t.Fatal("expected fragment to be dirty")
+	}
+	s.flush()
+	if len(s.dirty) != 0 {
+		t.Fatalf("unexpected dirty fragments: %d", len(s.dirty))
+	}
+
+	// Writes which change nothing don't dirty the fragment.
+	f.mustSetBits(1, 1)
+	if len(s.dirty) != 0 {
+		t.Fatalf("unexpected dirty fragments: %d", len(s.dirty))
+	}
+
+	f.mustSetBits(1, 2)
+	if err := f.closeFile(); err != nil {
+		t.Fatal(err)
+	} else if len(s.dirty) != 0 {
+		t.Fatalf("unexpected dirty fragments: %d", len(s.dirty))
+	}
+}
+
+// Ensure writes succeed when every write is flushed.
+func TestFsyncer_Always(t *testing.T) {
+	f := mustOpenFragment("i", "f", viewStandard, 0, "")
+	defer f.Clean(t)
+	f.fsyncer = newFsyncer(FsyncAlways, 0)
+
+	f.mustSetBits(1, 1, 2)
+	if err := f.Snapshot(); err != nil {
+		t.Fatal(err)
+	}
+	f.mustSetBits(2, 3)
+	if err := f.Reopen(); err != nil {
+		t.Fatal(err)
+	} else if n := f.row(1).Count(); n != 2 {
+		t.Fatalf("unexpected count: %d", n)
+	} else if n := f.row(2).Count(); n != 1 {
+		t.Fatalf("unexpected count: %d", n)
+	}
+
+	// Writes fail if they can't be flushed. The op log is swapped for a
+	// pipe, which can be written to but not synced.
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	file := f.file
+	f.file, f.storage.OpWriter = w, w
+	if _, err := f.setBit(3, 1); err == nil {
+		t.Fatal("expected error")
+	}
+	f.file, f.storage.OpWriter = file, file
+	w.Close()
+}
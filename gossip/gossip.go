@@ -191,7 +191,7 @@ func WithPilosaLogger(l logger.Logger) memberSetOption {
 // or WithLogger. If you pass WithLogOutput, be sure to also pass in a Transport
 // using WithTransport.
 func NewMemberSet(cfg Config, api *pilosa.API, options ...memberSetOption) (*memberSet, error) {
-	host := api.Node().URI.Host
+	host := api.Node().URI.Hostname()
 	g := &memberSet{
 		papi:   api,
 		Logger: logger.NopLogger,
@@ -246,7 +246,7 @@ func NewMemberSet(cfg Config, api *pilosa.API, options ...memberSetOption) (*mem
 	conf := memberlist.DefaultWANConfig()
 	conf.Transport = g.transport.net
 	conf.Name = api.Node().ID
-	conf.BindAddr = api.Node().URI.Host
+	conf.BindAddr = api.Node().URI.Hostname()
 	conf.BindPort = port
 	// AdvertisePort
 	if cfg.AdvertisePort != "" {
@@ -260,9 +260,9 @@ func NewMemberSet(cfg Config, api *pilosa.API, options ...memberSetOption) (*mem
 	}
 	// AdvertiseHost
 	if cfg.AdvertiseHost != "" {
-		conf.AdvertiseAddr = cfg.AdvertiseHost
+		conf.AdvertiseAddr = strings.TrimSuffix(strings.TrimPrefix(cfg.AdvertiseHost, "["), "]")
 	} else {
-		conf.AdvertiseAddr = hostToIP(api.Node().URI.Host)
+		conf.AdvertiseAddr = hostToIP(api.Node().URI.Hostname())
 	}
 	//
 	conf.TCPTimeout = time.Duration(cfg.StreamTimeout)
@@ -323,16 +323,31 @@ func (g *memberSet) LocalState(join bool) []byte {
 		Node:   g.papi.Node(),
 		Schema: &pilosa.Schema{Indexes: g.papi.Schema(context.Background())},
 	}
+	if settings, err := g.papi.Settings(context.Background()); err == nil {
+		m.Settings = &settings
+	}
+	if aliases, err := g.papi.Aliases(context.Background()); err == nil {
+		m.Aliases = &aliases
+	}
+	m.MaterializedViews, m.DeletedMaterializedViews = g.papi.MaterializedViewDefinitions(context.Background())
+	m.RewriteRules, m.DeletedRewriteRules = g.papi.RewriteRuleDefinitions(context.Background())
+	m.NamedQueries, m.DeletedNamedQueries = g.papi.NamedQueryDefinitions(context.Background())
+	if load := g.papi.Load(); !load.Time.IsZero() {
+		m.Load = &load
+	}
 	for _, idx := range m.Schema.Indexes {
 		is := &pilosa.IndexStatus{Name: idx.Name}
 		for _, f := range idx.Fields {
 			availableShards := roaring.NewBitmap()
+			var lastAccess time.Time
 			if field, _ := g.papi.Field(context.Background(), idx.Name, f.Name); field != nil {
 				availableShards = field.AvailableShards()
+				lastAccess = field.LastAccess()
 			}
 			is.Fields = append(is.Fields, &pilosa.FieldStatus{
 				Name:            f.Name,
 				AvailableShards: availableShards,
+				LastAccess:      lastAccess,
 			})
 		}
 		m.Indexes = append(m.Indexes, is)
@@ -595,20 +610,21 @@ type Config struct {
 	ToTheDeadTime toml.Duration `toml:"to-the-dead-time"`
 }
 
-// hostToIP converts host to an IP4 address based on net.LookupIP().
+// hostToIP converts host to an IP address based on net.LookupIP(),
+// preferring IPv4 addresses to IPv6 ones.
 func hostToIP(host string) string {
 	// if host is not an IP addr, check net.LookupIP()
 	if net.ParseIP(host) == nil {
 		hosts, err := net.LookupIP(host)
-		if err != nil {
+		if err != nil || len(hosts) == 0 {
 			return host
 		}
 		for _, h := range hosts {
-			// this restricts pilosa to IP4
 			if h.To4() != nil {
 				return h.String()
 			}
 		}
+		return hosts[0].String()
 	}
 	return host
 }
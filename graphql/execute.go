@@ -0,0 +1,386 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// Object is an object in the graph, whose fields are resolved as they are
+// selected.
+type Object interface {
+	// TypeName returns the name of the object's type.
+	TypeName() string
+
+	// Resolve returns the value of a field of the object. Values are nil,
+	// booleans, numbers, strings, Objects, or slices of them.
+	Resolve(ctx context.Context, field string, args Args) (interface{}, error)
+}
+
+// Args are the arguments of a field, with variables substituted. Arguments
+// which refer to variables which weren't given are left out.
+type Args map[string]interface{}
+
+// StringArg returns the value of a string argument, and whether it was given.
+func (a Args) StringArg(name string) (string, bool, error) {
+	v, ok := a[name]
+	if !ok || v == nil {
+		return "", false, nil
+	}
+	switch v := v.(type) {
+	case string:
+		return v, true, nil
+	case Enum:
+		return string(v), true, nil
+	}
+	return "", false, fmt.Errorf("argument %q must be a string", name)
+}
+
+// IntArg returns the value of an integer argument, and whether it was given.
+// Integral floats are accepted, since variables are decoded from JSON.
+func (a Args) IntArg(name string) (int64, bool, error) {
+	v, ok := a[name]
+	if !ok || v == nil {
+		return 0, false, nil
+	}
+	switch v := v.(type) {
+	case int64:
+		return v, true, nil
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1<<53 {
+			return int64(v), true, nil
+		}
+	}
+	return 0, false, fmt.Errorf("argument %q must be an integer", name)
+}
+
+// BoolArg returns the value of a boolean argument, and whether it was given.
+func (a Args) BoolArg(name string) (bool, bool, error) {
+	v, ok := a[name]
+	if !ok || v == nil {
+		return false, false, nil
+	}
+	if v, ok := v.(bool); ok {
+		return v, true, nil
+	}
+	return false, false, fmt.Errorf("argument %q must be a boolean", name)
+}
+
+// Error is an error in a response. Errors resolving a field give its path
+// in the response, and the field's value is null.
+type Error struct {
+	Message   string        `json:"message"`
+	Locations []Location    `json:"locations,omitempty"`
+	Path      []interface{} `json:"path,omitempty"`
+}
+
+func (e *Error) Error() string { return e.Message }
+
+// Response is the result of executing an operation. Data is nil if the
+// operation couldn't be executed at all, in which case Errors say why.
+type Response struct {
+	Data   interface{} `json:"data,omitempty"`
+	Errors []*Error    `json:"errors,omitempty"`
+}
+
+// Execute executes an operation of a document, selecting the fields of
+// root. The operation is chosen by name, which may be empty if the document
+// has only one.
+func Execute(ctx context.Context, doc *Document, operationName string, variables map[string]interface{}, root Object) *Response {
+	op, err := selectOperation(doc, operationName)
+	if err != nil {
+		return &Response{Errors: []*Error{err}}
+	}
+	vars := make(map[string]interface{}, len(op.Variables))
+	for _, def := range op.Variables {
+		if v, ok := variables[def.Name]; ok {
+			vars[def.Name] = v
+		} else if def.HasDefault {
+			vars[def.Name] = def.Default
+		} else if len(def.Type) > 0 && def.Type[len(def.Type)-1] == '!' {
+			return &Response{Errors: []*Error{{Message: fmt.Sprintf("variable $%s of type %s is required", def.Name, def.Type), Locations: []Location{op.Loc}}}}
+		}
+	}
+
+	e := &executor{doc: doc, vars: vars}
+	data := e.executeSelections(ctx, root, op.SelectionSet, nil)
+	return &Response{Data: data, Errors: e.errors}
+}
+
+func selectOperation(doc *Document, name string) (*Operation, *Error) {
+	var op *Operation
+	for _, o := range doc.Operations {
+		if name == "" || o.Name == name {
+			if op != nil {
+				return nil, &Error{Message: "operation name is required, since the document has more than one operation"}
+			}
+			op = o
+		}
+	}
+	if op == nil {
+		return nil, &Error{Message: fmt.Sprintf("unknown operation %q", name)}
+	} else if op.Type != "query" {
+		return nil, &Error{Message: fmt.Sprintf("%s operations are not supported", op.Type), Locations: []Location{op.Loc}}
+	}
+	return op, nil
+}
+
+type executor struct {
+	doc    *Document
+	vars   map[string]interface{}
+	errors []*Error
+}
+
+func (e *executor) errorf(f *Field, path []interface{}, format string, a ...interface{}) {
+	e.errors = append(e.errors, &Error{
+		Message:   fmt.Sprintf(format, a...),
+		Locations: []Location{f.Loc},
+		Path:      append([]interface{}(nil), path...),
+	})
+}
+
+// executeSelections resolves the fields selected of obj, whose value is at
+// path in the response.
+func (e *executor) executeSelections(ctx context.Context, obj Object, sels []Selection, path []interface{}) *orderedMap {
+	fields := &orderedMap{}
+	e.collectFields(obj, sels, fields, map[string]bool{})
+
+	result := &orderedMap{}
+	for i, key := range fields.keys {
+		fs := fields.values[i].([]*Field)
+		f := fs[0]
+		fieldPath := append(path[:len(path):len(path)], key)
+
+		if f.Name == "__typename" {
+			result.set(key, obj.TypeName())
+			continue
+		}
+		args, err := e.arguments(f.Arguments)
+		if err != nil {
+			e.errorf(f, fieldPath, "%s", err)
+			result.set(key, nil)
+			continue
+		}
+		v, err := obj.Resolve(ctx, f.Name, args)
+		if err != nil {
+			e.errorf(f, fieldPath, "%s", err)
+			result.set(key, nil)
+			continue
+		}
+
+		// Fields selected more than once have their selections merged.
+		var sub []Selection
+		for _, f := range fs {
+			sub = append(sub, f.SelectionSet...)
+		}
+		result.set(key, e.completeValue(ctx, f, sub, v, fieldPath))
+	}
+	return result
+}
+
+// completeValue returns the value of a field, given what it resolved to.
+func (e *executor) completeValue(ctx context.Context, f *Field, sub []Selection, v interface{}, path []interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	if obj, ok := v.(Object); ok {
+		if len(sub) == 0 {
+			e.errorf(f, path, "field %q of type %s must have a selection of subfields", f.Name, obj.TypeName())
+			return nil
+		}
+		return e.executeSelections(ctx, obj, sub, path)
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			return nil
+		}
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return []interface{}{}
+		}
+		list := make([]interface{}, rv.Len())
+		for i := range list {
+			list[i] = e.completeValue(ctx, f, sub, rv.Index(i).Interface(), append(path[:len(path):len(path)], i))
+		}
+		return list
+	}
+	if len(sub) > 0 {
+		e.errorf(f, path, "field %q is a scalar, and has no subfields", f.Name)
+		return nil
+	}
+	return v
+}
+
+// collectFields adds the fields selected of obj to fields, by their keys
+// in the response, following fragments.
+func (e *executor) collectFields(obj Object, sels []Selection, fields *orderedMap, visited map[string]bool) {
+	for _, sel := range sels {
+		switch sel := sel.(type) {
+		case *Field:
+			if !e.included(sel.Directives) {
+				continue
+			}
+			key := sel.ResponseKey()
+			fs, _ := fields.get(key).([]*Field)
+			fields.set(key, append(fs, sel))
+		case *FragmentSpread:
+			if !e.included(sel.Directives) || visited[sel.Name] {
+				continue
+			}
+			visited[sel.Name] = true
+			frag, ok := e.doc.Fragments[sel.Name]
+			if !ok {
+				e.errors = append(e.errors, &Error{Message: fmt.Sprintf("unknown fragment %q", sel.Name), Locations: []Location{sel.Loc}})
+				continue
+			}
+			if frag.TypeCondition == obj.TypeName() {
+				e.collectFields(obj, frag.SelectionSet, fields, visited)
+			}
+		case *InlineFragment:
+			if !e.included(sel.Directives) {
+				continue
+			}
+			if sel.TypeCondition == "" || sel.TypeCondition == obj.TypeName() {
+				e.collectFields(obj, sel.SelectionSet, fields, visited)
+			}
+		}
+	}
+}
+
+// included returns false if a selection is excluded by its @skip or
+// @include directive.
+func (e *executor) included(dirs []*Directive) bool {
+	for _, d := range dirs {
+		if d.Name != "skip" && d.Name != "include" {
+			continue
+		}
+		args, err := e.arguments(d.Arguments)
+		if err != nil {
+			continue
+		}
+		cond, _, _ := args.BoolArg("if")
+		if cond == (d.Name == "skip") {
+			return false
+		}
+	}
+	return true
+}
+
+// arguments returns the values of arguments, with variables substituted.
+func (e *executor) arguments(in []*Argument) (Args, error) {
+	args := make(Args, len(in))
+	for _, arg := range in {
+		if v, ok := arg.Value.(Variable); ok {
+			if _, ok := e.vars[string(v)]; !ok {
+				continue
+			}
+		}
+		v, err := e.value(arg.Value)
+		if err != nil {
+			return nil, err
+		}
+		args[arg.Name] = v
+	}
+	return args, nil
+}
+
+func (e *executor) value(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case Variable:
+		val, ok := e.vars[string(v)]
+		if !ok {
+			return nil, fmt.Errorf("variable $%s is not defined", v)
+		}
+		return val, nil
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i := range v {
+			var err error
+			if list[i], err = e.value(v[i]); err != nil {
+				return nil, err
+			}
+		}
+		return list, nil
+	case map[string]interface{}:
+		obj := make(map[string]interface{}, len(v))
+		for k := range v {
+			var err error
+			if obj[k], err = e.value(v[k]); err != nil {
+				return nil, err
+			}
+		}
+		return obj, nil
+	}
+	return v, nil
+}
+
+// orderedMap is a JSON object whose keys are kept in order, since the
+// fields of a response are in the order they were selected.
+type orderedMap struct {
+	keys   []string
+	values []interface{}
+}
+
+func (m *orderedMap) get(key string) interface{} {
+	for i, k := range m.keys {
+		if k == key {
+			return m.values[i]
+		}
+	}
+	return nil
+}
+
+func (m *orderedMap) set(key string, v interface{}) {
+	for i, k := range m.keys {
+		if k == key {
+			m.values[i] = v
+			return
+		}
+	}
+	m.keys = append(m.keys, key)
+	m.values = append(m.values, v)
+}
+
+// MarshalJSON encodes the map as a JSON object.
+func (m *orderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, k := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		val, err := json.Marshal(m.values[i])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(val)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
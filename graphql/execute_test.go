@@ -0,0 +1,164 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphql_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/pilosa/pilosa/v2/graphql"
+)
+
+// library is a graph of books, for testing.
+type library struct{}
+
+type book struct {
+	title string
+	year  int
+}
+
+var books = []book{{title: "Dune", year: 1965}, {title: "Emma", year: 1815}}
+
+func (library) TypeName() string { return "Query" }
+
+func (library) Resolve(ctx context.Context, field string, args graphql.Args) (interface{}, error) {
+	switch field {
+	case "books":
+		limit, ok, err := args.IntArg("limit")
+		if err != nil {
+			return nil, err
+		} else if !ok || int(limit) > len(books) {
+			limit = int64(len(books))
+		}
+		objs := make([]graphql.Object, limit)
+		for i := range objs {
+			objs[i] = books[i]
+		}
+		return objs, nil
+	case "book":
+		title, _, err := args.StringArg("title")
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range books {
+			if b.title == title {
+				return b, nil
+			}
+		}
+		return nil, nil
+	}
+	return nil, fmt.Errorf("unknown field %s", field)
+}
+
+func (book) TypeName() string { return "Book" }
+
+func (b book) Resolve(ctx context.Context, field string, args graphql.Args) (interface{}, error) {
+	switch field {
+	case "title":
+		return b.title, nil
+	case "year":
+		return b.year, nil
+	case "tags":
+		return []string{"classic"}, nil
+	case "sequel":
+		return nil, errors.New("out of print")
+	}
+	return nil, fmt.Errorf("unknown field %s", field)
+}
+
+// Ensure queries are executed against a graph of objects.
+func TestExecute(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		doc  string
+		op   string
+		vars map[string]interface{}
+		exp  string
+	}{
+		{
+			name: "Fields",
+			doc:  `{ books { title year } }`,
+			exp:  `{"data":{"books":[{"title":"Dune","year":1965},{"title":"Emma","year":1815}]}}`,
+		},
+		{
+			name: "AliasesAndArguments",
+			doc:  `{ first: books(limit: 1) { title } b: book(title: "Emma") { __typename year tags } none: book(title: "Ulysses") { title } }`,
+			exp:  `{"data":{"first":[{"title":"Dune"}],"b":{"__typename":"Book","year":1815,"tags":["classic"]},"none":null}}`,
+		},
+		{
+			name: "Variables",
+			doc:  `query Q($n: Int, $t: String = "Dune") { books(limit: $n) { title } book(title: $t) { year } }`,
+			vars: map[string]interface{}{"n": 1.0},
+			exp:  `{"data":{"books":[{"title":"Dune"}],"book":{"year":1965}}}`,
+		},
+		{
+			name: "Fragments",
+			doc:  `query { books(limit: 1) { ...T ... on Book { year } ... on Author { name } title @skip(if: true) } } fragment T on Book { title }`,
+			exp:  `{"data":{"books":[{"title":"Dune","year":1965}]}}`,
+		},
+		{
+			name: "MergedFields",
+			doc:  `{ book(title: "Dune") { title } book(title: "Dune") { year } }`,
+			exp:  `{"data":{"book":{"title":"Dune","year":1965}}}`,
+		},
+		{
+			name: "FieldErrors",
+			doc:  `{ books(limit: 1) { sequel { title } } nope }`,
+			exp:  `{"data":{"books":[{"sequel":null}],"nope":null},"errors":[{"message":"out of print","locations":[{"line":1,"column":21}],"path":["books",0,"sequel"]},{"message":"unknown field nope","locations":[{"line":1,"column":40}],"path":["nope"]}]}`,
+		},
+		{
+			name: "SelectionErrors",
+			doc:  `{ book(title: "Dune") { title { x } } books }`,
+			exp:  `{"data":{"book":{"title":null},"books":[null,null]},"errors":[{"message":"field \"title\" is a scalar, and has no subfields","locations":[{"line":1,"column":25}],"path":["book","title"]},{"message":"field \"books\" of type Book must have a selection of subfields","locations":[{"line":1,"column":39}],"path":["books",0]},{"message":"field \"books\" of type Book must have a selection of subfields","locations":[{"line":1,"column":39}],"path":["books",1]}]}`,
+		},
+		{
+			name: "OperationName",
+			doc:  `query A { books(limit: 1) { title } } query B { book(title: "Emma") { year } }`,
+			op:   "B",
+			exp:  `{"data":{"book":{"year":1815}}}`,
+		},
+		{
+			name: "OperationNameRequired",
+			doc:  `query A { books { title } } query B { books { year } }`,
+			exp:  `{"errors":[{"message":"operation name is required, since the document has more than one operation"}]}`,
+		},
+		{
+			name: "Mutation",
+			doc:  `mutation { addBook(title: "Ulysses") { title } }`,
+			exp:  `{"errors":[{"message":"mutation operations are not supported","locations":[{"line":1,"column":1}]}]}`,
+		},
+		{
+			name: "RequiredVariable",
+			doc:  `query Q($t: String!) { book(title: $t) { year } }`,
+			exp:  `{"errors":[{"message":"variable $t of type String! is required","locations":[{"line":1,"column":1}]}]}`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			doc, err := graphql.Parse(test.doc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			buf, err := json.Marshal(graphql.Execute(context.Background(), doc, test.op, test.vars, library{}))
+			if err != nil {
+				t.Fatal(err)
+			} else if string(buf) != test.exp {
+				t.Fatalf("unexpected response:\n%s\nexpected:\n%s", buf, test.exp)
+			}
+		})
+	}
+}
@@ -0,0 +1,623 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Document is a parsed GraphQL document.
+type Document struct {
+	Operations []*Operation
+	Fragments  map[string]*Fragment
+}
+
+// Operation is an operation in a document, such as a query.
+type Operation struct {
+	Type         string // "query", "mutation" or "subscription"
+	Name         string
+	Variables    []*VariableDefinition
+	SelectionSet []Selection
+	Loc          Location
+}
+
+// VariableDefinition declares a variable of an operation.
+type VariableDefinition struct {
+	Name       string
+	Type       string
+	Default    interface{}
+	HasDefault bool
+}
+
+// Fragment is a named fragment, which selections can spread.
+type Fragment struct {
+	Name          string
+	TypeCondition string
+	SelectionSet  []Selection
+}
+
+// Selection is a Field, FragmentSpread or InlineFragment.
+type Selection interface {
+	selection()
+}
+
+// Field selects a field of an object.
+type Field struct {
+	Alias        string
+	Name         string
+	Arguments    []*Argument
+	Directives   []*Directive
+	SelectionSet []Selection
+	Loc          Location
+}
+
+// FragmentSpread selects the fields of a named fragment.
+type FragmentSpread struct {
+	Name       string
+	Directives []*Directive
+	Loc        Location
+}
+
+// InlineFragment selects fields of objects of a type.
+type InlineFragment struct {
+	TypeCondition string
+	Directives    []*Directive
+	SelectionSet  []Selection
+}
+
+func (*Field) selection()          {}
+func (*FragmentSpread) selection() {}
+func (*InlineFragment) selection() {}
+
+// ResponseKey returns the key of the field's value in the response.
+func (f *Field) ResponseKey() string {
+	if f.Alias != "" {
+		return f.Alias
+	}
+	return f.Name
+}
+
+// Argument is an argument of a field or directive.
+type Argument struct {
+	Name  string
+	Value interface{}
+}
+
+// Directive annotates a selection, such as @skip(if: true).
+type Directive struct {
+	Name      string
+	Arguments []*Argument
+}
+
+// Values in a document are nil, bool, int64, float64, string, Enum,
+// Variable, []interface{}, or map[string]interface{}.
+type (
+	// Enum is an enum value.
+	Enum string
+
+	// Variable refers to a variable of the operation.
+	Variable string
+)
+
+// Location is the position of part of a document, from 1.
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// Parse parses a GraphQL document.
+func Parse(s string) (doc *Document, err error) {
+	p := &parser{lexer: lexer{src: s, line: 1, col: 1}}
+	defer func() {
+		if r := recover(); r != nil {
+			e, ok := r.(*Error)
+			if !ok {
+				panic(r)
+			}
+			doc, err = nil, e
+		}
+	}()
+	p.next()
+	return p.parseDocument(), nil
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunct
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	loc   Location
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of document"
+	case tokenString:
+		return strconv.Quote(t.value)
+	}
+	return fmt.Sprintf("%q", t.value)
+}
+
+// lexer splits a document into tokens. Whitespace, commas and comments are
+// ignored.
+type lexer struct {
+	src       string
+	pos       int
+	line, col int
+}
+
+func (l *lexer) peekByte(i int) byte {
+	if l.pos+i < len(l.src) {
+		return l.src[l.pos+i]
+	}
+	return 0
+}
+
+func (l *lexer) advance(n int) {
+	for ; n > 0 && l.pos < len(l.src); n-- {
+		if l.src[l.pos] == '\n' {
+			l.line, l.col = l.line+1, 1
+		} else if l.src[l.pos] < utf8.RuneSelf || utf8.RuneStart(l.src[l.pos]) {
+			l.col++
+		}
+		l.pos++
+	}
+}
+
+func (l *lexer) errorf(loc Location, format string, a ...interface{}) {
+	panic(&Error{Message: "syntax error: " + fmt.Sprintf(format, a...), Locations: []Location{loc}})
+}
+
+func (l *lexer) lex() token {
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		if c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',' {
+			l.advance(1)
+		} else if c == '#' {
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+				l.advance(1)
+			}
+		} else if strings.HasPrefix(l.src[l.pos:], "\ufeff") {
+			l.pos += len("\ufeff")
+		} else {
+			break
+		}
+	}
+	loc := Location{Line: l.line, Column: l.col}
+	if l.pos >= len(l.src) {
+		return token{kind: tokenEOF, loc: loc}
+	}
+
+	c := l.src[l.pos]
+	switch {
+	case strings.HasPrefix(l.src[l.pos:], "..."):
+		l.advance(3)
+		return token{kind: tokenPunct, value: "...", loc: loc}
+	case strings.IndexByte("!$&():=@[]{|}", c) >= 0:
+		l.advance(1)
+		return token{kind: tokenPunct, value: string(c), loc: loc}
+	case c == '_' || isLetter(c):
+		start := l.pos
+		for c := l.peekByte(0); c == '_' || isLetter(c) || isDigit(c); c = l.peekByte(0) {
+			l.advance(1)
+		}
+		return token{kind: tokenName, value: l.src[start:l.pos], loc: loc}
+	case c == '-' || isDigit(c):
+		return l.lexNumber(loc)
+	case c == '"':
+		if strings.HasPrefix(l.src[l.pos:], `"""`) {
+			return l.lexBlockString(loc)
+		}
+		return l.lexString(loc)
+	}
+	r, _ := utf8.DecodeRuneInString(l.src[l.pos:])
+	l.errorf(loc, "unexpected character %q", r)
+	return token{}
+}
+
+func (l *lexer) lexNumber(loc Location) token {
+	start, kind := l.pos, tokenInt
+	if l.peekByte(0) == '-' {
+		l.advance(1)
+	}
+	digits := func() {
+		if !isDigit(l.peekByte(0)) {
+			l.errorf(Location{Line: l.line, Column: l.col}, "invalid number, expected digit")
+		}
+		for isDigit(l.peekByte(0)) {
+			l.advance(1)
+		}
+	}
+	if l.peekByte(0) == '0' {
+		l.advance(1)
+		if isDigit(l.peekByte(0)) {
+			l.errorf(loc, "invalid number, unexpected digit after 0")
+		}
+	} else {
+		digits()
+	}
+	if l.peekByte(0) == '.' {
+		kind = tokenFloat
+		l.advance(1)
+		digits()
+	}
+	if c := l.peekByte(0); c == 'e' || c == 'E' {
+		kind = tokenFloat
+		l.advance(1)
+		if c := l.peekByte(0); c == '+' || c == '-' {
+			l.advance(1)
+		}
+		digits()
+	}
+	if c := l.peekByte(0); c == '_' || c == '.' || isLetter(c) {
+		l.errorf(Location{Line: l.line, Column: l.col}, "invalid number, unexpected %q", c)
+	}
+	return token{kind: kind, value: l.src[start:l.pos], loc: loc}
+}
+
+func (l *lexer) lexString(loc Location) token {
+	l.advance(1)
+	var buf strings.Builder
+	for {
+		if l.pos >= len(l.src) || l.src[l.pos] == '\n' || l.src[l.pos] == '\r' {
+			l.errorf(loc, "unterminated string")
+		}
+		c := l.src[l.pos]
+		if c == '"' {
+			l.advance(1)
+			return token{kind: tokenString, value: buf.String(), loc: loc}
+		} else if c != '\\' {
+			r, n := utf8.DecodeRuneInString(l.src[l.pos:])
+			buf.WriteRune(r)
+			l.advance(n)
+			continue
+		}
+
+		escLoc := Location{Line: l.line, Column: l.col}
+		switch e := l.peekByte(1); e {
+		case '"', '\\', '/':
+			buf.WriteByte(e)
+		case 'b':
+			buf.WriteByte('\b')
+		case 'f':
+			buf.WriteByte('\f')
+		case 'n':
+			buf.WriteByte('\n')
+		case 'r':
+			buf.WriteByte('\r')
+		case 't':
+			buf.WriteByte('\t')
+		case 'u':
+			if l.pos+6 > len(l.src) {
+				l.errorf(escLoc, "invalid unicode escape")
+			}
+			r, err := strconv.ParseUint(l.src[l.pos+2:l.pos+6], 16, 32)
+			if err != nil {
+				l.errorf(escLoc, "invalid unicode escape")
+			}
+			buf.WriteRune(rune(r))
+			l.advance(4)
+		default:
+			l.errorf(escLoc, "invalid escape sequence")
+		}
+		l.advance(2)
+	}
+}
+
+// lexBlockString lexes a """block string""", whose lines are stripped of
+// their common indentation, and of blank lines at its start and end.
+func (l *lexer) lexBlockString(loc Location) token {
+	l.advance(3)
+	var buf strings.Builder
+	for {
+		if l.pos >= len(l.src) {
+			l.errorf(loc, "unterminated string")
+		} else if strings.HasPrefix(l.src[l.pos:], `"""`) {
+			l.advance(3)
+			break
+		} else if strings.HasPrefix(l.src[l.pos:], `\"""`) {
+			buf.WriteString(`"""`)
+			l.advance(4)
+			continue
+		}
+		buf.WriteByte(l.src[l.pos])
+		l.advance(1)
+	}
+
+	lines := strings.Split(strings.Replace(buf.String(), "\r\n", "\n", -1), "\n")
+	indent := -1
+	for _, line := range lines[1:] {
+		trimmed := strings.TrimLeft(line, " \t")
+		if n := len(line) - len(trimmed); trimmed != "" && (indent < 0 || n < indent) {
+			indent = n
+		}
+	}
+	if indent > 0 {
+		for i := 1; i < len(lines); i++ {
+			if len(lines[i]) >= indent {
+				lines[i] = lines[i][indent:]
+			} else {
+				lines[i] = strings.TrimLeft(lines[i], " \t")
+			}
+		}
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return token{kind: tokenString, value: strings.Join(lines, "\n"), loc: loc}
+}
+
+func isLetter(c byte) bool { return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') }
+func isDigit(c byte) bool  { return c >= '0' && c <= '9' }
+
+// parser parses a document by recursive descent. Syntax errors are panicked
+// as *Error, and recovered by Parse.
+type parser struct {
+	lexer
+	tok token
+}
+
+func (p *parser) next() token {
+	t := p.tok
+	p.tok = p.lex()
+	return t
+}
+
+// peek returns true if the current token is the punctuator or name s.
+func (p *parser) peek(s string) bool {
+	return (p.tok.kind == tokenPunct || p.tok.kind == tokenName) && p.tok.value == s
+}
+
+// skip consumes the current token and returns true if it is the punctuator
+// or name s.
+func (p *parser) skip(s string) bool {
+	if p.peek(s) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(s string) {
+	if !p.skip(s) {
+		p.errorf(p.tok.loc, "expected %q, found %s", s, p.tok)
+	}
+}
+
+func (p *parser) name() string {
+	if p.tok.kind != tokenName {
+		p.errorf(p.tok.loc, "expected name, found %s", p.tok)
+	}
+	return p.next().value
+}
+
+func (p *parser) parseDocument() *Document {
+	doc := &Document{Fragments: make(map[string]*Fragment)}
+	for p.tok.kind != tokenEOF {
+		switch {
+		case p.peek("{"):
+			op := &Operation{Type: "query", Loc: p.tok.loc}
+			op.SelectionSet = p.parseSelectionSet()
+			doc.Operations = append(doc.Operations, op)
+		case p.peek("query"), p.peek("mutation"), p.peek("subscription"):
+			doc.Operations = append(doc.Operations, p.parseOperation())
+		case p.peek("fragment"):
+			loc := p.tok.loc
+			f := p.parseFragment()
+			if _, ok := doc.Fragments[f.Name]; ok {
+				p.errorf(loc, "fragment %q is defined more than once", f.Name)
+			}
+			doc.Fragments[f.Name] = f
+		default:
+			p.errorf(p.tok.loc, "expected operation or fragment, found %s", p.tok)
+		}
+	}
+	if len(doc.Operations) == 0 {
+		p.errorf(p.tok.loc, "document has no operations")
+	}
+	return doc
+}
+
+func (p *parser) parseOperation() *Operation {
+	op := &Operation{Loc: p.tok.loc}
+	op.Type = p.next().value
+	if p.tok.kind == tokenName {
+		op.Name = p.name()
+	}
+	if p.skip("(") {
+		for !p.skip(")") {
+			p.expect("$")
+			v := &VariableDefinition{Name: p.name()}
+			p.expect(":")
+			v.Type = p.parseType()
+			if p.skip("=") {
+				v.Default, v.HasDefault = p.parseValue(true), true
+			}
+			p.parseDirectives()
+			op.Variables = append(op.Variables, v)
+		}
+	}
+	p.parseDirectives()
+	op.SelectionSet = p.parseSelectionSet()
+	return op
+}
+
+func (p *parser) parseType() string {
+	var typ string
+	if p.skip("[") {
+		typ = "[" + p.parseType() + "]"
+		p.expect("]")
+	} else {
+		typ = p.name()
+	}
+	if p.skip("!") {
+		typ += "!"
+	}
+	return typ
+}
+
+func (p *parser) parseFragment() *Fragment {
+	p.expect("fragment")
+	f := &Fragment{}
+	if p.peek("on") {
+		p.errorf(p.tok.loc, "fragment must be named")
+	}
+	f.Name = p.name()
+	p.expect("on")
+	f.TypeCondition = p.name()
+	p.parseDirectives()
+	f.SelectionSet = p.parseSelectionSet()
+	return f
+}
+
+func (p *parser) parseSelectionSet() []Selection {
+	p.expect("{")
+	var sels []Selection
+	for !p.skip("}") {
+		sels = append(sels, p.parseSelection())
+	}
+	if len(sels) == 0 {
+		p.errorf(p.tok.loc, "selection set is empty")
+	}
+	return sels
+}
+
+func (p *parser) parseSelection() Selection {
+	loc := p.tok.loc
+	if !p.skip("...") {
+		return p.parseField()
+	}
+	if p.tok.kind == tokenName && !p.peek("on") {
+		return &FragmentSpread{Name: p.name(), Directives: p.parseDirectives(), Loc: loc}
+	}
+	f := &InlineFragment{}
+	if p.skip("on") {
+		f.TypeCondition = p.name()
+	}
+	f.Directives = p.parseDirectives()
+	f.SelectionSet = p.parseSelectionSet()
+	return f
+}
+
+func (p *parser) parseField() *Field {
+	f := &Field{Loc: p.tok.loc}
+	f.Name = p.name()
+	if p.skip(":") {
+		f.Alias, f.Name = f.Name, p.name()
+	}
+	f.Arguments = p.parseArguments(false)
+	f.Directives = p.parseDirectives()
+	if p.peek("{") {
+		f.SelectionSet = p.parseSelectionSet()
+	}
+	return f
+}
+
+func (p *parser) parseArguments(constant bool) []*Argument {
+	if !p.skip("(") {
+		return nil
+	}
+	var args []*Argument
+	for !p.skip(")") {
+		arg := &Argument{Name: p.name()}
+		p.expect(":")
+		arg.Value = p.parseValue(constant)
+		args = append(args, arg)
+	}
+	return args
+}
+
+func (p *parser) parseDirectives() []*Directive {
+	var dirs []*Directive
+	for p.skip("@") {
+		dirs = append(dirs, &Directive{Name: p.name(), Arguments: p.parseArguments(false)})
+	}
+	return dirs
+}
+
+// parseValue parses a value. Constant values may not refer to variables.
+func (p *parser) parseValue(constant bool) interface{} {
+	t := p.tok
+	switch t.kind {
+	case tokenInt:
+		p.next()
+		n, err := strconv.ParseInt(t.value, 10, 64)
+		if err != nil {
+			p.errorf(t.loc, "integer %s is out of range", t.value)
+		}
+		return n
+	case tokenFloat:
+		p.next()
+		f, err := strconv.ParseFloat(t.value, 64)
+		if err != nil {
+			p.errorf(t.loc, "float %s is out of range", t.value)
+		}
+		return f
+	case tokenString:
+		p.next()
+		return t.value
+	case tokenName:
+		p.next()
+		switch t.value {
+		case "true":
+			return true
+		case "false":
+			return false
+		case "null":
+			return nil
+		}
+		return Enum(t.value)
+	}
+
+	switch {
+	case p.skip("$"):
+		if constant {
+			p.errorf(t.loc, "unexpected variable in constant value")
+		}
+		return Variable(p.name())
+	case p.skip("["):
+		list := []interface{}{}
+		for !p.skip("]") {
+			list = append(list, p.parseValue(constant))
+		}
+		return list
+	case p.skip("{"):
+		obj := map[string]interface{}{}
+		for !p.skip("}") {
+			name := p.name()
+			p.expect(":")
+			obj[name] = p.parseValue(constant)
+		}
+		return obj
+	}
+	p.errorf(t.loc, "expected value, found %s", t)
+	return nil
+}
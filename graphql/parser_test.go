@@ -0,0 +1,117 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pilosa/pilosa/v2/graphql"
+)
+
+// Ensure the parser can parse GraphQL documents.
+func TestParse(t *testing.T) {
+	t.Run("Shorthand", func(t *testing.T) {
+		doc, err := graphql.Parse(`{ indexes { name } }`)
+		if err != nil {
+			t.Fatal(err)
+		} else if len(doc.Operations) != 1 || doc.Operations[0].Type != "query" {
+			t.Fatalf("unexpected operations: %#v", doc.Operations)
+		}
+		f := doc.Operations[0].SelectionSet[0].(*graphql.Field)
+		if f.Name != "indexes" || f.SelectionSet[0].(*graphql.Field).Name != "name" {
+			t.Fatalf("unexpected field: %#v", f)
+		}
+	})
+
+	t.Run("Operation", func(t *testing.T) {
+		doc, err := graphql.Parse(`
+			# Counts a row.
+			query Count($index: String!, $n: Int = 10) {
+				idx: index(name: $index) {
+					count(query: "Row(f=1)")
+					... on Index { name }
+					...Fields @skip(if: false)
+				}
+			}
+			fragment Fields on Index { fields { name } }
+		`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		op := doc.Operations[0]
+		if op.Name != "Count" || len(op.Variables) != 2 {
+			t.Fatalf("unexpected operation: %#v", op)
+		} else if v := op.Variables[1]; v.Type != "Int" || !v.HasDefault || v.Default != int64(10) {
+			t.Fatalf("unexpected variable: %#v", v)
+		}
+		f := op.SelectionSet[0].(*graphql.Field)
+		if f.ResponseKey() != "idx" || f.Name != "index" || f.Arguments[0].Value != graphql.Variable("index") {
+			t.Fatalf("unexpected field: %#v", f)
+		} else if f.Loc != (graphql.Location{Line: 4, Column: 5}) {
+			t.Fatalf("unexpected location: %#v", f.Loc)
+		}
+		if _, ok := f.SelectionSet[1].(*graphql.InlineFragment); !ok {
+			t.Fatalf("expected inline fragment: %#v", f.SelectionSet[1])
+		} else if s, ok := f.SelectionSet[2].(*graphql.FragmentSpread); !ok || s.Name != "Fields" || s.Directives[0].Name != "skip" {
+			t.Fatalf("unexpected fragment spread: %#v", f.SelectionSet[2])
+		} else if frag := doc.Fragments["Fields"]; frag == nil || frag.TypeCondition != "Index" {
+			t.Fatalf("unexpected fragment: %#v", frag)
+		}
+	})
+
+	t.Run("Values", func(t *testing.T) {
+		doc, err := graphql.Parse(`{ f(a: -12, b: 1.5e3, c: "x\né", d: true, e: null, f: ENUM, g: [1, "two"], h: {k: false}, i: """
+			block
+			  string
+		""") }`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		exp := []interface{}{
+			int64(-12), 1500.0, "x\né", true, nil, graphql.Enum("ENUM"),
+			[]interface{}{int64(1), "two"}, map[string]interface{}{"k": false}, "block\n  string",
+		}
+		args := doc.Operations[0].SelectionSet[0].(*graphql.Field).Arguments
+		for i, arg := range args {
+			if !reflect.DeepEqual(arg.Value, exp[i]) {
+				t.Fatalf("unexpected value of %s: %#v", arg.Name, arg.Value)
+			}
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		for _, test := range []struct {
+			doc string
+			msg string
+			loc graphql.Location
+		}{
+			{doc: ``, msg: "syntax error: document has no operations", loc: graphql.Location{Line: 1, Column: 1}},
+			{doc: `{ a `, msg: `syntax error: expected name, found end of document`, loc: graphql.Location{Line: 1, Column: 5}},
+			{doc: "{\n  a(x: 01) }", msg: `syntax error: invalid number, unexpected digit after 0`, loc: graphql.Location{Line: 2, Column: 8}},
+			{doc: `{ a(x: "open) }`, msg: `syntax error: unterminated string`, loc: graphql.Location{Line: 1, Column: 8}},
+			{doc: `{ }`, msg: `syntax error: selection set is empty`, loc: graphql.Location{Line: 1, Column: 4}},
+			{doc: `query Q($v: Int = $w) { a }`, msg: `syntax error: unexpected variable in constant value`, loc: graphql.Location{Line: 1, Column: 19}},
+			{doc: `{ a } fragment F on T { a } fragment F on T { b }`, msg: `syntax error: fragment "F" is defined more than once`, loc: graphql.Location{Line: 1, Column: 29}},
+		} {
+			_, err := graphql.Parse(test.doc)
+			if e, ok := err.(*graphql.Error); !ok {
+				t.Fatalf("%q: unexpected error: %#v", test.doc, err)
+			} else if e.Message != test.msg || !reflect.DeepEqual(e.Locations, []graphql.Location{test.loc}) {
+				t.Fatalf("%q: unexpected error: %s at %v", test.doc, e.Message, e.Locations)
+			}
+		}
+	})
+}
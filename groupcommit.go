@@ -0,0 +1,168 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// groupCommit coalesces the concurrent single-bit writes to a fragment. The
+// first write to arrive leads: it waits for the window for others to join,
+// then applies the whole batch with one acquisition of the fragment's lock
+// and one append to its op log. Writes which arrive while a batch is being
+// applied form the next batch, led by the first of them.
+type groupCommit struct {
+	mu      sync.Mutex
+	pending []*setBitOp
+	leading bool
+}
+
+// setBitOp is a single-bit write waiting to be applied by a group commit.
+type setBitOp struct {
+	rowID    uint64
+	columnID uint64
+	changed  bool
+	err      error
+
+	// Receives true if the write should lead the next batch, or false
+	// once it has been applied by another write's batch.
+	done chan bool
+}
+
+// setBitGrouped sets a bit as part of a group commit.
+func (f *fragment) setBitGrouped(rowID, columnID uint64) (changed bool, err error) {
+	c := &f.commits
+	op := &setBitOp{rowID: rowID, columnID: columnID, done: make(chan bool, 1)}
+
+	c.mu.Lock()
+	c.pending = append(c.pending, op)
+	if c.leading {
+		c.mu.Unlock()
+		if lead := <-op.done; !lead {
+			return op.changed, op.err
+		}
+	} else {
+		c.leading = true
+		c.mu.Unlock()
+	}
+
+	// Wait for other writes to join the batch, which includes this one.
+	time.Sleep(f.groupCommitWindow)
+	c.mu.Lock()
+	ops := c.pending
+	c.pending = nil
+	c.mu.Unlock()
+
+	f.commitSetBits(ops)
+
+	// Hand the lead to the first write which arrived during the commit.
+	c.mu.Lock()
+	if len(c.pending) > 0 {
+		c.pending[0].done <- true
+	} else {
+		c.leading = false
+	}
+	c.mu.Unlock()
+
+	for _, o := range ops {
+		if o != op {
+			o.done <- false
+		}
+	}
+	return op.changed, op.err
+}
+
+// commitSetBits applies a batch of single-bit writes, recording in each
+// whether it changed the fragment. A bit written more than once in the batch
+// is only changed by the first write.
+func (f *fragment) commitSetBits(ops []*setBitOp) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	mustClose, err := f.reopen()
+	if err != nil {
+		for _, op := range ops {
+			op.err = errors.Wrap(err, "reopening")
+		}
+		return
+	}
+	if mustClose {
+		defer f.safeClose()
+	}
+
+	positions := make([]uint64, 0, len(ops))
+	changed := make([]*setBitOp, 0, len(ops))
+	batch := make(map[uint64]struct{}, len(ops))
+	for _, op := range ops {
+		pos, err := f.pos(op.rowID, op.columnID)
+		if err != nil {
+			op.err = errors.Wrap(err, "getting bit pos")
+			continue
+		}
+		if _, ok := batch[pos]; ok || f.storage.Contains(pos) {
+			continue
+		}
+		batch[pos] = struct{}{}
+		positions = append(positions, pos)
+		changed = append(changed, op)
+	}
+	if len(positions) == 0 {
+		return
+	}
+
+	// Write to storage.
+	if _, err := f.storage.AddN(positions...); err != nil {
+		for _, op := range changed {
+			op.err = errors.Wrap(err, "writing")
+		}
+		return
+	}
+	syncErr := f.incrementOpN(len(positions))
+	f.stats.Count("setBit", int64(len(positions)), 0.001)
+	f.stats.Count("groupCommit", 1, 0.001)
+
+	// Update the caches of each row which changed, as setBit does.
+	rows := make(map[uint64]struct{})
+	for _, op := range changed {
+		op.changed = true
+		rows[op.rowID] = struct{}{}
+		if f.recordsBits() {
+			f.recordChange(ChangeEvent{Type: ChangeTypeSet, RowID: op.rowID, ColumnID: op.columnID})
+		}
+	}
+	for rowID := range rows {
+		delete(f.checksums, int(rowID/HashBlockSize))
+		if f.CacheType != CacheTypeNone {
+			n := f.storage.CountRange(rowID*ShardWidth, (rowID+1)*ShardWidth)
+			f.cache.Add(rowID, n)
+		}
+		f.rowCache.Add(rowID, nil)
+		if rowID > f.maxRowID {
+			f.maxRowID = rowID
+			f.stats.Gauge("rows", float64(f.maxRowID), 1.0)
+		}
+	}
+	f.newGeneration()
+
+	// The writes fail if they couldn't be flushed, once the caches reflect
+	// them.
+	if syncErr != nil {
+		for _, op := range changed {
+			op.changed, op.err = false, syncErr
+		}
+	}
+}
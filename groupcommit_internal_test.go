@@ -0,0 +1,69 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Ensure concurrent single-bit writes are grouped, and each bit is only
+// reported as changed by one of the writes which set it.
+func TestFragment_SetBit_GroupCommit(t *testing.T) {
+	f := mustOpenFragment("i", "f", viewStandard, 0, "")
+	defer f.Clean(t)
+	f.groupCommitWindow = 5 * time.Millisecond
+
+	// Every bit is written twice.
+	var wg sync.WaitGroup
+	var changed int64
+	errs := make(chan error, 200)
+	for i := 0; i < 200; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			j := uint64(i / 2)
+			rowID, columnID := j%4, j
+			if ok, err := f.setBit(rowID, columnID); err != nil {
+				errs <- err
+			} else if ok {
+				atomic.AddInt64(&changed, 1)
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+
+	if changed != 100 {
+		t.Fatalf("unexpected changed writes: %d", changed)
+	}
+	for rowID := uint64(0); rowID < 4; rowID++ {
+		if n := f.row(rowID).Count(); n != 25 {
+			t.Fatalf("unexpected count of row %d: %d", rowID, n)
+		}
+	}
+
+	// A bit already set is not changed.
+	if ok, err := f.setBit(0, 0); err != nil {
+		t.Fatal(err)
+	} else if ok {
+		t.Fatal("expected bit to be unchanged")
+	}
+}
@@ -42,6 +42,23 @@ type QueryRequest struct {
 	// If true, indicates that query is part of a larger distributed query.
 	// If false, this request is on the originating node.
 	Remote bool
+
+	// Scheduling priority of the query, either "interactive" or "batch".
+	// If empty, the query is interactive.
+	Priority string
+
+	// Include a profile of the query's execution in the response, if true.
+	Profile bool
+
+	// Execute every call even if some fail, if true. The result of a call
+	// which failed is a CallError, and the other calls' results are
+	// returned. Otherwise, the query fails with the first call to fail.
+	ContinueOnError bool
+
+	// Replicas to read shards from in preference to their primary owners:
+	// "local" for the node the query is sent to, or "zone:" followed by the
+	// name of a zone. If empty, shards are read from their primary owners.
+	Affinity string
 }
 
 // QueryResponse represent a response from a processed query.
@@ -55,22 +72,27 @@ type QueryResponse struct {
 
 	// Error during parsing or execution.
 	Err error
+
+	// Breakdown of the query's execution, if requested.
+	Profile *QueryProfile
 }
 
 // MarshalJSON marshals QueryResponse into a JSON-encoded byte slice
 func (resp *QueryResponse) MarshalJSON() ([]byte, error) {
 	if resp.Err != nil {
 		return json.Marshal(struct {
-			Err string `json:"error"`
-		}{Err: resp.Err.Error()})
+			Err *ResponseError `json:"error"`
+		}{Err: NewResponseError(resp.Err)})
 	}
 
 	return json.Marshal(struct {
 		Results        []interface{}    `json:"results"`
 		ColumnAttrSets []*ColumnAttrSet `json:"columnAttrs,omitempty"`
+		Profile        *QueryProfile    `json:"profile,omitempty"`
 	}{
 		Results:        resp.Results,
 		ColumnAttrSets: resp.ColumnAttrSets,
+		Profile:        resp.Profile,
 	})
 }
 
@@ -138,6 +160,14 @@ type BlockDataRequest struct {
 	View  string
 	Shard uint64
 	Block uint64
+
+	// Checksums requests the checksums of the block's containers
+	// rather than its data.
+	Checksums bool
+
+	// Containers limits the data returned to the block's containers
+	// with these keys, if set.
+	Containers []uint64
 }
 
 // BlockDataResponse is the structured response of a block
@@ -145,6 +175,10 @@ type BlockDataRequest struct {
 type BlockDataResponse struct {
 	RowIDs    []uint64
 	ColumnIDs []uint64
+
+	// Checksums are the checksums of the block's containers, if they
+	// were requested.
+	Checksums []ContainerChecksum
 }
 
 // TranslateKeysRequest describes the structure of a request
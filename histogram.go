@@ -0,0 +1,127 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"math"
+	"math/bits"
+	"sort"
+)
+
+// Defaults for FieldHistogramOptions.
+const (
+	defaultHistogramTop    = 10
+	defaultHistogramSample = 10000
+)
+
+// FieldHistogram is the distribution of the cardinalities of the rows of a
+// field, and its heaviest rows, which shows skew in the field.
+type FieldHistogram struct {
+	// Rows is the number of rows with any columns set.
+	Rows uint64 `json:"rows"`
+
+	// Cached is the number of rows whose cardinalities were read from the
+	// field's caches, and Scanned the number of the remaining rows which
+	// were counted. If they add up to less than Rows, the scanned rows were
+	// a sample, and the bucket counts are estimates.
+	Cached  uint64 `json:"cached"`
+	Scanned uint64 `json:"scanned"`
+
+	Buckets  []HistogramBucket `json:"buckets"`
+	Heaviest []Pair            `json:"heaviest"`
+}
+
+// HistogramBucket is the number of rows with between Min and Max columns set,
+// inclusive.
+type HistogramBucket struct {
+	Min  uint64 `json:"min"`
+	Max  uint64 `json:"max"`
+	Rows uint64 `json:"rows"`
+}
+
+// FieldHistogramOptions are the options of API.FieldHistogram.
+type FieldHistogramOptions struct {
+	// Top is the number of heaviest rows returned.
+	Top int
+
+	// Sample is the most rows which are not in the field's caches to count.
+	Sample int
+}
+
+// sampleRowIDs returns n of the sorted ids, evenly spaced, or all of them if
+// there are no more than n.
+func sampleRowIDs(ids []uint64, n int) []uint64 {
+	if len(ids) <= n {
+		return ids
+	}
+	sample := make([]uint64, n)
+	for i := range sample {
+		sample[i] = ids[i*len(ids)/n]
+	}
+	return sample
+}
+
+// newFieldHistogram returns the histogram of a field with the given number of
+// rows, from the cardinalities of the cached rows and of those scanned, each
+// of which stands for an equal share of the rows which are not cached.
+// Bucket i holds the rows with between 2^i and 2^(i+1)-1 columns set.
+func newFieldHistogram(rows uint64, cached, scanned []Pair, top int) *FieldHistogram {
+	h := &FieldHistogram{
+		Rows:    rows,
+		Cached:  uint64(len(cached)),
+		Scanned: uint64(len(scanned)),
+	}
+
+	weight := 1.0
+	if len(scanned) > 0 && rows > h.Cached {
+		weight = float64(rows-h.Cached) / float64(len(scanned))
+	}
+	var weights []float64
+	add := func(count uint64, w float64) {
+		if count == 0 {
+			return
+		}
+		i := bits.Len64(count) - 1
+		for len(weights) <= i {
+			weights = append(weights, 0)
+		}
+		weights[i] += w
+	}
+	for _, p := range cached {
+		add(p.Count, 1)
+	}
+	for _, p := range scanned {
+		add(p.Count, weight)
+	}
+
+	h.Buckets = make([]HistogramBucket, len(weights))
+	for i, w := range weights {
+		h.Buckets[i] = HistogramBucket{
+			Min:  1 << uint(i),
+			Max:  1<<uint(i) - 1 + 1<<uint(i),
+			Rows: uint64(math.Round(w)),
+		}
+	}
+
+	heaviest := make([]Pair, 0, len(cached)+len(scanned))
+	heaviest = append(heaviest, cached...)
+	heaviest = append(heaviest, scanned...)
+	sort.Stable(Pairs(heaviest))
+	if len(heaviest) > top {
+		heaviest = heaviest[:top]
+	}
+	h.Heaviest = heaviest
+	return h
+}
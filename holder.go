@@ -24,6 +24,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -39,6 +40,10 @@ const (
 	// defaultCacheFlushInterval is the default value for Fragment.CacheFlushInterval.
 	defaultCacheFlushInterval = 1 * time.Minute
 
+	// defaultMemoryCheckInterval is the default interval at which fragment
+	// heap usage is checked against the memory limit.
+	defaultMemoryCheckInterval = 10 * time.Second
+
 	// fileLimit is the maximum open file limit (ulimit -n) to automatically set.
 	fileLimit = 262144 // (512^2)
 
@@ -73,10 +78,65 @@ type Holder struct {
 	// The interval at which the cached row ids are persisted to disk.
 	cacheFlushInterval time.Duration
 
+	// The approximate number of bytes of heap memory fragments may use
+	// before idle fragments are released, checked every memoryCheckInterval.
+	// Zero means no limit.
+	memoryLimit         int64
+	memoryCheckInterval time.Duration
+
+	// Open spends up to cacheWarmupTimeout warming the caches of
+	// fragments, using cacheWarmupConcurrency goroutines. Zero disables
+	// warming.
+	cacheWarmupTimeout     time.Duration
+	cacheWarmupConcurrency int
+
+	// If lazyFragments is set, Open only finds the fragments of each view,
+	// and their data is loaded when they are first accessed.
+	lazyFragments bool
+
+	// Counts the fragments opened by Open, for reporting startup progress.
+	progress *openProgress
+
+	// The number of fragment data files which may be held open at once,
+	// beyond which the least recently written are closed. Zero means no
+	// limit.
+	maxOpenFragmentFiles int
+	filePool             *filePool
+
+	// Single-bit writes to a fragment arriving within this window of each
+	// other are applied together. Zero disables grouping.
+	groupCommitWindow time.Duration
+
+	// When the op logs of fragment files are flushed to disk. See
+	// FsyncAlways, FsyncInterval and FsyncNever.
+	fsyncPolicy   string
+	fsyncInterval time.Duration
+	fsyncer       *fsyncer
+
+	// Records the fragments found to be corrupt, whose files are moved
+	// out of the way.
+	quarantine *quarantine
+
 	Logger logger.Logger
 
 	snapshotQueue chan *fragment
 
+	// changes records local mutations for change data capture. It is nil
+	// unless enabled by the server.
+	changes *changeLog
+
+	// Alternative names for indexes and fields, resolved by queries.
+	aliases *aliasStore
+
+	// Queries stored on every node and executed by name.
+	namedQueries *namedQueryStore
+
+	// Named queries run on a cron schedule.
+	schedules *scheduleStore
+
+	// Reads and writes of each shard on this node, for rebalancing.
+	shardLoads *shardLoadTracker
+
 	// Manages replication from the primary node.
 	primaryTranslateNode     *Node
 	translateStoreReplicator *holderTranslateStoreReplicator
@@ -125,7 +185,16 @@ func NewHolder() *Holder {
 
 		NewAttrStore: newNopAttrStore,
 
-		cacheFlushInterval: defaultCacheFlushInterval,
+		cacheFlushInterval:     defaultCacheFlushInterval,
+		memoryCheckInterval:    defaultMemoryCheckInterval,
+		cacheWarmupConcurrency: 1,
+
+		progress: &openProgress{},
+
+		aliases:      newAliasStore(),
+		namedQueries: newNamedQueryStore(),
+		schedules:    newScheduleStore(),
+		shardLoads:   newShardLoadTracker(),
 
 		Logger: logger.NopLogger,
 
@@ -156,38 +225,73 @@ func (h *Holder) Open() error {
 	if err != nil {
 		return errors.Wrap(err, "reading directory")
 	}
+	h.progress.begin(countFragmentFiles(h.Path))
 
 	// Run snapshots asynchronously. The snapshotQueue will have a background
 	// task associated with it which flushes it and waits until this channel
 	// is closed, so we should always close this channel when done.
 	h.snapshotQueue = newSnapshotQueue(100, 2, h.Logger)
 
+	// Close the files of the least recently written fragments while over
+	// the limit, including while opening them.
+	h.filePool = nil
+	if h.maxOpenFragmentFiles > 0 {
+		h.filePool = newFilePool(h.maxOpenFragmentFiles)
+		h.filePool.stats = h.Stats
+		h.filePool.logger = h.Logger
+		h.wg.Add(1)
+		go func() { defer h.wg.Done(); h.filePool.run(h.closing) }()
+	}
+
+	// Flush fragment files to disk as set by the fsync policy.
+	h.fsyncer = nil
+	if h.fsyncPolicy == FsyncAlways || h.fsyncPolicy == FsyncInterval {
+		h.fsyncer = newFsyncer(h.fsyncPolicy, h.fsyncInterval)
+		h.fsyncer.stats = h.Stats
+		h.fsyncer.logger = h.Logger
+		if h.fsyncPolicy == FsyncInterval {
+			h.wg.Add(1)
+			go func() { defer h.wg.Done(); h.fsyncer.run(h.closing) }()
+		}
+	}
+
+	h.quarantine = newQuarantine(filepath.Join(h.Path, quarantineDir))
+	h.quarantine.logger = h.Logger
+	if err := h.quarantine.load(); err != nil {
+		return errors.Wrap(err, "loading quarantined fragments")
+	}
+
+	if err := h.aliases.open(filepath.Join(h.Path, aliasesFile)); err != nil {
+		return errors.Wrap(err, "opening aliases file")
+	}
+	if err := h.namedQueries.open(filepath.Join(h.Path, namedQueriesFile)); err != nil {
+		return errors.Wrap(err, "opening named queries file")
+	}
+	if err := h.schedules.open(filepath.Join(h.Path, schedulesFile)); err != nil {
+		return errors.Wrap(err, "opening schedules file")
+	}
+
 	for _, fi := range fis {
 		// Skip files or hidden directories.
 		if !fi.IsDir() || strings.HasPrefix(fi.Name(), ".") {
 			continue
 		}
 
-		h.Logger.Printf("opening index: %s", filepath.Base(fi.Name()))
-
-		index, err := h.newIndex(h.IndexPath(filepath.Base(fi.Name())), filepath.Base(fi.Name()))
-		if errors.Cause(err) == ErrName {
-			h.Logger.Printf("ERROR opening index: %s, err=%s", fi.Name(), err)
+		// Open the indexes of a namespace from its directory.
+		if strings.HasPrefix(fi.Name(), namespaceDirPrefix) {
+			if err := h.openNamespace(strings.TrimPrefix(fi.Name(), namespaceDirPrefix)); err != nil {
+				return err
+			}
 			continue
-		} else if err != nil {
-			return errors.Wrap(err, "opening index")
 		}
 
-		if err := index.Open(); err != nil {
-			if err == ErrName {
-				h.Logger.Printf("ERROR opening index: %s, err=%s", index.Name(), err)
-				continue
-			}
-			return fmt.Errorf("open index: name=%s, err=%s", index.Name(), err)
+		if err := h.openIndex(filepath.Base(fi.Name())); err != nil {
+			return err
 		}
-		h.mu.Lock()
-		h.indexes[index.Name()] = index
-		h.mu.Unlock()
+	}
+
+	if h.cacheWarmupTimeout > 0 {
+		h.warmCaches()
 	}
 	h.Logger.Printf("open holder: complete")
 
@@ -195,12 +299,62 @@ func (h *Holder) Open() error {
 	h.wg.Add(1)
 	go func() { defer h.wg.Done(); h.monitorCacheFlush() }()
 
+	// Periodically release idle fragments if over the memory limit.
+	if h.memoryLimit > 0 {
+		h.wg.Add(1)
+		go func() { defer h.wg.Done(); h.monitorMemory() }()
+	}
+
 	h.Stats.Open()
 
+	h.progress.finish()
 	h.opened.Close()
 	return nil
 }
 
+// openIndex opens an index found in the data directory. Indexes with invalid
+// names are logged and skipped.
+func (h *Holder) openIndex(name string) error {
+	h.Logger.Printf("opening index: %s", name)
+
+	index, err := h.newIndex(h.IndexPath(name), name)
+	if errors.Cause(err) == ErrName {
+		h.Logger.Printf("ERROR opening index: %s, err=%s", name, err)
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "opening index")
+	}
+
+	if err := index.Open(); err != nil {
+		if err == ErrName {
+			h.Logger.Printf("ERROR opening index: %s, err=%s", index.Name(), err)
+			return nil
+		}
+		return fmt.Errorf("open index: name=%s, err=%s", index.Name(), err)
+	}
+	h.mu.Lock()
+	h.indexes[index.Name()] = index
+	h.mu.Unlock()
+	return nil
+}
+
+// openNamespace opens the indexes found in the directory of a namespace.
+func (h *Holder) openNamespace(namespace string) error {
+	fis, err := ioutil.ReadDir(filepath.Join(h.Path, namespaceDirPrefix+namespace))
+	if err != nil {
+		return errors.Wrap(err, "reading namespace directory")
+	}
+	for _, fi := range fis {
+		if !fi.IsDir() || strings.HasPrefix(fi.Name(), ".") {
+			continue
+		}
+		if err := h.openIndex(namespace + namespaceSep + fi.Name()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Close closes all open fragments.
 func (h *Holder) Close() error {
 	h.Stats.Close()
@@ -263,7 +417,7 @@ func (h *Holder) HasData() (bool, error) {
 	}
 
 	for _, fi := range fis {
-		if !fi.IsDir() {
+		if !fi.IsDir() || strings.HasPrefix(fi.Name(), ".") {
 			continue
 		}
 		return true, nil
@@ -284,7 +438,7 @@ func (h *Holder) availableShardsByIndex() map[string]*roaring.Bitmap {
 func (h *Holder) Schema() []*IndexInfo {
 	var a []*IndexInfo
 	for _, index := range h.Indexes() {
-		di := &IndexInfo{Name: index.Name()}
+		di := &IndexInfo{Name: index.Name(), ReadOnly: index.ReadOnly()}
 		for _, field := range index.Fields() {
 			fi := &FieldInfo{Name: field.Name(), Options: field.Options()}
 			for _, view := range field.views() {
@@ -307,6 +461,7 @@ func (h *Holder) limitedSchema() []*IndexInfo {
 		di := &IndexInfo{
 			Name:       index.Name(),
 			Options:    index.Options(),
+			ReadOnly:   index.ReadOnly(),
 			ShardWidth: ShardWidth,
 		}
 		for _, field := range index.Fields() {
@@ -331,6 +486,12 @@ func (h *Holder) applySchema(schema *Schema) error {
 		if err != nil {
 			return errors.Wrap(err, "creating index")
 		}
+		// Indexes are only made writable by IndexReadOnlyMessage.
+		if index.ReadOnly {
+			if err := idx.setReadOnly(true); err != nil {
+				return errors.Wrap(err, "marking index read-only")
+			}
+		}
 		// Create fields that don't exist.
 		for _, f := range index.Fields {
 			field, err := idx.createFieldIfNotExists(f.Name, f.Options)
@@ -349,8 +510,14 @@ func (h *Holder) applySchema(schema *Schema) error {
 	return nil
 }
 
-// IndexPath returns the path where a given index is stored.
-func (h *Holder) IndexPath(name string) string { return filepath.Join(h.Path, name) }
+// IndexPath returns the path where a given index is stored. The indexes of a
+// namespace are stored in the namespace's directory.
+func (h *Holder) IndexPath(name string) string {
+	if namespace, name := splitIndexName(name); namespace != "" {
+		return filepath.Join(h.Path, namespaceDirPrefix+namespace, name)
+	}
+	return filepath.Join(h.Path, name)
+}
 
 // Index returns the index by name.
 func (h *Holder) Index(name string) *Index {
@@ -359,6 +526,15 @@ func (h *Holder) Index(name string) *Index {
 	return h.index(name)
 }
 
+// validateIndexWritable returns ErrIndexReadOnly if the index has been marked
+// read-only.
+func (h *Holder) validateIndexWritable(indexName string) error {
+	if index := h.Index(indexName); index != nil && index.ReadOnly() {
+		return errors.Wrapf(ErrIndexReadOnly, "index %s", indexName)
+	}
+	return nil
+}
+
 func (h *Holder) index(name string) *Index { return h.indexes[name] }
 
 // Indexes returns a list of all indexes in the holder.
@@ -382,7 +558,7 @@ func (h *Holder) CreateIndex(name string, opt IndexOptions) (*Index, error) {
 
 	// Ensure index doesn't already exist.
 	if h.index(name) != nil {
-		return nil, newConflictError(ErrIndexExists)
+		return nil, NewConflictError(ErrIndexExists)
 	}
 	return h.createIndex(name, opt)
 }
@@ -414,6 +590,12 @@ func (h *Holder) createIndex(name string, opt IndexOptions) (*Index, error) {
 
 	index.keys = opt.Keys
 	index.trackExistence = opt.TrackExistence
+	if opt.InMemory {
+		index.setInMemory()
+	}
+	if err := index.setTimeZone(opt.TimeZone); err != nil {
+		return nil, errors.Wrap(err, "setting time zone")
+	}
 
 	if err = index.Open(); err != nil {
 		return nil, errors.Wrap(err, "opening")
@@ -438,10 +620,20 @@ func (h *Holder) newIndex(path, name string) (*Index, error) {
 	}
 	index.logger = h.Logger
 	index.Stats = h.Stats.WithTags(fmt.Sprintf("index:%s", index.Name()))
+	if namespace := IndexNamespace(index.Name()); namespace != "" {
+		index.Stats = index.Stats.WithTags(fmt.Sprintf("namespace:%s", namespace))
+	}
 	index.broadcaster = h.broadcaster
 	index.newAttrStore = h.NewAttrStore
 	index.columnAttrs = h.NewAttrStore(filepath.Join(index.path, ".data"))
 	index.snapshotQueue = h.snapshotQueue
+	index.lazyFragments = h.lazyFragments
+	index.progress = h.progress
+	index.filePool = h.filePool
+	index.groupCommitWindow = h.groupCommitWindow
+	index.fsyncer = h.fsyncer
+	index.quarantine = h.quarantine
+	index.changes = h.changes
 	index.holder = h
 	index.OpenTranslateStore = h.OpenTranslateStore
 	return index, nil
@@ -463,9 +655,17 @@ func (h *Holder) DeleteIndex(name string) error {
 		return errors.Wrap(err, "closing")
 	}
 
-	// Delete index directory.
-	if err := os.RemoveAll(h.IndexPath(name)); err != nil {
-		return errors.Wrap(err, "removing directory")
+	// Delete index directory, and that of its namespace once empty.
+	if !index.inMemory {
+		if err := os.RemoveAll(h.IndexPath(name)); err != nil {
+			return errors.Wrap(err, "removing directory")
+		}
+		if IndexNamespace(name) != "" {
+			_ = os.Remove(filepath.Dir(h.IndexPath(name)))
+		}
+	}
+	if err := h.quarantine.drop(name, ""); err != nil {
+		return errors.Wrap(err, "dropping quarantined fragments")
 	}
 
 	// Remove reference.
@@ -521,7 +721,7 @@ func (h *Holder) flushCaches() {
 	for _, index := range h.Indexes() {
 		for _, field := range index.Fields() {
 			for _, view := range field.views() {
-				for _, fragment := range view.allFragments() {
+				for _, fragment := range view.loadedFragments() {
 					select {
 					case <-h.closing:
 						return
@@ -537,6 +737,134 @@ func (h *Holder) flushCaches() {
 	}
 }
 
+// monitorMemory periodically enforces the memory limit.
+// This is run in a goroutine.
+func (h *Holder) monitorMemory() {
+	ticker := time.NewTicker(h.memoryCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-h.closing:
+			return
+		case <-ticker.C:
+			h.enforceMemoryLimit()
+		}
+	}
+}
+
+// enforceMemoryLimit releases the heap memory of the least recently used
+// fragments until the total heap usage of all fragments is under the memory
+// limit. Fragments accessed within the last memoryCheckInterval are not
+// considered idle and are never released.
+func (h *Holder) enforceMemoryLimit() {
+	type fragmentSize struct {
+		frag       *fragment
+		size       int
+		lastAccess time.Time
+	}
+	var frags []fragmentSize
+	var total int64
+	for _, index := range h.Indexes() {
+		for _, field := range index.Fields() {
+			for _, view := range field.views() {
+				for _, frag := range view.loadedFragments() {
+					size := frag.heapSize()
+					total += int64(size)
+					if size > 0 {
+						frags = append(frags, fragmentSize{frag: frag, size: size, lastAccess: frag.lastAccessed()})
+					}
+				}
+			}
+		}
+	}
+	h.Stats.Gauge("fragmentHeapBytes", float64(total), 1.0)
+	if total <= h.memoryLimit {
+		return
+	}
+
+	sort.Slice(frags, func(i, j int) bool { return frags[i].lastAccess.Before(frags[j].lastAccess) })
+	idleBefore := time.Now().Add(-h.memoryCheckInterval)
+	for _, fs := range frags {
+		if total <= h.memoryLimit || fs.lastAccess.After(idleBefore) {
+			break
+		}
+
+		select {
+		case <-h.closing:
+			return
+		default:
+		}
+
+		n, err := fs.frag.release()
+		if err != nil {
+			h.Logger.Printf("ERROR releasing fragment: err=%s, path=%s", err, fs.frag.path)
+			continue
+		}
+		total -= int64(n)
+		h.Stats.Count("fragmentEvictions", 1, 1.0)
+		h.Stats.Count("fragmentEvictedBytes", int64(n), 1.0)
+	}
+	h.Stats.Gauge("fragmentHeapBytes", float64(total), 1.0)
+}
+
+// warmCaches prepares fragments for TopN() queries after they are opened, so
+// that the first queries after a restart are not much slower than later ones.
+// Fragments of newer shards, which are usually queried most, are warmed
+// first, until cacheWarmupTimeout has passed.
+func (h *Holder) warmCaches() {
+	start := time.Now()
+	deadline := start.Add(h.cacheWarmupTimeout)
+
+	var frags []*fragment
+	for _, index := range h.Indexes() {
+		for _, field := range index.Fields() {
+			if view := field.view(viewStandard); view != nil {
+				frags = append(frags, view.allFragments()...)
+			}
+		}
+	}
+	sort.SliceStable(frags, func(i, j int) bool { return frags[i].shard > frags[j].shard })
+
+	concurrency := h.cacheWarmupConcurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var warmed int64
+	var wg sync.WaitGroup
+	work := make(chan *fragment)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for frag := range work {
+				if err := frag.warmCache(); err != nil {
+					h.Logger.Printf("ERROR warming fragment cache: err=%s, path=%s", err, frag.path)
+					continue
+				}
+				atomic.AddInt64(&warmed, 1)
+			}
+		}()
+	}
+
+loop:
+	for _, frag := range frags {
+		if time.Now().After(deadline) {
+			break
+		}
+		select {
+		case work <- frag:
+		case <-h.closing:
+			break loop
+		}
+	}
+	close(work)
+	wg.Wait()
+
+	h.Logger.Printf("warmed caches of %d/%d fragments in %s", warmed, len(frags), time.Since(start))
+}
+
 // recalculateCaches recalculates caches on every index in the holder. This is
 // probably not practical to call in real-world workloads, but makes writing
 // integration tests much eaiser, since one doesn't have to wait 10 seconds
@@ -618,6 +946,40 @@ func (h *Holder) loadNodeID() (string, error) {
 	return nodeID, nil
 }
 
+// saveZone records the zone the node is in. The zone decides which shards
+// the node owns, so it can't be changed while the node holds indexes, as
+// their data would no longer be on the nodes which own it. Nodes which
+// predate zones are in the empty zone.
+func (h *Holder) saveZone(zone string) error {
+	zonePath := filepath.Join(h.Path, ".zone")
+	if err := os.MkdirAll(h.Path, 0777); err != nil {
+		return errors.Wrap(err, "creating directory")
+	}
+
+	buf, err := ioutil.ReadFile(zonePath)
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "reading file")
+	}
+	prev := strings.TrimSpace(string(buf))
+	if prev == zone {
+		return nil
+	}
+
+	fis, err := ioutil.ReadDir(h.Path)
+	if err != nil {
+		return errors.Wrap(err, "reading data dir")
+	}
+	for _, fi := range fis {
+		if fi.IsDir() && !strings.HasPrefix(fi.Name(), ".") {
+			return fmt.Errorf("cannot change zone from %q to %q while the node holds data", prev, zone)
+		}
+	}
+	if err := ioutil.WriteFile(zonePath, []byte(zone), 0600); err != nil {
+		return errors.Wrap(err, "writing file")
+	}
+	return nil
+}
+
 // Log startup time and version to $DATA_DIR/.startup.log
 func (h *Holder) logStartup() error {
 	time, err := time.Now().MarshalText()
@@ -706,7 +1068,7 @@ func (h *Holder) refreshTranslateStoreReplicator() {
 
 	var nodeURL string
 	if node != nil {
-		u := node.URI.URL()
+		u := node.clusterURI().URL()
 		nodeURL = u.String()
 	}
 
@@ -908,9 +1270,17 @@ func (s *holderSyncer) IsClosing() bool {
 }
 
 // SyncHolder compares the holder on host with the local holder and resolves differences.
+// Fragments which were written to since they were last synced are synced
+// first, and fragments which haven't changed since then are skipped; their
+// replicas which have changed sync them instead.
 func (s *holderSyncer) SyncHolder() error {
 	s.mu.Lock() // only allow one instance of SyncHolder to be running at a time
 	defer s.mu.Unlock()
+
+	if err := s.syncDirtyFragments(); err != nil {
+		return err
+	}
+
 	ti := time.Now()
 	// Iterate over schema in sorted order.
 	for _, di := range s.Holder.Schema() {
@@ -955,6 +1325,12 @@ func (s *holderSyncer) SyncHolder() error {
 						return nil
 					}
 
+					// Skip fragments which haven't changed since they were
+					// last synced.
+					if frag := s.Holder.fragment(di.Name, fi.Name, vi.Name, shard); frag != nil && !frag.dirty() {
+						continue
+					}
+
 					// Sync fragment if own it.
 					if err := s.syncFragment(di.Name, fi.Name, vi.Name, shard); err != nil {
 						return fmt.Errorf("fragment sync error: index=%s, field=%s, view=%s, shard=%d, err=%s", di.Name, fi.Name, vi.Name, shard, err)
@@ -971,6 +1347,29 @@ func (s *holderSyncer) SyncHolder() error {
 	return nil
 }
 
+// syncDirtyFragments synchronizes the fragments this node owns which were
+// written to since they were last synced, so that recently written data
+// converges before the rest of the holder is checked.
+func (s *holderSyncer) syncDirtyFragments() error {
+	for _, idx := range s.Holder.Indexes() {
+		for _, f := range idx.Fields() {
+			for _, v := range f.views() {
+				for _, frag := range v.loadedFragments() {
+					if s.IsClosing() {
+						return nil
+					} else if !s.Cluster.ownsShard(s.Node.ID, idx.Name(), frag.shard) || !frag.dirty() {
+						continue
+					}
+					if err := s.syncFragment(idx.Name(), f.Name(), v.name, frag.shard); err != nil {
+						return fmt.Errorf("fragment sync error: index=%s, field=%s, view=%s, shard=%d, err=%s", idx.Name(), f.Name(), v.name, frag.shard, err)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
 // syncIndex synchronizes index attributes with the rest of the cluster.
 func (s *holderSyncer) syncIndex(index string) error {
 	span, ctx := tracing.StartSpanFromContext(context.Background(), "HolderSyncer.syncIndex")
@@ -994,7 +1393,7 @@ func (s *holderSyncer) syncIndex(index string) error {
 	for _, node := range Nodes(s.Cluster.nodes).FilterID(s.Node.ID) {
 		// Retrieve attributes from differing blocks.
 		// Skip update and recomputation if no attributes have changed.
-		m, err := s.Cluster.InternalClient.ColumnAttrDiff(ctx, &node.URI, index, blks)
+		m, err := s.Cluster.InternalClient.ColumnAttrDiff(ctx, node.clusterURI(), index, blks)
 		if err != nil {
 			return errors.Wrap(err, "getting differing blocks")
 		} else if len(m) == 0 {
@@ -1041,7 +1440,7 @@ func (s *holderSyncer) syncField(index, name string) error {
 	for _, node := range Nodes(s.Cluster.nodes).FilterID(s.Node.ID) {
 		// Retrieve attributes from differing blocks.
 		// Skip update and recomputation if no attributes have changed.
-		m, err := s.Cluster.InternalClient.RowAttrDiff(ctx, &node.URI, index, name, blks)
+		m, err := s.Cluster.InternalClient.RowAttrDiff(ctx, node.clusterURI(), index, name, blks)
 		if errors.Cause(err) == ErrFieldNotFound {
 			continue // field not created remotely yet, skip
 		} else if err != nil {
@@ -1086,7 +1485,9 @@ func (s *holderSyncer) syncFragment(index, field, view string, shard uint64) err
 		return errors.Wrap(err, "creating fragment")
 	}
 
-	// Sync fragments together.
+	// Sync fragments together. Writes made while syncing are counted as
+	// unsynced, so the fragment is synced again next time.
+	writeN := frag.writeCount()
 	fs := fragmentSyncer{
 		Fragment: frag,
 		Node:     s.Node,
@@ -1095,7 +1496,10 @@ func (s *holderSyncer) syncFragment(index, field, view string, shard uint64) err
 	}
 	if err := fs.syncFragment(); err != nil {
 		return errors.Wrap(err, "syncing fragment")
+	} else if s.IsClosing() {
+		return nil
 	}
+	frag.markSynced(writeN, frag.Checksum())
 
 	return nil
 }
@@ -15,12 +15,16 @@
 package pilosa
 
 import (
+	"bytes"
+	"errors"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"reflect"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/pilosa/pilosa/v2/roaring"
 )
@@ -321,3 +325,383 @@ func TestHolderCleaner_Reopen(t *testing.T) {
 		t.Fatalf("couldn't close holder: %v", err)
 	}
 }
+
+// Ensure idle fragments are released when over the memory limit.
+func TestHolder_EnforceMemoryLimit(t *testing.T) {
+	h := newHolder()
+	h.memoryLimit = 1
+	h.memoryCheckInterval = time.Hour
+	if err := h.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	h.SetBit("i", "f", 1, 10)
+	h.SetBit("i", "f", 1, ShardWidth+10)
+	idle, active := h.fragment("i", "f", viewStandard, 0), h.fragment("i", "f", viewStandard, 1)
+	idle.row(1)
+	active.row(1)
+	if idle.heapSize() == 0 || active.heapSize() == 0 {
+		t.Fatalf("expected heap usage: idle=%d, active=%d", idle.heapSize(), active.heapSize())
+	}
+
+	atomic.StoreInt64(&idle.lastAccess, time.Now().Add(-2*time.Hour).UnixNano())
+	h.enforceMemoryLimit()
+
+	if n := idle.heapSize(); n != 0 {
+		t.Fatalf("expected idle fragment to be released, heap usage: %d", n)
+	} else if active.heapSize() == 0 {
+		t.Fatal("expected active fragment to be retained")
+	}
+
+	// Released data is reloaded from disk.
+	if a := idle.row(1).Columns(); !reflect.DeepEqual(a, []uint64{10}) {
+		t.Fatalf("unexpected columns: %v", a)
+	}
+}
+
+// Ensure fragment caches are warmed when the holder is opened, rebuilding
+// rank caches which were not saved.
+func TestHolder_WarmCaches(t *testing.T) {
+	h := newHolder()
+	h.cacheWarmupTimeout = time.Minute
+	h.cacheWarmupConcurrency = 2
+	if err := h.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	h.SetBit("i", "f", 1, 10)
+	h.SetBit("i", "f", 1, 11)
+	h.SetBit("i", "f", 2, ShardWidth+10)
+	cachePath := h.fragment("i", "f", viewStandard, 0).cachePath()
+	if err := h.Holder.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// A lost cache file is rebuilt from storage.
+	if err := os.Remove(cachePath); err != nil {
+		t.Fatal(err)
+	} else if err := h.Holder.Open(); err != nil {
+		t.Fatal(err)
+	}
+
+	for shard, rowID := range []uint64{1, 2} {
+		frag := h.fragment("i", "f", viewStandard, uint64(shard))
+		if top := frag.cache.Top(); len(top) != 1 || top[0].ID != rowID {
+			t.Fatalf("shard %d: unexpected rankings: %v", shard, top)
+		} else if _, ok := frag.rowCache.Fetch(rowID); !ok {
+			t.Fatalf("shard %d: expected row %d to be loaded", shard, rowID)
+		}
+	}
+}
+
+// Ensure fragments opened lazily are loaded when first accessed.
+func TestHolder_LazyFragments(t *testing.T) {
+	h := newHolder()
+	if err := h.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	h.SetBit("i", "f", 1, 10)
+	h.SetBit("i", "f", 1, ShardWidth+10)
+	if err := h.Holder.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	h.lazyFragments = true
+	if err := h.Holder.Open(); err != nil {
+		t.Fatal(err)
+	}
+
+	v := h.Index("i").Field("f").view(viewStandard)
+	if frags := v.loadedFragments(); len(frags) != 0 {
+		t.Fatalf("expected no loaded fragments, got %d", len(frags))
+	} else if shards := v.availableShards().Slice(); !reflect.DeepEqual(shards, []uint64{0, 1}) {
+		t.Fatalf("unexpected available shards: %v", shards)
+	}
+
+	// Background tasks skip fragments which haven't been loaded.
+	h.flushCaches()
+
+	if n := h.fragment("i", "f", viewStandard, 0).row(1).Count(); n != 1 {
+		t.Fatalf("unexpected count: %d", n)
+	} else if frags := v.loadedFragments(); len(frags) != 1 || frags[0].shard != 0 {
+		t.Fatalf("expected only shard 0 to be loaded, got %d fragments", len(frags))
+	}
+}
+
+// Ensure a fragment whose file fails validation is quarantined, and the rest
+// of the holder still opens.
+func TestHolder_QuarantineCorruptFragment(t *testing.T) {
+	for _, lazy := range []bool{false, true} {
+		h := newHolder()
+		if err := h.Open(); err != nil {
+			t.Fatal(err)
+		}
+		defer h.Close()
+
+		h.SetBit("i", "f", 1, 10)
+		h.SetBit("i", "f", 1, ShardWidth+10)
+		if err := h.Holder.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		// Flip the last byte of shard 0's data so its checksum doesn't match.
+		path := filepath.Join(h.Path, "i", "f", "views", viewStandard, "fragments", "0")
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data[len(data)-1] ^= 0xff
+		if err := ioutil.WriteFile(path, data, 0666); err != nil {
+			t.Fatal(err)
+		}
+
+		h.lazyFragments = lazy
+		if err := h.Holder.Open(); err != nil {
+			t.Fatalf("lazy=%v: %s", lazy, err)
+		}
+		if frag := h.fragment("i", "f", viewStandard, 0); frag != nil {
+			t.Fatalf("lazy=%v: expected corrupt fragment to be skipped", lazy)
+		} else if n := h.fragment("i", "f", viewStandard, 1).row(1).Count(); n != 1 {
+			t.Fatalf("lazy=%v: unexpected count: %d", lazy, n)
+		}
+		if !h.quarantine.has("i", 0, []string{"f"}) || h.quarantine.has("i", 1, []string{"f"}) || h.quarantine.has("i", 0, []string{"g"}) {
+			t.Fatalf("lazy=%v: expected only shard 0 of f to be quarantined", lazy)
+		}
+
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Fatalf("lazy=%v: expected fragment file to be moved, got %v", lazy, err)
+		}
+		paths, err := filepath.Glob(filepath.Join(h.Path, quarantineDir, "i", "f", viewStandard, "0.*Z"))
+		if err != nil {
+			t.Fatal(err)
+		} else if len(paths) != 1 {
+			t.Fatalf("lazy=%v: expected one quarantined file, got %v", lazy, paths)
+		} else if quarantined, err := ioutil.ReadFile(paths[0]); err != nil {
+			t.Fatal(err)
+		} else if !bytes.Equal(quarantined, data) {
+			t.Fatalf("lazy=%v: quarantined file differs from original", lazy)
+		}
+
+		// The fragment is still unrepaired after the holder is reopened.
+		if err := h.Holder.Close(); err != nil {
+			t.Fatal(err)
+		} else if err := h.Holder.Open(); err != nil {
+			t.Fatal(err)
+		} else if !h.quarantine.has("i", 0, []string{"f"}) {
+			t.Fatalf("lazy=%v: expected shard 0 to be quarantined after reopening", lazy)
+		} else if qfs := h.quarantine.take(); len(qfs) != 1 || qfs[0].File != paths[0] || qfs[0].View != viewStandard || qfs[0].Shard != 0 {
+			t.Fatalf("lazy=%v: unexpected pending fragments: %+v", lazy, qfs)
+		} else if err := h.quarantine.repaired(qfs[0]); err != nil {
+			t.Fatal(err)
+		}
+
+		// But not once it has been repaired.
+		if err := h.Holder.Close(); err != nil {
+			t.Fatal(err)
+		} else if err := h.Holder.Open(); err != nil {
+			t.Fatal(err)
+		} else if h.quarantine.has("i", 0, []string{"f"}) || len(h.quarantine.take()) != 0 {
+			t.Fatalf("lazy=%v: expected repaired fragment not to be reloaded", lazy)
+		} else if _, err := os.Stat(paths[0] + repairedExt); err != nil {
+			t.Fatal(err)
+		}
+
+		// The shard can be written to again.
+		h.SetBit("i", "f", 2, 10)
+		if n := h.fragment("i", "f", viewStandard, 0).row(2).Count(); n != 1 {
+			t.Fatalf("lazy=%v: unexpected count: %d", lazy, n)
+		}
+
+		// Deleting the field removes its quarantined files.
+		if err := h.Index("i").DeleteField("f"); err != nil {
+			t.Fatal(err)
+		} else if _, err := os.Stat(filepath.Join(h.Path, quarantineDir, "i", "f")); !os.IsNotExist(err) {
+			t.Fatalf("lazy=%v: expected quarantined files to be removed, got %v", lazy, err)
+		}
+	}
+}
+
+// Ensure deleting a field or index drops its quarantined fragments.
+func TestHolder_DeleteQuarantined(t *testing.T) {
+	h := newHolder()
+	if err := h.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	h.SetBit("i", "f", 1, 10)
+	h.SetBit("i", "g", 1, 10)
+	h.SetBit("j", "f", 1, 10)
+	for _, qf := range []quarantinedFragment{
+		{Index: "i", Field: "f", View: viewStandard, File: "f"},
+		{Index: "i", Field: "g", View: viewStandard, File: "g"},
+		{Index: "j", Field: "f", View: viewStandard, File: "j"},
+	} {
+		h.quarantine.add(qf, errors.New("marker"))
+	}
+
+	if err := h.Index("i").DeleteField("f"); err != nil {
+		t.Fatal(err)
+	} else if h.quarantine.has("i", 0, []string{"f"}) || !h.quarantine.has("i", 0, []string{"g"}) {
+		t.Fatal("expected only the deleted field's fragments to be dropped")
+	}
+	if err := h.DeleteIndex("i"); err != nil {
+		t.Fatal(err)
+	} else if h.quarantine.has("i", 0, []string{"g"}) || !h.quarantine.has("j", 0, []string{"f"}) {
+		t.Fatal("expected only the deleted index's fragments to be dropped")
+	} else if qfs := h.quarantine.take(); len(qfs) != 1 || qfs[0].Index != "j" {
+		t.Fatalf("unexpected pending fragments: %+v", qfs)
+	}
+}
+
+// Ensure the holder reports its progress opening fragments.
+func TestHolder_StartupProgress(t *testing.T) {
+	h := newHolder()
+	if err := h.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	h.SetBit("i", "f", 1, 10)
+	h.SetBit("i", "f", 1, ShardWidth+10)
+	h.SetBit("i", "g", 1, 10)
+	if err := h.Holder.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := countFragmentFiles(h.Path); n != 3 {
+		t.Fatalf("unexpected fragment file count: %d", n)
+	}
+
+	// Progress is estimated from the fragments opened so far.
+	h.progress.begin(3)
+	h.progress.fragmentOpened()
+	if p := h.progress.status(); p.Complete || p.Opened != 1 || p.Total != 3 || p.ETA == nil || p.Remaining == nil {
+		t.Fatalf("unexpected progress: %+v", p)
+	}
+
+	if err := h.Holder.Open(); err != nil {
+		t.Fatal(err)
+	}
+	if p := h.progress.status(); !p.Complete || p.Opened != 3 || p.Total != 3 || p.ETA != nil {
+		t.Fatalf("unexpected progress: %+v", p)
+	}
+}
+
+// Ensure the files of the least recently written fragments are closed once
+// over the limit, and reopened when they are next written to.
+func TestHolder_FilePool(t *testing.T) {
+	h := newHolder()
+	h.maxOpenFragmentFiles = 2
+	if err := h.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	for shard := uint64(0); shard < 4; shard++ {
+		h.SetBit("i", "f", 1, shard*ShardWidth)
+	}
+	for i := 0; h.filePool.len() > 2; i++ {
+		if i == 100 {
+			t.Fatalf("unexpected open files: %d", h.filePool.len())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if f := h.fragment("i", "f", viewStandard, 0); f.file != nil {
+		t.Fatal("expected file of shard 0 to be closed")
+	} else if f := h.fragment("i", "f", viewStandard, 3); f.file == nil {
+		t.Fatal("expected file of shard 3 to be open")
+	}
+
+	// Writes to a fragment whose file was closed are persisted, including
+	// roaring imports.
+	h.SetBit("i", "f", 2, 1)
+	f1 := h.fragment("i", "f", viewStandard, 1)
+	if err := f1.closeFile(); err != nil {
+		t.Fatal(err)
+	}
+	buf := &bytes.Buffer{}
+	if _, err := roaring.NewBitmap(3*ShardWidth+5, 3*ShardWidth+6).WriteTo(buf); err != nil {
+		t.Fatal(err)
+	} else if err := f1.importRoaringT(buf.Bytes(), false); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.Holder.Close(); err != nil {
+		t.Fatal(err)
+	} else if err := h.Holder.Open(); err != nil {
+		t.Fatal(err)
+	}
+	if n := h.Row("i", "f", 2).Count(); n != 1 {
+		t.Fatalf("unexpected count: %d", n)
+	} else if n := h.Row("i", "f", 1).Count(); n != 4 {
+		t.Fatalf("unexpected count: %d", n)
+	} else if n := h.Row("i", "f", 3).Count(); n != 2 {
+		t.Fatalf("unexpected count: %d", n)
+	}
+}
+
+func TestHolder_IdleFields(t *testing.T) {
+	h := newHolder()
+	if err := h.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	idx := h.MustCreateIndexIfNotExists("i", IndexOptions{})
+	f0, err := idx.CreateField("f0", OptFieldTypeDefault(), OptFieldIdleTTL(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := idx.CreateField("f1", OptFieldTypeDefault()); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	if fields := h.idleFields(now); len(fields) != 0 {
+		t.Fatalf("unexpected idle fields: %v", fields)
+	} else if fields := h.idleFields(now.Add(2 * time.Hour)); len(fields) != 1 || fields[0] != f0 {
+		t.Fatalf("unexpected idle fields: %v", fields)
+	}
+
+	// Use of the field on another node keeps it, and older use is ignored.
+	f0.mergeLastAccess(now.Add(90 * time.Minute))
+	f0.mergeLastAccess(now)
+	if fields := h.idleFields(now.Add(2 * time.Hour)); len(fields) != 0 {
+		t.Fatalf("unexpected idle fields: %v", fields)
+	}
+
+	// The idle TTL is kept on reopening.
+	if err := h.Holder.Close(); err != nil {
+		t.Fatal(err)
+	} else if err := h.Reopen(); err != nil {
+		t.Fatal(err)
+	} else if ttl := h.Field("i", "f0").Options().IdleTTL; time.Duration(ttl) != time.Hour {
+		t.Fatalf("unexpected idle TTL: %s", ttl)
+	}
+}
+
+// Ensure a node's zone can only be changed while it holds no data.
+func TestHolder_SaveZone(t *testing.T) {
+	h := newHolder()
+	if err := h.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	if err := h.saveZone("a"); err != nil {
+		t.Fatal(err)
+	} else if err := h.saveZone("a"); err != nil {
+		t.Fatal(err)
+	}
+
+	h.MustCreateIndexIfNotExists("i", IndexOptions{})
+	if err := h.saveZone("b"); err == nil {
+		t.Fatal("expected error changing zone of node with data")
+	} else if err := h.saveZone("a"); err != nil {
+		t.Fatal(err)
+	}
+}
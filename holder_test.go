@@ -193,8 +193,15 @@ func TestHolder_Open(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		if err := h.Reopen(); err == nil || !strings.Contains(err.Error(), "open fragment: shard=0, err=opening storage: unmarshal storage") {
-			t.Fatalf("unexpected error: %s", err)
+		// The corrupt fragment is quarantined rather than failing the open.
+		if err := h.Reopen(); err != nil {
+			t.Fatal(err)
+		} else if f := h.Field("foo", "bar"); f == nil {
+			t.Fatal("expected field")
+		} else if shards := f.AvailableShards().Slice(); len(shards) != 0 {
+			t.Fatalf("unexpected shards: %v", shards)
+		} else if _, err := os.Stat(filepath.Join(h.Path, ".quarantine", "foo", "bar", "standard")); err != nil {
+			t.Fatal(err)
 		}
 	})
 	// Try to re-create existing index
@@ -249,6 +256,13 @@ func TestHolder_HasData(t *testing.T) {
 			t.Fatal("expected HasData to return false, no err, but", ok, err)
 		}
 
+		// Hidden directories, such as the hint log's, aren't indexes.
+		if err := os.MkdirAll(filepath.Join(h.Path, ".hints"), 0777); err != nil {
+			t.Fatal(err)
+		} else if ok, err := h.HasData(); ok || err != nil {
+			t.Fatal("expected HasData to return false, no err, but", ok, err)
+		}
+
 		// Create an index directory to indicate data exists.
 		if err := os.Mkdir(h.IndexPath("test"), 0777); err != nil {
 			t.Fatal(err)
@@ -298,6 +312,89 @@ func TestHolder_DeleteIndex(t *testing.T) {
 	}
 }
 
+// Ensure the indexes of a namespace are stored in its directory.
+func TestHolder_Namespace(t *testing.T) {
+	hldr := test.MustOpenHolder()
+	defer hldr.Close()
+
+	hldr.SetBit("acme.i0", "f", 100, 200)
+	hldr.SetBit("acme.i1", "f", 100, 200)
+	if exp := filepath.Join(hldr.Path, "@acme", "i0"); hldr.IndexPath("acme.i0") != exp {
+		t.Fatalf("unexpected path: %s", hldr.IndexPath("acme.i0"))
+	} else if _, err := os.Stat(hldr.IndexPath("acme.i0")); err != nil {
+		t.Fatal(err)
+	}
+
+	// Namespaced indexes are found on reopening.
+	if err := hldr.Holder.Close(); err != nil {
+		t.Fatal(err)
+	} else if err := hldr.Reopen(); err != nil {
+		t.Fatal(err)
+	} else if hldr.Index("acme.i0") == nil || hldr.Index("acme.i1") == nil {
+		t.Fatal("expected namespaced indexes")
+	} else if n := hldr.Row("acme.i0", "f", 100).Count(); n != 1 {
+		t.Fatalf("unexpected count: %d", n)
+	}
+
+	// The namespace's directory is removed with its last index.
+	if err := hldr.DeleteIndex("acme.i0"); err != nil {
+		t.Fatal(err)
+	} else if _, err := os.Stat(filepath.Join(hldr.Path, "@acme")); err != nil {
+		t.Fatal(err)
+	} else if err := hldr.DeleteIndex("acme.i1"); err != nil {
+		t.Fatal(err)
+	} else if _, err := os.Stat(filepath.Join(hldr.Path, "@acme")); !os.IsNotExist(err) {
+		t.Fatalf("expected namespace directory deletion: %v", err)
+	}
+}
+
+func TestHolder_InMemory(t *testing.T) {
+	hldr := test.MustOpenHolder()
+	defer hldr.Close()
+
+	idx, err := hldr.CreateIndex("i", pilosa.IndexOptions{InMemory: true, TrackExistence: true})
+	if err != nil {
+		t.Fatal(err)
+	} else if !idx.Options().InMemory {
+		t.Fatal("expected in-memory index")
+	}
+	hldr.SetBit("i", "f", 100, 200)
+	hldr.SetBit("i", "f", 100, pilosa.ShardWidth+1)
+
+	// Imports large enough to be snapshotted stay in memory.
+	f := hldr.Field("i", "f")
+	rowIDs, columnIDs := make([]uint64, 20000), make([]uint64, 20000)
+	for i := range columnIDs {
+		rowIDs[i], columnIDs[i] = 1, uint64(i)
+	}
+	if err := f.Import(rowIDs, columnIDs, nil); err != nil {
+		t.Fatal(err)
+	} else if err := f.RowAttrStore().SetAttrs(100, map[string]interface{}{"x": "y"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := hldr.Row("i", "f", 100).Count(); n != 2 {
+		t.Fatalf("unexpected count: %d", n)
+	} else if n := hldr.Row("i", "f", 1).Count(); n != 20000 {
+		t.Fatalf("unexpected count: %d", n)
+	} else if attrs, err := f.RowAttrStore().Attrs(100); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(attrs, map[string]interface{}{"x": "y"}) {
+		t.Fatalf("unexpected attrs: %#v", attrs)
+	} else if _, err := os.Stat(hldr.IndexPath("i")); !os.IsNotExist(err) {
+		t.Fatalf("expected no index directory: %v", err)
+	}
+
+	// In-memory indexes are gone on reopening.
+	if err := hldr.Holder.Close(); err != nil {
+		t.Fatal(err)
+	} else if err := hldr.Reopen(); err != nil {
+		t.Fatal(err)
+	} else if hldr.Index("i") != nil {
+		t.Fatal("expected in-memory index to be gone")
+	}
+}
+
 // Ensure holder can sync with a remote holder.
 func TestHolderSyncer_SyncHolder(t *testing.T) {
 	c := test.MustNewCluster(t, 2)
@@ -449,6 +546,61 @@ func TestHolderSyncer_BlockIteratorLimits(t *testing.T) {
 	}
 }
 
+// Ensure holder can sync a block by exchanging only the containers which
+// differ.
+func TestHolderSyncer_Containers(t *testing.T) {
+	c := test.MustNewCluster(t, 2)
+	c[0].Config.Cluster.ReplicaN = 2
+	c[0].Config.AntiEntropy.Interval = 0
+	c[1].Config.Cluster.ReplicaN = 2
+	c[1].Config.AntiEntropy.Interval = 0
+	err := c.Start()
+	if err != nil {
+		t.Fatalf("starting cluster: %v", err)
+	}
+	defer c.Close()
+
+	_, err = c[0].API.CreateIndex(context.Background(), "i", pilosa.IndexOptions{})
+	if err != nil {
+		t.Fatalf("creating index i: %v", err)
+	}
+	_, err = c[0].API.CreateField(context.Background(), "i", "f", pilosa.OptFieldTypeSet(pilosa.DefaultCacheType, pilosa.DefaultCacheSize))
+	if err != nil {
+		t.Fatalf("creating field f: %v", err)
+	}
+
+	hldr0 := &test.Holder{Holder: c[0].Server.Holder()}
+	hldr1 := &test.Holder{Holder: c[1].Server.Holder()}
+
+	// Set the same data in most containers of the block on both holders.
+	for rowID := uint64(0); rowID < 10; rowID++ {
+		hldr0.SetBit("i", "f", rowID, 1)
+		hldr1.SetBit("i", "f", rowID, 1)
+	}
+
+	// Set data in a shared container on one, and a new container on the other.
+	hldr0.SetBit("i", "f", 0, 2)
+	hldr1.SetBit("i", "f", 5, 70000)
+
+	err = c[0].Server.SyncData()
+	if err != nil {
+		t.Fatalf("syncing node 0: %v", err)
+	}
+
+	// Verify data is the same on both nodes.
+	for i, hldr := range []*test.Holder{hldr0, hldr1} {
+		if a := hldr.Row("i", "f", 0).Columns(); !reflect.DeepEqual(a, []uint64{1, 2}) {
+			t.Errorf("unexpected columns(%d/0): %+v", i, a)
+		}
+		if a := hldr.Row("i", "f", 5).Columns(); !reflect.DeepEqual(a, []uint64{1, 70000}) {
+			t.Errorf("unexpected columns(%d/5): %+v", i, a)
+		}
+		if a := hldr.Row("i", "f", 9).Columns(); !reflect.DeepEqual(a, []uint64{1}) {
+			t.Errorf("unexpected columns(%d/9): %+v", i, a)
+		}
+	}
+}
+
 // Ensure holder correctly handles clears during block sync.
 func TestHolderSyncer_Clears(t *testing.T) {
 	c := test.MustNewCluster(t, 3)
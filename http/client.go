@@ -26,6 +26,9 @@ import (
 	"net/url"
 	"sort"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/pilosa/pilosa/v2"
 	"github.com/pilosa/pilosa/v2/encoding/proto"
@@ -40,10 +43,28 @@ type InternalClient struct {
 
 	// The client to use for HTTP communication.
 	httpClient *http.Client
+
+	// The codec to compress request and response bodies with, and the
+	// codecs each host has advertised that it accepts.
+	compression     string
+	mu              sync.RWMutex
+	hostCompression map[string]string
+}
+
+// internalClientOption is a functional option type for InternalClient.
+type internalClientOption func(c *InternalClient)
+
+// OptInternalClientCompression sets the codec which the client asks hosts to
+// compress responses with, and compresses request bodies with when the host
+// accepts it.
+func OptInternalClientCompression(codec string) internalClientOption {
+	return func(c *InternalClient) {
+		c.compression = codec
+	}
 }
 
 // NewInternalClient returns a new instance of InternalClient to connect to host.
-func NewInternalClient(host string, remoteClient *http.Client) (*InternalClient, error) {
+func NewInternalClient(host string, remoteClient *http.Client, opts ...internalClientOption) (*InternalClient, error) {
 	if host == "" {
 		return nil, pilosa.ErrHostRequired
 	}
@@ -53,16 +74,22 @@ func NewInternalClient(host string, remoteClient *http.Client) (*InternalClient,
 		return nil, errors.Wrap(err, "getting URI")
 	}
 
-	client := NewInternalClientFromURI(uri, remoteClient)
+	client := NewInternalClientFromURI(uri, remoteClient, opts...)
 	return client, nil
 }
 
-func NewInternalClientFromURI(defaultURI *pilosa.URI, remoteClient *http.Client) *InternalClient {
-	return &InternalClient{
-		defaultURI: defaultURI,
-		serializer: proto.Serializer{},
-		httpClient: remoteClient,
+func NewInternalClientFromURI(defaultURI *pilosa.URI, remoteClient *http.Client, opts ...internalClientOption) *InternalClient {
+	c := &InternalClient{
+		defaultURI:      defaultURI,
+		serializer:      proto.Serializer{},
+		httpClient:      remoteClient,
+		compression:     CompressionNone,
+		hostCompression: make(map[string]string),
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	return c
 }
 
 // MaxShardByIndex returns the number of shards on a server by index.
@@ -105,9 +132,16 @@ func (c *InternalClient) maxShardByIndex(ctx context.Context) (map[string]uint64
 func (c *InternalClient) Schema(ctx context.Context) ([]*pilosa.IndexInfo, error) {
 	span, ctx := tracing.StartSpanFromContext(ctx, "InternalClient.Schema")
 	defer span.Finish()
+	return c.SchemaNode(ctx, c.defaultURI)
+}
+
+// SchemaNode returns the schema of the node at uri.
+func (c *InternalClient) SchemaNode(ctx context.Context, uri *pilosa.URI) ([]*pilosa.IndexInfo, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "InternalClient.SchemaNode")
+	defer span.Finish()
 
 	// Execute request against the host.
-	u := c.defaultURI.Path("/schema")
+	u := uri.Path("/schema")
 
 	// Build request.
 	req, err := http.NewRequest("GET", u, nil)
@@ -280,8 +314,12 @@ func (c *InternalClient) QueryNode(ctx context.Context, uri *pilosa.URI, index s
 		return nil, errors.Wrap(err, "marshaling queryRequest")
 	}
 
-	// Create HTTP request.
+	// Create HTTP request. Queries from other nodes are marked remote, so
+	// they aren't metered as clients' queries without a token.
 	u := uri.Path(fmt.Sprintf("/index/%s/query", index))
+	if queryRequest.Remote {
+		u += "?remote=true"
+	}
 	req, err := http.NewRequest("POST", u, bytes.NewReader(buf))
 	if err != nil {
 		return nil, errors.Wrap(err, "creating request")
@@ -292,6 +330,12 @@ func (c *InternalClient) QueryNode(ctx context.Context, uri *pilosa.URI, index s
 	req.Header.Set("Accept", "application/x-protobuf")
 	req.Header.Set("User-Agent", "pilosa/"+pilosa.Version)
 
+	// Pass the API token on, so the remote node meters the containers the
+	// query scans there.
+	if token := pilosa.UsageToken(ctx); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
 	// Execute request against the host.
 	resp, err := c.executeRequest(req.WithContext(ctx))
 	if err != nil {
@@ -348,7 +392,7 @@ func (c *InternalClient) Import(ctx context.Context, index, field string, shard
 
 	// Import to each node.
 	for _, node := range nodes {
-		if err := c.importNode(ctx, node, index, field, buf, options); err != nil {
+		if err := c.importNode(ctx, node, index, field, buf, options, false); err != nil {
 			return fmt.Errorf("import node: host=%s, err=%s", node.URI, err)
 		}
 	}
@@ -402,7 +446,7 @@ func (c *InternalClient) ImportK(ctx context.Context, index, field string, bits
 	}
 
 	// Import to node.
-	if err := c.importNode(ctx, coord, index, field, buf, options); err != nil {
+	if err := c.importNode(ctx, coord, index, field, buf, options, false); err != nil {
 		return fmt.Errorf("import node: host=%s, err=%s", coord.URI, err)
 	}
 
@@ -463,7 +507,7 @@ func (c *InternalClient) marshalImportPayload(index, field string, shard uint64,
 }
 
 // importNode sends a pre-marshaled import request to a node.
-func (c *InternalClient) importNode(ctx context.Context, node *pilosa.Node, index, field string, buf []byte, opts *pilosa.ImportOptions) error {
+func (c *InternalClient) importNode(ctx context.Context, node *pilosa.Node, index, field string, buf []byte, opts *pilosa.ImportOptions, remote bool) error {
 	span, ctx := tracing.StartSpanFromContext(ctx, "InternalClient.importNode")
 	defer span.Finish()
 
@@ -478,6 +522,15 @@ func (c *InternalClient) importNode(ctx context.Context, node *pilosa.Node, inde
 	if opts.IgnoreKeyCheck {
 		vals.Set("ignoreKeyCheck", "true")
 	}
+	if opts.TimeQuantum != "" {
+		vals.Set("timeQuantum", string(opts.TimeQuantum))
+	}
+	if opts.SkipStandardView {
+		vals.Set("skipStandardView", "true")
+	}
+	if remote {
+		vals.Set("remote", "true")
+	}
 	url := fmt.Sprintf("%s?%s", u.String(), vals.Encode())
 
 	req, err := http.NewRequest("POST", url, bytes.NewReader(buf))
@@ -545,7 +598,7 @@ func (c *InternalClient) ImportValue(ctx context.Context, index, field string, s
 
 	// Import to each node.
 	for _, node := range nodes {
-		if err := c.importNode(ctx, node, index, field, buf, options); err != nil {
+		if err := c.importNode(ctx, node, index, field, buf, options, false); err != nil {
 			return fmt.Errorf("import node: host=%s, err=%s", node.URI, err)
 		}
 	}
@@ -553,6 +606,46 @@ func (c *InternalClient) ImportValue(ctx context.Context, index, field string, s
 	return nil
 }
 
+// ImportNode bulk imports bits for a single shard to the node at uri alone,
+// rather than to every node which owns the shard.
+func (c *InternalClient) ImportNode(ctx context.Context, uri *pilosa.URI, index, field string, shard uint64, bits []pilosa.Bit, opts ...pilosa.ImportOption) error {
+	span, ctx := tracing.StartSpanFromContext(ctx, "InternalClient.ImportNode")
+	defer span.Finish()
+
+	options := &pilosa.ImportOptions{}
+	for _, opt := range opts {
+		if err := opt(options); err != nil {
+			return errors.Wrap(err, "applying option")
+		}
+	}
+
+	buf, err := c.marshalImportPayload(index, field, shard, bits)
+	if err != nil {
+		return errors.Wrap(err, "creating payload")
+	}
+	return c.importNode(ctx, &pilosa.Node{URI: *uri}, index, field, buf, options, true)
+}
+
+// ImportValueNode bulk imports field values for a single shard to the node
+// at uri alone, rather than to every node which owns the shard.
+func (c *InternalClient) ImportValueNode(ctx context.Context, uri *pilosa.URI, index, field string, shard uint64, vals []pilosa.FieldValue, opts ...pilosa.ImportOption) error {
+	span, ctx := tracing.StartSpanFromContext(ctx, "InternalClient.ImportValueNode")
+	defer span.Finish()
+
+	options := &pilosa.ImportOptions{}
+	for _, opt := range opts {
+		if err := opt(options); err != nil {
+			return errors.Wrap(err, "applying option")
+		}
+	}
+
+	buf, err := c.marshalImportValuePayload(index, field, shard, vals)
+	if err != nil {
+		return errors.Wrap(err, "creating payload")
+	}
+	return c.importNode(ctx, &pilosa.Node{URI: *uri}, index, field, buf, options, true)
+}
+
 // ImportValueK bulk imports keyed field values to a host.
 func (c *InternalClient) ImportValueK(ctx context.Context, index, field string, vals []pilosa.FieldValue, opts ...pilosa.ImportOption) error {
 	span, ctx := tracing.StartSpanFromContext(ctx, "InternalClient.ImportValueK")
@@ -584,7 +677,7 @@ func (c *InternalClient) ImportValueK(ctx context.Context, index, field string,
 	}
 
 	// Import to node.
-	if err := c.importNode(ctx, coord, index, field, buf, options); err != nil {
+	if err := c.importNode(ctx, coord, index, field, buf, options, false); err != nil {
 		return fmt.Errorf("import node: host=%s, err=%s", coord.URI, err)
 	}
 
@@ -890,7 +983,7 @@ func (c *InternalClient) BlockData(ctx context.Context, uri *pilosa.URI, index,
 	if uri == nil {
 		panic("need to pass a URI to BlockData")
 	}
-	buf, err := c.serializer.Marshal(&pilosa.BlockDataRequest{
+	rsp, err := c.blockData(ctx, uri, &pilosa.BlockDataRequest{
 		Index: index,
 		Field: field,
 		View:  view,
@@ -898,13 +991,68 @@ func (c *InternalClient) BlockData(ctx context.Context, uri *pilosa.URI, index,
 		Block: uint64(block),
 	})
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "marshaling")
+		return nil, nil, err
+	}
+	return rsp.RowIDs, rsp.ColumnIDs, nil
+}
+
+// BlockChecksums returns the checksums of the containers in a block.
+func (c *InternalClient) BlockChecksums(ctx context.Context, uri *pilosa.URI, index, field, view string, shard uint64, block int) ([]pilosa.ContainerChecksum, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "InternalClient.BlockChecksums")
+	defer span.Finish()
+
+	if uri == nil {
+		panic("need to pass a URI to BlockChecksums")
+	}
+	rsp, err := c.blockData(ctx, uri, &pilosa.BlockDataRequest{
+		Index:     index,
+		Field:     field,
+		View:      view,
+		Shard:     shard,
+		Block:     uint64(block),
+		Checksums: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rsp.Checksums, nil
+}
+
+// BlockContainerData returns row/column id pairs for the containers in a
+// block with the given keys.
+func (c *InternalClient) BlockContainerData(ctx context.Context, uri *pilosa.URI, index, field, view string, shard uint64, block int, containers []uint64) ([]uint64, []uint64, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "InternalClient.BlockContainerData")
+	defer span.Finish()
+
+	if uri == nil {
+		panic("need to pass a URI to BlockContainerData")
+	}
+	rsp, err := c.blockData(ctx, uri, &pilosa.BlockDataRequest{
+		Index:      index,
+		Field:      field,
+		View:       view,
+		Shard:      shard,
+		Block:      uint64(block),
+		Containers: containers,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return rsp.RowIDs, rsp.ColumnIDs, nil
+}
+
+// blockData sends a block data request, returning an empty response if the
+// fragment doesn't exist.
+func (c *InternalClient) blockData(ctx context.Context, uri *pilosa.URI, blockReq *pilosa.BlockDataRequest) (*pilosa.BlockDataResponse, error) {
+	buf, err := c.serializer.Marshal(blockReq)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshaling")
 	}
 
 	u := uriPathToURL(uri, "/internal/fragment/block/data")
 	req, err := http.NewRequest("GET", u.String(), bytes.NewReader(buf))
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "creating request")
+		return nil, errors.Wrap(err, "creating request")
 	}
 	req.Header.Set("Content-Type", "application/protobuf")
 	req.Header.Set("Content-Length", strconv.Itoa(len(buf)))
@@ -914,20 +1062,20 @@ func (c *InternalClient) BlockData(ctx context.Context, uri *pilosa.URI, index,
 	resp, err := c.executeRequest(req.WithContext(ctx))
 	if err != nil {
 		if resp != nil && resp.StatusCode == http.StatusNotFound {
-			return nil, nil, nil
+			return &pilosa.BlockDataResponse{}, nil
 		}
-		return nil, nil, err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	// Decode response object.
 	var rsp pilosa.BlockDataResponse
 	if body, err := ioutil.ReadAll(resp.Body); err != nil {
-		return nil, nil, errors.Wrap(err, "reading")
+		return nil, errors.Wrap(err, "reading")
 	} else if err := c.serializer.Unmarshal(body, &rsp); err != nil {
-		return nil, nil, errors.Wrap(err, "unmarshalling")
+		return nil, errors.Wrap(err, "unmarshalling")
 	}
-	return rsp.RowIDs, rsp.ColumnIDs, nil
+	return &rsp, nil
 }
 
 // ColumnAttrDiff returns data from differing blocks on a remote host.
@@ -1030,6 +1178,9 @@ func (c *InternalClient) SendMessage(ctx context.Context, uri *pilosa.URI, msg [
 	// Execute request.
 	resp, err := c.executeRequest(req.WithContext(ctx))
 	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusConflict {
+			return pilosa.NewConflictError(err)
+		}
 		return errors.Wrap(err, "executing request")
 	}
 	return errors.Wrap(resp.Body.Close(), "closing response body")
@@ -1041,6 +1192,12 @@ func (c *InternalClient) SendMessage(ctx context.Context, uri *pilosa.URI, msg [
 // is closed.
 func (c *InternalClient) executeRequest(req *http.Request) (*http.Response, error) {
 	tracing.GlobalTracer.InjectHTTPHeaders(req)
+	if id := pilosa.RequestIDFromContext(req.Context()); id != "" {
+		req.Header.Set(pilosa.RequestIDHeader, id)
+	}
+	if err := c.compressRequest(req); err != nil {
+		return nil, err
+	}
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		if resp != nil {
@@ -1048,25 +1205,72 @@ func (c *InternalClient) executeRequest(req *http.Request) (*http.Response, erro
 		}
 		return nil, errors.Wrap(err, "getting response")
 	}
+	if err := c.decompressResponse(req, resp); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		defer resp.Body.Close()
 		buf, err := ioutil.ReadAll(resp.Body)
 		if err != nil {
 			return resp, errors.Wrapf(err, "bad status '%s' and err reading body", resp.Status)
 		}
-		var msg string
-		// try to decode a JSON response
+		// imports refused for backpressure say how long to wait
+		if resp.StatusCode == http.StatusTooManyRequests {
+			if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+				bp := pilosa.BackpressureError{RetryAfter: time.Duration(secs) * time.Second}
+				return resp, errors.WithMessage(bp, "server error "+resp.Status)
+			}
+		}
+		// try to decode a JSON response, keeping the cause of the error
 		var sr successResponse
-		if err = json.Unmarshal(buf, &sr); err == nil {
-			msg = sr.Error.Error()
-		} else {
-			msg = string(buf)
+		if err = json.Unmarshal(buf, &sr); err == nil && sr.Error != nil {
+			return resp, errors.WithMessage(sr.Error.Err(), "server error "+resp.Status)
 		}
-		return resp, errors.Errorf("server error %s: '%s'", resp.Status, msg)
+		return resp, errors.Errorf("server error %s: '%s'", resp.Status, buf)
 	}
 	return resp, nil
 }
 
+// compressRequest asks the host to compress its response with the client's
+// codec, and compresses the request body with it if the host has advertised
+// that it accepts it.
+func (c *InternalClient) compressRequest(req *http.Request) error {
+	if c.compression == CompressionNone || c.compression == "" {
+		return nil
+	}
+	req.Header.Set("Accept-Encoding", c.compression)
+
+	c.mu.RLock()
+	accepted := c.hostCompression[req.URL.Host]
+	c.mu.RUnlock()
+	for _, codec := range strings.Split(accepted, ",") {
+		if strings.TrimSpace(codec) == c.compression {
+			return compressRequest(req, c.compression)
+		}
+	}
+	return nil
+}
+
+// decompressResponse decompresses the body of a response, and records the
+// codecs which the host accepts.
+func (c *InternalClient) decompressResponse(req *http.Request, resp *http.Response) error {
+	if c.compression == CompressionNone || c.compression == "" {
+		return nil
+	}
+	if accepted := resp.Header.Get("Accept-Encoding"); accepted != "" {
+		c.mu.Lock()
+		c.hostCompression[req.URL.Host] = accepted
+		c.mu.Unlock()
+	}
+	body, err := decompressBody(resp.Header, resp.Body)
+	if err != nil {
+		return err
+	}
+	resp.Body = body
+	return nil
+}
+
 // Bits is a slice of Bit.
 type Bits []pilosa.Bit
 
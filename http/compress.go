@@ -0,0 +1,208 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pkg/errors"
+)
+
+// Codecs which nodes can use to compress the bodies of the requests and
+// responses they send each other.
+const (
+	CompressionNone   = "none"
+	CompressionSnappy = "snappy"
+	CompressionZstd   = "zstd"
+)
+
+// supportedCompression lists the codecs this node can decode, in order of
+// preference. Nodes advertise it in the Accept-Encoding header of their
+// responses, so that other nodes know which codecs they may compress request
+// bodies with.
+const supportedCompression = CompressionZstd + ", " + CompressionSnappy
+
+// minCompressSize is the size below which request bodies are sent
+// uncompressed.
+const minCompressSize = 1024
+
+// ValidateCompression returns an error if codec is not a known codec.
+func ValidateCompression(codec string) error {
+	switch codec {
+	case CompressionNone, CompressionSnappy, CompressionZstd:
+		return nil
+	}
+	return errors.Errorf("invalid compression codec: %q", codec)
+}
+
+// newCompressWriter returns a writer which compresses what is written to it
+// with codec before writing it to w.
+func newCompressWriter(codec string, w io.Writer) (io.WriteCloser, error) {
+	switch codec {
+	case CompressionSnappy:
+		return snappy.NewBufferedWriter(w), nil
+	case CompressionZstd:
+		return zstd.NewWriter(w, zstd.WithEncoderLevel(zstd.SpeedFastest), zstd.WithEncoderConcurrency(1))
+	}
+	return nil, errors.Errorf("invalid compression codec: %q", codec)
+}
+
+// newDecompressReader returns a reader which decompresses what is read from r
+// with codec.
+func newDecompressReader(codec string, r io.Reader) (io.ReadCloser, error) {
+	switch codec {
+	case CompressionSnappy:
+		return ioutil.NopCloser(snappy.NewReader(r)), nil
+	case CompressionZstd:
+		d, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		return d.IOReadCloser(), nil
+	}
+	return nil, errors.Errorf("invalid compression codec: %q", codec)
+}
+
+// acceptedCompression returns the first codec listed in an Accept-Encoding
+// header which this node supports, or an empty string if there is none.
+func acceptedCompression(header string) string {
+	for _, v := range strings.Split(header, ",") {
+		v = strings.TrimSpace(v)
+		if i := strings.IndexByte(v, ';'); i >= 0 {
+			v = strings.TrimSpace(v[:i])
+		}
+		if v == CompressionSnappy || v == CompressionZstd {
+			return v
+		}
+	}
+	return ""
+}
+
+// readCloser closes both a decompressing reader and the body it reads from.
+type readCloser struct {
+	io.ReadCloser
+	body io.Closer
+}
+
+func (r *readCloser) Close() error {
+	r.ReadCloser.Close()
+	return r.body.Close()
+}
+
+// decompressBody replaces a body compressed with the codec in its
+// Content-Encoding header with one which decompresses it.
+func decompressBody(header http.Header, body io.ReadCloser) (io.ReadCloser, error) {
+	codec := header.Get("Content-Encoding")
+	if codec != CompressionSnappy && codec != CompressionZstd {
+		return body, nil
+	}
+	rd, err := newDecompressReader(codec, body)
+	if err != nil {
+		return nil, errors.Wrap(err, "decompressing body")
+	}
+	header.Del("Content-Encoding")
+	header.Del("Content-Length")
+	return &readCloser{ReadCloser: rd, body: body}, nil
+}
+
+// compressRequest compresses the body of a request with codec, unless it is
+// too small to be worth it.
+func compressRequest(req *http.Request, codec string) error {
+	if req.Body == nil || req.ContentLength < minCompressSize {
+		return nil
+	}
+	defer req.Body.Close()
+
+	var buf bytes.Buffer
+	w, err := newCompressWriter(codec, &buf)
+	if err != nil {
+		return err
+	} else if _, err := io.Copy(w, req.Body); err != nil {
+		return errors.Wrap(err, "compressing body")
+	} else if err := w.Close(); err != nil {
+		return errors.Wrap(err, "compressing body")
+	}
+
+	data := buf.Bytes()
+	req.Body = ioutil.NopCloser(bytes.NewReader(data))
+	req.GetBody = func() (io.ReadCloser, error) { return ioutil.NopCloser(bytes.NewReader(data)), nil }
+	req.ContentLength = int64(len(data))
+	req.Header.Set("Content-Encoding", codec)
+	req.Header.Del("Content-Length")
+	return nil
+}
+
+// compressResponseWriter compresses the body of a response with a codec
+// accepted by the client, unless the handler has already encoded it.
+type compressResponseWriter struct {
+	http.ResponseWriter
+	codec string
+
+	w           io.WriteCloser // nil until the header is written, or if not compressing
+	wroteHeader bool
+}
+
+func (w *compressResponseWriter) WriteHeader(statusCode int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+
+	h := w.Header()
+	if statusCode != http.StatusNoContent && statusCode != http.StatusNotModified && h.Get("Content-Encoding") == "" {
+		if cw, err := newCompressWriter(w.codec, w.ResponseWriter); err == nil {
+			h.Set("Content-Encoding", w.codec)
+			h.Del("Content-Length")
+			w.w = cw
+		}
+	}
+	h.Add("Vary", "Accept-Encoding")
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *compressResponseWriter) Write(p []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.w == nil {
+		return w.ResponseWriter.Write(p)
+	}
+	return w.w.Write(p)
+}
+
+// Flush writes out whatever has been compressed so far, for handlers which
+// stream their responses.
+func (w *compressResponseWriter) Flush() {
+	if f, ok := w.w.(interface{ Flush() error }); ok {
+		_ = f.Flush()
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Close finishes compressing the response.
+func (w *compressResponseWriter) Close() error {
+	if w.w == nil {
+		return nil
+	}
+	return w.w.Close()
+}
@@ -0,0 +1,95 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Ensure the client and handler negotiate a codec, and compress request and
+// response bodies with it.
+func TestCompression(t *testing.T) {
+	for _, codec := range []string{CompressionZstd, CompressionSnappy} {
+		t.Run(codec, func(t *testing.T) {
+			h := &Handler{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := ioutil.ReadAll(r.Body)
+				if err != nil {
+					t.Fatal(err)
+				}
+				_, _ = w.Write(body)
+			})}
+			var encodings []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				encodings = append(encodings, r.Header.Get("Content-Encoding"))
+				h.ServeHTTP(w, r)
+			}))
+			defer srv.Close()
+
+			c := NewInternalClientFromURI(nil, srv.Client(), OptInternalClientCompression(codec))
+			body := bytes.Repeat([]byte("pilosa"), minCompressSize)
+
+			// The first request isn't compressed, since the host hasn't
+			// advertised which codecs it accepts yet.
+			for i, want := range []string{"", codec} {
+				req, err := http.NewRequest("POST", srv.URL, bytes.NewReader(body))
+				if err != nil {
+					t.Fatal(err)
+				}
+				resp, err := c.executeRequest(req)
+				if err != nil {
+					t.Fatal(err)
+				}
+				data, err := ioutil.ReadAll(resp.Body)
+				resp.Body.Close()
+				if err != nil {
+					t.Fatal(err)
+				} else if !bytes.Equal(data, body) {
+					t.Fatalf("request %d: unexpected response body of %d bytes", i, len(data))
+				} else if encodings[i] != want {
+					t.Fatalf("request %d: unexpected request encoding: %q", i, encodings[i])
+				}
+			}
+		})
+	}
+}
+
+// Ensure responses are not compressed unless the client accepts a codec.
+func TestCompression_NotAccepted(t *testing.T) {
+	h := &Handler{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("pilosa"))
+	})}
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	req, err := http.NewRequest("GET", srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Accept-Encoding", "br, identity;q=0.5")
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if enc := resp.Header.Get("Content-Encoding"); enc != "" {
+		t.Fatalf("unexpected response encoding: %q", enc)
+	} else if accepted := resp.Header.Get("Accept-Encoding"); accepted != supportedCompression {
+		t.Fatalf("unexpected accepted encodings: %q", accepted)
+	}
+}
@@ -1,26 +1,6 @@
-// Copyright 2017 Pilosa Corp.
-//
-// Licensed under the Apache License, Version 2.0 (the "License");
-// you may not use this file except in compliance with the License.
-// You may obtain a copy of the License at
-//
-//     http://www.apache.org/licenses/LICENSE-2.0
-//
-// Unless required by applicable law or agreed to in writing, software
-// distributed under the License is distributed on an "AS IS" BASIS,
-// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
-// See the License for the specific language governing permissions and
-// limitations under the License.
-
 package http
 
-// Error defines a standard application error.
-type Error struct {
-	// Human-readable message.
-	Message string `json:"message"`
-}
+import "github.com/pilosa/pilosa/v2"
 
-// Error returns the string representation of the error message.
-func (e *Error) Error() string {
-	return e.Message
-}
+// Error defines a standard application error.
+type Error = pilosa.ResponseError
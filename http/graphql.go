@@ -0,0 +1,433 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+
+	"github.com/pilosa/pilosa/v2"
+	"github.com/pilosa/pilosa/v2/graphql"
+	"github.com/pilosa/pilosa/v2/pql"
+	"github.com/pkg/errors"
+)
+
+// graphQLRequest is the body of a POST /graphql request.
+type graphQLRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// graphQLResponse documents the body of a /graphql response.
+type graphQLResponse struct {
+	Data   map[string]interface{} `json:"data,omitempty"`
+	Errors []*graphql.Error       `json:"errors,omitempty"`
+}
+
+// handleGetGraphQL handles GET /graphql requests, whose query is given by
+// the query, operationName and variables arguments.
+func (h *Handler) handleGetGraphQL(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	req := graphQLRequest{Query: q.Get("query"), OperationName: q.Get("operationName")}
+	if vars := q.Get("variables"); vars != "" {
+		if err := json.Unmarshal([]byte(vars), &req.Variables); err != nil {
+			h.writeGraphQLError(w, r, errors.Wrap(err, "decoding variables"))
+			return
+		}
+	}
+	h.serveGraphQL(w, r, req)
+}
+
+// handlePostGraphQL handles POST /graphql requests, whose body is either a
+// JSON graphQLRequest, or a query with the content type application/graphql.
+func (h *Handler) handlePostGraphQL(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		h.writeGraphQLError(w, r, errors.Wrap(err, "reading body"))
+		return
+	}
+
+	var req graphQLRequest
+	if typ, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); typ == "application/graphql" {
+		req.Query = string(body)
+	} else if err := json.Unmarshal(body, &req); err != nil {
+		h.writeGraphQLError(w, r, errors.Wrap(err, "decoding request"))
+		return
+	}
+	h.serveGraphQL(w, r, req)
+}
+
+// serveGraphQL executes a GraphQL query. The response is 200 OK if the query
+// was executed, even if some of its fields failed to resolve, in which case
+// its errors say which.
+func (h *Handler) serveGraphQL(w http.ResponseWriter, r *http.Request, req graphQLRequest) {
+	if req.Query == "" {
+		h.writeGraphQLError(w, r, errors.New("query is required"))
+		return
+	}
+	doc, err := graphql.Parse(req.Query)
+	if err != nil {
+		h.writeGraphQLError(w, r, err)
+		return
+	}
+
+	resp := graphql.Execute(r.Context(), doc, req.OperationName, req.Variables, graphQLQuery{api: h.api})
+	w.Header().Set("Content-Type", "application/json")
+	if resp.Data == nil {
+		w.WriteHeader(http.StatusBadRequest)
+	}
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.requestLogger(r).Printf("write graphql response error: %s", err)
+	}
+}
+
+// writeGraphQLError writes a response to a GraphQL request which couldn't be
+// executed.
+func (h *Handler) writeGraphQLError(w http.ResponseWriter, r *http.Request, err error) {
+	e, ok := err.(*graphql.Error)
+	if !ok {
+		e = &graphql.Error{Message: err.Error()}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	if err := json.NewEncoder(w).Encode(graphql.Response{Errors: []*graphql.Error{e}}); err != nil {
+		h.requestLogger(r).Printf("write graphql response error: %s", err)
+	}
+}
+
+// The objects of the graph exposed by /graphql. Indexes have fields, which
+// are queried for rows and their top rows. Queries are read-only.
+//
+//	type Query {
+//		indexes: [Index!]!
+//		index(name: String!): Index
+//	}
+//
+//	type Index {
+//		name: String!
+//		keys: Boolean!
+//		trackExistence: Boolean!
+//		timeZone: String!
+//		inMemory: Boolean!
+//		readOnly: Boolean!
+//		shardWidth: Int!
+//		fields: [Field!]!
+//		field(name: String!): Field
+//		count(query: String!): Int!
+//		row(query: String!): Row!
+//	}
+//
+//	type Field {
+//		name: String!
+//		type: String!
+//		keys: Boolean!
+//		cacheType: String
+//		cacheSize: Int
+//		min: Int
+//		max: Int
+//		timeQuantum: String
+//		row(id: Int, key: String): Row!
+//		topN(n: Int, filter: String): [Pair!]!
+//	}
+//
+//	type Row {
+//		columns: [Int!]!
+//		keys: [String!]!
+//		count: Int!
+//	}
+//
+//	type Pair {
+//		id: Int!
+//		key: String
+//		count: Int!
+//	}
+type (
+	graphQLQuery struct {
+		api *pilosa.API
+	}
+
+	graphQLIndex struct {
+		api  *pilosa.API
+		info *pilosa.IndexInfo
+	}
+
+	graphQLField struct {
+		api   *pilosa.API
+		index string
+		info  *pilosa.FieldInfo
+	}
+
+	graphQLRow struct {
+		row *pilosa.Row
+	}
+
+	graphQLPair struct {
+		pair pilosa.Pair
+	}
+)
+
+func (graphQLQuery) TypeName() string { return "Query" }
+func (graphQLIndex) TypeName() string { return "Index" }
+func (graphQLField) TypeName() string { return "Field" }
+func (graphQLRow) TypeName() string   { return "Row" }
+func (graphQLPair) TypeName() string  { return "Pair" }
+
+func (q graphQLQuery) Resolve(ctx context.Context, field string, args graphql.Args) (interface{}, error) {
+	switch field {
+	case "indexes":
+		var indexes []graphql.Object
+		for _, info := range q.api.Schema(ctx) {
+			indexes = append(indexes, graphQLIndex{api: q.api, info: info})
+		}
+		return indexes, nil
+	case "index":
+		name, err := requiredStringArg(args, "name")
+		if err != nil {
+			return nil, err
+		}
+		for _, info := range q.api.Schema(ctx) {
+			if info.Name == name {
+				return graphQLIndex{api: q.api, info: info}, nil
+			}
+		}
+		return nil, nil
+	}
+	return nil, unknownGraphQLField(q, field)
+}
+
+func (idx graphQLIndex) Resolve(ctx context.Context, field string, args graphql.Args) (interface{}, error) {
+	switch field {
+	case "name":
+		return idx.info.Name, nil
+	case "keys":
+		return idx.info.Options.Keys, nil
+	case "trackExistence":
+		return idx.info.Options.TrackExistence, nil
+	case "timeZone":
+		if idx.info.Options.TimeZone == "" {
+			return "UTC", nil
+		}
+		return idx.info.Options.TimeZone, nil
+	case "inMemory":
+		return idx.info.Options.InMemory, nil
+	case "readOnly":
+		return idx.info.ReadOnly, nil
+	case "shardWidth":
+		return idx.info.ShardWidth, nil
+	case "fields":
+		var fields []graphql.Object
+		for _, info := range idx.info.Fields {
+			fields = append(fields, graphQLField{api: idx.api, index: idx.info.Name, info: info})
+		}
+		return fields, nil
+	case "field":
+		name, err := requiredStringArg(args, "name")
+		if err != nil {
+			return nil, err
+		}
+		for _, info := range idx.info.Fields {
+			if info.Name == name {
+				return graphQLField{api: idx.api, index: idx.info.Name, info: info}, nil
+			}
+		}
+		return nil, nil
+	case "count":
+		query, err := requiredStringArg(args, "query")
+		if err != nil {
+			return nil, err
+		}
+		call, err := parseReadCall(query)
+		if err != nil {
+			return nil, err
+		}
+		return executeGraphQLCall(ctx, idx.api, idx.info.Name, &pql.Call{Name: "Count", Children: []*pql.Call{call}})
+	case "row":
+		query, err := requiredStringArg(args, "query")
+		if err != nil {
+			return nil, err
+		}
+		call, err := parseReadCall(query)
+		if err != nil {
+			return nil, err
+		}
+		return executeGraphQLCall(ctx, idx.api, idx.info.Name, call)
+	}
+	return nil, unknownGraphQLField(idx, field)
+}
+
+func (f graphQLField) Resolve(ctx context.Context, field string, args graphql.Args) (interface{}, error) {
+	opt := f.info.Options
+	switch field {
+	case "name":
+		return f.info.Name, nil
+	case "type":
+		return opt.Type, nil
+	case "keys":
+		return opt.Keys, nil
+	case "cacheType":
+		return optionalValue(opt.CacheType != "", opt.CacheType), nil
+	case "cacheSize":
+		return optionalValue(opt.CacheType != "", opt.CacheSize), nil
+	case "min":
+		return optionalValue(opt.Type == pilosa.FieldTypeInt, opt.Min), nil
+	case "max":
+		return optionalValue(opt.Type == pilosa.FieldTypeInt, opt.Max), nil
+	case "timeQuantum":
+		return optionalValue(opt.TimeQuantum != "", string(opt.TimeQuantum)), nil
+	case "row":
+		call := &pql.Call{Name: "Row", Args: map[string]interface{}{}}
+		if key, ok, err := args.StringArg("key"); err != nil {
+			return nil, err
+		} else if ok {
+			call.Args[f.info.Name] = key
+		} else if id, ok, err := args.IntArg("id"); err != nil {
+			return nil, err
+		} else if ok && id >= 0 {
+			call.Args[f.info.Name] = uint64(id)
+		} else {
+			return nil, errors.New("id or key is required")
+		}
+		return executeGraphQLCall(ctx, f.api, f.index, call)
+	case "topN":
+		call := &pql.Call{Name: "TopN", Args: map[string]interface{}{"_field": f.info.Name}}
+		if n, ok, err := args.IntArg("n"); err != nil {
+			return nil, err
+		} else if ok && n > 0 {
+			call.Args["n"] = uint64(n)
+		}
+		if filter, ok, err := args.StringArg("filter"); err != nil {
+			return nil, err
+		} else if ok {
+			child, err := parseReadCall(filter)
+			if err != nil {
+				return nil, err
+			}
+			call.Children = []*pql.Call{child}
+		}
+		return executeGraphQLCall(ctx, f.api, f.index, call)
+	}
+	return nil, unknownGraphQLField(f, field)
+}
+
+func (r graphQLRow) Resolve(ctx context.Context, field string, args graphql.Args) (interface{}, error) {
+	switch field {
+	case "columns":
+		return r.row.Columns(), nil
+	case "keys":
+		return r.row.Keys, nil
+	case "count":
+		return r.row.Count(), nil
+	}
+	return nil, unknownGraphQLField(r, field)
+}
+
+func (p graphQLPair) Resolve(ctx context.Context, field string, args graphql.Args) (interface{}, error) {
+	switch field {
+	case "id":
+		return p.pair.ID, nil
+	case "key":
+		return optionalValue(p.pair.Key != "", p.pair.Key), nil
+	case "count":
+		return p.pair.Count, nil
+	}
+	return nil, unknownGraphQLField(p, field)
+}
+
+// executeGraphQLCall executes a PQL call on an index, and returns its result
+// as a value of the graph.
+func executeGraphQLCall(ctx context.Context, api *pilosa.API, index string, call *pql.Call) (interface{}, error) {
+	resp, err := api.Query(ctx, &pilosa.QueryRequest{Index: index, Query: call.String()})
+	if err != nil {
+		return nil, err
+	} else if len(resp.Results) != 1 {
+		return nil, errors.Errorf("expected one result, got %d", len(resp.Results))
+	}
+	switch result := resp.Results[0].(type) {
+	case *pilosa.Row:
+		return graphQLRow{row: result}, nil
+	case []pilosa.Pair:
+		pairs := make([]graphql.Object, len(result))
+		for i := range result {
+			pairs[i] = graphQLPair{pair: result[i]}
+		}
+		return pairs, nil
+	case uint64:
+		return result, nil
+	default:
+		return nil, errors.Errorf("unexpected result of %s: %T", call.Name, result)
+	}
+}
+
+// parseReadCall parses a PQL query of a single call, which mustn't change
+// any data, since GraphQL queries are read-only.
+func parseReadCall(s string) (*pql.Call, error) {
+	q, err := pql.ParseString(s)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing query")
+	} else if len(q.Calls) != 1 {
+		return nil, errors.Errorf("query must be one call, found %d", len(q.Calls))
+	}
+	var check func(c *pql.Call) error
+	check = func(c *pql.Call) error {
+		switch c.Name {
+		case "Set", "Clear", "ClearRow", "Store", "SetRowAttrs", "SetColumnAttrs":
+			return errors.Errorf("%s can't be used in a GraphQL query", c.Name)
+		}
+		for _, child := range c.Children {
+			if err := check(child); err != nil {
+				return err
+			}
+		}
+		for _, arg := range c.Args {
+			if child, ok := arg.(*pql.Call); ok {
+				if err := check(child); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	if err := check(q.Calls[0]); err != nil {
+		return nil, err
+	}
+	return q.Calls[0], nil
+}
+
+func requiredStringArg(args graphql.Args, name string) (string, error) {
+	s, ok, err := args.StringArg(name)
+	if err != nil {
+		return "", err
+	} else if !ok {
+		return "", fmt.Errorf("argument %q is required", name)
+	}
+	return s, nil
+}
+
+// optionalValue returns v if ok, and nil otherwise.
+func optionalValue(ok bool, v interface{}) interface{} {
+	if !ok {
+		return nil
+	}
+	return v
+}
+
+func unknownGraphQLField(obj graphql.Object, field string) error {
+	return fmt.Errorf("cannot query field %q on type %s", field, obj.TypeName())
+}
@@ -15,6 +15,7 @@
 package http
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"encoding/json"
@@ -28,15 +29,19 @@ import (
 	_ "net/http/pprof" // Imported for its side-effect of registering pprof endpoints with the server.
 	"net/url"
 	"reflect"
+	"regexp"
 	"runtime/debug"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
 	"github.com/pilosa/pilosa/v2"
 	"github.com/pilosa/pilosa/v2/logger"
+	"github.com/pilosa/pilosa/v2/toml"
 	"github.com/pilosa/pilosa/v2/tracing"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -46,6 +51,10 @@ import (
 type Handler struct {
 	Handler http.Handler
 
+	// router routes requests without any of the middleware which options
+	// wrap Handler with, so that other listeners can apply their own.
+	router *mux.Router
+
 	logger logger.Logger
 
 	// Keeps the query argument validators for each handler
@@ -55,8 +64,22 @@ type Handler struct {
 
 	ln net.Listener
 
+	// An optional listener for requests from other nodes, served
+	// separately from the public API.
+	internalLn     net.Listener
+	internalServer *http.Server
+
+	// An optional additional listener for the public API, such as a
+	// plaintext one alongside TLS, with its own CORS allowed origins.
+	secondaryLn      net.Listener
+	secondaryOrigins []string
+	secondaryServer  *http.Server
+
 	closeTimeout time.Duration
 
+	// Query responses with rows of more columns than this are streamed.
+	streamThreshold uint64
+
 	server *http.Server
 }
 
@@ -74,7 +97,47 @@ var externalPrefixFlag = map[string]bool{
 }
 
 type errorResponse struct {
-	Error string `json:"error"`
+	Error interface{} `json:"error"`
+}
+
+// newErrorResponse returns the response describing err to r: its message
+// alone, or its description if r asks for typed errors.
+func newErrorResponse(r *http.Request, err error) errorResponse {
+	if !typedErrors(r) {
+		return errorResponse{Error: err.Error()}
+	}
+	return errorResponse{Error: responseError(r, err)}
+}
+
+// typedErrors returns true if errors in the response to r are described with
+// codes and the index and field they concern. Unversioned paths keep
+// describing errors by their message alone; versioned paths, and requests
+// from other nodes, get the typed description.
+func typedErrors(r *http.Request) bool {
+	if r == nil {
+		return false
+	}
+	return apiVersionPrefix.MatchString(r.URL.Path) ||
+		strings.HasPrefix(r.URL.Path, "/internal/") ||
+		r.URL.Query().Get("remote") == "true"
+}
+
+// responseError returns the description of an error in the response to r,
+// which is given the index and field named by the request's path if the
+// error doesn't name its own. r may be nil.
+func responseError(r *http.Request, err error) *Error {
+	e := pilosa.NewResponseError(err)
+	if r == nil {
+		return e
+	}
+	vars := mux.Vars(r)
+	if e.Index == "" {
+		e.Index = vars["index"]
+	}
+	if e.Field == "" {
+		e.Field = vars["field"]
+	}
+	return e
 }
 
 // handlerOption is a functional option type for pilosa.Handler
@@ -82,14 +145,20 @@ type handlerOption func(s *Handler) error
 
 func OptHandlerAllowedOrigins(origins []string) handlerOption {
 	return func(h *Handler) error {
-		h.Handler = handlers.CORS(
-			handlers.AllowedOrigins(origins),
-			handlers.AllowedHeaders([]string{"Content-Type"}),
-		)(h.Handler)
+		h.Handler = corsHandler(h.Handler, origins)
 		return nil
 	}
 }
 
+// corsHandler wraps a handler so that it answers CORS requests from the
+// given origins.
+func corsHandler(handler http.Handler, origins []string) http.Handler {
+	return handlers.CORS(
+		handlers.AllowedOrigins(origins),
+		handlers.AllowedHeaders([]string{"Content-Type"}),
+	)(handler)
+}
+
 func OptHandlerAPI(api *pilosa.API) handlerOption {
 	return func(h *Handler) error {
 		h.api = api
@@ -111,6 +180,29 @@ func OptHandlerListener(ln net.Listener) handlerOption {
 	}
 }
 
+// OptHandlerInternalListener sets a second listener on which the handler
+// serves requests from other nodes, so that they can be kept on a private
+// network.
+func OptHandlerInternalListener(ln net.Listener) handlerOption {
+	return func(h *Handler) error {
+		h.internalLn = ln
+		return nil
+	}
+}
+
+// OptHandlerSecondaryListener sets an additional listener on which the
+// handler serves the API, such as a plaintext listener on the loopback
+// interface for sidecars alongside a TLS listener for external clients. It
+// answers CORS requests from its own allowed origins, rather than those set
+// by OptHandlerAllowedOrigins.
+func OptHandlerSecondaryListener(ln net.Listener, allowedOrigins []string) handlerOption {
+	return func(h *Handler) error {
+		h.secondaryLn = ln
+		h.secondaryOrigins = allowedOrigins
+		return nil
+	}
+}
+
 // OptHandlerCloseTimeout controls how long to wait for the http Server to
 // shutdown cleanly before forcibly destroying it. Default is 30 seconds.
 func OptHandlerCloseTimeout(d time.Duration) handlerOption {
@@ -120,13 +212,24 @@ func OptHandlerCloseTimeout(d time.Duration) handlerOption {
 	}
 }
 
+// OptHandlerStreamThreshold sets the number of columns of a row above which
+// query responses are streamed. Zero disables streaming.
+func OptHandlerStreamThreshold(n uint64) handlerOption {
+	return func(h *Handler) error {
+		h.streamThreshold = n
+		return nil
+	}
+}
+
 // NewHandler returns a new instance of Handler with a default logger.
 func NewHandler(opts ...handlerOption) (*Handler, error) {
 	handler := &Handler{
-		logger:       logger.NopLogger,
-		closeTimeout: time.Second * 30,
+		logger:          logger.NopLogger,
+		closeTimeout:    time.Second * 30,
+		streamThreshold: defaultStreamThreshold,
 	}
-	handler.Handler = newRouter(handler)
+	handler.router = newRouter(handler)
+	handler.Handler = handler.router
 	handler.populateValidators()
 
 	for _, opt := range opts {
@@ -145,11 +248,25 @@ func NewHandler(opts ...handlerOption) (*Handler, error) {
 	}
 
 	handler.server = &http.Server{Handler: handler}
+	if handler.internalLn != nil {
+		internal := newInternalRouter(handler)
+		handler.internalServer = &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			handler.serve(w, r, internal)
+		})}
+	}
+	if handler.secondaryLn != nil {
+		secondary := corsHandler(handler.router, handler.secondaryOrigins)
+		handler.secondaryServer = &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			handler.serve(w, r, secondary)
+		})}
+	}
 
 	return handler, nil
 }
 
 func (h *Handler) Serve() error {
+	h.serveBackground("internal", h.internalServer, h.internalLn)
+	h.serveBackground("secondary", h.secondaryServer, h.secondaryLn)
 	err := h.server.Serve(h.ln)
 	if err != nil && err.Error() != "http: Server closed" {
 		h.logger.Printf("HTTP handler terminated with error: %s\n", err)
@@ -158,11 +275,32 @@ func (h *Handler) Serve() error {
 	return nil
 }
 
+// serveBackground serves one of the optional listeners in a goroutine, if
+// it is set.
+func (h *Handler) serveBackground(name string, srv *http.Server, ln net.Listener) {
+	if srv == nil {
+		return
+	}
+	go func() {
+		err := srv.Serve(ln)
+		if err != nil && err != http.ErrServerClosed {
+			h.logger.Printf("%s HTTP handler terminated with error: %s\n", name, err)
+		}
+	}()
+}
+
 // Close tries to cleanly shutdown the HTTP server, and failing that, after a
 // timeout, calls Server.Close.
 func (h *Handler) Close() error {
 	deadlineCtx, cancelFunc := context.WithDeadline(context.Background(), time.Now().Add(h.closeTimeout))
 	defer cancelFunc()
+	for _, srv := range []*http.Server{h.internalServer, h.secondaryServer} {
+		if srv == nil {
+			continue
+		} else if err := srv.Shutdown(deadlineCtx); err != nil {
+			_ = srv.Close()
+		}
+	}
 	err := h.server.Shutdown(deadlineCtx)
 	if err != nil {
 		err = h.server.Close()
@@ -173,26 +311,72 @@ func (h *Handler) Close() error {
 func (h *Handler) populateValidators() {
 	h.validators = map[string]*queryValidationSpec{}
 	h.validators["Home"] = queryValidationSpecRequired()
+	h.validators["GetOpenAPI"] = queryValidationSpecRequired()
 	h.validators["PostClusterResizeAbort"] = queryValidationSpecRequired()
 	h.validators["PostClusterResizeRemoveNode"] = queryValidationSpecRequired()
 	h.validators["PostClusterResizeSetCoordinator"] = queryValidationSpecRequired()
+	h.validators["GetChanges"] = queryValidationSpecRequired().Optional("since")
 	h.validators["GetExport"] = queryValidationSpecRequired("index", "field", "shard")
-	h.validators["GetIndexes"] = queryValidationSpecRequired()
-	h.validators["GetIndex"] = queryValidationSpecRequired()
+	h.validators["GetGraphQL"] = queryValidationSpecRequired("query").Optional("operationName", "variables")
+	h.validators["PostGraphQL"] = queryValidationSpecRequired()
+	h.validators["GetIndexes"] = queryValidationSpecRequired().Optional("prefix", "after", "limit", "fields")
+	h.validators["GetIndex"] = queryValidationSpecRequired().Optional("prefix", "after", "limit")
 	h.validators["PostIndex"] = queryValidationSpecRequired()
 	h.validators["DeleteIndex"] = queryValidationSpecRequired()
+	h.validators["PostIndexReadOnly"] = queryValidationSpecRequired()
+	h.validators["DeleteIndexReadOnly"] = queryValidationSpecRequired()
+	h.validators["GetMaterializedViews"] = queryValidationSpecRequired()
+	h.validators["PostMaterializedView"] = queryValidationSpecRequired()
+	h.validators["DeleteMaterializedView"] = queryValidationSpecRequired()
+	h.validators["GetRewriteRules"] = queryValidationSpecRequired()
+	h.validators["PostRewriteRule"] = queryValidationSpecRequired()
+	h.validators["DeleteRewriteRule"] = queryValidationSpecRequired()
+	h.validators["GetExportAttrs"] = queryValidationSpecRequired().Optional("format")
+	h.validators["GetUDFs"] = queryValidationSpecRequired()
+	h.validators["PostUDF"] = queryValidationSpecRequired()
+	h.validators["DeleteUDF"] = queryValidationSpecRequired()
 	h.validators["GetTranslateData"] = queryValidationSpecRequired("offset")
 	h.validators["PostTranslateKeys"] = queryValidationSpecRequired()
 	h.validators["PostField"] = queryValidationSpecRequired()
 	h.validators["DeleteField"] = queryValidationSpecRequired()
-	h.validators["PostImport"] = queryValidationSpecRequired().Optional("clear", "ignoreKeyCheck")
+	h.validators["DeleteRows"] = queryValidationSpecRequired().Optional("to")
+	h.validators["PostFieldTruncate"] = queryValidationSpecRequired()
+	h.validators["PostFieldReindex"] = queryValidationSpecRequired()
+	h.validators["GetFieldHistogram"] = queryValidationSpecRequired().Optional("top", "sample")
+	h.validators["GetJobs"] = queryValidationSpecRequired()
+	h.validators["GetUsage"] = queryValidationSpecRequired()
+	h.validators["GetUsageReport"] = queryValidationSpecRequired().Optional("from", "to", "token", "namespace")
+	h.validators["GetSettings"] = queryValidationSpecRequired()
+	h.validators["PostSettings"] = queryValidationSpecRequired()
+	h.validators["GetAliases"] = queryValidationSpecRequired()
+	h.validators["PostAliases"] = queryValidationSpecRequired()
+	h.validators["GetJob"] = queryValidationSpecRequired()
+	h.validators["GetJobResult"] = queryValidationSpecRequired().Optional("wait")
+	h.validators["DeleteJob"] = queryValidationSpecRequired()
+	h.validators["PostImport"] = queryValidationSpecRequired().Optional("clear", "ignoreKeyCheck", "async", "timeQuantum", "skipStandardView", "remote")
 	h.validators["PostImportRoaring"] = queryValidationSpecRequired().Optional("remote", "clear")
-	h.validators["PostQuery"] = queryValidationSpecRequired().Optional("shards", "columnAttrs", "excludeRowAttrs", "excludeColumns")
+	h.validators["PostQuery"] = queryValidationSpecRequired().Optional("shards", "columnAttrs", "excludeRowAttrs", "excludeColumns", "priority", "profile", "async", "continueOnError", "affinity", "remote")
+	h.validators["PostQueryValidate"] = queryValidationSpecRequired()
+	h.validators["GetNamedQueries"] = queryValidationSpecRequired()
+	h.validators["PostNamedQuery"] = queryValidationSpecRequired()
+	h.validators["DeleteNamedQuery"] = queryValidationSpecRequired()
+	// GetNamedQuery takes the parameters of the query, so has no validator.
+	h.validators["GetSchedules"] = queryValidationSpecRequired()
+	h.validators["GetSchedule"] = queryValidationSpecRequired()
+	h.validators["PostSchedule"] = queryValidationSpecRequired()
+	h.validators["DeleteSchedule"] = queryValidationSpecRequired()
 	h.validators["GetInfo"] = queryValidationSpecRequired()
 	h.validators["RecalculateCaches"] = queryValidationSpecRequired()
-	h.validators["GetSchema"] = queryValidationSpecRequired()
+	h.validators["GetSchema"] = queryValidationSpecRequired().Optional("prefix", "after", "limit", "fields")
+	h.validators["GetSchemaDiff"] = queryValidationSpecRequired("remote")
+	h.validators["GetShardsMax"] = queryValidationSpecRequired().Optional("cluster")
 	h.validators["PostSchema"] = queryValidationSpecRequired().Optional("remote")
+	h.validators["GetReadyz"] = queryValidationSpecRequired()
 	h.validators["GetStatus"] = queryValidationSpecRequired()
+	h.validators["GetClusterStatus"] = queryValidationSpecRequired()
+	h.validators["GetClusterTopology"] = queryValidationSpecRequired().Optional("index")
+	h.validators["PostClusterRebalance"] = queryValidationSpecRequired().Optional("dryRun")
+	h.validators["GetClusterSlices"] = queryValidationSpecRequired().Optional("index")
 	h.validators["GetVersion"] = queryValidationSpecRequired()
 	h.validators["PostClusterMessage"] = queryValidationSpecRequired()
 	h.validators["GetFragmentBlockData"] = queryValidationSpecRequired()
@@ -212,7 +396,7 @@ func (h *Handler) queryArgValidator(next http.Handler) http.Handler {
 		if validator, ok := h.validators[key]; ok {
 			if err := validator.validate(r.URL.Query()); err != nil {
 				// TODO: Return the response depending on the Accept header
-				response := errorResponse{Error: err.Error()}
+				response := newErrorResponse(r, pilosa.NewBadRequestError(err))
 				body, err := json.Marshal(response)
 				if err != nil {
 					http.Error(w, err.Error(), http.StatusBadRequest)
@@ -235,21 +419,98 @@ func (h *Handler) extractTracing(next http.Handler) http.Handler {
 	})
 }
 
+// extractToken adds the API token from a request's "Authorization: Bearer"
+// header to its context, so that the request's usage is metered against it.
+// Requests from other nodes, to /internal endpoints or marked remote, are
+// marked internal, so they aren't metered unless they pass a token on.
+func (h *Handler) extractToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+			ctx = pilosa.WithAPIToken(ctx, strings.TrimPrefix(auth, "Bearer "))
+		}
+		if strings.HasPrefix(r.URL.Path, "/internal/") || r.URL.Query().Get("remote") == "true" {
+			ctx = pilosa.WithInternalRequest(ctx)
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// namespaceFreeRoutes are the routes which do not name an index but may be
+// used with an API token bound to a namespace. They only describe the node,
+// or the schema or usage of the namespace.
+var namespaceFreeRoutes = map[string]bool{
+	"Home":           true,
+	"GetOpenAPI":     true,
+	"GetIndexes":     true,
+	"GetSchema":      true,
+	"GetInfo":        true,
+	"GetReadyz":      true,
+	"GetVersion":     true,
+	"GetUsageReport": true,
+}
+
+// checkNamespace rejects requests whose API token is bound to a namespace,
+// unless they name an index of the namespace, in their path or "index"
+// parameter, or use a route in namespaceFreeRoutes.
+func (h *Handler) checkNamespace(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if h.api.TokenNamespace(r.Context()) == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		index := mux.Vars(r)["index"]
+		if index == "" {
+			index = r.URL.Query().Get("index")
+		}
+		if index == "" {
+			if route := mux.CurrentRoute(r); route == nil || !namespaceFreeRoutes[route.GetName()] {
+				http.Error(w, pilosa.ErrNamespaceForbidden.Error(), http.StatusForbidden)
+				return
+			}
+		} else if err := h.api.CheckNamespace(r.Context(), index); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 func (h *Handler) collectStats(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sw := &statsResponseWriter{ResponseWriter: w}
+		var body *countingReader
+		if r.Body != nil {
+			body = &countingReader{ReadCloser: r.Body}
+			r.Body = body
+		}
+
 		t := time.Now()
-		next.ServeHTTP(w, r)
+		next.ServeHTTP(sw, r)
 		dur := time.Since(t)
 
+		h.collectRouteStats(r, sw, body, dur)
+
+		// Meter the bytes of the request and response against its token.
+		n := sw.n
+		if body != nil {
+			n += body.n
+		}
+		h.api.AddNetworkUsage(r.Context(), n)
+
 		statsTags := make([]string, 0, 5)
 
 		longQueryTime := h.api.LongQueryTime()
 		if longQueryTime > 0 && dur > longQueryTime {
-			h.logger.Printf("%s %s %v", r.Method, r.URL.String(), dur)
+			h.requestLogger(r).Printf("%s %s %v", r.Method, r.URL.String(), dur)
 			statsTags = append(statsTags, "slow_query")
 		}
 
 		pathParts := strings.Split(r.URL.Path, "/")
+		if len(pathParts) > 2 && isAPIVersion(pathParts[1]) {
+			pathParts = pathParts[1:]
+		}
 		if externalPrefixFlag[pathParts[1]] {
 			statsTags = append(statsTags, "external")
 		}
@@ -270,17 +531,175 @@ func (h *Handler) collectStats(next http.Handler) http.Handler {
 	})
 }
 
+// collectRouteStats records the latency, and the request and response sizes,
+// of a request by method, route and status code, so that the behaviour of
+// each endpoint can be seen separately.
+func (h *Handler) collectRouteStats(r *http.Request, w *statsResponseWriter, body *countingReader, dur time.Duration) {
+	route := "unknown"
+	if path, err := mux.CurrentRoute(r).GetPathTemplate(); err == nil {
+		route = path
+	}
+	status := w.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	stats := h.api.StatsWithTags([]string{
+		"method:" + r.Method,
+		"route:" + route,
+		"status:" + strconv.Itoa(status),
+	})
+	if stats == nil {
+		return
+	}
+	stats.Histogram("http.request.duration_seconds", dur.Seconds(), 1.0)
+	if body != nil {
+		stats.Histogram("http.request.size_bytes", float64(body.n), 1.0)
+	}
+	stats.Histogram("http.response.size_bytes", float64(w.n), 1.0)
+}
+
+// statsResponseWriter records the status code and size of a response.
+type statsResponseWriter struct {
+	http.ResponseWriter
+	status int
+	n      int64
+}
+
+func (w *statsResponseWriter) WriteHeader(statusCode int) {
+	if w.status == 0 {
+		w.status = statusCode
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *statsResponseWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(p)
+	w.n += int64(n)
+	return n, err
+}
+
+// Flush passes on flushes for handlers which stream their responses.
+func (w *statsResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// countingReader counts the bytes read from a request body.
+type countingReader struct {
+	io.ReadCloser
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.n += int64(n)
+	return n, err
+}
+
+// apiVersions are the versions of the public API which are served, oldest
+// first. Each is served under a path prefix of its name, such as /v1/schema,
+// and the first is also served without a prefix, for clients written before
+// the API was versioned.
+var apiVersions = []string{"v1"}
+
 // newRouter creates a new mux http router.
 func newRouter(handler *Handler) *mux.Router {
 	router := mux.NewRouter()
 	router.HandleFunc("/", handler.handleHome).Methods("GET").Name("Home")
-	router.HandleFunc("/cluster/resize/abort", handler.handlePostClusterResizeAbort).Methods("POST").Name("PostClusterResizeAbort")
-	router.HandleFunc("/cluster/resize/remove-node", handler.handlePostClusterResizeRemoveNode).Methods("POST").Name("PostClusterResizeRemoveNode")
-	router.HandleFunc("/cluster/resize/set-coordinator", handler.handlePostClusterResizeSetCoordinator).Methods("POST").Name("PostClusterResizeSetCoordinator")
 	router.PathPrefix("/debug/pprof/").Handler(http.DefaultServeMux).Methods("GET")
 	router.Handle("/debug/vars", expvar.Handler()).Methods("GET")
 	router.Handle("/metrics", promhttp.Handler())
+	router.HandleFunc("/openapi.json", handler.handleGetOpenAPI).Methods("GET").Name("GetOpenAPI")
+
+	for _, version := range apiVersions {
+		addAPIRoutes(router.PathPrefix("/"+version).Subrouter(), handler)
+	}
+	addAPIRoutes(router, handler)
+	router.NotFoundHandler = http.HandlerFunc(handler.handleNotFound)
+
+	// /internal endpoints are only served on the public listener if there is
+	// no internal listener for them.
+	addInternalRoutes(router.PathPrefix("/internal").MatcherFunc(func(*http.Request, *mux.RouteMatch) bool {
+		return handler.internalLn == nil
+	}).Subrouter(), handler)
+
+	router.Use(handler.queryArgValidator)
+	router.Use(handler.extractTracing)
+	router.Use(handler.extractToken)
+	router.Use(handler.checkNamespace)
+	router.Use(handler.collectStats)
+	return router
+}
+
+// newInternalRouter creates the router of the internal listener, which serves
+// the /internal endpoints and the parts of the public API which other nodes
+// use.
+func newInternalRouter(handler *Handler) *mux.Router {
+	router := mux.NewRouter()
+	addInternalRoutes(router.PathPrefix("/internal").Subrouter(), handler)
+	router.HandleFunc("/index/{index}/field/{field}/import", handler.handlePostImport).Methods("POST").Name("PostImport")
+	router.HandleFunc("/index/{index}/field/{field}/import-roaring/{shard}", handler.handlePostImportRoaring).Methods("POST").Name("PostImportRoaring")
+	router.HandleFunc("/index/{index}/query", handler.handlePostQuery).Methods("POST").Name("PostQuery")
+	router.HandleFunc("/schema", handler.handleGetSchema).Methods("GET").Name("GetSchema")
+	router.HandleFunc("/schema", handler.handlePostSchema).Methods("POST").Name("PostSchema")
+	router.HandleFunc("/status", handler.handleGetStatus).Methods("GET").Name("GetStatus")
+	router.HandleFunc("/version", handler.handleGetVersion).Methods("GET").Name("GetVersion")
+	router.NotFoundHandler = http.HandlerFunc(handler.handleNotFound)
+
+	router.Use(handler.queryArgValidator)
+	router.Use(handler.extractTracing)
+	router.Use(handler.extractToken)
+	router.Use(handler.checkNamespace)
+	router.Use(handler.collectStats)
+	return router
+}
+
+// addInternalRoutes adds the /internal endpoints to router, which is given
+// the /internal prefix. They are for internal use only; they may change at
+// any time. DO NOT rely on these for external applications!
+func addInternalRoutes(router *mux.Router, handler *Handler) {
+	router.HandleFunc("/cluster/message", handler.handlePostClusterMessage).Methods("POST").Name("PostClusterMessage")
+	router.HandleFunc("/fragment/block/data", handler.handleGetFragmentBlockData).Methods("GET").Name("GetFragmentBlockData")
+	router.HandleFunc("/fragment/blocks", handler.handleGetFragmentBlocks).Methods("GET").Name("GetFragmentBlocks")
+	router.HandleFunc("/fragment/data", handler.handleGetFragmentData).Methods("GET").Name("GetFragmentData")
+	router.HandleFunc("/fragment/nodes", handler.handleGetFragmentNodes).Methods("GET").Name("GetFragmentNodes")
+	router.HandleFunc("/index/{index}/attr/diff", handler.handlePostIndexAttrDiff).Methods("POST").Name("PostIndexAttrDiff")
+	router.HandleFunc("/translate/data", handler.handlePostTranslateData).Methods("POST").Name("PostTranslateData")
+	router.HandleFunc("/translate/keys", handler.handlePostTranslateKeys).Methods("POST").Name("PostTranslateKeys")
+	router.HandleFunc("/index/{index}/field/{field}/attr/diff", handler.handlePostFieldAttrDiff).Methods("POST").Name("PostFieldAttrDiff")
+	router.HandleFunc("/index/{index}/field/{field}/remote-available-shards/{shardID}", handler.handleDeleteRemoteAvailableShard).Methods("DELETE")
+	router.HandleFunc("/nodes", handler.handleGetNodes).Methods("GET").Name("GetNodes")
+	router.HandleFunc("/shards/max", handler.handleGetShardsMax).Methods("GET").Name("GetShardsMax") // TODO: deprecate, but it's being used by the client
+}
+
+// addAPIRoutes adds the routes of the public API to router.
+func addAPIRoutes(router *mux.Router, handler *Handler) {
+	router.HandleFunc("/aliases", handler.handleGetAliases).Methods("GET").Name("GetAliases")
+	router.HandleFunc("/aliases", handler.handlePostAliases).Methods("POST").Name("PostAliases")
+	router.HandleFunc("/query/named", handler.handleGetNamedQueries).Methods("GET").Name("GetNamedQueries")
+	router.HandleFunc("/query/named/{name}", handler.handleGetNamedQuery).Methods("GET").Name("GetNamedQuery")
+	router.HandleFunc("/query/named/{name}", handler.handlePostNamedQuery).Methods("POST").Name("PostNamedQuery")
+	router.HandleFunc("/query/named/{name}", handler.handleDeleteNamedQuery).Methods("DELETE").Name("DeleteNamedQuery")
+	router.HandleFunc("/schedule", handler.handleGetSchedules).Methods("GET").Name("GetSchedules")
+	router.HandleFunc("/schedule/{name}", handler.handleGetSchedule).Methods("GET").Name("GetSchedule")
+	router.HandleFunc("/schedule/{name}", handler.handlePostSchedule).Methods("POST").Name("PostSchedule")
+	router.HandleFunc("/schedule/{name}", handler.handleDeleteSchedule).Methods("DELETE").Name("DeleteSchedule")
+	router.HandleFunc("/cluster/status", handler.handleGetClusterStatus).Methods("GET").Name("GetClusterStatus")
+	router.HandleFunc("/cluster/topology", handler.handleGetClusterTopology).Methods("GET").Name("GetClusterTopology")
+	router.HandleFunc("/cluster/rebalance", handler.handlePostClusterRebalance).Methods("POST").Name("PostClusterRebalance")
+	router.HandleFunc("/cluster/slices", handler.handleGetClusterSlices).Methods("GET").Name("GetClusterSlices")
+	router.HandleFunc("/cluster/resize/abort", handler.handlePostClusterResizeAbort).Methods("POST").Name("PostClusterResizeAbort")
+	router.HandleFunc("/cluster/resize/remove-node", handler.handlePostClusterResizeRemoveNode).Methods("POST").Name("PostClusterResizeRemoveNode")
+	router.HandleFunc("/cluster/resize/set-coordinator", handler.handlePostClusterResizeSetCoordinator).Methods("POST").Name("PostClusterResizeSetCoordinator")
+	router.HandleFunc("/changes", handler.handleGetChanges).Methods("GET").Name("GetChanges")
 	router.HandleFunc("/export", handler.handleGetExport).Methods("GET").Name("GetExport")
+	router.HandleFunc("/graphql", handler.handleGetGraphQL).Methods("GET").Name("GetGraphQL")
+	router.HandleFunc("/graphql", handler.handlePostGraphQL).Methods("POST").Name("PostGraphQL")
 	router.HandleFunc("/index", handler.handleGetIndexes).Methods("GET").Name("GetIndexes")
 	router.HandleFunc("/index", handler.handlePostIndex).Methods("POST").Name("PostIndex")
 	router.HandleFunc("/index/", handler.handlePostIndex).Methods("POST").Name("PostIndex")
@@ -294,55 +713,135 @@ func newRouter(handler *Handler) *mux.Router {
 	router.HandleFunc("/index/{index}/field/{field}", handler.handleDeleteField).Methods("DELETE").Name("DeleteField")
 	router.HandleFunc("/index/{index}/field/{field}/import", handler.handlePostImport).Methods("POST").Name("PostImport")
 	router.HandleFunc("/index/{index}/field/{field}/import-roaring/{shard}", handler.handlePostImportRoaring).Methods("POST").Name("PostImportRoaring")
+	router.HandleFunc("/index/{index}/field/{field}/row/{row}", handler.handleDeleteRows).Methods("DELETE").Name("DeleteRows")
+	router.HandleFunc("/index/{index}/field/{field}/truncate", handler.handlePostFieldTruncate).Methods("POST").Name("PostFieldTruncate")
+	router.HandleFunc("/index/{index}/field/{field}/reindex", handler.handlePostFieldReindex).Methods("POST").Name("PostFieldReindex")
+	router.HandleFunc("/index/{index}/field/{field}/histogram", handler.handleGetFieldHistogram).Methods("GET").Name("GetFieldHistogram")
 	router.HandleFunc("/index/{index}/query", handler.handlePostQuery).Methods("POST").Name("PostQuery")
+	router.HandleFunc("/index/{index}/query/validate", handler.handlePostQueryValidate).Methods("POST").Name("PostQueryValidate")
+	router.HandleFunc("/index/{index}/read-only", handler.handlePostIndexReadOnly).Methods("POST").Name("PostIndexReadOnly")
+	router.HandleFunc("/index/{index}/read-only", handler.handleDeleteIndexReadOnly).Methods("DELETE").Name("DeleteIndexReadOnly")
+	router.HandleFunc("/index/{index}/materialized", handler.handleGetMaterializedViews).Methods("GET").Name("GetMaterializedViews")
+	router.HandleFunc("/index/{index}/materialized/{name}", handler.handlePostMaterializedView).Methods("POST").Name("PostMaterializedView")
+	router.HandleFunc("/index/{index}/materialized/{name}", handler.handleDeleteMaterializedView).Methods("DELETE").Name("DeleteMaterializedView")
+	router.HandleFunc("/index/{index}/rewrite", handler.handleGetRewriteRules).Methods("GET").Name("GetRewriteRules")
+	router.HandleFunc("/index/{index}/rewrite/{name}", handler.handlePostRewriteRule).Methods("POST").Name("PostRewriteRule")
+	router.HandleFunc("/index/{index}/rewrite/{name}", handler.handleDeleteRewriteRule).Methods("DELETE").Name("DeleteRewriteRule")
+	router.HandleFunc("/index/{index}/attrs/export", handler.handleGetExportAttrs).Methods("GET").Name("GetExportAttrs")
 	router.HandleFunc("/info", handler.handleGetInfo).Methods("GET").Name("GetInfo")
+	router.HandleFunc("/jobs", handler.handleGetJobs).Methods("GET").Name("GetJobs")
+	router.HandleFunc("/jobs/{id}", handler.handleGetJob).Methods("GET").Name("GetJob")
+	router.HandleFunc("/jobs/{id}", handler.handleDeleteJob).Methods("DELETE").Name("DeleteJob")
+	router.HandleFunc("/jobs/{id}/result", handler.handleGetJobResult).Methods("GET").Name("GetJobResult")
+	router.HandleFunc("/readyz", handler.handleGetReadyz).Methods("GET").Name("GetReadyz")
 	router.HandleFunc("/recalculate-caches", handler.handleRecalculateCaches).Methods("POST").Name("RecalculateCaches")
 	router.HandleFunc("/schema", handler.handleGetSchema).Methods("GET").Name("GetSchema")
 	router.HandleFunc("/schema", handler.handlePostSchema).Methods("POST").Name("PostSchema")
+	router.HandleFunc("/schema/diff", handler.handleGetSchemaDiff).Methods("GET").Name("GetSchemaDiff")
+	router.HandleFunc("/settings", handler.handleGetSettings).Methods("GET").Name("GetSettings")
+	router.HandleFunc("/settings", handler.handlePostSettings).Methods("POST").Name("PostSettings")
 	router.HandleFunc("/status", handler.handleGetStatus).Methods("GET").Name("GetStatus")
+	router.HandleFunc("/udf", handler.handleGetUDFs).Methods("GET").Name("GetUDFs")
+	router.HandleFunc("/udf/{name}", handler.handlePostUDF).Methods("POST").Name("PostUDF")
+	router.HandleFunc("/udf/{name}", handler.handleDeleteUDF).Methods("DELETE").Name("DeleteUDF")
+	router.HandleFunc("/usage", handler.handleGetUsage).Methods("GET").Name("GetUsage")
+	router.HandleFunc("/usage/report", handler.handleGetUsageReport).Methods("GET").Name("GetUsageReport")
 	router.HandleFunc("/version", handler.handleGetVersion).Methods("GET").Name("GetVersion")
+}
 
-	// /internal endpoints are for internal use only; they may change at any time.
-	// DO NOT rely on these for external applications!
-	router.HandleFunc("/internal/cluster/message", handler.handlePostClusterMessage).Methods("POST").Name("PostClusterMessage")
-	router.HandleFunc("/internal/fragment/block/data", handler.handleGetFragmentBlockData).Methods("GET").Name("GetFragmentBlockData")
-	router.HandleFunc("/internal/fragment/blocks", handler.handleGetFragmentBlocks).Methods("GET").Name("GetFragmentBlocks")
-	router.HandleFunc("/internal/fragment/data", handler.handleGetFragmentData).Methods("GET").Name("GetFragmentData")
-	router.HandleFunc("/internal/fragment/nodes", handler.handleGetFragmentNodes).Methods("GET").Name("GetFragmentNodes")
-	router.HandleFunc("/internal/index/{index}/attr/diff", handler.handlePostIndexAttrDiff).Methods("POST").Name("PostIndexAttrDiff")
-	router.HandleFunc("/internal/translate/data", handler.handlePostTranslateData).Methods("POST").Name("PostTranslateData")
-	router.HandleFunc("/internal/translate/keys", handler.handlePostTranslateKeys).Methods("POST").Name("PostTranslateKeys")
-	router.HandleFunc("/internal/index/{index}/field/{field}/attr/diff", handler.handlePostFieldAttrDiff).Methods("POST").Name("PostFieldAttrDiff")
-	router.HandleFunc("/internal/index/{index}/field/{field}/remote-available-shards/{shardID}", handler.handleDeleteRemoteAvailableShard).Methods("DELETE")
-	router.HandleFunc("/internal/nodes", handler.handleGetNodes).Methods("GET").Name("GetNodes")
-	router.HandleFunc("/internal/shards/max", handler.handleGetShardsMax).Methods("GET").Name("GetShardsMax") // TODO: deprecate, but it's being used by the client
+// maxRequestIDLen is the longest request ID accepted from a client.
+const maxRequestIDLen = 128
 
-	router.Use(handler.queryArgValidator)
-	router.Use(handler.extractTracing)
-	router.Use(handler.collectStats)
-	return router
+// validRequestID returns true if id can be used as a request ID. IDs are
+// logged, so they are limited to a reasonable length of printable characters.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLen {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// requestLogger returns the handler's logger, which prefixes messages with
+// the ID of r.
+func (h *Handler) requestLogger(r *http.Request) logger.Logger {
+	return pilosa.RequestLogger(r.Context(), h.logger)
 }
 
 // ServeHTTP handles an HTTP request.
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.serve(w, r, h.Handler)
+}
+
+// serve handles a request with next, which is the router wrapped with the
+// middleware of the listener it arrived on.
+func (h *Handler) serve(w http.ResponseWriter, r *http.Request, next http.Handler) {
+	// Tag the request with an ID, which is logged with it, passed on to the
+	// other nodes it makes requests to, and returned to the client. The ID
+	// sent by a client or another node is kept, so that the request can be
+	// followed across the cluster.
+	id := r.Header.Get(pilosa.RequestIDHeader)
+	if !validRequestID(id) {
+		id = pilosa.NewRequestID()
+	}
+	w.Header().Set(pilosa.RequestIDHeader, id)
+	r = r.WithContext(pilosa.WithRequestID(r.Context(), id))
+
 	defer func() {
 		if err := recover(); err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			stack := debug.Stack()
 			msg := "PANIC: %s\n%s"
-			h.logger.Printf(msg, err, stack)
+			h.requestLogger(r).Printf(msg, err, stack)
 			fmt.Fprintf(w, msg, err, stack)
 		}
 	}()
 
-	h.Handler.ServeHTTP(w, r)
+	// Decompress request bodies, and compress responses with a codec the
+	// client accepts. Other nodes use these to cut the size of the data
+	// they exchange.
+	body, err := decompressBody(r.Header, r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if body != r.Body {
+		r.Body, r.ContentLength = body, -1
+	}
+	w.Header().Set("Accept-Encoding", supportedCompression)
+	if codec := acceptedCompression(r.Header.Get("Accept-Encoding")); codec != "" {
+		cw := &compressResponseWriter{ResponseWriter: w, codec: codec}
+		defer cw.Close()
+		next.ServeHTTP(cw, r)
+		return
+	}
+
+	next.ServeHTTP(w, r)
+}
+
+// overloadedRetryAfter is the number of seconds after which clients are told
+// to retry requests rejected because the node is overloaded.
+const overloadedRetryAfter = "5"
+
+// setBackpressureRetryAfter tells clients how many seconds to wait before
+// retrying a request refused with a pilosa.BackpressureError, if err is one.
+func setBackpressureRetryAfter(w http.ResponseWriter, err error) {
+	if delay, ok := pilosa.RetryAfter(err); ok {
+		secs := int((delay + time.Second - 1) / time.Second)
+		w.Header().Set("Retry-After", strconv.Itoa(secs))
+	}
 }
 
 // successResponse is a general success/error struct for http responses.
 type successResponse struct {
 	h       *Handler
-	Success bool   `json:"success"`
-	Error   *Error `json:"error,omitempty"`
+	req     *http.Request // the request responded to, if known
+	Success bool          `json:"success"`
+	Error   *Error        `json:"error,omitempty"`
 }
 
 // check determines success or failure based on the error.
@@ -366,18 +865,51 @@ func (r *successResponse) check(err error) (statusCode int) {
 	default:
 		statusCode = http.StatusInternalServerError
 	}
+	if cause == pilosa.ErrInsufficientDiskSpace {
+		statusCode = http.StatusInsufficientStorage
+	} else if cause == pilosa.ErrQuotaExceeded {
+		statusCode = http.StatusTooManyRequests
+	} else if cause == pilosa.ErrOverloaded {
+		statusCode = http.StatusServiceUnavailable
+	} else if cause == pilosa.ErrBackpressure {
+		statusCode = http.StatusTooManyRequests
+	} else if cause == pilosa.ErrFeatureUnsupported {
+		statusCode = http.StatusNotImplemented
+	} else if cause == pilosa.ErrIndexReadOnly || cause == pilosa.ErrNamespaceForbidden {
+		statusCode = http.StatusForbidden
+	}
 
 	r.Success = false
-	r.Error = &Error{Message: err.Error()}
+	r.Error = responseError(r.req, err)
 
 	return statusCode
 }
 
+// MarshalJSON encodes the response, describing its error by message alone
+// unless the request asks for typed errors.
+func (r successResponse) MarshalJSON() ([]byte, error) {
+	type response successResponse
+	if r.Error == nil || typedErrors(r.req) {
+		return json.Marshal(response(r))
+	}
+	type messageError struct {
+		Message string `json:"message"`
+	}
+	return json.Marshal(struct {
+		Success bool         `json:"success"`
+		Error   messageError `json:"error"`
+	}{Success: r.Success, Error: messageError{Message: r.Error.Message}})
+}
+
 // write sends a response to the http.ResponseWriter based on the success
 // status and the error.
 func (r *successResponse) write(w http.ResponseWriter, err error) {
 	// Apply the error and get the status code.
 	statusCode := r.check(err)
+	if statusCode == http.StatusServiceUnavailable {
+		w.Header().Set("Retry-After", overloadedRetryAfter)
+	}
+	setBackpressureRetryAfter(w, err)
 
 	// Marshal the json response.
 	msg, err := json.Marshal(r)
@@ -409,6 +941,36 @@ func (h *Handler) handleHome(w http.ResponseWriter, _ *http.Request) {
 	http.Error(w, "Welcome. Pilosa is running. Visit https://www.pilosa.com/docs/ for more information.", http.StatusNotFound)
 }
 
+// apiVersionPrefix matches the prefix of paths in a version of the API.
+var apiVersionPrefix = regexp.MustCompile(`^/(v[0-9]+)/`)
+
+// handleNotFound handles requests which don't match a route. Requests for a
+// version of the API which isn't served are told so, so that clients can fall
+// back to an older version.
+func (h *Handler) handleNotFound(w http.ResponseWriter, r *http.Request) {
+	if m := apiVersionPrefix.FindStringSubmatch(r.URL.Path); m != nil && !isAPIVersion(m[1]) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		err := errors.Wrap(pilosa.ErrAPIVersionUnsupported, m[1])
+		if err := json.NewEncoder(w).Encode(newErrorResponse(r, err)); err != nil {
+			h.requestLogger(r).Printf("write not found response error: %s", err)
+		}
+		return
+	}
+	http.NotFound(w, r)
+}
+
+// isAPIVersion returns true if version is a version of the API which is
+// served.
+func isAPIVersion(version string) bool {
+	for _, v := range apiVersions {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
+
 // validHeaderAcceptJSON returns false if one or more Accept
 // headers are present, but none of them are "application/json"
 // (or any matching wildcard). Otherwise returns true.
@@ -431,10 +993,93 @@ func (h *Handler) handleGetSchema(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	q := r.URL.Query()
+	page, err := parseListPage(q)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	fields := true
+	if s := q.Get("fields"); s != "" {
+		if fields, err = strconv.ParseBool(s); err != nil {
+			http.Error(w, "invalid fields argument", http.StatusBadRequest)
+			return
+		}
+	}
+
 	schema := h.api.Schema(r.Context())
-	if err := json.NewEncoder(w).Encode(map[string]interface{}{"indexes": schema}); err != nil { // TODO: use pilosa.Schema instead of map[string]interface{} here?
-		h.logger.Printf("write schema response error: %s", err)
+	start, end, next := page.bounds(len(schema), func(i int) string { return schema[i].Name })
+	schema = schema[start:end]
+	if !fields {
+		for _, idx := range schema {
+			idx.Fields = nil
+		}
+	}
+
+	resp := map[string]interface{}{"indexes": schema} // TODO: use pilosa.Schema instead of map[string]interface{} here?
+	if next != "" {
+		resp["next"] = next
+	}
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.requestLogger(r).Printf("write schema response error: %s", err)
+	}
+}
+
+// handleGetSchemaDiff handles GET /schema/diff requests, which compare this
+// node's schema with that of another node.
+func (h *Handler) handleGetSchemaDiff(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
 	}
+
+	diff, err := h.api.SchemaDiff(r.Context(), r.URL.Query().Get("remote"))
+	if err != nil {
+		resp := successResponse{h: h, req: r}
+		resp.write(w, err)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(diff); err != nil {
+		h.requestLogger(r).Printf("write schema diff response error: %s", err)
+	}
+}
+
+// listPage selects a page of a listing of indexes or fields sorted by name.
+type listPage struct {
+	prefix string // only names with the prefix are listed
+	after  string // only names after this are listed
+	limit  int    // maximum number of names listed, if positive
+}
+
+// parseListPage returns the page given by the prefix, after and limit query
+// arguments.
+func parseListPage(q url.Values) (listPage, error) {
+	page := listPage{prefix: q.Get("prefix"), after: q.Get("after")}
+	if s := q.Get("limit"); s != "" {
+		limit, err := strconv.Atoi(s)
+		if err != nil || limit < 1 {
+			return page, errors.New("limit must be a positive integer")
+		}
+		page.limit = limit
+	}
+	return page, nil
+}
+
+// bounds returns the range [start, end) of the n sorted names which are on
+// the page. If more names follow the page, next is the value of the after
+// argument for the next page.
+func (p listPage) bounds(n int, name func(i int) string) (start, end int, next string) {
+	start = sort.Search(n, func(i int) bool {
+		return name(i) > p.after && name(i) >= p.prefix
+	})
+	end = start
+	for end < n && strings.HasPrefix(name(end), p.prefix) {
+		if p.limit > 0 && end-start == p.limit {
+			return start, end, name(end - 1)
+		}
+		end++
+	}
+	return start, end, ""
 }
 
 func (h *Handler) handlePostSchema(w http.ResponseWriter, r *http.Request) {
@@ -465,70 +1110,172 @@ func (h *Handler) handleGetStatus(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	status := getStatusResponse{
-		State:   h.api.State(),
-		Nodes:   h.api.Hosts(r.Context()),
-		LocalID: h.api.Node().ID,
+		State:         h.api.State(),
+		Nodes:         h.api.Hosts(r.Context()),
+		LocalID:       h.api.Node().ID,
+		ReadOnlyNodes: h.api.ReadOnlyNodes(),
+		SchemaVersion: h.api.SchemaVersion(),
+		Startup:       h.api.StartupProgress(),
 	}
 	if err := json.NewEncoder(w).Encode(status); err != nil {
-		h.logger.Printf("write status response error: %s", err)
+		h.requestLogger(r).Printf("write status response error: %s", err)
 	}
 }
 
-func (h *Handler) handleGetInfo(w http.ResponseWriter, r *http.Request) {
+// handleGetReadyz handles GET /readyz requests. It responds with 200 once the
+// node has finished opening its data, and 503 until then.
+func (h *Handler) handleGetReadyz(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain")
+	if p := h.api.StartupProgress(); !p.Complete {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprintf(w, "starting: %d/%d fragments opened\n", p.Opened, p.Total)
+		return
+	}
+	fmt.Fprintln(w, "ready")
+}
+
+// handleGetClusterStatus handles GET /cluster/status requests.
+func (h *Handler) handleGetClusterStatus(w http.ResponseWriter, r *http.Request) {
 	if !validHeaderAcceptJSON(r.Header) {
 		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
 		return
 	}
-	info := h.api.Info()
-	if err := json.NewEncoder(w).Encode(info); err != nil {
-		h.logger.Printf("write info response error: %s", err)
+	loads, health := h.api.ClusterLoad(), h.api.NodeHealth()
+	status := getClusterStatusResponse{State: h.api.State()}
+	for _, node := range h.api.Hosts(r.Context()) {
+		ns := clusterNodeStatus{Node: node, Health: health[node.ID]}
+		if load, ok := loads[node.ID]; ok {
+			ns.Load = &load
+		}
+		status.Nodes = append(status.Nodes, ns)
+	}
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		h.requestLogger(r).Printf("write cluster status response error: %s", err)
 	}
 }
 
-type getSchemaResponse struct {
-	Indexes []*pilosa.IndexInfo `json:"indexes"`
+// handleGetClusterTopology handles GET /cluster/topology requests.
+func (h *Handler) handleGetClusterTopology(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	topology, err := h.api.ClusterTopology(r.Context(), r.URL.Query().Get("index"))
+	if err != nil {
+		resp := successResponse{h: h, req: r}
+		resp.write(w, err)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(topology); err != nil {
+		h.requestLogger(r).Printf("write cluster topology response error: %s", err)
+	}
 }
 
-type getStatusResponse struct {
-	State   string         `json:"state"`
-	Nodes   []*pilosa.Node `json:"nodes"`
-	LocalID string         `json:"localID"`
+// handlePostClusterRebalance handles POST /cluster/rebalance requests.
+func (h *Handler) handlePostClusterRebalance(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	plan, err := h.api.RebalanceShards(r.Context(), r.URL.Query().Get("dryRun") == "true")
+	if err != nil {
+		resp := successResponse{h: h, req: r}
+		resp.write(w, err)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(plan); err != nil {
+		h.requestLogger(r).Printf("write rebalance response error: %s", err)
+	}
 }
 
-// handlePostQuery handles /query requests.
-func (h *Handler) handlePostQuery(w http.ResponseWriter, r *http.Request) {
-	// Parse incoming request.
-	req, err := h.readQueryRequest(r)
+// handleGetClusterSlices handles GET /cluster/slices requests.
+func (h *Handler) handleGetClusterSlices(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	dist, err := h.api.ShardDistribution(r.Context(), r.URL.Query().Get("index"))
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		e := h.writeQueryResponse(w, r, &pilosa.QueryResponse{Err: err})
-		if e != nil {
-			h.logger.Printf("write query response error: %v (while trying to write another error: %v)", e, err)
-		}
+		resp := successResponse{h: h, req: r}
+		resp.write(w, err)
 		return
 	}
-	// TODO: Remove
-	req.Index = mux.Vars(r)["index"]
+	if err := json.NewEncoder(w).Encode(dist); err != nil {
+		h.requestLogger(r).Printf("write cluster slices response error: %s", err)
+	}
+}
 
-	resp, err := h.api.Query(r.Context(), req)
+type getClusterStatusResponse struct {
+	State string              `json:"state"`
+	Nodes []clusterNodeStatus `json:"nodes"`
+}
+
+// clusterNodeStatus is a node along with its latest reported load and its
+// health according to the receiving node's failure detector, if known.
+type clusterNodeStatus struct {
+	*pilosa.Node
+	Health string           `json:"health,omitempty"`
+	Load   *pilosa.NodeLoad `json:"load,omitempty"`
+}
+
+func (h *Handler) handleGetInfo(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	info := h.api.Info()
+	if err := json.NewEncoder(w).Encode(info); err != nil {
+		h.requestLogger(r).Printf("write info response error: %s", err)
+	}
+}
+
+type getSchemaResponse struct {
+	Indexes []*pilosa.IndexInfo `json:"indexes"`
+}
+
+type getStatusResponse struct {
+	State   string         `json:"state"`
+	Nodes   []*pilosa.Node `json:"nodes"`
+	LocalID string         `json:"localID"`
+
+	// IDs of nodes which have disabled writes because they are low on
+	// disk space.
+	ReadOnlyNodes []string `json:"readOnlyNodes,omitempty"`
+
+	// Version of the last schema change the node has seen.
+	SchemaVersion uint64 `json:"schemaVersion,omitempty"`
+
+	// Progress of opening the node's fragments.
+	Startup pilosa.StartupProgress `json:"startup"`
+}
+
+// handlePostQuery handles /query requests.
+func (h *Handler) handlePostQuery(w http.ResponseWriter, r *http.Request) {
+	// Parse incoming request.
+	req, err := h.readQueryRequest(r)
 	if err != nil {
-		switch errors.Cause(err) {
-		case pilosa.ErrTooManyWrites:
-			w.WriteHeader(http.StatusRequestEntityTooLarge)
-		case pilosa.ErrTranslateStoreReadOnly:
-			u := h.api.PrimaryReplicaNodeURL()
-			u.Path, u.RawQuery = r.URL.Path, r.URL.RawQuery
-			http.Redirect(w, r, u.String(), http.StatusFound)
-			return
-		default:
-			w.WriteHeader(http.StatusBadRequest)
-		}
-		e := h.writeQueryResponse(w, r, &pilosa.QueryResponse{Err: err})
+		w.WriteHeader(http.StatusBadRequest)
+		e := h.writeQueryResponse(w, r, &pilosa.QueryResponse{Err: responseError(r, pilosa.NewBadRequestError(err))})
 		if e != nil {
-			h.logger.Printf("write query response error: %v (while trying to write another error: %v)", e, err)
+			h.requestLogger(r).Printf("write query response error: %v (while trying to write another error: %v)", e, err)
 		}
 		return
 	}
+	// TODO: Remove
+	req.Index = mux.Vars(r)["index"]
+
+	// Asynchronous queries respond with the status of the query's job.
+	if r.URL.Query().Get("async") == "true" {
+		job, err := h.api.QueryAsync(r.Context(), req)
+		h.writeJobResponse(w, job, err)
+		return
+	}
+
+	resp, err := h.api.Query(r.Context(), req)
+	if err != nil {
+		h.writeQueryError(w, r, err)
+		return
+	}
 
 	// Set appropriate status code, if there is an error. It doesn't appear that
 	// resp.Err could ever be set in API.Query, so this code block is probably
@@ -544,25 +1291,112 @@ func (h *Handler) handlePostQuery(w http.ResponseWriter, r *http.Request) {
 
 	// Write response back to client.
 	if err := h.writeQueryResponse(w, r, &resp); err != nil {
-		h.logger.Printf("write query response error: %s", err)
+		h.requestLogger(r).Printf("write query response error: %s", err)
+	}
+}
+
+// writeQueryError writes the error of a query, with a status matching it.
+func (h *Handler) writeQueryError(w http.ResponseWriter, r *http.Request, err error) {
+	switch errors.Cause(err) {
+	case pilosa.ErrTooManyWrites:
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+	case pilosa.ErrInsufficientDiskSpace:
+		w.WriteHeader(http.StatusInsufficientStorage)
+	case pilosa.ErrQuotaExceeded:
+		w.WriteHeader(http.StatusTooManyRequests)
+	case pilosa.ErrOverloaded:
+		w.Header().Set("Retry-After", overloadedRetryAfter)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	case pilosa.ErrIndexReadOnly, pilosa.ErrNamespaceForbidden:
+		w.WriteHeader(http.StatusForbidden)
+	case pilosa.ErrNamedQueryNotFound:
+		w.WriteHeader(http.StatusNotFound)
+	case pilosa.ErrTranslateStoreReadOnly:
+		u := h.api.PrimaryReplicaNodeURL()
+		u.Path, u.RawQuery = r.URL.Path, r.URL.RawQuery
+		http.Redirect(w, r, u.String(), http.StatusFound)
+		return
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+	e := h.writeQueryResponse(w, r, &pilosa.QueryResponse{Err: responseError(r, err)})
+	if e != nil {
+		h.requestLogger(r).Printf("write query response error: %v (while trying to write another error: %v)", e, err)
 	}
 }
 
-// handleGetShardsMax handles GET /internal/shards/max requests.
+// handlePostQueryValidate handles POST /index/{index}/query/validate
+// requests, which check a query against the index's schema without executing
+// it.
+func (h *Handler) handlePostQueryValidate(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+
+	req, err := h.readQueryRequest(r)
+	if err == nil {
+		req.Index = mux.Vars(r)["index"]
+		var diags []pilosa.QueryDiagnostic
+		if diags, err = h.api.ValidateQuery(r.Context(), req); err == nil {
+			resp := validateQueryResponse{Valid: true, Diagnostics: diags}
+			if resp.Diagnostics == nil {
+				resp.Diagnostics = []pilosa.QueryDiagnostic{}
+			}
+			for _, d := range diags {
+				if d.Severity == pilosa.DiagnosticError {
+					resp.Valid = false
+				}
+			}
+			if err := json.NewEncoder(w).Encode(resp); err != nil {
+				h.requestLogger(r).Printf("write query validation response error: %s", err)
+			}
+			return
+		}
+	} else {
+		err = pilosa.NewBadRequestError(err)
+	}
+	resp := successResponse{h: h, req: r}
+	resp.write(w, err)
+}
+
+// validateQueryResponse is the response of a query validation. The query is
+// valid if none of its diagnostics are errors.
+type validateQueryResponse struct {
+	Valid       bool                     `json:"valid"`
+	Diagnostics []pilosa.QueryDiagnostic `json:"diagnostics"`
+}
+
+// handleGetShardsMax handles GET /internal/shards/max requests. With
+// cluster=true, the response includes the maximum shards known to each node,
+// and the overall maximum of each index.
 func (h *Handler) handleGetShardsMax(w http.ResponseWriter, r *http.Request) {
 	if !validHeaderAcceptJSON(r.Header) {
 		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
 		return
 	}
-	if err := json.NewEncoder(w).Encode(getShardsMaxResponse{
-		Standard: h.api.MaxShards(r.Context()),
-	}); err != nil {
-		h.logger.Printf("write shards-max response error: %s", err)
+	var resp getShardsMaxResponse
+	if r.URL.Query().Get("cluster") == "true" {
+		resp.Standard = make(map[string]uint64)
+		resp.Nodes = h.api.MaxShardsByNode(r.Context())
+		for _, shards := range resp.Nodes {
+			for index, max := range shards {
+				if cur, ok := resp.Standard[index]; !ok || max > cur {
+					resp.Standard[index] = max
+				}
+			}
+		}
+	} else {
+		resp.Standard = h.api.MaxShards(r.Context())
+	}
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.requestLogger(r).Printf("write shards-max response error: %s", err)
 	}
 }
 
 type getShardsMaxResponse struct {
-	Standard map[string]uint64 `json:"standard"`
+	Standard map[string]uint64            `json:"standard"`
+	Nodes    map[string]map[string]uint64 `json:"nodes,omitempty"`
 }
 
 // handleGetIndexes handles GET /index request.
@@ -576,11 +1410,19 @@ func (h *Handler) handleGetIndex(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
 		return
 	}
+	page, err := parseListPage(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	indexName := mux.Vars(r)["index"]
 	for _, idx := range h.api.Schema(r.Context()) {
 		if idx.Name == indexName {
-			if err := json.NewEncoder(w).Encode(idx); err != nil {
-				h.logger.Printf("write response error: %s", err)
+			start, end, next := page.bounds(len(idx.Fields), func(i int) string { return idx.Fields[i].Name })
+			idx.Fields = idx.Fields[start:end]
+			if err := json.NewEncoder(w).Encode(getIndexResponse{IndexInfo: idx, Next: next}); err != nil {
+				h.requestLogger(r).Printf("write response error: %s", err)
 			}
 			return
 		}
@@ -588,6 +1430,12 @@ func (h *Handler) handleGetIndex(w http.ResponseWriter, r *http.Request) {
 	http.Error(w, fmt.Sprintf("Index %s Not Found", indexName), http.StatusNotFound)
 }
 
+// getIndexResponse is an index with a page of its fields.
+type getIndexResponse struct {
+	*pilosa.IndexInfo
+	Next string `json:"next,omitempty"`
+}
+
 type postIndexRequest struct {
 	Options pilosa.IndexOptions `json:"options"`
 }
@@ -675,7 +1523,7 @@ func (h *Handler) handleDeleteIndex(w http.ResponseWriter, r *http.Request) {
 
 	indexName := mux.Vars(r)["index"]
 
-	resp := successResponse{h: h}
+	resp := successResponse{h: h, req: r}
 	err := h.api.DeleteIndex(r.Context(), indexName)
 	resp.write(w, err)
 }
@@ -692,7 +1540,7 @@ func (h *Handler) handlePostIndex(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp := successResponse{h: h}
+	resp := successResponse{h: h, req: r}
 
 	// Decode request.
 	req := postIndexRequest{
@@ -740,7 +1588,7 @@ func (h *Handler) handlePostIndexAttrDiff(w http.ResponseWriter, r *http.Request
 	if err := json.NewEncoder(w).Encode(postIndexAttrDiffResponse{
 		Attrs: attrs,
 	}); err != nil {
-		h.logger.Printf("response encoding error: %s", err)
+		h.requestLogger(r).Printf("response encoding error: %s", err)
 	}
 }
 
@@ -771,7 +1619,7 @@ func (h *Handler) handlePostField(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp := successResponse{h: h}
+	resp := successResponse{h: h, req: r}
 
 	// Decode request.
 	var req postFieldRequest
@@ -783,6 +1631,22 @@ func (h *Handler) handlePostField(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Set fields created without cache options use the defaults from the
+	// cluster settings.
+	if req.Options.Type == "" || req.Options.Type == pilosa.FieldTypeSet {
+		settings, err := h.api.Settings(r.Context())
+		if err != nil {
+			resp.write(w, err)
+			return
+		}
+		if req.Options.CacheType == nil {
+			req.Options.CacheType = &settings.DefaultCacheType
+		}
+		if req.Options.CacheSize == nil {
+			req.Options.CacheSize = &settings.DefaultCacheSize
+		}
+	}
+
 	// Validate field options.
 	if err := req.Options.validate(); err != nil {
 		resp.write(w, err)
@@ -806,6 +1670,25 @@ func (h *Handler) handlePostField(w http.ResponseWriter, r *http.Request) {
 		fos = append(fos, pilosa.OptFieldTypeInt(*req.Options.Min, *req.Options.Max))
 	case pilosa.FieldTypeTime:
 		fos = append(fos, pilosa.OptFieldTypeTime(*req.Options.TimeQuantum, req.Options.NoStandardView))
+		if req.Options.CacheType != nil {
+			var cacheSize uint32
+			if req.Options.CacheSize != nil {
+				cacheSize = *req.Options.CacheSize
+			}
+			fos = append(fos, pilosa.OptFieldTimeCache(*req.Options.CacheType, cacheSize))
+		}
+		var viewCacheType, viewStorage string
+		var viewCacheSize uint32
+		if req.Options.TimeViewCacheType != nil {
+			viewCacheType = *req.Options.TimeViewCacheType
+		}
+		if req.Options.TimeViewCacheSize != nil {
+			viewCacheSize = *req.Options.TimeViewCacheSize
+		}
+		if req.Options.TimeViewStorage != nil {
+			viewStorage = *req.Options.TimeViewStorage
+		}
+		fos = append(fos, pilosa.OptFieldTimeViews(viewCacheType, viewCacheSize, viewStorage))
 	case pilosa.FieldTypeMutex:
 		fos = append(fos, pilosa.OptFieldTypeMutex(*req.Options.CacheType, *req.Options.CacheSize))
 	case pilosa.FieldTypeBool:
@@ -816,6 +1699,12 @@ func (h *Handler) handlePostField(w http.ResponseWriter, r *http.Request) {
 			fos = append(fos, pilosa.OptFieldKeys())
 		}
 	}
+	if req.Options.Inverse != nil && *req.Options.Inverse {
+		fos = append(fos, pilosa.OptFieldInverse())
+	}
+	if req.Options.IdleTTL != nil {
+		fos = append(fos, pilosa.OptFieldIdleTTL(time.Duration(*req.Options.IdleTTL)))
+	}
 
 	_, err = h.api.CreateField(r.Context(), indexName, fieldName, fos...)
 	if _, ok := err.(pilosa.BadRequestError); ok {
@@ -840,6 +1729,14 @@ type fieldOptions struct {
 	TimeQuantum    *pilosa.TimeQuantum `json:"timeQuantum,omitempty"`
 	Keys           *bool               `json:"keys,omitempty"`
 	NoStandardView bool                `json:"noStandardView,omitempty"`
+
+	TimeViewCacheType *string `json:"timeViewCacheType,omitempty"`
+	TimeViewCacheSize *uint32 `json:"timeViewCacheSize,omitempty"`
+	TimeViewStorage   *string `json:"timeViewStorage,omitempty"`
+
+	Inverse *bool `json:"inverse,omitempty"`
+
+	IdleTTL *toml.Duration `json:"idleTTL,omitempty"`
 }
 
 func (o *fieldOptions) validate() error {
@@ -847,6 +1744,24 @@ func (o *fieldOptions) validate() error {
 	defaultCacheType := pilosa.DefaultCacheType
 	defaultCacheSize := uint32(pilosa.DefaultCacheSize)
 
+	if o.IdleTTL != nil && *o.IdleTTL < 0 {
+		return pilosa.NewBadRequestError(errors.New("idleTTL must not be negative"))
+	}
+
+	if o.Type != pilosa.FieldTypeTime {
+		typ := o.Type
+		if typ == "" {
+			typ = pilosa.FieldTypeSet
+		}
+		if o.TimeViewCacheType != nil {
+			return pilosa.NewBadRequestError(errors.Errorf("timeViewCacheType does not apply to field type %s", typ))
+		} else if o.TimeViewCacheSize != nil {
+			return pilosa.NewBadRequestError(errors.Errorf("timeViewCacheSize does not apply to field type %s", typ))
+		} else if o.TimeViewStorage != nil {
+			return pilosa.NewBadRequestError(errors.Errorf("timeViewStorage does not apply to field type %s", typ))
+		}
+	}
+
 	switch o.Type {
 	case pilosa.FieldTypeSet, "":
 		// Because FieldTypeSet is the default, its arguments are
@@ -875,18 +1790,20 @@ func (o *fieldOptions) validate() error {
 			return pilosa.NewBadRequestError(errors.New("cacheSize does not apply to field type int"))
 		} else if o.TimeQuantum != nil {
 			return pilosa.NewBadRequestError(errors.New("timeQuantum does not apply to field type int"))
+		} else if o.Inverse != nil {
+			return pilosa.NewBadRequestError(errors.New("inverse does not apply to field type int"))
 		}
 	case pilosa.FieldTypeTime:
-		if o.CacheType != nil {
-			return pilosa.NewBadRequestError(errors.New("cacheType does not apply to field type time"))
-		} else if o.CacheSize != nil {
-			return pilosa.NewBadRequestError(errors.New("cacheSize does not apply to field type time"))
+		if o.CacheSize != nil && o.CacheType == nil {
+			return pilosa.NewBadRequestError(errors.New("cacheSize requires cacheType for field type time"))
 		} else if o.Min != nil {
 			return pilosa.NewBadRequestError(errors.New("min does not apply to field type time"))
 		} else if o.Max != nil {
 			return pilosa.NewBadRequestError(errors.New("max does not apply to field type time"))
 		} else if o.TimeQuantum == nil {
 			return pilosa.NewBadRequestError(errors.New("timeQuantum is required for field type time"))
+		} else if o.Inverse != nil && *o.Inverse && o.NoStandardView {
+			return pilosa.NewBadRequestError(errors.New("inverse requires the standard view"))
 		}
 	case pilosa.FieldTypeMutex:
 		if o.CacheType == nil {
@@ -915,6 +1832,8 @@ func (o *fieldOptions) validate() error {
 			return pilosa.NewBadRequestError(errors.New("timeQuantum does not apply to field type bool"))
 		} else if o.Keys != nil {
 			return pilosa.NewBadRequestError(errors.New("keys does not apply to field type bool"))
+		} else if o.Inverse != nil {
+			return pilosa.NewBadRequestError(errors.New("inverse does not apply to field type bool"))
 		}
 	default:
 		return errors.Errorf("invalid field type: %s", o.Type)
@@ -929,11 +1848,401 @@ func (h *Handler) handleDeleteField(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	indexName := mux.Vars(r)["index"]
-	fieldName := mux.Vars(r)["field"]
-
-	resp := successResponse{h: h}
-	err := h.api.DeleteField(r.Context(), indexName, fieldName)
+	indexName := mux.Vars(r)["index"]
+	fieldName := mux.Vars(r)["field"]
+
+	resp := successResponse{h: h, req: r}
+	err := h.api.DeleteField(r.Context(), indexName, fieldName)
+	resp.write(w, err)
+}
+
+// handleDeleteRows handles DELETE /index/{index}/field/{field}/row/{row}
+// requests, which clear the row, or the rows up to the optional "to" row.
+func (h *Handler) handleDeleteRows(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+
+	vars := mux.Vars(r)
+	start, err := strconv.ParseUint(vars["row"], 10, 64)
+	if err != nil {
+		http.Error(w, "row should be an unsigned integer", http.StatusBadRequest)
+		return
+	}
+	end := start
+	if s := r.URL.Query().Get("to"); s != "" {
+		if end, err = strconv.ParseUint(s, 10, 64); err != nil {
+			http.Error(w, "to should be an unsigned integer", http.StatusBadRequest)
+			return
+		}
+	}
+
+	resp := successResponse{h: h, req: r}
+	_, err = h.api.DeleteRows(r.Context(), vars["index"], vars["field"], start, end)
+	resp.write(w, err)
+}
+
+// handlePostFieldTruncate handles POST /index/{index}/field/{field}/truncate
+// requests, which remove all of the field's data.
+func (h *Handler) handlePostFieldTruncate(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+
+	vars := mux.Vars(r)
+	resp := successResponse{h: h, req: r}
+	err := h.api.TruncateField(r.Context(), vars["index"], vars["field"])
+	resp.write(w, err)
+}
+
+// handleGetFieldHistogram handles GET /index/{index}/field/{field}/histogram
+// requests, which return the distribution of the field's row cardinalities.
+func (h *Handler) handleGetFieldHistogram(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+
+	var opt pilosa.FieldHistogramOptions
+	var err error
+	q := r.URL.Query()
+	if s := q.Get("top"); s != "" {
+		if opt.Top, err = strconv.Atoi(s); err != nil || opt.Top <= 0 {
+			http.Error(w, "top should be a positive integer", http.StatusBadRequest)
+			return
+		}
+	}
+	if s := q.Get("sample"); s != "" {
+		if opt.Sample, err = strconv.Atoi(s); err != nil || opt.Sample <= 0 {
+			http.Error(w, "sample should be a positive integer", http.StatusBadRequest)
+			return
+		}
+	}
+
+	vars := mux.Vars(r)
+	hist, err := h.api.FieldHistogram(r.Context(), vars["index"], vars["field"], opt)
+	if err != nil {
+		resp := successResponse{h: h, req: r}
+		resp.write(w, err)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(hist); err != nil {
+		h.requestLogger(r).Printf("write field histogram response error: %s", err)
+	}
+}
+
+// handlePostIndexReadOnly handles POST /index/{index}/read-only requests,
+// which reject writes to the index.
+func (h *Handler) handlePostIndexReadOnly(w http.ResponseWriter, r *http.Request) {
+	h.setIndexReadOnly(w, r, true)
+}
+
+// handleDeleteIndexReadOnly handles DELETE /index/{index}/read-only requests,
+// which accept writes to the index again.
+func (h *Handler) handleDeleteIndexReadOnly(w http.ResponseWriter, r *http.Request) {
+	h.setIndexReadOnly(w, r, false)
+}
+
+func (h *Handler) setIndexReadOnly(w http.ResponseWriter, r *http.Request, readOnly bool) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+
+	resp := successResponse{h: h, req: r}
+	err := h.api.SetIndexReadOnly(r.Context(), mux.Vars(r)["index"], readOnly)
+	resp.write(w, err)
+}
+
+// handleGetMaterializedViews handles GET /index/{index}/materialized requests.
+func (h *Handler) handleGetMaterializedViews(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+
+	views, err := h.api.MaterializedViews(r.Context(), mux.Vars(r)["index"])
+	if err != nil {
+		resp := successResponse{h: h, req: r}
+		resp.write(w, err)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(getMaterializedViewsResponse{Views: views}); err != nil {
+		h.requestLogger(r).Printf("write materialized views response error: %s", err)
+	}
+}
+
+type getMaterializedViewsResponse struct {
+	Views []pilosa.MaterializedView `json:"views"`
+}
+
+// handlePostMaterializedView handles POST /index/{index}/materialized/{name}
+// requests. The request body is the PQL query defining the view.
+func (h *Handler) handlePostMaterializedView(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+
+	query, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp := successResponse{h: h, req: r}
+	vars := mux.Vars(r)
+	err = h.api.CreateMaterializedView(r.Context(), vars["index"], vars["name"], string(query))
+	resp.write(w, err)
+}
+
+// handleDeleteMaterializedView handles DELETE /index/{index}/materialized/{name}
+// requests.
+func (h *Handler) handleDeleteMaterializedView(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+
+	resp := successResponse{h: h, req: r}
+	vars := mux.Vars(r)
+	err := h.api.DeleteMaterializedView(r.Context(), vars["index"], vars["name"])
+	resp.write(w, err)
+}
+
+// handleGetRewriteRules handles GET /index/{index}/rewrite requests.
+func (h *Handler) handleGetRewriteRules(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+
+	rules, err := h.api.RewriteRules(r.Context(), mux.Vars(r)["index"])
+	if err != nil {
+		resp := successResponse{h: h, req: r}
+		resp.write(w, err)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(getRewriteRulesResponse{Rules: rules}); err != nil {
+		h.requestLogger(r).Printf("write rewrite rules response error: %s", err)
+	}
+}
+
+type getRewriteRulesResponse struct {
+	Rules []pilosa.RewriteRule `json:"rules"`
+}
+
+type postRewriteRuleRequest struct {
+	Match   string `json:"match"`
+	Replace string `json:"replace"`
+}
+
+// handlePostRewriteRule handles POST /index/{index}/rewrite/{name} requests.
+func (h *Handler) handlePostRewriteRule(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+
+	resp := successResponse{h: h, req: r}
+	var req postRewriteRuleRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		resp.write(w, pilosa.NewBadRequestError(err))
+		return
+	}
+
+	vars := mux.Vars(r)
+	rule := pilosa.RewriteRule{Name: vars["name"], Match: req.Match, Replace: req.Replace}
+	err := h.api.CreateRewriteRule(r.Context(), vars["index"], rule)
+	resp.write(w, err)
+}
+
+// handleDeleteRewriteRule handles DELETE /index/{index}/rewrite/{name}
+// requests.
+func (h *Handler) handleDeleteRewriteRule(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+
+	resp := successResponse{h: h, req: r}
+	vars := mux.Vars(r)
+	err := h.api.DeleteRewriteRule(r.Context(), vars["index"], vars["name"])
+	resp.write(w, err)
+}
+
+// handleGetNamedQueries handles GET /query/named requests.
+func (h *Handler) handleGetNamedQueries(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+
+	queries, err := h.api.NamedQueries(r.Context())
+	if err != nil {
+		resp := successResponse{h: h, req: r}
+		resp.write(w, err)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(getNamedQueriesResponse{Queries: queries}); err != nil {
+		h.requestLogger(r).Printf("write named queries response error: %s", err)
+	}
+}
+
+type getNamedQueriesResponse struct {
+	Queries []pilosa.NamedQuery `json:"queries"`
+}
+
+type postNamedQueryRequest struct {
+	Index string `json:"index"`
+	Query string `json:"query"`
+}
+
+// handlePostNamedQuery handles POST /query/named/{name} requests.
+func (h *Handler) handlePostNamedQuery(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+
+	resp := successResponse{h: h, req: r}
+	var req postNamedQueryRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		resp.write(w, pilosa.NewBadRequestError(err))
+		return
+	}
+
+	q := pilosa.NamedQuery{Name: mux.Vars(r)["name"], Index: req.Index, Query: req.Query}
+	err := h.api.CreateNamedQuery(r.Context(), q)
+	resp.write(w, err)
+}
+
+// handleDeleteNamedQuery handles DELETE /query/named/{name} requests.
+func (h *Handler) handleDeleteNamedQuery(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+
+	resp := successResponse{h: h, req: r}
+	err := h.api.DeleteNamedQuery(r.Context(), mux.Vars(r)["name"])
+	resp.write(w, err)
+}
+
+// handleGetNamedQuery handles GET /query/named/{name} requests, which execute
+// the named query. The URL query gives its parameters.
+func (h *Handler) handleGetNamedQuery(w http.ResponseWriter, r *http.Request) {
+	params := make(map[string]string)
+	for k, v := range r.URL.Query() {
+		if len(v) != 1 {
+			h.writeQueryError(w, r, pilosa.NewBadRequestError(errors.Errorf("parameter %s given %d times", k, len(v))))
+			return
+		}
+		params[k] = v[0]
+	}
+
+	resp, err := h.api.QueryNamed(r.Context(), mux.Vars(r)["name"], params)
+	if err != nil {
+		h.writeQueryError(w, r, err)
+		return
+	}
+	if err := h.writeQueryResponse(w, r, &resp); err != nil {
+		h.requestLogger(r).Printf("write query response error: %s", err)
+	}
+}
+
+// handleGetSchedules handles GET /schedule requests.
+func (h *Handler) handleGetSchedules(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+
+	schedules, err := h.api.Schedules(r.Context())
+	if err != nil {
+		resp := successResponse{h: h, req: r}
+		resp.write(w, err)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(getSchedulesResponse{Schedules: schedules}); err != nil {
+		h.requestLogger(r).Printf("write schedules response error: %s", err)
+	}
+}
+
+type getSchedulesResponse struct {
+	Schedules []pilosa.Schedule `json:"schedules"`
+}
+
+// handleGetSchedule handles GET /schedule/{name} requests, which return the
+// schedule and its recent runs.
+func (h *Handler) handleGetSchedule(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+
+	status, err := h.api.ScheduleStatus(r.Context(), mux.Vars(r)["name"])
+	if err != nil {
+		resp := successResponse{h: h, req: r}
+		resp.write(w, err)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		h.requestLogger(r).Printf("write schedule response error: %s", err)
+	}
+}
+
+type postScheduleRequest struct {
+	Query    string              `json:"query"`
+	Params   map[string]string   `json:"params"`
+	Cron     string              `json:"cron"`
+	Sink     pilosa.ScheduleSink `json:"sink"`
+	AlertURL string              `json:"alertURL"`
+}
+
+// handlePostSchedule handles POST /schedule/{name} requests.
+func (h *Handler) handlePostSchedule(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+
+	resp := successResponse{h: h, req: r}
+	var req postScheduleRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		resp.write(w, pilosa.NewBadRequestError(err))
+		return
+	}
+
+	sched := pilosa.Schedule{
+		Name:     mux.Vars(r)["name"],
+		Query:    req.Query,
+		Params:   req.Params,
+		Cron:     req.Cron,
+		Sink:     req.Sink,
+		AlertURL: req.AlertURL,
+	}
+	err := h.api.CreateSchedule(r.Context(), sched)
+	resp.write(w, err)
+}
+
+// handleDeleteSchedule handles DELETE /schedule/{name} requests.
+func (h *Handler) handleDeleteSchedule(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+
+	resp := successResponse{h: h, req: r}
+	err := h.api.DeleteSchedule(r.Context(), mux.Vars(r)["name"])
 	resp.write(w, err)
 }
 
@@ -948,7 +2257,7 @@ func (h *Handler) handleDeleteRemoteAvailableShard(w http.ResponseWriter, r *htt
 	fieldName := mux.Vars(r)["field"]
 	shardID, _ := strconv.ParseUint(mux.Vars(r)["shardID"], 10, 64)
 
-	resp := successResponse{h: h}
+	resp := successResponse{h: h, req: r}
 	err := h.api.DeleteAvailableShard(r.Context(), indexName, fieldName, shardID)
 	resp.write(w, err)
 }
@@ -984,7 +2293,7 @@ func (h *Handler) handlePostFieldAttrDiff(w http.ResponseWriter, r *http.Request
 	if err := json.NewEncoder(w).Encode(postFieldAttrDiffResponse{
 		Attrs: attrs,
 	}); err != nil {
-		h.logger.Printf("response encoding error: %s", err)
+		h.requestLogger(r).Printf("response encoding error: %s", err)
 	}
 }
 
@@ -1045,17 +2354,30 @@ func (h *Handler) readURLQueryRequest(r *http.Request) (*pilosa.QueryRequest, er
 		ColumnAttrs:     q.Get("columnAttrs") == "true",
 		ExcludeRowAttrs: q.Get("excludeRowAttrs") == "true",
 		ExcludeColumns:  q.Get("excludeColumns") == "true",
+		Priority:        q.Get("priority"),
+		Profile:         q.Get("profile") == "true",
+		ContinueOnError: q.Get("continueOnError") == "true",
+		Affinity:        q.Get("affinity"),
 	}, nil
 }
 
 // writeQueryResponse writes the response from the executor to w.
 func (h *Handler) writeQueryResponse(w http.ResponseWriter, r *http.Request, resp *pilosa.QueryResponse) error {
+	if h.streamQueryResponse(resp) {
+		if acceptsProtobufStream(r) {
+			w.Header().Set("Content-Type", contentTypeProtobufStream)
+			return h.writeProtobufStreamQueryResponse(w, resp)
+		} else if validHeaderAcceptJSON(r.Header) {
+			w.Header().Set("Content-Type", "application/json")
+			return h.writeJSONStreamQueryResponse(w, resp)
+		}
+	}
 	if !validHeaderAcceptJSON(r.Header) {
 		w.Header().Set("Content-Type", "application/protobuf")
 		return h.writeProtobufQueryResponse(w, resp)
 	}
 	w.Header().Set("Content-Type", "application/json")
-	return h.writeJSONQueryResponse(w, resp)
+	return h.writeJSONQueryResponse(w, r, resp)
 }
 
 // writeProtobufQueryResponse writes the response from the executor to w as protobuf.
@@ -1069,17 +2391,36 @@ func (h *Handler) writeProtobufQueryResponse(w io.Writer, resp *pilosa.QueryResp
 }
 
 // writeJSONQueryResponse writes the response from the executor to w as JSON.
-func (h *Handler) writeJSONQueryResponse(w io.Writer, resp *pilosa.QueryResponse) error {
+func (h *Handler) writeJSONQueryResponse(w io.Writer, r *http.Request, resp *pilosa.QueryResponse) error {
+	if resp.Err != nil && !typedErrors(r) {
+		return json.NewEncoder(w).Encode(errorResponse{Error: resp.Err.Error()})
+	}
 	return json.NewEncoder(w).Encode(resp)
 }
 
+// Bulk loads send a steady stream of large import requests, so the request
+// bodies and the structures they are decoded into are pooled. The serializer
+// reuses the capacity of a pooled request's slices.
+var (
+	importBodyPool         = sync.Pool{New: func() interface{} { return &bytes.Buffer{} }}
+	importRequestPool      = sync.Pool{New: func() interface{} { return &pilosa.ImportRequest{} }}
+	importValueRequestPool = sync.Pool{New: func() interface{} { return &pilosa.ImportValueRequest{} }}
+)
+
 // handlePostImport handles /import requests.
 func (h *Handler) handlePostImport(w http.ResponseWriter, r *http.Request) {
+	// Asynchronous imports run as a job, and respond with the job's status.
+	q := r.URL.Query()
+	async := q.Get("async") == "true"
+
 	// Verify that request is only communicating over protobufs.
 	if r.Header.Get("Content-Type") != "application/x-protobuf" {
 		http.Error(w, "Unsupported media type", http.StatusUnsupportedMediaType)
 		return
-	} else if r.Header.Get("Accept") != "application/x-protobuf" {
+	} else if async && !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	} else if !async && r.Header.Get("Accept") != "application/x-protobuf" {
 		http.Error(w, "Not acceptable", http.StatusNotAcceptable)
 		return
 	}
@@ -1087,13 +2428,18 @@ func (h *Handler) handlePostImport(w http.ResponseWriter, r *http.Request) {
 	fieldName := mux.Vars(r)["field"]
 
 	// If the clear flag is true, treat the import as clear bits.
-	q := r.URL.Query()
 	doClear := q.Get("clear") == "true"
 	doIgnoreKeyCheck := q.Get("ignoreKeyCheck") == "true"
 
 	opts := []pilosa.ImportOption{
 		pilosa.OptImportOptionsClear(doClear),
 		pilosa.OptImportOptionsIgnoreKeyCheck(doIgnoreKeyCheck),
+		pilosa.OptImportOptionsSkipStandardView(q.Get("skipStandardView") == "true"),
+	}
+
+	// Imports may write only some of the time views of a time field.
+	if tq := q.Get("timeQuantum"); tq != "" {
+		opts = append(opts, pilosa.OptImportOptionsTimeQuantum(pilosa.TimeQuantum(tq)))
 	}
 
 	// Get index and field type to determine how to handle the
@@ -1112,17 +2458,29 @@ func (h *Handler) handlePostImport(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Read entire body.
-	body, err := ioutil.ReadAll(r.Body)
-	if err != nil {
+	bodyBuf := importBodyPool.Get().(*bytes.Buffer)
+	defer importBodyPool.Put(bodyBuf)
+	bodyBuf.Reset()
+	if r.ContentLength > 0 {
+		bodyBuf.Grow(int(r.ContentLength))
+	}
+	if _, err := bodyBuf.ReadFrom(r.Body); err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	body := bodyBuf.Bytes()
+
+	if async {
+		h.importJob(w, r, field, body, opts)
+		return
+	}
 
 	// Unmarshal request based on field type.
 	if field.Type() == pilosa.FieldTypeInt {
 		// Field type: Int
 		// Marshal into request object.
-		req := &pilosa.ImportValueRequest{}
+		req := importValueRequestPool.Get().(*pilosa.ImportValueRequest)
+		defer importValueRequestPool.Put(req)
 		if err := h.api.Serializer.Unmarshal(body, req); err != nil {
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
@@ -1132,6 +2490,18 @@ func (h *Handler) handlePostImport(w http.ResponseWriter, r *http.Request) {
 			switch errors.Cause(err) {
 			case pilosa.ErrClusterDoesNotOwnShard:
 				http.Error(w, err.Error(), http.StatusPreconditionFailed)
+			case pilosa.ErrInsufficientDiskSpace:
+				http.Error(w, err.Error(), http.StatusInsufficientStorage)
+			case pilosa.ErrQuotaExceeded:
+				http.Error(w, err.Error(), http.StatusTooManyRequests)
+			case pilosa.ErrOverloaded:
+				w.Header().Set("Retry-After", overloadedRetryAfter)
+				http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			case pilosa.ErrBackpressure:
+				setBackpressureRetryAfter(w, err)
+				http.Error(w, err.Error(), http.StatusTooManyRequests)
+			case pilosa.ErrIndexReadOnly:
+				http.Error(w, err.Error(), http.StatusForbidden)
 			default:
 				http.Error(w, err.Error(), http.StatusInternalServerError)
 			}
@@ -1140,7 +2510,8 @@ func (h *Handler) handlePostImport(w http.ResponseWriter, r *http.Request) {
 	} else {
 		// Field type: Set, Time
 		// Marshal into request object.
-		req := &pilosa.ImportRequest{}
+		req := importRequestPool.Get().(*pilosa.ImportRequest)
+		defer importRequestPool.Put(req)
 		if err := h.api.Serializer.Unmarshal(body, req); err != nil {
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
@@ -1150,6 +2521,18 @@ func (h *Handler) handlePostImport(w http.ResponseWriter, r *http.Request) {
 			switch errors.Cause(err) {
 			case pilosa.ErrClusterDoesNotOwnShard:
 				http.Error(w, err.Error(), http.StatusPreconditionFailed)
+			case pilosa.ErrInsufficientDiskSpace:
+				http.Error(w, err.Error(), http.StatusInsufficientStorage)
+			case pilosa.ErrQuotaExceeded:
+				http.Error(w, err.Error(), http.StatusTooManyRequests)
+			case pilosa.ErrOverloaded:
+				w.Header().Set("Retry-After", overloadedRetryAfter)
+				http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			case pilosa.ErrBackpressure:
+				setBackpressureRetryAfter(w, err)
+				http.Error(w, err.Error(), http.StatusTooManyRequests)
+			case pilosa.ErrIndexReadOnly:
+				http.Error(w, err.Error(), http.StatusForbidden)
 			default:
 				http.Error(w, err.Error(), http.StatusInternalServerError)
 			}
@@ -1167,8 +2550,31 @@ func (h *Handler) handlePostImport(w http.ResponseWriter, r *http.Request) {
 	// Write response.
 	_, err = w.Write(buf)
 	if err != nil {
-		h.logger.Printf("writing import response: %v", err)
+		h.requestLogger(r).Printf("writing import response: %v", err)
+	}
+}
+
+// importJob starts a job importing body, and writes the job's status. The
+// request is not pooled since the job outlives the handler.
+func (h *Handler) importJob(w http.ResponseWriter, r *http.Request, field *pilosa.Field, body []byte, opts []pilosa.ImportOption) {
+	var job pilosa.JobStatus
+	var err error
+	if field.Type() == pilosa.FieldTypeInt {
+		req := &pilosa.ImportValueRequest{}
+		if err := h.api.Serializer.Unmarshal(body, req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		job, err = h.api.ImportValueJob(r.Context(), req, int64(len(body)), opts...)
+	} else {
+		req := &pilosa.ImportRequest{}
+		if err := h.api.Serializer.Unmarshal(body, req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		job, err = h.api.ImportJob(r.Context(), req, int64(len(body)), opts...)
 	}
+	h.writeJobResponse(w, job, err)
 }
 
 // handleGetExport handles /export requests.
@@ -1205,6 +2611,41 @@ func (h *Handler) handleGetExportCSV(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// handleGetExportAttrs handles GET /index/{index}/attrs/export requests.
+func (h *Handler) handleGetExportAttrs(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == pilosa.AttrExportCSV {
+		w.Header().Set("Content-Type", "text/csv")
+	} else {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+	}
+
+	ew := &exportWriter{ResponseWriter: w}
+	err := h.api.ExportAttrs(r.Context(), mux.Vars(r)["index"], format, ew)
+	if err == nil {
+		return
+	} else if ew.started {
+		// The status has been sent, so the client sees a truncated export.
+		h.requestLogger(r).Printf("attribute export error: %s", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	resp := successResponse{h: h, req: r}
+	resp.write(w, err)
+}
+
+// exportWriter records whether an export has begun writing its response,
+// after which errors can no longer be reported by status code.
+type exportWriter struct {
+	http.ResponseWriter
+	started bool
+}
+
+func (w *exportWriter) Write(p []byte) (int, error) {
+	w.started = true
+	return w.ResponseWriter.Write(p)
+}
+
 // handleGetFragmentNodes handles /internal/fragment/nodes requests.
 func (h *Handler) handleGetFragmentNodes(w http.ResponseWriter, r *http.Request) {
 	if !validHeaderAcceptJSON(r.Header) {
@@ -1230,7 +2671,7 @@ func (h *Handler) handleGetFragmentNodes(w http.ResponseWriter, r *http.Request)
 
 	// Write to response.
 	if err := json.NewEncoder(w).Encode(nodes); err != nil {
-		h.logger.Printf("json write error: %s", err)
+		h.requestLogger(r).Printf("json write error: %s", err)
 	}
 }
 
@@ -1246,7 +2687,7 @@ func (h *Handler) handleGetNodes(w http.ResponseWriter, r *http.Request) {
 
 	// Write to response.
 	if err := json.NewEncoder(w).Encode(nodes); err != nil {
-		h.logger.Printf("json write error: %s", err)
+		h.requestLogger(r).Printf("json write error: %s", err)
 	}
 }
 
@@ -1269,7 +2710,7 @@ func (h *Handler) handleGetFragmentBlockData(w http.ResponseWriter, r *http.Requ
 	w.Header().Set("Content-Length", strconv.Itoa(len(buf)))
 	_, err = w.Write(buf)
 	if err != nil {
-		h.logger.Printf("writing fragment/block/data response: %v", err)
+		h.requestLogger(r).Printf("writing fragment/block/data response: %v", err)
 	}
 }
 
@@ -1301,7 +2742,7 @@ func (h *Handler) handleGetFragmentBlocks(w http.ResponseWriter, r *http.Request
 	if err := json.NewEncoder(w).Encode(getFragmentBlocksResponse{
 		Blocks: blocks,
 	}); err != nil {
-		h.logger.Printf("block response encoding error: %s", err)
+		h.requestLogger(r).Printf("block response encoding error: %s", err)
 	}
 }
 
@@ -1326,7 +2767,7 @@ func (h *Handler) handleGetFragmentData(w http.ResponseWriter, r *http.Request)
 	}
 	// Stream fragment to response body.
 	if _, err := f.WriteTo(w); err != nil {
-		h.logger.Printf("error streaming fragment data: %s", err)
+		h.requestLogger(r).Printf("error streaming fragment data: %s", err)
 	}
 }
 
@@ -1336,16 +2777,20 @@ func (h *Handler) handleGetVersion(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
 		return
 	}
-	err := json.NewEncoder(w).Encode(struct {
-		Version string `json:"version"`
-	}{
-		Version: h.api.Version(),
+	err := json.NewEncoder(w).Encode(getVersionResponse{
+		Version:     h.api.Version(),
+		APIVersions: apiVersions,
 	})
 	if err != nil {
-		h.logger.Printf("write version response error: %s", err)
+		h.requestLogger(r).Printf("write version response error: %s", err)
 	}
 }
 
+type getVersionResponse struct {
+	Version     string   `json:"version"`
+	APIVersions []string `json:"apiVersions"`
+}
+
 // QueryResult types.
 const (
 	QueryResultTypeRow uint32 = iota
@@ -1399,7 +2844,7 @@ func (h *Handler) handlePostClusterResizeSetCoordinator(w http.ResponseWriter, r
 		Old: oldNode,
 		New: newNode,
 	}); err != nil {
-		h.logger.Printf("response encoding error: %s", err)
+		h.requestLogger(r).Printf("response encoding error: %s", err)
 	}
 }
 
@@ -1440,7 +2885,7 @@ func (h *Handler) handlePostClusterResizeRemoveNode(w http.ResponseWriter, r *ht
 	if err := json.NewEncoder(w).Encode(removeNodeResponse{
 		Remove: removeNode,
 	}); err != nil {
-		h.logger.Printf("response encoding error: %s", err)
+		h.requestLogger(r).Printf("response encoding error: %s", err)
 	}
 }
 
@@ -1476,7 +2921,7 @@ func (h *Handler) handlePostClusterResizeAbort(w http.ResponseWriter, r *http.Re
 	if err := json.NewEncoder(w).Encode(clusterResizeAbortResponse{
 		Info: msg,
 	}); err != nil {
-		h.logger.Printf("response encoding error: %s", err)
+		h.requestLogger(r).Printf("response encoding error: %s", err)
 	}
 }
 
@@ -1506,13 +2951,19 @@ func (h *Handler) handlePostClusterMessage(w http.ResponseWriter, r *http.Reques
 	}
 
 	err := h.api.ClusterMessage(r.Context(), r.Body)
-	if err != nil {
+	if _, ok := errors.Cause(err).(pilosa.ConflictError); ok {
+		// Conflicts are reported to the sender, which may have asked the
+		// coordinator for a schema change another node made first.
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	} else if err != nil {
 		// TODO this was the previous behavior, but perhaps not everything is a bad request
 		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
 
 	if err := json.NewEncoder(w).Encode(defaultClusterMessageResponse{}); err != nil {
-		h.logger.Printf("response encoding error: %s", err)
+		h.requestLogger(r).Printf("response encoding error: %s", err)
 	}
 }
 
@@ -1549,7 +3000,7 @@ func (h *Handler) handlePostTranslateData(w http.ResponseWriter, r *http.Request
 		if err := rd.ReadEntry(&entry); err == io.EOF {
 			return
 		} else if err != nil {
-			h.logger.Printf("http: translate store read error: %s", err)
+			h.requestLogger(r).Printf("http: translate store read error: %s", err)
 			return
 		}
 
@@ -1561,6 +3012,347 @@ func (h *Handler) handlePostTranslateData(w http.ResponseWriter, r *http.Request
 	}
 }
 
+// handleGetUDFs handles GET /udf requests.
+func (h *Handler) handleGetUDFs(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(getUDFsResponse{
+		UDFs: h.api.UDFs(r.Context()),
+	}); err != nil {
+		h.requestLogger(r).Printf("write udfs response error: %s", err)
+	}
+}
+
+type getUDFsResponse struct {
+	UDFs []string `json:"udfs"`
+}
+
+// handlePostUDF handles POST /udf/{name} requests. The request body is the
+// compiled module.
+func (h *Handler) handlePostUDF(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+
+	code, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp := successResponse{h: h, req: r}
+	err = h.api.LoadUDF(r.Context(), mux.Vars(r)["name"], code)
+	resp.write(w, err)
+}
+
+// handleDeleteUDF handles DELETE /udf/{name} requests.
+func (h *Handler) handleDeleteUDF(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+
+	resp := successResponse{h: h, req: r}
+	err := h.api.DeleteUDF(r.Context(), mux.Vars(r)["name"])
+	resp.write(w, err)
+}
+
+// handlePostFieldReindex handles POST /index/{index}/field/{field}/reindex
+// requests, which start a job rebuilding the field's derived data.
+func (h *Handler) handlePostFieldReindex(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	vars := mux.Vars(r)
+	job, err := h.api.ReindexField(r.Context(), vars["index"], vars["field"])
+	h.writeJobResponse(w, job, err)
+}
+
+// handleGetJobs handles GET /jobs requests.
+func (h *Handler) handleGetJobs(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	jobs, err := h.api.Jobs(r.Context())
+	if err != nil {
+		resp := successResponse{h: h, req: r}
+		resp.write(w, err)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(getJobsResponse{Jobs: jobs}); err != nil {
+		h.requestLogger(r).Printf("write jobs response error: %s", err)
+	}
+}
+
+type getJobsResponse struct {
+	Jobs []pilosa.JobStatus `json:"jobs"`
+}
+
+// handleGetJob handles GET /jobs/{id} requests.
+func (h *Handler) handleGetJob(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "job id should be an integer", http.StatusBadRequest)
+		return
+	}
+	job, err := h.api.Job(r.Context(), id)
+	h.writeJobResponse(w, job, err)
+}
+
+// handleGetJobResult handles GET /jobs/{id}/result requests, which return
+// the response of an asynchronous query once it has finished. Until then,
+// the job's status is returned with 202 Accepted, unless the "wait" argument
+// is true, in which case the request waits for the query to finish.
+func (h *Handler) handleGetJobResult(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "job id should be an integer", http.StatusBadRequest)
+		return
+	}
+	wait := r.URL.Query().Get("wait") == "true"
+
+	job, resp, err := h.api.QueryResult(r.Context(), id, wait)
+	if err != nil {
+		h.writeJobResponse(w, job, err)
+		return
+	} else if resp == nil {
+		w.WriteHeader(http.StatusAccepted)
+		h.writeJobResponse(w, job, nil)
+		return
+	}
+
+	if resp.Err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+	}
+	if err := h.writeQueryResponse(w, r, resp); err != nil {
+		h.requestLogger(r).Printf("write query response error: %s", err)
+	}
+}
+
+// handleDeleteJob handles DELETE /jobs/{id} requests, which cancel a job.
+func (h *Handler) handleDeleteJob(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "job id should be an integer", http.StatusBadRequest)
+		return
+	}
+	job, err := h.api.CancelJob(r.Context(), id)
+	h.writeJobResponse(w, job, err)
+}
+
+// writeJobResponse writes the status of a job, or err if it is not nil.
+func (h *Handler) writeJobResponse(w http.ResponseWriter, job pilosa.JobStatus, err error) {
+	if err != nil {
+		resp := successResponse{h: h}
+		resp.write(w, err)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(job); err != nil {
+		h.logger.Printf("write job response error: %s", err)
+	}
+}
+
+// handleGetSettings handles GET /settings requests.
+func (h *Handler) handleGetSettings(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	settings, err := h.api.Settings(r.Context())
+	h.writeSettingsResponse(w, settings, err)
+}
+
+// handlePostSettings handles POST /settings requests, which change the
+// settings given in the request body on every node.
+func (h *Handler) handlePostSettings(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+
+	var update pilosa.SettingsUpdate
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&update); err != nil {
+		resp := successResponse{h: h, req: r}
+		resp.write(w, pilosa.NewBadRequestError(err))
+		return
+	}
+	settings, err := h.api.UpdateSettings(r.Context(), update)
+	h.writeSettingsResponse(w, settings, err)
+}
+
+func (h *Handler) writeSettingsResponse(w http.ResponseWriter, settings pilosa.Settings, err error) {
+	if err != nil {
+		resp := successResponse{h: h}
+		resp.write(w, err)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(settings); err != nil {
+		h.logger.Printf("write settings response error: %s", err)
+	}
+}
+
+// handleGetAliases handles GET /aliases requests.
+func (h *Handler) handleGetAliases(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	aliases, err := h.api.Aliases(r.Context())
+	h.writeAliasesResponse(w, aliases, err)
+}
+
+// handlePostAliases handles POST /aliases requests, which set or remove the
+// aliases given in the request body on every node, all at once.
+func (h *Handler) handlePostAliases(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+
+	var update pilosa.AliasesUpdate
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&update); err != nil {
+		resp := successResponse{h: h, req: r}
+		resp.write(w, pilosa.NewBadRequestError(err))
+		return
+	}
+	aliases, err := h.api.UpdateAliases(r.Context(), update)
+	h.writeAliasesResponse(w, aliases, err)
+}
+
+func (h *Handler) writeAliasesResponse(w http.ResponseWriter, aliases pilosa.Aliases, err error) {
+	if err != nil {
+		resp := successResponse{h: h}
+		resp.write(w, err)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(aliases); err != nil {
+		h.logger.Printf("write aliases response error: %s", err)
+	}
+}
+
+// handleGetUsage handles GET /usage requests.
+func (h *Handler) handleGetUsage(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	usage, err := h.api.Usage(r.Context())
+	if err != nil {
+		resp := successResponse{h: h, req: r}
+		resp.write(w, err)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(getUsageResponse{Usage: usage}); err != nil {
+		h.requestLogger(r).Printf("write usage response error: %s", err)
+	}
+}
+
+type getUsageResponse struct {
+	Usage []pilosa.Usage `json:"usage"`
+}
+
+// handleGetUsageReport handles GET /usage/report requests.
+func (h *Handler) handleGetUsageReport(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	q := r.URL.Query()
+	days, err := h.api.UsageReport(r.Context(), pilosa.UsageReportRequest{
+		From:      q.Get("from"),
+		To:        q.Get("to"),
+		Token:     q.Get("token"),
+		Namespace: q.Get("namespace"),
+	})
+	if err != nil {
+		resp := successResponse{h: h, req: r}
+		resp.write(w, err)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(getUsageReportResponse{Days: days}); err != nil {
+		h.requestLogger(r).Printf("write usage report response error: %s", err)
+	}
+}
+
+type getUsageReportResponse struct {
+	Days []pilosa.UsageDay `json:"days"`
+}
+
+// handleGetChanges handles GET /changes requests. It streams mutations
+// applied on this node as newline-delimited JSON, starting after the
+// sequence number given by the "since" parameter, until the client
+// disconnects.
+func (h *Handler) handleGetChanges(w http.ResponseWriter, r *http.Request) {
+	var since uint64
+	if s := r.URL.Query().Get("since"); s != "" {
+		var err error
+		if since, err = strconv.ParseUint(s, 10, 64); err != nil {
+			http.Error(w, "since should be an unsigned integer", http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Read the first batch before writing the header so that errors can
+	// be reported with an appropriate status code.
+	events, err := h.api.Changes(r.Context(), since, changesBatchSize)
+	switch errors.Cause(err) {
+	case nil:
+	case pilosa.ErrChangeLogDisabled:
+		http.Error(w, err.Error(), http.StatusNotImplemented)
+		return
+	case pilosa.ErrChangeLogTruncated:
+		http.Error(w, err.Error(), http.StatusGone)
+		return
+	case context.Canceled:
+		return
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+
+	// Copy events to the client until an error occurs or the client disconnects.
+	enc := json.NewEncoder(w)
+	for {
+		for i := range events {
+			if err := enc.Encode(&events[i]); err != nil {
+				return
+			}
+		}
+		w.(http.Flusher).Flush()
+
+		since = events[len(events)-1].Seq
+		if events, err = h.api.Changes(r.Context(), since, changesBatchSize); err != nil {
+			if errors.Cause(err) != context.Canceled {
+				h.requestLogger(r).Printf("http: change stream error: %s", err)
+			}
+			return
+		}
+	}
+}
+
+// changesBatchSize is the maximum number of change events written between flushes.
+const changesBatchSize = 1000
+
 type queryValidationSpec struct {
 	required []string
 	args     map[string]struct{}
@@ -1675,6 +3467,18 @@ func (h *Handler) handlePostImportRoaring(w http.ResponseWriter, r *http.Request
 		resp.Err = err.Error()
 		if _, ok := err.(pilosa.BadRequestError); ok {
 			w.WriteHeader(http.StatusBadRequest)
+		} else if errors.Cause(err) == pilosa.ErrInsufficientDiskSpace {
+			w.WriteHeader(http.StatusInsufficientStorage)
+		} else if errors.Cause(err) == pilosa.ErrQuotaExceeded {
+			w.WriteHeader(http.StatusTooManyRequests)
+		} else if errors.Cause(err) == pilosa.ErrOverloaded {
+			w.Header().Set("Retry-After", overloadedRetryAfter)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		} else if errors.Cause(err) == pilosa.ErrBackpressure {
+			setBackpressureRetryAfter(w, err)
+			w.WriteHeader(http.StatusTooManyRequests)
+		} else if errors.Cause(err) == pilosa.ErrIndexReadOnly {
+			w.WriteHeader(http.StatusForbidden)
 		} else {
 			w.WriteHeader(http.StatusInternalServerError)
 		}
@@ -1690,7 +3494,7 @@ func (h *Handler) handlePostImportRoaring(w http.ResponseWriter, r *http.Request
 	// Write response.
 	_, err = w.Write(buf)
 	if err != nil {
-		h.logger.Printf("writing import-roaring response: %v", err)
+		h.requestLogger(r).Printf("writing import-roaring response: %v", err)
 		return
 	}
 }
@@ -1713,6 +3517,6 @@ func (h *Handler) handlePostTranslateKeys(w http.ResponseWriter, r *http.Request
 	// Write response.
 	_, err = w.Write(buf)
 	if err != nil {
-		h.logger.Printf("writing translate keys response: %v", err)
+		h.requestLogger(r).Printf("writing translate keys response: %v", err)
 	}
 }
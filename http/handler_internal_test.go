@@ -17,11 +17,14 @@ package http
 import (
 	"bytes"
 	"encoding/json"
+	"net/url"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/pilosa/pilosa/v2"
+	"github.com/pilosa/pilosa/v2/toml"
 )
 
 // Test custom UnmarshalJSON for postIndexRequest object
@@ -103,10 +106,15 @@ func int64Ptr(i int64) *int64 {
 	return &i
 }
 
+func boolPtr(b bool) *bool {
+	return &b
+}
+
 // Test fieldOption validation.
 func TestFieldOptionValidation(t *testing.T) {
 	timeQuantum := pilosa.TimeQuantum("YMD")
 	defaultCacheSize := uint32(pilosa.DefaultCacheSize)
+	idleTTL := toml.Duration(30 * time.Minute)
 	tests := []struct {
 		json     string
 		expected postFieldRequest
@@ -152,8 +160,31 @@ func TestFieldOptionValidation(t *testing.T) {
 		}}},
 		{json: `{"options": {"type": "time", "timeQuantum": "YMD", "min": 0}}`, err: "min does not apply to field type time"},
 		{json: `{"options": {"type": "time", "timeQuantum": "YMD", "max": 1000}}`, err: "max does not apply to field type time"},
-		{json: `{"options": {"type": "time", "timeQuantum": "YMD", "cacheType": "ranked"}}`, err: "cacheType does not apply to field type time"},
-		{json: `{"options": {"type": "time", "timeQuantum": "YMD", "cacheSize": 1000}}`, err: "cacheSize does not apply to field type time"},
+		{json: `{"options": {"type": "time", "timeQuantum": "YMD", "cacheType": "ranked", "timeViewCacheType": "none", "timeViewStorage": "lazy"}}`, expected: postFieldRequest{Options: fieldOptions{
+			Type:              pilosa.FieldTypeTime,
+			TimeQuantum:       &timeQuantum,
+			CacheType:         stringPtr("ranked"),
+			TimeViewCacheType: stringPtr("none"),
+			TimeViewStorage:   stringPtr("lazy"),
+		}}},
+		{json: `{"options": {"type": "time", "timeQuantum": "YMD", "cacheSize": 1000}}`, err: "cacheSize requires cacheType for field type time"},
+		{json: `{"options": {"type": "set", "timeViewCacheType": "none"}}`, err: "timeViewCacheType does not apply to field type set"},
+		{json: `{"options": {"type": "int", "min": 0, "max": 1000, "timeViewStorage": "lazy"}}`, err: "timeViewStorage does not apply to field type int"},
+		{json: `{"options": {"type": "mutex", "inverse": true}}`, expected: postFieldRequest{Options: fieldOptions{
+			Type:      pilosa.FieldTypeMutex,
+			CacheType: stringPtr(pilosa.DefaultCacheType),
+			CacheSize: &defaultCacheSize,
+			Inverse:   boolPtr(true),
+		}}},
+		{json: `{"options": {"type": "int", "min": 0, "max": 1000, "inverse": true}}`, err: "inverse does not apply to field type int"},
+		{json: `{"options": {"type": "time", "timeQuantum": "YMD", "noStandardView": true, "inverse": true}}`, err: "inverse requires the standard view"},
+		{json: `{"options": {"type": "int", "min": 0, "max": 1000, "idleTTL": "30m"}}`, expected: postFieldRequest{Options: fieldOptions{
+			Type:    pilosa.FieldTypeInt,
+			Min:     int64Ptr(0),
+			Max:     int64Ptr(1000),
+			IdleTTL: &idleTTL,
+		}}},
+		{json: `{"options": {"idleTTL": "-1m"}}`, err: "idleTTL must not be negative"},
 	}
 	for i, test := range tests {
 		actual := &postFieldRequest{}
@@ -178,3 +209,40 @@ func TestFieldOptionValidation(t *testing.T) {
 		}
 	}
 }
+
+func TestListPage(t *testing.T) {
+	names := []string{"a", "ab", "abc", "b", "ba", "c"}
+	name := func(i int) string { return names[i] }
+	tests := []struct {
+		query string
+		names []string
+		next  string
+	}{
+		{query: "", names: names},
+		{query: "limit=2", names: []string{"a", "ab"}, next: "ab"},
+		{query: "limit=2&after=ab", names: []string{"abc", "b"}, next: "b"},
+		{query: "limit=2&after=b", names: []string{"ba", "c"}},
+		{query: "prefix=a&limit=2", names: []string{"a", "ab"}, next: "ab"},
+		{query: "prefix=a&after=ab", names: []string{"abc"}},
+		{query: "prefix=b&limit=2", names: []string{"b", "ba"}},
+		{query: "prefix=d", names: []string{}},
+	}
+	for i, test := range tests {
+		q, err := url.ParseQuery(test.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		page, err := parseListPage(q)
+		if err != nil {
+			t.Fatalf("test %d: %v", i, err)
+		}
+		start, end, next := page.bounds(len(names), name)
+		if !reflect.DeepEqual(names[start:end], test.names) || next != test.next {
+			t.Errorf("test %d: unexpected page: %v, next %q", i, names[start:end], next)
+		}
+	}
+
+	if _, err := parseListPage(url.Values{"limit": {"0"}}); err == nil {
+		t.Fatal("expected error for zero limit")
+	}
+}
@@ -0,0 +1,376 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"encoding"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/gorilla/mux"
+	"github.com/pilosa/pilosa/v2"
+)
+
+// Content types of request and response bodies which aren't JSON.
+const (
+	contentTypeText     = "text/plain"
+	contentTypeCSV      = "text/csv"
+	contentTypeNDJSON   = "application/x-ndjson"
+	contentTypeProtobuf = "application/x-protobuf"
+	contentTypeBinary   = "application/octet-stream"
+)
+
+// openAPIOperation documents an operation of the public API. Request and
+// response are values of the types of JSON bodies, whose schemas are
+// generated from them, unless a content type is given.
+type openAPIOperation struct {
+	summary string
+
+	request     interface{}
+	requestType string
+
+	response     interface{}
+	responseType string
+}
+
+// queryResponseDoc documents the JSON encoding of a pilosa.QueryResponse.
+type queryResponseDoc struct {
+	Results        []interface{}          `json:"results"`
+	ColumnAttrSets []pilosa.ColumnAttrSet `json:"columnAttrs,omitempty"`
+	Profile        *pilosa.QueryProfile   `json:"profile,omitempty"`
+}
+
+// openAPIOperations documents the operations of the public API, by the names
+// of their routes.
+var openAPIOperations = map[string]openAPIOperation{
+	"GetAliases":                      {summary: "Get the index and field aliases.", response: pilosa.Aliases{}},
+	"PostAliases":                     {summary: "Set or remove index and field aliases, all at once.", request: pilosa.AliasesUpdate{}, response: pilosa.Aliases{}},
+	"GetClusterSlices":                {summary: "Get the replicas of each shard of each index, and the health of the nodes holding them.", response: pilosa.ShardDistribution{}},
+	"GetClusterStatus":                {summary: "Get the state of the cluster and the health and load of each node.", response: getClusterStatusResponse{}},
+	"GetClusterTopology":              {summary: "Get the nodes which own each shard of each index.", response: pilosa.ClusterTopology{}},
+	"PostClusterRebalance":            {summary: "Move the reads of hot shards to other replicas, or preview the moves.", response: pilosa.RebalancePlan{}},
+	"PostClusterResizeAbort":          {summary: "Abort the running resize job.", response: clusterResizeAbortResponse{}},
+	"PostClusterResizeRemoveNode":     {summary: "Remove a node from the cluster.", request: removeNodeRequest{}, response: removeNodeResponse{}},
+	"PostClusterResizeSetCoordinator": {summary: "Make a node the coordinator of the cluster.", request: setCoordinatorRequest{}, response: setCoordinatorResponse{}},
+	"GetChanges":                      {summary: "Stream the changes to the schema and data since a sequence number, one JSON event per line.", responseType: contentTypeNDJSON},
+	"GetExport":                       {summary: "Export the data of a shard of a field as CSV.", responseType: contentTypeCSV},
+	"GetGraphQL":                      {summary: "Execute a GraphQL query of the indexes and fields, and their rows.", response: graphQLResponse{}},
+	"PostGraphQL":                     {summary: "Execute a GraphQL query of the indexes and fields, and their rows.", request: graphQLRequest{}, response: graphQLResponse{}},
+	"GetIndexes":                      {summary: "List the indexes and their fields.", response: getSchemaResponse{}},
+	"PostIndex":                       {summary: "Create an index.", request: postIndexRequest{}, response: successResponse{}},
+	"GetIndex":                        {summary: "Get an index and a page of its fields.", response: getIndexResponse{}},
+	"DeleteIndex":                     {summary: "Delete an index.", response: successResponse{}},
+	"PostField":                       {summary: "Create a field.", request: postFieldRequest{}, response: successResponse{}},
+	"DeleteField":                     {summary: "Delete a field.", response: successResponse{}},
+	"PostImport":                      {summary: "Import bits or values into a shard of a field.", requestType: contentTypeProtobuf, responseType: contentTypeProtobuf},
+	"PostImportRoaring":               {summary: "Import roaring bitmaps into a shard of a field.", requestType: contentTypeProtobuf, responseType: contentTypeProtobuf},
+	"DeleteRows":                      {summary: "Clear a row, or a range of rows, of a field.", response: successResponse{}},
+	"PostFieldTruncate":               {summary: "Clear every row of a field, keeping its schema.", response: successResponse{}},
+	"PostFieldReindex":                {summary: "Rebuild a field's caches and derived data in a job.", response: pilosa.JobStatus{}},
+	"GetFieldHistogram":               {summary: "Get the distribution of a field's row cardinalities and its heaviest rows.", response: pilosa.FieldHistogram{}},
+	"PostQuery":                       {summary: "Execute a PQL query on an index.", requestType: contentTypeText, response: queryResponseDoc{}},
+	"PostQueryValidate":               {summary: "Check a PQL query against the schema without executing it.", requestType: contentTypeText, response: validateQueryResponse{}},
+	"PostIndexReadOnly":               {summary: "Make an index read-only.", response: successResponse{}},
+	"DeleteIndexReadOnly":             {summary: "Make a read-only index writable.", response: successResponse{}},
+	"GetMaterializedViews":            {summary: "List the materialized views of an index.", response: getMaterializedViewsResponse{}},
+	"PostMaterializedView":            {summary: "Define a materialized view of an index.", requestType: contentTypeText, response: successResponse{}},
+	"DeleteMaterializedView":          {summary: "Remove a materialized view of an index.", response: successResponse{}},
+	"GetRewriteRules":                 {summary: "List the rewrite rules of an index.", response: getRewriteRulesResponse{}},
+	"PostRewriteRule":                 {summary: "Add or replace a rule rewriting calls in queries of an index.", request: postRewriteRuleRequest{}, response: successResponse{}},
+	"DeleteRewriteRule":               {summary: "Remove a rewrite rule of an index.", response: successResponse{}},
+	"GetNamedQueries":                 {summary: "List the named queries.", response: getNamedQueriesResponse{}},
+	"GetNamedQuery":                   {summary: "Execute a named query, with its parameters given in the URL query.", response: queryResponseDoc{}},
+	"PostNamedQuery":                  {summary: "Add or replace a named query.", request: postNamedQueryRequest{}, response: successResponse{}},
+	"DeleteNamedQuery":                {summary: "Remove a named query.", response: successResponse{}},
+	"GetSchedules":                    {summary: "List the schedules.", response: getSchedulesResponse{}},
+	"GetSchedule":                     {summary: "Get a schedule, when it is next due, and its recent runs.", response: pilosa.ScheduleStatus{}},
+	"PostSchedule":                    {summary: "Add or replace a schedule which runs a named query on a cron schedule.", request: postScheduleRequest{}, response: successResponse{}},
+	"DeleteSchedule":                  {summary: "Remove a schedule.", response: successResponse{}},
+	"GetExportAttrs":                  {summary: "Export the column and row attributes of an index as JSON lines or CSV.", responseType: contentTypeNDJSON},
+	"GetInfo":                         {summary: "Get information about the server.", response: reflect.Zero(reflect.TypeOf((*pilosa.API).Info).Out(0)).Interface()},
+	"GetJobs":                         {summary: "List the jobs running or recently finished.", response: getJobsResponse{}},
+	"GetJob":                          {summary: "Get the status of a job.", response: pilosa.JobStatus{}},
+	"DeleteJob":                       {summary: "Cancel a job.", response: pilosa.JobStatus{}},
+	"GetJobResult":                    {summary: "Get the result of an asynchronous query.", response: queryResponseDoc{}},
+	"GetReadyz":                       {summary: "Check whether the node has finished starting up.", responseType: contentTypeText},
+	"RecalculateCaches":               {summary: "Recalculate the caches of every fragment."},
+	"GetSchema":                       {summary: "Get the schema of every index.", response: getSchemaResponse{}},
+	"PostSchema":                      {summary: "Create the indexes and fields of a schema.", request: pilosa.Schema{}},
+	"GetSchemaDiff":                   {summary: "Compare the schema with another cluster's.", response: pilosa.SchemaDiff{}},
+	"GetSettings":                     {summary: "Get the settings which can be changed at runtime.", response: pilosa.Settings{}},
+	"PostSettings":                    {summary: "Change settings at runtime.", request: pilosa.SettingsUpdate{}, response: pilosa.Settings{}},
+	"GetStatus":                       {summary: "Get the status of the cluster's nodes.", response: getStatusResponse{}},
+	"GetUDFs":                         {summary: "List the user-defined functions.", response: getUDFsResponse{}},
+	"PostUDF":                         {summary: "Load a user-defined function.", requestType: contentTypeBinary, response: successResponse{}},
+	"DeleteUDF":                       {summary: "Remove a user-defined function.", response: successResponse{}},
+	"GetUsage":                        {summary: "Get the usage of each API token.", response: getUsageResponse{}},
+	"GetUsageReport":                  {summary: "Get the daily usage of each API token and namespace.", response: getUsageReportResponse{}},
+	"GetVersion":                      {summary: "Get the version of the server and the API versions it serves.", response: getVersionResponse{}},
+}
+
+// pathVarRegexp matches the variables in a route's path template.
+var pathVarRegexp = regexp.MustCompile(`\{([^}:]+)(:[^}]*)?\}`)
+
+// handleGetOpenAPI handles GET /openapi.json requests.
+func (h *Handler) handleGetOpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(h.openAPIDocument()); err != nil {
+		h.requestLogger(r).Printf("write openapi response error: %s", err)
+	}
+}
+
+// openAPIDocument returns an OpenAPI 3 document describing the routes of the
+// current version of the public API.
+func (h *Handler) openAPIDocument() map[string]interface{} {
+	version := apiVersions[len(apiVersions)-1]
+	schemas := map[string]interface{}{}
+	paths := map[string]map[string]interface{}{}
+
+	_ = h.router.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		tmpl, err := route.GetPathTemplate()
+		if err != nil || !strings.HasPrefix(tmpl, "/"+version+"/") {
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			return nil
+		}
+		name := route.GetName()
+		path := pathVarRegexp.ReplaceAllString(tmpl, "{$1}")
+		if paths[path] == nil {
+			paths[path] = map[string]interface{}{}
+		}
+		for _, method := range methods {
+			paths[path][strings.ToLower(method)] = h.openAPIOperation(name, tmpl, schemas)
+		}
+		return nil
+	})
+
+	schemas["Error"] = map[string]interface{}{
+		"type":        "object",
+		"description": "An error. Clients should handle errors by their code; the message is meant for people, and may change.",
+		"properties": map[string]interface{}{
+			"code":    map[string]interface{}{"type": "string", "enum": pilosa.ErrorCodes()},
+			"message": map[string]interface{}{"type": "string"},
+			"index":   map[string]interface{}{"type": "string"},
+			"field":   map[string]interface{}{"type": "string"},
+		},
+		"required": []string{"code", "message"},
+	}
+	schemas["ErrorResponse"] = map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"success": map[string]interface{}{"type": "boolean"},
+			"error":   map[string]interface{}{"$ref": "#/components/schemas/Error"},
+		},
+		"required": []string{"error"},
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.2",
+		"info": map[string]interface{}{
+			"title":       "Pilosa",
+			"version":     h.api.Version(),
+			"description": "The Pilosa HTTP API. The paths are also served without the /" + version + " prefix, for clients written before the API was versioned.",
+		},
+		"paths":      paths,
+		"components": map[string]interface{}{"schemas": schemas},
+	}
+}
+
+// openAPIOperation returns the description of the operation of the route
+// named name, with the path template tmpl. The schemas of its bodies are
+// added to schemas.
+func (h *Handler) openAPIOperation(name, tmpl string, schemas map[string]interface{}) map[string]interface{} {
+	doc, ok := openAPIOperations[name]
+	if !ok {
+		doc.summary = name
+	}
+
+	var params []interface{}
+	for _, m := range pathVarRegexp.FindAllStringSubmatch(tmpl, -1) {
+		params = append(params, map[string]interface{}{
+			"name":     m[1],
+			"in":       "path",
+			"required": true,
+			"schema":   map[string]interface{}{"type": "string"},
+		})
+	}
+	if spec := h.validators[name]; spec != nil {
+		args := make([]string, 0, len(spec.args))
+		for arg := range spec.args {
+			args = append(args, arg)
+		}
+		sort.Strings(args)
+		for _, arg := range args {
+			required := false
+			for _, req := range spec.required {
+				required = required || req == arg
+			}
+			params = append(params, map[string]interface{}{
+				"name":     arg,
+				"in":       "query",
+				"required": required,
+				"schema":   map[string]interface{}{"type": "string"},
+			})
+		}
+	}
+
+	op := map[string]interface{}{
+		"operationId": name,
+		"summary":     doc.summary,
+		"responses": map[string]interface{}{
+			"200": openAPIBody(doc.response, doc.responseType, schemas, "Success."),
+			"default": map[string]interface{}{
+				"description": "An error.",
+				"content": map[string]interface{}{
+					"application/json": map[string]interface{}{
+						"schema": map[string]interface{}{"$ref": "#/components/schemas/ErrorResponse"},
+					},
+				},
+			},
+		},
+	}
+	if params != nil {
+		op["parameters"] = params
+	}
+	if doc.request != nil || doc.requestType != "" {
+		op["requestBody"] = openAPIBody(doc.request, doc.requestType, schemas, "")
+	}
+	return op
+}
+
+// openAPIBody describes a request or response body, which is either JSON
+// encoding a value like v, or has the content type typ.
+func openAPIBody(v interface{}, typ string, schemas map[string]interface{}, description string) map[string]interface{} {
+	body := map[string]interface{}{}
+	if description != "" {
+		body["description"] = description
+	}
+	switch {
+	case typ != "":
+		body["content"] = map[string]interface{}{typ: map[string]interface{}{}}
+	case v != nil:
+		body["content"] = map[string]interface{}{
+			"application/json": map[string]interface{}{"schema": jsonSchema(reflect.TypeOf(v), schemas)},
+		}
+	}
+	return body
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	timeType          = reflect.TypeOf(time.Time{})
+	uriType           = reflect.TypeOf(pilosa.URI{})
+)
+
+// jsonSchema returns the schema of the JSON encoding of values of type t.
+// Named structs are added to schemas, and referred to.
+func jsonSchema(t reflect.Type, schemas map[string]interface{}) map[string]interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch {
+	case t == timeType:
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case t == uriType:
+		return map[string]interface{}{"type": "string"}
+	case t.Implements(jsonMarshalerType) || reflect.PtrTo(t).Implements(jsonMarshalerType):
+		// Encoded in its own way, so its shape is unknown.
+		return map[string]interface{}{}
+	case t.Implements(textMarshalerType) || reflect.PtrTo(t).Implements(textMarshalerType):
+		return map[string]interface{}{"type": "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "format": "byte"}
+		}
+		return map[string]interface{}{"type": "array", "items": jsonSchema(t.Elem(), schemas)}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": jsonSchema(t.Elem(), schemas)}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return structSchema(t, schemas)
+		}
+		name := schemaName(t)
+		if _, ok := schemas[name]; !ok {
+			schemas[name] = nil // placeholder, for recursive types
+			schemas[name] = structSchema(t, schemas)
+		}
+		return map[string]interface{}{"$ref": "#/components/schemas/" + name}
+	}
+	return map[string]interface{}{}
+}
+
+// structSchema returns the schema of the JSON encoding of a struct.
+func structSchema(t reflect.Type, schemas map[string]interface{}) map[string]interface{} {
+	props := map[string]interface{}{}
+	addStructProperties(t, props, schemas)
+	return map[string]interface{}{"type": "object", "properties": props}
+}
+
+// addStructProperties adds the properties of the JSON encoding of a struct to
+// props, including those of its embedded structs.
+func addStructProperties(t reflect.Type, props, schemas map[string]interface{}) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		ft := f.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			addStructProperties(ft, props, schemas)
+			continue
+		}
+		if f.PkgPath != "" {
+			continue // unexported
+		}
+		if name == "" {
+			name = f.Name
+		}
+		props[name] = jsonSchema(f.Type, schemas)
+	}
+}
+
+// schemaName returns the name of the schema of a named type, which is
+// exported, so that generated clients export it.
+func schemaName(t reflect.Type) string {
+	name := []rune(t.Name())
+	name[0] = unicode.ToUpper(name[0])
+	return string(name)
+}
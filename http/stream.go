@@ -0,0 +1,226 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/pilosa/pilosa/v2"
+	"github.com/pkg/errors"
+)
+
+// Query responses with a row of more columns than the stream threshold are
+// streamed: the columns are written a shard at a time, and flushed, so they
+// are sent with chunked transfer encoding rather than encoded in memory all
+// at once.
+//
+// JSON responses are streamed without the client asking, since the body is
+// the same. Protobuf responses are only streamed to clients which accept
+// contentTypeProtobufStream, whose body is a series of frames. Each frame is
+// the uvarint position of a result, the uvarint length of a protobuf
+// QueryResponse, and the QueryResponse, which holds the result. A row is sent
+// as consecutive frames for the same result, each holding some of its
+// columns, which the client unions. The column attributes, if any, follow in
+// a frame positioned after the last result.
+
+// defaultStreamThreshold is the number of columns of a row above which query
+// responses are streamed.
+const defaultStreamThreshold = 1 << 20
+
+// contentTypeProtobufStream is the content type of streamed protobuf query
+// responses.
+const contentTypeProtobufStream = "application/x-protobuf-stream"
+
+// streamQueryResponse returns true if resp has a row large enough to stream.
+func (h *Handler) streamQueryResponse(resp *pilosa.QueryResponse) bool {
+	if h.streamThreshold == 0 || resp.Err != nil {
+		return false
+	}
+	for _, result := range resp.Results {
+		if row, ok := result.(*pilosa.Row); ok && row.Count() > h.streamThreshold {
+			return true
+		}
+	}
+	return false
+}
+
+// acceptsProtobufStream returns true if the request accepts streamed
+// protobuf query responses.
+func acceptsProtobufStream(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), contentTypeProtobufStream)
+}
+
+// streamWriter buffers writes to a response, and flushes them to the client.
+type streamWriter struct {
+	*bufio.Writer
+	w http.ResponseWriter
+}
+
+func newStreamWriter(w http.ResponseWriter) *streamWriter {
+	return &streamWriter{Writer: bufio.NewWriter(w), w: w}
+}
+
+// flush sends the buffered writes to the client.
+func (sw *streamWriter) flush() error {
+	if err := sw.Flush(); err != nil {
+		return err
+	}
+	if f, ok := sw.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+// writeJSONStreamQueryResponse writes resp to w as JSON, streaming the
+// columns of large rows.
+func (h *Handler) writeJSONStreamQueryResponse(w http.ResponseWriter, resp *pilosa.QueryResponse) error {
+	sw := newStreamWriter(w)
+	sw.WriteString(`{"results":[`)
+	for i, result := range resp.Results {
+		if i > 0 {
+			sw.WriteByte(',')
+		}
+		if row, ok := result.(*pilosa.Row); ok && row.Count() > h.streamThreshold {
+			if err := writeJSONStreamRow(sw, row); err != nil {
+				return errors.Wrapf(err, "writing result %d", i)
+			}
+			continue
+		}
+		buf, err := json.Marshal(result)
+		if err != nil {
+			return errors.Wrapf(err, "encoding result %d", i)
+		}
+		sw.Write(buf)
+	}
+	sw.WriteByte(']')
+
+	if len(resp.ColumnAttrSets) > 0 {
+		buf, err := json.Marshal(resp.ColumnAttrSets)
+		if err != nil {
+			return errors.Wrap(err, "encoding column attributes")
+		}
+		sw.WriteString(`,"columnAttrs":`)
+		sw.Write(buf)
+	}
+	if resp.Profile != nil {
+		buf, err := json.Marshal(resp.Profile)
+		if err != nil {
+			return errors.Wrap(err, "encoding profile")
+		}
+		sw.WriteString(`,"profile":`)
+		sw.Write(buf)
+	}
+	sw.WriteString("}\n")
+	return sw.flush()
+}
+
+// writeJSONStreamRow writes row to sw as JSON, like Row.MarshalJSON, flushing
+// after the columns of each shard.
+func writeJSONStreamRow(sw *streamWriter, row *pilosa.Row) error {
+	attrs := row.Attrs
+	if attrs == nil {
+		attrs = make(map[string]interface{})
+	}
+	buf, err := json.Marshal(attrs)
+	if err != nil {
+		return errors.Wrap(err, "encoding attributes")
+	}
+	sw.WriteString(`{"attrs":`)
+	sw.Write(buf)
+
+	sw.WriteString(`,"columns":[`)
+	first := true
+	var scratch [20]byte
+	for _, seg := range row.Segments() {
+		for _, col := range seg.Columns() {
+			if !first {
+				sw.WriteByte(',')
+			}
+			first = false
+			sw.Write(strconv.AppendUint(scratch[:0], col, 10))
+		}
+		if err := sw.flush(); err != nil {
+			return err
+		}
+	}
+	sw.WriteByte(']')
+
+	if len(row.Keys) > 0 {
+		buf, err := json.Marshal(row.Keys)
+		if err != nil {
+			return errors.Wrap(err, "encoding keys")
+		}
+		sw.WriteString(`,"keys":`)
+		sw.Write(buf)
+	}
+	sw.WriteByte('}')
+	return nil
+}
+
+// writeProtobufStreamQueryResponse writes resp to w as a series of protobuf
+// frames, splitting large rows a shard at a time.
+func (h *Handler) writeProtobufStreamQueryResponse(w http.ResponseWriter, resp *pilosa.QueryResponse) error {
+	sw := newStreamWriter(w)
+	for i, result := range resp.Results {
+		// Keyed rows are translated as a whole, so are sent in one frame.
+		row, ok := result.(*pilosa.Row)
+		if !ok || row.Count() <= h.streamThreshold || len(row.Keys) > 0 {
+			if err := h.writeProtobufFrame(sw, i, &pilosa.QueryResponse{Results: []interface{}{result}}); err != nil {
+				return errors.Wrapf(err, "writing result %d", i)
+			}
+			continue
+		}
+
+		for j, seg := range row.Segments() {
+			part := pilosa.NewRow(seg.Columns()...)
+			if j == 0 {
+				part.Attrs = row.Attrs
+			}
+			if err := h.writeProtobufFrame(sw, i, &pilosa.QueryResponse{Results: []interface{}{part}}); err != nil {
+				return errors.Wrapf(err, "writing result %d", i)
+			} else if err := sw.flush(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resp.ColumnAttrSets) > 0 {
+		trailer := &pilosa.QueryResponse{ColumnAttrSets: resp.ColumnAttrSets}
+		if err := h.writeProtobufFrame(sw, len(resp.Results), trailer); err != nil {
+			return errors.Wrap(err, "writing column attributes")
+		}
+	}
+	return sw.flush()
+}
+
+// writeProtobufFrame writes a frame holding resp for the result at position
+// i.
+func (h *Handler) writeProtobufFrame(sw *streamWriter, i int, resp *pilosa.QueryResponse) error {
+	buf, err := h.api.Serializer.Marshal(resp)
+	if err != nil {
+		return errors.Wrap(err, "marshalling")
+	}
+	var scratch [2 * binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(scratch[:], uint64(i))
+	n += binary.PutUvarint(scratch[n:], uint64(len(buf)))
+	sw.Write(scratch[:n])
+	_, err = sw.Write(buf)
+	return err
+}
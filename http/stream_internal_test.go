@@ -0,0 +1,118 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/pilosa/pilosa/v2"
+	"github.com/pilosa/pilosa/v2/encoding/proto"
+)
+
+// newStreamTestResponse returns a response with a row of columns in two
+// shards, which is streamed above a threshold of 2.
+func newStreamTestResponse() *pilosa.QueryResponse {
+	row := pilosa.NewRow(1, 2, pilosa.ShardWidth+3)
+	row.Attrs = map[string]interface{}{"a": "b"}
+	return &pilosa.QueryResponse{
+		Results:        []interface{}{uint64(7), row, pilosa.NewRow(4)},
+		ColumnAttrSets: []*pilosa.ColumnAttrSet{{ID: 1, Attrs: map[string]interface{}{"c": "d"}}},
+	}
+}
+
+// Ensure streamed JSON responses are the same as those encoded at once.
+func TestHandler_WriteJSONStreamQueryResponse(t *testing.T) {
+	h := &Handler{streamThreshold: 2}
+	resp := newStreamTestResponse()
+	if !h.streamQueryResponse(resp) {
+		t.Fatal("expected response to be streamed")
+	} else if h.streamThreshold = 3; h.streamQueryResponse(resp) {
+		t.Fatal("expected response not to be streamed")
+	}
+	h.streamThreshold = 2
+
+	w := httptest.NewRecorder()
+	if err := h.writeJSONStreamQueryResponse(w, resp); err != nil {
+		t.Fatal(err)
+	}
+	exp, err := json.Marshal(resp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got, want interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decoding %s: %v", w.Body.Bytes(), err)
+	} else if err := json.Unmarshal(exp, &want); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %s, expected %s", w.Body.Bytes(), exp)
+	}
+}
+
+// Ensure large rows are split over several protobuf frames.
+func TestHandler_WriteProtobufStreamQueryResponse(t *testing.T) {
+	h := &Handler{streamThreshold: 2, api: &pilosa.API{Serializer: proto.Serializer{}}}
+	resp := newStreamTestResponse()
+
+	w := httptest.NewRecorder()
+	if err := h.writeProtobufStreamQueryResponse(w, resp); err != nil {
+		t.Fatal(err)
+	}
+
+	var positions []uint64
+	var columns []uint64
+	var attrs []*pilosa.ColumnAttrSet
+	r := bufio.NewReader(w.Body)
+	for {
+		i, err := binary.ReadUvarint(r)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		n, err := binary.ReadUvarint(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		buf := make([]byte, n)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			t.Fatal(err)
+		}
+		var frame pilosa.QueryResponse
+		if err := (proto.Serializer{}).Unmarshal(buf, &frame); err != nil {
+			t.Fatal(err)
+		}
+		positions = append(positions, i)
+		if i == 1 {
+			columns = append(columns, frame.Results[0].(*pilosa.Row).Columns()...)
+		}
+		attrs = append(attrs, frame.ColumnAttrSets...)
+	}
+
+	if exp := []uint64{0, 1, 1, 2, 3}; !reflect.DeepEqual(positions, exp) {
+		t.Fatalf("unexpected frames: %v", positions)
+	} else if exp := []uint64{1, 2, pilosa.ShardWidth + 3}; !reflect.DeepEqual(columns, exp) {
+		t.Fatalf("unexpected columns: %v", columns)
+	} else if len(attrs) != 1 || attrs[0].ID != 1 {
+		t.Fatalf("unexpected column attributes: %+v", attrs)
+	}
+}
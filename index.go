@@ -44,9 +44,28 @@ type Index struct {
 	trackExistence bool
 	existenceFld   *Field
 
+	// Writes are rejected while the index is read-only.
+	readOnly bool
+
+	// In-memory indexes are never written to disk, and are lost when the
+	// node restarts.
+	inMemory bool
+
+	// Time zone of timestamps given without an offset.
+	timeZone string
+	location *time.Location
+
 	// Fields by name.
 	fields map[string]*Field
 
+	// Materialized views by name, and the versions of those deleted.
+	materialized        map[string]*materializedView
+	deletedMaterialized map[string]int64
+
+	// Rewrite rules by name, and the versions of those deleted.
+	rewriteRules        map[string]*rewriteRule
+	deletedRewriteRules map[string]int64
+
 	newAttrStore func(string) AttrStore
 
 	// Column attribute storage and cache.
@@ -59,6 +78,19 @@ type Index struct {
 
 	logger        logger.Logger
 	snapshotQueue chan *fragment
+	lazyFragments bool
+	progress      *openProgress
+	filePool      *filePool
+
+	// Passed to fragments, to group their writes and flush their files.
+	groupCommitWindow time.Duration
+	fsyncer           *fsyncer
+
+	// Directory corrupt fragment files are moved to.
+	quarantine *quarantine
+
+	// Records local mutations for change data capture, if set.
+	changes *changeLog
 
 	// Used for notifying holder when a field is added.
 	holder *Holder
@@ -69,7 +101,7 @@ type Index struct {
 
 // NewIndex returns a new instance of Index.
 func NewIndex(path, name string) (*Index, error) {
-	err := validateName(name)
+	err := validateIndexName(name)
 	if err != nil {
 		return nil, errors.Wrap(err, "validating name")
 	}
@@ -79,6 +111,11 @@ func NewIndex(path, name string) (*Index, error) {
 		name:   name,
 		fields: make(map[string]*Field),
 
+		materialized:        make(map[string]*materializedView),
+		deletedMaterialized: make(map[string]int64),
+		rewriteRules:        make(map[string]*rewriteRule),
+		deletedRewriteRules: make(map[string]int64),
+
 		newAttrStore: newNopAttrStore,
 		columnAttrs:  nopStore,
 
@@ -86,6 +123,7 @@ func NewIndex(path, name string) (*Index, error) {
 		Stats:          stats.NopStatsClient,
 		logger:         logger.NopLogger,
 		trackExistence: true,
+		location:       time.UTC,
 
 		OpenTranslateStore: OpenInMemTranslateStore,
 	}, nil
@@ -106,6 +144,39 @@ func (i *Index) ColumnAttrStore() AttrStore { return i.columnAttrs }
 // TranslateStore returns the underlying translation store for the index.
 func (i *Index) TranslateStore() TranslateStore { return i.translateStore }
 
+// Location returns the time zone of timestamps given without an offset, such
+// as those of Set() and of time ranges. Timestamps are stored in UTC.
+func (i *Index) Location() *time.Location { return i.location }
+
+// setTimeZone sets the time zone of the index by its IANA name.
+func (i *Index) setTimeZone(name string) error {
+	loc, err := loadTimeZone(name)
+	if err != nil {
+		return err
+	}
+	i.timeZone, i.location = name, loc
+	return nil
+}
+
+// ReadOnly returns true if writes to the index are rejected.
+func (i *Index) ReadOnly() bool {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.readOnly
+}
+
+// setReadOnly marks the index read-only, or writable, and records it in the
+// meta file.
+func (i *Index) setReadOnly(readOnly bool) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.readOnly == readOnly {
+		return nil
+	}
+	i.readOnly = readOnly
+	return errors.Wrap(i.saveMeta(), "saving meta")
+}
+
 // Options returns all options for this index.
 func (i *Index) Options() IndexOptions {
 	i.mu.RLock()
@@ -117,26 +188,42 @@ func (i *Index) options() IndexOptions {
 	return IndexOptions{
 		Keys:           i.keys,
 		TrackExistence: i.trackExistence,
+		TimeZone:       i.timeZone,
+		InMemory:       i.inMemory,
 	}
 }
 
+// setInMemory makes the index in-memory. Its attributes and keys are kept in
+// memory, and its fragments are not counted against the file pool, as they
+// have no files. It must be called before the index is opened.
+func (i *Index) setInMemory() {
+	i.inMemory = true
+	i.newAttrStore = newMemAttrStore
+	i.columnAttrs = newMemAttrStore("")
+	i.OpenTranslateStore = OpenInMemTranslateStore
+	i.filePool = nil
+}
+
 // Open opens and initializes the index.
 func (i *Index) Open() (err error) {
-	// Ensure the path exists.
-	i.logger.Debugf("ensure index path exists: %s", i.path)
-	if err := os.MkdirAll(i.path, 0777); err != nil {
-		return errors.Wrap(err, "creating directory")
-	}
+	// In-memory indexes have no directory to read.
+	if !i.inMemory {
+		// Ensure the path exists.
+		i.logger.Debugf("ensure index path exists: %s", i.path)
+		if err := os.MkdirAll(i.path, 0777); err != nil {
+			return errors.Wrap(err, "creating directory")
+		}
 
-	// Read meta file.
-	i.logger.Debugf("load meta file for index: %s", i.name)
-	if err := i.loadMeta(); err != nil {
-		return errors.Wrap(err, "loading meta file")
-	}
+		// Read meta file.
+		i.logger.Debugf("load meta file for index: %s", i.name)
+		if err := i.loadMeta(); err != nil {
+			return errors.Wrap(err, "loading meta file")
+		}
 
-	i.logger.Debugf("open fields for index: %s", i.name)
-	if err := i.openFields(); err != nil {
-		return errors.Wrap(err, "opening fields")
+		i.logger.Debugf("open fields for index: %s", i.name)
+		if err := i.openFields(); err != nil {
+			return errors.Wrap(err, "opening fields")
+		}
 	}
 
 	if i.trackExistence {
@@ -145,6 +232,13 @@ func (i *Index) Open() (err error) {
 		}
 	}
 
+	if err := i.loadMaterializedViews(); err != nil {
+		return errors.Wrap(err, "loading materialized views")
+	}
+	if err := i.loadRewriteRules(); err != nil {
+		return errors.Wrap(err, "loading rewrite rules")
+	}
+
 	if err := i.columnAttrs.Open(); err != nil {
 		return errors.Wrap(err, "opening attrstore")
 	}
@@ -240,16 +334,26 @@ func (i *Index) loadMeta() error {
 	// Copy metadata fields.
 	i.keys = pb.Keys
 	i.trackExistence = pb.TrackExistence
+	i.readOnly = pb.ReadOnly
+	if err := i.setTimeZone(pb.TimeZone); err != nil {
+		return errors.Wrap(err, "loading time zone")
+	}
 
 	return nil
 }
 
 // saveMeta writes meta data for the index.
 func (i *Index) saveMeta() error {
+	if i.inMemory {
+		return nil
+	}
+
 	// Marshal metadata.
 	buf, err := proto.Marshal(&internal.IndexMeta{
 		Keys:           i.keys,
 		TrackExistence: i.trackExistence,
+		ReadOnly:       i.readOnly,
+		TimeZone:       i.timeZone,
 	})
 	if err != nil {
 		return errors.Wrap(err, "marshalling")
@@ -359,7 +463,7 @@ func (i *Index) CreateField(name string, opts ...FieldOption) (*Field, error) {
 
 	// Ensure field doesn't already exist.
 	if i.fields[name] != nil {
-		return nil, newConflictError(ErrFieldExists)
+		return nil, NewConflictError(ErrFieldExists)
 	}
 
 	// Apply functional options.
@@ -418,6 +522,10 @@ func (i *Index) createField(name string, opt FieldOptions) (*Field, error) {
 		return nil, errors.New("field name required")
 	} else if opt.CacheType != "" && !isValidCacheType(opt.CacheType) {
 		return nil, ErrInvalidCacheType
+	} else if opt.TimeViewCacheType != "" && !isValidCacheType(opt.TimeViewCacheType) {
+		return nil, ErrInvalidCacheType
+	} else if !isValidViewStorage(opt.TimeViewStorage) {
+		return nil, ErrInvalidViewStorage
 	}
 
 	// Initialize field.
@@ -463,7 +571,15 @@ func (i *Index) newField(path, name string) (*Field, error) {
 	f.broadcaster = i.broadcaster
 	f.rowAttrStore = i.newAttrStore(filepath.Join(f.path, ".data"))
 	f.snapshotQueue = i.snapshotQueue
+	f.lazyFragments = i.lazyFragments
+	f.progress = i.progress
+	f.filePool = i.filePool
+	f.groupCommitWindow = i.groupCommitWindow
+	f.fsyncer = i.fsyncer
+	f.quarantine = i.quarantine
+	f.changes = i.changes
 	f.OpenTranslateStore = i.OpenTranslateStore
+	f.inMemory = i.inMemory
 	return f, nil
 }
 
@@ -484,8 +600,13 @@ func (i *Index) DeleteField(name string) error {
 	}
 
 	// Delete field directory.
-	if err := os.RemoveAll(i.fieldPath(name)); err != nil {
-		return errors.Wrap(err, "removing directory")
+	if !i.inMemory {
+		if err := os.RemoveAll(i.fieldPath(name)); err != nil {
+			return errors.Wrap(err, "removing directory")
+		}
+	}
+	if err := i.quarantine.drop(i.name, name); err != nil {
+		return errors.Wrap(err, "dropping quarantined fragments")
 	}
 
 	// If the field being deleted is the existence field,
@@ -516,6 +637,7 @@ func (p indexSlice) Less(i, j int) bool { return p[i].Name() < p[j].Name() }
 type IndexInfo struct {
 	Name       string       `json:"name"`
 	Options    IndexOptions `json:"options"`
+	ReadOnly   bool         `json:"readOnly,omitempty"`
 	Fields     []*FieldInfo `json:"fields"`
 	ShardWidth uint64       `json:"shardWidth"`
 }
@@ -530,6 +652,14 @@ func (p indexInfoSlice) Less(i, j int) bool { return p[i].Name < p[j].Name }
 type IndexOptions struct {
 	Keys           bool `json:"keys"`
 	TrackExistence bool `json:"trackExistence"`
+
+	// TimeZone is the IANA name of the time zone of timestamps given
+	// without an offset, such as "America/New_York". It defaults to UTC.
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// InMemory keeps the index only in memory, with no files on disk, for
+	// ephemeral data. Its data is lost when the node restarts.
+	InMemory bool `json:"inMemory,omitempty"`
 }
 
 // hasTime returns true if a contains a non-nil time.
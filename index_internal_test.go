@@ -16,7 +16,10 @@ package pilosa
 
 import (
 	"io/ioutil"
+	"reflect"
 	"testing"
+
+	"github.com/pilosa/pilosa/v2/pql"
 )
 
 // mustOpenIndex returns a new, opened index at a temporary path. Panic on error.
@@ -86,3 +89,105 @@ func TestIndex_Existence_Delete(t *testing.T) {
 		t.Fatalf("expected index.existenceField to be nil")
 	}
 }
+
+// Ensure that the read-only state is kept across reopens.
+func TestIndex_ReadOnly(t *testing.T) {
+	index := mustOpenIndex(IndexOptions{})
+	defer index.Close()
+
+	if err := index.setReadOnly(true); err != nil {
+		t.Fatal(err)
+	} else if err := index.reopen(); err != nil {
+		t.Fatal(err)
+	} else if !index.ReadOnly() {
+		t.Fatal("expected index to be read-only")
+	}
+
+	if err := index.setReadOnly(false); err != nil {
+		t.Fatal(err)
+	} else if err := index.reopen(); err != nil {
+		t.Fatal(err)
+	} else if index.ReadOnly() {
+		t.Fatal("expected index to be writable")
+	}
+}
+
+// Ensure that a materialized view is only recomputed after the fields it reads
+// change, and that its definition is kept across reopens.
+func TestIndex_MaterializedView(t *testing.T) {
+	index := mustOpenIndex(IndexOptions{})
+	defer index.Close()
+
+	f, err := index.CreateField("f")
+	if err != nil {
+		t.Fatal(err)
+	} else if _, err := index.CreateField("g"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.SetBit(1, 1, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := index.createMaterializedView("v", "Row(f=1)", "Row(f=1)", 0); err != nil {
+		t.Fatal(err)
+	} else if err := index.reopen(); err != nil {
+		t.Fatal(err)
+	}
+	mv := index.materializedView("v")
+	if mv == nil {
+		t.Fatal("expected materialized view after reopen")
+	}
+	f = index.Field("f")
+
+	var computed int
+	read := func() []uint64 {
+		row, err := mv.shardRow(index, 0, func(*pql.Call) (*Row, error) {
+			computed++
+			return f.Row(1)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return row.Columns()
+	}
+
+	if cols := read(); !reflect.DeepEqual(cols, []uint64{1}) || computed != 1 {
+		t.Fatalf("unexpected columns %v after %d computations", cols, computed)
+	}
+	if cols := read(); !reflect.DeepEqual(cols, []uint64{1}) || computed != 1 {
+		t.Fatalf("unexpected columns %v after %d computations", cols, computed)
+	}
+
+	// Changes to other fields don't invalidate the view.
+	if _, err := index.Field("g").SetBit(1, 2, nil); err != nil {
+		t.Fatal(err)
+	} else if cols := read(); !reflect.DeepEqual(cols, []uint64{1}) || computed != 1 {
+		t.Fatalf("unexpected columns %v after %d computations", cols, computed)
+	}
+
+	if _, err := f.SetBit(1, 2, nil); err != nil {
+		t.Fatal(err)
+	} else if cols := read(); !reflect.DeepEqual(cols, []uint64{1, 2}) || computed != 2 {
+		t.Fatalf("unexpected columns %v after %d computations", cols, computed)
+	}
+
+	version, err := index.deleteMaterializedView("v", 0)
+	if err != nil {
+		t.Fatal(err)
+	} else if err := index.reopen(); err != nil {
+		t.Fatal(err)
+	} else if views := index.MaterializedViews(); len(views) != 0 {
+		t.Fatalf("unexpected views: %+v", views)
+	}
+
+	// The deletion is kept, so an earlier version of the view isn't created
+	// again.
+	if v, err := index.createMaterializedView("v", "Row(f=1)", "Row(f=1)", version); err != nil {
+		t.Fatal(err)
+	} else if v != 0 || index.materializedView("v") != nil {
+		t.Fatalf("unexpected view at version %d", v)
+	}
+	if _, deleted := index.materializedViewDefinitions(); len(deleted) != 1 || deleted[0].Name != "v" || deleted[0].Version != version {
+		t.Fatalf("unexpected deletions: %+v", deleted)
+	}
+}
@@ -19,6 +19,10 @@
 		AttrMap
 		QueryRequest
 		QueryResponse
+		ResponseError
+		QueryProfile
+		ShardProfile
+		NodeProfile
 		QueryResult
 		ImportRequest
 		ImportValueRequest
@@ -327,6 +331,10 @@ type QueryRequest struct {
 	Remote          bool     `protobuf:"varint,5,opt,name=Remote,proto3" json:"Remote,omitempty"`
 	ExcludeRowAttrs bool     `protobuf:"varint,6,opt,name=ExcludeRowAttrs,proto3" json:"ExcludeRowAttrs,omitempty"`
 	ExcludeColumns  bool     `protobuf:"varint,7,opt,name=ExcludeColumns,proto3" json:"ExcludeColumns,omitempty"`
+	Priority        string   `protobuf:"bytes,8,opt,name=Priority,proto3" json:"Priority,omitempty"`
+	Profile         bool     `protobuf:"varint,9,opt,name=Profile,proto3" json:"Profile,omitempty"`
+	ContinueOnError bool     `protobuf:"varint,10,opt,name=ContinueOnError,proto3" json:"ContinueOnError,omitempty"`
+	Affinity        string   `protobuf:"bytes,11,opt,name=Affinity,proto3" json:"Affinity,omitempty"`
 }
 
 func (m *QueryRequest) Reset()                    { *m = QueryRequest{} }
@@ -376,10 +384,40 @@ func (m *QueryRequest) GetExcludeColumns() bool {
 	return false
 }
 
+func (m *QueryRequest) GetPriority() string {
+	if m != nil {
+		return m.Priority
+	}
+	return ""
+}
+
+func (m *QueryRequest) GetProfile() bool {
+	if m != nil {
+		return m.Profile
+	}
+	return false
+}
+
+func (m *QueryRequest) GetContinueOnError() bool {
+	if m != nil {
+		return m.ContinueOnError
+	}
+	return false
+}
+
+func (m *QueryRequest) GetAffinity() string {
+	if m != nil {
+		return m.Affinity
+	}
+	return ""
+}
+
 type QueryResponse struct {
 	Err            string           `protobuf:"bytes,1,opt,name=Err,proto3" json:"Err,omitempty"`
 	Results        []*QueryResult   `protobuf:"bytes,2,rep,name=Results" json:"Results,omitempty"`
 	ColumnAttrSets []*ColumnAttrSet `protobuf:"bytes,3,rep,name=ColumnAttrSets" json:"ColumnAttrSets,omitempty"`
+	Profile        *QueryProfile    `protobuf:"bytes,4,opt,name=Profile" json:"Profile,omitempty"`
+	Error          *ResponseError   `protobuf:"bytes,5,opt,name=Error" json:"Error,omitempty"`
 }
 
 func (m *QueryResponse) Reset()                    { *m = QueryResponse{} }
@@ -408,6 +446,188 @@ func (m *QueryResponse) GetColumnAttrSets() []*ColumnAttrSet {
 	return nil
 }
 
+func (m *QueryResponse) GetProfile() *QueryProfile {
+	if m != nil {
+		return m.Profile
+	}
+	return nil
+}
+
+func (m *QueryResponse) GetError() *ResponseError {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+type ResponseError struct {
+	Code    string `protobuf:"bytes,1,opt,name=Code,proto3" json:"Code,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=Message,proto3" json:"Message,omitempty"`
+	Index   string `protobuf:"bytes,3,opt,name=Index,proto3" json:"Index,omitempty"`
+	Field   string `protobuf:"bytes,4,opt,name=Field,proto3" json:"Field,omitempty"`
+}
+
+func (m *ResponseError) Reset()                    { *m = ResponseError{} }
+func (m *ResponseError) String() string            { return proto.CompactTextString(m) }
+func (*ResponseError) ProtoMessage()               {}
+func (*ResponseError) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{11} }
+
+func (m *ResponseError) GetCode() string {
+	if m != nil {
+		return m.Code
+	}
+	return ""
+}
+
+func (m *ResponseError) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *ResponseError) GetIndex() string {
+	if m != nil {
+		return m.Index
+	}
+	return ""
+}
+
+func (m *ResponseError) GetField() string {
+	if m != nil {
+		return m.Field
+	}
+	return ""
+}
+
+type QueryProfile struct {
+	Parse             int64           `protobuf:"varint,1,opt,name=Parse,proto3" json:"Parse,omitempty"`
+	Execute           int64           `protobuf:"varint,2,opt,name=Execute,proto3" json:"Execute,omitempty"`
+	Shards            []*ShardProfile `protobuf:"bytes,3,rep,name=Shards" json:"Shards,omitempty"`
+	Nodes             []*NodeProfile  `protobuf:"bytes,4,rep,name=Nodes" json:"Nodes,omitempty"`
+	ContainersScanned int64           `protobuf:"varint,5,opt,name=ContainersScanned,proto3" json:"ContainersScanned,omitempty"`
+	CacheHits         int64           `protobuf:"varint,6,opt,name=CacheHits,proto3" json:"CacheHits,omitempty"`
+}
+
+func (m *QueryProfile) Reset()                    { *m = QueryProfile{} }
+func (m *QueryProfile) String() string            { return proto.CompactTextString(m) }
+func (*QueryProfile) ProtoMessage()               {}
+func (*QueryProfile) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{12} }
+
+func (m *QueryProfile) GetParse() int64 {
+	if m != nil {
+		return m.Parse
+	}
+	return 0
+}
+
+func (m *QueryProfile) GetExecute() int64 {
+	if m != nil {
+		return m.Execute
+	}
+	return 0
+}
+
+func (m *QueryProfile) GetShards() []*ShardProfile {
+	if m != nil {
+		return m.Shards
+	}
+	return nil
+}
+
+func (m *QueryProfile) GetNodes() []*NodeProfile {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+func (m *QueryProfile) GetContainersScanned() int64 {
+	if m != nil {
+		return m.ContainersScanned
+	}
+	return 0
+}
+
+func (m *QueryProfile) GetCacheHits() int64 {
+	if m != nil {
+		return m.CacheHits
+	}
+	return 0
+}
+
+type ShardProfile struct {
+	Shard   uint64 `protobuf:"varint,1,opt,name=Shard,proto3" json:"Shard,omitempty"`
+	Node    string `protobuf:"bytes,2,opt,name=Node,proto3" json:"Node,omitempty"`
+	Execute int64  `protobuf:"varint,3,opt,name=Execute,proto3" json:"Execute,omitempty"`
+}
+
+func (m *ShardProfile) Reset()                    { *m = ShardProfile{} }
+func (m *ShardProfile) String() string            { return proto.CompactTextString(m) }
+func (*ShardProfile) ProtoMessage()               {}
+func (*ShardProfile) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{13} }
+
+func (m *ShardProfile) GetShard() uint64 {
+	if m != nil {
+		return m.Shard
+	}
+	return 0
+}
+
+func (m *ShardProfile) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
+}
+
+func (m *ShardProfile) GetExecute() int64 {
+	if m != nil {
+		return m.Execute
+	}
+	return 0
+}
+
+type NodeProfile struct {
+	Node     string `protobuf:"bytes,1,opt,name=Node,proto3" json:"Node,omitempty"`
+	Requests int64  `protobuf:"varint,2,opt,name=Requests,proto3" json:"Requests,omitempty"`
+	Network  int64  `protobuf:"varint,3,opt,name=Network,proto3" json:"Network,omitempty"`
+	Execute  int64  `protobuf:"varint,4,opt,name=Execute,proto3" json:"Execute,omitempty"`
+}
+
+func (m *NodeProfile) Reset()                    { *m = NodeProfile{} }
+func (m *NodeProfile) String() string            { return proto.CompactTextString(m) }
+func (*NodeProfile) ProtoMessage()               {}
+func (*NodeProfile) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{14} }
+
+func (m *NodeProfile) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
+}
+
+func (m *NodeProfile) GetRequests() int64 {
+	if m != nil {
+		return m.Requests
+	}
+	return 0
+}
+
+func (m *NodeProfile) GetNetwork() int64 {
+	if m != nil {
+		return m.Network
+	}
+	return 0
+}
+
+func (m *NodeProfile) GetExecute() int64 {
+	if m != nil {
+		return m.Execute
+	}
+	return 0
+}
+
 type QueryResult struct {
 	Type           uint32          `protobuf:"varint,6,opt,name=Type,proto3" json:"Type,omitempty"`
 	Row            *Row            `protobuf:"bytes,1,opt,name=Row" json:"Row,omitempty"`
@@ -418,12 +638,13 @@ type QueryResult struct {
 	RowIDs         []uint64        `protobuf:"varint,7,rep,packed,name=RowIDs" json:"RowIDs,omitempty"`
 	GroupCounts    []*GroupCount   `protobuf:"bytes,8,rep,name=GroupCounts" json:"GroupCounts,omitempty"`
 	RowIdentifiers *RowIdentifiers `protobuf:"bytes,9,opt,name=RowIdentifiers" json:"RowIdentifiers,omitempty"`
+	Error          *ResponseError  `protobuf:"bytes,10,opt,name=Error" json:"Error,omitempty"`
 }
 
 func (m *QueryResult) Reset()                    { *m = QueryResult{} }
 func (m *QueryResult) String() string            { return proto.CompactTextString(m) }
 func (*QueryResult) ProtoMessage()               {}
-func (*QueryResult) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{11} }
+func (*QueryResult) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{15} }
 
 func (m *QueryResult) GetType() uint32 {
 	if m != nil {
@@ -488,6 +709,13 @@ func (m *QueryResult) GetRowIdentifiers() *RowIdentifiers {
 	return nil
 }
 
+func (m *QueryResult) GetError() *ResponseError {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
 type ImportRequest struct {
 	Index      string   `protobuf:"bytes,1,opt,name=Index,proto3" json:"Index,omitempty"`
 	Field      string   `protobuf:"bytes,2,opt,name=Field,proto3" json:"Field,omitempty"`
@@ -502,7 +730,7 @@ type ImportRequest struct {
 func (m *ImportRequest) Reset()                    { *m = ImportRequest{} }
 func (m *ImportRequest) String() string            { return proto.CompactTextString(m) }
 func (*ImportRequest) ProtoMessage()               {}
-func (*ImportRequest) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{12} }
+func (*ImportRequest) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{16} }
 
 func (m *ImportRequest) GetIndex() string {
 	if m != nil {
@@ -572,7 +800,7 @@ type ImportValueRequest struct {
 func (m *ImportValueRequest) Reset()                    { *m = ImportValueRequest{} }
 func (m *ImportValueRequest) String() string            { return proto.CompactTextString(m) }
 func (*ImportValueRequest) ProtoMessage()               {}
-func (*ImportValueRequest) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{13} }
+func (*ImportValueRequest) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{17} }
 
 func (m *ImportValueRequest) GetIndex() string {
 	if m != nil {
@@ -625,7 +853,7 @@ type TranslateKeysRequest struct {
 func (m *TranslateKeysRequest) Reset()                    { *m = TranslateKeysRequest{} }
 func (m *TranslateKeysRequest) String() string            { return proto.CompactTextString(m) }
 func (*TranslateKeysRequest) ProtoMessage()               {}
-func (*TranslateKeysRequest) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{14} }
+func (*TranslateKeysRequest) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{18} }
 
 func (m *TranslateKeysRequest) GetIndex() string {
 	if m != nil {
@@ -655,7 +883,7 @@ type TranslateKeysResponse struct {
 func (m *TranslateKeysResponse) Reset()                    { *m = TranslateKeysResponse{} }
 func (m *TranslateKeysResponse) String() string            { return proto.CompactTextString(m) }
 func (*TranslateKeysResponse) ProtoMessage()               {}
-func (*TranslateKeysResponse) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{15} }
+func (*TranslateKeysResponse) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{19} }
 
 func (m *TranslateKeysResponse) GetIDs() []uint64 {
 	if m != nil {
@@ -672,7 +900,7 @@ type ImportRoaringRequestView struct {
 func (m *ImportRoaringRequestView) Reset()                    { *m = ImportRoaringRequestView{} }
 func (m *ImportRoaringRequestView) String() string            { return proto.CompactTextString(m) }
 func (*ImportRoaringRequestView) ProtoMessage()               {}
-func (*ImportRoaringRequestView) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{16} }
+func (*ImportRoaringRequestView) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{20} }
 
 func (m *ImportRoaringRequestView) GetName() string {
 	if m != nil {
@@ -696,7 +924,7 @@ type ImportRoaringRequest struct {
 func (m *ImportRoaringRequest) Reset()                    { *m = ImportRoaringRequest{} }
 func (m *ImportRoaringRequest) String() string            { return proto.CompactTextString(m) }
 func (*ImportRoaringRequest) ProtoMessage()               {}
-func (*ImportRoaringRequest) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{17} }
+func (*ImportRoaringRequest) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{21} }
 
 func (m *ImportRoaringRequest) GetClear() bool {
 	if m != nil {
@@ -724,6 +952,10 @@ func init() {
 	proto.RegisterType((*AttrMap)(nil), "internal.AttrMap")
 	proto.RegisterType((*QueryRequest)(nil), "internal.QueryRequest")
 	proto.RegisterType((*QueryResponse)(nil), "internal.QueryResponse")
+	proto.RegisterType((*ResponseError)(nil), "internal.ResponseError")
+	proto.RegisterType((*QueryProfile)(nil), "internal.QueryProfile")
+	proto.RegisterType((*ShardProfile)(nil), "internal.ShardProfile")
+	proto.RegisterType((*NodeProfile)(nil), "internal.NodeProfile")
 	proto.RegisterType((*QueryResult)(nil), "internal.QueryResult")
 	proto.RegisterType((*ImportRequest)(nil), "internal.ImportRequest")
 	proto.RegisterType((*ImportValueRequest)(nil), "internal.ImportValueRequest")
@@ -1181,6 +1413,38 @@ func (m *QueryRequest) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i++
 	}
+	if len(m.Priority) > 0 {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(len(m.Priority)))
+		i += copy(dAtA[i:], m.Priority)
+	}
+	if m.Profile {
+		dAtA[i] = 0x48
+		i++
+		if m.Profile {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.ContinueOnError {
+		dAtA[i] = 0x50
+		i++
+		if m.ContinueOnError {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.Affinity) > 0 {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(len(m.Affinity)))
+		i += copy(dAtA[i:], m.Affinity)
+	}
 	return i, nil
 }
 
@@ -1229,10 +1493,30 @@ func (m *QueryResponse) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
+	if m.Profile != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(m.Profile.Size()))
+		n7, err := m.Profile.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n7
+	}
+	if m.Error != nil {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(m.Error.Size()))
+		n8, err := m.Error.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n8
+	}
 	return i, nil
 }
 
-func (m *QueryResult) Marshal() (dAtA []byte, err error) {
+func (m *ResponseError) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -1242,106 +1526,39 @@ func (m *QueryResult) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *QueryResult) MarshalTo(dAtA []byte) (int, error) {
+func (m *ResponseError) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.Row != nil {
+	if len(m.Code) > 0 {
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintPublic(dAtA, i, uint64(m.Row.Size()))
-		n7, err := m.Row.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n7
-	}
-	if m.N != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintPublic(dAtA, i, uint64(m.N))
-	}
-	if len(m.Pairs) > 0 {
-		for _, msg := range m.Pairs {
-			dAtA[i] = 0x1a
-			i++
-			i = encodeVarintPublic(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
-	}
-	if m.Changed {
-		dAtA[i] = 0x20
-		i++
-		if m.Changed {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i++
-	}
-	if m.ValCount != nil {
-		dAtA[i] = 0x2a
-		i++
-		i = encodeVarintPublic(dAtA, i, uint64(m.ValCount.Size()))
-		n8, err := m.ValCount.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n8
+		i = encodeVarintPublic(dAtA, i, uint64(len(m.Code)))
+		i += copy(dAtA[i:], m.Code)
 	}
-	if m.Type != 0 {
-		dAtA[i] = 0x30
+	if len(m.Message) > 0 {
+		dAtA[i] = 0x12
 		i++
-		i = encodeVarintPublic(dAtA, i, uint64(m.Type))
+		i = encodeVarintPublic(dAtA, i, uint64(len(m.Message)))
+		i += copy(dAtA[i:], m.Message)
 	}
-	if len(m.RowIDs) > 0 {
-		dAtA10 := make([]byte, len(m.RowIDs)*10)
-		var j9 int
-		for _, num := range m.RowIDs {
-			for num >= 1<<7 {
-				dAtA10[j9] = uint8(uint64(num)&0x7f | 0x80)
-				num >>= 7
-				j9++
-			}
-			dAtA10[j9] = uint8(num)
-			j9++
-		}
-		dAtA[i] = 0x3a
+	if len(m.Index) > 0 {
+		dAtA[i] = 0x1a
 		i++
-		i = encodeVarintPublic(dAtA, i, uint64(j9))
-		i += copy(dAtA[i:], dAtA10[:j9])
-	}
-	if len(m.GroupCounts) > 0 {
-		for _, msg := range m.GroupCounts {
-			dAtA[i] = 0x42
-			i++
-			i = encodeVarintPublic(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
+		i = encodeVarintPublic(dAtA, i, uint64(len(m.Index)))
+		i += copy(dAtA[i:], m.Index)
 	}
-	if m.RowIdentifiers != nil {
-		dAtA[i] = 0x4a
+	if len(m.Field) > 0 {
+		dAtA[i] = 0x22
 		i++
-		i = encodeVarintPublic(dAtA, i, uint64(m.RowIdentifiers.Size()))
-		n11, err := m.RowIdentifiers.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n11
+		i = encodeVarintPublic(dAtA, i, uint64(len(m.Field)))
+		i += copy(dAtA[i:], m.Field)
 	}
 	return i, nil
 }
 
-func (m *ImportRequest) Marshal() (dAtA []byte, err error) {
+func (m *QueryProfile) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -1351,16 +1568,270 @@ func (m *ImportRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ImportRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *QueryProfile) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Index) > 0 {
-		dAtA[i] = 0xa
+	if m.Parse != 0 {
+		dAtA[i] = 0x8
 		i++
-		i = encodeVarintPublic(dAtA, i, uint64(len(m.Index)))
-		i += copy(dAtA[i:], m.Index)
+		i = encodeVarintPublic(dAtA, i, uint64(m.Parse))
+	}
+	if m.Execute != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(m.Execute))
+	}
+	if len(m.Shards) > 0 {
+		for _, msg := range m.Shards {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintPublic(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Nodes) > 0 {
+		for _, msg := range m.Nodes {
+			dAtA[i] = 0x22
+			i++
+			i = encodeVarintPublic(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.ContainersScanned != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(m.ContainersScanned))
+	}
+	if m.CacheHits != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(m.CacheHits))
+	}
+	return i, nil
+}
+
+func (m *ShardProfile) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ShardProfile) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Shard != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(m.Shard))
+	}
+	if len(m.Node) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(len(m.Node)))
+		i += copy(dAtA[i:], m.Node)
+	}
+	if m.Execute != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(m.Execute))
+	}
+	return i, nil
+}
+
+func (m *NodeProfile) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *NodeProfile) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Node) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(len(m.Node)))
+		i += copy(dAtA[i:], m.Node)
+	}
+	if m.Requests != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(m.Requests))
+	}
+	if m.Network != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(m.Network))
+	}
+	if m.Execute != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(m.Execute))
+	}
+	return i, nil
+}
+
+func (m *QueryResult) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryResult) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Row != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(m.Row.Size()))
+		n9, err := m.Row.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n9
+	}
+	if m.N != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(m.N))
+	}
+	if len(m.Pairs) > 0 {
+		for _, msg := range m.Pairs {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintPublic(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.Changed {
+		dAtA[i] = 0x20
+		i++
+		if m.Changed {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.ValCount != nil {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(m.ValCount.Size()))
+		n10, err := m.ValCount.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n10
+	}
+	if m.Type != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(m.Type))
+	}
+	if len(m.RowIDs) > 0 {
+		dAtA12 := make([]byte, len(m.RowIDs)*10)
+		var j11 int
+		for _, num := range m.RowIDs {
+			for num >= 1<<7 {
+				dAtA12[j11] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j11++
+			}
+			dAtA12[j11] = uint8(num)
+			j11++
+		}
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(j11))
+		i += copy(dAtA[i:], dAtA12[:j11])
+	}
+	if len(m.GroupCounts) > 0 {
+		for _, msg := range m.GroupCounts {
+			dAtA[i] = 0x42
+			i++
+			i = encodeVarintPublic(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.RowIdentifiers != nil {
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(m.RowIdentifiers.Size()))
+		n13, err := m.RowIdentifiers.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n13
+	}
+	if m.Error != nil {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(m.Error.Size()))
+		n14, err := m.Error.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n14
+	}
+	return i, nil
+}
+
+func (m *ImportRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ImportRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Index) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(len(m.Index)))
+		i += copy(dAtA[i:], m.Index)
 	}
 	if len(m.Field) > 0 {
 		dAtA[i] = 0x12
@@ -1374,56 +1845,56 @@ func (m *ImportRequest) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintPublic(dAtA, i, uint64(m.Shard))
 	}
 	if len(m.RowIDs) > 0 {
-		dAtA13 := make([]byte, len(m.RowIDs)*10)
-		var j12 int
+		dAtA16 := make([]byte, len(m.RowIDs)*10)
+		var j15 int
 		for _, num := range m.RowIDs {
 			for num >= 1<<7 {
-				dAtA13[j12] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA16[j15] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j12++
+				j15++
 			}
-			dAtA13[j12] = uint8(num)
-			j12++
+			dAtA16[j15] = uint8(num)
+			j15++
 		}
 		dAtA[i] = 0x22
 		i++
-		i = encodeVarintPublic(dAtA, i, uint64(j12))
-		i += copy(dAtA[i:], dAtA13[:j12])
+		i = encodeVarintPublic(dAtA, i, uint64(j15))
+		i += copy(dAtA[i:], dAtA16[:j15])
 	}
 	if len(m.ColumnIDs) > 0 {
-		dAtA15 := make([]byte, len(m.ColumnIDs)*10)
-		var j14 int
+		dAtA18 := make([]byte, len(m.ColumnIDs)*10)
+		var j17 int
 		for _, num := range m.ColumnIDs {
 			for num >= 1<<7 {
-				dAtA15[j14] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA18[j17] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j14++
+				j17++
 			}
-			dAtA15[j14] = uint8(num)
-			j14++
+			dAtA18[j17] = uint8(num)
+			j17++
 		}
 		dAtA[i] = 0x2a
 		i++
-		i = encodeVarintPublic(dAtA, i, uint64(j14))
-		i += copy(dAtA[i:], dAtA15[:j14])
+		i = encodeVarintPublic(dAtA, i, uint64(j17))
+		i += copy(dAtA[i:], dAtA18[:j17])
 	}
 	if len(m.Timestamps) > 0 {
-		dAtA17 := make([]byte, len(m.Timestamps)*10)
-		var j16 int
+		dAtA20 := make([]byte, len(m.Timestamps)*10)
+		var j19 int
 		for _, num1 := range m.Timestamps {
 			num := uint64(num1)
 			for num >= 1<<7 {
-				dAtA17[j16] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA20[j19] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j16++
+				j19++
 			}
-			dAtA17[j16] = uint8(num)
-			j16++
+			dAtA20[j19] = uint8(num)
+			j19++
 		}
 		dAtA[i] = 0x32
 		i++
-		i = encodeVarintPublic(dAtA, i, uint64(j16))
-		i += copy(dAtA[i:], dAtA17[:j16])
+		i = encodeVarintPublic(dAtA, i, uint64(j19))
+		i += copy(dAtA[i:], dAtA20[:j19])
 	}
 	if len(m.RowKeys) > 0 {
 		for _, s := range m.RowKeys {
@@ -1491,39 +1962,39 @@ func (m *ImportValueRequest) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintPublic(dAtA, i, uint64(m.Shard))
 	}
 	if len(m.ColumnIDs) > 0 {
-		dAtA19 := make([]byte, len(m.ColumnIDs)*10)
-		var j18 int
+		dAtA22 := make([]byte, len(m.ColumnIDs)*10)
+		var j21 int
 		for _, num := range m.ColumnIDs {
 			for num >= 1<<7 {
-				dAtA19[j18] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA22[j21] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j18++
+				j21++
 			}
-			dAtA19[j18] = uint8(num)
-			j18++
+			dAtA22[j21] = uint8(num)
+			j21++
 		}
 		dAtA[i] = 0x2a
 		i++
-		i = encodeVarintPublic(dAtA, i, uint64(j18))
-		i += copy(dAtA[i:], dAtA19[:j18])
+		i = encodeVarintPublic(dAtA, i, uint64(j21))
+		i += copy(dAtA[i:], dAtA22[:j21])
 	}
 	if len(m.Values) > 0 {
-		dAtA21 := make([]byte, len(m.Values)*10)
-		var j20 int
+		dAtA24 := make([]byte, len(m.Values)*10)
+		var j23 int
 		for _, num1 := range m.Values {
 			num := uint64(num1)
 			for num >= 1<<7 {
-				dAtA21[j20] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA24[j23] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j20++
+				j23++
 			}
-			dAtA21[j20] = uint8(num)
-			j20++
+			dAtA24[j23] = uint8(num)
+			j23++
 		}
 		dAtA[i] = 0x32
 		i++
-		i = encodeVarintPublic(dAtA, i, uint64(j20))
-		i += copy(dAtA[i:], dAtA21[:j20])
+		i = encodeVarintPublic(dAtA, i, uint64(j23))
+		i += copy(dAtA[i:], dAtA24[:j23])
 	}
 	if len(m.ColumnKeys) > 0 {
 		for _, s := range m.ColumnKeys {
@@ -1604,21 +2075,21 @@ func (m *TranslateKeysResponse) MarshalTo(dAtA []byte) (int, error) {
 	var l int
 	_ = l
 	if len(m.IDs) > 0 {
-		dAtA23 := make([]byte, len(m.IDs)*10)
-		var j22 int
+		dAtA26 := make([]byte, len(m.IDs)*10)
+		var j25 int
 		for _, num := range m.IDs {
 			for num >= 1<<7 {
-				dAtA23[j22] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA26[j25] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j22++
+				j25++
 			}
-			dAtA23[j22] = uint8(num)
-			j22++
+			dAtA26[j25] = uint8(num)
+			j25++
 		}
 		dAtA[i] = 0x1a
 		i++
-		i = encodeVarintPublic(dAtA, i, uint64(j22))
-		i += copy(dAtA[i:], dAtA23[:j22])
+		i = encodeVarintPublic(dAtA, i, uint64(j25))
+		i += copy(dAtA[i:], dAtA26[:j25])
 	}
 	return i, nil
 }
@@ -1889,6 +2360,20 @@ func (m *QueryRequest) Size() (n int) {
 	if m.ExcludeColumns {
 		n += 2
 	}
+	l = len(m.Priority)
+	if l > 0 {
+		n += 1 + l + sovPublic(uint64(l))
+	}
+	if m.Profile {
+		n += 2
+	}
+	if m.ContinueOnError {
+		n += 2
+	}
+	l = len(m.Affinity)
+	if l > 0 {
+		n += 1 + l + sovPublic(uint64(l))
+	}
 	return n
 }
 
@@ -1911,30 +2396,125 @@ func (m *QueryResponse) Size() (n int) {
 			n += 1 + l + sovPublic(uint64(l))
 		}
 	}
+	if m.Profile != nil {
+		l = m.Profile.Size()
+		n += 1 + l + sovPublic(uint64(l))
+	}
+	if m.Error != nil {
+		l = m.Error.Size()
+		n += 1 + l + sovPublic(uint64(l))
+	}
 	return n
 }
 
-func (m *QueryResult) Size() (n int) {
+func (m *ResponseError) Size() (n int) {
 	var l int
 	_ = l
-	if m.Row != nil {
-		l = m.Row.Size()
+	l = len(m.Code)
+	if l > 0 {
 		n += 1 + l + sovPublic(uint64(l))
 	}
-	if m.N != 0 {
-		n += 1 + sovPublic(uint64(m.N))
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovPublic(uint64(l))
 	}
-	if len(m.Pairs) > 0 {
-		for _, e := range m.Pairs {
+	l = len(m.Index)
+	if l > 0 {
+		n += 1 + l + sovPublic(uint64(l))
+	}
+	l = len(m.Field)
+	if l > 0 {
+		n += 1 + l + sovPublic(uint64(l))
+	}
+	return n
+}
+
+func (m *QueryProfile) Size() (n int) {
+	var l int
+	_ = l
+	if m.Parse != 0 {
+		n += 1 + sovPublic(uint64(m.Parse))
+	}
+	if m.Execute != 0 {
+		n += 1 + sovPublic(uint64(m.Execute))
+	}
+	if len(m.Shards) > 0 {
+		for _, e := range m.Shards {
 			l = e.Size()
 			n += 1 + l + sovPublic(uint64(l))
 		}
 	}
-	if m.Changed {
-		n += 2
+	if len(m.Nodes) > 0 {
+		for _, e := range m.Nodes {
+			l = e.Size()
+			n += 1 + l + sovPublic(uint64(l))
+		}
 	}
-	if m.ValCount != nil {
-		l = m.ValCount.Size()
+	if m.ContainersScanned != 0 {
+		n += 1 + sovPublic(uint64(m.ContainersScanned))
+	}
+	if m.CacheHits != 0 {
+		n += 1 + sovPublic(uint64(m.CacheHits))
+	}
+	return n
+}
+
+func (m *ShardProfile) Size() (n int) {
+	var l int
+	_ = l
+	if m.Shard != 0 {
+		n += 1 + sovPublic(uint64(m.Shard))
+	}
+	l = len(m.Node)
+	if l > 0 {
+		n += 1 + l + sovPublic(uint64(l))
+	}
+	if m.Execute != 0 {
+		n += 1 + sovPublic(uint64(m.Execute))
+	}
+	return n
+}
+
+func (m *NodeProfile) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Node)
+	if l > 0 {
+		n += 1 + l + sovPublic(uint64(l))
+	}
+	if m.Requests != 0 {
+		n += 1 + sovPublic(uint64(m.Requests))
+	}
+	if m.Network != 0 {
+		n += 1 + sovPublic(uint64(m.Network))
+	}
+	if m.Execute != 0 {
+		n += 1 + sovPublic(uint64(m.Execute))
+	}
+	return n
+}
+
+func (m *QueryResult) Size() (n int) {
+	var l int
+	_ = l
+	if m.Row != nil {
+		l = m.Row.Size()
+		n += 1 + l + sovPublic(uint64(l))
+	}
+	if m.N != 0 {
+		n += 1 + sovPublic(uint64(m.N))
+	}
+	if len(m.Pairs) > 0 {
+		for _, e := range m.Pairs {
+			l = e.Size()
+			n += 1 + l + sovPublic(uint64(l))
+		}
+	}
+	if m.Changed {
+		n += 2
+	}
+	if m.ValCount != nil {
+		l = m.ValCount.Size()
 		n += 1 + l + sovPublic(uint64(l))
 	}
 	if m.Type != 0 {
@@ -1957,6 +2537,10 @@ func (m *QueryResult) Size() (n int) {
 		l = m.RowIdentifiers.Size()
 		n += 1 + l + sovPublic(uint64(l))
 	}
+	if m.Error != nil {
+		l = m.Error.Size()
+		n += 1 + l + sovPublic(uint64(l))
+	}
 	return n
 }
 
@@ -3204,7 +3788,849 @@ func (m *AttrMap) Unmarshal(dAtA []byte) error {
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Attrs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Attrs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Attrs = append(m.Attrs, &Attr{})
+			if err := m.Attrs[len(m.Attrs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPublic(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueryRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPublic
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Query", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Query = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPublic
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Shards = append(m.Shards, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPublic
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthPublic
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPublic
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Shards = append(m.Shards, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Shards", wireType)
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ColumnAttrs", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ColumnAttrs = bool(v != 0)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Remote", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Remote = bool(v != 0)
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExcludeRowAttrs", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ExcludeRowAttrs = bool(v != 0)
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExcludeColumns", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ExcludeColumns = bool(v != 0)
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Priority = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Profile", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Profile = bool(v != 0)
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContinueOnError", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ContinueOnError = bool(v != 0)
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Affinity", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Affinity = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPublic(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueryResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPublic
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Err", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Err = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Results", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Results = append(m.Results, &QueryResult{})
+			if err := m.Results[len(m.Results)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ColumnAttrSets", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ColumnAttrSets = append(m.ColumnAttrSets, &ColumnAttrSet{})
+			if err := m.ColumnAttrSets[len(m.ColumnAttrSets)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Profile", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Profile == nil {
+				m.Profile = &QueryProfile{}
+			}
+			if err := m.Profile.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Error == nil {
+				m.Error = &ResponseError{}
+			}
+			if err := m.Error.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPublic(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseError) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPublic
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseError: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseError: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Code = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Message = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Index = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Field", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Field = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPublic(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueryProfile) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPublic
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryProfile: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryProfile: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Parse", wireType)
+			}
+			m.Parse = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Parse |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Execute", wireType)
+			}
+			m.Execute = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Execute |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Shards", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Shards = append(m.Shards, &ShardProfile{})
+			if err := m.Shards[len(m.Shards)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Nodes", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3228,11 +4654,49 @@ func (m *AttrMap) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Attrs = append(m.Attrs, &Attr{})
-			if err := m.Attrs[len(m.Attrs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Nodes = append(m.Nodes, &NodeProfile{})
+			if err := m.Nodes[len(m.Nodes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContainersScanned", wireType)
+			}
+			m.ContainersScanned = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ContainersScanned |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CacheHits", wireType)
+			}
+			m.CacheHits = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CacheHits |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPublic(dAtA[iNdEx:])
@@ -3254,7 +4718,7 @@ func (m *AttrMap) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *QueryRequest) Unmarshal(dAtA []byte) error {
+func (m *ShardProfile) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3277,108 +4741,17 @@ func (m *QueryRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: QueryRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ShardProfile: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: QueryRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ShardProfile: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Query", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPublic
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPublic
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Query = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
-			if wireType == 0 {
-				var v uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowPublic
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					v |= (uint64(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				m.Shards = append(m.Shards, v)
-			} else if wireType == 2 {
-				var packedLen int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowPublic
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					packedLen |= (int(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				if packedLen < 0 {
-					return ErrInvalidLengthPublic
-				}
-				postIndex := iNdEx + packedLen
-				if postIndex > l {
-					return io.ErrUnexpectedEOF
-				}
-				for iNdEx < postIndex {
-					var v uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowPublic
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						v |= (uint64(b) & 0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					m.Shards = append(m.Shards, v)
-				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field Shards", wireType)
-			}
-		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ColumnAttrs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Shard", wireType)
 			}
-			var v int
+			m.Shard = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPublic
@@ -3388,17 +4761,16 @@ func (m *QueryRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				m.Shard |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.ColumnAttrs = bool(v != 0)
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Remote", wireType)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Node", wireType)
 			}
-			var v int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPublic
@@ -3408,37 +4780,26 @@ func (m *QueryRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Remote = bool(v != 0)
-		case 6:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ExcludeRowAttrs", wireType)
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
 			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPublic
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
-			m.ExcludeRowAttrs = bool(v != 0)
-		case 7:
+			m.Node = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ExcludeColumns", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Execute", wireType)
 			}
-			var v int
+			m.Execute = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPublic
@@ -3448,12 +4809,11 @@ func (m *QueryRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				m.Execute |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.ExcludeColumns = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPublic(dAtA[iNdEx:])
@@ -3475,7 +4835,7 @@ func (m *QueryRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *QueryResponse) Unmarshal(dAtA []byte) error {
+func (m *NodeProfile) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3498,15 +4858,15 @@ func (m *QueryResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: QueryResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: NodeProfile: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: QueryResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: NodeProfile: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Err", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Node", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3531,13 +4891,13 @@ func (m *QueryResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Err = string(dAtA[iNdEx:postIndex])
+			m.Node = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Results", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Requests", wireType)
 			}
-			var msglen int
+			m.Requests = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPublic
@@ -3547,28 +4907,16 @@ func (m *QueryResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.Requests |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthPublic
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Results = append(m.Results, &QueryResult{})
-			if err := m.Results[len(m.Results)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ColumnAttrSets", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Network", wireType)
 			}
-			var msglen int
+			m.Network = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPublic
@@ -3578,23 +4926,30 @@ func (m *QueryResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.Network |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthPublic
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Execute", wireType)
 			}
-			m.ColumnAttrSets = append(m.ColumnAttrSets, &ColumnAttrSet{})
-			if err := m.ColumnAttrSets[len(m.ColumnAttrSets)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Execute = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Execute |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPublic(dAtA[iNdEx:])
@@ -3926,6 +5281,39 @@ func (m *QueryResult) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Error == nil {
+				m.Error = &ResponseError{}
+			}
+			if err := m.Error.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPublic(dAtA[iNdEx:])
@@ -5166,60 +6554,77 @@ var (
 func init() { proto.RegisterFile("public.proto", fileDescriptorPublic) }
 
 var fileDescriptorPublic = []byte{
-	// 880 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xcd, 0x8e, 0x1b, 0x45,
-	0x10, 0xa6, 0x3d, 0x63, 0x7b, 0x5c, 0x5e, 0x9b, 0xa8, 0xe5, 0x84, 0x11, 0x8a, 0x8c, 0x35, 0x42,
-	0x68, 0xb8, 0x6c, 0x24, 0x23, 0xa1, 0x9c, 0xf8, 0xd9, 0x78, 0x83, 0xac, 0xc0, 0x0a, 0x6a, 0x57,
-	0x46, 0x1c, 0x3b, 0x71, 0x27, 0x19, 0x69, 0x3c, 0x6d, 0x66, 0x7a, 0x70, 0xf6, 0x39, 0xb8, 0xf0,
-	0x08, 0x1c, 0x78, 0x90, 0x1c, 0x11, 0x4f, 0x00, 0xcb, 0x8b, 0xa0, 0xae, 0x9e, 0xde, 0x1e, 0x7b,
-	0x97, 0x08, 0xa1, 0xdc, 0xea, 0xab, 0xea, 0xaa, 0xa9, 0xaf, 0xfe, 0x6c, 0x38, 0xda, 0xd6, 0x4f,
-	0xf3, 0xec, 0xd9, 0xf1, 0xb6, 0x54, 0x5a, 0xf1, 0x28, 0x2b, 0xb4, 0x2c, 0x0b, 0x91, 0x27, 0x3f,
-	0x40, 0x80, 0x6a, 0xc7, 0x63, 0xe8, 0x3f, 0x52, 0x79, 0xbd, 0x29, 0xaa, 0x98, 0xcd, 0x82, 0x34,
-	0x44, 0x07, 0xf9, 0x87, 0xd0, 0xfd, 0x52, 0xeb, 0xb2, 0x8a, 0x3b, 0xb3, 0x20, 0x1d, 0xce, 0xc7,
-	0xc7, 0xce, 0xf5, 0xd8, 0xa8, 0xd1, 0x1a, 0x39, 0x87, 0xf0, 0x89, 0xbc, 0xac, 0xe2, 0x60, 0x16,
-	0xa4, 0x03, 0x24, 0x39, 0x79, 0x08, 0x63, 0x54, 0xbb, 0xe5, 0x5a, 0x16, 0x3a, 0x7b, 0x9e, 0x49,
-	0xfb, 0x0a, 0xd5, 0xce, 0x7d, 0x82, 0xe4, 0x6b, 0xcf, 0x4e, 0xcb, 0xf3, 0x33, 0x08, 0xbf, 0x15,
-	0x59, 0xc9, 0xc7, 0xd0, 0x59, 0x2e, 0x62, 0x36, 0x63, 0x69, 0x88, 0x9d, 0xe5, 0x82, 0x4f, 0xa0,
-	0xfb, 0x48, 0xd5, 0x85, 0x8e, 0x3b, 0xa4, 0xb2, 0x80, 0xdf, 0x81, 0xe0, 0x89, 0xbc, 0x8c, 0x83,
-	0x19, 0x4b, 0x07, 0x68, 0xc4, 0xe4, 0x0c, 0xa2, 0xc7, 0x99, 0xcc, 0xd7, 0x86, 0xd9, 0x04, 0xba,
-	0x24, 0x53, 0x98, 0x01, 0x5a, 0x60, 0xb4, 0x26, 0xb7, 0x85, 0x8b, 0x44, 0x80, 0xdf, 0x83, 0x1e,
-	0xaa, 0x9d, 0x0f, 0xd6, 0xa0, 0xe4, 0x6b, 0x80, 0xaf, 0x4a, 0x55, 0x6f, 0xed, 0xf7, 0x52, 0xe8,
-	0x12, 0x22, 0x1a, 0xc3, 0x39, 0xf7, 0x15, 0x71, 0x1f, 0x45, 0xfb, 0xe0, 0xf6, 0x7c, 0x93, 0x39,
-	0x44, 0x2b, 0x91, 0x5f, 0xe7, 0xbe, 0x12, 0x39, 0xe5, 0x16, 0xa0, 0x11, 0xf7, 0x7d, 0x02, 0xe7,
-	0xf3, 0x3d, 0x8c, 0x6c, 0x43, 0x4c, 0xb9, 0xcf, 0xa5, 0xbe, 0x51, 0x9a, 0xff, 0xd6, 0xa6, 0x9b,
-	0xa5, 0xfa, 0x95, 0x41, 0x68, 0x6c, 0xce, 0xc4, 0xae, 0x4d, 0xa6, 0x33, 0x17, 0x97, 0x5b, 0xd9,
-	0x24, 0x4f, 0x32, 0x9f, 0xc1, 0xf0, 0x5c, 0x97, 0x59, 0xf1, 0x62, 0x25, 0xf2, 0x5a, 0x36, 0x81,
-	0xda, 0x2a, 0xfe, 0x3e, 0x44, 0xcb, 0x42, 0x5b, 0x73, 0x48, 0x14, 0xae, 0x31, 0xbf, 0x0f, 0x83,
-	0x13, 0xa5, 0x72, 0x6b, 0xec, 0xce, 0x58, 0x1a, 0xa1, 0x57, 0xf0, 0x29, 0xc0, 0xe3, 0x5c, 0x89,
-	0xc6, 0xb7, 0x37, 0x63, 0x29, 0xc3, 0x96, 0x26, 0x79, 0x00, 0x7d, 0x93, 0xe9, 0x37, 0x62, 0xeb,
-	0xd9, 0xb2, 0x37, 0xb0, 0x4d, 0x5e, 0x33, 0x38, 0xfa, 0xae, 0x96, 0xe5, 0x25, 0xca, 0x1f, 0x6b,
-	0x59, 0x69, 0x53, 0x5b, 0xc2, 0x6e, 0x16, 0x08, 0x98, 0xae, 0x9f, 0xbf, 0x14, 0xe5, 0xda, 0xd6,
-	0x2e, 0xc4, 0x06, 0x19, 0xae, 0xbe, 0xe6, 0x15, 0x71, 0x8d, 0xb0, 0xad, 0xa2, 0x79, 0x91, 0x1b,
-	0xa5, 0x1d, 0x99, 0x06, 0xf1, 0x14, 0xde, 0x3d, 0x7d, 0xf5, 0x2c, 0xaf, 0xd7, 0x12, 0xd5, 0xce,
-	0x7a, 0xf7, 0xe8, 0xc1, 0xa1, 0x9a, 0x7f, 0x04, 0xe3, 0x46, 0xe5, 0xd6, 0xaf, 0x4f, 0x0f, 0x0f,
-	0xb4, 0xc9, 0xcf, 0x0c, 0x46, 0x0d, 0x95, 0x6a, 0xab, 0x8a, 0x4a, 0x9a, 0x7e, 0x9d, 0x96, 0xa5,
-	0xeb, 0xd7, 0x69, 0x59, 0xf2, 0x07, 0xd0, 0x47, 0x59, 0xd5, 0xb9, 0x76, 0x43, 0x70, 0xd7, 0x97,
-	0xc5, 0xf9, 0xd6, 0xb9, 0x46, 0xf7, 0x8a, 0x7f, 0x0e, 0xe3, 0xbd, 0xa1, 0xb2, 0xeb, 0x3b, 0x9c,
-	0xbf, 0xe7, 0xfd, 0xf6, 0xec, 0x78, 0xf0, 0x3c, 0xf9, 0xa3, 0x03, 0xc3, 0x56, 0x64, 0xfe, 0x01,
-	0x1d, 0x13, 0xca, 0x69, 0x38, 0x1f, 0xf9, 0x28, 0x66, 0x25, 0xe8, 0xcc, 0x1c, 0x01, 0x3b, 0x6b,
-	0xe6, 0x89, 0x9d, 0x99, 0x2e, 0x9a, 0x35, 0x77, 0x9f, 0x6d, 0x75, 0xd1, 0xa8, 0xd1, 0x1a, 0xe9,
-	0x34, 0xbd, 0x14, 0xc5, 0x0b, 0xb9, 0xa6, 0x79, 0x8a, 0xd0, 0x41, 0x7e, 0xec, 0x17, 0x89, 0x1a,
-	0xb0, 0xb7, 0x8b, 0xce, 0x82, 0x7e, 0xd9, 0xdc, 0x40, 0x9b, 0x5e, 0x8c, 0x9a, 0x81, 0xb6, 0x2b,
-	0xbf, 0x5c, 0x98, 0xc2, 0x53, 0xf3, 0x2d, 0xe2, 0x9f, 0xc2, 0xd0, 0xaf, 0x7c, 0x15, 0x47, 0x94,
-	0xe1, 0xc4, 0x87, 0xf7, 0x46, 0x6c, 0x3f, 0xe4, 0x5f, 0x1c, 0x1e, 0xbd, 0x78, 0x40, 0x99, 0xc5,
-	0x7b, 0xd5, 0x68, 0xd9, 0xf1, 0xe0, 0x7d, 0xf2, 0x17, 0x83, 0xd1, 0x72, 0xb3, 0x55, 0xa5, 0x6e,
-	0x8d, 0xed, 0xb2, 0x58, 0xcb, 0x57, 0x6e, 0x6c, 0x09, 0xf8, 0xc3, 0xd6, 0x39, 0x38, 0x6c, 0x34,
-	0xbe, 0x34, 0xae, 0x21, 0x5a, 0xd0, 0x62, 0x19, 0xee, 0xb1, 0xbc, 0x0f, 0x03, 0xdb, 0x52, 0x63,
-	0xea, 0x92, 0xc9, 0x2b, 0xcc, 0x42, 0x5e, 0x64, 0x1b, 0x59, 0x69, 0xb1, 0xd9, 0x9a, 0x09, 0x0e,
-	0xd2, 0x00, 0x5b, 0x1a, 0xd3, 0x19, 0x7b, 0x20, 0x6d, 0xf1, 0x06, 0xe8, 0xa0, 0xf1, 0xb4, 0x61,
-	0xc8, 0x18, 0x91, 0xb1, 0xa5, 0x49, 0x7e, 0x63, 0xc0, 0x2d, 0x47, 0x5a, 0xed, 0xb7, 0x47, 0xf4,
-	0xcd, 0x84, 0xee, 0x41, 0x8f, 0xbe, 0xe7, 0xc8, 0x34, 0xe8, 0x20, 0xdd, 0xfe, 0x8d, 0x74, 0x57,
-	0x30, 0xb9, 0x28, 0x45, 0x51, 0xe5, 0x42, 0x4b, 0xa3, 0xf8, 0x3f, 0xf9, 0xde, 0xf6, 0x0b, 0xf9,
-	0x31, 0xdc, 0x3d, 0x88, 0xeb, 0x97, 0xdb, 0x10, 0x08, 0x88, 0x80, 0x11, 0x93, 0x13, 0x88, 0x9b,
-	0xa1, 0x50, 0xc2, 0x1c, 0xdb, 0x26, 0x85, 0x55, 0x26, 0x77, 0x26, 0xf4, 0x99, 0xd8, 0xc8, 0x26,
-	0x0b, 0x92, 0x8d, 0x6e, 0x21, 0xb4, 0xa0, 0x1c, 0x8e, 0x90, 0xe4, 0xe4, 0x39, 0x4c, 0x6e, 0x8b,
-	0x41, 0x3f, 0x39, 0xb9, 0x14, 0xf6, 0x98, 0x44, 0x68, 0x01, 0x7f, 0x08, 0xdd, 0x9f, 0x32, 0xb9,
-	0x73, 0xc7, 0x24, 0xf1, 0x03, 0xfc, 0x6f, 0x89, 0xa0, 0x75, 0x38, 0xb9, 0xf3, 0xfa, 0x6a, 0xca,
-	0x7e, 0xbf, 0x9a, 0xb2, 0x3f, 0xaf, 0xa6, 0xec, 0x97, 0xbf, 0xa7, 0xef, 0x3c, 0xed, 0xd1, 0xdf,
-	0x8e, 0x4f, 0xfe, 0x09, 0x00, 0x00, 0xff, 0xff, 0x9f, 0x87, 0xba, 0x9b, 0x86, 0x08, 0x00, 0x00,
+	// 1147 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xad, 0x56, 0xcd, 0x6e, 0x1c, 0x45,
+	0x10, 0x66, 0x3c, 0xbb, 0xf6, 0x6e, 0x8d, 0xbd, 0x84, 0x96, 0x13, 0x56, 0x08, 0x99, 0x68, 0x84,
+	0xa2, 0xa0, 0x04, 0x83, 0x16, 0x09, 0xe5, 0x04, 0xc4, 0x6b, 0x07, 0x56, 0x90, 0xc5, 0xb4, 0x2d,
+	0x23, 0x8e, 0x13, 0x6f, 0xaf, 0x3d, 0x62, 0x76, 0x66, 0x33, 0x3f, 0xd8, 0x3e, 0xf2, 0x16, 0x3c,
+	0x02, 0x07, 0x1e, 0x83, 0x03, 0x47, 0x1e, 0x81, 0x9f, 0x03, 0x2f, 0xc0, 0x03, 0x50, 0x55, 0xdd,
+	0x3d, 0xd3, 0x3b, 0x36, 0x51, 0x84, 0x38, 0x8c, 0x54, 0x5f, 0xfd, 0x75, 0x55, 0x75, 0x75, 0xd5,
+	0xc0, 0xe6, 0xb2, 0x7a, 0x96, 0xc4, 0xa7, 0xbb, 0xcb, 0x3c, 0x2b, 0x33, 0xd1, 0x8b, 0xd3, 0x52,
+	0xe5, 0x69, 0x94, 0x84, 0xdf, 0x80, 0x2f, 0xb3, 0x0b, 0x31, 0x84, 0x8d, 0x71, 0x96, 0x54, 0x8b,
+	0xb4, 0x18, 0x7a, 0x77, 0xfd, 0xfb, 0x1d, 0x69, 0xa1, 0x78, 0x1b, 0xba, 0x8f, 0xcb, 0x32, 0x2f,
+	0x86, 0x6b, 0xc8, 0x0f, 0x46, 0x83, 0x5d, 0x6b, 0xba, 0x4b, 0x6c, 0xa9, 0x85, 0x42, 0x40, 0xe7,
+	0x73, 0x75, 0x55, 0x0c, 0x7d, 0x54, 0xea, 0x4b, 0xa6, 0xc3, 0x47, 0x30, 0x40, 0xd7, 0x93, 0x99,
+	0x4a, 0xcb, 0x78, 0x1e, 0x2b, 0xad, 0x85, 0x1c, 0x7b, 0x04, 0xd3, 0xb5, 0xe5, 0x9a, 0x63, 0xf9,
+	0x11, 0x74, 0x0e, 0xa3, 0x38, 0x17, 0x03, 0x58, 0x9b, 0xec, 0xa3, 0xb6, 0x87, 0xda, 0x48, 0x89,
+	0x6d, 0xe8, 0x8e, 0xb3, 0x2a, 0x2d, 0x51, 0x99, 0x58, 0x1a, 0x88, 0x5b, 0xe0, 0xa3, 0x15, 0x1e,
+	0xed, 0xa1, 0x03, 0x22, 0xc3, 0x29, 0xf4, 0x9e, 0xc4, 0x2a, 0x99, 0x51, 0x66, 0x68, 0xc3, 0x34,
+	0xbb, 0xe9, 0x4b, 0x0d, 0x88, 0x4b, 0xb1, 0xed, 0x5b, 0x4f, 0x0c, 0xc4, 0x1d, 0x58, 0x47, 0xa2,
+	0x71, 0x66, 0x50, 0xf8, 0x05, 0xc0, 0xa7, 0x79, 0x56, 0x2d, 0xf5, 0x79, 0xf7, 0xa1, 0xcb, 0x88,
+	0xd3, 0x08, 0x46, 0xa2, 0xa9, 0x88, 0x3d, 0x54, 0x6a, 0x85, 0x9b, 0xe3, 0x0d, 0x47, 0xd0, 0x3b,
+	0x89, 0x92, 0x3a, 0x76, 0xa4, 0x39, 0x36, 0x5f, 0x12, 0xb9, 0x6a, 0xe3, 0x5b, 0x9b, 0xaf, 0x61,
+	0x4b, 0x5f, 0x08, 0x95, 0xfb, 0x48, 0x95, 0xd7, 0x4a, 0xf3, 0x72, 0xd7, 0x74, 0xbd, 0x54, 0x3f,
+	0x7a, 0xd0, 0x21, 0x99, 0x15, 0x79, 0xb5, 0x88, 0x6e, 0xe6, 0xf8, 0x6a, 0xa9, 0x4c, 0xf0, 0x4c,
+	0x8b, 0xbb, 0x10, 0x1c, 0x95, 0x79, 0x9c, 0x9e, 0x61, 0xa8, 0x95, 0x32, 0x8e, 0x5c, 0x96, 0x78,
+	0x03, 0x7a, 0x93, 0xb4, 0xd4, 0xe2, 0x0e, 0xa7, 0x50, 0x63, 0xf1, 0x26, 0xf4, 0xf7, 0xb2, 0x2c,
+	0xd1, 0xc2, 0x2e, 0x0a, 0x7b, 0xb2, 0x61, 0x88, 0x1d, 0x80, 0x27, 0x49, 0x16, 0x19, 0xdb, 0x75,
+	0x14, 0x7b, 0xd2, 0xe1, 0x84, 0xef, 0xc1, 0x06, 0x45, 0xfa, 0x34, 0x5a, 0x36, 0xd9, 0x7a, 0x2f,
+	0xc8, 0x36, 0xfc, 0x79, 0x0d, 0x36, 0xbf, 0xaa, 0x54, 0x7e, 0x25, 0xd5, 0xf3, 0x4a, 0x15, 0x25,
+	0xd5, 0x96, 0xb1, 0xed, 0x05, 0x06, 0x74, 0xeb, 0x47, 0xe7, 0x51, 0x3e, 0xd3, 0xb5, 0xeb, 0x48,
+	0x83, 0x28, 0xd7, 0xa6, 0xe6, 0x05, 0xe7, 0xda, 0x93, 0x2e, 0x8b, 0xfb, 0x45, 0x2d, 0xb2, 0xd2,
+	0x26, 0x63, 0x10, 0x76, 0xc8, 0xab, 0x07, 0x97, 0xa7, 0x49, 0x35, 0x53, 0xd8, 0x0c, 0xda, 0x7a,
+	0x9d, 0x15, 0xda, 0x6c, 0x71, 0x0f, 0x06, 0x86, 0x65, 0x9f, 0xdf, 0x06, 0x2b, 0xb6, 0xb8, 0x54,
+	0xd5, 0xc3, 0x3c, 0xce, 0xf2, 0xb8, 0xbc, 0x1a, 0xf6, 0x38, 0xf8, 0x1a, 0xd3, 0xdb, 0x3d, 0xcc,
+	0xb3, 0x79, 0x9c, 0xa8, 0x61, 0x9f, 0x8d, 0x2d, 0xa4, 0x38, 0xc6, 0x19, 0xbe, 0xbe, 0xb4, 0x52,
+	0x5f, 0xa6, 0x07, 0x79, 0x9e, 0xe5, 0x43, 0xd0, 0x71, 0xb4, 0xd8, 0xe4, 0xff, 0xf1, 0x7c, 0x1e,
+	0xa7, 0xe4, 0x3f, 0xd0, 0xfe, 0x2d, 0x0e, 0xff, 0xf6, 0x60, 0xcb, 0x94, 0xb1, 0x58, 0x66, 0x69,
+	0xa1, 0xa8, 0x57, 0xd0, 0xcc, 0xf6, 0x0a, 0x92, 0x02, 0xef, 0x06, 0xa5, 0x55, 0x52, 0xda, 0x06,
+	0xbc, 0xdd, 0x5c, 0x89, 0xb5, 0x45, 0xa9, 0xb4, 0x5a, 0xe2, 0x63, 0x18, 0xac, 0x34, 0xb4, 0x1e,
+	0x1d, 0xc1, 0xe8, 0xf5, 0xc6, 0x6e, 0x45, 0x2e, 0x5b, 0xea, 0xe2, 0xfd, 0x26, 0x6b, 0x6a, 0xb3,
+	0x60, 0x74, 0xa7, 0x75, 0xa2, 0x91, 0x36, 0xd5, 0x78, 0x17, 0xba, 0xba, 0x06, 0x5d, 0xd6, 0x77,
+	0x4e, 0xb2, 0x89, 0xb1, 0x58, 0x6a, 0xad, 0x30, 0x86, 0xad, 0x15, 0x3e, 0xbd, 0x87, 0x71, 0x36,
+	0x53, 0x26, 0x6d, 0xa6, 0xa9, 0xf6, 0x4f, 0x55, 0x51, 0x44, 0x67, 0xfa, 0x99, 0xf4, 0xa5, 0x85,
+	0xd4, 0x6b, 0x93, 0x74, 0xa6, 0x2e, 0xcd, 0x1b, 0xd1, 0xa0, 0x99, 0x46, 0x1d, 0x67, 0x1a, 0x85,
+	0x7f, 0x79, 0xa6, 0x51, 0x6d, 0xa8, 0xa8, 0x76, 0x18, 0xe5, 0x85, 0x32, 0x83, 0x41, 0x03, 0x3a,
+	0xec, 0xe0, 0x52, 0x9d, 0x56, 0xa5, 0x32, 0xc3, 0xc1, 0x42, 0xb1, 0x5b, 0xb7, 0xb0, 0xae, 0xa2,
+	0x53, 0x0b, 0xe6, 0xdb, 0x5a, 0xd8, 0xd6, 0x7e, 0x00, 0xdd, 0x29, 0x86, 0x5f, 0x60, 0x18, 0xad,
+	0xcb, 0x22, 0xb6, 0xd5, 0xd6, 0x3a, 0xe2, 0x21, 0xbc, 0x46, 0xed, 0x12, 0xc5, 0x29, 0xce, 0xf0,
+	0xa3, 0xd3, 0x28, 0x4d, 0xd5, 0x8c, 0x6b, 0xe8, 0xcb, 0xeb, 0x02, 0x7a, 0xe3, 0xe3, 0xe8, 0xf4,
+	0x5c, 0x7d, 0x16, 0x97, 0xba, 0xeb, 0x7d, 0xd9, 0x30, 0x42, 0x09, 0x9b, 0x6e, 0x40, 0x94, 0x28,
+	0x63, 0x33, 0xc9, 0x34, 0xa0, 0x4a, 0xd3, 0xd1, 0xa6, 0xa4, 0x4c, 0xbb, 0xc9, 0xfb, 0x2b, 0xc9,
+	0x87, 0xcf, 0x21, 0x70, 0xa2, 0xae, 0x8d, 0x3d, 0xc7, 0x18, 0xdb, 0xdb, 0xcc, 0x80, 0xc2, 0x94,
+	0xae, 0xc6, 0xe4, 0x78, 0xaa, 0xca, 0x8b, 0x2c, 0xff, 0xd6, 0x3a, 0x36, 0xd0, 0x3d, 0xb2, 0xb3,
+	0x7a, 0xe4, 0xf7, 0x3e, 0x04, 0x4e, 0x5b, 0x8b, 0xb7, 0x78, 0x8b, 0xf2, 0x91, 0xc1, 0x68, 0xcb,
+	0x69, 0x2c, 0xdc, 0x05, 0xbc, 0x5f, 0x37, 0xc1, 0x9b, 0x9a, 0x41, 0xea, 0x4d, 0x69, 0x7c, 0xd1,
+	0x7e, 0xb3, 0xb7, 0xe5, 0x8c, 0x2f, 0x62, 0x4b, 0x2d, 0xe4, 0x9d, 0x7c, 0x1e, 0xa5, 0x67, 0x4a,
+	0x77, 0x0b, 0xbe, 0x6b, 0x03, 0xf1, 0xba, 0xeb, 0x0d, 0x62, 0x9a, 0xd9, 0x59, 0x42, 0x56, 0x22,
+	0x9b, 0x2d, 0x63, 0x27, 0x39, 0x5d, 0xc7, 0x96, 0x99, 0xe4, 0x7a, 0xd7, 0x4d, 0xf6, 0x69, 0xe2,
+	0xf0, 0xd4, 0xd3, 0x48, 0x7c, 0x08, 0x41, 0xb3, 0xeb, 0x0a, 0x1c, 0x36, 0x14, 0xe1, 0x76, 0xe3,
+	0xbe, 0x11, 0x4a, 0x57, 0x51, 0x7c, 0xd2, 0xde, 0xf6, 0x3c, 0x8c, 0x82, 0xd1, 0x70, 0xa5, 0x1a,
+	0x8e, 0x5c, 0xb6, 0xff, 0x0e, 0xea, 0xf7, 0x09, 0x2f, 0xf5, 0x3e, 0x7f, 0xc7, 0xb1, 0x34, 0x59,
+	0x2c, 0xb3, 0xbc, 0x74, 0xc6, 0xbb, 0x7e, 0x72, 0xde, 0x8d, 0x4f, 0x6e, 0xad, 0xf5, 0x03, 0xa0,
+	0x1b, 0xcf, 0x77, 0x1b, 0xaf, 0x29, 0x4a, 0x67, 0xa5, 0x28, 0xd4, 0xd4, 0x3c, 0x7e, 0x48, 0xd4,
+	0x65, 0x51, 0xc3, 0xa0, 0xc5, 0x75, 0x1c, 0x2f, 0x30, 0x82, 0x68, 0xb1, 0xa4, 0x9e, 0xf7, 0xb1,
+	0x55, 0x1c, 0x0e, 0x5d, 0xa4, 0xfe, 0x91, 0xd0, 0xb5, 0xc6, 0x21, 0x61, 0x20, 0x59, 0x6a, 0x37,
+	0x2c, 0xec, 0xb1, 0xd0, 0xe1, 0x84, 0x3f, 0x79, 0x20, 0x74, 0x8e, 0xbc, 0x02, 0xff, 0xbf, 0x44,
+	0x5f, 0x9c, 0x10, 0x96, 0x81, 0xcf, 0xb3, 0xc9, 0x18, 0xd4, 0x0a, 0x77, 0xe3, 0x5a, 0xb8, 0x27,
+	0xb0, 0x7d, 0x9c, 0x47, 0x69, 0x91, 0x44, 0xa5, 0x22, 0xc6, 0x7f, 0x89, 0xf7, 0xa6, 0x3f, 0xc9,
+	0x77, 0xe0, 0x76, 0xcb, 0x6f, 0xb3, 0x88, 0x28, 0x01, 0x9f, 0x13, 0x20, 0x32, 0xdc, 0x83, 0xa1,
+	0x69, 0x8a, 0x2c, 0xa2, 0x9f, 0x12, 0x13, 0xc2, 0x49, 0xac, 0x2e, 0x78, 0x32, 0x44, 0x8b, 0x66,
+	0x32, 0x20, 0x4d, 0xbc, 0xfd, 0xa8, 0x8c, 0x38, 0x86, 0x4d, 0xc9, 0x74, 0x38, 0x87, 0xed, 0x9b,
+	0x7c, 0xf0, 0xaf, 0x59, 0xa2, 0x22, 0xbd, 0xf8, 0x7a, 0x52, 0x03, 0xf1, 0x08, 0xba, 0xdf, 0xa1,
+	0x77, 0xbb, 0xf8, 0xc2, 0xa6, 0x6d, 0xff, 0x2d, 0x10, 0xa9, 0x0d, 0xf6, 0x6e, 0xfd, 0xf2, 0xc7,
+	0x8e, 0xf7, 0x2b, 0x7e, 0xbf, 0xe1, 0xf7, 0xc3, 0x9f, 0x3b, 0xaf, 0x3c, 0x5b, 0xe7, 0xdf, 0xf3,
+	0x0f, 0xfe, 0x01, 0x16, 0x2a, 0xf5, 0x12, 0xae, 0x0b, 0x00, 0x00,
 }
@@ -0,0 +1,187 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"github.com/pilosa/pilosa/v2/roaring"
+	"github.com/pkg/errors"
+)
+
+// The inverse view of a field holds the bits of its standard view with rows
+// and columns exchanged, so that the rows of a column are read as a single
+// row rather than by scanning every row of the field.
+//
+// A bit is kept in the same shard of the inverse view as its column, so the
+// inverse view is owned, replicated, and resized along with the rest of the
+// shard. Within a shard the low shardwidth.Exponent bits of the row and
+// column IDs are exchanged: the row ID's offset within a shard becomes the
+// column, and the column's offset within its shard replaces the low bits of
+// the row ID. Fields with fewer than ShardWidth rows therefore keep their
+// inverse in the first ShardWidth rows of the view. The mapping is its own
+// inverse.
+
+// shardOffsetMask masks the offset of an ID within a shard.
+const shardOffsetMask = ShardWidth - 1
+
+// inverseBit returns the position in the inverse view of the bit at rowID
+// and columnID, or the position in the standard view of a bit of the inverse
+// view.
+func inverseBit(rowID, columnID uint64) (uint64, uint64) {
+	return rowID&^shardOffsetMask | columnID&shardOffsetMask, columnID&^shardOffsetMask | rowID&shardOffsetMask
+}
+
+// setInverseBit sets the inverse of a bit set in the standard view. For a
+// mutex field, the column's other rows are cleared.
+func (f *Field) setInverseBit(rowID, columnID uint64) error {
+	view, err := f.createViewIfNotExists(viewInverse)
+	if err != nil {
+		return errors.Wrap(err, "creating inverse view")
+	}
+
+	if f.Type() == FieldTypeMutex {
+		for _, existing := range f.inverseRows(columnID) {
+			if existing == rowID {
+				continue
+			}
+			if _, err := view.clearBit(inverseBit(existing, columnID)); err != nil {
+				return errors.Wrap(err, "clearing on inverse view")
+			}
+		}
+	}
+
+	invRowID, invColumnID := inverseBit(rowID, columnID)
+	_, err = view.setBit(invRowID, invColumnID)
+	return errors.Wrap(err, "setting on inverse view")
+}
+
+// clearInverseBit clears the inverse of a bit cleared in the standard view.
+func (f *Field) clearInverseBit(rowID, columnID uint64) error {
+	view := f.view(viewInverse)
+	if view == nil {
+		return nil
+	}
+	invRowID, invColumnID := inverseBit(rowID, columnID)
+	_, err := view.clearBit(invRowID, invColumnID)
+	return errors.Wrap(err, "clearing on inverse view")
+}
+
+// importInverse imports the inverse of bits imported into one shard of the
+// standard view.
+func (f *Field) importInverse(shard uint64, rowIDs, columnIDs []uint64, options *ImportOptions) error {
+	invRowIDs := make([]uint64, len(rowIDs))
+	invColumnIDs := make([]uint64, len(columnIDs))
+	for i := range rowIDs {
+		invRowIDs[i], invColumnIDs[i] = inverseBit(rowIDs[i], columnIDs[i])
+	}
+
+	view, err := f.createViewIfNotExists(viewInverse)
+	if err != nil {
+		return errors.Wrap(err, "creating inverse view")
+	}
+	frag, err := view.CreateFragmentIfNotExists(shard)
+	if err != nil {
+		return errors.Wrap(err, "creating inverse fragment")
+	}
+	return errors.Wrap(frag.bulkImport(invRowIDs, invColumnIDs, options), "importing inverse")
+}
+
+// rebuildInverse brings one shard of the inverse view up to date with the
+// standard view. It is used after writes which change whole rows, whose
+// inverse is scattered over many rows of the inverse view, and costs a pass
+// over the shard.
+func (f *Field) rebuildInverse(shard uint64) error {
+	if !f.options.Inverse {
+		return nil
+	}
+
+	want := roaring.NewBitmap()
+	if view := f.view(viewStandard); view != nil {
+		if frag := view.Fragment(shard); frag != nil {
+			if err := frag.forEachBit(func(rowID, columnID uint64) error {
+				want.DirectAdd(pos(inverseBit(rowID, columnID)))
+				return nil
+			}); err != nil {
+				return errors.Wrap(err, "reading standard view")
+			}
+		}
+	}
+
+	view, err := f.createViewIfNotExists(viewInverse)
+	if err != nil {
+		return errors.Wrap(err, "creating inverse view")
+	}
+	frag, err := view.CreateFragmentIfNotExists(shard)
+	if err != nil {
+		return errors.Wrap(err, "creating inverse fragment")
+	}
+	have := roaring.NewBitmap()
+	if err := frag.forEachBit(func(rowID, columnID uint64) error {
+		have.DirectAdd(pos(rowID, columnID))
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "reading inverse view")
+	}
+
+	// Clear the bits no longer in the standard view, then set the new ones.
+	for _, diff := range []struct {
+		positions *roaring.Bitmap
+		clear     bool
+	}{
+		{have.Difference(want), true},
+		{want.Difference(have), false},
+	} {
+		var rowIDs, columnIDs []uint64
+		diff.positions.ForEach(func(p uint64) {
+			rowIDs = append(rowIDs, p/ShardWidth)
+			columnIDs = append(columnIDs, shard*ShardWidth+p%ShardWidth)
+		})
+		if len(rowIDs) == 0 {
+			continue
+		}
+		if err := frag.bulkImport(rowIDs, columnIDs, &ImportOptions{Clear: diff.clear}); err != nil {
+			return errors.Wrap(err, "importing inverse")
+		}
+	}
+	return nil
+}
+
+// inverseRows returns the rows of the standard view in which columnID is
+// set, read from the inverse view.
+func (f *Field) inverseRows(columnID uint64) []uint64 {
+	view := f.view(viewInverse)
+	if view == nil {
+		return nil
+	}
+	frag := view.Fragment(columnID / ShardWidth)
+	if frag == nil {
+		return nil
+	}
+
+	// maxRowID is not maintained by imports, so read the storage's.
+	frag.mu.RLock()
+	maxRowID := frag.storage.Max() / ShardWidth
+	frag.mu.RUnlock()
+
+	// Each block of ShardWidth rows of the inverse view holds the column's
+	// rows from one block of ShardWidth rows of the standard view.
+	var rowIDs []uint64
+	for base := uint64(0); base <= maxRowID; base += ShardWidth {
+		for _, invColumnID := range frag.row(base | columnID&shardOffsetMask).Columns() {
+			rowID, _ := inverseBit(base|columnID&shardOffsetMask, invColumnID)
+			rowIDs = append(rowIDs, rowID)
+		}
+	}
+	return rowIDs
+}
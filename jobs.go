@@ -0,0 +1,407 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pilosa/pilosa/v2/logger"
+	"github.com/pkg/errors"
+)
+
+// Background job states.
+const (
+	JobStateRunning  = "RUNNING"
+	JobStateDone     = "DONE"
+	JobStateCanceled = "CANCELED"
+	JobStateFailed   = "FAILED"
+)
+
+// Background job types.
+const (
+	// JobTypeReindex is the type of jobs which rebuild a field's derived data.
+	JobTypeReindex = "reindex"
+
+	// JobTypeImport is the type of jobs which import data in batches.
+	JobTypeImport = "import"
+
+	// JobTypeQuery is the type of jobs which execute a query asynchronously.
+	JobTypeQuery = "query"
+)
+
+// maxFinishedJobs is the number of finished jobs whose status is retained.
+const maxFinishedJobs = 100
+
+// jobJournalFile is the file in the data directory which records the status
+// of background jobs, so that it is still available after a restart.
+const jobJournalFile = ".jobs"
+
+// Background job errors.
+var (
+	ErrJobNotFound    = errors.New("job not found")
+	ErrJobInterrupted = errors.New("job interrupted by restart")
+)
+
+// JobStatus describes the progress of a background job on a node.
+type JobStatus struct {
+	ID       int64      `json:"id"`
+	Type     string     `json:"type"`
+	Index    string     `json:"index"`
+	Field    string     `json:"field"`
+	State    string     `json:"state"`
+	Done     int        `json:"done"`
+	Total    int        `json:"total"`
+	Bytes    int64      `json:"bytes,omitempty"`
+	Error    string     `json:"error,omitempty"`
+	Started  time.Time  `json:"started"`
+	ETA      *time.Time `json:"eta,omitempty"`
+	Finished *time.Time `json:"finished,omitempty"`
+}
+
+// jobRegistry tracks the background jobs running on a node, along with the
+// most recently finished ones. If it has a journal, the status of each job is
+// written there when the job starts and finishes.
+type jobRegistry struct {
+	mu   sync.Mutex
+	wg   sync.WaitGroup
+	seq  int64
+	jobs map[int64]*backgroundJob
+
+	path   string // journal path
+	logger logger.Logger
+}
+
+type backgroundJob struct {
+	status JobStatus
+	cancel context.CancelFunc
+	result interface{}
+	done   chan struct{} // closed when the job finishes
+}
+
+func newJobRegistry() *jobRegistry {
+	return &jobRegistry{
+		jobs:   make(map[int64]*backgroundJob),
+		logger: logger.NopLogger,
+	}
+}
+
+// open loads the jobs recorded in the journal at path, and records later
+// jobs there. Jobs which were running when the journal was last written
+// did not finish, and are marked as failed.
+func (r *jobRegistry) open(path string, logger logger.Logger) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.path, r.logger = path, logger
+
+	buf, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "reading job journal")
+	}
+	var a []JobStatus
+	if err := json.Unmarshal(buf, &a); err != nil {
+		return errors.Wrap(err, "decoding job journal")
+	}
+
+	now := time.Now().UTC()
+	for _, status := range a {
+		if status.State == JobStateRunning {
+			status.State = JobStateFailed
+			status.Error = ErrJobInterrupted.Error()
+			status.ETA = nil
+			status.Finished = &now
+		}
+		done := make(chan struct{})
+		close(done)
+		r.jobs[status.ID] = &backgroundJob{status: status, cancel: func() {}, done: done}
+		if status.ID > r.seq {
+			r.seq = status.ID
+		}
+	}
+	return r.unprotectedSave()
+}
+
+// unprotectedSave writes the status of every job to the journal, if any.
+func (r *jobRegistry) unprotectedSave() error {
+	if r.path == "" {
+		return nil
+	}
+	a := make([]JobStatus, 0, len(r.jobs))
+	for _, j := range r.jobs {
+		a = append(a, j.status)
+	}
+	sort.Slice(a, func(i, j int) bool { return a[i].ID < a[j].ID })
+	buf, err := json.Marshal(a)
+	if err != nil {
+		return errors.Wrap(err, "encoding job journal")
+	}
+
+	tempPath := r.path + tempExt
+	if err := ioutil.WriteFile(tempPath, buf, 0666); err != nil {
+		return errors.Wrap(err, "writing job journal")
+	}
+	return errors.Wrap(os.Rename(tempPath, r.path), "renaming job journal")
+}
+
+// start runs fn in the background as a new job described by status, whose
+// ID, state, and start time are assigned here. fn reports progress with the
+// given function, and should return promptly once ctx is done.
+func (r *jobRegistry) start(status JobStatus, fn func(ctx context.Context, progress func(done, total int)) error) JobStatus {
+	return r.startResult(status, func(ctx context.Context, progress func(done, total int)) (interface{}, error) {
+		return nil, fn(ctx, progress)
+	})
+}
+
+// startResult is like start, for jobs which produce a result. The result is
+// kept in memory with the job's status, and is available from result.
+func (r *jobRegistry) startResult(status JobStatus, fn func(ctx context.Context, progress func(done, total int)) (interface{}, error)) JobStatus {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.seq++
+	status.ID = r.seq
+	status.State = JobStateRunning
+	status.Started = time.Now().UTC()
+	j := &backgroundJob{status: status, cancel: cancel, done: make(chan struct{})}
+	r.jobs[j.status.ID] = j
+	if err := r.unprotectedSave(); err != nil {
+		r.logger.Printf("saving job journal: %v", err)
+	}
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		defer cancel()
+		result, err := fn(ctx, func(done, total int) {
+			r.mu.Lock()
+			j.status.Done, j.status.Total = done, total
+			j.status.ETA = estimateJobFinish(j.status.Started, done, total)
+			r.mu.Unlock()
+		})
+		r.finish(ctx, j, result, err)
+	}()
+	return j.status
+}
+
+// estimateJobFinish returns when a job which started at the given time is
+// expected to finish, assuming the rest of the work proceeds at the same
+// rate. It returns nil if there is nothing to estimate from.
+func estimateJobFinish(started time.Time, done, total int) *time.Time {
+	if done <= 0 || done >= total {
+		return nil
+	}
+	elapsed := time.Since(started)
+	eta := started.Add(time.Duration(float64(elapsed) * float64(total) / float64(done)))
+	return &eta
+}
+
+// finish records the outcome of a job and discards the oldest finished jobs.
+func (r *jobRegistry) finish(ctx context.Context, j *backgroundJob, result interface{}, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	defer close(j.done)
+
+	now := time.Now().UTC()
+	j.status.Finished = &now
+	j.status.ETA = nil
+	switch {
+	case ctx.Err() != nil:
+		j.status.State = JobStateCanceled
+	case err != nil:
+		j.status.State = JobStateFailed
+		j.status.Error = err.Error()
+	default:
+		j.status.State = JobStateDone
+		j.result = result
+	}
+
+	var finished []int64
+	for id, other := range r.jobs {
+		if other.status.Finished != nil {
+			finished = append(finished, id)
+		}
+	}
+	if len(finished) > maxFinishedJobs {
+		sort.Slice(finished, func(i, j int) bool { return finished[i] < finished[j] })
+		for _, id := range finished[:len(finished)-maxFinishedJobs] {
+			delete(r.jobs, id)
+		}
+	}
+
+	if err := r.unprotectedSave(); err != nil {
+		r.logger.Printf("saving job journal: %v", err)
+	}
+}
+
+// status returns the status of a job.
+func (r *jobRegistry) status(id int64) (JobStatus, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	j, ok := r.jobs[id]
+	if !ok {
+		return JobStatus{}, newNotFoundError(ErrJobNotFound, strconv.FormatInt(id, 10))
+	}
+	return j.status, nil
+}
+
+// result returns the status and result of a job. If wait is true, it waits
+// for the job to finish, or for ctx to be done. The result is nil unless the
+// job is done.
+func (r *jobRegistry) result(ctx context.Context, id int64, wait bool) (JobStatus, interface{}, error) {
+	r.mu.Lock()
+	j, ok := r.jobs[id]
+	r.mu.Unlock()
+	if !ok {
+		return JobStatus{}, nil, newNotFoundError(ErrJobNotFound, strconv.FormatInt(id, 10))
+	}
+
+	if wait {
+		select {
+		case <-j.done:
+		case <-ctx.Done():
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return j.status, j.result, nil
+}
+
+// list returns the status of every job, ordered by ID.
+func (r *jobRegistry) list() []JobStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	a := make([]JobStatus, 0, len(r.jobs))
+	for _, j := range r.jobs {
+		a = append(a, j.status)
+	}
+	sort.Slice(a, func(i, j int) bool { return a[i].ID < a[j].ID })
+	return a
+}
+
+// cancel asks a running job to stop. Canceling a finished job has no effect.
+func (r *jobRegistry) cancel(id int64) (JobStatus, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	j, ok := r.jobs[id]
+	if !ok {
+		return JobStatus{}, newNotFoundError(ErrJobNotFound, strconv.FormatInt(id, 10))
+	}
+	j.cancel()
+	return j.status, nil
+}
+
+// close cancels all running jobs and waits for them to stop.
+func (r *jobRegistry) close() {
+	r.mu.Lock()
+	for _, j := range r.jobs {
+		j.cancel()
+	}
+	r.mu.Unlock()
+	r.wg.Wait()
+}
+
+// importJobBatchSize is the number of records imported at a time by an
+// import job.
+const importJobBatchSize = 1 << 16
+
+// importBatches calls fn for successive batches of the n records of an
+// import, reporting the number of records imported as progress.
+func importBatches(ctx context.Context, n int, progress func(done, total int), fn func(i, j int) error) error {
+	progress(0, n)
+	for i := 0; i < n; i += importJobBatchSize {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		j := i + importJobBatchSize
+		if j > n {
+			j = n
+		}
+		if err := fn(i, j); err != nil {
+			return errors.Wrapf(err, "importing records %d-%d", i, j)
+		}
+		progress(j, n)
+	}
+	return nil
+}
+
+// sliceUint64s returns a[i:j], or nil if a is empty, since the slices of an
+// import request are either empty or all the same length.
+func sliceUint64s(a []uint64, i, j int) []uint64 {
+	if len(a) == 0 {
+		return nil
+	}
+	return a[i:j]
+}
+
+// sliceInt64s is like sliceUint64s, for int64s.
+func sliceInt64s(a []int64, i, j int) []int64 {
+	if len(a) == 0 {
+		return nil
+	}
+	return a[i:j]
+}
+
+// sliceStrings is like sliceUint64s, for strings.
+func sliceStrings(a []string, i, j int) []string {
+	if len(a) == 0 {
+		return nil
+	}
+	return a[i:j]
+}
+
+// reindexField rebuilds the data derived from the local fragments of a
+// field: each fragment's rank cache, row cache, and block checksums, and the
+// columns the fragments contribute to the index's existence field.
+func reindexField(ctx context.Context, idx *Index, f *Field, progress func(done, total int)) error {
+	var frags []*fragment
+	for _, view := range f.views() {
+		frags = append(frags, view.allFragments()...)
+	}
+
+	// The existence field is derived from every other field.
+	ef := idx.existenceField()
+	if f == ef {
+		ef = nil
+	}
+
+	progress(0, len(frags))
+	for i, frag := range frags {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := frag.rebuildCaches(); err != nil {
+			return errors.Wrapf(err, "rebuilding caches: view=%s, shard=%d", frag.view, frag.shard)
+		}
+		// The inverse view's columns are rows of the field.
+		if ef != nil && frag.view != viewInverse {
+			columnIDs := frag.columns().Columns()
+			if err := ef.Import(make([]uint64, len(columnIDs)), columnIDs, nil); err != nil {
+				return errors.Wrapf(err, "importing existence: view=%s, shard=%d", frag.view, frag.shard)
+			}
+		}
+		progress(i+1, len(frags))
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pilosa/pilosa/v2/logger"
+)
+
+// Ensure job statuses are kept across restarts, and jobs which were running
+// are reported as interrupted.
+func TestJobRegistry_Journal(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pilosa-jobs-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, jobJournalFile)
+
+	r := newJobRegistry()
+	if err := r.open(path, logger.NopLogger); err != nil {
+		t.Fatal(err)
+	}
+	done := r.start(JobStatus{Type: JobTypeImport, Index: "i", Field: "f", Bytes: 10}, func(ctx context.Context, progress func(done, total int)) error {
+		progress(3, 3)
+		return nil
+	})
+	release := make(chan struct{})
+	running := r.start(JobStatus{Type: JobTypeImport, Index: "i", Field: "g"}, func(ctx context.Context, progress func(done, total int)) error {
+		<-release
+		return nil
+	})
+	for i := 0; ; i++ {
+		if status, err := r.status(done.ID); err != nil {
+			t.Fatal(err)
+		} else if status.State == JobStateDone {
+			break
+		} else if i == 100 {
+			t.Fatal("job did not finish")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// Read the journal before the running job finishes.
+	buf, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	close(release)
+	r.close()
+	if err := ioutil.WriteFile(path, buf, 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	r = newJobRegistry()
+	if err := r.open(path, logger.NopLogger); err != nil {
+		t.Fatal(err)
+	}
+	if status, err := r.status(done.ID); err != nil {
+		t.Fatal(err)
+	} else if status.State != JobStateDone || status.Done != 3 || status.Bytes != 10 {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+	if status, err := r.status(running.ID); err != nil {
+		t.Fatal(err)
+	} else if status.State != JobStateFailed || status.Error != ErrJobInterrupted.Error() || status.Finished == nil {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+
+	// New jobs do not reuse IDs.
+	if status := r.start(JobStatus{Type: JobTypeReindex}, func(ctx context.Context, progress func(done, total int)) error { return nil }); status.ID != running.ID+1 {
+		t.Fatalf("unexpected id: %d", status.ID)
+	}
+	r.close()
+}
+
+func TestEstimateJobFinish(t *testing.T) {
+	started := time.Now().Add(-time.Minute)
+	if eta := estimateJobFinish(started, 0, 10); eta != nil {
+		t.Fatalf("unexpected eta: %v", eta)
+	} else if eta := estimateJobFinish(started, 10, 10); eta != nil {
+		t.Fatalf("unexpected eta: %v", eta)
+	}
+
+	// A quarter of the work took a minute, so the job should take four.
+	eta := estimateJobFinish(started, 25, 100)
+	if eta == nil {
+		t.Fatal("expected eta")
+	} else if d := eta.Sub(started); d < 4*time.Minute || d > 4*time.Minute+time.Second {
+		t.Fatalf("unexpected eta: %v after start", d)
+	}
+}
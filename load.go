@@ -0,0 +1,166 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pilosa/pilosa/v2/toml"
+)
+
+// loadSampleInterval is the interval at which each node samples its load.
+const loadSampleInterval = 10 * time.Second
+
+// NodeLoad is a sample of the load on a node. Each node gossips its latest
+// sample along with its status, so that the load of the whole cluster can be
+// monitored from any node.
+type NodeLoad struct {
+	// Time is when the sample was taken.
+	Time time.Time `json:"time"`
+
+	// MemoryUsed is the number of bytes of heap in use.
+	MemoryUsed uint64 `json:"memoryUsed"`
+
+	// DiskUsed and DiskFree are the number of bytes used and available on
+	// the file system containing the data directory.
+	DiskUsed uint64 `json:"diskUsed"`
+	DiskFree uint64 `json:"diskFree"`
+
+	// QueriesPerSecond is the rate of queries received since the previous
+	// sample, including those forwarded by other nodes.
+	QueriesPerSecond float64 `json:"queriesPerSecond"`
+
+	// ImportLag is how long the oldest running import job has been running,
+	// or zero if there is none.
+	ImportLag toml.Duration `json:"importLag"`
+
+	// HotShards are the shards with the most reads and writes on the node
+	// since the previous sample.
+	HotShards []ShardLoad `json:"hotShards,omitempty"`
+}
+
+// loadMonitor counts the queries a node receives, and holds the latest
+// sample of its load.
+type loadMonitor struct {
+	queries int64 // accessed atomically
+
+	mu          sync.RWMutex
+	load        NodeLoad
+	lastQueries int64
+}
+
+// countQuery records a query received by the node.
+func (m *loadMonitor) countQuery() {
+	atomic.AddInt64(&m.queries, 1)
+}
+
+// get returns the latest sample.
+func (m *loadMonitor) get() NodeLoad {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.load
+}
+
+// record replaces the latest sample with load, after setting its rate of
+// queries since the previous sample.
+func (m *loadMonitor) record(load NodeLoad) {
+	queries := atomic.LoadInt64(&m.queries)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if elapsed := load.Time.Sub(m.load.Time); !m.load.Time.IsZero() && elapsed > 0 {
+		load.QueriesPerSecond = float64(queries-m.lastQueries) / elapsed.Seconds()
+	}
+	m.load, m.lastQueries = load, queries
+}
+
+// monitorLoad periodically samples the load on the node. This is run in a
+// goroutine.
+func (s *Server) monitorLoad() {
+	ticker := time.NewTicker(loadSampleInterval)
+	defer ticker.Stop()
+
+	s.load.record(s.sampleLoad())
+	for {
+		select {
+		case <-s.closing:
+			return
+		case <-ticker.C:
+			s.load.record(s.sampleLoad())
+		}
+	}
+}
+
+// sampleLoad returns the current load on the node, except for the rate of
+// queries, which is set when the sample is recorded.
+func (s *Server) sampleLoad() NodeLoad {
+	load := NodeLoad{Time: time.Now()}
+
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	load.MemoryUsed = m.HeapInuse
+
+	if used, err := diskUsed(s.holder.Path); err != nil {
+		s.logger.Printf("checking used disk space: %s", err)
+	} else {
+		load.DiskUsed = used
+	}
+	if free, err := s.diskFree(s.holder.Path); err != nil {
+		s.logger.Printf("checking free disk space: %s", err)
+	} else {
+		load.DiskFree = free
+	}
+
+	load.HotShards = s.holder.shardLoads.sample(hotShardN)
+
+	for _, job := range s.jobs.list() {
+		if job.Type != JobTypeImport || job.State != JobStateRunning {
+			continue
+		}
+		if lag := toml.Duration(load.Time.Sub(job.Started)); lag > load.ImportLag {
+			load.ImportLag = lag
+		}
+	}
+	return load
+}
+
+// Load returns the latest sample of the load on this node.
+func (api *API) Load() NodeLoad {
+	return api.server.load.get()
+}
+
+// ClusterLoad returns the latest sample of the load on each node in the
+// cluster, keyed by node ID. Remote nodes report their load in the status they
+// gossip; a node which has not reported yet is omitted.
+func (api *API) ClusterLoad() map[string]NodeLoad {
+	return api.server.clusterLoad()
+}
+
+// clusterLoad returns the latest sample of the load on each node in the
+// cluster, keyed by node ID.
+func (s *Server) clusterLoad() map[string]NodeLoad {
+	m := map[string]NodeLoad{s.nodeID: s.load.get()}
+	s.peersMu.RLock()
+	defer s.peersMu.RUnlock()
+	for _, node := range s.cluster.Nodes() {
+		if load, ok := s.peerLoads[node.ID]; ok {
+			m[node.ID] = load
+		}
+	}
+	return m
+}
@@ -0,0 +1,40 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoadMonitor_Record(t *testing.T) {
+	var m loadMonitor
+	start := time.Now()
+
+	// The first sample has no previous one to take a rate from.
+	m.countQuery()
+	m.record(NodeLoad{Time: start, MemoryUsed: 1})
+	if load := m.get(); load.QueriesPerSecond != 0 || load.MemoryUsed != 1 {
+		t.Fatalf("unexpected load: %+v", load)
+	}
+
+	for i := 0; i < 20; i++ {
+		m.countQuery()
+	}
+	m.record(NodeLoad{Time: start.Add(10 * time.Second)})
+	if load := m.get(); load.QueriesPerSecond != 2 {
+		t.Fatalf("unexpected load: %+v", load)
+	}
+}
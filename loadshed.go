@@ -0,0 +1,76 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"runtime"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// defaultHeapCheckInterval is the interval at which the heap in use is
+// checked when a high-water mark is configured.
+const defaultHeapCheckInterval = time.Second
+
+// ErrOverloaded is returned for batch queries and imports received by a node
+// whose heap is above its high-water mark.
+var ErrOverloaded = errors.New("server is overloaded, try again later")
+
+// monitorHeap periodically checks the heap in use against the high-water
+// mark. This is run in a goroutine.
+func (s *Server) monitorHeap() {
+	ticker := time.NewTicker(s.heapCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.closing:
+			return
+		case <-ticker.C:
+			s.checkHeap()
+		}
+	}
+}
+
+// checkHeap starts shedding load once the heap in use reaches the high-water
+// mark, and stops once it has dropped below it.
+func (s *Server) checkHeap() {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+
+	var overloaded int32
+	if m.HeapInuse >= s.heapHighWater {
+		overloaded = 1
+	}
+	if atomic.SwapInt32(&s.overloaded, overloaded) == overloaded {
+		return
+	}
+	if overloaded == 1 {
+		s.logger.Printf("heap in use %d bytes is above %d bytes, rejecting batch queries and imports", m.HeapInuse, s.heapHighWater)
+	} else {
+		s.logger.Printf("heap in use %d bytes has dropped, accepting batch queries and imports", m.HeapInuse)
+	}
+}
+
+// validateNotOverloaded returns ErrOverloaded if the node is shedding load.
+func (api *API) validateNotOverloaded() error {
+	if atomic.LoadInt32(&api.server.overloaded) == 0 {
+		return nil
+	}
+	api.holder.Stats.Count("loadShed", 1, 1.0)
+	return ErrOverloaded
+}
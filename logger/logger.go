@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"strings"
 )
 
 // Ensure nopLogger implements interface.
@@ -84,6 +85,26 @@ func (vb *verboseLogger) Logger() *log.Logger {
 	return vb.logger
 }
 
+// WithPrefix returns a Logger which prefixes each message logged by l with
+// prefix.
+func WithPrefix(l Logger, prefix string) Logger {
+	return &prefixLogger{logger: l, prefix: strings.Replace(prefix, "%", "%%", -1)}
+}
+
+// prefixLogger is a Logger which prefixes each message.
+type prefixLogger struct {
+	logger Logger
+	prefix string
+}
+
+func (p *prefixLogger) Printf(format string, v ...interface{}) {
+	p.logger.Printf(p.prefix+format, v...)
+}
+
+func (p *prefixLogger) Debugf(format string, v ...interface{}) {
+	p.logger.Debugf(p.prefix+format, v...)
+}
+
 // CaptureLogger is a logger that stores all the print and debug messages
 // it sees, useful for testing.
 type CaptureLogger struct {
@@ -0,0 +1,404 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pilosa/pilosa/v2/pql"
+	"github.com/pkg/errors"
+)
+
+// Materialized view errors.
+var (
+	ErrMaterializedViewNotFound = errors.New("materialized view not found")
+	ErrMaterializedViewExists   = errors.New("materialized view already exists")
+)
+
+// materializedFile is the file in the index directory which records the
+// definitions of the index's materialized views.
+const materializedFile = ".materialized"
+
+// materializedCalls are the calls a materialized view may be defined with.
+var materializedCalls = map[string]bool{
+	"Row": true, "Range": true, "Union": true, "Intersect": true,
+	"Difference": true, "Xor": true, "Not": true, "Shift": true,
+}
+
+// MaterializedView is a named row query whose result is kept for each shard,
+// and recomputed only for the shards in which the fields it reads change.
+// It is queried with Materialized(name=<name>).
+type MaterializedView struct {
+	Name  string `json:"name"`
+	Query string `json:"query"`
+}
+
+// CreateMaterializedViewMessage is an internal message indicating that a
+// materialized view was defined. Call is the query with its keys translated
+// to IDs. Version orders the changes to a view, so that nodes merging the
+// views of others keep the latest.
+type CreateMaterializedViewMessage struct {
+	Index   string
+	Name    string
+	Query   string
+	Call    string
+	Version int64
+}
+
+// DeleteMaterializedViewMessage is an internal message indicating that a
+// materialized view was deleted.
+type DeleteMaterializedViewMessage struct {
+	Index   string
+	Name    string
+	Version int64
+}
+
+// materializedView is the state of a materialized view on this node.
+type materializedView struct {
+	MaterializedView
+	version int64
+
+	// The call computing the view, with keys translated to IDs.
+	call *pql.Call
+
+	// Names of the fields the call reads, sorted.
+	fields []string
+
+	mu     sync.Mutex
+	shards map[uint64]*materializedShard
+}
+
+// materializedShard is the result of a materialized view in one shard, and
+// the generations of the fragments it was computed from.
+type materializedShard struct {
+	row   *Row
+	stamp []uint64
+}
+
+// materializedViewMeta is a materialized view as recorded in the index
+// directory. The views which were deleted are recorded too, so that an
+// earlier version isn't restored from another node.
+type materializedViewMeta struct {
+	Name    string `json:"name"`
+	Query   string `json:"query"`
+	Call    string `json:"call"`
+	Version int64  `json:"version,omitempty"`
+	Deleted bool   `json:"deleted,omitempty"`
+}
+
+// newMaterializedView returns a materialized view of idx named name, which
+// computes call. Query is the definition as given, before translation, which
+// is checked against the schema.
+func newMaterializedView(idx *Index, name, query, call string) (*materializedView, error) {
+	if err := validateName(name); err != nil {
+		return nil, err
+	}
+	c, err := parseMaterializedCall(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var check func(c *pql.Call) error
+	check = func(c *pql.Call) error {
+		if !materializedCalls[c.Name] {
+			return errors.Errorf("%s() cannot be used in a materialized view", c.Name)
+		}
+		for _, k := range []string{"from", "to"} {
+			if s, ok := c.Args[k].(string); ok && strings.HasPrefix(s, "now") {
+				return errors.Errorf("relative time %q cannot be used in a materialized view", s)
+			}
+		}
+		for _, child := range c.Children {
+			if err := check(child); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := check(c); err != nil {
+		return nil, NewBadRequestError(err)
+	}
+
+	v := &queryValidator{
+		holder:     idx.holder,
+		customCall: func(string) bool { return false },
+	}
+	v.validate(idx, c, []int{0})
+	for _, d := range v.diags {
+		if d.Severity == DiagnosticError {
+			return nil, errors.Wrapf(d.ResponseError.Err(), "%s", d.Call)
+		}
+	}
+
+	if call != query {
+		if c, err = parseMaterializedCall(call); err != nil {
+			return nil, err
+		}
+	}
+
+	return &materializedView{
+		MaterializedView: MaterializedView{Name: name, Query: query},
+		call:             c,
+		fields:           materializedFields(c),
+		shards:           make(map[uint64]*materializedShard),
+	}, nil
+}
+
+// parseMaterializedCall parses the single call of a materialized view.
+func parseMaterializedCall(s string) (*pql.Call, error) {
+	q, err := pql.NewParser(strings.NewReader(s)).Parse()
+	if err != nil {
+		return nil, NewBadRequestError(errors.Wrap(err, "parsing"))
+	} else if len(q.Calls) != 1 {
+		return nil, NewBadRequestError(errors.New("materialized view must be a single call"))
+	}
+	return q.Calls[0], nil
+}
+
+// materializedFields returns the sorted names of the fields which c may
+// read, including the existence field if c uses Not().
+func materializedFields(c *pql.Call) []string {
+	names := callFields(c)
+	var walk func(c *pql.Call)
+	walk = func(c *pql.Call) {
+		if c.Name == "Not" {
+			names = append(names, existenceFieldName)
+		}
+		for _, child := range c.Children {
+			walk(child)
+		}
+	}
+	walk(c)
+
+	sort.Strings(names)
+	fields := names[:0]
+	for i, name := range names {
+		if i == 0 || name != names[i-1] {
+			fields = append(fields, name)
+		}
+	}
+	return fields
+}
+
+// stamp returns the generations of the fragments of shard which the view
+// reads. The view's result in the shard is unchanged while its stamp is.
+func (mv *materializedView) stamp(idx *Index, shard uint64) []uint64 {
+	var stamp []uint64
+	for _, name := range mv.fields {
+		f := idx.Field(name)
+		if f == nil {
+			stamp = append(stamp, 0)
+			continue
+		}
+		views := f.views()
+		sort.Slice(views, func(i, j int) bool { return views[i].name < views[j].name })
+		stamp = append(stamp, uint64(len(views)))
+		for _, view := range views {
+			var gen uint64
+			if frag := view.Fragment(shard); frag != nil {
+				gen = frag.generation()
+			}
+			stamp = append(stamp, gen)
+		}
+	}
+	return stamp
+}
+
+// shardRow returns the view's result in shard, calling compute to recompute
+// it if the fields it reads have changed since it was last computed.
+func (mv *materializedView) shardRow(idx *Index, shard uint64, compute func(c *pql.Call) (*Row, error)) (*Row, error) {
+	// The stamp is taken before computing, so that changes made while
+	// computing cause the next read to compute again.
+	stamp := mv.stamp(idx, shard)
+
+	mv.mu.Lock()
+	s := mv.shards[shard]
+	mv.mu.Unlock()
+	if s != nil && uint64SlicesEqual(s.stamp, stamp) {
+		return s.row.Union(), nil
+	}
+
+	row, err := compute(mv.call.Clone())
+	if err != nil {
+		return nil, err
+	}
+	row.Freeze()
+
+	mv.mu.Lock()
+	mv.shards[shard] = &materializedShard{row: row, stamp: stamp}
+	mv.mu.Unlock()
+	return row.Union(), nil
+}
+
+// MaterializedViews returns the materialized views of the index, sorted by
+// name.
+func (i *Index) MaterializedViews() []MaterializedView {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	views := make([]MaterializedView, 0, len(i.materialized))
+	for _, mv := range i.materialized {
+		views = append(views, mv.MaterializedView)
+	}
+	sort.Slice(views, func(a, b int) bool { return views[a].Name < views[b].Name })
+	return views
+}
+
+// materializedViewDefinitions returns the definitions of the index's
+// materialized views, and the views which were deleted, with their versions,
+// as they are sent to other nodes.
+func (i *Index) materializedViewDefinitions() ([]*CreateMaterializedViewMessage, []*DeleteMaterializedViewMessage) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	msgs := make([]*CreateMaterializedViewMessage, 0, len(i.materialized))
+	for _, mv := range i.materialized {
+		msgs = append(msgs, &CreateMaterializedViewMessage{Index: i.name, Name: mv.Name, Query: mv.Query, Call: mv.call.String(), Version: mv.version})
+	}
+	deleted := make([]*DeleteMaterializedViewMessage, 0, len(i.deletedMaterialized))
+	for name, version := range i.deletedMaterialized {
+		deleted = append(deleted, &DeleteMaterializedViewMessage{Index: i.name, Name: name, Version: version})
+	}
+	return msgs, deleted
+}
+
+// unprotectedMaterializedViewVersion returns the version of the materialized
+// view named name, or of its deletion, or zero if there is neither.
+func (i *Index) unprotectedMaterializedViewVersion(name string) int64 {
+	if mv := i.materialized[name]; mv != nil {
+		return mv.version
+	}
+	return i.deletedMaterialized[name]
+}
+
+// materializedView returns the materialized view named name, or nil.
+func (i *Index) materializedView(name string) *materializedView {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.materialized[name]
+}
+
+// createMaterializedView defines a materialized view, replacing any view of
+// the same name. A zero version makes the change a new one, later than the
+// view's current version; otherwise the change is ignored unless its version
+// is later. Returns the version of the change, or zero if it was ignored.
+func (i *Index) createMaterializedView(name, query, call string, version int64) (int64, error) {
+	mv, err := newMaterializedView(i, name, query, call)
+	if err != nil {
+		return 0, err
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if current := i.unprotectedMaterializedViewVersion(name); version == 0 {
+		version = nextVersion(current)
+	} else if version <= current {
+		return 0, nil
+	}
+	mv.version = version
+	i.materialized[name] = mv
+	delete(i.deletedMaterialized, name)
+	return version, i.saveMaterializedViews()
+}
+
+// deleteMaterializedView removes the materialized view named name. Versions
+// are as for createMaterializedView, except that a new change to a view which
+// doesn't exist fails. A deletion is recorded even if the view doesn't exist,
+// so that an earlier version of it isn't created later.
+func (i *Index) deleteMaterializedView(name string, version int64) (int64, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if current := i.unprotectedMaterializedViewVersion(name); version == 0 {
+		if i.materialized[name] == nil {
+			return 0, newNotFoundError(ErrMaterializedViewNotFound, name)
+		}
+		version = nextVersion(current)
+	} else if version <= current {
+		return 0, nil
+	}
+	delete(i.materialized, name)
+	i.deletedMaterialized[name] = version
+	return version, i.saveMaterializedViews()
+}
+
+// loadMaterializedViews reads the definitions of the index's materialized
+// views. It is called once the fields are open, so they can be validated.
+func (i *Index) loadMaterializedViews() error {
+	if i.inMemory {
+		return nil
+	}
+	buf, err := ioutil.ReadFile(filepath.Join(i.path, materializedFile))
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "reading materialized views file")
+	}
+	var metas []materializedViewMeta
+	if err := json.Unmarshal(buf, &metas); err != nil {
+		return errors.Wrap(err, "decoding materialized views file")
+	}
+
+	i.materialized = make(map[string]*materializedView, len(metas))
+	i.deletedMaterialized = make(map[string]int64)
+	for _, m := range metas {
+		if m.Deleted {
+			i.deletedMaterialized[m.Name] = m.Version
+			continue
+		}
+		mv, err := newMaterializedView(i, m.Name, m.Query, m.Call)
+		if err != nil {
+			// A field the view reads may have been deleted since.
+			i.logger.Printf("skipping materialized view %s of index %s: %v", m.Name, i.name, err)
+			continue
+		}
+		mv.version = m.Version
+		i.materialized[m.Name] = mv
+	}
+	return nil
+}
+
+// saveMaterializedViews writes the definitions of the index's materialized
+// views to the index directory.
+func (i *Index) saveMaterializedViews() error {
+	if i.inMemory {
+		return nil
+	}
+	metas := make([]materializedViewMeta, 0, len(i.materialized)+len(i.deletedMaterialized))
+	for _, mv := range i.materialized {
+		metas = append(metas, materializedViewMeta{Name: mv.Name, Query: mv.Query, Call: mv.call.String(), Version: mv.version})
+	}
+	for name, version := range i.deletedMaterialized {
+		metas = append(metas, materializedViewMeta{Name: name, Version: version, Deleted: true})
+	}
+	sort.Slice(metas, func(a, b int) bool { return metas[a].Name < metas[b].Name })
+	buf, err := json.Marshal(metas)
+	if err != nil {
+		return errors.Wrap(err, "encoding materialized views file")
+	}
+
+	path := filepath.Join(i.path, materializedFile)
+	tempPath := path + tempExt
+	if err := ioutil.WriteFile(tempPath, buf, 0666); err != nil {
+		return errors.Wrap(err, "writing materialized views file")
+	}
+	return errors.Wrap(os.Rename(tempPath, path), "renaming materialized views file")
+}
@@ -0,0 +1,324 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pilosa/pilosa/v2/pql"
+	"github.com/pkg/errors"
+)
+
+// Named query errors.
+var (
+	ErrNamedQueryNotFound = errors.New("named query not found")
+)
+
+// namedQueriesFile is the file in the data directory which records the
+// named queries.
+const namedQueriesFile = ".queries"
+
+// namedQueryParamPrefix starts the string values of a named query which are
+// replaced by parameters when it is executed.
+const namedQueryParamPrefix = "$"
+
+// NamedQuery is a query of an index which is stored on every node and
+// executed by name, so that clients such as dashboards refer to a stable name
+// rather than embedding the query.
+//
+// String values of the form "$name", such as Row(stargazer="$user"), are
+// parameters, which are given when the query is executed. Named queries may
+// not write.
+type NamedQuery struct {
+	Name  string `json:"name"`
+	Index string `json:"index"`
+	Query string `json:"query"`
+}
+
+// CreateNamedQueryMessage is an internal message indicating that a named
+// query was created or replaced. Version orders the changes to a query, so
+// that nodes merging the queries of others keep the latest.
+type CreateNamedQueryMessage struct {
+	Query   NamedQuery
+	Version int64
+}
+
+// DeleteNamedQueryMessage is an internal message indicating that a named
+// query was deleted.
+type DeleteNamedQueryMessage struct {
+	Name    string
+	Version int64
+}
+
+// namedQueryMeta is a named query as recorded in the named queries file. The
+// queries which were deleted are recorded too, so that an earlier version
+// isn't restored from another node.
+type namedQueryMeta struct {
+	NamedQuery
+	Version int64 `json:"version,omitempty"`
+	Deleted bool  `json:"deleted,omitempty"`
+}
+
+// validate checks that q can be stored. The index must exist in h.
+func (q NamedQuery) validate(h *Holder) error {
+	if err := validateName(q.Name); err != nil {
+		return err
+	} else if h.Index(h.resolveIndex(q.Index)) == nil {
+		return newNotFoundError(ErrIndexNotFound, q.Index)
+	}
+	calls, err := q.parse()
+	if err != nil {
+		return err
+	}
+
+	var check func(c *pql.Call) error
+	check = func(c *pql.Call) error {
+		if writeCalls[c.Name] {
+			return errors.Errorf("%s() cannot be used in a named query", c.Name)
+		}
+		for _, child := range c.Children {
+			if err := check(child); err != nil {
+				return err
+			}
+		}
+		for _, v := range c.Args {
+			if child, ok := v.(*pql.Call); ok {
+				if err := check(child); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	for _, c := range calls {
+		if err := check(c); err != nil {
+			return NewBadRequestError(err)
+		}
+	}
+	return nil
+}
+
+// parse returns the calls of the query.
+func (q NamedQuery) parse() ([]*pql.Call, error) {
+	parsed, err := pql.NewParser(strings.NewReader(q.Query)).Parse()
+	if err != nil {
+		return nil, NewBadRequestError(errors.Wrap(err, "parsing"))
+	}
+	return parsed.Calls, nil
+}
+
+// bind returns the query with its parameters replaced by params. Values which
+// are integers replace parameters with integers, and other values with
+// strings. Every parameter must be given, and every value used.
+func (q NamedQuery) bind(params map[string]string) (string, error) {
+	calls, err := q.parse()
+	if err != nil {
+		return "", err
+	}
+
+	used := make(map[string]bool, len(params))
+	var bind func(c *pql.Call) error
+	bind = func(c *pql.Call) error {
+		for k, v := range c.Args {
+			switch v := v.(type) {
+			case string:
+				if !strings.HasPrefix(v, namedQueryParamPrefix) {
+					continue
+				}
+				name := strings.TrimPrefix(v, namedQueryParamPrefix)
+				value, ok := params[name]
+				if !ok {
+					return errors.Errorf("parameter %s required", name)
+				}
+				used[name] = true
+				if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+					c.Args[k] = n
+				} else {
+					c.Args[k] = value
+				}
+			case *pql.Call:
+				if err := bind(v); err != nil {
+					return err
+				}
+			}
+		}
+		for _, child := range c.Children {
+			if err := bind(child); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, c := range calls {
+		if err := bind(c); err != nil {
+			return "", err
+		}
+	}
+	for name := range params {
+		if !used[name] {
+			return "", errors.Errorf("unknown parameter %s", name)
+		}
+	}
+	return (&pql.Query{Calls: calls}).String(), nil
+}
+
+// namedQueryStore holds a node's named queries.
+type namedQueryStore struct {
+	mu      sync.RWMutex
+	queries map[string]NamedQuery
+	path    string
+
+	// The versions of the queries, and of those deleted.
+	versions map[string]int64
+}
+
+func newNamedQueryStore() *namedQueryStore {
+	return &namedQueryStore{
+		queries:  make(map[string]NamedQuery),
+		versions: make(map[string]int64),
+	}
+}
+
+// open loads the named queries recorded in the file at path, and records
+// later changes there.
+func (s *namedQueryStore) open(path string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.path = path
+
+	buf, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "reading named queries file")
+	}
+	var metas []namedQueryMeta
+	if err := json.Unmarshal(buf, &metas); err != nil {
+		return errors.Wrap(err, "decoding named queries file")
+	}
+	for _, m := range metas {
+		if !m.Deleted {
+			s.queries[m.Name] = m.NamedQuery
+		}
+		s.versions[m.Name] = m.Version
+	}
+	return nil
+}
+
+// get returns the named query named name.
+func (s *namedQueryStore) get(name string) (NamedQuery, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	q, ok := s.queries[name]
+	return q, ok
+}
+
+// all returns the named queries, sorted by name.
+func (s *namedQueryStore) all() []NamedQuery {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	queries := make([]NamedQuery, 0, len(s.queries))
+	for _, q := range s.queries {
+		queries = append(queries, q)
+	}
+	sort.Slice(queries, func(i, j int) bool { return queries[i].Name < queries[j].Name })
+	return queries
+}
+
+// definitions returns the named queries, and the queries which were
+// deleted, with their versions, as they are sent to other nodes.
+func (s *namedQueryStore) definitions() ([]*CreateNamedQueryMessage, []*DeleteNamedQueryMessage) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	queries := make([]*CreateNamedQueryMessage, 0, len(s.queries))
+	var deleted []*DeleteNamedQueryMessage
+	for name, version := range s.versions {
+		if q, ok := s.queries[name]; ok {
+			queries = append(queries, &CreateNamedQueryMessage{Query: q, Version: version})
+		} else {
+			deleted = append(deleted, &DeleteNamedQueryMessage{Name: name, Version: version})
+		}
+	}
+	return queries, deleted
+}
+
+// create adds q, replacing any query of the same name. A zero version makes
+// the change a new one, later than the query's current version; otherwise
+// the change is ignored unless its version is later. Returns the version of
+// the change, or zero if it was ignored.
+func (s *namedQueryStore) create(q NamedQuery, version int64) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if current := s.versions[q.Name]; version == 0 {
+		version = nextVersion(current)
+	} else if version <= current {
+		return 0, nil
+	}
+	s.queries[q.Name] = q
+	s.versions[q.Name] = version
+	return version, s.unprotectedSave()
+}
+
+// delete removes the query named name. Versions are as for create, except
+// that a new change to a query which doesn't exist fails. A deletion is
+// recorded even if the query doesn't exist, so that an earlier version of it
+// isn't created later.
+func (s *namedQueryStore) delete(name string, version int64) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if current := s.versions[name]; version == 0 {
+		if _, ok := s.queries[name]; !ok {
+			return 0, newNotFoundError(ErrNamedQueryNotFound, name)
+		}
+		version = nextVersion(current)
+	} else if version <= current {
+		return 0, nil
+	}
+	delete(s.queries, name)
+	s.versions[name] = version
+	return version, s.unprotectedSave()
+}
+
+// unprotectedSave writes the named queries to the named queries file, if any.
+func (s *namedQueryStore) unprotectedSave() error {
+	if s.path == "" {
+		return nil
+	}
+	metas := make([]namedQueryMeta, 0, len(s.versions))
+	for name, version := range s.versions {
+		q, ok := s.queries[name]
+		if !ok {
+			q = NamedQuery{Name: name}
+		}
+		metas = append(metas, namedQueryMeta{NamedQuery: q, Version: version, Deleted: !ok})
+	}
+	sort.Slice(metas, func(i, j int) bool { return metas[i].Name < metas[j].Name })
+	buf, err := json.Marshal(metas)
+	if err != nil {
+		return errors.Wrap(err, "encoding named queries file")
+	}
+
+	tempPath := s.path + tempExt
+	if err := ioutil.WriteFile(tempPath, buf, 0666); err != nil {
+		return errors.Wrap(err, "writing named queries file")
+	}
+	return errors.Wrap(os.Rename(tempPath, s.path), "renaming named queries file")
+}
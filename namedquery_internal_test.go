@@ -0,0 +1,115 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pilosa/pilosa/v2/pql"
+)
+
+func TestNamedQuery_Bind(t *testing.T) {
+	q := NamedQuery{Name: "q", Index: "i", Query: `Count(Intersect(Row(f="$a"), Row(g="$b"))) Row(h="$a")`}
+
+	s, err := q.bind(map[string]string{"a": "1", "b": "x y"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bound, err := pql.NewParser(strings.NewReader(s)).Parse()
+	if err != nil {
+		t.Fatalf("parsing %s: %v", s, err)
+	}
+	rows := bound.Calls[0].Children[0].Children
+	if got := rows[0].Args["f"]; got != int64(1) {
+		t.Fatalf("unexpected value: %#v", got)
+	} else if got := rows[1].Args["g"]; got != "x y" {
+		t.Fatalf("unexpected value: %#v", got)
+	} else if got := bound.Calls[1].Args["h"]; got != int64(1) {
+		t.Fatalf("unexpected value: %#v", got)
+	}
+
+	if _, err := q.bind(map[string]string{"a": "1"}); err == nil {
+		t.Fatal("expected error for missing parameter")
+	} else if _, err := q.bind(map[string]string{"a": "1", "b": "2", "c": "3"}); err == nil {
+		t.Fatal("expected error for unknown parameter")
+	}
+}
+
+func TestNamedQuery_Validate(t *testing.T) {
+	h := newHolder()
+	if err := h.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+	h.MustCreateIndexIfNotExists("i", IndexOptions{})
+
+	if err := (NamedQuery{Name: "q", Index: "i", Query: `Count(Row(f="$a"))`}).validate(h.Holder); err != nil {
+		t.Fatal(err)
+	}
+	for _, q := range []NamedQuery{
+		{Name: "q", Index: "nope", Query: `Count(Row(f=1))`},
+		{Name: "Q!", Index: "i", Query: `Count(Row(f=1))`},
+		{Name: "q", Index: "i", Query: `Count(Row(f=1)`},
+		{Name: "q", Index: "i", Query: `Count(Row(f=1)) Set(1, f="$a")`},
+	} {
+		if err := q.validate(h.Holder); err == nil {
+			t.Fatalf("expected error for %+v", q)
+		}
+	}
+}
+
+// Ensure named queries are kept across restarts.
+func TestNamedQueryStore_Reopen(t *testing.T) {
+	h := newHolder()
+	if err := h.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	queries := []NamedQuery{
+		{Name: "a", Index: "i", Query: `Count(Row(f=1))`},
+		{Name: "b", Index: "i", Query: `Row(f="$x")`},
+	}
+	for _, q := range append([]NamedQuery{{Name: "c", Index: "i"}}, queries...) {
+		if _, err := h.namedQueries.create(q, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+	version, err := h.namedQueries.delete("c", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := h.Holder.Close(); err != nil {
+		t.Fatal(err)
+	} else if err := h.Reopen(); err != nil {
+		t.Fatal(err)
+	} else if got := h.namedQueries.all(); !reflect.DeepEqual(got, queries) {
+		t.Fatalf("unexpected queries: %+v", got)
+	}
+
+	// The deletion is kept too, so an earlier version of the query isn't
+	// created again.
+	if v, err := h.namedQueries.create(NamedQuery{Name: "c", Index: "i"}, version); err != nil {
+		t.Fatal(err)
+	} else if _, ok := h.namedQueries.get("c"); v != 0 || ok {
+		t.Fatalf("unexpected query at version %d", v)
+	}
+	if _, deleted := h.namedQueries.definitions(); len(deleted) != 1 || deleted[0].Name != "c" || deleted[0].Version != version {
+		t.Fatalf("unexpected deletions: %+v", deleted)
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pilosa/pilosa/v2/pql"
+	"github.com/pkg/errors"
+)
+
+// An index named "<namespace>.<name>" belongs to a namespace, which groups
+// the indexes of one tenant of a cluster. Since index names cannot otherwise
+// contain a ".", the indexes of different namespaces, and of no namespace,
+// never collide. The indexes of a namespace are stored together, in a
+// directory of the data directory named after it, and their metrics are
+// tagged with it.
+//
+// Namespaces need not be declared to hold indexes. A declared namespace binds
+// API tokens, which may only be used on its indexes, and may set a quota
+// shared by the usage of all of them.
+
+// namespaceSep separates the namespace of an index from its name within the
+// namespace.
+const namespaceSep = "."
+
+// namespaceDirPrefix prefixes the name of the directory holding the indexes
+// of a namespace. It cannot begin an index name.
+const namespaceDirPrefix = "@"
+
+// ErrNamespaceForbidden is returned when an API token bound to a namespace is
+// used outside it.
+var ErrNamespaceForbidden = errors.New("forbidden outside the API token's namespace")
+
+// Namespace declares a namespace.
+type Namespace struct {
+	// Name is the name of the namespace, which prefixes its indexes.
+	Name string
+
+	// Tokens are the API tokens bound to the namespace.
+	Tokens []string
+
+	// Quota limits the combined usage of the namespace's tokens, in addition
+	// to the node's quota, which applies to each token.
+	Quota Quota
+}
+
+// validate returns an error if the namespace's name or quota is invalid.
+func (ns Namespace) validate() error {
+	if err := validateName(ns.Name); err != nil {
+		return errors.Wrap(err, "namespace")
+	}
+	return errors.Wrapf(ns.Quota.validate(), "namespace %s", ns.Name)
+}
+
+// IndexNamespace returns the namespace of an index, or an empty string if it
+// does not belong to one.
+func IndexNamespace(index string) string {
+	namespace, _ := splitIndexName(index)
+	return namespace
+}
+
+// splitIndexName returns the namespace of an index, if any, and its name
+// within the namespace.
+func splitIndexName(index string) (namespace, name string) {
+	if i := strings.Index(index, namespaceSep); i >= 0 {
+		return index[:i], index[i+len(namespaceSep):]
+	}
+	return "", index
+}
+
+// validateIndexName returns an error if an index name, or the namespace it is
+// qualified with, is invalid.
+func validateIndexName(index string) error {
+	namespace, name := splitIndexName(index)
+	if namespace != "" || strings.HasPrefix(index, namespaceSep) {
+		if err := validateName(namespace); err != nil {
+			return err
+		}
+	}
+	return validateName(name)
+}
+
+// namespaceTokens maps API tokens to the namespaces they are bound to.
+type namespaceTokens map[string]*Namespace
+
+// newNamespaceTokens returns the tokens of namespaces, returning an error if
+// a namespace is invalid or declared twice, or a token is bound to more than
+// one namespace.
+func newNamespaceTokens(namespaces []Namespace) (namespaceTokens, error) {
+	m := make(namespaceTokens)
+	names := make(map[string]struct{})
+	for i := range namespaces {
+		ns := &namespaces[i]
+		if err := ns.validate(); err != nil {
+			return nil, err
+		} else if _, ok := names[ns.Name]; ok {
+			return nil, errors.Errorf("namespace %s declared twice", ns.Name)
+		}
+		names[ns.Name] = struct{}{}
+
+		for _, token := range ns.Tokens {
+			if token == "" {
+				return nil, errors.Errorf("namespace %s has an empty token", ns.Name)
+			} else if other, ok := m[token]; ok {
+				return nil, errors.Errorf("token bound to namespaces %s and %s", other.Name, ns.Name)
+			}
+			m[token] = ns
+		}
+	}
+	return m, nil
+}
+
+// TokenNamespace returns the namespace which the API token of a request is
+// bound to, or an empty string if the request may use any index.
+func (api *API) TokenNamespace(ctx context.Context) string {
+	if ns := api.server.namespaceTokens[APITokenFromContext(ctx)]; ns != nil {
+		return ns.Name
+	}
+	return ""
+}
+
+// CheckNamespace returns ErrNamespaceForbidden if the API token of a request
+// is bound to a namespace other than that of index.
+func (api *API) CheckNamespace(ctx context.Context, index string) error {
+	if ns := api.TokenNamespace(ctx); ns != "" && IndexNamespace(index) != ns {
+		return errors.Wrapf(ErrNamespaceForbidden, "index %s", index)
+	}
+	return nil
+}
+
+// checkQueryNamespace returns ErrNamespaceForbidden if the API token of a
+// request is bound to a namespace and a query of index reads an index outside
+// it: index itself, or one named by an Index() call anywhere in the query,
+// whether by name or through an alias.
+func (api *API) checkQueryNamespace(ctx context.Context, index string, q *pql.Query) error {
+	if api.TokenNamespace(ctx) == "" {
+		return nil
+	}
+	for _, name := range append([]string{index}, crossIndexNames(q.Calls)...) {
+		if err := api.CheckNamespace(ctx, name); err != nil {
+			return err
+		} else if err := api.CheckNamespace(ctx, api.holder.resolveIndex(name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -45,8 +45,9 @@ var (
 	ErrInvalidRangeOperation    = errors.New("invalid range operation")
 	ErrInvalidBetweenValue      = errors.New("invalid value for between operation")
 
-	ErrInvalidView      = errors.New("invalid view")
-	ErrInvalidCacheType = errors.New("invalid cache type")
+	ErrInvalidView        = errors.New("invalid view")
+	ErrInvalidCacheType   = errors.New("invalid cache type")
+	ErrInvalidViewStorage = errors.New("invalid view storage")
 
 	ErrName  = errors.New("invalid index or field name, must match [a-z][a-z0-9_-]* and contain at most 64 characters")
 	ErrLabel = errors.New("invalid row or column label, must match [A-Za-z0-9_-]")
@@ -58,6 +59,14 @@ var (
 	ErrQueryTimeout     = errors.New("query timeout")
 	ErrTooManyWrites    = errors.New("too many write commands")
 
+	// ErrIndexReadOnly is returned for writes to an index which has been
+	// marked read-only.
+	ErrIndexReadOnly = errors.New("index is read-only")
+
+	// ErrQueryMemoryExceeded is returned when a query allocates more memory
+	// for intermediate rows than the configured limit.
+	ErrQueryMemoryExceeded = errors.New("query memory limit exceeded")
+
 	// TODO(2.0) poorly named - used when a *node* doesn't own a shard. Probably
 	// we won't need this error at all by 2.0 though.
 	ErrClusterDoesNotOwnShard = errors.New("node does not own shard")
@@ -66,6 +75,10 @@ var (
 	ErrNodeNotCoordinator = errors.New("node is not the coordinator")
 	ErrResizeNotRunning   = errors.New("no resize job currently running")
 
+	// ErrAPIVersionUnsupported is returned for requests to a version of the
+	// HTTP API which isn't served.
+	ErrAPIVersionUnsupported = errors.New("unsupported API version")
+
 	ErrNotImplemented            = errors.New("not implemented")
 	ErrFieldsArgumentRequired    = errors.New("fields argument required")
 	ErrExpectedFieldListArgument = errors.New("expected field list argument")
@@ -101,8 +114,8 @@ type ConflictError struct {
 	error
 }
 
-// newConflictError returns err wrapped in a ConflictError.
-func newConflictError(err error) ConflictError {
+// NewConflictError returns err wrapped in a ConflictError.
+func NewConflictError(err error) ConflictError {
 	return ConflictError{err}
 }
 
@@ -110,9 +123,10 @@ func newConflictError(err error) ConflictError {
 // such that in an HTTP scenario, http.StatusNotFound would be returned.
 type NotFoundError error
 
-// newNotFoundError returns err wrapped in a NotFoundError.
+// newNotFoundError returns err wrapped in a NotFoundError, naming the resource
+// which wasn't found.
 func newNotFoundError(err error, name string) NotFoundError {
-	return NotFoundError(errors.WithMessage(err, name))
+	return NotFoundError(resourceError{error: errors.WithMessage(err, name), name: name})
 }
 
 // Regular expression to validate index and field names.
@@ -158,6 +172,19 @@ func validateName(name string) error {
 	return nil
 }
 
+// uint64SlicesEqual determines if two uint64 slices have the same elements.
+func uint64SlicesEqual(a, b []uint64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 // stringSlicesAreEqual determines if two string slices are equal.
 func stringSlicesAreEqual(a, b []string) bool {
 
@@ -2530,14 +2530,33 @@ func (p *PQL) Init() {
 							l241:
 								position, tokenIndex = position236, tokenIndex236
 								if buffer[position] != rune('_') {
-									goto l230
+									goto l314
 								}
 								position++
 								if buffer[position] != rune('f') {
-									goto l230
+									goto l314
 								}
 								position++
 								if buffer[position] != rune('i') {
+									goto l314
+								}
+								position++
+								if buffer[position] != rune('e') {
+									goto l314
+								}
+								position++
+								if buffer[position] != rune('l') {
+									goto l314
+								}
+								position++
+								if buffer[position] != rune('d') {
+									goto l314
+								}
+								position++
+								goto l236
+							l314:
+								position, tokenIndex = position236, tokenIndex236
+								if buffer[position] != rune('_') {
 									goto l230
 								}
 								position++
@@ -2545,11 +2564,27 @@ func (p *PQL) Init() {
 									goto l230
 								}
 								position++
-								if buffer[position] != rune('l') {
+								if buffer[position] != rune('x') {
 									goto l230
 								}
 								position++
-								if buffer[position] != rune('d') {
+								if buffer[position] != rune('p') {
+									goto l230
+								}
+								position++
+								if buffer[position] != rune('i') {
+									goto l230
+								}
+								position++
+								if buffer[position] != rune('r') {
+									goto l230
+								}
+								position++
+								if buffer[position] != rune('e') {
+									goto l230
+								}
+								position++
+								if buffer[position] != rune('s') {
 									goto l230
 								}
 								position++
@@ -2571,7 +2606,7 @@ func (p *PQL) Init() {
 			position, tokenIndex = position230, tokenIndex230
 			return false
 		},
-		/* 17 reserved <- <(('_' 'r' 'o' 'w') / ('_' 'c' 'o' 'l') / ('_' 's' 't' 'a' 'r' 't') / ('_' 'e' 'n' 'd') / ('_' 't' 'i' 'm' 'e' 's' 't' 'a' 'm' 'p') / ('_' 'f' 'i' 'e' 'l' 'd'))> */
+		/* 17 reserved <- <(('_' 'r' 'o' 'w') / ('_' 'c' 'o' 'l') / ('_' 's' 't' 'a' 'r' 't') / ('_' 'e' 'n' 'd') / ('_' 't' 'i' 'm' 'e' 's' 't' 'a' 'm' 'p') / ('_' 'f' 'i' 'e' 'l' 'd') / ('_' 'e' 'x' 'p' 'i' 'r' 'e' 's'))> */
 		nil,
 		/* 18 posfield <- <(<fieldExpr> Action50)> */
 		func() bool {
@@ -2860,7 +2895,7 @@ func (p *PQL) Init() {
 			position, tokenIndex = position281, tokenIndex281
 			return false
 		},
-		/* 29 timestampbasicfmt <- <([0-9] [0-9] [0-9] [0-9] '-' ('0' / '1') [0-9] '-' [0-3] [0-9] 'T' [0-9] [0-9] ':' [0-9] [0-9])> */
+		/* 29 timestampbasicfmt <- <([0-9] [0-9] [0-9] [0-9] '-' ('0' / '1') [0-9] '-' [0-3] [0-9] 'T' [0-9] [0-9] ':' [0-9] [0-9] (':' [0-9] [0-9] ('.' [0-9]+)?)? ('Z' / (('+' / '-') [0-9] [0-9] ':' [0-9] [0-9]))?)> */
 		func() bool {
 			position290, tokenIndex290 := position, tokenIndex
 			{
@@ -2940,6 +2975,104 @@ func (p *PQL) Init() {
 					goto l290
 				}
 				position++
+				{
+					position302, tokenIndex302 := position, tokenIndex
+					if buffer[position] != rune(':') {
+						goto l302
+					}
+					position++
+					if c := buffer[position]; c < rune('0') || c > rune('9') {
+						goto l302
+					}
+					position++
+					if c := buffer[position]; c < rune('0') || c > rune('9') {
+						goto l302
+					}
+					position++
+					{
+						position304, tokenIndex304 := position, tokenIndex
+						if buffer[position] != rune('.') {
+							goto l304
+						}
+						position++
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l304
+						}
+						position++
+					l306:
+						{
+							position307, tokenIndex307 := position, tokenIndex
+							if c := buffer[position]; c < rune('0') || c > rune('9') {
+								goto l307
+							}
+							position++
+							goto l306
+						l307:
+							position, tokenIndex = position307, tokenIndex307
+						}
+						goto l305
+					l304:
+						position, tokenIndex = position304, tokenIndex304
+					}
+				l305:
+					goto l303
+				l302:
+					position, tokenIndex = position302, tokenIndex302
+				}
+			l303:
+				{
+					position308, tokenIndex308 := position, tokenIndex
+					{
+						position310, tokenIndex310 := position, tokenIndex
+						if buffer[position] != rune('Z') {
+							goto l311
+						}
+						position++
+						goto l310
+					l311:
+						position, tokenIndex = position310, tokenIndex310
+						{
+							position312, tokenIndex312 := position, tokenIndex
+							if buffer[position] != rune('+') {
+								goto l313
+							}
+							position++
+							goto l312
+						l313:
+							position, tokenIndex = position312, tokenIndex312
+							if buffer[position] != rune('-') {
+								goto l308
+							}
+							position++
+						}
+					l312:
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l308
+						}
+						position++
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l308
+						}
+						position++
+						if buffer[position] != rune(':') {
+							goto l308
+						}
+						position++
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l308
+						}
+						position++
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l308
+						}
+						position++
+					}
+				l310:
+					goto l309
+				l308:
+					position, tokenIndex = position308, tokenIndex308
+				}
+			l309:
 				add(ruletimestampbasicfmt, position291)
 			}
 			return true
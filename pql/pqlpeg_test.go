@@ -95,6 +95,10 @@ func TestPEGWorking(t *testing.T) {
 			name:   "SetTime",
 			input:  "Set(2, f=1, 1999-12-31T00:00)",
 			ncalls: 1},
+		{
+			name:   "SetTimeOffset",
+			input:  "Set(2, f=1, 1999-12-31T19:00:00-05:00)",
+			ncalls: 1},
 		{
 			name:   "DoubleSet",
 			input:  "Set(1, a=4)Set(2, a=4)",
@@ -271,6 +275,10 @@ func TestPEGWorking(t *testing.T) {
 			name:   "RangeTimeToQuotes",
 			input:  `Row(a=4, to="2010-08-04T00:00")`,
 			ncalls: 1},
+		{
+			name:   "RangeTimeRFC3339",
+			input:  `Row(a=4, from=2010-07-04T00:00:00Z, to="2010-08-04T00:00:00.5+02:00")`,
+			ncalls: 1},
 		{
 			name:   "Dashed Frame",
 			input:  "Set(1, my-frame=9)",
@@ -368,6 +376,17 @@ func TestPQLDeepEquality(t *testing.T) {
 					"_timestamp": "2010-07-08T14:44",
 				},
 			}},
+		{
+			name: "SetOffset",
+			call: "Set(1, a=7, 2010-07-08T14:44:05+09:30)",
+			exp: &Call{
+				Name: "Set",
+				Args: map[string]interface{}{
+					"a":          int64(7),
+					"_col":       int64(1),
+					"_timestamp": "2010-07-08T14:44:05+09:30",
+				},
+			}},
 		{
 			name: "SetRowAttrs",
 			call: "SetRowAttrs(myfield, 9, z=4)",
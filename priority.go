@@ -0,0 +1,142 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Query priorities. Interactive queries are scheduled ahead of batch queries
+// and exports, both when waiting to be admitted and in the executor's worker
+// pool.
+const (
+	PriorityInteractive = "interactive"
+	PriorityBatch       = "batch"
+)
+
+// queryPriority is the parsed form of a query priority.
+type queryPriority int
+
+const (
+	priorityInteractive queryPriority = iota
+	priorityBatch
+
+	priorityN // number of priorities
+)
+
+// parseQueryPriority parses a query priority. An empty string is interactive.
+func parseQueryPriority(s string) (queryPriority, error) {
+	switch s {
+	case "", PriorityInteractive:
+		return priorityInteractive, nil
+	case PriorityBatch:
+		return priorityBatch, nil
+	default:
+		return 0, errors.Errorf("invalid priority: %q", s)
+	}
+}
+
+// String returns the name of the priority.
+func (p queryPriority) String() string {
+	if p == priorityBatch {
+		return PriorityBatch
+	}
+	return PriorityInteractive
+}
+
+type queryPriorityKey struct{}
+
+// withQueryPriority returns a context carrying the priority of a query.
+func withQueryPriority(ctx context.Context, p queryPriority) context.Context {
+	return context.WithValue(ctx, queryPriorityKey{}, p)
+}
+
+// queryPriorityFromContext returns the priority of the query, which is
+// interactive unless set otherwise.
+func queryPriorityFromContext(ctx context.Context) queryPriority {
+	p, _ := ctx.Value(queryPriorityKey{}).(queryPriority)
+	return p
+}
+
+// admission limits the number of queries executing on a node at once. When
+// the limit is reached, waiting queries are admitted in priority order, and
+// in arrival order within a priority.
+//
+// Only queries which originate on a node are subject to admission; the
+// remote parts of distributed queries are always admitted, since holding
+// them back could deadlock queries waiting on each other across nodes.
+type admission struct {
+	mu      sync.Mutex
+	limit   int // zero means no limit
+	running int
+	waiting [priorityN][]chan struct{}
+}
+
+func newAdmission(limit int) *admission {
+	return &admission{limit: limit}
+}
+
+// acquire blocks until the query may execute or ctx is done. If it returns
+// nil, release must be called once the query completes.
+func (a *admission) acquire(ctx context.Context, p queryPriority) error {
+	a.mu.Lock()
+	if a.limit <= 0 || a.running < a.limit {
+		a.running++
+		a.mu.Unlock()
+		return nil
+	}
+	ch := make(chan struct{})
+	a.waiting[p] = append(a.waiting[p], ch)
+	a.mu.Unlock()
+
+	select {
+	case <-ch:
+		return nil
+	case <-ctx.Done():
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for i, other := range a.waiting[p] {
+		if other == ch {
+			a.waiting[p] = append(a.waiting[p][:i], a.waiting[p][i+1:]...)
+			return validateQueryContext(ctx)
+		}
+	}
+	// The query was admitted concurrently, so pass its slot on.
+	a.unprotectedRelease()
+	return validateQueryContext(ctx)
+}
+
+// release hands the query's slot to the next waiting query, if any.
+func (a *admission) release() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.unprotectedRelease()
+}
+
+func (a *admission) unprotectedRelease() {
+	for p := range a.waiting {
+		if len(a.waiting[p]) > 0 {
+			close(a.waiting[p][0])
+			a.waiting[p] = a.waiting[p][1:]
+			return
+		}
+	}
+	a.running--
+}
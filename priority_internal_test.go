@@ -0,0 +1,145 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseQueryPriority(t *testing.T) {
+	for s, exp := range map[string]queryPriority{
+		"":            priorityInteractive,
+		"interactive": priorityInteractive,
+		"batch":       priorityBatch,
+	} {
+		if p, err := parseQueryPriority(s); err != nil {
+			t.Fatal(err)
+		} else if p != exp {
+			t.Fatalf("%q: expected %v, got %v", s, exp, p)
+		}
+	}
+	if _, err := parseQueryPriority("urgent"); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+// waitQueued waits until n queries of priority p are waiting for admission.
+func waitQueued(t *testing.T, a *admission, p queryPriority, n int) {
+	t.Helper()
+	for i := 0; i < 100; i++ {
+		a.mu.Lock()
+		queued := len(a.waiting[p])
+		a.mu.Unlock()
+		if queued == n {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("expected %d queued %v queries", n, p)
+}
+
+// Ensure interactive queries are admitted ahead of batch queries which have
+// waited longer.
+func TestAdmission_Priority(t *testing.T) {
+	a := newAdmission(1)
+	ctx := context.Background()
+	if err := a.acquire(ctx, priorityBatch); err != nil {
+		t.Fatal(err)
+	}
+
+	admitted := make(chan queryPriority)
+	wait := func(p queryPriority) {
+		go func() {
+			if err := a.acquire(ctx, p); err != nil {
+				t.Error(err)
+			}
+			admitted <- p
+		}()
+		waitQueued(t, a, p, 1)
+	}
+	wait(priorityBatch)
+	wait(priorityInteractive)
+
+	var order []queryPriority
+	for i := 0; i < 2; i++ {
+		a.release()
+		order = append(order, <-admitted)
+	}
+	if exp := []queryPriority{priorityInteractive, priorityBatch}; !reflect.DeepEqual(order, exp) {
+		t.Fatalf("expected admission order %v, got %v", exp, order)
+	}
+
+	a.release()
+	if a.running != 0 {
+		t.Fatalf("expected no running queries, got %d", a.running)
+	}
+}
+
+// Ensure a query which gives up waiting leaves the queue.
+func TestAdmission_Cancel(t *testing.T) {
+	a := newAdmission(1)
+	if err := a.acquire(context.Background(), priorityInteractive); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errc := make(chan error)
+	go func() { errc <- a.acquire(ctx, priorityBatch) }()
+	waitQueued(t, a, priorityBatch, 1)
+	cancel()
+	if err := <-errc; err == nil {
+		t.Fatal("expected error")
+	}
+	waitQueued(t, a, priorityBatch, 0)
+
+	a.release()
+	if a.running != 0 {
+		t.Fatalf("expected no running queries, got %d", a.running)
+	}
+}
+
+// Ensure workers run queued interactive jobs before batch jobs.
+func TestWorker_Priority(t *testing.T) {
+	work, batchWork := make(chan job, 2), make(chan job, 2)
+	ch := make(chan mapResponse, 4)
+	newJob := func(shard uint64) job {
+		return job{
+			shard:      shard,
+			mapFn:      func(shard uint64) (interface{}, error) { return shard, nil },
+			ctx:        context.Background(),
+			resultChan: ch,
+		}
+	}
+	batchWork <- newJob(0)
+	batchWork <- newJob(1)
+	work <- newJob(2)
+	work <- newJob(3)
+	close(work)
+	close(batchWork)
+
+	worker(work, batchWork)
+	close(ch)
+
+	var shards []uint64
+	for resp := range ch {
+		shards = append(shards, resp.result.(uint64))
+	}
+	if exp := []uint64{2, 3, 0, 1}; !reflect.DeepEqual(shards, exp) {
+		t.Fatalf("expected shards %v, got %v", exp, shards)
+	}
+}
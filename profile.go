@@ -0,0 +1,179 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pilosa/pilosa/v2/toml"
+)
+
+// QueryProfile breaks down where a query spent its time, so that slow
+// queries can be diagnosed. It is included in a query's response when the
+// query is made with the profile option.
+type QueryProfile struct {
+	Parse   toml.Duration `json:"parse"`
+	Execute toml.Duration `json:"execute"`
+
+	// Time spent executing the query on each shard, by each node. A shard
+	// executed more than once, by several calls, is listed once with the
+	// total time.
+	Shards []ShardProfile `json:"shards"`
+
+	// Requests to other nodes executing part of the query.
+	Nodes []NodeProfile `json:"nodes,omitempty"`
+
+	ContainersScanned int64 `json:"containersScanned"`
+	CacheHits         int64 `json:"cacheHits"`
+}
+
+// ShardProfile is the time a node spent executing a query on a shard.
+type ShardProfile struct {
+	Shard   uint64        `json:"shard"`
+	Node    string        `json:"node"`
+	Execute toml.Duration `json:"execute"`
+}
+
+// NodeProfile is the time spent on the requests sent to another node to
+// execute part of a query. Network is the time taken by the requests, less
+// the time the node spent executing them.
+type NodeProfile struct {
+	Node     string        `json:"node"`
+	Requests int           `json:"requests"`
+	Network  toml.Duration `json:"network"`
+	Execute  toml.Duration `json:"execute"`
+}
+
+// queryProfile collects the profile of a query, which may be executing on
+// many shards concurrently. Its methods may be called on a nil queryProfile,
+// which does nothing.
+type queryProfile struct {
+	node string
+	scan *queryScan
+
+	mu     sync.Mutex
+	shards map[ShardProfile]time.Duration // keyed without Execute
+	nodes  map[string]*NodeProfile
+
+	remoteContainers int64 // accessed atomically
+	cacheHits        int64 // accessed atomically
+}
+
+type queryProfileKey struct{}
+
+// withQueryProfile returns a context which profiles a query executed on
+// node. Containers are counted by the context's queryScan, which is added if
+// there isn't one already.
+func withQueryProfile(ctx context.Context, node string) (context.Context, *queryProfile) {
+	scan := queryScanFromContext(ctx)
+	if scan == nil {
+		ctx, scan = withQueryScan(ctx)
+	}
+	p := &queryProfile{
+		node:   node,
+		scan:   scan,
+		shards: make(map[ShardProfile]time.Duration),
+		nodes:  make(map[string]*NodeProfile),
+	}
+	return context.WithValue(ctx, queryProfileKey{}, p), p
+}
+
+// queryProfileFromContext returns the query's profile, if it is being
+// profiled.
+func queryProfileFromContext(ctx context.Context) *queryProfile {
+	p, _ := ctx.Value(queryProfileKey{}).(*queryProfile)
+	return p
+}
+
+// shardExecuted records the time taken to execute part of the query on a
+// local shard.
+func (p *queryProfile) shardExecuted(shard uint64, d time.Duration) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.shards[ShardProfile{Shard: shard, Node: p.node}] += d
+}
+
+// rowRead records whether a row was read from a fragment's row cache.
+func (p *queryProfile) rowRead(cached bool) {
+	if p == nil || !cached {
+		return
+	}
+	atomic.AddInt64(&p.cacheHits, 1)
+}
+
+// remoteExecuted records a request to node which took d, and the profile
+// the node returned, if any.
+func (p *queryProfile) remoteExecuted(node string, d time.Duration, remote *QueryProfile) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	np := p.nodes[node]
+	if np == nil {
+		np = &NodeProfile{Node: node}
+		p.nodes[node] = np
+	}
+	np.Requests++
+	if remote == nil {
+		np.Network += toml.Duration(d)
+		return
+	}
+	np.Execute += remote.Execute
+	if network := d - time.Duration(remote.Execute); network > 0 {
+		np.Network += toml.Duration(network)
+	}
+	for _, sp := range remote.Shards {
+		p.shards[ShardProfile{Shard: sp.Shard, Node: sp.Node}] += time.Duration(sp.Execute)
+	}
+	atomic.AddInt64(&p.remoteContainers, remote.ContainersScanned)
+	atomic.AddInt64(&p.cacheHits, remote.CacheHits)
+}
+
+// report returns the profile of the query, which took parse to parse and
+// execute to execute.
+func (p *queryProfile) report(parse, execute time.Duration) *QueryProfile {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	qp := &QueryProfile{
+		Parse:             toml.Duration(parse),
+		Execute:           toml.Duration(execute),
+		Shards:            make([]ShardProfile, 0, len(p.shards)),
+		ContainersScanned: p.scan.count() + atomic.LoadInt64(&p.remoteContainers),
+		CacheHits:         atomic.LoadInt64(&p.cacheHits),
+	}
+	for sp, d := range p.shards {
+		sp.Execute = toml.Duration(d)
+		qp.Shards = append(qp.Shards, sp)
+	}
+	sort.Slice(qp.Shards, func(i, j int) bool {
+		if qp.Shards[i].Node != qp.Shards[j].Node {
+			return qp.Shards[i].Node < qp.Shards[j].Node
+		}
+		return qp.Shards[i].Shard < qp.Shards[j].Shard
+	})
+	for _, np := range p.nodes {
+		qp.Nodes = append(qp.Nodes, *np)
+	}
+	sort.Slice(qp.Nodes, func(i, j int) bool { return qp.Nodes[i].Node < qp.Nodes[j].Node })
+	return qp
+}
@@ -0,0 +1,423 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pilosa/pilosa/v2/logger"
+	"github.com/pkg/errors"
+)
+
+// quarantineDir is the directory in the holder's data directory which the
+// files of fragments that fail validation are moved to. It is hidden, so the
+// holder doesn't mistake it for an index.
+const quarantineDir = ".quarantine"
+
+// quarantineRetryInterval is how often the repair of quarantined fragments
+// which couldn't be repaired is retried.
+const quarantineRetryInterval = time.Minute
+
+// repairedExt is appended to the name of a quarantined file once its
+// fragment is repaired.
+const repairedExt = ".repaired"
+
+// quarantinedFileRegexp matches the names of quarantined fragment files,
+// which are the shard followed by the time they were quarantined.
+var quarantinedFileRegexp = regexp.MustCompile(`^(\d+)\.\d{8}T\d{6}\.\d{9}Z$`)
+
+// auditLogFile is the file in the quarantine directory which records each
+// fragment quarantined, and each attempt to repair one.
+const auditLogFile = "audit.log"
+
+// Events recorded in the audit log.
+const (
+	quarantineEventQuarantined  = "quarantined"
+	quarantineEventRepaired     = "repaired"
+	quarantineEventRepairFailed = "repairFailed"
+)
+
+// errNoReplicas is returned when a quarantined fragment can't be repaired
+// because its shard has no other replica. Its repair is not retried.
+var errNoReplicas = errors.New("no other replicas")
+
+// corruptFragmentError is returned when a fragment's file can't be read
+// because its contents are invalid, as opposed to it being inaccessible.
+type corruptFragmentError struct {
+	err error
+}
+
+func (e corruptFragmentError) Error() string { return e.err.Error() }
+
+// isCorruptFragment returns true if err is caused by a fragment's file
+// failing validation.
+func isCorruptFragment(err error) bool {
+	_, ok := errors.Cause(err).(corruptFragmentError)
+	return ok
+}
+
+// quarantinedFragment identifies a fragment whose file was quarantined, and
+// where the file was moved to.
+type quarantinedFragment struct {
+	Index string `json:"index"`
+	Field string `json:"field"`
+	View  string `json:"view"`
+	Shard uint64 `json:"shard"`
+	File  string `json:"file"`
+}
+
+// quarantineEvent is an entry in the audit log.
+type quarantineEvent struct {
+	Time  time.Time `json:"time"`
+	Event string    `json:"event"`
+	quarantinedFragment
+
+	// The node a fragment was repaired from.
+	Source string `json:"source,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// quarantine moves corrupt fragment files out of the holder, and keeps the
+// fragments which have yet to be repaired. Until they are, queries which read
+// their fields go to the other replicas of their shards instead.
+type quarantine struct {
+	path string
+
+	mu      sync.Mutex
+	pending []quarantinedFragment
+
+	// The fragments which have yet to be repaired, by quarantined file.
+	unrepaired map[string]quarantinedFragment
+
+	// Signalled when a fragment is quarantined.
+	notify chan struct{}
+
+	logger logger.Logger
+}
+
+func newQuarantine(path string) *quarantine {
+	return &quarantine{
+		path:       path,
+		unrepaired: make(map[string]quarantinedFragment),
+		notify:     make(chan struct{}, 1),
+		logger:     logger.NopLogger,
+	}
+}
+
+// load finds the quarantined files of fragments which have yet to be
+// repaired, so that their repair resumes after a restart. It is called before
+// the holder's indexes are opened.
+func (q *quarantine) load() error {
+	views, err := filepath.Glob(filepath.Join(q.path, "*", "*", "*"))
+	if err != nil {
+		return errors.Wrap(err, "listing views")
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, dir := range views {
+		fis, err := ioutil.ReadDir(dir)
+		if err != nil {
+			return errors.Wrap(err, "reading quarantine directory")
+		}
+		for _, fi := range fis {
+			m := quarantinedFileRegexp.FindStringSubmatch(fi.Name())
+			if m == nil || fi.IsDir() {
+				continue
+			}
+			shard, err := strconv.ParseUint(m[1], 10, 64)
+			if err != nil {
+				continue
+			}
+			view, field, index := filepath.Base(dir), filepath.Base(filepath.Dir(dir)), filepath.Base(filepath.Dir(filepath.Dir(dir)))
+			qf := quarantinedFragment{Index: index, Field: field, View: view, Shard: shard, File: filepath.Join(dir, fi.Name())}
+			q.pending = append(q.pending, qf)
+			q.unrepaired[qf.File] = qf
+		}
+	}
+	if len(q.pending) > 0 {
+		q.logger.Printf("ERROR: %d quarantined fragments have yet to be repaired", len(q.pending))
+		select {
+		case q.notify <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// add records that a fragment was quarantined because of cause.
+func (q *quarantine) add(qf quarantinedFragment, cause error) {
+	q.record(quarantineEvent{Event: quarantineEventQuarantined, quarantinedFragment: qf, Error: cause.Error()})
+
+	q.mu.Lock()
+	q.pending = append(q.pending, qf)
+	q.unrepaired[qf.File] = qf
+	q.mu.Unlock()
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+}
+
+// retry returns fragments which couldn't be repaired to those pending,
+// unless they were dropped in the meantime.
+func (q *quarantine) retry(qfs []quarantinedFragment) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, qf := range qfs {
+		if _, ok := q.unrepaired[qf.File]; ok {
+			q.pending = append(q.pending, qf)
+		}
+	}
+}
+
+// repaired records that a quarantined fragment was repaired, and marks its
+// file as such so it isn't repaired again after a restart.
+func (q *quarantine) repaired(qf quarantinedFragment) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	delete(q.unrepaired, qf.File)
+	return errors.Wrap(os.Rename(qf.File, qf.File+repairedExt), "marking file repaired")
+}
+
+// drop forgets the quarantined fragments of a field, or of all of an index's
+// fields if field is empty, and removes their files. It is called when the
+// field or index is deleted, since there is nothing left to repair.
+func (q *quarantine) drop(index, field string) error {
+	if q == nil {
+		return nil
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	dropped := func(qf quarantinedFragment) bool {
+		return qf.Index == index && (field == "" || qf.Field == field)
+	}
+	for file, qf := range q.unrepaired {
+		if dropped(qf) {
+			delete(q.unrepaired, file)
+		}
+	}
+	pending := q.pending[:0]
+	for _, qf := range q.pending {
+		if !dropped(qf) {
+			pending = append(pending, qf)
+		}
+	}
+	q.pending = pending
+
+	if err := os.RemoveAll(filepath.Join(q.path, index, field)); err != nil {
+		return errors.Wrap(err, "removing quarantined files")
+	}
+	return nil
+}
+
+// has returns true if a shard has quarantined fragments of any of fields, or
+// of the existence field, which have yet to be repaired.
+func (q *quarantine) has(index string, shard uint64, fields []string) bool {
+	if q == nil {
+		return false
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, qf := range q.unrepaired {
+		if qf.Index != index || qf.Shard != shard {
+			continue
+		} else if qf.Field == existenceFieldName {
+			return true
+		}
+		for _, field := range fields {
+			if qf.Field == field {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// take returns the fragments quarantined since it was last called.
+func (q *quarantine) take() []quarantinedFragment {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	pending := q.pending
+	q.pending = nil
+	return pending
+}
+
+// record appends an event to the audit log. Failures are logged, since the
+// log is only a record.
+func (q *quarantine) record(e quarantineEvent) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	e.Time = time.Now().UTC()
+	if err := func() error {
+		buf, err := json.Marshal(e)
+		if err != nil {
+			return errors.Wrap(err, "marshaling")
+		}
+		file, err := os.OpenFile(filepath.Join(q.path, auditLogFile), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0640)
+		if err != nil {
+			return errors.Wrap(err, "opening")
+		}
+		if _, err := file.Write(append(buf, '\n')); err != nil {
+			file.Close()
+			return errors.Wrap(err, "writing")
+		}
+		return file.Close()
+	}(); err != nil {
+		q.logger.Printf("recording %s fragment in audit log: %s", e.Event, err)
+	}
+}
+
+// quarantineFragment moves the data and cache files of a fragment which
+// failed to open because its file is corrupt out of the view, so that the
+// rest of the holder can open without it. The files are kept for
+// inspection, under the index, field and view they were found in.
+func (v *view) quarantineFragment(frag *fragment, cause error) error {
+	dir := filepath.Join(v.quarantine.path, v.index, v.field, v.name)
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return errors.Wrap(err, "creating quarantine directory")
+	}
+	dst := filepath.Join(dir, fmt.Sprintf("%d.%s", frag.shard, time.Now().UTC().Format("20060102T150405.000000000Z")))
+	if err := os.Rename(frag.path, dst); err != nil {
+		return errors.Wrap(err, "moving fragment file")
+	}
+	if err := os.Rename(frag.cachePath(), dst+cacheExt); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "moving cache file")
+	}
+	// Statistics are recomputed from the data when it is restored.
+	_ = os.Remove(frag.statsPath())
+
+	v.stats.Count("fragmentQuarantined", 1, 1.0)
+	v.logger.Printf("ERROR: fragment is corrupt and has been quarantined, its data will not be served: index=%s, field=%s, view=%s, shard=%d, file=%s, err=%s", v.index, v.field, v.name, frag.shard, dst, cause)
+	v.quarantine.add(quarantinedFragment{Index: v.index, Field: v.field, View: v.name, Shard: frag.shard, File: dst}, cause)
+	return nil
+}
+
+// repairFragments restores fragments quarantined as corrupt from their other
+// replicas as they are found, once the cluster is up. Fragments which can't
+// be repaired are retried periodically. This is run in a goroutine.
+func (s *Server) repairFragments() {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		if state := s.cluster.State(); state == ClusterStateNormal || state == ClusterStateDegraded {
+			break
+		}
+		select {
+		case <-s.closing:
+			return
+		case <-ticker.C:
+		}
+	}
+
+	q := s.holder.quarantine
+	for {
+		var failed []quarantinedFragment
+		for _, qf := range q.take() {
+			select {
+			case <-s.closing:
+				return
+			default:
+			}
+
+			if s.holder.Field(qf.Index, qf.Field) == nil {
+				// The field was deleted while the node was down.
+				if err := q.drop(qf.Index, qf.Field); err != nil {
+					s.logger.Printf("ERROR: dropping quarantined fragments of deleted field: index=%s, field=%s, err=%s", qf.Index, qf.Field, err)
+				}
+				continue
+			}
+			source, err := s.repairFragment(qf)
+			if err == errNoReplicas {
+				s.logger.Printf("ERROR: quarantined fragment can't be repaired, its shard has no other replica: index=%s, field=%s, view=%s, shard=%d", qf.Index, qf.Field, qf.View, qf.Shard)
+				q.record(quarantineEvent{Event: quarantineEventRepairFailed, quarantinedFragment: qf, Error: err.Error()})
+				continue
+			} else if err != nil {
+				s.logger.Printf("ERROR: repairing quarantined fragment, will retry: index=%s, field=%s, view=%s, shard=%d, err=%s", qf.Index, qf.Field, qf.View, qf.Shard, err)
+				q.record(quarantineEvent{Event: quarantineEventRepairFailed, quarantinedFragment: qf, Error: err.Error()})
+				failed = append(failed, qf)
+				continue
+			}
+			if err := q.repaired(qf); err != nil {
+				s.logger.Printf("ERROR: recording repaired fragment: file=%s, err=%s", qf.File, err)
+			}
+			s.holder.Stats.Count("fragmentRepaired", 1, 1.0)
+			s.logger.Printf("repaired quarantined fragment from node %s: index=%s, field=%s, view=%s, shard=%d", source, qf.Index, qf.Field, qf.View, qf.Shard)
+			q.record(quarantineEvent{Event: quarantineEventRepaired, quarantinedFragment: qf, Source: source})
+		}
+
+		var retry <-chan time.Time
+		if len(failed) > 0 {
+			q.retry(failed)
+			retry = time.After(quarantineRetryInterval)
+		}
+		select {
+		case <-s.closing:
+			return
+		case <-q.notify:
+		case <-retry:
+		}
+	}
+}
+
+// repairFragment restores a quarantined fragment from the first of its other
+// replicas which has a copy, and returns the ID of that node.
+func (s *Server) repairFragment(qf quarantinedFragment) (string, error) {
+	err := errNoReplicas
+	for _, node := range s.cluster.ShardNodes(qf.Index, qf.Shard) {
+		if node.ID == s.nodeID {
+			continue
+		}
+		if err = s.restoreFragment(qf, node); err == nil {
+			return node.ID, nil
+		}
+		err = errors.Wrapf(err, "node %s", node.ID)
+	}
+	return "", err
+}
+
+// restoreFragment replaces the data of a fragment with a copy from node.
+func (s *Server) restoreFragment(qf quarantinedFragment, node *Node) error {
+	rd, err := s.cluster.InternalClient.RetrieveShardFromURI(context.Background(), qf.Index, qf.Field, qf.View, qf.Shard, *node.clusterURI())
+	if err != nil {
+		return errors.Wrap(err, "retrieving shard")
+	}
+	defer rd.Close()
+
+	f := s.holder.Field(qf.Index, qf.Field)
+	if f == nil {
+		return newNotFoundError(ErrFieldNotFound, qf.Field)
+	}
+	v, err := f.createViewIfNotExists(qf.View)
+	if err != nil {
+		return errors.Wrap(err, "creating view")
+	}
+	frag, err := v.CreateFragmentIfNotExists(qf.Shard)
+	if err != nil {
+		return errors.Wrap(err, "creating fragment")
+	}
+	if _, err := frag.ReadFrom(rd); err != nil {
+		return errors.Wrap(err, "copying remote shard")
+	}
+	return nil
+}
@@ -0,0 +1,390 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pilosa/pilosa/v2/tracing"
+	"github.com/pkg/errors"
+)
+
+const (
+	// hotShardN is the number of the hottest shards each node reports in
+	// its load.
+	hotShardN = 32
+
+	// rebalanceThreshold is how many times the mean reads the reads of the
+	// busiest node may reach before its hot shards are moved.
+	rebalanceThreshold = 1.25
+
+	// rebalanceMaxMoves is the most shards moved by one rebalance.
+	rebalanceMaxMoves = 8
+)
+
+// ShardLoad is the number of reads and writes of a shard on a node in an
+// interval. Each call of a query which reads the shard counts as a read, and
+// each write to it, or import into it, as a write.
+type ShardLoad struct {
+	Index  string `json:"index"`
+	Shard  uint64 `json:"shard"`
+	Reads  uint64 `json:"reads"`
+	Writes uint64 `json:"writes"`
+}
+
+// ShardReadOwner is the node which serves the reads of a shard.
+type ShardReadOwner struct {
+	Index string `json:"index"`
+	Shard uint64 `json:"shard"`
+	Node  string `json:"node"`
+}
+
+// ShardMove is the move of the reads of a shard from one of its replicas to
+// another.
+type ShardMove struct {
+	Index string `json:"index"`
+	Shard uint64 `json:"shard"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Reads uint64 `json:"reads"`
+}
+
+// RebalancePlan is the result of rebalancing the reads of hot shards.
+type RebalancePlan struct {
+	// DryRun is true if the plan was not applied.
+	DryRun bool `json:"dryRun"`
+
+	// Reads of the hot shards served by each node, before and after the
+	// moves, in the latest sample of the nodes' load.
+	Before map[string]uint64 `json:"before"`
+	After  map[string]uint64 `json:"after"`
+
+	Moves []ShardMove `json:"moves"`
+
+	// ReadOwners are the replicas which serve the reads of shards in place
+	// of their primary owners once the plan is applied.
+	ReadOwners []ShardReadOwner `json:"readOwners"`
+}
+
+// ReadOwnersMessage is an internal message replacing the replicas which serve
+// the reads of shards in place of their primary owners.
+type ReadOwnersMessage struct {
+	Owners []ShardReadOwner
+}
+
+// shardKey identifies a shard of an index.
+type shardKey struct {
+	index string
+	shard uint64
+}
+
+// shardLoadTracker counts the reads and writes of each shard on a node.
+type shardLoadTracker struct {
+	mu     sync.Mutex
+	counts map[shardKey]*ShardLoad
+}
+
+func newShardLoadTracker() *shardLoadTracker {
+	return &shardLoadTracker{counts: make(map[shardKey]*ShardLoad)}
+}
+
+// get returns the counts of a shard. t.mu must be held.
+func (t *shardLoadTracker) get(index string, shard uint64) *ShardLoad {
+	k := shardKey{index: index, shard: shard}
+	l, ok := t.counts[k]
+	if !ok {
+		l = &ShardLoad{Index: index, Shard: shard}
+		t.counts[k] = l
+	}
+	return l
+}
+
+// countReads records a read of each of the shards.
+func (t *shardLoadTracker) countReads(index string, shards []uint64) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, shard := range shards {
+		t.get(index, shard).Reads++
+	}
+}
+
+// countWrite records a write to a shard.
+func (t *shardLoadTracker) countWrite(index string, shard uint64) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.get(index, shard).Writes++
+}
+
+// sample returns the counts of the n shards with the most reads and writes
+// since the previous sample, and starts counting again.
+func (t *shardLoadTracker) sample(n int) []ShardLoad {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	counts := t.counts
+	t.counts = make(map[shardKey]*ShardLoad)
+	t.mu.Unlock()
+
+	loads := make([]ShardLoad, 0, len(counts))
+	for _, l := range counts {
+		loads = append(loads, *l)
+	}
+	sort.Slice(loads, func(i, j int) bool {
+		if a, b := loads[i].Reads+loads[i].Writes, loads[j].Reads+loads[j].Writes; a != b {
+			return a > b
+		} else if loads[i].Index != loads[j].Index {
+			return loads[i].Index < loads[j].Index
+		}
+		return loads[i].Shard < loads[j].Shard
+	})
+	if len(loads) > n {
+		loads = loads[:n]
+	}
+	return loads
+}
+
+// readNodes returns the owners of a shard in the order they are read from:
+// the replica serving its reads in place of the primary owner first, if the
+// rebalancer moved them, and then the owners in order. Safe for concurrent
+// use.
+func (c *cluster) readNodes(index string, shard uint64) []*Node {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	nodes := c.shardNodes(index, shard)
+	id, ok := c.readOwners[shardKey{index: index, shard: shard}]
+	if !ok || len(nodes) == 0 || nodes[0].ID == id {
+		return nodes
+	}
+	for i, node := range nodes {
+		if node.ID == id {
+			ordered := make([]*Node, 0, len(nodes))
+			ordered = append(ordered, node)
+			ordered = append(ordered, nodes[:i]...)
+			return append(ordered, nodes[i+1:]...)
+		}
+	}
+	return nodes
+}
+
+// setReadOwners replaces the replicas serving the reads of shards in place of
+// their primary owners.
+func (c *cluster) setReadOwners(owners []ShardReadOwner) {
+	m := make(map[shardKey]string, len(owners))
+	for _, o := range owners {
+		m[shardKey{index: o.Index, shard: o.Shard}] = o.Node
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.readOwners = m
+}
+
+// planRebalance moves the reads of the hottest shards of the busiest nodes to
+// their other replicas until no node serves more than rebalanceThreshold times
+// the mean reads, no move would reduce the busiest node's reads, or maxMoves
+// shards have been moved. Only the hot shards reported in the nodes' loads
+// are considered, and only nodes which reported their load receive reads.
+// owners returns the IDs of the owners of a shard, the primary first, and
+// current the replicas currently serving the reads of shards.
+func planRebalance(loads map[string]NodeLoad, owners func(index string, shard uint64) []string, current map[shardKey]string, maxMoves int) *RebalancePlan {
+	reads := make(map[shardKey]uint64)
+	nodeReads := make(map[string]uint64, len(loads))
+	for id, load := range loads {
+		nodeReads[id] = 0
+		for _, l := range load.HotShards {
+			reads[shardKey{index: l.Index, shard: l.Shard}] += l.Reads
+		}
+	}
+
+	// Assign each hot shard to the replica currently serving its reads.
+	keys := make([]shardKey, 0, len(reads))
+	for k := range reads {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if reads[keys[i]] != reads[keys[j]] {
+			return reads[keys[i]] > reads[keys[j]]
+		} else if keys[i].index != keys[j].index {
+			return keys[i].index < keys[j].index
+		}
+		return keys[i].shard < keys[j].shard
+	})
+	shardOwners := make(map[shardKey][]string, len(keys))
+	reader := make(map[shardKey]string, len(keys))
+	for _, k := range keys {
+		ids := owners(k.index, k.shard)
+		if len(ids) == 0 {
+			continue
+		}
+		shardOwners[k] = ids
+		reader[k] = ids[0]
+		for _, id := range ids {
+			if id == current[k] {
+				reader[k] = id
+			}
+		}
+		if _, ok := nodeReads[reader[k]]; ok {
+			nodeReads[reader[k]] += reads[k]
+		}
+	}
+
+	plan := &RebalancePlan{Before: make(map[string]uint64, len(nodeReads)), Moves: []ShardMove{}}
+	for id, n := range nodeReads {
+		plan.Before[id] = n
+	}
+
+	for len(plan.Moves) < maxMoves && len(nodeReads) > 1 {
+		var busiest string
+		var total uint64
+		for id, n := range nodeReads {
+			total += n
+			if busiest == "" || n > nodeReads[busiest] || (n == nodeReads[busiest] && id < busiest) {
+				busiest = id
+			}
+		}
+		mean := float64(total) / float64(len(nodeReads))
+		if float64(nodeReads[busiest]) <= mean*rebalanceThreshold {
+			break
+		}
+
+		// Move the hottest shard which another replica can take without
+		// becoming busier than the busiest node was.
+		var move *ShardMove
+		for _, k := range keys {
+			if reader[k] != busiest {
+				continue
+			}
+			var to string
+			for _, id := range shardOwners[k] {
+				n, ok := nodeReads[id]
+				if !ok || id == busiest || n+reads[k] >= nodeReads[busiest] {
+					continue
+				} else if to == "" || n < nodeReads[to] {
+					to = id
+				}
+			}
+			if to != "" {
+				move = &ShardMove{Index: k.index, Shard: k.shard, From: busiest, To: to, Reads: reads[k]}
+				reader[k] = to
+				break
+			}
+		}
+		if move == nil {
+			break
+		}
+		nodeReads[move.From] -= move.Reads
+		nodeReads[move.To] += move.Reads
+		plan.Moves = append(plan.Moves, *move)
+	}
+
+	plan.After = nodeReads
+	plan.ReadOwners = []ShardReadOwner{}
+	for _, k := range keys {
+		if ids, ok := shardOwners[k]; ok && reader[k] != ids[0] {
+			plan.ReadOwners = append(plan.ReadOwners, ShardReadOwner{Index: k.index, Shard: k.shard, Node: reader[k]})
+		}
+	}
+	return plan
+}
+
+// rebalance plans the moves of the reads of hot shards from the latest load
+// of each node, and unless dryRun is true, applies them on every node.
+func (s *Server) rebalance(dryRun bool) (*RebalancePlan, error) {
+	s.cluster.mu.RLock()
+	current := make(map[shardKey]string, len(s.cluster.readOwners))
+	for k, id := range s.cluster.readOwners {
+		current[k] = id
+	}
+	s.cluster.mu.RUnlock()
+
+	owners := func(index string, shard uint64) []string {
+		var ids []string
+		for _, node := range s.cluster.ShardNodes(index, shard) {
+			ids = append(ids, node.ID)
+		}
+		return ids
+	}
+	plan := planRebalance(s.clusterLoad(), owners, current, rebalanceMaxMoves)
+	plan.DryRun = dryRun
+	if dryRun {
+		return plan, nil
+	}
+
+	s.cluster.setReadOwners(plan.ReadOwners)
+	err := s.SendSync(&ReadOwnersMessage{Owners: plan.ReadOwners})
+	return plan, errors.Wrap(err, "sending ReadOwners message")
+}
+
+// monitorRebalance periodically rebalances the reads of hot shards, if a
+// rebalance interval is set. Only the coordinator rebalances, and only when
+// the shards have replicas to move their reads to. This is run in a
+// goroutine.
+func (s *Server) monitorRebalance() {
+	if s.rebalanceInterval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(s.rebalanceInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.closing:
+			return
+		case <-ticker.C:
+			if !s.cluster.isCoordinator() || s.cluster.State() != ClusterStateNormal || s.cluster.ReplicaN < 2 {
+				continue
+			} else if err := s.cluster.validateFeatures(FeatureReadRebalance); err != nil {
+				continue
+			}
+			plan, err := s.rebalance(false)
+			if err != nil {
+				s.logger.Printf("rebalancing shard reads: %s", err)
+			} else if len(plan.Moves) > 0 {
+				s.logger.Printf("rebalanced shard reads: moves=%d, owners=%d", len(plan.Moves), len(plan.ReadOwners))
+			}
+		}
+	}
+}
+
+// RebalanceShards moves the reads of the hottest shards of the busiest nodes
+// to other replicas of the shards, so that CPU load is spread evenly over the
+// nodes. Shards' data is not moved, so only shards with replicas can be
+// rebalanced. If dryRun is true, the plan is returned without being applied.
+func (api *API) RebalanceShards(ctx context.Context, dryRun bool) (*RebalancePlan, error) {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.RebalanceShards")
+	defer span.Finish()
+
+	if err := api.validate(apiRebalanceShards); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+
+	// Nodes which do not handle the message would keep reading from the
+	// primary owners.
+	if !dryRun {
+		if err := api.cluster.validateFeatures(FeatureReadRebalance); err != nil {
+			return nil, errors.Wrap(err, "rebalancing shards")
+		}
+	}
+	return api.server.rebalance(dryRun)
+}
@@ -0,0 +1,105 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Ensure the hottest shards are sampled, and the counts reset.
+func TestShardLoadTracker_Sample(t *testing.T) {
+	tr := newShardLoadTracker()
+	tr.countReads("i", []uint64{0, 1})
+	tr.countReads("i", []uint64{1})
+	tr.countWrite("j", 0)
+
+	exp := []ShardLoad{
+		{Index: "i", Shard: 1, Reads: 2},
+		{Index: "i", Shard: 0, Reads: 1},
+	}
+	if loads := tr.sample(2); !reflect.DeepEqual(loads, exp) {
+		t.Fatalf("unexpected sample: %+v", loads)
+	} else if loads := tr.sample(2); len(loads) != 0 {
+		t.Fatalf("expected empty sample: %+v", loads)
+	}
+
+	var nilTracker *shardLoadTracker
+	nilTracker.countWrite("i", 0)
+	if loads := nilTracker.sample(2); loads != nil {
+		t.Fatalf("unexpected sample: %+v", loads)
+	}
+}
+
+// Ensure a shard is read from the replica its reads were moved to first.
+func TestCluster_ReadNodes(t *testing.T) {
+	c := newCluster()
+	c.ReplicaN = 3
+	c.nodes = []*Node{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+
+	owners := c.shardNodes("i", 0)
+	if nodes := c.readNodes("i", 0); !reflect.DeepEqual(nodes, owners) {
+		t.Fatalf("unexpected read nodes: %v", nodes)
+	}
+
+	c.setReadOwners([]ShardReadOwner{{Index: "i", Shard: 0, Node: owners[2].ID}})
+	exp := []*Node{owners[2], owners[0], owners[1]}
+	if nodes := c.readNodes("i", 0); !reflect.DeepEqual(nodes, exp) {
+		t.Fatalf("unexpected read nodes: %v", nodes)
+	} else if nodes := c.readNodes("i", 1); !reflect.DeepEqual(nodes, c.shardNodes("i", 1)) {
+		t.Fatalf("unexpected read nodes: %v", nodes)
+	}
+}
+
+func TestPlanRebalance(t *testing.T) {
+	loads := map[string]NodeLoad{
+		"a": {HotShards: []ShardLoad{{Index: "i", Shard: 0, Reads: 500}, {Index: "i", Shard: 1, Reads: 400}}},
+		"b": {HotShards: []ShardLoad{{Index: "i", Shard: 2, Reads: 100}}},
+		"c": {},
+	}
+	owners := func(index string, shard uint64) []string {
+		return map[uint64][]string{0: {"a", "c"}, 1: {"a", "b"}, 2: {"b", "a"}}[shard]
+	}
+
+	t.Run("Move", func(t *testing.T) {
+		plan := planRebalance(loads, owners, nil, rebalanceMaxMoves)
+		if exp := map[string]uint64{"a": 900, "b": 100, "c": 0}; !reflect.DeepEqual(plan.Before, exp) {
+			t.Fatalf("unexpected reads before: %v", plan.Before)
+		} else if exp := map[string]uint64{"a": 400, "b": 100, "c": 500}; !reflect.DeepEqual(plan.After, exp) {
+			t.Fatalf("unexpected reads after: %v", plan.After)
+		} else if exp := []ShardMove{{Index: "i", Shard: 0, From: "a", To: "c", Reads: 500}}; !reflect.DeepEqual(plan.Moves, exp) {
+			t.Fatalf("unexpected moves: %+v", plan.Moves)
+		} else if exp := []ShardReadOwner{{Index: "i", Shard: 0, Node: "c"}}; !reflect.DeepEqual(plan.ReadOwners, exp) {
+			t.Fatalf("unexpected read owners: %+v", plan.ReadOwners)
+		}
+	})
+
+	// Shards already moved stay with their replica when balanced.
+	t.Run("Current", func(t *testing.T) {
+		plan := planRebalance(loads, owners, map[shardKey]string{{index: "i", shard: 0}: "c"}, rebalanceMaxMoves)
+		if len(plan.Moves) != 0 {
+			t.Fatalf("unexpected moves: %+v", plan.Moves)
+		} else if exp := []ShardReadOwner{{Index: "i", Shard: 0, Node: "c"}}; !reflect.DeepEqual(plan.ReadOwners, exp) {
+			t.Fatalf("unexpected read owners: %+v", plan.ReadOwners)
+		}
+	})
+
+	t.Run("MaxMoves", func(t *testing.T) {
+		plan := planRebalance(loads, owners, nil, 0)
+		if len(plan.Moves) != 0 || len(plan.ReadOwners) != 0 {
+			t.Fatalf("unexpected plan: %+v", plan)
+		}
+	})
+}
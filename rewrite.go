@@ -0,0 +1,352 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pilosa/pilosa/v2/pql"
+	"github.com/pkg/errors"
+)
+
+// Rewrite rule errors.
+var (
+	ErrRewriteRuleNotFound = errors.New("rewrite rule not found")
+	ErrRewriteRuleExists   = errors.New("rewrite rule already exists")
+)
+
+// rewriteFile is the file in the index directory which records the index's
+// rewrite rules.
+const rewriteFile = ".rewrite"
+
+// maxRewriteDepth is the number of times a call may be rewritten, so that
+// rules which expand into each other fail rather than loop.
+const maxRewriteDepth = 16
+
+// RewriteRule replaces each call in a query of an index which is the same as
+// Match with Replace, before the query is executed. It lets the definitions
+// shared by many clients, such as Segment(name=vip), be kept in one place.
+//
+// Match must be a call which the executor does not implement itself, so
+// rules cannot change the meaning of other queries. Replace may use other
+// rules, but may not write.
+type RewriteRule struct {
+	Name    string `json:"name"`
+	Match   string `json:"match"`
+	Replace string `json:"replace"`
+}
+
+// CreateRewriteRuleMessage is an internal message indicating that a rewrite
+// rule was created or replaced. Version orders the changes to a rule, so
+// that nodes merging the rules of others keep the latest.
+type CreateRewriteRuleMessage struct {
+	Index   string
+	Rule    RewriteRule
+	Version int64
+}
+
+// DeleteRewriteRuleMessage is an internal message indicating that a rewrite
+// rule was deleted.
+type DeleteRewriteRuleMessage struct {
+	Index   string
+	Name    string
+	Version int64
+}
+
+// rewriteRule is a rewrite rule with its calls parsed.
+type rewriteRule struct {
+	RewriteRule
+	version int64
+
+	match   *pql.Call
+	replace *pql.Call
+}
+
+// rewriteRuleMeta is a rewrite rule as recorded in the index directory. The
+// rules which were deleted are recorded too, so that an earlier version
+// isn't restored from another node.
+type rewriteRuleMeta struct {
+	RewriteRule
+	Version int64 `json:"version,omitempty"`
+	Deleted bool  `json:"deleted,omitempty"`
+}
+
+// newRewriteRule parses and checks the calls of a rewrite rule.
+func newRewriteRule(rule RewriteRule) (*rewriteRule, error) {
+	if err := validateName(rule.Name); err != nil {
+		return nil, err
+	}
+	match, err := parseRewriteCall(rule.Match)
+	if err != nil {
+		return nil, errors.Wrap(err, "match")
+	} else if knownCalls[match.Name] {
+		return nil, NewBadRequestError(errors.Errorf("%s() cannot be rewritten", match.Name))
+	}
+	replace, err := parseRewriteCall(rule.Replace)
+	if err != nil {
+		return nil, errors.Wrap(err, "replace")
+	}
+
+	var check func(c *pql.Call) error
+	check = func(c *pql.Call) error {
+		if writeCalls[c.Name] {
+			return errors.Errorf("%s() cannot be used in a rewrite rule", c.Name)
+		}
+		for _, child := range c.Children {
+			if err := check(child); err != nil {
+				return err
+			}
+		}
+		for _, v := range c.Args {
+			if child, ok := v.(*pql.Call); ok {
+				if err := check(child); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	if err := check(replace); err != nil {
+		return nil, NewBadRequestError(err)
+	}
+
+	return &rewriteRule{RewriteRule: rule, match: match, replace: replace}, nil
+}
+
+// parseRewriteCall parses one of the calls of a rewrite rule.
+func parseRewriteCall(s string) (*pql.Call, error) {
+	q, err := pql.NewParser(strings.NewReader(s)).Parse()
+	if err != nil {
+		return nil, NewBadRequestError(errors.Wrap(err, "parsing"))
+	} else if len(q.Calls) != 1 {
+		return nil, NewBadRequestError(errors.New("rewrite rule must be a single call"))
+	}
+	return q.Calls[0], nil
+}
+
+// RewriteRules returns the rewrite rules of the index, sorted by name.
+func (i *Index) RewriteRules() []RewriteRule {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	rules := make([]RewriteRule, 0, len(i.rewriteRules))
+	for _, r := range i.rewriteRules {
+		rules = append(rules, r.RewriteRule)
+	}
+	sort.Slice(rules, func(a, b int) bool { return rules[a].Name < rules[b].Name })
+	return rules
+}
+
+// rewriteRule returns the rewrite rule named name, or nil.
+func (i *Index) rewriteRule(name string) *rewriteRule {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.rewriteRules[name]
+}
+
+// rewriteRuleDefinitions returns the rewrite rules of the index, and the
+// rules which were deleted, with their versions, as they are sent to other
+// nodes.
+func (i *Index) rewriteRuleDefinitions() ([]*CreateRewriteRuleMessage, []*DeleteRewriteRuleMessage) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	rules := make([]*CreateRewriteRuleMessage, 0, len(i.rewriteRules))
+	for _, r := range i.rewriteRules {
+		rules = append(rules, &CreateRewriteRuleMessage{Index: i.name, Rule: r.RewriteRule, Version: r.version})
+	}
+	deleted := make([]*DeleteRewriteRuleMessage, 0, len(i.deletedRewriteRules))
+	for name, version := range i.deletedRewriteRules {
+		deleted = append(deleted, &DeleteRewriteRuleMessage{Index: i.name, Name: name, Version: version})
+	}
+	return rules, deleted
+}
+
+// unprotectedRewriteRuleVersion returns the version of the rewrite rule named
+// name, or of its deletion, or zero if there is neither.
+func (i *Index) unprotectedRewriteRuleVersion(name string) int64 {
+	if r := i.rewriteRules[name]; r != nil {
+		return r.version
+	}
+	return i.deletedRewriteRules[name]
+}
+
+// createRewriteRule adds a rewrite rule, replacing any rule of the same
+// name. Another rule may not match the same call. A zero version makes the
+// change a new one, later than the rule's current version; otherwise the
+// change is ignored unless its version is later. Returns the version of the
+// change, or zero if it was ignored.
+func (i *Index) createRewriteRule(rule RewriteRule, version int64) (int64, error) {
+	r, err := newRewriteRule(rule)
+	if err != nil {
+		return 0, err
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if current := i.unprotectedRewriteRuleVersion(r.Name); version == 0 {
+		version = nextVersion(current)
+	} else if version <= current {
+		return 0, nil
+	}
+	for name, other := range i.rewriteRules {
+		if name != r.Name && other.match.String() == r.match.String() {
+			return 0, errors.Wrapf(NewConflictError(ErrRewriteRuleExists), "%s matches %s", name, r.match)
+		}
+	}
+	r.version = version
+	i.rewriteRules[r.Name] = r
+	delete(i.deletedRewriteRules, r.Name)
+	return version, i.saveRewriteRules()
+}
+
+// deleteRewriteRule removes the rewrite rule named name. Versions are as for
+// createRewriteRule, except that a new change to a rule which doesn't exist
+// fails. A deletion is recorded even if the rule doesn't exist, so that an
+// earlier version of it isn't created later.
+func (i *Index) deleteRewriteRule(name string, version int64) (int64, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if current := i.unprotectedRewriteRuleVersion(name); version == 0 {
+		if i.rewriteRules[name] == nil {
+			return 0, newNotFoundError(ErrRewriteRuleNotFound, name)
+		}
+		version = nextVersion(current)
+	} else if version <= current {
+		return 0, nil
+	}
+	delete(i.rewriteRules, name)
+	i.deletedRewriteRules[name] = version
+	return version, i.saveRewriteRules()
+}
+
+// rewriteCalls replaces the calls, and the calls within them, which match a
+// rewrite rule of the index.
+func (i *Index) rewriteCalls(calls []*pql.Call) error {
+	i.mu.RLock()
+	rules := make(map[string]*pql.Call, len(i.rewriteRules))
+	names := make(map[string]bool, len(i.rewriteRules))
+	for _, r := range i.rewriteRules {
+		rules[r.match.String()] = r.replace
+		names[r.match.Name] = true
+	}
+	i.mu.RUnlock()
+	if len(rules) == 0 {
+		return nil
+	}
+
+	var rewrite func(c *pql.Call, depth int) (*pql.Call, error)
+	rewrite = func(c *pql.Call, depth int) (*pql.Call, error) {
+		for names[c.Name] {
+			replace, ok := rules[c.String()]
+			if !ok {
+				break
+			} else if depth == maxRewriteDepth {
+				return nil, errors.Errorf("rewriting %s: rules nested too deeply", c)
+			}
+			c, depth = replace.Clone(), depth+1
+		}
+		for j, child := range c.Children {
+			child, err := rewrite(child, depth)
+			if err != nil {
+				return nil, err
+			}
+			c.Children[j] = child
+		}
+		for k, v := range c.Args {
+			if child, ok := v.(*pql.Call); ok {
+				child, err := rewrite(child, depth)
+				if err != nil {
+					return nil, err
+				}
+				c.Args[k] = child
+			}
+		}
+		return c, nil
+	}
+	for j, c := range calls {
+		c, err := rewrite(c, 0)
+		if err != nil {
+			return err
+		}
+		calls[j] = c
+	}
+	return nil
+}
+
+// loadRewriteRules reads the index's rewrite rules.
+func (i *Index) loadRewriteRules() error {
+	if i.inMemory {
+		return nil
+	}
+	buf, err := ioutil.ReadFile(filepath.Join(i.path, rewriteFile))
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "reading rewrite rules file")
+	}
+	var metas []rewriteRuleMeta
+	if err := json.Unmarshal(buf, &metas); err != nil {
+		return errors.Wrap(err, "decoding rewrite rules file")
+	}
+
+	i.rewriteRules = make(map[string]*rewriteRule, len(metas))
+	i.deletedRewriteRules = make(map[string]int64)
+	for _, m := range metas {
+		if m.Deleted {
+			i.deletedRewriteRules[m.Name] = m.Version
+			continue
+		}
+		r, err := newRewriteRule(m.RewriteRule)
+		if err != nil {
+			return errors.Wrapf(err, "rewrite rule %s", m.Name)
+		}
+		r.version = m.Version
+		i.rewriteRules[r.Name] = r
+	}
+	return nil
+}
+
+// saveRewriteRules writes the index's rewrite rules to the index directory.
+func (i *Index) saveRewriteRules() error {
+	if i.inMemory {
+		return nil
+	}
+	metas := make([]rewriteRuleMeta, 0, len(i.rewriteRules)+len(i.deletedRewriteRules))
+	for _, r := range i.rewriteRules {
+		metas = append(metas, rewriteRuleMeta{RewriteRule: r.RewriteRule, Version: r.version})
+	}
+	for name, version := range i.deletedRewriteRules {
+		metas = append(metas, rewriteRuleMeta{RewriteRule: RewriteRule{Name: name}, Version: version, Deleted: true})
+	}
+	sort.Slice(metas, func(a, b int) bool { return metas[a].Name < metas[b].Name })
+	buf, err := json.Marshal(metas)
+	if err != nil {
+		return errors.Wrap(err, "encoding rewrite rules file")
+	}
+
+	path := filepath.Join(i.path, rewriteFile)
+	tempPath := path + tempExt
+	if err := ioutil.WriteFile(tempPath, buf, 0666); err != nil {
+		return errors.Wrap(err, "writing rewrite rules file")
+	}
+	return errors.Wrap(os.Rename(tempPath, path), "renaming rewrite rules file")
+}
@@ -0,0 +1,90 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pilosa/pilosa/v2/pql"
+)
+
+func TestIndex_RewriteCalls(t *testing.T) {
+	h := newHolder()
+	if err := h.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+	idx := h.MustCreateIndexIfNotExists("i", IndexOptions{})
+
+	for _, rule := range []RewriteRule{
+		{Name: "vip", Match: "Segment(name=vip)", Replace: "Intersect(Row(f=1), Segment(name=active))"},
+		{Name: "active", Match: "Segment(name=active)", Replace: "Row(g=1)"},
+		{Name: "loop", Match: "Loop()", Replace: "Not(Loop())"},
+	} {
+		if _, err := idx.createRewriteRule(rule, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rewrite := func(s string) (string, error) {
+		q, err := pql.NewParser(strings.NewReader(s)).Parse()
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = idx.rewriteCalls(q.Calls)
+		return q.String(), err
+	}
+
+	// Rules are applied within the replacements of other rules, and only
+	// to calls with the same arguments.
+	if got, err := rewrite("Count(Segment(name=vip)) Segment(name=other)"); err != nil {
+		t.Fatal(err)
+	} else if exp := "Count(Intersect(Row(f=1), Row(g=1)))\n" + `Segment(name="other")`; got != exp {
+		t.Fatalf("unexpected query:\n%s", got)
+	}
+	if _, err := rewrite("Loop()"); err == nil {
+		t.Fatal("expected error for rules nested too deeply")
+	}
+
+	// Rules are kept across restarts.
+	if err := h.Holder.Close(); err != nil {
+		t.Fatal(err)
+	} else if err := h.Reopen(); err != nil {
+		t.Fatal(err)
+	} else if rules := h.Index("i").RewriteRules(); len(rules) != 3 || rules[0].Name != "active" {
+		t.Fatalf("unexpected rules: %+v", rules)
+	}
+
+	// So are deletions, which earlier versions of the rule don't undo.
+	idx = h.Index("i")
+	version, err := idx.deleteRewriteRule("loop", 0)
+	if err != nil {
+		t.Fatal(err)
+	} else if err := h.Holder.Close(); err != nil {
+		t.Fatal(err)
+	} else if err := h.Reopen(); err != nil {
+		t.Fatal(err)
+	}
+	idx = h.Index("i")
+	if v, err := idx.createRewriteRule(RewriteRule{Name: "loop", Match: "Loop()", Replace: "Row(g=1)"}, version); err != nil {
+		t.Fatal(err)
+	} else if v != 0 || idx.rewriteRule("loop") != nil {
+		t.Fatalf("unexpected rule at version %d", v)
+	}
+	if _, deleted := idx.rewriteRuleDefinitions(); len(deleted) != 1 || deleted[0].Name != "loop" || deleted[0].Version != version {
+		t.Fatalf("unexpected deletions: %+v", deleted)
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roaring
+
+// Bitmap kernels operate on equal-length slices of words, typically the
+// bitmapN words of a bitmap container. They dominate the CPU time of most
+// queries, so platform-specific implementations may replace the generic
+// ones at init time (see kernels_amd64.go). All implementations must return
+// identical results.
+var (
+	// popcountSlice returns the number of bits set in s.
+	popcountSlice = popcountSliceGeneric
+
+	// popcountAndSlice returns the number of bits set in both s and m.
+	popcountAndSlice = popcountAndSliceGeneric
+
+	// andSlice sets dst to a&b and returns the number of bits set in dst.
+	andSlice = andSliceGeneric
+
+	// orSlice sets dst to a|b and returns the number of bits set in dst.
+	orSlice = orSliceGeneric
+)
+
+// kernelsName describes the selected kernel implementation.
+var kernelsName = "generic"
+
+func popcountSliceGeneric(s []uint64) uint64 {
+	cnt := uint64(0)
+	for _, v := range s {
+		cnt += popcount(v)
+	}
+	return cnt
+}
+
+func popcountAndSliceGeneric(s, m []uint64) uint64 {
+	m = m[:len(s)]
+	cnt := uint64(0)
+	for i := range s {
+		cnt += popcount(s[i] & m[i])
+	}
+	return cnt
+}
+
+func andSliceGeneric(dst, a, b []uint64) uint64 {
+	a, b = a[:len(dst)], b[:len(dst)]
+	cnt := uint64(0)
+	for i := range dst {
+		dst[i] = a[i] & b[i]
+		cnt += popcount(dst[i])
+	}
+	return cnt
+}
+
+func orSliceGeneric(dst, a, b []uint64) uint64 {
+	a, b = a[:len(dst)], b[:len(dst)]
+	cnt := uint64(0)
+	for i := range dst {
+		dst[i] = a[i] | b[i]
+		cnt += popcount(dst[i])
+	}
+	return cnt
+}
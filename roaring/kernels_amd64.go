@@ -0,0 +1,82 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build amd64,!purego
+
+package roaring
+
+import "golang.org/x/sys/cpu"
+
+func init() {
+	if cpu.X86.HasAVX2 {
+		popcountSlice = popcountSliceAVX2
+		popcountAndSlice = popcountAndSliceAVX2
+		andSlice = andSliceAVX2
+		orSlice = orSliceAVX2
+		kernelsName = "avx2"
+	}
+}
+
+// The assembly functions process n words, where n is a multiple of 4.
+
+//go:noescape
+func popcntAVX2(p *uint64, n int) uint64
+
+//go:noescape
+func andCountAVX2(a, b *uint64, n int) uint64
+
+//go:noescape
+func andAVX2(dst, a, b *uint64, n int) uint64
+
+//go:noescape
+func orAVX2(dst, a, b *uint64, n int) uint64
+
+func popcountSliceAVX2(s []uint64) uint64 {
+	n := len(s) &^ 3
+	var cnt uint64
+	if n > 0 {
+		cnt = popcntAVX2(&s[0], n)
+	}
+	return cnt + popcountSliceGeneric(s[n:])
+}
+
+func popcountAndSliceAVX2(s, m []uint64) uint64 {
+	m = m[:len(s)]
+	n := len(s) &^ 3
+	var cnt uint64
+	if n > 0 {
+		cnt = andCountAVX2(&s[0], &m[0], n)
+	}
+	return cnt + popcountAndSliceGeneric(s[n:], m[n:])
+}
+
+func andSliceAVX2(dst, a, b []uint64) uint64 {
+	a, b = a[:len(dst)], b[:len(dst)]
+	n := len(dst) &^ 3
+	var cnt uint64
+	if n > 0 {
+		cnt = andAVX2(&dst[0], &a[0], &b[0], n)
+	}
+	return cnt + andSliceGeneric(dst[n:], a[n:], b[n:])
+}
+
+func orSliceAVX2(dst, a, b []uint64) uint64 {
+	a, b = a[:len(dst)], b[:len(dst)]
+	n := len(dst) &^ 3
+	var cnt uint64
+	if n > 0 {
+		cnt = orAVX2(&dst[0], &a[0], &b[0], n)
+	}
+	return cnt + orSliceGeneric(dst[n:], a[n:], b[n:])
+}
@@ -0,0 +1,149 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build amd64,!purego
+
+#include "textflag.h"
+
+// Population counts use the nibble lookup method: each byte is split into
+// two nibbles whose counts are looked up with VPSHUFB, and the byte counts
+// are summed into four 64-bit lanes with VPSADBW.
+
+// popcntLUT holds the bit count of each nibble, repeated for both lanes.
+DATA popcntLUT<>+0x00(SB)/8, $0x0302020102010100
+DATA popcntLUT<>+0x08(SB)/8, $0x0403030203020201
+DATA popcntLUT<>+0x10(SB)/8, $0x0302020102010100
+DATA popcntLUT<>+0x18(SB)/8, $0x0403030203020201
+GLOBL popcntLUT<>(SB), (NOPTR+RODATA), $32
+
+DATA popcntMask<>+0x00(SB)/8, $0x0f0f0f0f0f0f0f0f
+DATA popcntMask<>+0x08(SB)/8, $0x0f0f0f0f0f0f0f0f
+DATA popcntMask<>+0x10(SB)/8, $0x0f0f0f0f0f0f0f0f
+DATA popcntMask<>+0x18(SB)/8, $0x0f0f0f0f0f0f0f0f
+GLOBL popcntMask<>(SB), (NOPTR+RODATA), $32
+
+// SETUP loads the lookup table into Y14, the mask into Y15, and clears the
+// zero register Y13 and the accumulator Y12.
+#define SETUP \
+	VMOVDQU popcntLUT<>(SB), Y14; \
+	VMOVDQU popcntMask<>(SB), Y15; \
+	VPXOR   Y13, Y13, Y13; \
+	VPXOR   Y12, Y12, Y12
+
+// POPCNT adds the bit count of v to the accumulator, clobbering v and t.
+#define POPCNT(v, t) \
+	VPSRLW  $4, v, t; \
+	VPAND   Y15, v, v; \
+	VPAND   Y15, t, t; \
+	VPSHUFB v, Y14, v; \
+	VPSHUFB t, Y14, t; \
+	VPADDB  t, v, v; \
+	VPSADBW Y13, v, v; \
+	VPADDQ  v, Y12, Y12
+
+// REDUCE sums the accumulator lanes into AX.
+#define REDUCE \
+	VEXTRACTI128 $1, Y12, X0; \
+	VPADDQ       X0, X12, X12; \
+	VPSHUFD      $0x4e, X12, X0; \
+	VPADDQ       X0, X12, X12; \
+	VZEROUPPER; \
+	MOVQ         X12, AX
+
+// func popcntAVX2(p *uint64, n int) uint64
+TEXT ·popcntAVX2(SB), NOSPLIT, $0-24
+	MOVQ p+0(FP), SI
+	MOVQ n+8(FP), CX
+	SHRQ $2, CX
+	SETUP
+
+popcntLoop:
+	VMOVDQU (SI), Y0
+	POPCNT(Y0, Y1)
+	ADDQ    $32, SI
+	DECQ    CX
+	JNZ     popcntLoop
+
+	REDUCE
+	MOVQ AX, ret+16(FP)
+	RET
+
+// func andCountAVX2(a, b *uint64, n int) uint64
+TEXT ·andCountAVX2(SB), NOSPLIT, $0-32
+	MOVQ a+0(FP), SI
+	MOVQ b+8(FP), DX
+	MOVQ n+16(FP), CX
+	SHRQ $2, CX
+	SETUP
+
+andCountLoop:
+	VMOVDQU (SI), Y0
+	VPAND   (DX), Y0, Y0
+	POPCNT(Y0, Y1)
+	ADDQ    $32, SI
+	ADDQ    $32, DX
+	DECQ    CX
+	JNZ     andCountLoop
+
+	REDUCE
+	MOVQ AX, ret+24(FP)
+	RET
+
+// func andAVX2(dst, a, b *uint64, n int) uint64
+TEXT ·andAVX2(SB), NOSPLIT, $0-40
+	MOVQ dst+0(FP), DI
+	MOVQ a+8(FP), SI
+	MOVQ b+16(FP), DX
+	MOVQ n+24(FP), CX
+	SHRQ $2, CX
+	SETUP
+
+andLoop:
+	VMOVDQU (SI), Y0
+	VPAND   (DX), Y0, Y0
+	VMOVDQU Y0, (DI)
+	POPCNT(Y0, Y1)
+	ADDQ    $32, SI
+	ADDQ    $32, DX
+	ADDQ    $32, DI
+	DECQ    CX
+	JNZ     andLoop
+
+	REDUCE
+	MOVQ AX, ret+32(FP)
+	RET
+
+// func orAVX2(dst, a, b *uint64, n int) uint64
+TEXT ·orAVX2(SB), NOSPLIT, $0-40
+	MOVQ dst+0(FP), DI
+	MOVQ a+8(FP), SI
+	MOVQ b+16(FP), DX
+	MOVQ n+24(FP), CX
+	SHRQ $2, CX
+	SETUP
+
+orLoop:
+	VMOVDQU (SI), Y0
+	VPOR    (DX), Y0, Y0
+	VMOVDQU Y0, (DI)
+	POPCNT(Y0, Y1)
+	ADDQ    $32, SI
+	ADDQ    $32, DX
+	ADDQ    $32, DI
+	DECQ    CX
+	JNZ     orLoop
+
+	REDUCE
+	MOVQ AX, ret+32(FP)
+	RET
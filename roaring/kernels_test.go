@@ -0,0 +1,106 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roaring
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// Ensure the selected kernels match the generic implementations.
+func TestKernels(t *testing.T) {
+	t.Logf("kernels: %s", kernelsName)
+
+	rnd := rand.New(rand.NewSource(0))
+	for _, n := range []int{0, 1, 3, 4, 7, 64, bitmapN - 1, bitmapN} {
+		a, b := make([]uint64, n), make([]uint64, n)
+		for i := range a {
+			a[i], b[i] = rnd.Uint64(), rnd.Uint64()
+		}
+		// Include saturated words, which overflow naive byte counters.
+		if n > 0 {
+			a[0], b[0] = ^uint64(0), ^uint64(0)
+		}
+
+		if got, exp := popcountSlice(a), popcountSliceGeneric(a); got != exp {
+			t.Fatalf("popcountSlice(%d) = %d, expected %d", n, got, exp)
+		}
+		if got, exp := popcountAndSlice(a, b), popcountAndSliceGeneric(a, b); got != exp {
+			t.Fatalf("popcountAndSlice(%d) = %d, expected %d", n, got, exp)
+		}
+
+		dst, exp := make([]uint64, n), make([]uint64, n)
+		if got, expN := andSlice(dst, a, b), andSliceGeneric(exp, a, b); got != expN {
+			t.Fatalf("andSlice(%d) = %d, expected %d", n, got, expN)
+		} else if !reflect.DeepEqual(dst, exp) {
+			t.Fatalf("andSlice(%d) mismatch", n)
+		}
+		if got, expN := orSlice(dst, a, b), orSliceGeneric(exp, a, b); got != expN {
+			t.Fatalf("orSlice(%d) = %d, expected %d", n, got, expN)
+		} else if !reflect.DeepEqual(dst, exp) {
+			t.Fatalf("orSlice(%d) mismatch", n)
+		}
+	}
+
+	// A full bitmap has every bit set.
+	full := make([]uint64, bitmapN)
+	for i := range full {
+		full[i] = ^uint64(0)
+	}
+	if n := popcountSlice(full); n != maxContainerVal+1 {
+		t.Fatalf("unexpected full count: %d", n)
+	}
+}
+
+func BenchmarkKernels(b *testing.B) {
+	rnd := rand.New(rand.NewSource(0))
+	x, y, dst := make([]uint64, bitmapN), make([]uint64, bitmapN), make([]uint64, bitmapN)
+	for i := range x {
+		x[i], y[i] = rnd.Uint64(), rnd.Uint64()
+	}
+
+	for _, impl := range []struct {
+		name             string
+		popcountSlice    func([]uint64) uint64
+		popcountAndSlice func(s, m []uint64) uint64
+		andSlice         func(dst, a, b []uint64) uint64
+		orSlice          func(dst, a, b []uint64) uint64
+	}{
+		{"generic", popcountSliceGeneric, popcountAndSliceGeneric, andSliceGeneric, orSliceGeneric},
+		{kernelsName, popcountSlice, popcountAndSlice, andSlice, orSlice},
+	} {
+		b.Run(impl.name+"/Popcount", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				impl.popcountSlice(x)
+			}
+		})
+		b.Run(impl.name+"/IntersectionCount", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				impl.popcountAndSlice(x, y)
+			}
+		})
+		b.Run(impl.name+"/Intersect", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				impl.andSlice(dst, x, y)
+			}
+		})
+		b.Run(impl.name+"/Union", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				impl.orSlice(dst, x, y)
+			}
+		})
+	}
+}
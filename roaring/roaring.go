@@ -434,6 +434,20 @@ func (b *Bitmap) Size() int {
 	return numbytes
 }
 
+// HeapSize returns the number of bytes used by containers whose data is
+// not mapped from storage.
+func (b *Bitmap) HeapSize() int {
+	numbytes := 0
+	citer, _ := b.Containers.Iterator(0)
+	for citer.Next() {
+		_, c := citer.Value()
+		if !c.Mapped() {
+			numbytes += c.size()
+		}
+	}
+	return numbytes
+}
+
 // CountRange returns the number of bits set between [start, end).
 func (b *Bitmap) CountRange(start, end uint64) (n uint64) {
 	if roaringSentinel {
@@ -2973,6 +2987,21 @@ func (c *Container) size() int {
 	}
 }
 
+// Type returns the type of the container: "array", "bitmap", or "run", or
+// "nil" for a nil container.
+func (c *Container) Type() string {
+	switch {
+	case c == nil:
+		return "nil"
+	case c.isArray():
+		return "array"
+	case c.isRun():
+		return "run"
+	default:
+		return "bitmap"
+	}
+}
+
 // info returns the current stats about the container.
 func (c *Container) info() containerInfo {
 	info := containerInfo{N: c.N()}
@@ -3054,18 +3083,7 @@ func (c *Container) Repair() {
 }
 
 func (c *Container) bitmapRepair() {
-	n := int32(0)
-	// Manually unroll loop to make it a little faster.
-	// TODO(rartoul): Can probably make this a few x faster using
-	// SIMD instructions.
-	bitmap := c.bitmap()[:bitmapN]
-	for i := 0; i <= bitmapN-4; i += 4 {
-		n += int32(popcount(bitmap[i]))
-		n += int32(popcount(bitmap[i+1]))
-		n += int32(popcount(bitmap[i+2]))
-		n += int32(popcount(bitmap[i+3]))
-	}
-	c.setN(n)
+	c.setN(int32(popcountSlice(c.bitmap()[:bitmapN])))
 }
 
 // containerInfo represents a point-in-time snapshot of container stats.
@@ -3462,18 +3480,8 @@ func intersectArrayBitmap(a, b *Container) *Container {
 
 func intersectBitmapBitmap(a, b *Container) *Container {
 	statsHit("intersect/BitmapBitmap")
-	// local variables added to prevent BCE checks in loop
-	// see https://go101.org/article/bounds-check-elimination.html
-	var (
-		ab = a.bitmap()[:bitmapN]
-		bb = b.bitmap()[:bitmapN]
-		ob = make([]uint64, bitmapN)
-		n  int32
-	)
-	for i := 0; i < bitmapN; i++ {
-		ob[i] = ab[i] & bb[i]
-		n += int32(popcount(ob[i]))
-	}
+	ob := make([]uint64, bitmapN)
+	n := int32(andSlice(ob, a.bitmap()[:bitmapN], b.bitmap()[:bitmapN]))
 
 	output := NewContainerBitmapN(ob, n)
 	return output
@@ -3882,21 +3890,8 @@ func unionBitmapArrayInPlace(a, b *Container) *Container {
 }
 
 func unionBitmapBitmap(a, b *Container) *Container {
-	// local variables added to prevent BCE checks in loop
-	// see https://go101.org/article/bounds-check-elimination.html
-
-	var (
-		ab = a.bitmap()[:bitmapN]
-		bb = b.bitmap()[:bitmapN]
-		ob = make([]uint64, bitmapN)[:bitmapN]
-
-		n int32
-	)
-
-	for i := 0; i < bitmapN; i++ {
-		ob[i] = ab[i] | bb[i]
-		n += int32(popcount(ob[i]))
-	}
+	ob := make([]uint64, bitmapN)
+	n := int32(orSlice(ob, a.bitmap()[:bitmapN], b.bitmap()[:bitmapN]))
 
 	output := NewContainerBitmapN(ob, n)
 	return output
@@ -5292,19 +5287,6 @@ func popcount(x uint64) uint64 {
 	return uint64(bits.OnesCount64(x))
 }
 
-func popcountAndSlice(s, m []uint64) uint64 {
-	var (
-		a = s[:bitmapN]
-		b = m[:bitmapN]
-	)
-
-	cnt := uint64(0)
-	for i := 0; i < bitmapN; i++ {
-		cnt += popcount(a[i] & b[i])
-	}
-	return cnt
-}
-
 // constants from github.com/RoaringBitmap/roaring
 // taken from  roaring/util.go
 const (
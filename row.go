@@ -243,6 +243,28 @@ func (r *Row) SetBit(i uint64) (changed bool) {
 	return r.createSegmentIfNotExists(i / ShardWidth).SetBit(i)
 }
 
+// page returns a row of at most limit of the columns of r from start on. It
+// seeks to start within the containers of each segment, rather than
+// iterating over the columns before it.
+func (r *Row) page(start, limit uint64) *Row {
+	other := &Row{Attrs: r.Attrs}
+	var n uint64
+	for i := range r.segments {
+		if n == limit {
+			break
+		} else if (r.segments[i].shard+1)*ShardWidth <= start {
+			continue
+		}
+		itr := r.segments[i].data.Iterator()
+		itr.Seek(start)
+		for col, eof := itr.Next(); !eof && n < limit; col, eof = itr.Next() {
+			other.SetBit(col)
+			n++
+		}
+	}
+	return other
+}
+
 // Segments returns a list of all segments in the row.
 func (r *Row) Segments() []rowSegment {
 	return r.segments
@@ -259,6 +281,19 @@ func (r *Row) segment(shard uint64) *rowSegment {
 	return nil
 }
 
+// shardRow returns a row of the columns of r in shard. It shares the data
+// of r, which is copied before it is written to.
+func (r *Row) shardRow(shard uint64) *Row {
+	row := NewRow()
+	if r == nil {
+		return row
+	}
+	if seg := r.segment(shard); seg != nil {
+		row.segments = []rowSegment{{data: seg.data, shard: shard, n: seg.n}}
+	}
+	return row
+}
+
 func (r *Row) createSegmentIfNotExists(shard uint64) *rowSegment {
 	i := sort.Search(len(r.segments), func(i int) bool {
 		return r.segments[i].shard >= shard
@@ -299,6 +334,28 @@ func (r *Row) Count() uint64 {
 	return n
 }
 
+// size returns the approximate number of bytes used by the row's bitmaps.
+func (r *Row) size() int {
+	var n int
+	for i := range r.segments {
+		if r.segments[i].data != nil {
+			n += r.segments[i].data.Size()
+		}
+	}
+	return n
+}
+
+// containerN returns the number of roaring containers in the row.
+func (r *Row) containerN() int {
+	var n int
+	for i := range r.segments {
+		if r.segments[i].data != nil {
+			n += r.segments[i].data.Containers.Size()
+		}
+	}
+	return n
+}
+
 // MarshalJSON returns a JSON-encoded byte slice of r.
 func (r *Row) MarshalJSON() ([]byte, error) {
 	var o struct {
@@ -0,0 +1,597 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pilosa/pilosa/v2/pql"
+	"github.com/pkg/errors"
+)
+
+// A schedule runs a named query on a cron schedule and delivers its results
+// to a sink: a webhook, a file, or a row of a field, which materializes the
+// query. The coordinator runs the schedules, and shares the record of each
+// run with the other nodes, so every node can report the recent runs. Failed
+// runs are logged, and posted to the schedule's alert URL, if any.
+
+// Schedule errors.
+var (
+	ErrScheduleNotFound = errors.New("schedule not found")
+)
+
+// Schedule sink types.
+const (
+	ScheduleSinkWebhook = "webhook"
+	ScheduleSinkFile    = "file"
+	ScheduleSinkField   = "field"
+)
+
+// schedulesFile is the file in the data directory which records the
+// schedules.
+const schedulesFile = ".schedules"
+
+// scheduleResultsDir is the directory in the data directory under which file
+// sinks are written.
+const scheduleResultsDir = ".results"
+
+// scheduleInterval is the interval at which the coordinator checks for
+// schedules which are due.
+const scheduleInterval = 10 * time.Second
+
+// scheduleRunTimeout bounds a single run of a schedule.
+const scheduleRunTimeout = 5 * time.Minute
+
+// maxScheduleRuns is the number of runs of each schedule which are kept.
+const maxScheduleRuns = 20
+
+// Schedule runs the named query Query, with its parameters replaced by
+// Params, at the times matched by Cron, and delivers the results to Sink.
+//
+// Cron has the five fields of a crontab entry: minute, hour, day of month,
+// month, and day of week, which are matched in UTC.
+type Schedule struct {
+	Name     string            `json:"name"`
+	Query    string            `json:"query"`
+	Params   map[string]string `json:"params,omitempty"`
+	Cron     string            `json:"cron"`
+	Sink     ScheduleSink      `json:"sink"`
+	AlertURL string            `json:"alertURL,omitempty"`
+}
+
+// ScheduleSink is where the results of a schedule are delivered. Webhooks are
+// posted the results as JSON, and files, which are relative to the results
+// directory of the coordinator's data directory, have them appended as a
+// line of JSON. A field sink stores the row the query returns as Row of
+// Field, in the query's index.
+type ScheduleSink struct {
+	Type  string `json:"type"`
+	URL   string `json:"url,omitempty"`
+	Path  string `json:"path,omitempty"`
+	Field string `json:"field,omitempty"`
+	Row   uint64 `json:"row,omitempty"`
+}
+
+// ScheduleRun is the record of a run of a schedule. Error is empty if the run
+// succeeded.
+type ScheduleRun struct {
+	Start    time.Time     `json:"start"`
+	Duration time.Duration `json:"duration"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// ScheduleStatus is a schedule, the next time it is due, and its recent runs,
+// oldest first.
+type ScheduleStatus struct {
+	Schedule Schedule      `json:"schedule"`
+	Next     time.Time     `json:"next"`
+	Runs     []ScheduleRun `json:"runs"`
+}
+
+// CreateScheduleMessage is an internal message indicating that a schedule was
+// created or replaced.
+type CreateScheduleMessage struct {
+	Schedule Schedule
+}
+
+// DeleteScheduleMessage is an internal message indicating that a schedule was
+// deleted.
+type DeleteScheduleMessage struct {
+	Name string
+}
+
+// ScheduleRunMessage is an internal message recording a run of a schedule.
+type ScheduleRunMessage struct {
+	Name string
+	Run  ScheduleRun
+}
+
+// scheduleResult is delivered to webhook and file sinks.
+type scheduleResult struct {
+	Schedule string        `json:"schedule"`
+	Time     time.Time     `json:"time"`
+	Results  []interface{} `json:"results"`
+}
+
+// scheduleAlert is posted to the alert URL of a schedule when a run fails.
+type scheduleAlert struct {
+	Schedule string    `json:"schedule"`
+	Time     time.Time `json:"time"`
+	Error    string    `json:"error"`
+}
+
+// validate checks that s can be stored. Its named query must exist in h.
+func (s Schedule) validate(h *Holder) error {
+	if err := validateName(s.Name); err != nil {
+		return err
+	} else if _, err := parseCron(s.Cron); err != nil {
+		return NewBadRequestError(errors.Wrap(err, "parsing cron"))
+	} else if s.AlertURL != "" {
+		if err := validateScheduleURL(s.AlertURL); err != nil {
+			return NewBadRequestError(errors.Wrap(err, "alert url"))
+		}
+	}
+
+	q, ok := h.namedQueries.get(s.Query)
+	if !ok {
+		return newNotFoundError(ErrNamedQueryNotFound, s.Query)
+	}
+	query, err := q.bind(s.Params)
+	if err != nil {
+		return NewBadRequestError(errors.Wrap(err, "binding named query"))
+	}
+
+	switch s.Sink.Type {
+	case ScheduleSinkWebhook:
+		if err := validateScheduleURL(s.Sink.URL); err != nil {
+			return NewBadRequestError(errors.Wrap(err, "sink url"))
+		}
+	case ScheduleSinkFile:
+		if _, err := scheduleResultsPath(h.Path, s.Sink.Path); err != nil {
+			return NewBadRequestError(err)
+		}
+	case ScheduleSinkField:
+		f := h.Field(h.resolveIndex(q.Index), s.Sink.Field)
+		if f == nil {
+			return newNotFoundError(ErrFieldNotFound, s.Sink.Field)
+		} else if f.Type() != FieldTypeSet {
+			return NewBadRequestError(errors.Errorf("can't store into a %s field", f.Type()))
+		}
+		parsed, err := pql.NewParser(strings.NewReader(query)).Parse()
+		if err != nil {
+			return NewBadRequestError(errors.Wrap(err, "parsing"))
+		} else if len(parsed.Calls) != 1 {
+			return NewBadRequestError(errors.Errorf("query stored into a field must be a single call, not %d", len(parsed.Calls)))
+		}
+	default:
+		return NewBadRequestError(errors.Errorf("invalid sink type: %q", s.Sink.Type))
+	}
+	return nil
+}
+
+// validateScheduleURL checks that u is an HTTP URL.
+func validateScheduleURL(u string) error {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return err
+	} else if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return errors.Errorf("unsupported scheme: %q", parsed.Scheme)
+	}
+	return nil
+}
+
+// scheduleResultsPath returns the path of the file sink path in the results
+// directory of the data directory dataDir. The path may not leave the
+// results directory.
+func scheduleResultsPath(dataDir, path string) (string, error) {
+	clean := filepath.Clean(path)
+	if path == "" || filepath.IsAbs(clean) || clean == "." || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return "", errors.Errorf("invalid sink path: %q", path)
+	}
+	return filepath.Join(dataDir, scheduleResultsDir, clean), nil
+}
+
+// cronSchedule is a parsed crontab schedule. Each field is a bitmap of the
+// values it matches.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+
+	// The day of the month and day of the week match if either does, unless
+	// either is "*".
+	domStar, dowStar bool
+}
+
+// parseCron parses the five fields of a crontab entry. Each field is a list
+// of "*", values, or ranges, each optionally followed by a step, such as
+// "*/15" or "1-5". Sunday is day 0 or 7 of the week.
+func parseCron(spec string) (*cronSchedule, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, errors.Errorf("expected 5 fields, got %d", len(fields))
+	}
+
+	var c cronSchedule
+	var err error
+	if c.minute, err = parseCronField(fields[0], 0, 59); err != nil {
+		return nil, errors.Wrap(err, "minute")
+	} else if c.hour, err = parseCronField(fields[1], 0, 23); err != nil {
+		return nil, errors.Wrap(err, "hour")
+	} else if c.dom, err = parseCronField(fields[2], 1, 31); err != nil {
+		return nil, errors.Wrap(err, "day of month")
+	} else if c.month, err = parseCronField(fields[3], 1, 12); err != nil {
+		return nil, errors.Wrap(err, "month")
+	} else if c.dow, err = parseCronField(fields[4], 0, 7); err != nil {
+		return nil, errors.Wrap(err, "day of week")
+	}
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1
+	}
+	c.domStar, c.dowStar = fields[2] == "*", fields[4] == "*"
+	return &c, nil
+}
+
+// parseCronField parses a field whose values are between min and max.
+func parseCronField(field string, min, max int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rng, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n < 1 {
+				return 0, errors.Errorf("invalid step: %q", part)
+			}
+			rng, step = part[:i], n
+		}
+
+		lo, hi := min, max
+		if rng != "*" {
+			var err error
+			bounds := strings.SplitN(rng, "-", 2)
+			if lo, err = strconv.Atoi(bounds[0]); err != nil {
+				return 0, errors.Errorf("invalid value: %q", part)
+			}
+			hi = lo
+			if len(bounds) == 2 {
+				if hi, err = strconv.Atoi(bounds[1]); err != nil {
+					return 0, errors.Errorf("invalid value: %q", part)
+				}
+			} else if step > 1 {
+				hi = max
+			}
+			if lo < min || hi > max || lo > hi {
+				return 0, errors.Errorf("out of range: %q", part)
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// next returns the first time after t which the schedule matches, or the
+// zero time if there is none within five years.
+func (c *cronSchedule) next(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		} else if !c.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+		} else if c.hour&(1<<uint(t.Hour())) == 0 {
+			t = t.Truncate(time.Hour).Add(time.Hour)
+		} else if c.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+		} else {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// matchDay returns true if the schedule matches the day of t.
+func (c *cronSchedule) matchDay(t time.Time) bool {
+	dom := c.dom&(1<<uint(t.Day())) != 0
+	dow := c.dow&(1<<uint(t.Weekday())) != 0
+	if c.domStar || c.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// scheduleStore holds a node's schedules, and the recent runs of each.
+type scheduleStore struct {
+	mu        sync.RWMutex
+	schedules map[string]Schedule
+	runs      map[string][]ScheduleRun
+	path      string
+}
+
+func newScheduleStore() *scheduleStore {
+	return &scheduleStore{
+		schedules: make(map[string]Schedule),
+		runs:      make(map[string][]ScheduleRun),
+	}
+}
+
+// open loads the schedules recorded in the file at path, and records later
+// changes there.
+func (s *scheduleStore) open(path string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.path = path
+
+	buf, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "reading schedules file")
+	}
+	var schedules []Schedule
+	if err := json.Unmarshal(buf, &schedules); err != nil {
+		return errors.Wrap(err, "decoding schedules file")
+	}
+	for _, sched := range schedules {
+		s.schedules[sched.Name] = sched
+	}
+	return nil
+}
+
+// get returns the schedule named name.
+func (s *scheduleStore) get(name string) (Schedule, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	sched, ok := s.schedules[name]
+	return sched, ok
+}
+
+// all returns the schedules, sorted by name.
+func (s *scheduleStore) all() []Schedule {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.unprotectedAll()
+}
+
+func (s *scheduleStore) unprotectedAll() []Schedule {
+	schedules := make([]Schedule, 0, len(s.schedules))
+	for _, sched := range s.schedules {
+		schedules = append(schedules, sched)
+	}
+	sort.Slice(schedules, func(i, j int) bool { return schedules[i].Name < schedules[j].Name })
+	return schedules
+}
+
+// create adds sched, replacing any schedule of the same name.
+func (s *scheduleStore) create(sched Schedule) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.schedules[sched.Name] = sched
+	return s.unprotectedSave()
+}
+
+// delete removes the schedule named name, and its runs.
+func (s *scheduleStore) delete(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.schedules[name]; !ok {
+		return newNotFoundError(ErrScheduleNotFound, name)
+	}
+	delete(s.schedules, name)
+	delete(s.runs, name)
+	return s.unprotectedSave()
+}
+
+// addRun records a run of the schedule named name, keeping the most recent
+// runs. Runs of unknown schedules are ignored.
+func (s *scheduleStore) addRun(name string, run ScheduleRun) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.schedules[name]; !ok {
+		return
+	}
+	runs := append(s.runs[name], run)
+	if len(runs) > maxScheduleRuns {
+		runs = append([]ScheduleRun(nil), runs[len(runs)-maxScheduleRuns:]...)
+	}
+	s.runs[name] = runs
+}
+
+// recentRuns returns the recent runs of the schedule named name, oldest
+// first.
+func (s *scheduleStore) recentRuns(name string) []ScheduleRun {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]ScheduleRun{}, s.runs[name]...)
+}
+
+// unprotectedSave writes the schedules to the schedules file, if any.
+func (s *scheduleStore) unprotectedSave() error {
+	if s.path == "" {
+		return nil
+	}
+	buf, err := json.Marshal(s.unprotectedAll())
+	if err != nil {
+		return errors.Wrap(err, "encoding schedules file")
+	}
+
+	tempPath := s.path + tempExt
+	if err := ioutil.WriteFile(tempPath, buf, 0666); err != nil {
+		return errors.Wrap(err, "writing schedules file")
+	}
+	return errors.Wrap(os.Rename(tempPath, s.path), "renaming schedules file")
+}
+
+// monitorSchedules runs the schedules which are due, while this node is the
+// coordinator.
+func (s *Server) monitorSchedules() {
+	ticker := time.NewTicker(scheduleInterval)
+	defer ticker.Stop()
+
+	// The next time each schedule is due, and the cron it was computed from,
+	// so replaced schedules are rescheduled.
+	type due struct {
+		spec string
+		cron *cronSchedule
+		next time.Time
+	}
+	dues := make(map[string]due)
+
+	for {
+		select {
+		case <-s.closing:
+			return
+		case now := <-ticker.C:
+			// A new coordinator starts the schedules from when it took over.
+			if !s.cluster.isCoordinator() || s.cluster.State() != ClusterStateNormal {
+				dues = make(map[string]due)
+				continue
+			}
+
+			next := make(map[string]due)
+			for _, sched := range s.holder.schedules.all() {
+				d, ok := dues[sched.Name]
+				if !ok || d.spec != sched.Cron {
+					c, err := parseCron(sched.Cron)
+					if err != nil {
+						s.logger.Printf("parsing cron of schedule %s: %s", sched.Name, err)
+						continue
+					}
+					d = due{spec: sched.Cron, cron: c, next: c.next(now)}
+				} else if !d.next.IsZero() && !now.Before(d.next) {
+					s.runScheduleAndRecord(sched, now)
+					d.next = d.cron.next(now)
+				}
+				next[sched.Name] = d
+			}
+			dues = next
+		}
+	}
+}
+
+// runScheduleAndRecord runs sched, and records the run on every node. A
+// failed run is logged and alerted.
+func (s *Server) runScheduleAndRecord(sched Schedule, now time.Time) {
+	ctx, cancel := context.WithTimeout(WithInternalRequest(context.Background()), scheduleRunTimeout)
+	defer cancel()
+
+	run := ScheduleRun{Start: now.UTC()}
+	err := s.runSchedule(ctx, sched, run.Start)
+	run.Duration = time.Since(now)
+	if err != nil {
+		run.Error = err.Error()
+		s.logger.Printf("running schedule %s: %s", sched.Name, err)
+		if sched.AlertURL != "" {
+			alert := scheduleAlert{Schedule: sched.Name, Time: run.Start, Error: run.Error}
+			if err := postJSON(s.scheduleClient, sched.AlertURL, alert); err != nil {
+				s.logger.Printf("alerting failure of schedule %s: url=%s, err=%s", sched.Name, sched.AlertURL, err)
+			}
+		}
+	}
+
+	s.holder.schedules.addRun(sched.Name, run)
+	if err := s.SendAsync(&ScheduleRunMessage{Name: sched.Name, Run: run}); err != nil {
+		s.logger.Printf("sending ScheduleRun message: %s", err)
+	}
+}
+
+// runSchedule executes the named query of sched and delivers the results to
+// its sink.
+func (s *Server) runSchedule(ctx context.Context, sched Schedule, start time.Time) error {
+	q, ok := s.holder.namedQueries.get(sched.Query)
+	if !ok {
+		return newNotFoundError(ErrNamedQueryNotFound, sched.Query)
+	}
+	query, err := q.bind(sched.Params)
+	if err != nil {
+		return errors.Wrap(err, "binding named query")
+	}
+	parsed, err := pql.NewParser(strings.NewReader(query)).Parse()
+	if err != nil {
+		return errors.Wrap(err, "parsing")
+	}
+
+	// The row the query returns is stored into the field.
+	if sched.Sink.Type == ScheduleSinkField {
+		if len(parsed.Calls) != 1 {
+			return errors.Errorf("query stored into a field must be a single call, not %d", len(parsed.Calls))
+		}
+		parsed.Calls = []*pql.Call{{
+			Name:     "Store",
+			Args:     map[string]interface{}{sched.Sink.Field: sched.Sink.Row},
+			Children: parsed.Calls,
+		}}
+	}
+
+	// Writes are refused as they are for queries sent to the API. Named
+	// queries can't write, but the Store() of a field sink does, which
+	// WriteCallN doesn't count.
+	if sched.Sink.Type == ScheduleSinkField || parsed.WriteCallN() > 0 {
+		if err := s.cluster.validateWritable(s.cluster.Node); err != nil {
+			return err
+		} else if err := s.holder.validateIndexWritable(s.holder.resolveIndex(q.Index)); err != nil {
+			return err
+		}
+	}
+
+	resp, err := s.executor.Execute(ctx, q.Index, parsed, nil, &execOptions{})
+	if err != nil {
+		return errors.Wrap(err, "executing")
+	}
+	result := scheduleResult{Schedule: sched.Name, Time: start, Results: resp.Results}
+
+	switch sched.Sink.Type {
+	case ScheduleSinkWebhook:
+		return errors.Wrap(postJSON(s.scheduleClient, sched.Sink.URL, result), "posting results")
+	case ScheduleSinkFile:
+		return errors.Wrap(appendScheduleResult(s.holder.Path, sched.Sink.Path, result), "writing results")
+	}
+	return nil
+}
+
+// appendScheduleResult appends result as a line of JSON to the file sink path
+// in the results directory of dataDir.
+func appendScheduleResult(dataDir, path string, result scheduleResult) error {
+	path, err := scheduleResultsPath(dataDir, path)
+	if err != nil {
+		return err
+	}
+	buf, err := json.Marshal(result)
+	if err != nil {
+		return errors.Wrap(err, "encoding")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+		return errors.Wrap(err, "creating directory")
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0666)
+	if err != nil {
+		return errors.Wrap(err, "opening")
+	}
+	if _, err := f.Write(append(buf, '\n')); err != nil {
+		f.Close()
+		return errors.Wrap(err, "writing")
+	}
+	return errors.Wrap(f.Close(), "closing")
+}
@@ -0,0 +1,197 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pilosa/pilosa/v2/logger"
+	"github.com/pkg/errors"
+)
+
+func TestCronSchedule_Next(t *testing.T) {
+	// Tuesday.
+	now := time.Date(2019, 10, 15, 10, 30, 45, 0, time.UTC)
+
+	for _, tt := range []struct {
+		spec string
+		exp  time.Time
+	}{
+		{"* * * * *", time.Date(2019, 10, 15, 10, 31, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2019, 10, 15, 10, 45, 0, 0, time.UTC)},
+		{"0 2 * * *", time.Date(2019, 10, 16, 2, 0, 0, 0, time.UTC)},
+		{"30 9-17/4 * * 1-5", time.Date(2019, 10, 15, 13, 30, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2019, 10, 20, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 1 *", time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC)},
+
+		// The day of the month or week matches when both are given.
+		{"0 0 1 * 4", time.Date(2019, 10, 17, 0, 0, 0, 0, time.UTC)},
+
+		// There is no February 30th.
+		{"0 0 30 2 *", time.Time{}},
+	} {
+		c, err := parseCron(tt.spec)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.spec, err)
+		} else if got := c.next(now); !got.Equal(tt.exp) {
+			t.Fatalf("%s: got %s, expected %s", tt.spec, got, tt.exp)
+		}
+	}
+
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *", "a * * * *"} {
+		if _, err := parseCron(spec); err == nil {
+			t.Fatalf("expected error for %q", spec)
+		}
+	}
+}
+
+func TestSchedule_Validate(t *testing.T) {
+	h := newHolder()
+	if err := h.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+	h.MustCreateFieldIfNotExists("i", "f")
+	if _, err := h.namedQueries.create(NamedQuery{Name: "q", Index: "i", Query: `Row(f="$row")`}, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	valid := Schedule{Name: "s", Query: "q", Params: map[string]string{"row": "1"}, Cron: "0 * * * *"}
+	for _, sink := range []ScheduleSink{
+		{Type: ScheduleSinkWebhook, URL: "http://localhost/results"},
+		{Type: ScheduleSinkFile, Path: "daily/q.json"},
+		{Type: ScheduleSinkField, Field: "f", Row: 2},
+	} {
+		sched := valid
+		sched.Sink = sink
+		if err := sched.validate(h.Holder); err != nil {
+			t.Fatalf("%+v: %v", sink, err)
+		}
+	}
+
+	valid.Sink = ScheduleSink{Type: ScheduleSinkWebhook, URL: "http://localhost/results"}
+	for _, fn := range []func(s *Schedule){
+		func(s *Schedule) { s.Cron = "0 * * *" },
+		func(s *Schedule) { s.Query = "nope" },
+		func(s *Schedule) { s.Params = nil },
+		func(s *Schedule) { s.AlertURL = "ftp://localhost" },
+		func(s *Schedule) { s.Sink = ScheduleSink{Type: "email"} },
+		func(s *Schedule) { s.Sink = ScheduleSink{Type: ScheduleSinkFile, Path: "../q.json"} },
+		func(s *Schedule) { s.Sink = ScheduleSink{Type: ScheduleSinkFile, Path: "/tmp/q.json"} },
+		func(s *Schedule) { s.Sink = ScheduleSink{Type: ScheduleSinkField, Field: "nope"} },
+	} {
+		sched := valid
+		fn(&sched)
+		if err := sched.validate(h.Holder); err == nil {
+			t.Fatalf("expected error for %+v", sched)
+		}
+	}
+}
+
+// Ensure schedules are kept across restarts, and only recent runs are kept.
+func TestScheduleStore_Reopen(t *testing.T) {
+	h := newHolder()
+	if err := h.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	sched := Schedule{Name: "s", Query: "q", Cron: "0 * * * *", Sink: ScheduleSink{Type: ScheduleSinkField, Field: "f", Row: 1}}
+	if err := h.schedules.create(sched); err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2019, 10, 15, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < maxScheduleRuns+5; i++ {
+		h.schedules.addRun("s", ScheduleRun{Start: start.Add(time.Duration(i) * time.Hour)})
+	}
+	h.schedules.addRun("nope", ScheduleRun{Start: start})
+	if runs := h.schedules.recentRuns("s"); len(runs) != maxScheduleRuns {
+		t.Fatalf("unexpected number of runs: %d", len(runs))
+	} else if !runs[0].Start.Equal(start.Add(5 * time.Hour)) {
+		t.Fatalf("unexpected first run: %+v", runs[0])
+	} else if runs := h.schedules.recentRuns("nope"); len(runs) != 0 {
+		t.Fatalf("unexpected runs: %+v", runs)
+	}
+
+	if err := h.Holder.Close(); err != nil {
+		t.Fatal(err)
+	} else if err := h.Reopen(); err != nil {
+		t.Fatal(err)
+	} else if got := h.schedules.all(); !reflect.DeepEqual(got, []Schedule{sched}) {
+		t.Fatalf("unexpected schedules: %+v", got)
+	}
+}
+
+func TestAppendScheduleResult(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pilosa-schedule-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for i := 0; i < 2; i++ {
+		result := scheduleResult{Schedule: "s", Time: time.Date(2019, 10, 15, i, 0, 0, 0, time.UTC), Results: []interface{}{uint64(i)}}
+		if err := appendScheduleResult(dir, "daily/s.json", result); err != nil {
+			t.Fatal(err)
+		}
+	}
+	buf, err := ioutil.ReadFile(dir + "/" + scheduleResultsDir + "/daily/s.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(buf)), "\n")
+	if len(lines) != 2 || lines[1] != `{"schedule":"s","time":"2019-10-15T01:00:00Z","results":[1]}` {
+		t.Fatalf("unexpected results: %s", buf)
+	}
+}
+
+// Ensure schedules which store their results into a field don't write to
+// read-only indexes, or while the node is low on disk space.
+func TestServer_RunSchedule_Writable(t *testing.T) {
+	h := newHolder()
+	if err := h.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+	h.MustCreateFieldIfNotExists("i", "f")
+	if _, err := h.namedQueries.create(NamedQuery{Name: "q", Index: "i", Query: "Row(f=1)"}, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewTestCluster(1)
+	s := &Server{cluster: c, holder: h.Holder, nodeID: c.Node.ID, logger: logger.NopLogger}
+	sched := Schedule{Name: "s", Query: "q", Cron: "0 * * * *", Sink: ScheduleSink{Type: ScheduleSinkField, Field: "f", Row: 2}}
+	ctx := context.Background()
+
+	if err := h.Index("i").setReadOnly(true); err != nil {
+		t.Fatal(err)
+	} else if err := s.runSchedule(ctx, sched, time.Now()); errors.Cause(err) != ErrIndexReadOnly {
+		t.Fatalf("unexpected error: %v", err)
+	} else if err := h.Index("i").setReadOnly(false); err != nil {
+		t.Fatal(err)
+	}
+
+	c.setNodeReadOnly(c.Node.ID, true)
+	if err := s.runSchedule(ctx, sched, time.Now()); errors.Cause(err) != ErrInsufficientDiskSpace {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
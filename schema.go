@@ -0,0 +1,260 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// changeSchema makes the schema change m, which is a CreateIndexMessage,
+// DeleteIndexMessage, CreateFieldMessage or DeleteFieldMessage, on every node.
+//
+// Schema changes are made one at a time by the coordinator, which assigns
+// each a schema version before broadcasting it. Other nodes forward changes
+// to the coordinator, so that changes made concurrently through different
+// nodes, such as creating the same field with different options, cannot leave
+// nodes disagreeing about the schema. Once changeSchema returns, the change
+// has been made on this node.
+func (s *Server) changeSchema(m Message) error {
+	coordinator := s.schemaCoordinator()
+	if coordinator == nil {
+		return s.makeSchemaChange(m)
+	}
+
+	req := &SchemaChangeRequest{}
+	switch obj := m.(type) {
+	case *CreateIndexMessage:
+		req.CreateIndex = obj
+	case *DeleteIndexMessage:
+		req.DeleteIndex = obj
+	case *CreateFieldMessage:
+		req.CreateField = obj
+	case *DeleteFieldMessage:
+		req.DeleteField = obj
+	default:
+		return fmt.Errorf("unexpected schema change: %T", m)
+	}
+	return errors.Wrap(s.SendTo(coordinator, req), "forwarding schema change to coordinator")
+}
+
+// schemaCoordinator returns the node to forward schema changes to, or nil if
+// this node makes them itself. Schema changes are made locally by the
+// coordinator, when there is no coordinator, and when the coordinator does
+// not support FeatureSchemaCoordinator.
+func (s *Server) schemaCoordinator() *Node {
+	c := s.cluster
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.unprotectedIsCoordinator() {
+		return nil
+	}
+	coordinator := c.unprotectedCoordinatorNode()
+	if coordinator == nil || (!c.Static && !coordinator.HasFeatures(FeatureSchemaCoordinator)) {
+		return nil
+	}
+	return coordinator
+}
+
+// makeSchemaChange makes the schema change m on this node, then broadcasts it
+// with the next schema version.
+func (s *Server) makeSchemaChange(m Message) error {
+	s.schemaMu.Lock()
+	defer s.schemaMu.Unlock()
+
+	switch obj := m.(type) {
+	case *CreateIndexMessage:
+		if _, err := s.holder.CreateIndex(obj.Index, *obj.Meta); err != nil {
+			return err
+		}
+		obj.SchemaVersion = s.nextSchemaVersion()
+	case *DeleteIndexMessage:
+		if err := s.holder.DeleteIndex(obj.Index); err != nil {
+			return err
+		}
+		s.executor.invalidateTopN(obj.Index)
+		obj.SchemaVersion = s.nextSchemaVersion()
+	case *CreateFieldMessage:
+		idx := s.holder.Index(obj.Index)
+		if idx == nil {
+			return newNotFoundError(ErrIndexNotFound, obj.Index)
+		}
+		opt := *obj.Meta
+		if _, err := idx.CreateField(obj.Field, func(fo *FieldOptions) error {
+			*fo = opt
+			return nil
+		}); err != nil {
+			return err
+		}
+		obj.SchemaVersion = s.nextSchemaVersion()
+	case *DeleteFieldMessage:
+		idx := s.holder.Index(obj.Index)
+		if idx == nil {
+			return newNotFoundError(ErrIndexNotFound, obj.Index)
+		}
+		if err := idx.DeleteField(obj.Field); err != nil {
+			return err
+		}
+		s.executor.invalidateTopN(obj.Index, obj.Field)
+		obj.SchemaVersion = s.nextSchemaVersion()
+	default:
+		return fmt.Errorf("unexpected schema change: %T", m)
+	}
+
+	if err := s.SendSync(m); err != nil {
+		s.logger.Printf("problem broadcasting schema change: %s", err)
+		return errors.Wrap(err, "broadcasting schema change")
+	}
+	return nil
+}
+
+// message returns the schema change requested.
+func (r *SchemaChangeRequest) message() Message {
+	switch {
+	case r.CreateIndex != nil:
+		return r.CreateIndex
+	case r.DeleteIndex != nil:
+		return r.DeleteIndex
+	case r.CreateField != nil:
+		return r.CreateField
+	case r.DeleteField != nil:
+		return r.DeleteField
+	}
+	return nil
+}
+
+// nextSchemaVersion returns the version of a new schema change. Versions are
+// times, so that they keep increasing across restarts and changes of
+// coordinator, but are always later than any version seen before.
+func (s *Server) nextSchemaVersion() uint64 {
+	v := uint64(time.Now().UnixNano())
+	if cur := atomic.LoadUint64(&s.schemaVersion); v <= cur {
+		v = cur + 1
+	}
+	s.observeSchemaVersion(v)
+	return v
+}
+
+// observeSchemaVersion records the version of a schema change made on this
+// node, if it is later than the current schema version. Changes from nodes
+// which do not version them have a version of zero.
+func (s *Server) observeSchemaVersion(v uint64) {
+	for {
+		cur := atomic.LoadUint64(&s.schemaVersion)
+		if v <= cur || atomic.CompareAndSwapUint64(&s.schemaVersion, cur, v) {
+			return
+		}
+	}
+}
+
+// Kinds of difference between the schemas of two nodes.
+const (
+	// SchemaDiffMissing is an index or field which the remote node lacks.
+	SchemaDiffMissing = "missing"
+
+	// SchemaDiffExtra is an index or field which only the remote node has.
+	SchemaDiffExtra = "extra"
+
+	// SchemaDiffOptions is an index or field whose options differ.
+	SchemaDiffOptions = "options"
+
+	// SchemaDiffReadOnly is an index which is read-only on one node only.
+	SchemaDiffReadOnly = "readOnly"
+)
+
+// SchemaDiff describes how the schema of a remote node differs from the
+// schema of this node.
+type SchemaDiff struct {
+	Remote      string             `json:"remote"`
+	Differences []SchemaDifference `json:"differences"`
+}
+
+// SchemaDifference is a difference between the schemas of two nodes. Field is
+// empty for differences in an index itself. Local and Remote are the options,
+// or read-only states, on each node, for differences of those.
+type SchemaDifference struct {
+	Type   string      `json:"type"`
+	Index  string      `json:"index"`
+	Field  string      `json:"field,omitempty"`
+	Local  interface{} `json:"local,omitempty"`
+	Remote interface{} `json:"remote,omitempty"`
+}
+
+// diffSchemas returns the differences between the local and remote schemas.
+// Options are compared as they are encoded in JSON, which is how remote
+// schemas are received.
+func diffSchemas(local, remote []*IndexInfo) []SchemaDifference {
+	diffs := []SchemaDifference{}
+	remoteIndexes := make(map[string]*IndexInfo, len(remote))
+	for _, idx := range remote {
+		remoteIndexes[idx.Name] = idx
+	}
+
+	for _, l := range local {
+		r := remoteIndexes[l.Name]
+		if r == nil {
+			diffs = append(diffs, SchemaDifference{Type: SchemaDiffMissing, Index: l.Name})
+			continue
+		}
+		delete(remoteIndexes, l.Name)
+
+		if !equalJSON(&l.Options, &r.Options) {
+			diffs = append(diffs, SchemaDifference{Type: SchemaDiffOptions, Index: l.Name, Local: l.Options, Remote: r.Options})
+		}
+		if l.ReadOnly != r.ReadOnly {
+			diffs = append(diffs, SchemaDifference{Type: SchemaDiffReadOnly, Index: l.Name, Local: l.ReadOnly, Remote: r.ReadOnly})
+		}
+
+		remoteFields := make(map[string]*FieldInfo, len(r.Fields))
+		for _, f := range r.Fields {
+			remoteFields[f.Name] = f
+		}
+		for _, lf := range l.Fields {
+			rf := remoteFields[lf.Name]
+			if rf == nil {
+				diffs = append(diffs, SchemaDifference{Type: SchemaDiffMissing, Index: l.Name, Field: lf.Name})
+				continue
+			}
+			delete(remoteFields, lf.Name)
+			if !equalJSON(&lf.Options, &rf.Options) {
+				diffs = append(diffs, SchemaDifference{Type: SchemaDiffOptions, Index: l.Name, Field: lf.Name, Local: &lf.Options, Remote: &rf.Options})
+			}
+		}
+		for _, rf := range r.Fields {
+			if remoteFields[rf.Name] != nil {
+				diffs = append(diffs, SchemaDifference{Type: SchemaDiffExtra, Index: l.Name, Field: rf.Name})
+			}
+		}
+	}
+
+	for _, r := range remote {
+		if remoteIndexes[r.Name] != nil {
+			diffs = append(diffs, SchemaDifference{Type: SchemaDiffExtra, Index: r.Name})
+		}
+	}
+	return diffs
+}
+
+// equalJSON returns true if a and b have the same JSON encoding.
+func equalJSON(a, b interface{}) bool {
+	abuf, aerr := json.Marshal(a)
+	bbuf, berr := json.Marshal(b)
+	return aerr == nil && berr == nil && bytes.Equal(abuf, bbuf)
+}
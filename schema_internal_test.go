@@ -0,0 +1,54 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffSchemas(t *testing.T) {
+	set := FieldOptions{Type: FieldTypeSet, CacheType: CacheTypeRanked, CacheSize: 100}
+	local := []*IndexInfo{
+		{Name: "a", Fields: []*FieldInfo{{Name: "f", Options: set}, {Name: "g", Options: set}}},
+		{Name: "b", Options: IndexOptions{Keys: true}},
+		{Name: "c"},
+	}
+	lru := set
+	lru.CacheType = CacheTypeLRU
+	remote := []*IndexInfo{
+		{Name: "a", ReadOnly: true, Fields: []*FieldInfo{{Name: "f", Options: lru}, {Name: "h", Options: set}}},
+		{Name: "b"},
+		{Name: "d"},
+	}
+
+	diffs := diffSchemas(local, remote)
+	exp := []SchemaDifference{
+		{Type: SchemaDiffReadOnly, Index: "a", Local: false, Remote: true},
+		{Type: SchemaDiffOptions, Index: "a", Field: "f", Local: &local[0].Fields[0].Options, Remote: &remote[0].Fields[0].Options},
+		{Type: SchemaDiffMissing, Index: "a", Field: "g"},
+		{Type: SchemaDiffExtra, Index: "a", Field: "h"},
+		{Type: SchemaDiffOptions, Index: "b", Local: IndexOptions{Keys: true}, Remote: IndexOptions{}},
+		{Type: SchemaDiffMissing, Index: "c"},
+		{Type: SchemaDiffExtra, Index: "d"},
+	}
+	if !reflect.DeepEqual(diffs, exp) {
+		t.Fatalf("unexpected differences:\n%+v\nexpected:\n%+v", diffs, exp)
+	}
+
+	if diffs := diffSchemas(local, local); len(diffs) != 0 {
+		t.Fatalf("unexpected differences: %+v", diffs)
+	}
+}
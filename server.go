@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -30,6 +31,7 @@ import (
 	"github.com/pilosa/pilosa/v2/logger"
 	"github.com/pilosa/pilosa/v2/roaring"
 	"github.com/pilosa/pilosa/v2/stats"
+	"github.com/pilosa/pilosa/v2/toml"
 	"github.com/pkg/errors"
 	"golang.org/x/sync/errgroup"
 )
@@ -54,23 +56,85 @@ type Server struct { // nolint: maligned
 	diagnostics      *diagnosticsCollector
 	executor         *executor
 	executorPoolSize int
+	maxQueryFanOut   int
+	maxRemoteFanOut  int
+	scanConcurrency  int
+	maxQueryMemory   int64
+	topNCacheTTL     time.Duration
+	calls            map[string]CallDefinition
 	hosts            []string
 	clusterDisabled  bool
 	serializer       Serializer
 
+	admission            *admission
+	maxConcurrentQueries int
+
+	jobs *jobRegistry
+
+	// Interval at which the coordinator rebalances the reads of hot
+	// shards. Zero disables rebalancing.
+	rebalanceInterval time.Duration
+
+	quota Quota
+	usage *usageMeter
+
+	// API tokens bound to namespaces.
+	namespaceTokens namespaceTokens
+
+	settings *settingsStore
+
+	// schemaMu serializes the schema changes made by the coordinator.
+	schemaMu      sync.Mutex
+	schemaVersion uint64 // accessed atomically
+
+	load *loadMonitor
+
+	// peerMaxShards is the maximum shard of each index known to each remote
+	// node, and peerLoads is the load on each, as of the last NodeStatus
+	// received from it.
+	peersMu       sync.RWMutex
+	peerMaxShards map[string]map[string]uint64
+	peerLoads     map[string]NodeLoad
+
 	// External
 	systemInfo SystemInfo
 	gcNotifier GCNotifier
 	logger     logger.Logger
 
 	nodeID              string
+	zone                string
 	uri                 URI
+	internalURI         *URI
 	antiEntropyInterval time.Duration
 	metricInterval      time.Duration
 	diagnosticInterval  time.Duration
 	maxWritesPerRequest int
 	isCoordinator       bool
 	syncer              holderSyncer
+	events              eventHandlers
+	webhooks            *schemaWebhooks
+
+	// Posts the results of schedules to webhooks, and their failures to
+	// alert URLs.
+	scheduleClient *http.Client
+
+	udfRuntime UDFRuntime
+	udfMu      sync.Mutex
+	udfs       map[string]UDFModule
+
+	// Writes are disabled while free disk space is below minDiskFree.
+	minDiskFree       uint64
+	diskCheckInterval time.Duration
+	diskFree          func(path string) (uint64, error)
+
+	// Expired attributes are removed every attrExpiryInterval.
+	attrExpiryInterval time.Duration
+
+	// Batch queries and imports are rejected while the heap in use is
+	// above heapHighWater.
+	heapHighWater     uint64
+	heapCheckInterval time.Duration
+	overloaded        int32 // accessed atomically
 
 	defaultClient InternalClient
 	dataDir       string
@@ -131,6 +195,61 @@ func OptServerAntiEntropyInterval(interval time.Duration) ServerOption {
 	}
 }
 
+// OptServerChangeLogSize is a functional option on Server
+// used to set the number of mutations retained for change data capture.
+// A size of zero disables the change log.
+func OptServerChangeLogSize(n int) ServerOption {
+	return func(s *Server) error {
+		s.holder.changes = newChangeLog(n)
+		return nil
+	}
+}
+
+// OptServerEventHandler is a functional option on Server
+// used to register a handler for internal server events.
+// It may be passed multiple times.
+func OptServerEventHandler(h EventHandler) ServerOption {
+	return func(s *Server) error {
+		s.events = append(s.events, h)
+		return nil
+	}
+}
+
+// OptServerCall is a functional option on Server
+// used to register a custom PQL call. See Server.RegisterCall.
+func OptServerCall(name string, def CallDefinition) ServerOption {
+	return func(s *Server) error {
+		if err := validateCallDefinition(name, def); err != nil {
+			return errors.Wrap(err, "validating call")
+		} else if _, ok := s.calls[name]; ok {
+			return fmt.Errorf("call already registered: %s", name)
+		}
+		if s.calls == nil {
+			s.calls = make(map[string]CallDefinition)
+		}
+		s.calls[name] = def
+		return nil
+	}
+}
+
+// OptServerUDFRuntime is a functional option on Server
+// used to set the runtime which compiles user-defined functions.
+func OptServerUDFRuntime(r UDFRuntime) ServerOption {
+	return func(s *Server) error {
+		s.udfRuntime = r
+		return nil
+	}
+}
+
+// OptServerSchemaWebhooks is a functional option on Server
+// used to set the URLs which are notified of schema changes.
+func OptServerSchemaWebhooks(urls []string) ServerOption {
+	return func(s *Server) error {
+		s.webhooks = newSchemaWebhooks(urls)
+		return nil
+	}
+}
+
 // OptServerLongQueryTime is a functional option on Server
 // used to set long query duration.
 func OptServerLongQueryTime(dur time.Duration) ServerOption {
@@ -140,6 +259,37 @@ func OptServerLongQueryTime(dur time.Duration) ServerOption {
 	}
 }
 
+// OptServerRebalanceInterval is a functional option on Server used to set
+// the interval at which the coordinator moves the reads of hot shards to
+// other replicas. Zero disables rebalancing.
+func OptServerRebalanceInterval(interval time.Duration) ServerOption {
+	return func(s *Server) error {
+		if interval < 0 {
+			return errors.New("rebalance interval must not be negative")
+		}
+		s.rebalanceInterval = interval
+		return nil
+	}
+}
+
+// OptServerHeartbeat is a functional option on Server used to set the
+// interval at which nodes send each other heartbeats, and the suspicion (phi)
+// at which the failure detector considers a node SUSPECT and DOWN. A zero
+// interval disables heartbeats.
+func OptServerHeartbeat(interval time.Duration, suspectThreshold, downThreshold float64) ServerOption {
+	return func(s *Server) error {
+		if interval < 0 {
+			return errors.New("heartbeat interval must not be negative")
+		} else if suspectThreshold <= 0 || downThreshold < suspectThreshold {
+			return errors.New("down threshold must be at least the suspect threshold, which must be positive")
+		}
+		s.cluster.failures.interval = interval
+		s.cluster.failures.suspectThreshold = suspectThreshold
+		s.cluster.failures.downThreshold = downThreshold
+		return nil
+	}
+}
+
 // OptServerMaxWritesPerRequest is a functional option on Server
 // used to set the maximum number of writes allowed per request.
 func OptServerMaxWritesPerRequest(n int) ServerOption {
@@ -186,6 +336,9 @@ func OptServerInternalClient(c InternalClient) ServerOption {
 	}
 }
 
+// OptServerExecutorPoolSize is a functional option on Server
+// used to set the number of workers which execute queries against
+// local shards.
 func OptServerExecutorPoolSize(size int) ServerOption {
 	return func(s *Server) error {
 		s.executorPoolSize = size
@@ -193,6 +346,222 @@ func OptServerExecutorPoolSize(size int) ServerOption {
 	}
 }
 
+// OptServerMaxRemoteFanOut is a functional option on Server
+// used to limit the number of requests to other nodes a query
+// coordinated by the server may have outstanding at once.
+// Zero means no limit.
+func OptServerMaxRemoteFanOut(n int) ServerOption {
+	return func(s *Server) error {
+		s.maxRemoteFanOut = n
+		return nil
+	}
+}
+
+// OptServerMaxQueryFanOut is a functional option on Server
+// used to limit the number of shards a single query may have
+// queued or executing in the executor's worker pool at once.
+// Zero means no limit.
+func OptServerMaxQueryFanOut(n int) ServerOption {
+	return func(s *Server) error {
+		s.maxQueryFanOut = n
+		return nil
+	}
+}
+
+// OptServerScanConcurrency is a functional option on Server
+// used to set the number of goroutines which scan a single shard
+// for large operations such as Sum(), Rows(), and exact TopN().
+func OptServerScanConcurrency(n int) ServerOption {
+	return func(s *Server) error {
+		s.scanConcurrency = n
+		return nil
+	}
+}
+
+// OptServerTopNCacheTTL is a functional option on Server
+// used to set how long the merged results of TopN() calls
+// coordinated by the node are reused for identical calls,
+// unless a field they read is written to first. Zero
+// disables the cache.
+func OptServerTopNCacheTTL(d time.Duration) ServerOption {
+	return func(s *Server) error {
+		s.topNCacheTTL = d
+		return nil
+	}
+}
+
+// OptServerMaxQueryMemory is a functional option on Server
+// used to set the maximum number of bytes a single query may
+// allocate for intermediate rows on a node. Queries which exceed
+// it fail with ErrQueryMemoryExceeded. Zero means no limit.
+func OptServerMaxQueryMemory(n int64) ServerOption {
+	return func(s *Server) error {
+		s.maxQueryMemory = n
+		return nil
+	}
+}
+
+// OptServerQuota is a functional option on Server
+// used to set the quota which limits the usage of each API
+// token on a node. Requests which exceed it fail with
+// ErrQuotaExceeded.
+func OptServerQuota(q Quota) ServerOption {
+	return func(s *Server) error {
+		if err := q.validate(); err != nil {
+			return err
+		}
+		s.quota = q
+		return nil
+	}
+}
+
+// OptServerNamespaces is a functional option on Server
+// used to declare namespaces, binding API tokens to them and
+// setting their quotas.
+func OptServerNamespaces(namespaces ...Namespace) ServerOption {
+	return func(s *Server) error {
+		m, err := newNamespaceTokens(namespaces)
+		if err != nil {
+			return err
+		}
+		s.namespaceTokens = m
+		return nil
+	}
+}
+
+// OptServerMaxConcurrentQueries is a functional option on Server
+// used to set the maximum number of queries which may execute on
+// a node at once. Further queries wait, with interactive queries
+// admitted ahead of batch queries. Zero means no limit.
+func OptServerMaxConcurrentQueries(n int) ServerOption {
+	return func(s *Server) error {
+		s.maxConcurrentQueries = n
+		return nil
+	}
+}
+
+// OptServerMemoryLimit is a functional option on Server
+// used to set the approximate number of bytes of heap memory
+// fragments may use before idle fragments are released.
+// Zero means no limit.
+func OptServerMemoryLimit(n int64) ServerOption {
+	return func(s *Server) error {
+		s.holder.memoryLimit = n
+		return nil
+	}
+}
+
+// OptServerMaxOpenFragmentFiles is a functional option on Server
+// used to set the number of fragment data files which may be held
+// open at once, beyond which the least recently written are closed.
+// Zero means no limit.
+func OptServerMaxOpenFragmentFiles(n int) ServerOption {
+	return func(s *Server) error {
+		s.holder.maxOpenFragmentFiles = n
+		return nil
+	}
+}
+
+// OptServerGroupCommitWindow is a functional option on Server
+// used to set the window within which concurrent single-bit writes
+// to a fragment are applied together. Zero disables grouping.
+func OptServerGroupCommitWindow(d time.Duration) ServerOption {
+	return func(s *Server) error {
+		if d < 0 {
+			return errors.New("group commit window must not be negative")
+		}
+		s.holder.groupCommitWindow = d
+		return nil
+	}
+}
+
+// OptServerFsync is a functional option on Server used to set
+// when the writes to fragment files are flushed to disk: after
+// every write, at the given interval, or never, leaving it to the
+// operating system. See FsyncAlways, FsyncInterval and FsyncNever.
+func OptServerFsync(policy string, interval time.Duration) ServerOption {
+	return func(s *Server) error {
+		if err := validateFsyncPolicy(policy, interval); err != nil {
+			return err
+		}
+		s.holder.fsyncPolicy = policy
+		s.holder.fsyncInterval = interval
+		return nil
+	}
+}
+
+// OptServerLazyFragments is a functional option on Server
+// used to defer loading the data of each fragment until it
+// is first accessed, rather than when the holder is opened.
+func OptServerLazyFragments(lazy bool) ServerOption {
+	return func(s *Server) error {
+		s.holder.lazyFragments = lazy
+		return nil
+	}
+}
+
+// OptServerCacheWarmup is a functional option on Server
+// used to warm the caches of fragments for TopN() queries
+// when the holder is opened, for up to timeout, using the
+// given number of goroutines. A zero timeout disables it.
+func OptServerCacheWarmup(timeout time.Duration, concurrency int) ServerOption {
+	return func(s *Server) error {
+		s.holder.cacheWarmupTimeout = timeout
+		s.holder.cacheWarmupConcurrency = concurrency
+		return nil
+	}
+}
+
+// OptServerMinDiskFree is a functional option on Server
+// used to set the number of free bytes on the data directory's
+// file system below which the node rejects writes.
+// Zero disables the check.
+func OptServerMinDiskFree(n uint64) ServerOption {
+	return func(s *Server) error {
+		s.minDiskFree = n
+		return nil
+	}
+}
+
+// OptServerDiskCheckInterval is a functional option on Server
+// used to set the interval between checks of free disk space.
+func OptServerDiskCheckInterval(interval time.Duration) ServerOption {
+	return func(s *Server) error {
+		s.diskCheckInterval = interval
+		return nil
+	}
+}
+
+// OptServerAttrExpiryInterval is a functional option on Server
+// used to set the interval at which expired attributes are removed.
+// Zero disables their removal.
+func OptServerAttrExpiryInterval(interval time.Duration) ServerOption {
+	return func(s *Server) error {
+		s.attrExpiryInterval = interval
+		return nil
+	}
+}
+
+// OptServerHeapHighWater is a functional option on Server
+// used to set the number of bytes of heap in use above which
+// the node rejects batch queries and imports.
+// Zero disables the check.
+func OptServerHeapHighWater(n uint64) ServerOption {
+	return func(s *Server) error {
+		s.heapHighWater = n
+		return nil
+	}
+}
+
+// OptServerHeapCheckInterval is a functional option on Server
+// used to set the interval between checks of the heap in use.
+func OptServerHeapCheckInterval(interval time.Duration) ServerOption {
+	return func(s *Server) error {
+		s.heapCheckInterval = interval
+		return nil
+	}
+}
+
 // OptServerPrimaryTranslateStore has been deprecated.
 func OptServerPrimaryTranslateStore(store TranslateStore) ServerOption {
 	return func(s *Server) error {
@@ -228,6 +597,16 @@ func OptServerURI(uri *URI) ServerOption {
 	}
 }
 
+// OptServerInternalURI is a functional option on Server used to set the
+// address of a listener for requests from other nodes, separate from the
+// public API. Other nodes send this node internal requests on it.
+func OptServerInternalURI(uri *URI) ServerOption {
+	return func(s *Server) error {
+		s.internalURI = uri
+		return nil
+	}
+}
+
 // OptServerClusterDisabled tells the server whether to use a static cluster with the
 // defined hosts. Mostly used for testing.
 func OptServerClusterDisabled(disabled bool, hosts []string) ServerOption {
@@ -256,6 +635,16 @@ func OptServerIsCoordinator(is bool) ServerOption {
 	}
 }
 
+// OptServerZone is a functional option on Server
+// used to set the zone, such as a rack or availability zone,
+// which the server is in.
+func OptServerZone(zone string) ServerOption {
+	return func(s *Server) error {
+		s.zone = zone
+		return nil
+	}
+}
+
 // OptServerNodeID is a functional option on Server
 // used to set the server node ID.
 func OptServerNodeID(nodeID string) ServerOption {
@@ -310,6 +699,21 @@ func NewServer(opts ...ServerOption) (*Server, error) {
 		diagnosticInterval:  0,
 
 		logger: logger.NopLogger,
+
+		udfs: make(map[string]UDFModule),
+
+		jobs: newJobRegistry(),
+
+		scheduleClient: &http.Client{Timeout: 10 * time.Second},
+
+		load:          &loadMonitor{},
+		peerMaxShards: make(map[string]map[string]uint64),
+		peerLoads:     make(map[string]NodeLoad),
+
+		diskCheckInterval:  defaultDiskCheckInterval,
+		heapCheckInterval:  defaultHeapCheckInterval,
+		diskFree:           diskFree,
+		attrExpiryInterval: defaultAttrExpiryInterval,
 	}
 	s.cluster.InternalClient = s.defaultClient
 
@@ -327,7 +731,28 @@ func NewServer(opts ...ServerOption) (*Server, error) {
 	if s.executorPoolSize > 0 {
 		executorOpts = append(executorOpts, optExecutorWorkerPoolSize(s.executorPoolSize))
 	}
+	if s.maxQueryFanOut > 0 {
+		executorOpts = append(executorOpts, optExecutorMaxQueryFanOut(s.maxQueryFanOut))
+	}
+	if s.maxRemoteFanOut > 0 {
+		executorOpts = append(executorOpts, optExecutorMaxRemoteFanOut(s.maxRemoteFanOut))
+	}
+	if s.scanConcurrency > 0 {
+		executorOpts = append(executorOpts, optExecutorScanConcurrency(s.scanConcurrency))
+	}
+	if s.maxQueryMemory > 0 {
+		executorOpts = append(executorOpts, optExecutorMaxQueryMemory(s.maxQueryMemory))
+	}
+	if s.topNCacheTTL > 0 {
+		executorOpts = append(executorOpts, optExecutorTopNCacheTTL(s.topNCacheTTL))
+	}
+	for name, def := range s.calls {
+		executorOpts = append(executorOpts, optExecutorCall(name, def))
+	}
 	s.executor = newExecutor(executorOpts...)
+	s.admission = newAdmission(s.maxConcurrentQueries)
+	s.usage = newUsageMeter(s.quota)
+	s.usage.namespaces = s.namespaceTokens
 
 	// s.holder.translateFile.logger = s.logger
 
@@ -340,6 +765,10 @@ func NewServer(opts ...ServerOption) (*Server, error) {
 	// s.holder.translateFile.Path = filepath.Join(path, ".keys")
 	s.holder.Logger = s.logger
 	s.holder.Stats.SetLogger(s.logger)
+	if s.webhooks != nil {
+		s.webhooks.Logger = s.logger
+		s.events = append(s.events, s.webhooks)
+	}
 
 	s.cluster.Path = path
 	s.cluster.logger = s.logger
@@ -357,6 +786,10 @@ func NewServer(opts ...ServerOption) (*Server, error) {
 		URI:           s.uri,
 		IsCoordinator: s.cluster.Coordinator == s.nodeID,
 		State:         nodeStateDown,
+		Zone:          s.zone,
+		Version:       Version,
+		Features:      supportedFeatures,
+		InternalURI:   s.internalURI,
 	}
 	s.cluster.Node = node
 	if s.clusterDisabled {
@@ -372,8 +805,17 @@ func NewServer(opts ...ServerOption) (*Server, error) {
 	s.executor.Holder = s.holder
 	s.executor.Node = node
 	s.executor.Cluster = s.cluster
-	s.executor.MaxWritesPerRequest = s.maxWritesPerRequest
+	s.settings = newSettingsStore(Settings{
+		AntiEntropyInterval: toml.Duration(s.antiEntropyInterval),
+		LongQueryTime:       toml.Duration(s.cluster.longQueryTime),
+		MaxWritesPerRequest: s.maxWritesPerRequest,
+		MaxQueryMemory:      s.maxQueryMemory,
+		DefaultCacheType:    DefaultCacheType,
+		DefaultCacheSize:    DefaultCacheSize,
+	})
+	s.applySettings()
 	s.cluster.broadcaster = s
+	s.cluster.events = s.events
 	s.cluster.maxWritesPerRequest = s.maxWritesPerRequest
 	s.holder.broadcaster = s
 
@@ -423,6 +865,12 @@ func (s *Server) Open() error {
 		log.Println(errors.Wrap(err, "logging startup"))
 	}
 
+	// Refuse to move a node which holds data to another zone before it
+	// joins the cluster, as that would change which nodes own its shards.
+	if err := s.holder.saveZone(s.cluster.Node.Zone); err != nil {
+		return errors.Wrap(err, "saving zone")
+	}
+
 	// Open Cluster management.
 	if err := s.cluster.waitForStarted(); err != nil {
 		return errors.Wrap(err, "opening Cluster")
@@ -432,6 +880,24 @@ func (s *Server) Open() error {
 	if err := s.holder.Open(); err != nil {
 		return errors.Wrap(err, "opening Holder")
 	}
+	if err := s.jobs.open(filepath.Join(s.holder.Path, jobJournalFile), s.logger); err != nil {
+		return errors.Wrap(err, "opening job journal")
+	}
+	if err := s.usage.open(filepath.Join(s.holder.Path, usageFile), s.logger); err != nil {
+		return errors.Wrap(err, "opening usage file")
+	}
+	if err := s.settings.open(filepath.Join(s.holder.Path, settingsFile)); err != nil {
+		return errors.Wrap(err, "opening settings file")
+	}
+	if err := s.cluster.hints.open(filepath.Join(s.holder.Path, hintsDir)); err != nil {
+		return errors.Wrap(err, "opening hint log")
+	}
+	s.applySettings()
+	if s.udfRuntime != nil {
+		if err := s.loadUDFs(); err != nil {
+			return errors.Wrap(err, "loading UDFs")
+		}
+	}
 	if err := s.cluster.setNodeState(nodeStateReady); err != nil {
 		return errors.Wrap(err, "setting nodeState")
 	}
@@ -450,10 +916,38 @@ func (s *Server) Open() error {
 	s.syncer.Stats = s.holder.Stats.WithTags("HolderSyncer")
 
 	// Start background monitoring.
-	s.wg.Add(3)
+	s.wg.Add(10)
 	go func() { defer s.wg.Done(); s.monitorAntiEntropy() }()
+	go func() { defer s.wg.Done(); s.monitorLoad() }()
 	go func() { defer s.wg.Done(); s.monitorRuntime() }()
 	go func() { defer s.wg.Done(); s.monitorDiagnostics() }()
+	go func() { defer s.wg.Done(); s.webhooks.run(s.closing) }()
+	go func() { defer s.wg.Done(); s.monitorUsageStorage() }()
+	go func() { defer s.wg.Done(); s.monitorEphemeralFields() }()
+	go func() { defer s.wg.Done(); s.monitorSchedules() }()
+	go func() { defer s.wg.Done(); s.monitorRebalance() }()
+	go func() { defer s.wg.Done(); s.sendTopNInvalidations() }()
+	if s.minDiskFree > 0 {
+		s.wg.Add(1)
+		go func() { defer s.wg.Done(); s.monitorDiskSpace() }()
+	}
+	if s.attrExpiryInterval > 0 {
+		s.wg.Add(1)
+		go func() { defer s.wg.Done(); s.monitorAttrExpiry() }()
+	}
+	if s.heapHighWater > 0 {
+		s.checkHeap()
+		s.wg.Add(1)
+		go func() { defer s.wg.Done(); s.monitorHeap() }()
+	}
+	if s.cluster.failures.interval > 0 && !s.clusterDisabled {
+		s.wg.Add(1)
+		go func() { defer s.wg.Done(); s.monitorHeartbeats() }()
+	}
+	if s.cluster.ReplicaN > 1 {
+		s.wg.Add(1)
+		go func() { defer s.wg.Done(); s.repairFragments() }()
+	}
 
 	return nil
 }
@@ -465,6 +959,11 @@ func (s *Server) Close() error {
 	// Notify goroutines to stop.
 	close(s.closing)
 	s.wg.Wait()
+	s.jobs.close()
+	if err := s.usage.close(); err != nil {
+		s.logger.Printf("closing usage file: %v", err)
+	}
+	s.closeUDFs()
 
 	var errh error
 	var errc error
@@ -505,22 +1004,58 @@ func (s *Server) loadNodeID() string {
 // NodeID returns the server's node id.
 func (s *Server) NodeID() string { return s.nodeID }
 
+// RegisterCall adds a custom PQL call which can be used in queries against
+// any index. The call must be registered on every node in the cluster.
+// It returns an error if the name is invalid, already registered, or
+// belongs to a built-in call.
+func (s *Server) RegisterCall(name string, def CallDefinition) error {
+	return s.executor.registerCall(name, def)
+}
+
 // SyncData manually invokes the anti entropy process which makes sure that this
 // node has the data from all replicas across the cluster.
 func (s *Server) SyncData() error {
-	return errors.Wrap(s.syncer.SyncHolder(), "syncing holder")
+	return errors.Wrap(s.syncHolder(), "syncing holder")
+}
+
+// syncHolder runs the holder syncer and publishes a SyncEvent on completion.
+func (s *Server) syncHolder() error {
+	t := time.Now()
+	err := s.syncer.SyncHolder()
+	s.events.HandleEvent(SyncEvent{Duration: time.Since(t), Err: err})
+	return err
 }
 
 func (s *Server) monitorAntiEntropy() {
-	if s.antiEntropyInterval == 0 || s.cluster.ReplicaN <= 1 {
+	if s.cluster.ReplicaN <= 1 {
 		return // anti entropy disabled
 	}
 	s.cluster.initializeAntiEntropy()
 
-	ticker := time.NewTicker(s.antiEntropyInterval)
-	defer ticker.Stop()
-
-	s.logger.Printf("holder sync monitor initializing (%s interval)", s.antiEntropyInterval)
+	// The interval is part of the cluster settings, so the ticker is
+	// replaced when they change. A zero interval disables anti-entropy
+	// until it is set again.
+	var interval time.Duration
+	var ticker *time.Ticker
+	var tick <-chan time.Time
+	reset := func() {
+		if ticker != nil {
+			ticker.Stop()
+			ticker, tick = nil, nil
+		}
+		interval = time.Duration(s.settings.get().AntiEntropyInterval)
+		if interval > 0 {
+			ticker = time.NewTicker(interval)
+			tick = ticker.C
+		}
+		s.logger.Printf("holder sync monitor initializing (%s interval)", interval)
+	}
+	reset()
+	defer func() {
+		if ticker != nil {
+			ticker.Stop()
+		}
+	}()
 
 	// Initialize syncer with local holder and remote client.
 	for {
@@ -530,7 +1065,12 @@ func (s *Server) monitorAntiEntropy() {
 			return
 		case <-s.cluster.abortAntiEntropyCh: // receive here so we don't block resizing
 			continue
-		case <-ticker.C:
+		case <-s.settings.changed:
+			if time.Duration(s.settings.get().AntiEntropyInterval) != interval {
+				reset()
+			}
+			continue
+		case <-tick:
 			s.holder.Stats.Count("AntiEntropy", 1, 1.0)
 		}
 		t := time.Now()
@@ -541,7 +1081,7 @@ func (s *Server) monitorAntiEntropy() {
 		}
 		// Sync holders.
 		s.logger.Printf("holder sync beginning")
-		if err := s.syncer.SyncHolder(); err != nil {
+		if err := s.syncHolder(); err != nil {
 			s.logger.Printf("holder sync error: err=%s", err)
 			continue
 		}
@@ -556,7 +1096,7 @@ func (s *Server) monitorAntiEntropy() {
 		// other.
 		for {
 			select {
-			case <-ticker.C:
+			case <-tick:
 				continue
 			default:
 			}
@@ -565,6 +1105,33 @@ func (s *Server) monitorAntiEntropy() {
 	}
 }
 
+// publishMessageEvent sends the event corresponding to a broadcast message,
+// if any, to the registered event handlers. It is called both for messages
+// originating on this node and for those received from other nodes, once
+// they have been applied.
+func (s *Server) publishMessageEvent(m Message) {
+	if len(s.events) == 0 {
+		return
+	}
+	var ev Event
+	now := time.Now().UTC()
+	switch obj := m.(type) {
+	case *CreateIndexMessage:
+		ev = SchemaEvent{Type: SchemaEventCreateIndex, Index: obj.Index, NodeID: s.nodeID, Time: now}
+	case *DeleteIndexMessage:
+		ev = SchemaEvent{Type: SchemaEventDeleteIndex, Index: obj.Index, NodeID: s.nodeID, Time: now}
+	case *CreateFieldMessage:
+		ev = SchemaEvent{Type: SchemaEventCreateField, Index: obj.Index, Field: obj.Field, NodeID: s.nodeID, Time: now}
+	case *DeleteFieldMessage:
+		ev = SchemaEvent{Type: SchemaEventDeleteField, Index: obj.Index, Field: obj.Field, NodeID: s.nodeID, Time: now}
+	case *CreateShardMessage:
+		ev = ShardEvent{Index: obj.Index, Field: obj.Field, Shard: obj.Shard}
+	default:
+		return
+	}
+	s.events.HandleEvent(ev)
+}
+
 // receiveMessage represents an implementation of BroadcastHandler.
 func (s *Server) receiveMessage(m Message) error {
 	switch obj := m.(type) {
@@ -582,10 +1149,13 @@ func (s *Server) receiveMessage(m Message) error {
 		if err != nil {
 			return err
 		}
+		s.observeSchemaVersion(obj.SchemaVersion)
 	case *DeleteIndexMessage:
 		if err := s.holder.DeleteIndex(obj.Index); err != nil {
 			return err
 		}
+		s.executor.invalidateTopN(obj.Index)
+		s.observeSchemaVersion(obj.SchemaVersion)
 	case *CreateFieldMessage:
 		idx := s.holder.Index(obj.Index)
 		if idx == nil {
@@ -596,11 +1166,19 @@ func (s *Server) receiveMessage(m Message) error {
 		if err != nil {
 			return err
 		}
+		s.observeSchemaVersion(obj.SchemaVersion)
 	case *DeleteFieldMessage:
 		idx := s.holder.Index(obj.Index)
 		if err := idx.DeleteField(obj.Field); err != nil {
 			return err
 		}
+		s.executor.invalidateTopN(obj.Index, obj.Field)
+		s.observeSchemaVersion(obj.SchemaVersion)
+	case *SchemaChangeRequest:
+		if !s.cluster.isCoordinator() {
+			return ErrNodeNotCoordinator
+		}
+		return s.makeSchemaChange(obj.message())
 	case *DeleteAvailableShardMessage:
 		f := s.holder.Field(obj.Index, obj.Field)
 		if err := f.RemoveAvailableShard(obj.ShardID); err != nil {
@@ -624,6 +1202,58 @@ func (s *Server) receiveMessage(m Message) error {
 		if err != nil {
 			return err
 		}
+	case *HeartbeatMessage:
+		s.cluster.failures.heartbeat(obj.NodeID, time.Now())
+	case *CreateMaterializedViewMessage:
+		idx := s.holder.Index(obj.Index)
+		if idx == nil {
+			return fmt.Errorf("local index not found: %s", obj.Index)
+		}
+		if _, err := idx.createMaterializedView(obj.Name, obj.Query, obj.Call, obj.Version); err != nil {
+			return err
+		}
+	case *DeleteMaterializedViewMessage:
+		idx := s.holder.Index(obj.Index)
+		if idx == nil {
+			return fmt.Errorf("local index not found: %s", obj.Index)
+		}
+		if _, err := idx.deleteMaterializedView(obj.Name, obj.Version); err != nil && errors.Cause(err) != ErrMaterializedViewNotFound {
+			return err
+		}
+	case *CreateRewriteRuleMessage:
+		idx := s.holder.Index(obj.Index)
+		if idx == nil {
+			return fmt.Errorf("local index not found: %s", obj.Index)
+		}
+		if _, err := idx.createRewriteRule(obj.Rule, obj.Version); err != nil {
+			return err
+		}
+	case *DeleteRewriteRuleMessage:
+		idx := s.holder.Index(obj.Index)
+		if idx == nil {
+			return fmt.Errorf("local index not found: %s", obj.Index)
+		}
+		if _, err := idx.deleteRewriteRule(obj.Name, obj.Version); err != nil && errors.Cause(err) != ErrRewriteRuleNotFound {
+			return err
+		}
+	case *IndexReadOnlyMessage:
+		idx := s.holder.Index(obj.Index)
+		if idx == nil {
+			return fmt.Errorf("local index not found: %s", obj.Index)
+		}
+		if err := idx.setReadOnly(obj.ReadOnly); err != nil {
+			return err
+		}
+	case *TruncateFieldMessage:
+		f := s.holder.Field(obj.Index, obj.Field)
+		if f == nil {
+			return fmt.Errorf("local field not found: %s", obj.Field)
+		}
+		if err := f.truncate(); err != nil {
+			return err
+		}
+		s.holder.changes.append(ChangeEvent{Type: ChangeTypeTruncate, Index: obj.Index, Field: obj.Field})
+		s.executor.invalidateTopN(obj.Index, obj.Field)
 	case *ClusterStatus:
 		err := s.cluster.mergeClusterStatus(obj)
 		if err != nil {
@@ -657,13 +1287,56 @@ func (s *Server) receiveMessage(m Message) error {
 		}
 	case *NodeStatus:
 		s.handleRemoteStatus(obj)
+	case *LoadUDFMessage:
+		if err := s.loadUDF(context.Background(), obj.Name, obj.Code, true); err != nil {
+			return errors.Wrapf(err, "loading udf %s", obj.Name)
+		}
+	case *DeleteUDFMessage:
+		if err := s.deleteUDF(context.Background(), obj.Name); err != nil {
+			return errors.Wrapf(err, "deleting udf %s", obj.Name)
+		}
+	case *NodeReadOnlyMessage:
+		s.cluster.setNodeReadOnly(obj.NodeID, obj.ReadOnly)
+	case *SettingsMessage:
+		if err := s.updateSettings(obj.Settings); err != nil {
+			return errors.Wrap(err, "updating settings")
+		}
+	case *AliasesMessage:
+		if _, err := s.holder.aliases.update(obj.Aliases); err != nil {
+			return errors.Wrap(err, "updating aliases")
+		}
+	case *CreateNamedQueryMessage:
+		if _, err := s.holder.namedQueries.create(obj.Query, obj.Version); err != nil {
+			return errors.Wrap(err, "creating named query")
+		}
+	case *DeleteNamedQueryMessage:
+		if _, err := s.holder.namedQueries.delete(obj.Name, obj.Version); err != nil && errors.Cause(err) != ErrNamedQueryNotFound {
+			return errors.Wrap(err, "deleting named query")
+		}
+	case *CreateScheduleMessage:
+		if err := s.holder.schedules.create(obj.Schedule); err != nil {
+			return errors.Wrap(err, "creating schedule")
+		}
+	case *DeleteScheduleMessage:
+		if err := s.holder.schedules.delete(obj.Name); err != nil && errors.Cause(err) != ErrScheduleNotFound {
+			return errors.Wrap(err, "deleting schedule")
+		}
+	case *ScheduleRunMessage:
+		s.holder.schedules.addRun(obj.Name, obj.Run)
+	case *ReadOwnersMessage:
+		s.cluster.setReadOwners(obj.Owners)
+	case *InvalidateTopNMessage:
+		s.executor.invalidateTopN(obj.Index, obj.Fields...)
 	}
+	s.publishMessageEvent(m)
 
 	return nil
 }
 
 // SendSync represents an implementation of Broadcaster.
 func (s *Server) SendSync(m Message) error {
+	s.publishMessageEvent(m)
+
 	var eg errgroup.Group
 	msg, err := s.serializer.Marshal(m)
 	if err != nil {
@@ -679,7 +1352,7 @@ func (s *Server) SendSync(m Message) error {
 		}
 
 		eg.Go(func() error {
-			return s.defaultClient.SendMessage(context.Background(), &node.URI, msg)
+			return s.defaultClient.SendMessage(context.Background(), node.clusterURI(), msg)
 		})
 	}
 
@@ -698,7 +1371,7 @@ func (s *Server) SendTo(to *Node, m Message) error {
 		return fmt.Errorf("marshaling message: %v", err)
 	}
 	msg = append([]byte{getMessageType(m)}, msg...)
-	return s.defaultClient.SendMessage(context.Background(), &to.URI, msg)
+	return s.defaultClient.SendMessage(context.Background(), to.clusterURI(), msg)
 }
 
 // node returns the pilosa.node object. It is used by membership protocols to
@@ -707,6 +1380,40 @@ func (s *Server) node() Node {
 	return *s.cluster.Node
 }
 
+// updateSettings replaces the cluster settings with settings, if they are
+// more recent, and applies them.
+func (s *Server) updateSettings(settings Settings) error {
+	if ok, err := s.settings.update(settings); !ok {
+		return err
+	} else if err != nil {
+		s.logger.Printf("saving settings: %v", err)
+	}
+	s.logger.Printf("cluster settings changed: %+v", settings)
+	s.applySettings()
+	return nil
+}
+
+// changeSettings applies update to the node's cluster settings, and returns
+// the new settings.
+func (s *Server) changeSettings(update SettingsUpdate) (Settings, error) {
+	settings, err := s.settings.apply(update)
+	if _, ok := err.(BadRequestError); ok {
+		return Settings{}, err
+	} else if err != nil {
+		s.logger.Printf("saving settings: %v", err)
+	}
+	s.logger.Printf("cluster settings changed: %+v", settings)
+	s.applySettings()
+	return settings, nil
+}
+
+// applySettings applies the cluster settings which are not read from the
+// settings store directly.
+func (s *Server) applySettings() {
+	settings := s.settings.get()
+	s.executor.setLimits(settings.MaxWritesPerRequest, settings.MaxQueryMemory)
+}
+
 // handleRemoteStatus receives incoming NodeStatus from remote nodes.
 func (s *Server) handleRemoteStatus(pb Message) {
 	// Ignore NodeStatus messages until the cluster is in a Normal state.
@@ -731,11 +1438,102 @@ func (s *Server) mergeRemoteStatus(ns *NodeStatus) error {
 		return nil
 	}
 
+	// Record the node's maximum shards and load, so they can be reported
+	// without asking it.
+	s.peersMu.Lock()
+	s.peerMaxShards[ns.Node.ID] = ns.maxShards()
+	if ns.Load != nil {
+		s.peerLoads[ns.Node.ID] = *ns.Load
+	}
+	s.peersMu.Unlock()
+
 	// Sync schema.
 	if err := s.holder.applySchema(ns.Schema); err != nil {
 		return errors.Wrap(err, "applying schema")
 	}
 
+	// Sync settings, in case a broadcast was missed.
+	if ns.Settings != nil {
+		if err := s.updateSettings(*ns.Settings); err != nil {
+			return errors.Wrap(err, "updating settings")
+		}
+	}
+
+	// Sync aliases, which are ordered by version like the settings.
+	if ns.Aliases != nil {
+		if _, err := s.holder.aliases.update(*ns.Aliases); err != nil {
+			return errors.Wrap(err, "updating aliases")
+		}
+	}
+
+	// Apply the changes to materialized views which are later than those
+	// made here, including deletions, in case a broadcast was missed. Nodes
+	// which don't version the views send them unversioned; those are taken
+	// as the earliest version, so they only create the views which are
+	// missing.
+	for _, m := range ns.MaterializedViews {
+		idx := s.holder.Index(m.Index)
+		if idx == nil {
+			continue
+		}
+		version := m.Version
+		if version == 0 {
+			version = 1
+		}
+		if _, err := idx.createMaterializedView(m.Name, m.Query, m.Call, version); err != nil {
+			s.logger.Printf("creating materialized view %s of index %s: %v", m.Name, m.Index, err)
+		}
+	}
+	for _, m := range ns.DeletedMaterializedViews {
+		idx := s.holder.Index(m.Index)
+		if idx == nil {
+			continue
+		}
+		if _, err := idx.deleteMaterializedView(m.Name, m.Version); err != nil {
+			s.logger.Printf("deleting materialized view %s of index %s: %v", m.Name, m.Index, err)
+		}
+	}
+
+	// Likewise rewrite rules.
+	for _, m := range ns.RewriteRules {
+		idx := s.holder.Index(m.Index)
+		if idx == nil {
+			continue
+		}
+		version := m.Version
+		if version == 0 {
+			version = 1
+		}
+		if _, err := idx.createRewriteRule(m.Rule, version); err != nil {
+			s.logger.Printf("creating rewrite rule %s of index %s: %v", m.Rule.Name, m.Index, err)
+		}
+	}
+	for _, m := range ns.DeletedRewriteRules {
+		idx := s.holder.Index(m.Index)
+		if idx == nil {
+			continue
+		}
+		if _, err := idx.deleteRewriteRule(m.Name, m.Version); err != nil {
+			s.logger.Printf("deleting rewrite rule %s of index %s: %v", m.Name, m.Index, err)
+		}
+	}
+
+	// Likewise named queries.
+	for _, m := range ns.NamedQueries {
+		version := m.Version
+		if version == 0 {
+			version = 1
+		}
+		if _, err := s.holder.namedQueries.create(m.Query, version); err != nil {
+			s.logger.Printf("creating named query %s: %v", m.Query.Name, err)
+		}
+	}
+	for _, m := range ns.DeletedNamedQueries {
+		if _, err := s.holder.namedQueries.delete(m.Name, m.Version); err != nil {
+			s.logger.Printf("deleting named query %s: %v", m.Name, err)
+		}
+	}
+
 	// Sync available shards.
 	for _, is := range ns.Indexes {
 		for _, fs := range is.Fields {
@@ -750,6 +1548,7 @@ func (s *Server) mergeRemoteStatus(ns *NodeStatus) error {
 			if err := f.AddRemoteAvailableShards(fs.AvailableShards); err != nil {
 				return errors.Wrap(err, "adding remote available shards")
 			}
+			f.mergeLastAccess(fs.LastAccess)
 		}
 	}
 
@@ -24,6 +24,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/pilosa/pilosa/v2"
 	"github.com/pilosa/pilosa/v2/gossip"
 	"github.com/pilosa/pilosa/v2/toml"
 	"github.com/pkg/errors"
@@ -44,6 +45,30 @@ type TLSConfig struct {
 	EnableClientVerification bool `toml:"enable-client-verification"`
 }
 
+// QuotaConfig limits the usage of API tokens within a period.
+type QuotaConfig struct {
+	// Period is "month" or "182d".
+	Period string `toml:"period"`
+	// Queries is the number of queries a token may issue.
+	Queries int64 `toml:"queries"`
+	// Containers is the number of containers a token's queries may scan.
+	Containers int64 `toml:"containers"`
+	// ImportBytes is the number of bytes a token may import.
+	ImportBytes int64 `toml:"import-bytes"`
+}
+
+// NamespaceConfig declares a namespace, which holds the indexes named
+// "<name>.<index>".
+type NamespaceConfig struct {
+	// Name is the name of the namespace.
+	Name string `toml:"name"`
+	// Tokens are the API tokens bound to the namespace, which may only be
+	// used on its indexes.
+	Tokens []string `toml:"tokens"`
+	// Quota limits the combined usage of the namespace's tokens.
+	Quota QuotaConfig `toml:"quota"`
+}
+
 // Config represents the configuration for the command.
 type Config struct {
 	// DataDir is the directory where Pilosa stores both indexed data and
@@ -58,11 +83,24 @@ type Config struct {
 	// route to an interface that Bind is listening on.
 	Advertise string `toml:"advertise"`
 
+	// BindInternal is the host:port on which Pilosa listens for requests
+	// from other nodes, separately from the public API. If empty, other
+	// nodes send their requests to Bind.
+	BindInternal string `toml:"bind-internal"`
+
+	// AdvertiseInternal is the address of the internal listener which is
+	// advertised to other nodes in the cluster.
+	AdvertiseInternal string `toml:"advertise-internal"`
+
 	// MaxWritesPerRequest limits the number of mutating commands that can be in
 	// a single request to the server. This includes Set, Clear,
 	// SetRowAttrs & SetColumnAttrs.
 	MaxWritesPerRequest int `toml:"max-writes-per-request"`
 
+	// ChangeLogSize is the number of recent mutations retained in memory
+	// for the change data capture stream. Zero disables the stream.
+	ChangeLogSize int `toml:"change-log-size"`
+
 	// LogPath configures where Pilosa will write logs.
 	LogPath string `toml:"log-path"`
 
@@ -73,8 +111,29 @@ type Config struct {
 	Handler struct {
 		// CORS Allowed Origins
 		AllowedOrigins []string `toml:"allowed-origins"`
+
+		// StreamThreshold is the number of columns of a row above which
+		// query responses are streamed. Zero disables streaming.
+		StreamThreshold uint64 `toml:"stream-threshold"`
 	} `toml:"handler"`
 
+	// Secondary configures an optional additional listener for the
+	// public API, such as a plaintext listener on the loopback interface
+	// for sidecars alongside a TLS listener on Bind.
+	Secondary struct {
+		// Bind is the host:port on which the listener listens. The
+		// listener is disabled if it is empty.
+		Bind string `toml:"bind"`
+
+		// CORS Allowed Origins of requests to the listener, which
+		// replace those of Handler.
+		AllowedOrigins []string `toml:"allowed-origins"`
+	} `toml:"secondary"`
+
+	// SchemaWebhooks are URLs which receive a JSON POST whenever an index
+	// or field is created or deleted on this node.
+	SchemaWebhooks []string `toml:"schema-webhooks"`
+
 	// MaxMapCount puts an in-process limit on the number of mmaps. After this
 	// is exhausted, Pilosa will fall back to reading the file into memory
 	// normally.
@@ -91,11 +150,81 @@ type Config struct {
 	TLS TLSConfig `toml:"tls"`
 
 	// WorkerPoolSize controls how many goroutines are created for
-	// processing queries. Defaults to runtime.NumCPU(). It is
-	// intentionally not defined as a flag... only exposed here so
-	// that we can limit the size while running tests in CI so we
-	// don't exhaust the goroutine limit.
-	WorkerPoolSize int
+	// processing queries against local shards. Defaults to
+	// runtime.NumCPU().
+	WorkerPoolSize int `toml:"worker-pool-size"`
+
+	// MaxQueryFanOut limits the number of shards a single query may have
+	// queued or executing in the worker pool at once, so that queries
+	// over many shards do not starve other queries. Zero means no limit.
+	MaxQueryFanOut int `toml:"max-query-fan-out"`
+
+	// MaxRemoteFanOut limits the number of requests to other nodes a
+	// query coordinated by this node may have outstanding at once, which
+	// bounds the partial results held in memory on wide clusters. Zero
+	// means no limit.
+	MaxRemoteFanOut int `toml:"max-remote-fan-out"`
+
+	// ScanConcurrency is the number of goroutines used to scan a single
+	// shard for large operations such as Sum(), Rows(), and exact TopN().
+	ScanConcurrency int `toml:"scan-concurrency"`
+
+	// TopNCacheTTL is how long the merged results of TopN() calls are
+	// reused for identical calls, unless a field they read is written to
+	// through this node first. Zero disables the cache.
+	TopNCacheTTL toml.Duration `toml:"topn-cache-ttl"`
+
+	// MaxQueryMemory is the maximum number of bytes a single query may
+	// allocate for intermediate rows on a node. Zero means no limit.
+	MaxQueryMemory int64 `toml:"max-query-memory"`
+
+	// MaxConcurrentQueries is the maximum number of queries which may
+	// execute on a node at once. Further queries wait, with interactive
+	// queries admitted ahead of batch queries. Zero means no limit.
+	MaxConcurrentQueries int `toml:"max-concurrent-queries"`
+
+	// MemoryLimit is the approximate number of bytes of heap memory which
+	// fragments may use before idle fragments are released. Zero means no
+	// limit.
+	MemoryLimit int64 `toml:"memory-limit"`
+
+	// HeapHighWater is the number of bytes of heap in use above which batch
+	// queries and imports are rejected, so that the server is not killed
+	// for running out of memory. Zero disables the check.
+	HeapHighWater uint64 `toml:"heap-high-water"`
+
+	// LazyFragments defers loading the data of each fragment until it is
+	// first accessed, so that the server starts without reading every
+	// fragment from disk.
+	LazyFragments bool `toml:"lazy-fragments"`
+
+	// MaxOpenFragmentFiles is the number of fragment data files which may be
+	// held open at once. Beyond it, the files of the least recently written
+	// fragments are closed, and reopened when they are next written to.
+	// Zero means no limit.
+	MaxOpenFragmentFiles int `toml:"max-open-fragment-files"`
+
+	// GroupCommitWindow is how long a single-bit write to a fragment waits
+	// for concurrent writes to the same fragment, which are then applied
+	// together with one acquisition of its lock and one append to its op
+	// log. Zero disables grouping.
+	GroupCommitWindow toml.Duration `toml:"group-commit-window"`
+
+	// Fsync is when the writes appended to fragment files are flushed to
+	// disk: "always", after every write; "interval", every FsyncInterval;
+	// or "never", leaving it to the operating system. Files are flushed
+	// when they are closed under every policy.
+	Fsync         string        `toml:"fsync"`
+	FsyncInterval toml.Duration `toml:"fsync-interval"`
+
+	// MinDiskFree is the number of free bytes on the file system containing
+	// the data directory below which writes are rejected. Zero disables the
+	// check.
+	MinDiskFree uint64 `toml:"min-disk-free"`
+
+	// AttrExpiryInterval is the interval at which attributes past their
+	// expiration are removed. Zero disables their removal.
+	AttrExpiryInterval toml.Duration `toml:"attr-expiry-interval"`
 
 	// ImportWorkerPoolSize controls how many goroutines are created for
 	// processing importRoaring jobs. Defaults to runtime.NumCPU(). It is
@@ -110,6 +239,24 @@ type Config struct {
 		Coordinator bool     `toml:"coordinator"`
 		ReplicaN    int      `toml:"replicas"`
 		Hosts       []string `toml:"hosts"`
+		// Zone is the failure domain, such as a rack or availability
+		// zone, which the node is in.
+		Zone string `toml:"zone"`
+		// HeartbeatInterval is the interval at which nodes send each
+		// other heartbeats. SuspectThreshold and DownThreshold are the
+		// suspicion (phi) that a node has failed, judged from the
+		// heartbeats received from it, at which it is considered
+		// SUSPECT and DOWN.
+		HeartbeatInterval toml.Duration `toml:"heartbeat-interval"`
+		SuspectThreshold  float64       `toml:"suspect-threshold"`
+		DownThreshold     float64       `toml:"down-threshold"`
+		// Compression is the codec which nodes compress the data they
+		// send each other with: zstd, snappy, or none.
+		Compression string `toml:"compression"`
+		// RebalanceInterval is the interval at which the coordinator
+		// moves the reads of hot shards to other replicas. Zero
+		// disables rebalancing.
+		RebalanceInterval toml.Duration `toml:"rebalance-interval"`
 		// TODO(2.0) move this out of cluster. (why is it here??)
 		LongQueryTime toml.Duration `toml:"long-query-time"`
 	} `toml:"cluster"`
@@ -127,17 +274,36 @@ type Config struct {
 		Interval toml.Duration `toml:"interval"`
 	} `toml:"anti-entropy"`
 
+	// CacheWarmup configures the warming of fragment caches for TopN()
+	// queries when the server starts.
+	CacheWarmup struct {
+		// Timeout is how long to spend warming caches. Zero disables
+		// warming.
+		Timeout toml.Duration `toml:"timeout"`
+
+		// Concurrency is the number of fragments warmed at once.
+		Concurrency int `toml:"concurrency"`
+	} `toml:"cache-warmup"`
+
 	Metric struct {
 		// Service can be statsd, expvar, or none.
 		Service string `toml:"service"`
 		// Host tells the statsd client where to write.
-		Host         string        `toml:"host"`
+		Host string `toml:"host"`
+		// SampleRate is the fraction of events the statsd client sends.
+		SampleRate   float64       `toml:"sample-rate"`
 		PollInterval toml.Duration `toml:"poll-interval"`
 		// Diagnostics toggles sending some limited diagnostic information to
 		// Pilosa's developers.
 		Diagnostics bool `toml:"diagnostics"`
 	} `toml:"metric"`
 
+	// Quota limits the usage of each API token on a node within a period.
+	Quota QuotaConfig `toml:"quota"`
+
+	// Namespaces declares namespaces, binding API tokens to them.
+	Namespaces []NamespaceConfig `toml:"namespaces"`
+
 	Tracing struct {
 		// SamplerType is the type of sampler to use.
 		SamplerType string `toml:"sampler-type"`
@@ -175,13 +341,26 @@ func NewConfig() *Config {
 
 		WorkerPoolSize:       runtime.NumCPU(),
 		ImportWorkerPoolSize: runtime.NumCPU(),
+		ScanConcurrency:      1,
+
+		AttrExpiryInterval: toml.Duration(time.Minute),
+
+		Fsync:         pilosa.FsyncNever,
+		FsyncInterval: toml.Duration(time.Second),
 	}
 
+	// Handler config.
+	c.Handler.StreamThreshold = 1 << 20
+
 	// Cluster config.
 	c.Cluster.Disabled = false
 	c.Cluster.ReplicaN = 1
 	c.Cluster.Hosts = []string{}
 	c.Cluster.LongQueryTime = toml.Duration(time.Minute)
+	c.Cluster.HeartbeatInterval = toml.Duration(time.Second)
+	c.Cluster.SuspectThreshold = 8
+	c.Cluster.DownThreshold = 16
+	c.Cluster.Compression = "zstd"
 
 	// Gossip config.
 	c.Gossip.Port = "14000"
@@ -197,11 +376,18 @@ func NewConfig() *Config {
 	// AntiEntropy config.
 	c.AntiEntropy.Interval = toml.Duration(10 * time.Minute)
 
+	// CacheWarmup config.
+	c.CacheWarmup.Concurrency = 2
+
 	// Metric config.
 	c.Metric.Service = "none"
+	c.Metric.SampleRate = 1.0
 	c.Metric.PollInterval = toml.Duration(0 * time.Minute)
 	c.Metric.Diagnostics = true
 
+	// Quota config.
+	c.Quota.Period = "month"
+
 	// Tracing config.
 	c.Tracing.SamplerType = jaeger.SamplerTypeRemote
 	c.Tracing.SamplerParam = 0.001
@@ -233,6 +419,30 @@ func (cfg *Config) validateAddrs(ctx context.Context) error {
 	}
 	cfg.Bind = schemeHostPortString(listenScheme, listenHost, listenPort)
 
+	// Validate the internal addresses, if there is an internal listener.
+	if cfg.BindInternal != "" {
+		advScheme, advHost, advPort, err := validateAdvertiseAddr(ctx, cfg.AdvertiseInternal, cfg.BindInternal)
+		if err != nil {
+			return errors.Wrapf(err, "validating internal advertise address")
+		}
+		cfg.AdvertiseInternal = schemeHostPortString(advScheme, advHost, advPort)
+
+		listenScheme, listenHost, listenPort, err := validateListenAddr(ctx, cfg.BindInternal)
+		if err != nil {
+			return errors.Wrap(err, "validating internal listen address")
+		}
+		cfg.BindInternal = schemeHostPortString(listenScheme, listenHost, listenPort)
+	}
+
+	// Validate the secondary listen address, if there is one.
+	if cfg.Secondary.Bind != "" {
+		listenScheme, listenHost, listenPort, err := validateListenAddr(ctx, cfg.Secondary.Bind)
+		if err != nil {
+			return errors.Wrap(err, "validating secondary listen address")
+		}
+		cfg.Secondary.Bind = schemeHostPortString(listenScheme, listenHost, listenPort)
+	}
+
 	return nil
 }
 
@@ -273,9 +483,11 @@ func validateAdvertiseAddr(ctx context.Context, advAddr, listenAddr string) (str
 	}
 	advPort = strconv.Itoa(portNumber)
 
-	// If the advertise host is empty, then we have two cases.
+	// If the advertise host is empty, then we have two cases. Listening
+	// on an unspecified address ("0.0.0.0", or "::" for both IPv4 and
+	// IPv6) can't be advertised, so guess the outbound IP instead.
 	if advHost == "" {
-		if listenHost == "0.0.0.0" {
+		if ip := net.ParseIP(listenHost); ip != nil && ip.IsUnspecified() {
 			advHost = outboundIP().String()
 		} else {
 			advHost = listenHost
@@ -291,8 +503,12 @@ func outboundIP() net.IP {
 	// if an actual connection to 8.8.8.8 were made, so this
 	// choice of address is just meant to ensure that an
 	// external address is returned (as opposed to a local
-	// address like 127.0.0.1).
+	// address like 127.0.0.1). Hosts without an IPv4 route
+	// fall back to Google's public IPv6 DNS server.
 	conn, err := net.Dial("udp", "8.8.8.8:80")
+	if err != nil {
+		conn, err = net.Dial("udp", "[2001:4860:4860::8888]:80")
+	}
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -104,6 +104,17 @@ func TestConfig_validateAddrs(t *testing.T) {
 		{"",
 			addrs{"0.0.0.0:1234", ""},
 			addrs{"0.0.0.0:1234", outboundAddr + ":1234"}},
+		// Listen on all interfaces, both IPv4 and IPv6.
+		{"",
+			addrs{"[::]:1234", ""},
+			addrs{"[::]:1234", net.JoinHostPort(outboundAddr, "1234")}},
+		// Listen on an explicit IPv6 address.
+		{"",
+			addrs{"[::1]:1234", ""},
+			addrs{"[::1]:1234", "[::1]:1234"}},
+		{"",
+			addrs{"http://[::1]:", "[::1]:7890"},
+			addrs{"http://[::1]:10101", "http://[::1]:7890"}},
 		// Expected errors.
 
 		// Missing port number.
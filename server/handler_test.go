@@ -19,12 +19,14 @@ import (
 	"context"
 	"encoding/hex"
 	"encoding/json"
+	"expvar"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"math"
 	gohttp "net/http"
 	"net/http/httptest"
+	"net/url"
 	"reflect"
 	"strings"
 	"testing"
@@ -35,7 +37,10 @@ import (
 	"github.com/pilosa/pilosa/v2/encoding/proto"
 	"github.com/pilosa/pilosa/v2/http"
 	"github.com/pilosa/pilosa/v2/server"
+	"github.com/pilosa/pilosa/v2/stats"
 	"github.com/pilosa/pilosa/v2/test"
+	"github.com/pilosa/pilosa/v2/toml"
+	"github.com/pkg/errors"
 )
 
 func TestHandler_PostSchemaCluster(t *testing.T) {
@@ -77,6 +82,200 @@ func TestHandler_PostSchemaCluster(t *testing.T) {
 	})
 }
 
+// Ensure requests are measured by method, route and status code.
+func TestHandler_RouteStats(t *testing.T) {
+	cluster := test.MustNewCluster(t, 1)
+	cluster[0].Config.Metric.Service = "expvar"
+	if err := cluster.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer cluster.Close()
+	h := cluster[0].Handler.(*http.Handler)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, test.MustNewHTTPRequest("POST", "/index/i/query", strings.NewReader("Row(f=1)")))
+	if w.Code != gohttp.StatusBadRequest {
+		t.Fatalf("unexpected status code: %d", w.Code)
+	}
+
+	node, _ := stats.Expvar.Get("NodeID:node0").(*expvar.Map)
+	if node == nil {
+		t.Fatalf("node stats not found: %s", stats.Expvar.String())
+	}
+	m, ok := node.Get("method:POST,route:/index/{index}/query,status:400").(*expvar.Map)
+	if !ok {
+		t.Fatalf("route not measured: %s", stats.Expvar.String())
+	}
+	if v, ok := m.Get("http.request.size_bytes").(*expvar.Float); !ok || v.Value() != float64(len("Row(f=1)")) {
+		t.Fatalf("unexpected request size: %v", m.Get("http.request.size_bytes"))
+	}
+	if v, ok := m.Get("http.response.size_bytes").(*expvar.Float); !ok || v.Value() != float64(w.Body.Len()) {
+		t.Fatalf("unexpected response size: %v", m.Get("http.response.size_bytes"))
+	}
+	if m.Get("http.request.duration_seconds") == nil {
+		t.Fatal("request duration not measured")
+	}
+}
+
+// Ensure GraphQL queries can explore the schema and query rows.
+func TestHandler_GraphQL(t *testing.T) {
+	cluster := test.MustRunCluster(t, 1)
+	defer cluster.Close()
+	cmd := cluster[0]
+	h := cmd.Handler.(*http.Handler).Handler
+
+	cmd.MustCreateIndex(t, "i", pilosa.IndexOptions{TrackExistence: true})
+	cmd.MustCreateField(t, "i", "f", pilosa.OptFieldTypeSet(pilosa.CacheTypeRanked, 100))
+	cmd.MustCreateField(t, "i", "n", pilosa.OptFieldTypeInt(-10, 10))
+	cmd.MustQuery(t, &pilosa.QueryRequest{Index: "i", Query: "Set(1, f=10) Set(2, f=10) Set(3, f=11) Set(1, n=5)"})
+	cmd.MustRecalculateCaches(t)
+
+	for _, tt := range []struct {
+		name   string
+		method string
+		path   string
+		ctype  string
+		body   string
+		code   int
+		exp    string
+	}{
+		{
+			name:   "Schema",
+			method: "POST",
+			path:   "/v1/graphql",
+			ctype:  "application/graphql",
+			body:   `{ indexes { name trackExistence fields { name type min max } } }`,
+			code:   gohttp.StatusOK,
+			exp:    `{"data":{"indexes":[{"name":"i","trackExistence":true,"fields":[{"name":"f","type":"set","min":null,"max":null},{"name":"n","type":"int","min":-10,"max":10}]}]}}`,
+		},
+		{
+			name:   "Queries",
+			method: "POST",
+			path:   "/graphql",
+			ctype:  "application/json",
+			body:   `{"query": "query Q($row: Int!) { index(name: \"i\") { all: count(query: \"Union(Row(f=10), Row(f=11))\") count(query: \"Row(f=10)\") field(name: \"f\") { row(id: $row) { columns count } topN(n: 1) { id count } } } }", "variables": {"row": 10}}`,
+			code:   gohttp.StatusOK,
+			exp:    `{"data":{"index":{"all":3,"count":2,"field":{"row":{"columns":[1,2],"count":2},"topN":[{"id":10,"count":2}]}}}}`,
+		},
+		{
+			name:   "GET",
+			method: "GET",
+			path:   `/graphql?query=` + url.QueryEscape(`{ index(name: "i") { row(query: "Row(n > 0)") { columns } } }`),
+			code:   gohttp.StatusOK,
+			exp:    `{"data":{"index":{"row":{"columns":[1]}}}}`,
+		},
+		{
+			name:   "ReadOnly",
+			method: "POST",
+			path:   "/graphql",
+			ctype:  "application/graphql",
+			body:   `{ index(name: "i") { count(query: "Store(Row(f=10), f=12)") } }`,
+			code:   gohttp.StatusOK,
+			exp:    `{"data":{"index":{"count":null}},"errors":[{"message":"Store can't be used in a GraphQL query","locations":[{"line":1,"column":22}],"path":["index","count"]}]}`,
+		},
+		{
+			name:   "SyntaxError",
+			method: "POST",
+			path:   "/graphql",
+			ctype:  "application/graphql",
+			body:   `{ index(name: "i") `,
+			code:   gohttp.StatusBadRequest,
+			exp:    `{"errors":[{"message":"syntax error: expected name, found end of document","locations":[{"line":1,"column":20}]}]}`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			req := test.MustNewHTTPRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			if tt.ctype != "" {
+				req.Header.Set("Content-Type", tt.ctype)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, req)
+			if w.Code != tt.code {
+				t.Fatalf("unexpected status code: %d, body: %s", w.Code, w.Body.String())
+			} else if body := strings.TrimSpace(w.Body.String()); body != tt.exp {
+				t.Fatalf("unexpected body:\n%s\nexpected:\n%s", body, tt.exp)
+			}
+		})
+	}
+}
+
+// Ensure an API token bound to a namespace may only use its indexes.
+func TestHandler_Namespaces(t *testing.T) {
+	cluster := test.MustRunCluster(t, 1, []server.CommandOption{
+		server.OptCommandServerOptions(pilosa.OptServerNamespaces(pilosa.Namespace{
+			Name:   "acme",
+			Tokens: []string{"acme-token"},
+			Quota:  pilosa.Quota{Queries: 2},
+		})),
+	})
+	defer cluster.Close()
+	h := cluster[0].Handler.(*http.Handler).Handler
+
+	do := func(method, path, body, token string) *httptest.ResponseRecorder {
+		req := test.MustNewHTTPRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		return w
+	}
+
+	for _, index := range []string{"acme.events", "events", "b.x"} {
+		if w := do("POST", "/index/"+index, "", ""); w.Code != gohttp.StatusOK {
+			t.Fatalf("creating %s: %d %s", index, w.Code, w.Body.String())
+		} else if w := do("POST", "/index/"+index+"/field/f", "", ""); w.Code != gohttp.StatusOK {
+			t.Fatalf("creating %s/f: %d %s", index, w.Code, w.Body.String())
+		}
+	}
+	if w := do("POST", "/index/acme.", "", ""); w.Code == gohttp.StatusOK {
+		t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+	}
+
+	if w := do("POST", "/index/acme.events/query", "Set(1, f=1)", "acme-token"); w.Code != gohttp.StatusOK {
+		t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+	} else if w := do("POST", "/index/events/query", "Set(1, f=1)", "acme-token"); w.Code != gohttp.StatusForbidden {
+		t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+	} else if w := do("GET", "/usage", "", "acme-token"); w.Code != gohttp.StatusForbidden {
+		t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+	} else if w := do("POST", "/index/events/query", "Set(1, f=1)", "other-token"); w.Code != gohttp.StatusOK {
+		t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+	}
+
+	// Indexes of other namespaces can't be read through Index() calls,
+	// however deeply nested.
+	for _, q := range []string{
+		`Count(Index(Row(f=1), name="b.x"))`,
+		`Count(Union(Row(f=1), Intersect(Index(Row(f=1), name="b.x"))))`,
+		`Count(Index(Row(f=1), name="events"))`,
+	} {
+		if w := do("POST", "/index/acme.events/query", q, "acme-token"); w.Code != gohttp.StatusForbidden {
+			t.Fatalf("%s: unexpected status code: %d %s", q, w.Code, w.Body.String())
+		}
+	}
+
+	// The schema holds only the namespace's indexes.
+	w := do("GET", "/schema", "", "acme-token")
+	if w.Code != gohttp.StatusOK {
+		t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+	}
+	var schema struct {
+		Indexes []pilosa.IndexInfo `json:"indexes"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &schema); err != nil {
+		t.Fatal(err)
+	} else if len(schema.Indexes) != 1 || schema.Indexes[0].Name != "acme.events" {
+		t.Fatalf("unexpected schema: %+v", schema.Indexes)
+	}
+
+	// The namespace's quota is shared by its tokens.
+	if w := do("POST", "/index/acme.events/query", "Count(Row(f=1))", "acme-token"); w.Code != gohttp.StatusOK {
+		t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+	} else if w := do("POST", "/index/acme.events/query", "Count(Row(f=1))", "acme-token"); w.Code != gohttp.StatusTooManyRequests {
+		t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+	}
+}
+
 func TestHandler_Endpoints(t *testing.T) {
 	cluster := test.MustRunCluster(t, 1)
 	defer cluster.Close()
@@ -202,6 +401,30 @@ func TestHandler_Endpoints(t *testing.T) {
 		}
 	})
 
+	t.Run("Schema page", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("GET", "/schema?limit=1&fields=false", nil))
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d", w.Code)
+		}
+		target := fmt.Sprintf(`{"indexes":[{"name":"i0","options":{"keys":false,"trackExistence":false},"fields":null,"shardWidth":%d}],"next":"i0"}
+`, pilosa.ShardWidth)
+		if body := w.Body.String(); body != target {
+			t.Fatalf("%s != %s", target, body)
+		}
+
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("GET", "/index/i0?after=f0", nil))
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d", w.Code)
+		}
+		target = fmt.Sprintf(`{"name":"i0","options":{"keys":false,"trackExistence":false},"fields":[{"name":"f1","options":{"type":"set","cacheType":"ranked","cacheSize":50000,"keys":false}}],"shardWidth":%d}
+`, pilosa.ShardWidth)
+		if body := w.Body.String(); body != target {
+			t.Fatalf("%s != %s", target, body)
+		}
+	})
+
 	t.Run("ImportRoaring", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		roaringData, _ := hex.DecodeString("3B3001000100000900010000000100010009000100")
@@ -271,6 +494,38 @@ func TestHandler_Endpoints(t *testing.T) {
 		if len(ret["nodes"].([]interface{})) != 1 {
 			t.Fatalf("wrong length nodes list: %#v", ret)
 		}
+		if startup, ok := ret["startup"].(map[string]interface{}); !ok || startup["complete"] != true {
+			t.Fatalf("unexpected startup progress: %#v", ret)
+		}
+	})
+
+	t.Run("Readyz", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("GET", "/readyz", nil))
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d", w.Code)
+		} else if body := w.Body.String(); body != "ready\n" {
+			t.Fatalf("unexpected body: %q", body)
+		}
+	})
+
+	t.Run("Cluster status", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("GET", "/cluster/status", nil))
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d", w.Code)
+		}
+		ret := mustJSONDecode(t, w.Body)
+		nodes := ret["nodes"].([]interface{})
+		if len(nodes) != 1 {
+			t.Fatalf("wrong length nodes list: %#v", ret)
+		}
+		node := nodes[0].(map[string]interface{})
+		if node["id"] != cmd.API.Node().ID {
+			t.Fatalf("unexpected node: %#v", node)
+		} else if load, ok := node["load"].(map[string]interface{}); !ok || load["memoryUsed"].(float64) == 0 {
+			t.Fatalf("unexpected load: %#v", node)
+		}
 	})
 
 	t.Run("Abort no resize job", func(t *testing.T) {
@@ -350,6 +605,16 @@ func TestHandler_Endpoints(t *testing.T) {
 		}
 	})
 
+	t.Run("Query args error v1", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("POST", "/v1/index/i0/query?shards=a,b", strings.NewReader("Count(Row(f0=30))")))
+		if w.Code != gohttp.StatusBadRequest {
+			t.Fatalf("unexpected status code: %d", w.Code)
+		} else if body := w.Body.String(); body != `{"error":{"code":"BadRequest","message":"invalid shard argument","index":"i0"}}`+"\n" {
+			t.Fatalf("unexpected body: %q", body)
+		}
+	})
+
 	t.Run("Query params err", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		h.ServeHTTP(w, test.MustNewHTTPRequest("POST", "/index/i0/query?shards=0,1&db=sample", strings.NewReader("Count(Row(f0=30))")))
@@ -379,6 +644,29 @@ func TestHandler_Endpoints(t *testing.T) {
 		}
 	})
 
+	t.Run("ContinueOnError", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("POST", "/index/i0/query?continueOnError=true", strings.NewReader("Count(Row(f0=30)) Row(nosuchfield=1)")))
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d", w.Code)
+		} else if body := w.Body.String(); body != `{"results":[3,{"error":{"code":"FieldNotFound","message":"map reduce: nosuchfield: field not found","field":"nosuchfield"}}]}`+"\n" {
+			t.Fatalf("unexpected body: %s", body)
+		}
+
+		w = httptest.NewRecorder()
+		r := test.MustNewHTTPRequest("POST", "/index/i0/query?continueOnError=true", strings.NewReader("Count(Row(f0=30)) Row(nosuchfield=1)"))
+		r.Header.Set("Accept", "application/x-protobuf")
+		h.ServeHTTP(w, r)
+		var resp pilosa.QueryResponse
+		if err := cmd.API.Serializer.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		} else if resp.Results[0] != uint64(3) {
+			t.Fatalf("unexpected result: %#v", resp.Results[0])
+		} else if e, ok := resp.Results[1].(pilosa.CallError); !ok || errors.Cause(e.Err) != pilosa.ErrFieldNotFound {
+			t.Fatalf("unexpected result: %#v", resp.Results[1])
+		}
+	})
+
 	t.Run("Row JSON", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		h.ServeHTTP(w, test.MustNewHTTPRequest("POST", "/index/i0/query", strings.NewReader("Row(f0=30)")))
@@ -536,6 +824,118 @@ func TestHandler_Endpoints(t *testing.T) {
 		}
 	})
 
+	t.Run("Query async", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("POST", "/index/i0/query?shards=0,1&async=true", strings.NewReader("Count(Row(f0=30))")))
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		}
+		var job pilosa.JobStatus
+		if err := json.Unmarshal(w.Body.Bytes(), &job); err != nil {
+			t.Fatal(err)
+		} else if job.Type != pilosa.JobTypeQuery {
+			t.Fatalf("unexpected job: %+v", job)
+		}
+
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("GET", fmt.Sprintf("/jobs/%d/result?wait=true", job.ID), nil))
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		} else if body := w.Body.String(); body != `{"results":[2]}`+"\n" {
+			t.Fatalf("unexpected body: %q", body)
+		}
+
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("POST", "/index/i0/query?async=true", strings.NewReader("Count(")))
+		if w.Code != gohttp.StatusBadRequest {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("Usage", func(t *testing.T) {
+		req := test.MustNewHTTPRequest("POST", "/index/i0/query", strings.NewReader("Count(Row(f0=30))"))
+		req.Header.Set("Authorization", "Bearer team-a")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		}
+
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("GET", "/usage", nil))
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		}
+		var resp struct {
+			Usage []pilosa.Usage `json:"usage"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		}
+
+		// Requests without a token are metered together, separately from
+		// those of each token.
+		usage := make(map[string]pilosa.Usage)
+		for _, u := range resp.Usage {
+			usage[u.Token] = u
+		}
+		if u := usage["team-a"]; u.Queries != 1 || u.Containers == 0 {
+			t.Fatalf("unexpected usage: %+v", resp.Usage)
+		} else if u.CPUTime == 0 || u.NetworkBytes == 0 {
+			t.Fatalf("unexpected usage: %+v", resp.Usage)
+		} else if _, ok := usage["anonymous"]; !ok {
+			t.Fatalf("expected usage without a token: %+v", resp.Usage)
+		}
+	})
+
+	t.Run("UsageReport", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("GET", "/usage/report?token=team-a&from="+time.Now().UTC().Format("2006-01-02"), nil))
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		}
+		var resp struct {
+			Days []pilosa.UsageDay `json:"days"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		} else if len(resp.Days) != 1 || resp.Days[0].Token != "team-a" || resp.Days[0].Queries != 1 || resp.Days[0].CPUTime == 0 {
+			t.Fatalf("unexpected days: %+v", resp.Days)
+		}
+
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("GET", "/usage/report?from=yesterday", nil))
+		if w.Code != gohttp.StatusBadRequest {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("Settings", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("POST", "/settings", strings.NewReader(`{"longQueryTime":"2m0s"}`)))
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		}
+
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("GET", "/settings", nil))
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		}
+		var settings pilosa.Settings
+		if err := json.Unmarshal(w.Body.Bytes(), &settings); err != nil {
+			t.Fatal(err)
+		} else if settings.LongQueryTime != toml.Duration(2*time.Minute) || settings.Version == 0 {
+			t.Fatalf("unexpected settings: %+v", settings)
+		}
+
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("POST", "/settings", strings.NewReader(`{"bogus":1}`)))
+		if w.Code != gohttp.StatusBadRequest {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		}
+	})
+
 	t.Run("Query empty", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		h.ServeHTTP(w, test.MustNewHTTPRequest("POST", "/index/i0/query", strings.NewReader("")))
@@ -691,6 +1091,29 @@ func TestHandler_Endpoints(t *testing.T) {
 		}
 	})
 
+	t.Run("Rows delete", func(t *testing.T) {
+		hldr.SetBit("i", "f2", 1, 10)
+		hldr.SetBit("i", "f2", 2, 20)
+		hldr.SetBit("i", "f2", 3, 30)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("DELETE", "/index/i/field/f2/row/1?to=2", strings.NewReader("")))
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d, body: %s", w.Code, w.Body.String())
+		} else if body := w.Body.String(); body != `{"success":true}`+"\n" {
+			t.Fatalf("unexpected body: %s", body)
+		} else if rows := hldr.Row("i", "f2", 1).Columns(); len(rows) != 0 {
+			t.Fatalf("unexpected columns: %v", rows)
+		} else if rows := hldr.Row("i", "f2", 3).Columns(); len(rows) != 1 {
+			t.Fatalf("unexpected columns: %v", rows)
+		}
+
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("DELETE", "/index/i/field/f2/row/2?to=1", strings.NewReader("")))
+		if w.Code != gohttp.StatusBadRequest {
+			t.Fatalf("unexpected status code: %d, body: %s", w.Code, w.Body.String())
+		}
+	})
+
 	i := hldr.MustCreateIndexIfNotExists("i", pilosa.IndexOptions{})
 	if err := i.ColumnAttrStore().SetAttrs(1, map[string]interface{}{"foo": 1, "bar": 2}); err != nil {
 		t.Fatal(err)
@@ -776,11 +1199,91 @@ func TestHandler_Endpoints(t *testing.T) {
 		version := strings.TrimPrefix(pilosa.Version, "v")
 		if w.Code != gohttp.StatusOK {
 			t.Fatalf("unexpected status code: %d", w.Code)
-		} else if w.Body.String() != `{"version":"`+version+`"}`+"\n" {
+		} else if w.Body.String() != `{"version":"`+version+`","apiVersions":["v1"]}`+"\n" {
 			t.Fatalf("unexpected body: %q", w.Body.String())
 		}
 	})
 
+	t.Run("API version", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("GET", "/v1/schema", nil))
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d", w.Code)
+		} else if !strings.HasPrefix(w.Body.String(), `{"indexes":`) {
+			t.Fatalf("unexpected body: %q", w.Body.String())
+		}
+
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("POST", "/v1/index/nosuchindex/query", strings.NewReader("Count(All())")))
+		if w.Code != gohttp.StatusBadRequest {
+			t.Fatalf("unexpected status code: %d", w.Code)
+		} else if !strings.Contains(w.Body.String(), `"code":"IndexNotFound"`) {
+			t.Fatalf("unexpected body: %q", w.Body.String())
+		}
+
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("PUT", "/v1/schema", nil))
+		if w.Code != gohttp.StatusMethodNotAllowed {
+			t.Fatalf("unexpected status code: %d", w.Code)
+		}
+
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("GET", "/v1/no_such_path", nil))
+		if w.Code != gohttp.StatusNotFound {
+			t.Fatalf("unexpected status code: %d", w.Code)
+		} else if strings.Contains(w.Body.String(), "APIVersionUnsupported") {
+			t.Fatalf("unexpected body: %q", w.Body.String())
+		}
+
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("GET", "/v99/schema", nil))
+		if w.Code != gohttp.StatusNotFound {
+			t.Fatalf("unexpected status code: %d", w.Code)
+		} else if body := w.Body.String(); body != `{"error":{"code":"APIVersionUnsupported","message":"v99: unsupported API version"}}`+"\n" {
+			t.Fatalf("unexpected body: %q", body)
+		}
+	})
+
+	t.Run("OpenAPI", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("GET", "/openapi.json", nil))
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d", w.Code)
+		}
+		var doc struct {
+			Paths map[string]map[string]struct {
+				OperationID string                 `json:"operationId"`
+				Summary     string                 `json:"summary"`
+				RequestBody map[string]interface{} `json:"requestBody"`
+			} `json:"paths"`
+			Components struct {
+				Schemas map[string]interface{} `json:"schemas"`
+			} `json:"components"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+			t.Fatal(err)
+		}
+
+		for path, ops := range doc.Paths {
+			if !strings.HasPrefix(path, "/v1/") {
+				t.Fatalf("unexpected path: %s", path)
+			}
+			for method, op := range ops {
+				if op.Summary == op.OperationID {
+					t.Fatalf("operation not documented: %s %s (%s)", method, path, op.OperationID)
+				}
+			}
+		}
+		if op := doc.Paths["/v1/index/{index}/query"]["post"]; op.OperationID != "PostQuery" || op.RequestBody == nil {
+			t.Fatalf("unexpected query operation: %#v", op)
+		}
+		for _, name := range []string{"Error", "ErrorResponse", "GetSchemaResponse", "JobStatus"} {
+			if _, ok := doc.Components.Schemas[name]; !ok {
+				t.Fatalf("expected schema %s", name)
+			}
+		}
+	})
+
 	t.Run("Fragment Nodes", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		r := test.MustNewHTTPRequest("GET", "/internal/fragment/nodes?index=i&shard=0", nil)
@@ -858,6 +1361,32 @@ func TestHandler_Endpoints(t *testing.T) {
 		}
 	})
 
+	t.Run("Request ID", func(t *testing.T) {
+		h := cmd.Handler.(*http.Handler)
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("GET", "/version", nil))
+		if id := w.Header().Get(pilosa.RequestIDHeader); len(id) != 32 {
+			t.Fatalf("expected generated request ID, got %q", id)
+		}
+
+		req := test.MustNewHTTPRequest("GET", "/version", nil)
+		req.Header.Set(pilosa.RequestIDHeader, "ticket-1234")
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		if id := w.Header().Get(pilosa.RequestIDHeader); id != "ticket-1234" {
+			t.Fatalf("expected request ID to be kept, got %q", id)
+		}
+
+		req = test.MustNewHTTPRequest("GET", "/version", nil)
+		req.Header.Set(pilosa.RequestIDHeader, "not valid")
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		if id := w.Header().Get(pilosa.RequestIDHeader); id == "not valid" || id == "" {
+			t.Fatalf("expected invalid request ID to be replaced, got %q", id)
+		}
+	})
+
 	t.Run("index handlers", func(t *testing.T) {
 		// create index
 		w := httptest.NewRecorder()
@@ -879,6 +1408,16 @@ func TestHandler_Endpoints(t *testing.T) {
 			t.Errorf("unexpected body: %q", w.Body.String())
 		}
 
+		// create index again under /v1
+		w = httptest.NewRecorder()
+		r = test.MustNewHTTPRequest("POST", "/v1/index/idx1", strings.NewReader(""))
+		h.ServeHTTP(w, r)
+		if w.Code != gohttp.StatusConflict {
+			t.Errorf("unexpected status code: %d", w.Code)
+		} else if w.Body.String() != `{"success":false,"error":{"code":"IndexExists","message":"creating index: index already exists","index":"idx1"}}`+"\n" {
+			t.Errorf("unexpected body: %q", w.Body.String())
+		}
+
 		// create field
 		w = httptest.NewRecorder()
 		r = test.MustNewHTTPRequest("POST", "/index/idx1/field/fld1", strings.NewReader(""))
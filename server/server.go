@@ -31,6 +31,7 @@ import (
 	"os/signal"
 	"runtime"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -48,6 +49,7 @@ import (
 	"github.com/pilosa/pilosa/v2/stats"
 	"github.com/pilosa/pilosa/v2/statsd"
 	"github.com/pilosa/pilosa/v2/syswrap"
+	"github.com/pilosa/pilosa/v2/wasm"
 	"github.com/pkg/errors"
 )
 
@@ -83,6 +85,8 @@ type Command struct {
 	API          *pilosa.API
 	ln           net.Listener
 	listenURI    *pilosa.URI
+	internalLn   net.Listener
+	secondaryLn  net.Listener
 	closeTimeout time.Duration
 
 	serverOptions []pilosa.ServerOption
@@ -253,7 +257,7 @@ func (m *Command) SetupServer() error {
 
 	// Setup TLS
 	var TLSConfig *tls.Config
-	if uri.Scheme == "https" {
+	if uri.Scheme == "https" || strings.HasPrefix(m.Config.BindInternal, "https://") || strings.HasPrefix(m.Config.Secondary.Bind, "https://") {
 		TLSConfig, err = GetTLSConfig(&m.Config.TLS, m.logger.Logger())
 		if err != nil {
 			return errors.Wrap(err, "get tls config")
@@ -265,7 +269,7 @@ func (m *Command) SetupServer() error {
 		diagnosticsInterval = defaultDiagnosticsInterval
 	}
 
-	statsClient, err := newStatsClient(m.Config.Metric.Service, m.Config.Metric.Host)
+	statsClient, err := newStatsClient(m.Config.Metric.Service, m.Config.Metric.Host, m.Config.Metric.SampleRate)
 	if err != nil {
 		return errors.Wrap(err, "new stats client")
 	}
@@ -284,6 +288,9 @@ func (m *Command) SetupServer() error {
 	m.listenURI = uri
 
 	c := http.GetHTTPClient(TLSConfig)
+	if err := http.ValidateCompression(m.Config.Cluster.Compression); err != nil {
+		return errors.Wrap(err, "validating cluster compression")
+	}
 
 	// Get advertise address as uri.
 	advertiseURI, err := pilosa.AddressWithDefaults(m.Config.Advertise)
@@ -294,6 +301,43 @@ func (m *Command) SetupServer() error {
 		advertiseURI.SetPort(uri.Port)
 	}
 
+	// Listen for requests from other nodes separately, if configured.
+	internalURIOpt := pilosa.OptServerInternalURI(nil)
+	if m.Config.BindInternal != "" {
+		internalURI, err := pilosa.AddressWithDefaults(m.Config.BindInternal)
+		if err != nil {
+			return errors.Wrap(err, "processing internal bind address")
+		}
+		m.internalLn, err = getListener(*internalURI, TLSConfig)
+		if err != nil {
+			return errors.Wrap(err, "getting internal listener")
+		}
+		if internalURI.Port == 0 {
+			internalURI.SetPort(uint16(m.internalLn.Addr().(*net.TCPAddr).Port))
+		}
+
+		advertiseInternalURI, err := pilosa.AddressWithDefaults(m.Config.AdvertiseInternal)
+		if err != nil {
+			return errors.Wrap(err, "processing internal advertise address")
+		}
+		if advertiseInternalURI.Port == 0 {
+			advertiseInternalURI.SetPort(internalURI.Port)
+		}
+		internalURIOpt = pilosa.OptServerInternalURI(advertiseInternalURI)
+	}
+
+	// Serve the API on a secondary listener as well, if configured.
+	if m.Config.Secondary.Bind != "" {
+		secondaryURI, err := pilosa.AddressWithDefaults(m.Config.Secondary.Bind)
+		if err != nil {
+			return errors.Wrap(err, "processing secondary bind address")
+		}
+		m.secondaryLn, err = getListener(*secondaryURI, TLSConfig)
+		if err != nil {
+			return errors.Wrap(err, "getting secondary listener")
+		}
+	}
+
 	// Primary store configuration is handled automatically now.
 	if m.Config.Translation.PrimaryURL != "" {
 		m.logger.Printf("DEPRECATED: The primary-url configuration option is no longer used.")
@@ -310,19 +354,43 @@ func (m *Command) SetupServer() error {
 		pilosa.OptServerLongQueryTime(time.Duration(m.Config.Cluster.LongQueryTime)),
 		pilosa.OptServerDataDir(m.Config.DataDir),
 		pilosa.OptServerReplicaN(m.Config.Cluster.ReplicaN),
+		pilosa.OptServerZone(m.Config.Cluster.Zone),
+		pilosa.OptServerRebalanceInterval(time.Duration(m.Config.Cluster.RebalanceInterval)),
+		pilosa.OptServerHeartbeat(time.Duration(m.Config.Cluster.HeartbeatInterval), m.Config.Cluster.SuspectThreshold, m.Config.Cluster.DownThreshold),
 		pilosa.OptServerMaxWritesPerRequest(m.Config.MaxWritesPerRequest),
+		pilosa.OptServerChangeLogSize(m.Config.ChangeLogSize),
+		pilosa.OptServerSchemaWebhooks(m.Config.SchemaWebhooks),
 		pilosa.OptServerMetricInterval(time.Duration(m.Config.Metric.PollInterval)),
 		pilosa.OptServerDiagnosticsInterval(diagnosticsInterval),
 		pilosa.OptServerExecutorPoolSize(m.Config.WorkerPoolSize),
+		pilosa.OptServerMaxQueryFanOut(m.Config.MaxQueryFanOut),
+		pilosa.OptServerMaxRemoteFanOut(m.Config.MaxRemoteFanOut),
+		pilosa.OptServerScanConcurrency(m.Config.ScanConcurrency),
+		pilosa.OptServerTopNCacheTTL(time.Duration(m.Config.TopNCacheTTL)),
+		pilosa.OptServerMaxQueryMemory(m.Config.MaxQueryMemory),
+		pilosa.OptServerMaxConcurrentQueries(m.Config.MaxConcurrentQueries),
+		pilosa.OptServerMemoryLimit(m.Config.MemoryLimit),
+		pilosa.OptServerHeapHighWater(m.Config.HeapHighWater),
+		pilosa.OptServerLazyFragments(m.Config.LazyFragments),
+		pilosa.OptServerMaxOpenFragmentFiles(m.Config.MaxOpenFragmentFiles),
+		pilosa.OptServerGroupCommitWindow(time.Duration(m.Config.GroupCommitWindow)),
+		pilosa.OptServerFsync(m.Config.Fsync, time.Duration(m.Config.FsyncInterval)),
+		pilosa.OptServerCacheWarmup(time.Duration(m.Config.CacheWarmup.Timeout), m.Config.CacheWarmup.Concurrency),
+		pilosa.OptServerMinDiskFree(m.Config.MinDiskFree),
+		pilosa.OptServerAttrExpiryInterval(time.Duration(m.Config.AttrExpiryInterval)),
+		pilosa.OptServerQuota(newQuota(m.Config.Quota)),
+		pilosa.OptServerNamespaces(newNamespaces(m.Config.Namespaces)...),
 		pilosa.OptServerOpenTranslateStore(boltdb.OpenTranslateStore),
 		pilosa.OptServerOpenTranslateReader(http.GetOpenTranslateReaderFunc(c)),
 		pilosa.OptServerLogger(m.logger),
 		pilosa.OptServerAttrStoreFunc(boltdb.NewAttrStore),
 		pilosa.OptServerSystemInfo(gopsutil.NewSystemInfo()),
+		pilosa.OptServerUDFRuntime(wasm.NewRuntime(wasm.DefaultMemoryLimitPages)),
 		pilosa.OptServerGCNotifier(gcnotify.NewActiveGCNotifier()),
 		pilosa.OptServerStatsClient(statsClient),
 		pilosa.OptServerURI(advertiseURI),
-		pilosa.OptServerInternalClient(http.NewInternalClientFromURI(uri, c)),
+		internalURIOpt,
+		pilosa.OptServerInternalClient(http.NewInternalClientFromURI(uri, c, http.OptInternalClientCompression(m.Config.Cluster.Compression))),
 		pilosa.OptServerClusterDisabled(m.Config.Cluster.Disabled, m.Config.Cluster.Hosts),
 		pilosa.OptServerSerializer(proto.Serializer{}),
 		coordinatorOpt,
@@ -349,7 +417,10 @@ func (m *Command) SetupServer() error {
 		http.OptHandlerAPI(m.API),
 		http.OptHandlerLogger(m.logger),
 		http.OptHandlerListener(m.ln),
+		http.OptHandlerInternalListener(m.internalLn),
+		http.OptHandlerSecondaryListener(m.secondaryLn, m.Config.Secondary.AllowedOrigins),
 		http.OptHandlerCloseTimeout(m.closeTimeout),
+		http.OptHandlerStreamThreshold(m.Config.Handler.StreamThreshold),
 	)
 	return errors.Wrap(err, "new handler")
 }
@@ -366,7 +437,7 @@ func (m *Command) setupNetworking() error {
 	}
 
 	// get the host portion of addr to use for binding
-	gossipHost := m.listenURI.Host
+	gossipHost := m.listenURI.Hostname()
 	m.gossipTransport, err = gossip.NewTransport(gossipHost, gossipPort, m.logger.Logger())
 	if err != nil {
 		return errors.Wrap(err, "getting transport")
@@ -415,13 +486,32 @@ func (m *Command) Close() error {
 	return errors.Wrap(err, "closing everything")
 }
 
+// newQuota returns the quota of a quota config.
+func newQuota(c QuotaConfig) pilosa.Quota {
+	return pilosa.Quota{
+		Period:      c.Period,
+		Queries:     c.Queries,
+		Containers:  c.Containers,
+		ImportBytes: c.ImportBytes,
+	}
+}
+
+// newNamespaces returns the namespaces declared by namespace configs.
+func newNamespaces(a []NamespaceConfig) []pilosa.Namespace {
+	namespaces := make([]pilosa.Namespace, len(a))
+	for i, c := range a {
+		namespaces[i] = pilosa.Namespace{Name: c.Name, Tokens: c.Tokens, Quota: newQuota(c.Quota)}
+	}
+	return namespaces
+}
+
 // newStatsClient creates a stats client from the config
-func newStatsClient(name string, host string) (stats.StatsClient, error) {
+func newStatsClient(name string, host string, sampleRate float64) (stats.StatsClient, error) {
 	switch name {
 	case "expvar":
 		return stats.NewExpvarStatsClient(), nil
 	case "statsd":
-		return statsd.NewStatsClient(host)
+		return statsd.NewStatsClient(host, statsd.OptClientSampleRate(sampleRate))
 	case "prometheus":
 		return prometheus.NewPrometheusClient()
 	case "nop", "none":
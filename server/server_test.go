@@ -17,11 +17,15 @@ package server_test
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"flag"
 	"fmt"
 	"io/ioutil"
 	"math/rand"
+	"net"
+	gohttp "net/http"
+	"path/filepath"
 	"reflect"
 	"sort"
 	"strconv"
@@ -887,6 +891,66 @@ func TestClusterQueriesAfterRestart(t *testing.T) {
 	}
 }
 
+// Ensure a fragment found to be corrupt when a node opens is repaired from
+// another replica.
+func TestClusterRepairCorruptFragment(t *testing.T) {
+	cluster := test.MustNewCluster(t, 2)
+	for _, c := range cluster {
+		c.Config.Cluster.ReplicaN = 2
+	}
+	if err := cluster.Start(); err != nil {
+		t.Fatalf("starting cluster: %v", err)
+	}
+	defer cluster.Close()
+	cmd1 := cluster[1]
+
+	cluster[0].MustCreateIndex(t, "i", pilosa.IndexOptions{})
+	cluster[0].MustCreateField(t, "i", "f")
+	cluster[0].MustQuery(t, &pilosa.QueryRequest{Index: "i", Query: "Set(10, f=1) Set(20, f=1)"})
+
+	if err := cmd1.Command.Close(); err != nil {
+		t.Fatalf("closing node 1: %v", err)
+	}
+
+	// Corrupt the fragment's data, after its header, on node 1.
+	path := filepath.Join(cmd1.Config.DataDir, "i", "f", "views", "standard", "fragments", "0")
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data[36] ^= 0xff
+	if err := ioutil.WriteFile(path, data, 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	config := cmd1.Command.Config
+	config.Bind = cmd1.API.Node().URI.HostPort()
+	config.Gossip.Port = strconv.Itoa(int(cmd1.Command.GossipTransport().URI.Port))
+	cmd1.Command = server.NewCommand(cmd1.Stdin, cmd1.Stdout, cmd1.Stderr)
+	cmd1.Command.Config = config
+	if err := cmd1.Start(); err != nil {
+		t.Fatalf("reopening node 1: %v", err)
+	}
+
+	auditLog := filepath.Join(cmd1.Config.DataDir, ".quarantine", "audit.log")
+	for i := 0; ; i++ {
+		buf, err := ioutil.ReadFile(auditLog)
+		if err == nil && strings.Contains(string(buf), `"event":"repaired"`) {
+			break
+		} else if i == 500 {
+			t.Fatalf("fragment not repaired: %s", buf)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	row, err := cmd1.Server.Holder().Field("i", "f").Row(1)
+	if err != nil {
+		t.Fatal(err)
+	} else if cols := row.Columns(); !reflect.DeepEqual(cols, []uint64{10, 20}) {
+		t.Fatalf("unexpected columns after repair: %v", cols)
+	}
+}
+
 // TODO: confirm that things keep working if a node is hard-closed (no nodeLeave event) and immediately restarted with a different address.
 
 func TestClusterExhaustingConnections(t *testing.T) {
@@ -985,3 +1049,166 @@ func TestClusterExhaustingConnectionsImport(t *testing.T) {
 		t.Fatalf("setting lots of shards: %v", err)
 	}
 }
+
+// Ensure nodes with an internal listener advertise it, and send each other
+// requests on it.
+func TestClusterInternalListener(t *testing.T) {
+	cluster := test.MustNewCluster(t, 3)
+	for _, c := range cluster {
+		c.Config.BindInternal = "http://localhost:0"
+	}
+	if err := cluster.Start(); err != nil {
+		t.Fatalf("starting cluster: %v", err)
+	}
+	defer cluster.Close()
+
+	for i, c := range cluster {
+		for _, node := range c.API.Hosts(context.Background()) {
+			if node.InternalURI == nil {
+				t.Fatalf("node %d: expected internal URI for node %s", i, node.ID)
+			} else if node.InternalURI.Port == node.URI.Port {
+				t.Fatalf("node %d: expected internal port to differ from %d", i, node.URI.Port)
+			}
+		}
+	}
+
+	cluster[0].MustCreateIndex(t, "i", pilosa.IndexOptions{})
+	cluster[0].MustCreateField(t, "i", "f")
+	var rowcols [][2]uint64
+	for shard := uint64(0); shard < 6; shard++ {
+		rowcols = append(rowcols, [2]uint64{1, shard * pilosa.ShardWidth})
+	}
+	cluster.ImportBits(t, "i", "f", rowcols)
+
+	// Every node must reach the others to count the bits in every shard.
+	for i, c := range cluster {
+		resp := c.MustQuery(t, &pilosa.QueryRequest{Index: "i", Query: "Count(Row(f=1))"})
+		if n := resp.Results[0].(uint64); n != 6 {
+			t.Fatalf("node %d: unexpected count: %d", i, n)
+		}
+	}
+
+	// The internal endpoints are only served on the internal listener.
+	node := cluster[0].API.Node()
+	for _, tt := range []struct {
+		uri  pilosa.URI
+		code int
+	}{
+		{uri: node.URI, code: gohttp.StatusNotFound},
+		{uri: *node.InternalURI, code: gohttp.StatusOK},
+	} {
+		resp, err := gohttp.Get(tt.uri.String() + "/internal/nodes")
+		if err != nil {
+			t.Fatalf("getting nodes from %s: %v", tt.uri, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tt.code {
+			t.Fatalf("getting nodes from %s: expected status %d, got %d", tt.uri, tt.code, resp.StatusCode)
+		}
+	}
+}
+
+// Ensure a cluster can run with its nodes bound to an IPv6 address.
+func TestClusterIPv6(t *testing.T) {
+	ln, err := net.Listen("tcp", "[::1]:0")
+	if err != nil {
+		t.Skipf("IPv6 is not available: %v", err)
+	}
+	ln.Close()
+
+	cluster := test.MustNewCluster(t, 3)
+	for _, c := range cluster {
+		c.Config.Bind = "http://[::1]:0"
+	}
+	if err := cluster.Start(); err != nil {
+		t.Fatalf("starting cluster: %v", err)
+	}
+	defer cluster.Close()
+
+	for i, c := range cluster {
+		if hosts := c.API.Hosts(context.Background()); len(hosts) != 3 {
+			t.Fatalf("node %d: expected 3 hosts, got %d", i, len(hosts))
+		} else if host := hosts[0].URI.Host; host != "[::1]" {
+			t.Fatalf("node %d: unexpected host: %s", i, host)
+		}
+	}
+
+	cluster[0].MustCreateIndex(t, "i", pilosa.IndexOptions{})
+	cluster[0].MustCreateField(t, "i", "f")
+	var rowcols [][2]uint64
+	for shard := uint64(0); shard < 6; shard++ {
+		rowcols = append(rowcols, [2]uint64{1, shard * pilosa.ShardWidth})
+	}
+	cluster.ImportBits(t, "i", "f", rowcols)
+
+	for i, c := range cluster {
+		resp := c.MustQuery(t, &pilosa.QueryRequest{Index: "i", Query: "Count(Row(f=1))"})
+		if n := resp.Results[0].(uint64); n != 6 {
+			t.Fatalf("node %d: unexpected count: %d", i, n)
+		}
+	}
+}
+
+// Ensure a server can serve the API on a TLS listener and a plaintext
+// secondary listener at once, each with its own CORS allowed origins.
+func TestServerSecondaryListener(t *testing.T) {
+	ln, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	secondaryAddr := ln.Addr().String()
+	ln.Close()
+
+	cluster := test.MustNewCluster(t, 1)
+	c := cluster[0]
+	c.Config.Bind = "https://localhost:0"
+	c.Config.TLS.CertificatePath = "./testdata/certs/localhost.crt"
+	c.Config.TLS.CertificateKeyPath = "./testdata/certs/localhost.key"
+	c.Config.TLS.SkipVerify = true
+	c.Config.Handler.AllowedOrigins = []string{"http://external/"}
+	c.Config.Secondary.Bind = "http://" + secondaryAddr
+	c.Config.Secondary.AllowedOrigins = []string{"http://sidecar/"}
+	if err := cluster.Start(); err != nil {
+		t.Fatalf("starting cluster: %v", err)
+	}
+	defer cluster.Close()
+
+	client := http.GetHTTPClient(&tls.Config{InsecureSkipVerify: true})
+	for _, tt := range []struct {
+		url    string
+		origin string
+		status int
+	}{
+		{c.URL(), "http://external/", gohttp.StatusOK},
+		{c.URL(), "http://sidecar/", gohttp.StatusMethodNotAllowed},
+		{"http://" + secondaryAddr, "http://sidecar/", gohttp.StatusOK},
+		{"http://" + secondaryAddr, "http://external/", gohttp.StatusMethodNotAllowed},
+	} {
+		req, err := gohttp.NewRequest("OPTIONS", tt.url+"/index/i/query", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Origin", tt.origin)
+		req.Header.Set("Access-Control-Request-Method", "POST")
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tt.status {
+			t.Fatalf("%s from %s: expected status %d, got %d", tt.url, tt.origin, tt.status, resp.StatusCode)
+		}
+	}
+
+	if !strings.HasPrefix(c.URL(), "https://") {
+		t.Fatalf("expected a TLS primary listener: %s", c.URL())
+	}
+	resp, err := client.Get("http://" + secondaryAddr + "/version")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != gohttp.StatusOK {
+		t.Fatalf("unexpected status from secondary listener: %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pilosa/pilosa/v2/toml"
+	"github.com/pkg/errors"
+)
+
+// settingsFile is the file in the data directory which records the cluster
+// settings, so that settings changed at runtime are kept across restarts.
+const settingsFile = ".settings"
+
+// Settings are runtime settings which apply to every node in the cluster.
+// They start out as each node's configuration, and are changed for the whole
+// cluster with UpdateSettings. Version orders changes, so that each node
+// keeps the most recent settings regardless of the order it receives them in.
+type Settings struct {
+	Version             int64         `json:"version"`
+	AntiEntropyInterval toml.Duration `json:"antiEntropyInterval"`
+	LongQueryTime       toml.Duration `json:"longQueryTime"`
+	MaxWritesPerRequest int           `json:"maxWritesPerRequest"`
+	MaxQueryMemory      int64         `json:"maxQueryMemory"`
+
+	// Cache options given to set fields created without them.
+	DefaultCacheType string `json:"defaultCacheType"`
+	DefaultCacheSize uint32 `json:"defaultCacheSize"`
+}
+
+// SettingsUpdate is a change to the cluster settings. Only the non-nil
+// fields are changed.
+type SettingsUpdate struct {
+	AntiEntropyInterval *toml.Duration `json:"antiEntropyInterval,omitempty"`
+	LongQueryTime       *toml.Duration `json:"longQueryTime,omitempty"`
+	MaxWritesPerRequest *int           `json:"maxWritesPerRequest,omitempty"`
+	MaxQueryMemory      *int64         `json:"maxQueryMemory,omitempty"`
+	DefaultCacheType    *string        `json:"defaultCacheType,omitempty"`
+	DefaultCacheSize    *uint32        `json:"defaultCacheSize,omitempty"`
+}
+
+// apply returns s with the update applied, or an error if the update is
+// invalid. The version of the result is later than that of s.
+func (u SettingsUpdate) apply(s Settings) (Settings, error) {
+	if u.AntiEntropyInterval != nil {
+		if *u.AntiEntropyInterval < 0 {
+			return s, errors.New("antiEntropyInterval must not be negative")
+		}
+		s.AntiEntropyInterval = *u.AntiEntropyInterval
+	}
+	if u.LongQueryTime != nil {
+		if *u.LongQueryTime < 0 {
+			return s, errors.New("longQueryTime must not be negative")
+		}
+		s.LongQueryTime = *u.LongQueryTime
+	}
+	if u.MaxWritesPerRequest != nil {
+		if *u.MaxWritesPerRequest < 0 {
+			return s, errors.New("maxWritesPerRequest must not be negative")
+		}
+		s.MaxWritesPerRequest = *u.MaxWritesPerRequest
+	}
+	if u.MaxQueryMemory != nil {
+		if *u.MaxQueryMemory < 0 {
+			return s, errors.New("maxQueryMemory must not be negative")
+		}
+		s.MaxQueryMemory = *u.MaxQueryMemory
+	}
+	if u.DefaultCacheType != nil {
+		if !isValidCacheType(*u.DefaultCacheType) {
+			return s, ErrInvalidCacheType
+		}
+		s.DefaultCacheType = *u.DefaultCacheType
+	}
+	if u.DefaultCacheSize != nil {
+		s.DefaultCacheSize = *u.DefaultCacheSize
+	}
+
+	// Versions are times so that concurrent updates on different nodes
+	// resolve to the last one everywhere.
+	if v := time.Now().UnixNano(); v > s.Version {
+		s.Version = v
+	} else {
+		s.Version++
+	}
+	return s, nil
+}
+
+// settingsStore holds a node's copy of the cluster settings.
+type settingsStore struct {
+	mu       sync.RWMutex
+	settings Settings
+	path     string
+
+	// changed receives a value when the settings change.
+	changed chan struct{}
+}
+
+func newSettingsStore(settings Settings) *settingsStore {
+	return &settingsStore{
+		settings: settings,
+		changed:  make(chan struct{}, 1),
+	}
+}
+
+// open loads the settings recorded in the file at path, if they are more
+// recent than the current settings, and records later settings there.
+func (s *settingsStore) open(path string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.path = path
+
+	buf, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "reading settings file")
+	}
+	var settings Settings
+	if err := json.Unmarshal(buf, &settings); err != nil {
+		return errors.Wrap(err, "decoding settings file")
+	}
+	if settings.Version > s.settings.Version {
+		s.settings = settings
+	}
+	return nil
+}
+
+// get returns the current settings.
+func (s *settingsStore) get() Settings {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.settings
+}
+
+// update replaces the current settings with settings, if they are more
+// recent, and returns true if they were replaced.
+func (s *settingsStore) update(settings Settings) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if settings.Version <= s.settings.Version {
+		return false, nil
+	}
+	return true, s.unprotectedSet(settings)
+}
+
+// apply changes the current settings by update, and returns the new
+// settings. The settings are read, changed and replaced under the lock, so
+// that an update made concurrently isn't lost. An invalid update returns a
+// BadRequestError; other errors are from saving the new settings.
+func (s *settingsStore) apply(update SettingsUpdate) (Settings, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	settings, err := update.apply(s.settings)
+	if err != nil {
+		return Settings{}, NewBadRequestError(err)
+	}
+	return settings, s.unprotectedSet(settings)
+}
+
+// unprotectedSet replaces the current settings, and saves them.
+func (s *settingsStore) unprotectedSet(settings Settings) error {
+	s.settings = settings
+
+	select {
+	case s.changed <- struct{}{}:
+	default:
+	}
+	return s.unprotectedSave()
+}
+
+// unprotectedSave writes the settings to the settings file, if any.
+func (s *settingsStore) unprotectedSave() error {
+	if s.path == "" {
+		return nil
+	}
+	buf, err := json.Marshal(s.settings)
+	if err != nil {
+		return errors.Wrap(err, "encoding settings file")
+	}
+
+	tempPath := s.path + tempExt
+	if err := ioutil.WriteFile(tempPath, buf, 0666); err != nil {
+		return errors.Wrap(err, "writing settings file")
+	}
+	return errors.Wrap(os.Rename(tempPath, s.path), "renaming settings file")
+}
+
+// SettingsMessage is an internal message for broadcasting the cluster
+// settings.
+type SettingsMessage struct {
+	Settings Settings
+}
@@ -0,0 +1,128 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pilosa/pilosa/v2/toml"
+)
+
+func TestSettingsUpdate_Apply(t *testing.T) {
+	old := Settings{Version: 1, MaxWritesPerRequest: 10, DefaultCacheType: CacheTypeRanked}
+
+	n, cacheType := 5, CacheTypeLRU
+	s, err := SettingsUpdate{MaxWritesPerRequest: &n, DefaultCacheType: &cacheType}.apply(old)
+	if err != nil {
+		t.Fatal(err)
+	} else if s.MaxWritesPerRequest != 5 || s.DefaultCacheType != CacheTypeLRU {
+		t.Fatalf("unexpected settings: %+v", s)
+	} else if s.Version <= old.Version {
+		t.Fatalf("expected later version: %d", s.Version)
+	}
+
+	// Versions increase even if the clock does not.
+	future := Settings{Version: time.Now().Add(time.Hour).UnixNano()}
+	if s, err := (SettingsUpdate{}).apply(future); err != nil {
+		t.Fatal(err)
+	} else if s.Version != future.Version+1 {
+		t.Fatalf("unexpected version: %d", s.Version)
+	}
+
+	negative := toml.Duration(-time.Second)
+	if _, err := (SettingsUpdate{LongQueryTime: &negative}).apply(old); err == nil {
+		t.Fatal("expected error for negative duration")
+	}
+	invalid := "bogus"
+	if _, err := (SettingsUpdate{DefaultCacheType: &invalid}).apply(old); err != ErrInvalidCacheType {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// Ensure settings are kept across restarts, and only replaced by more recent
+// settings.
+func TestSettingsStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pilosa-settings-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, settingsFile)
+
+	s := newSettingsStore(Settings{MaxWritesPerRequest: 10})
+	if err := s.open(path); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := s.update(Settings{Version: 2, MaxWritesPerRequest: 20}); err != nil {
+		t.Fatal(err)
+	} else if !ok {
+		t.Fatal("expected update")
+	}
+	select {
+	case <-s.changed:
+	default:
+		t.Fatal("expected change notification")
+	}
+	if ok, err := s.update(Settings{Version: 1, MaxWritesPerRequest: 30}); err != nil {
+		t.Fatal(err)
+	} else if ok {
+		t.Fatal("unexpected update with older version")
+	}
+
+	s = newSettingsStore(Settings{MaxWritesPerRequest: 10})
+	if err := s.open(path); err != nil {
+		t.Fatal(err)
+	} else if got := s.get(); got.Version != 2 || got.MaxWritesPerRequest != 20 {
+		t.Fatalf("unexpected settings: %+v", got)
+	}
+}
+
+// Ensure concurrent updates are each applied to the result of the others, so
+// that none are lost.
+func TestSettingsStore_Apply(t *testing.T) {
+	s := newSettingsStore(Settings{})
+	n, longQueryTime := 5, toml.Duration(time.Minute)
+	updates := []SettingsUpdate{
+		{MaxWritesPerRequest: &n},
+		{LongQueryTime: &longQueryTime},
+	}
+
+	var wg sync.WaitGroup
+	for _, update := range updates {
+		wg.Add(1)
+		go func(update SettingsUpdate) {
+			defer wg.Done()
+			if _, err := s.apply(update); err != nil {
+				t.Error(err)
+			}
+		}(update)
+	}
+	wg.Wait()
+	if got := s.get(); got.MaxWritesPerRequest != 5 || got.LongQueryTime != longQueryTime {
+		t.Fatalf("unexpected settings: %+v", got)
+	}
+
+	invalid := "bogus"
+	if _, err := s.apply(SettingsUpdate{DefaultCacheType: &invalid}); err == nil {
+		t.Fatal("expected error")
+	} else if _, ok := err.(BadRequestError); !ok {
+		t.Fatalf("unexpected error type: %T", err)
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pilosa/pilosa/v2/toml"
+)
+
+// StartupProgress reports how far the holder has got opening its fragments,
+// so that a slow startup can be told apart from a hung one.
+type StartupProgress struct {
+	Complete bool      `json:"complete"`
+	Opened   int       `json:"fragmentsOpened"`
+	Total    int       `json:"fragmentsTotal"`
+	Started  time.Time `json:"started"`
+
+	// Estimated time remaining until every fragment is open, assuming the
+	// rest open at the same rate. Omitted when there is nothing to
+	// estimate from.
+	ETA       *time.Time     `json:"eta,omitempty"`
+	Remaining *toml.Duration `json:"remaining,omitempty"`
+}
+
+// openProgress counts the fragments opened while the holder opens. Its
+// methods may be called on a nil openProgress, which does nothing.
+type openProgress struct {
+	mu       sync.Mutex
+	started  time.Time
+	opened   int
+	total    int
+	complete bool
+}
+
+// begin starts counting towards total fragments.
+func (p *openProgress) begin(total int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.started = time.Now().UTC()
+	p.opened, p.total = 0, total
+	p.complete = false
+}
+
+// fragmentOpened records that a fragment was opened.
+func (p *openProgress) fragmentOpened() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.opened < p.total {
+		p.opened++
+	}
+}
+
+// finish records that the holder has opened.
+func (p *openProgress) finish() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.opened = p.total
+	p.complete = true
+}
+
+// status returns the progress so far.
+func (p *openProgress) status() StartupProgress {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	s := StartupProgress{
+		Complete: p.complete,
+		Opened:   p.opened,
+		Total:    p.total,
+		Started:  p.started,
+	}
+	if !p.complete {
+		if s.ETA = estimateJobFinish(p.started, p.opened, p.total); s.ETA != nil {
+			remaining := toml.Duration(time.Until(*s.ETA).Round(time.Second))
+			if remaining < 0 {
+				remaining = 0
+			}
+			s.Remaining = &remaining
+		}
+	}
+	return s
+}
+
+// countFragmentFiles returns the number of fragment files in the holder's
+// data directory. Errors are ignored, since the count is only an estimate.
+func countFragmentFiles(path string) int {
+	paths, _ := filepath.Glob(filepath.Join(path, "*", "*", "views", "*", "fragments", "*"))
+	namespaced, _ := filepath.Glob(filepath.Join(path, namespaceDirPrefix+"*", "*", "*", "views", "*", "fragments", "*"))
+	paths = append(paths, namespaced...)
+	var n int
+	for _, p := range paths {
+		if _, err := strconv.ParseUint(filepath.Base(p), 10, 64); err == nil {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,206 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+
+	"github.com/pilosa/pilosa/v2/roaring"
+	"github.com/pkg/errors"
+)
+
+// fragmentStatistics are lightweight statistics of a fragment's data, which
+// the executor uses to estimate the cost of evaluating calls. They are
+// computed when the fragment is snapshotted, and saved beside its data file,
+// so they don't reflect the writes made since its last snapshot.
+type fragmentStatistics struct {
+	Rows       uint64 // rows with any bits set
+	Bits       uint64 // bits set in every row
+	MaxRowBits uint64 // bits set in the largest row
+
+	// Number of containers of each type.
+	Arrays  uint64
+	Bitmaps uint64
+	Runs    uint64
+}
+
+// fragmentStatisticsSize is the size of the body of a statistics file.
+const fragmentStatisticsSize = 6 * 8
+
+// newFragmentStatistics returns the statistics of the data in bm. Only the
+// containers' counts are read, not their data.
+func newFragmentStatistics(bm *roaring.Bitmap) fragmentStatistics {
+	var s fragmentStatistics
+	var row, rowBits uint64
+	citer, _ := bm.Containers.Iterator(0)
+	for citer.Next() {
+		key, c := citer.Value()
+		n := uint64(c.N())
+		if n == 0 {
+			continue
+		}
+
+		// Containers are ordered, so those of a row are consecutive.
+		if r := key >> shardVsContainerExponent; s.Rows == 0 || r != row {
+			row, rowBits = r, 0
+			s.Rows++
+		}
+		rowBits += n
+		if rowBits > s.MaxRowBits {
+			s.MaxRowBits = rowBits
+		}
+		s.Bits += n
+
+		switch c.Type() {
+		case "array":
+			s.Arrays++
+		case "bitmap":
+			s.Bitmaps++
+		case "run":
+			s.Runs++
+		}
+	}
+	return s
+}
+
+// add adds the statistics of other, of another fragment, to s. MaxRowBits
+// becomes the largest of a row in either fragment.
+func (s *fragmentStatistics) add(other fragmentStatistics) {
+	s.Rows += other.Rows
+	s.Bits += other.Bits
+	if other.MaxRowBits > s.MaxRowBits {
+		s.MaxRowBits = other.MaxRowBits
+	}
+	s.Arrays += other.Arrays
+	s.Bitmaps += other.Bitmaps
+	s.Runs += other.Runs
+}
+
+// marshal returns the encoded statistics.
+func (s *fragmentStatistics) marshal() []byte {
+	buf := make([]byte, fragmentStatisticsSize)
+	for i, v := range []uint64{s.Rows, s.Bits, s.MaxRowBits, s.Arrays, s.Bitmaps, s.Runs} {
+		binary.LittleEndian.PutUint64(buf[i*8:], v)
+	}
+	return buf
+}
+
+// unmarshal decodes statistics encoded by marshal.
+func (s *fragmentStatistics) unmarshal(buf []byte) error {
+	if len(buf) < fragmentStatisticsSize {
+		return errors.Errorf("statistics truncated: %d bytes", len(buf))
+	}
+	for i, v := range []*uint64{&s.Rows, &s.Bits, &s.MaxRowBits, &s.Arrays, &s.Bitmaps, &s.Runs} {
+		*v = binary.LittleEndian.Uint64(buf[i*8:])
+	}
+	return nil
+}
+
+// statistics returns the statistics of the fragment's data, computing them
+// if they weren't saved.
+func (f *fragment) statistics() fragmentStatistics {
+	f.mu.RLock()
+	stats := f.planStats
+	f.mu.RUnlock()
+	if stats != nil {
+		return *stats
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.storage == nil {
+		return fragmentStatistics{}
+	} else if f.planStats == nil {
+		stats := newFragmentStatistics(f.storage)
+		f.planStats = &stats
+	}
+	return *f.planStats
+}
+
+// openStatistics reads the fragment's saved statistics, if any. Invalid
+// statistics are skipped, and recomputed when needed.
+func (f *fragment) openStatistics() {
+	f.planStats = nil
+	if f.inMemory {
+		return
+	}
+
+	path := f.statsPath()
+	buf, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return
+	} else if err != nil {
+		f.Logger.Printf("error reading statistics, skipping: path=%s, err=%s", path, err)
+		return
+	}
+
+	var stats fragmentStatistics
+	if !hasFileHeader(buf, statsFileMagic) {
+		f.Logger.Printf("invalid statistics file, skipping: path=%s", path)
+		return
+	} else if buf, err = readFileHeader(buf, statsFileMagic, statsFileVersion); err != nil {
+		f.Logger.Printf("invalid statistics file, skipping: path=%s, err=%s", path, err)
+		return
+	} else if err := stats.unmarshal(buf); err != nil {
+		f.Logger.Printf("invalid statistics file, skipping: path=%s, err=%s", path, err)
+		return
+	}
+	f.planStats = &stats
+}
+
+// writeStatistics saves the statistics beside the fragment's data file.
+func (f *fragment) writeStatistics(stats fragmentStatistics) error {
+	if f.inMemory {
+		return nil
+	}
+	buf := stats.marshal()
+	buf = append(newFileHeader(statsFileMagic, statsFileVersion, buf).marshal(), buf...)
+	return ioutil.WriteFile(f.statsPath(), buf, 0666)
+}
+
+// rowCount returns the number of bits set in a row. It is summed from the
+// counts of the row's containers, so the row's data is not read.
+func (f *fragment) rowCount(rowID uint64) uint64 {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	if f.storage == nil {
+		return 0
+	}
+	return f.storage.CountRange(rowID*ShardWidth, (rowID+1)*ShardWidth)
+}
+
+// statistics returns the combined statistics of the view's fragments on this
+// node.
+func (v *view) statistics() fragmentStatistics {
+	var stats fragmentStatistics
+	for _, frag := range v.allFragments() {
+		if err := frag.ensureOpen(); err != nil {
+			continue
+		}
+		stats.add(frag.statistics())
+	}
+	return stats
+}
+
+// statistics returns the combined statistics of the fragments of the field's
+// standard view on this node.
+func (f *Field) statistics() fragmentStatistics {
+	if v := f.view(viewStandard); v != nil {
+		return v.statistics()
+	}
+	return fragmentStatistics{}
+}
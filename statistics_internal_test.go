@@ -0,0 +1,72 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+// Ensure fragment statistics are computed, saved on snapshot, and read back
+// when the fragment is reopened.
+func TestFragment_Statistics(t *testing.T) {
+	f := mustOpenFragment("i", "f", viewStandard, 0, "")
+	defer f.Clean(t)
+
+	// Snapshots may change the types of containers, but not their number.
+	check := func(stats fragmentStatistics) {
+		t.Helper()
+		if stats.Rows != 2 || stats.Bits != 5 || stats.MaxRowBits != 3 {
+			t.Fatalf("unexpected statistics: %+v", stats)
+		} else if n := stats.Arrays + stats.Bitmaps + stats.Runs; n != 3 {
+			t.Fatalf("unexpected container count: %d", n)
+		}
+	}
+
+	f.mustSetBits(1, 1, 2, 3)
+	f.mustSetBits(2, 5, 1<<16+1)
+	check(f.statistics())
+	if n := f.rowCount(2); n != 2 {
+		t.Fatalf("unexpected row count: %d", n)
+	}
+
+	if err := f.Snapshot(); err != nil {
+		t.Fatal(err)
+	} else if _, err := os.Stat(f.statsPath()); err != nil {
+		t.Fatalf("expected statistics file: %v", err)
+	}
+	if err := f.Reopen(); err != nil {
+		t.Fatal(err)
+	} else if f.planStats == nil {
+		t.Fatal("expected saved statistics")
+	}
+	check(*f.planStats)
+
+	// The saved statistics are those of the data as it was written.
+	if exp := newFragmentStatistics(f.storage); *f.planStats != exp {
+		t.Fatalf("unexpected saved statistics: %+v, expected %+v", *f.planStats, exp)
+	}
+
+	// Invalid statistics files are skipped, and the statistics recomputed.
+	if err := ioutil.WriteFile(f.statsPath(), []byte("garbage"), 0666); err != nil {
+		t.Fatal(err)
+	} else if err := f.Reopen(); err != nil {
+		t.Fatal(err)
+	} else if f.planStats != nil {
+		t.Fatalf("expected no saved statistics: %+v", f.planStats)
+	}
+	check(f.statistics())
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/DataDog/datadog-go/statsd"
 	"github.com/pilosa/pilosa/v2/logger"
 	"github.com/pilosa/pilosa/v2/stats"
+	"github.com/pkg/errors"
 )
 
 // StatsD protocol wrapper using the DataDog library that added Tags to the StatsD protocol
@@ -39,22 +40,47 @@ var _ stats.StatsClient = &statsClient{}
 
 // statsClient represents a StatsD implementation of pilosa.statsClient.
 type statsClient struct {
-	client *statsd.Client
-	tags   []string
-	logger logger.Logger
+	client     *statsd.Client
+	tags       []string
+	sampleRate float64
+	logger     logger.Logger
+}
+
+// ClientOption is a functional option type for the StatsD client.
+type ClientOption func(c *statsClient) error
+
+// OptClientSampleRate is a functional option which sets the fraction of
+// events which are sent to the agent. Each event's own rate is scaled by it,
+// and the agent scales the values it receives back up, so a lower rate cuts
+// the traffic to the agent at the cost of accuracy.
+func OptClientSampleRate(rate float64) ClientOption {
+	return func(c *statsClient) error {
+		if rate <= 0 || rate > 1 {
+			return errors.Errorf("sample rate must be greater than 0 and at most 1: %v", rate)
+		}
+		c.sampleRate = rate
+		return nil
+	}
 }
 
 // NewStatsClient returns a new instance of StatsClient.
-func NewStatsClient(host string) (*statsClient, error) {
+func NewStatsClient(host string, opts ...ClientOption) (*statsClient, error) {
+	sc := &statsClient{
+		sampleRate: 1,
+		logger:     logger.NopLogger,
+	}
+	for _, opt := range opts {
+		if err := opt(sc); err != nil {
+			return nil, errors.Wrap(err, "applying option")
+		}
+	}
+
 	c, err := statsd.NewBuffered(host, bufferLen)
 	if err != nil {
 		return nil, err
 	}
-
-	return &statsClient{
-		client: c,
-		logger: logger.NopLogger,
-	}, nil
+	sc.client = c
+	return sc, nil
 }
 
 // Open no-op
@@ -73,15 +99,21 @@ func (c *statsClient) Tags() []string {
 // WithTags returns a new client with additional tags appended.
 func (c *statsClient) WithTags(tags ...string) stats.StatsClient {
 	return &statsClient{
-		client: c.client,
-		tags:   unionStringSlice(c.tags, tags),
-		logger: c.logger,
+		client:     c.client,
+		tags:       unionStringSlice(c.tags, tags),
+		sampleRate: c.sampleRate,
+		logger:     c.logger,
 	}
 }
 
+// rate returns the rate at which to sample an event recorded at rate.
+func (c *statsClient) rate(rate float64) float64 {
+	return rate * c.sampleRate
+}
+
 // Count tracks the number of times something occurs per second.
 func (c *statsClient) Count(name string, value int64, rate float64) {
-	if err := c.client.Count(prefix+name, value, c.tags, rate); err != nil {
+	if err := c.client.Count(prefix+name, value, c.tags, c.rate(rate)); err != nil {
 		c.logger.Printf("statsd.StatsClient.Count error: %s", err)
 	}
 }
@@ -89,35 +121,35 @@ func (c *statsClient) Count(name string, value int64, rate float64) {
 // CountWithCustomTags tracks the number of times something occurs per second with custom tags.
 func (c *statsClient) CountWithCustomTags(name string, value int64, rate float64, t []string) {
 	tags := append(c.tags, t...)
-	if err := c.client.Count(prefix+name, value, tags, rate); err != nil {
+	if err := c.client.Count(prefix+name, value, tags, c.rate(rate)); err != nil {
 		c.logger.Printf("statsd.StatsClient.Count error: %s", err)
 	}
 }
 
 // Gauge sets the value of a metric.
 func (c *statsClient) Gauge(name string, value float64, rate float64) {
-	if err := c.client.Gauge(prefix+name, value, c.tags, rate); err != nil {
+	if err := c.client.Gauge(prefix+name, value, c.tags, c.rate(rate)); err != nil {
 		c.logger.Printf("statsd.StatsClient.Gauge error: %s", err)
 	}
 }
 
 // Histogram tracks statistical distribution of a metric.
 func (c *statsClient) Histogram(name string, value float64, rate float64) {
-	if err := c.client.Histogram(prefix+name, value, c.tags, rate); err != nil {
+	if err := c.client.Histogram(prefix+name, value, c.tags, c.rate(rate)); err != nil {
 		c.logger.Printf("statsd.StatsClient.Histogram error: %s", err)
 	}
 }
 
 // Set tracks number of unique elements.
 func (c *statsClient) Set(name string, value string, rate float64) {
-	if err := c.client.Set(prefix+name, value, c.tags, rate); err != nil {
+	if err := c.client.Set(prefix+name, value, c.tags, c.rate(rate)); err != nil {
 		c.logger.Printf("statsd.StatsClient.Set error: %s", err)
 	}
 }
 
 // Timing tracks timing information for a metric.
 func (c *statsClient) Timing(name string, value time.Duration, rate float64) {
-	if err := c.client.Timing(prefix+name, value, c.tags, rate); err != nil {
+	if err := c.client.Timing(prefix+name, value, c.tags, c.rate(rate)); err != nil {
 		c.logger.Printf("statsd.StatsClient.Timing error: %s", err)
 	}
 }
@@ -15,7 +15,9 @@
 package statsd_test
 
 import (
+	"net"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -60,3 +62,50 @@ func TestStatsClient_Methods(t *testing.T) {
 	c.Timing("tt", dur, 1.0)
 	c.Set("ss", "ss", 1.0)
 }
+
+// Ensure events are sent at the configured sample rate.
+func TestStatsClient_SampleRate(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if _, err := statsd.NewStatsClient(conn.LocalAddr().String(), statsd.OptClientSampleRate(2)); err == nil {
+		t.Fatal("expected error for sample rate above 1")
+	}
+
+	c, err := statsd.NewStatsClient(conn.LocalAddr().String(), statsd.OptClientSampleRate(0.5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tc := c.WithTags("index:i")
+	for i := 0; i < 100; i++ {
+		tc.Histogram("hh", 1, 1.0)
+	}
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// About half the events are sent, each with the rate it was sampled at.
+	var sent int
+	buf := make([]byte, 64*1024)
+	for {
+		if err := conn.SetReadDeadline(time.Now().Add(100 * time.Millisecond)); err != nil {
+			t.Fatal(err)
+		}
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			break
+		}
+		for _, line := range strings.Split(strings.TrimSpace(string(buf[:n])), "\n") {
+			if line != "pilosa.hh:1.000000|h|@0.5|#index:i" {
+				t.Fatalf("unexpected event: %q", line)
+			}
+			sent++
+		}
+	}
+	if sent == 0 || sent == 100 {
+		t.Fatalf("unexpected number of events sent: %d", sent)
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -216,21 +217,183 @@ func nextDayGTE(t time.Time, end time.Time) bool {
 	return end.After(next)
 }
 
-// parseTime parses a string or int64 into a time.Time value.
-func parseTime(t interface{}) (time.Time, error) {
-	var err error
-	var calcTime time.Time
+// localTimeFormats are the layouts of timestamps without an offset, which
+// are in the time zone of their index. Fractional seconds are accepted after
+// the seconds.
+var localTimeFormats = []string{TimeFormat, "2006-01-02T15:04:05"}
+
+// parseTime parses a string or int64 into a time.Time value in UTC. Strings
+// are RFC3339 timestamps, timestamps without an offset in loc, or times
+// relative to now, such as "now-7d"; int64s are seconds since the Unix epoch.
+func parseTime(t interface{}, loc *time.Location) (time.Time, error) {
 	switch v := t.(type) {
 	case string:
-		if calcTime, err = time.Parse(TimeFormat, v); err != nil {
-			return time.Time{}, errors.New("cannot parse string time")
+		if strings.HasPrefix(v, "now") {
+			return parseRelativeTime(v, time.Now(), loc)
+		}
+		if calcTime, err := time.Parse(time.RFC3339, v); err == nil {
+			return calcTime.UTC(), nil
+		}
+		for _, layout := range localTimeFormats {
+			if calcTime, err := time.ParseInLocation(layout, v, loc); err == nil {
+				return calcTime.UTC(), nil
+			}
 		}
+		return time.Time{}, errors.New("cannot parse string time")
 	case int64:
-		calcTime = time.Unix(v, 0).UTC()
+		return time.Unix(v, 0).UTC(), nil
 	default:
 		return time.Time{}, errors.New("arg must be a timestamp")
 	}
-	return calcTime, nil
+}
+
+// parseRelativeTime parses a time relative to now, such as "now", "now-7d" or
+// "now+1h30m". The units are s, m, h, d and w, where days and weeks are
+// calendar days in loc.
+func parseRelativeTime(s string, now time.Time, loc *time.Location) (time.Time, error) {
+	t := now.In(loc)
+	rest := strings.TrimPrefix(s, "now")
+	if rest == "" {
+		return t.UTC(), nil
+	}
+
+	sign := 1
+	switch rest[0] {
+	case '-':
+		sign = -1
+	case '+':
+	default:
+		return time.Time{}, fmt.Errorf("cannot parse relative time: %q", s)
+	}
+	rest = rest[1:]
+	if rest == "" {
+		return time.Time{}, fmt.Errorf("cannot parse relative time: %q", s)
+	}
+	for rest != "" {
+		i := 0
+		for i < len(rest) && rest[i] >= '0' && rest[i] <= '9' {
+			i++
+		}
+		if i == 0 || i == len(rest) {
+			return time.Time{}, fmt.Errorf("cannot parse relative time: %q", s)
+		}
+		n, err := strconv.Atoi(rest[:i])
+		if err != nil {
+			return time.Time{}, fmt.Errorf("cannot parse relative time: %q", s)
+		}
+		n *= sign
+
+		switch rest[i] {
+		case 's':
+			t = t.Add(time.Duration(n) * time.Second)
+		case 'm':
+			t = t.Add(time.Duration(n) * time.Minute)
+		case 'h':
+			t = t.Add(time.Duration(n) * time.Hour)
+		case 'd':
+			t = t.AddDate(0, 0, n)
+		case 'w':
+			t = t.AddDate(0, 0, 7*n)
+		default:
+			return time.Time{}, fmt.Errorf("unknown unit of relative time: %q", rest[i])
+		}
+		rest = rest[i+1:]
+	}
+	return t.UTC(), nil
+}
+
+// loadTimeZone returns the location of a time zone given by its IANA name,
+// such as "America/New_York". The empty name is UTC. The local time zone
+// isn't allowed, since it may differ between nodes.
+func loadTimeZone(name string) (*time.Location, error) {
+	if name == "Local" {
+		return nil, fmt.Errorf("unknown time zone: %q", name)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone: %q", name)
+	}
+	return loc, nil
+}
+
+// timeRangeEnd returns the exclusive end of a time range. If end is unset
+// then the range is open and extends to the current time. We don't need to
+// worry about the start of a range since it is the zero value if omitted.
+func timeRangeEnd(end time.Time) time.Time {
+	if end.IsZero() {
+		// Use current time + 1 day, in order to account for timezone differences.
+		return time.Now().AddDate(0, 0, 1)
+	}
+	return end
+}
+
+// alignTimeRange truncates both ends of the time range [start, end) to the
+// smallest unit of q, which is the unit of the smallest views the range is
+// read from. A view is read if its start is within the aligned range. Zero
+// times are left open.
+func alignTimeRange(start, end time.Time, q TimeQuantum) (time.Time, time.Time) {
+	truncate := func(t time.Time) time.Time {
+		if t.IsZero() {
+			return t
+		}
+		y, m, d := t.Date()
+		switch {
+		case q.HasHour():
+			return time.Date(y, m, d, t.Hour(), 0, 0, 0, t.Location())
+		case q.HasDay():
+			return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+		case q.HasMonth():
+			return time.Date(y, m, 1, 0, 0, 0, 0, t.Location())
+		case q.HasYear():
+			return time.Date(y, 1, 1, 0, 0, 0, 0, t.Location())
+		}
+		return t
+	}
+	return truncate(start), truncate(end)
+}
+
+// timeRangeViews returns the views of a field to union to read the time range
+// [start, end), which is open at either end if the time is zero. The range is
+// aligned to the field's time quantum and limited to the times of its
+// existing views, so that as few views as possible are read.
+func timeRangeViews(f *Field, start, end time.Time) ([]string, error) {
+	q := f.TimeQuantum()
+	if q == "" {
+		return nil, nil
+	}
+
+	// Get min/max based on existing time views.
+	var vs []string
+	for _, v := range f.views() {
+		if strings.HasPrefix(v.name, viewStandard+"_") {
+			vs = append(vs, v.name)
+		}
+	}
+	min, max := minMaxViews(vs, q)
+
+	// If min/max are empty, there were no time views.
+	if min == "" || max == "" {
+		return nil, nil
+	}
+
+	// Convert min/max from string to time.Time.
+	minTime, err := timeOfView(min, false)
+	if err != nil {
+		return nil, fmt.Errorf("getting min time from view: %s: %v", min, err)
+	}
+	maxTime, err := timeOfView(max, true)
+	if err != nil {
+		return nil, fmt.Errorf("getting max time from view: %s: %v", max, err)
+	}
+
+	start, end = alignTimeRange(start, end, q)
+	if start.IsZero() || start.Before(minTime) {
+		start = minTime
+	}
+	if end.IsZero() || end.After(maxTime) {
+		end = maxTime
+	}
+	return viewsByTimeRange(viewStandard, start, end, q), nil
 }
 
 // minMaxViews returns the min and max view from a list of views
@@ -281,7 +444,7 @@ func timeOfView(v string, adj bool) (time.Time, error) {
 		return time.Time{}, nil
 	}
 
-	layout := "2006010203"
+	layout := "2006010215"
 	timePart := viewTimePart(v)
 
 	switch len(timePart) {
@@ -38,6 +38,95 @@ func TestParseTimeQuantum(t *testing.T) {
 	})
 }
 
+// Ensure timestamps are parsed in the given time zone, unless they have an
+// offset, and are returned in UTC.
+func TestParseTime(t *testing.T) {
+	loc, err := loadTimeZone("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		v   interface{}
+		exp time.Time
+	}{
+		{"2010-01-02T03:04", time.Date(2010, 1, 2, 8, 4, 0, 0, time.UTC)},
+		{"2010-07-02T03:04:05", time.Date(2010, 7, 2, 7, 4, 5, 0, time.UTC)},
+		{"2010-01-02T03:04:05.5", time.Date(2010, 1, 2, 8, 4, 5, 5e8, time.UTC)},
+		{"2010-01-02T03:04:05Z", time.Date(2010, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{"2010-01-02T03:04:05+09:30", time.Date(2010, 1, 1, 17, 34, 5, 0, time.UTC)},
+		{int64(1262401445), time.Date(2010, 1, 2, 3, 4, 5, 0, time.UTC)},
+	} {
+		if ts, err := parseTime(tt.v, loc); err != nil {
+			t.Fatalf("parsing %v: %s", tt.v, err)
+		} else if !ts.Equal(tt.exp) || ts.Location() != time.UTC {
+			t.Fatalf("parsing %v: expected %s, got %s", tt.v, tt.exp, ts)
+		}
+	}
+
+	if _, err := parseTime("2010-01-02", loc); err == nil {
+		t.Fatal("expected error parsing date without time")
+	}
+	if _, err := loadTimeZone("Nowhere/Special"); err == nil {
+		t.Fatal("expected error loading unknown time zone")
+	} else if _, err := loadTimeZone("Local"); err == nil {
+		t.Fatal("expected error loading local time zone")
+	}
+}
+
+// Ensure times relative to now are parsed, with days in the given time zone.
+func TestParseRelativeTime(t *testing.T) {
+	loc, err := loadTimeZone("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2010, 3, 15, 12, 0, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		s   string
+		exp time.Time
+	}{
+		{"now", now},
+		{"now-30s", now.Add(-30 * time.Second)},
+		{"now+1h30m", now.Add(90 * time.Minute)},
+		{"now-1w", time.Date(2010, 3, 8, 13, 0, 0, 0, time.UTC)}, // across the start of DST
+		{"now-7d12h", time.Date(2010, 3, 8, 1, 0, 0, 0, time.UTC)},
+	} {
+		if ts, err := parseRelativeTime(tt.s, now, loc); err != nil {
+			t.Fatalf("parsing %s: %s", tt.s, err)
+		} else if !ts.Equal(tt.exp) {
+			t.Fatalf("parsing %s: expected %s, got %s", tt.s, tt.exp, ts)
+		}
+	}
+
+	for _, s := range []string{"now-", "now7d", "now-7", "now-7y", "now-d"} {
+		if _, err := parseRelativeTime(s, now, loc); err == nil {
+			t.Fatalf("expected error parsing %s", s)
+		}
+	}
+}
+
+// Ensure time ranges are truncated to the smallest unit of a quantum.
+func TestAlignTimeRange(t *testing.T) {
+	start := time.Date(2010, 3, 15, 12, 30, 0, 0, time.UTC)
+	end := time.Date(2011, 5, 20, 6, 15, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		q          TimeQuantum
+		start, end time.Time
+	}{
+		{"YMDH", time.Date(2010, 3, 15, 12, 0, 0, 0, time.UTC), time.Date(2011, 5, 20, 6, 0, 0, 0, time.UTC)},
+		{"YMD", time.Date(2010, 3, 15, 0, 0, 0, 0, time.UTC), time.Date(2011, 5, 20, 0, 0, 0, 0, time.UTC)},
+		{"YM", time.Date(2010, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2011, 5, 1, 0, 0, 0, 0, time.UTC)},
+		{"Y", time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2011, 1, 1, 0, 0, 0, 0, time.UTC)},
+	} {
+		if s, e := alignTimeRange(start, end, tt.q); !s.Equal(tt.start) || !e.Equal(tt.end) {
+			t.Fatalf("aligning to %s: unexpected range %s - %s", tt.q, s, e)
+		}
+	}
+
+	if s, e := alignTimeRange(time.Time{}, end, "YMD"); !s.IsZero() || e.IsZero() {
+		t.Fatalf("expected open start, got %s - %s", s, e)
+	}
+}
+
 // Ensure generated view name can be returned for a given time unit.
 func TestViewByTimeUnit(t *testing.T) {
 	ts := time.Date(2000, time.January, 2, 3, 4, 5, 6, time.UTC)
@@ -252,6 +341,12 @@ func TestTimeOfView(t *testing.T) {
 				time.Date(2019, 2, 3, 9, 0, 0, 0, time.UTC),
 				"",
 			},
+			{
+				"std_2019020315",
+				time.Date(2019, 2, 3, 15, 0, 0, 0, time.UTC),
+				time.Date(2019, 2, 3, 16, 0, 0, 0, time.UTC),
+				"",
+			},
 			{
 				"foo",
 				time.Time{},
@@ -0,0 +1,284 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pilosa/pilosa/v2/pql"
+	"github.com/pkg/errors"
+)
+
+// UDF errors.
+var (
+	ErrUDFRuntimeNotConfigured = errors.New("no UDF runtime configured")
+	ErrUDFNotFound             = errors.New("udf not found")
+)
+
+// udfDir is the name of the directory, within the data directory, in which
+// loaded UDF modules are stored so that they survive restarts.
+const udfDir = ".udf"
+
+// UDFRuntime compiles user-defined function modules, such as WebAssembly
+// binaries, into sandboxed UDFModules.
+type UDFRuntime interface {
+	Compile(ctx context.Context, code []byte) (UDFModule, error)
+}
+
+// UDFModule is a compiled user-defined function. A module is either a
+// filter, which selects columns from a row and so can be used anywhere a row
+// is expected, or a map/reduce function, which computes an integer from the
+// columns of a row in each shard and merges the per-shard values.
+//
+// Modules must be safe for concurrent use.
+type UDFModule interface {
+	// IsFilter returns true if the module implements Filter rather than
+	// Map and Reduce.
+	IsFilter() bool
+
+	// Filter returns the subset of columns which the module selects.
+	Filter(ctx context.Context, columns []uint64) ([]uint64, error)
+
+	// Map computes a value from the columns of a row within one shard.
+	Map(ctx context.Context, columns []uint64) (int64, error)
+
+	// Reduce merges two values returned by Map or Reduce.
+	Reduce(ctx context.Context, a, b int64) (int64, error)
+
+	Close(ctx context.Context) error
+}
+
+// LoadUDFMessage is an internal message indicating that a UDF module was loaded.
+type LoadUDFMessage struct {
+	Name string
+	Code []byte
+}
+
+// DeleteUDFMessage is an internal message indicating that a UDF module was deleted.
+type DeleteUDFMessage struct {
+	Name string
+}
+
+// udfCallDefinition returns a call definition which executes mod. The call
+// takes a single row argument, which defaults to all columns if omitted.
+func udfCallDefinition(mod UDFModule) CallDefinition {
+	rowArg := func(ctx context.Context, shard *CallShard, c *pql.Call) (*Row, error) {
+		switch len(c.Children) {
+		case 0:
+			return shard.All()
+		case 1:
+			return shard.Row(ctx, c.Children[0])
+		default:
+			return nil, errors.Errorf("%s() accepts a single row argument", c.Name)
+		}
+	}
+
+	if mod.IsFilter() {
+		return CallDefinition{
+			Map: func(ctx context.Context, shard *CallShard, c *pql.Call) (interface{}, error) {
+				row, err := rowArg(ctx, shard, c)
+				if err != nil {
+					return nil, err
+				}
+				columns, err := mod.Filter(ctx, row.Columns())
+				if err != nil {
+					return nil, errors.Wrap(err, "running filter")
+				}
+				return NewRow(columns...), nil
+			},
+			Reduce: func(prev, v interface{}) (interface{}, error) {
+				other, _ := prev.(*Row)
+				if other == nil {
+					return v, nil
+				}
+				other.Merge(v.(*Row))
+				return other, nil
+			},
+		}
+	}
+
+	return CallDefinition{
+		Map: func(ctx context.Context, shard *CallShard, c *pql.Call) (interface{}, error) {
+			row, err := rowArg(ctx, shard, c)
+			if err != nil {
+				return nil, err
+			}
+			columns := row.Columns()
+			v, err := mod.Map(ctx, columns)
+			if err != nil {
+				return nil, errors.Wrap(err, "running map")
+			}
+			return ValCount{Val: v, Count: int64(len(columns))}, nil
+		},
+		Reduce: func(prev, v interface{}) (interface{}, error) {
+			other, ok := prev.(ValCount)
+			if !ok {
+				return v, nil
+			}
+			vc := v.(ValCount)
+			val, err := mod.Reduce(context.Background(), other.Val, vc.Val)
+			if err != nil {
+				return nil, errors.Wrap(err, "running reduce")
+			}
+			return ValCount{Val: val, Count: other.Count + vc.Count}, nil
+		},
+	}
+}
+
+// loadUDF compiles code and registers it as a PQL call named name,
+// replacing any module previously loaded under that name. If persist is
+// true, the code is also written to the data directory.
+func (s *Server) loadUDF(ctx context.Context, name string, code []byte, persist bool) error {
+	if s.udfRuntime == nil {
+		return ErrUDFRuntimeNotConfigured
+	}
+
+	mod, err := s.udfRuntime.Compile(ctx, code)
+	if err != nil {
+		return NewBadRequestError(errors.Wrap(err, "compiling module"))
+	}
+	def := udfCallDefinition(mod)
+	if err := validateCallDefinition(name, def); err != nil {
+		mod.Close(ctx)
+		return NewBadRequestError(err)
+	}
+
+	s.udfMu.Lock()
+	defer s.udfMu.Unlock()
+
+	// The module is registered before its code is written, so that code
+	// which can't be registered isn't left to fail when the server opens.
+	// The module it replaces is registered again if either fails.
+	prev, replaced := s.udfs[name]
+	restore := func() {
+		mod.Close(ctx)
+		if replaced {
+			if err := s.executor.registerCall(name, udfCallDefinition(prev)); err != nil {
+				s.logger.Printf("restoring udf %s: %v", name, err)
+			}
+		}
+	}
+	if replaced {
+		s.executor.unregisterCall(name)
+	}
+	if err := s.executor.registerCall(name, def); err != nil {
+		restore()
+		return NewConflictError(err)
+	}
+
+	if persist {
+		if err := s.writeUDF(name, code); err != nil {
+			s.executor.unregisterCall(name)
+			restore()
+			return err
+		}
+	}
+
+	if replaced {
+		prev.Close(ctx)
+	}
+	s.udfs[name] = mod
+	return nil
+}
+
+// writeUDF writes the code of the module named name to the data directory,
+// replacing the code of any earlier module.
+func (s *Server) writeUDF(name string, code []byte) error {
+	if err := os.MkdirAll(filepath.Join(s.holder.Path, udfDir), 0777); err != nil {
+		return errors.Wrap(err, "creating udf directory")
+	}
+	tempPath := s.udfPath(name) + tempExt
+	if err := ioutil.WriteFile(tempPath, code, 0666); err != nil {
+		os.Remove(tempPath)
+		return errors.Wrap(err, "writing udf")
+	}
+	return errors.Wrap(os.Rename(tempPath, s.udfPath(name)), "renaming udf")
+}
+
+// deleteUDF unregisters and removes the named module.
+func (s *Server) deleteUDF(ctx context.Context, name string) error {
+	s.udfMu.Lock()
+	defer s.udfMu.Unlock()
+
+	mod, ok := s.udfs[name]
+	if !ok {
+		return newNotFoundError(ErrUDFNotFound, name)
+	}
+	s.executor.unregisterCall(name)
+	delete(s.udfs, name)
+
+	if err := os.Remove(s.udfPath(name)); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "removing udf")
+	}
+	return mod.Close(ctx)
+}
+
+// udfNames returns the names of the loaded modules in sorted order.
+func (s *Server) udfNames() []string {
+	s.udfMu.Lock()
+	defer s.udfMu.Unlock()
+	names := make([]string, 0, len(s.udfs))
+	for name := range s.udfs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// loadUDFs loads all modules stored in the data directory.
+func (s *Server) loadUDFs() error {
+	fis, err := ioutil.ReadDir(filepath.Join(s.holder.Path, udfDir))
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "reading udf directory")
+	}
+
+	for _, fi := range fis {
+		if fi.IsDir() || filepath.Ext(fi.Name()) != ".wasm" {
+			continue
+		}
+		name := strings.TrimSuffix(fi.Name(), ".wasm")
+		code, err := ioutil.ReadFile(filepath.Join(s.holder.Path, udfDir, fi.Name()))
+		if err != nil {
+			return errors.Wrapf(err, "reading udf %s", name)
+		}
+		if err := s.loadUDF(context.Background(), name, code, false); err != nil {
+			return errors.Wrapf(err, "loading udf %s", name)
+		}
+	}
+	return nil
+}
+
+// closeUDFs releases all loaded modules.
+func (s *Server) closeUDFs() {
+	s.udfMu.Lock()
+	defer s.udfMu.Unlock()
+	for name, mod := range s.udfs {
+		s.executor.unregisterCall(name)
+		mod.Close(context.Background())
+		delete(s.udfs, name)
+	}
+}
+
+// udfPath returns the path of the stored code for the named module.
+func (s *Server) udfPath(name string) string {
+	return filepath.Join(s.holder.Path, udfDir, name+".wasm")
+}
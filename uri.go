@@ -27,13 +27,13 @@ import (
 )
 
 var schemeRegexp = regexp.MustCompile("^[+a-z]+$")
-var hostRegexp = regexp.MustCompile(`^[0-9a-z.-]+$|^\[[:0-9a-fA-F]+\]$`)
-var addressRegexp = regexp.MustCompile(`^(([+a-z]+):\/\/)?([0-9a-z.-]+|\[[:0-9a-fA-F]+\])?(:([0-9]+))?$`)
+var hostRegexp = regexp.MustCompile(`^[0-9a-z.-]+$|^\[[:0-9a-fA-F.]+(%[0-9a-zA-Z._-]+)?\]$`)
+var addressRegexp = regexp.MustCompile(`^(([+a-z]+):\/\/)?([0-9a-z.-]+|\[[:0-9a-fA-F.]+(%[0-9a-zA-Z._-]+)?\])?(:([0-9]+))?$`)
 
 // URI represents a Pilosa URI.
 // A Pilosa URI consists of three parts:
 // 1) Scheme: Protocol of the URI. Default: http.
-// 2) Host: Hostname or IP URI. Default: localhost. IPv6 addresses should be written in brackets, e.g., `[fd42:4201:f86b:7e09:216:3eff:fefa:ed80]`.
+// 2) Host: Hostname or IP URI. Default: localhost. IPv6 addresses should be written in brackets, e.g., `[fd42:4201:f86b:7e09:216:3eff:fefa:ed80]`, and are stored that way.
 // 3) Port: Port of the URI. Default: 10101.
 //
 // All parts of the URI are optional. The following are equivalent:
@@ -51,7 +51,7 @@ type URI struct {
 
 // URL returns a url.URL representation of the URI.
 func (u *URI) URL() url.URL {
-	return url.URL{Scheme: u.Scheme, Host: net.JoinHostPort(u.Host, strconv.Itoa(int(u.Port)))}
+	return url.URL{Scheme: u.Scheme, Host: u.HostPort()}
 }
 
 // defaultURI creates and returns the default URI.
@@ -102,8 +102,12 @@ func (u *URI) setScheme(scheme string) error {
 	return nil
 }
 
-// setHost sets the host of this URI.
+// setHost sets the host of this URI. IPv6 addresses may be passed with or
+// without brackets.
 func (u *URI) setHost(host string) error {
+	if strings.Contains(host, ":") && !strings.HasPrefix(host, "[") {
+		host = "[" + host + "]"
+	}
 	m := hostRegexp.FindStringSubmatch(host)
 	if m == nil {
 		return errors.New("invalid host")
@@ -117,14 +121,19 @@ func (u *URI) SetPort(port uint16) {
 	u.Port = port
 }
 
+// Hostname returns the host of this URI without the brackets around IPv6
+// addresses, as expected by net.Dial and net.Listen.
+func (u *URI) Hostname() string {
+	return strings.TrimSuffix(strings.TrimPrefix(u.Host, "["), "]")
+}
+
 // HostPort returns `Host:Port`
 func (u *URI) HostPort() string {
 	// XXX: The following is just to make TestHandler_Status; remove it
 	if u == nil {
 		return ""
 	}
-	s := fmt.Sprintf("%s:%d", u.Host, u.Port)
-	return s
+	return net.JoinHostPort(u.Hostname(), strconv.Itoa(int(u.Port)))
 }
 
 // normalize returns the address in a form usable by a HTTP client.
@@ -134,12 +143,14 @@ func (u *URI) normalize() string {
 	if index >= 0 {
 		scheme = scheme[:index]
 	}
-	return fmt.Sprintf("%s://%s:%d", scheme, u.Host, u.Port)
+	// url.URL escapes the zones of IPv6 addresses.
+	addr := url.URL{Scheme: scheme, Host: u.HostPort()}
+	return addr.String()
 }
 
 // String returns the address as a string.
 func (u URI) String() string {
-	return fmt.Sprintf("%s://%s:%d", u.Scheme, u.Host, u.Port)
+	return fmt.Sprintf("%s://%s", u.Scheme, u.HostPort())
 }
 
 // Path returns URI with path
@@ -178,8 +189,8 @@ func parseAddress(address string) (uri *URI, err error) {
 		host = m[3]
 	}
 	var port = 10101
-	if m[5] != "" {
-		port, err = strconv.Atoi(m[5])
+	if m[6] != "" {
+		port, err = strconv.Atoi(m[6])
 		if err != nil {
 			return nil, errors.New("converting port string to int")
 		}
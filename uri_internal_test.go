@@ -136,6 +136,36 @@ func TestHostPort(t *testing.T) {
 	}
 }
 
+func TestHostPort_IPv6(t *testing.T) {
+	for _, host := range []string{"::1", "[::1]"} {
+		uri, err := NewURIFromHostPort(host, 15001)
+		if err != nil {
+			t.Fatal(err)
+		}
+		compare(t, uri, "http", "[::1]", 15001)
+		if uri.Hostname() != "::1" {
+			t.Fatalf("%s != ::1", uri.Hostname())
+		} else if uri.HostPort() != "[::1]:15001" {
+			t.Fatalf("%s != [::1]:15001", uri.HostPort())
+		} else if u := uri.URL(); u.String() != "http://[::1]:15001" {
+			t.Fatalf("%s != http://[::1]:15001", u.String())
+		} else if uri.Path("/status") != "http://[::1]:15001/status" {
+			t.Fatalf("%s != http://[::1]:15001/status", uri.Path("/status"))
+		}
+	}
+}
+
+func TestURIPath_IPv6Zone(t *testing.T) {
+	uri, err := NewURIFromAddress("[fe80::1%eth0]:6888")
+	if err != nil {
+		t.Fatal(err)
+	}
+	target := "http://[fe80::1%25eth0]:6888/status"
+	if uri.Path("/status") != target {
+		t.Fatalf("%s != %s", uri.Path("/status"), target)
+	}
+}
+
 func compare(t *testing.T, uri *URI, scheme string, host string, port uint16) {
 	if uri.Scheme != scheme {
 		t.Fatalf("Scheme does not match: %s != %s", uri.Scheme, scheme)
@@ -167,6 +197,9 @@ func validFixture() []uriItem {
 		{"[::1]:3333", "http", "[::1]", 3333},
 		{"[fd42:4201:f86b:7e09:216:3eff:fefa:ed80]:3333", "http", "[fd42:4201:f86b:7e09:216:3eff:fefa:ed80]", 3333},
 		{"https://[fd42:4201:f86b:7e09:216:3eff:fefa:ed80]:3333", "https", "[fd42:4201:f86b:7e09:216:3eff:fefa:ed80]", 3333},
+		{"[::]:3333", "http", "[::]", 3333},
+		{"[::ffff:10.0.0.1]:3333", "http", "[::ffff:10.0.0.1]", 3333},
+		{"[fe80::1%eth0]:3333", "http", "[fe80::1%eth0]", 3333},
 	}
 	return test
 }
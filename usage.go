@@ -0,0 +1,446 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pilosa/pilosa/v2/logger"
+	"github.com/pkg/errors"
+)
+
+// Quota periods.
+const (
+	// QuotaPeriodMonth resets usage at the start of each calendar month (UTC).
+	QuotaPeriodMonth = "month"
+
+	// QuotaPeriod182Days resets usage every 182 days, counted from the Unix
+	// epoch.
+	QuotaPeriod182Days = "182d"
+)
+
+// usageFile is the file in the data directory which records the usage of
+// each API token, so that it is kept across restarts.
+const usageFile = ".usage"
+
+// usageSaveInterval is the minimum time between writes of the usage file
+// while a node is running. Usage is always written when the node closes.
+const usageSaveInterval = 10 * time.Second
+
+// anonymousToken is the token the usage of requests without an API token is
+// metered under, so that they share a quota rather than escape it.
+const anonymousToken = "anonymous"
+
+// ErrQuotaExceeded is returned when an API token has used its quota for the
+// current period.
+var ErrQuotaExceeded = errors.New("quota exceeded")
+
+// Quota limits the usage of each API token within a period. Zero limits are
+// unlimited.
+type Quota struct {
+	// Period is either QuotaPeriodMonth or QuotaPeriod182Days. If empty,
+	// the period is a month.
+	Period string
+
+	// Queries is the number of queries a token may issue.
+	Queries int64
+
+	// Containers is the number of roaring containers a token's queries may
+	// scan.
+	Containers int64
+
+	// ImportBytes is the number of bytes a token may import.
+	ImportBytes int64
+}
+
+// validate returns an error if the quota's period is unknown.
+func (q Quota) validate() error {
+	switch q.Period {
+	case "", QuotaPeriodMonth, QuotaPeriod182Days:
+		return nil
+	default:
+		return errors.Errorf("invalid quota period: %q", q.Period)
+	}
+}
+
+// periodStart returns the start of the quota period containing t.
+func (q Quota) periodStart(t time.Time) time.Time {
+	t = t.UTC()
+	if q.Period == QuotaPeriod182Days {
+		const day = 24 * 60 * 60
+		days := t.Unix() / day
+		return time.Unix((days-days%182)*day, 0).UTC()
+	}
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// Usage is the usage of an API token on a node within a quota period. The
+// combined usage of the tokens of a namespace has no token.
+type Usage struct {
+	Token       string    `json:"token"`
+	Namespace   string    `json:"namespace,omitempty"`
+	PeriodStart time.Time `json:"periodStart"`
+	Queries     int64     `json:"queries"`
+	Containers  int64     `json:"containers"`
+	ImportBytes int64     `json:"importBytes"`
+
+	// CPUTime is the number of nanoseconds spent executing queries and
+	// imports, measured as the time each took on the node.
+	CPUTime int64 `json:"cpuTime"`
+
+	// NetworkBytes is the number of bytes of requests received and
+	// responses sent.
+	NetworkBytes int64 `json:"networkBytes"`
+}
+
+// usageMeter records the usage of each API token on a node, and enforces the
+// node's quota. Usage without a token, that of internal requests, is neither
+// metered nor limited; see UsageToken.
+// The usage of the tokens bound to a namespace is also recorded together,
+// and limited by the namespace's quota.
+type usageMeter struct {
+	mu         sync.Mutex
+	quota      Quota
+	usage      map[string]*Usage
+	namespaces namespaceTokens
+	nsUsage    map[string]*Usage
+	days       map[usageDayKey]*UsageDay
+	now        func() time.Time
+
+	path   string // usage file path
+	saved  time.Time
+	logger logger.Logger
+}
+
+func newUsageMeter(quota Quota) *usageMeter {
+	return &usageMeter{
+		quota:   quota,
+		usage:   make(map[string]*Usage),
+		nsUsage: make(map[string]*Usage),
+		days:    make(map[usageDayKey]*UsageDay),
+		now:     time.Now,
+		logger:  logger.NopLogger,
+	}
+}
+
+// open loads the usage recorded in the file at path, and records later usage
+// there.
+func (m *usageMeter) open(path string, logger logger.Logger) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.path, m.logger = path, logger
+
+	if err := m.unprotectedOpenDays(); err != nil {
+		return err
+	}
+
+	buf, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "reading usage file")
+	}
+	var a []Usage
+	if err := json.Unmarshal(buf, &a); err != nil {
+		return errors.Wrap(err, "decoding usage file")
+	}
+	for i := range a {
+		if a[i].Token == "" {
+			m.nsUsage[a[i].Namespace] = &a[i]
+		} else {
+			m.usage[a[i].Token] = &a[i]
+		}
+	}
+	return nil
+}
+
+// close writes the usage file.
+func (m *usageMeter) close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.unprotectedSave()
+}
+
+// unprotectedSave writes the usage of every token to the usage file, if any,
+// and the daily usage to the usage days file.
+func (m *usageMeter) unprotectedSave() error {
+	if m.path == "" {
+		return nil
+	}
+	if err := m.unprotectedSaveDays(); err != nil {
+		return err
+	}
+	buf, err := json.Marshal(m.unprotectedAll())
+	if err != nil {
+		return errors.Wrap(err, "encoding usage file")
+	}
+
+	tempPath := m.path + tempExt
+	if err := ioutil.WriteFile(tempPath, buf, 0666); err != nil {
+		return errors.Wrap(err, "writing usage file")
+	}
+	m.saved = m.now()
+	return errors.Wrap(os.Rename(tempPath, m.path), "renaming usage file")
+}
+
+// unprotectedUsage returns the usage of token in the current period, starting
+// a new period if the previous one has ended.
+func (m *usageMeter) unprotectedUsage(token string) *Usage {
+	start := m.quota.periodStart(m.now())
+	u := m.usage[token]
+	if u == nil || !u.PeriodStart.Equal(start) {
+		u = &Usage{Token: token, PeriodStart: start}
+		m.usage[token] = u
+	}
+	return u
+}
+
+// unprotectedNamespaceUsage returns the combined usage of the tokens of a
+// namespace in the current period of its quota.
+func (m *usageMeter) unprotectedNamespaceUsage(ns *Namespace) *Usage {
+	start := ns.Quota.periodStart(m.now())
+	u := m.nsUsage[ns.Name]
+	if u == nil || !u.PeriodStart.Equal(start) {
+		u = &Usage{Namespace: ns.Name, PeriodStart: start}
+		m.nsUsage[ns.Name] = u
+	}
+	return u
+}
+
+// charge adds delta to the usage of token, unless the token has already used
+// its quota of a resource which delta uses, in which case ErrQuotaExceeded is
+// returned. Queries are refused once either their count or the containers
+// they scan reach the quota.
+func (m *usageMeter) charge(token string, delta Usage) error {
+	if token == "" {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	u := m.unprotectedUsage(token)
+	if err := m.quota.check(u, delta); err != nil {
+		return err
+	}
+	if ns := m.namespaces[token]; ns != nil {
+		if err := ns.Quota.check(m.unprotectedNamespaceUsage(ns), delta); err != nil {
+			return errors.Wrapf(err, "namespace %s", ns.Name)
+		}
+	}
+	m.unprotectedAdd(token, delta)
+	return nil
+}
+
+// check returns ErrQuotaExceeded if u has used the quota of a resource which
+// delta uses.
+func (q Quota) check(u *Usage, delta Usage) error {
+	if delta.Queries > 0 {
+		if q.Queries > 0 && u.Queries >= q.Queries {
+			return errors.Wrapf(ErrQuotaExceeded, "%d queries since %s", u.Queries, u.PeriodStart.Format(time.RFC3339))
+		} else if q.Containers > 0 && u.Containers >= q.Containers {
+			return errors.Wrapf(ErrQuotaExceeded, "%d containers scanned since %s", u.Containers, u.PeriodStart.Format(time.RFC3339))
+		}
+	}
+	if delta.ImportBytes > 0 && q.ImportBytes > 0 && u.ImportBytes >= q.ImportBytes {
+		return errors.Wrapf(ErrQuotaExceeded, "%d bytes imported since %s", u.ImportBytes, u.PeriodStart.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// add adds delta to the usage of token without checking the quota.
+func (m *usageMeter) add(token string, delta Usage) {
+	if token == "" {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.unprotectedAdd(token, delta)
+}
+
+// addTime adds the time since start, when a query or import began, to the
+// CPU time of token.
+func (m *usageMeter) addTime(token string, start time.Time) {
+	m.add(token, Usage{CPUTime: int64(time.Since(start))})
+}
+
+// unprotectedAdd adds delta to the usage of token, and of its namespace, in
+// the current period and day.
+func (m *usageMeter) unprotectedAdd(token string, delta Usage) {
+	m.unprotectedUsage(token).add(delta)
+	date := m.now().UTC().Format(usageDateFormat)
+	if ns := m.namespaces[token]; ns != nil {
+		m.unprotectedNamespaceUsage(ns).add(delta)
+		m.unprotectedDay(date, token, ns.Name).add(delta)
+		m.unprotectedDay(date, "", ns.Name).add(delta)
+	} else {
+		m.unprotectedDay(date, token, "").add(delta)
+	}
+
+	if m.now().Sub(m.saved) >= usageSaveInterval {
+		if err := m.unprotectedSave(); err != nil {
+			m.logger.Printf("saving usage: %v", err)
+		}
+	}
+}
+
+// add adds delta to u.
+func (u *Usage) add(delta Usage) {
+	u.Queries += delta.Queries
+	u.Containers += delta.Containers
+	u.ImportBytes += delta.ImportBytes
+	u.CPUTime += delta.CPUTime
+	u.NetworkBytes += delta.NetworkBytes
+}
+
+// all returns the usage of every token in the current period, sorted by token,
+// preceded by the usage of each declared namespace, sorted by namespace.
+func (m *usageMeter) all() []Usage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.unprotectedAll()
+}
+
+func (m *usageMeter) unprotectedAll() []Usage {
+	a := make([]Usage, 0, len(m.usage))
+	for token := range m.usage {
+		a = append(a, *m.unprotectedUsage(token))
+	}
+	seen := make(map[string]struct{})
+	for _, ns := range m.namespaces {
+		if _, ok := seen[ns.Name]; ok {
+			continue
+		} else if _, ok := m.nsUsage[ns.Name]; ok {
+			a = append(a, *m.unprotectedNamespaceUsage(ns))
+		}
+		seen[ns.Name] = struct{}{}
+	}
+	sort.Slice(a, func(i, j int) bool {
+		if a[i].Token != a[j].Token {
+			return a[i].Token < a[j].Token
+		}
+		return a[i].Namespace < a[j].Namespace
+	})
+	return a
+}
+
+type apiTokenKey struct{}
+
+// WithAPIToken returns a context carrying the API token of a request, which
+// its usage is metered against.
+func WithAPIToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, apiTokenKey{}, token)
+}
+
+// APITokenFromContext returns the API token of a request, if any.
+func APITokenFromContext(ctx context.Context) string {
+	token, _ := ctx.Value(apiTokenKey{}).(string)
+	return token
+}
+
+type internalRequestKey struct{}
+
+// WithInternalRequest returns a context for a request which a node sends to
+// another, or makes of itself, such as to run a schedule or replay a hint,
+// rather than one a client sends.
+func WithInternalRequest(ctx context.Context) context.Context {
+	return context.WithValue(ctx, internalRequestKey{}, true)
+}
+
+// UsageToken returns the token which a request's usage is metered under: its
+// API token, or anonymousToken if a client sent it without one. Internal
+// requests are metered under the token of the client request they serve,
+// which nodes pass on, and not at all if there is none.
+func UsageToken(ctx context.Context) string {
+	if token := APITokenFromContext(ctx); token != "" {
+		return token
+	} else if ctx.Value(internalRequestKey{}) != nil {
+		return ""
+	}
+	return anonymousToken
+}
+
+// queryScan counts the roaring containers read by a query, which may be
+// executing on many shards concurrently.
+type queryScan struct {
+	containers int64 // accessed atomically
+}
+
+type queryScanKey struct{}
+
+// withQueryScan returns a context which counts the containers read by a query.
+func withQueryScan(ctx context.Context) (context.Context, *queryScan) {
+	s := &queryScan{}
+	return context.WithValue(ctx, queryScanKey{}, s), s
+}
+
+// queryScanFromContext returns the query's container counter, if any.
+func queryScanFromContext(ctx context.Context) *queryScan {
+	s, _ := ctx.Value(queryScanKey{}).(*queryScan)
+	return s
+}
+
+// add records the containers of a row read from storage. A nil queryScan
+// counts nothing.
+func (s *queryScan) add(row *Row) {
+	if s == nil || row == nil {
+		return
+	}
+	atomic.AddInt64(&s.containers, int64(row.containerN()))
+}
+
+// count returns the number of containers read so far.
+func (s *queryScan) count() int64 {
+	return atomic.LoadInt64(&s.containers)
+}
+
+// importRequestSize returns the number of bytes an import request is metered
+// as: eight for each ID and timestamp, plus the length of each key.
+func importRequestSize(req *ImportRequest) int64 {
+	n := 8 * int64(len(req.RowIDs)+len(req.ColumnIDs)+len(req.Timestamps))
+	for _, key := range req.RowKeys {
+		n += int64(len(key))
+	}
+	for _, key := range req.ColumnKeys {
+		n += int64(len(key))
+	}
+	return n
+}
+
+// importValueRequestSize returns the number of bytes a value import request
+// is metered as: eight for each ID and value, plus the length of each key.
+func importValueRequestSize(req *ImportValueRequest) int64 {
+	n := 8 * int64(len(req.ColumnIDs)+len(req.Values))
+	for _, key := range req.ColumnKeys {
+		n += int64(len(key))
+	}
+	return n
+}
+
+// importRoaringRequestSize returns the number of bytes a roaring import
+// request is metered as, which is the size of its encoded bitmaps.
+func importRoaringRequestSize(req *ImportRoaringRequest) int64 {
+	var n int64
+	for _, data := range req.Views {
+		n += int64(len(data))
+	}
+	return n
+}
@@ -0,0 +1,198 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pilosa/pilosa/v2/logger"
+	"github.com/pkg/errors"
+)
+
+func TestQuota_PeriodStart(t *testing.T) {
+	now := time.Date(2019, 3, 15, 12, 0, 0, 0, time.UTC)
+	if start := (Quota{}).periodStart(now); !start.Equal(time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected month start: %v", start)
+	}
+
+	// 182-day periods are counted from the Unix epoch.
+	start := (Quota{Period: QuotaPeriod182Days}).periodStart(now)
+	if d := now.Sub(start); d < 0 || d >= 182*24*time.Hour {
+		t.Fatalf("unexpected 182d start: %v", start)
+	} else if days := start.Unix() / (24 * 60 * 60); days%182 != 0 {
+		t.Fatalf("unaligned 182d start: %v", start)
+	}
+}
+
+// Ensure usage is limited by the quota, reset each period, and kept across
+// restarts.
+func TestUsageMeter(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pilosa-usage-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, usageFile)
+
+	now := time.Date(2019, 3, 15, 12, 0, 0, 0, time.UTC)
+	m := newUsageMeter(Quota{Queries: 1, Containers: 10})
+	m.now = func() time.Time { return now }
+	if err := m.open(path, logger.NopLogger); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.charge("a", Usage{Queries: 1}); err != nil {
+		t.Fatal(err)
+	} else if err := m.charge("a", Usage{Queries: 1}); errors.Cause(err) != ErrQuotaExceeded {
+		t.Fatalf("unexpected error: %v", err)
+	} else if err := m.charge("", Usage{Queries: 1}); err != nil {
+		t.Fatal(err)
+	}
+	m.add("b", Usage{Containers: 10})
+	if err := m.charge("b", Usage{Queries: 1}); errors.Cause(err) != ErrQuotaExceeded {
+		t.Fatalf("unexpected error: %v", err)
+	} else if err := m.close(); err != nil {
+		t.Fatal(err)
+	}
+
+	m = newUsageMeter(Quota{Queries: 1, Containers: 10})
+	m.now = func() time.Time { return now }
+	if err := m.open(path, logger.NopLogger); err != nil {
+		t.Fatal(err)
+	}
+	if usage := m.all(); len(usage) != 2 || usage[0].Queries != 1 || usage[1].Containers != 10 {
+		t.Fatalf("unexpected usage: %+v", usage)
+	}
+
+	// Usage is reset once the period ends.
+	now = now.AddDate(0, 1, 0)
+	if err := m.charge("a", Usage{Queries: 1}); err != nil {
+		t.Fatal(err)
+	} else if usage := m.all(); usage[0].Queries != 1 || usage[1].Containers != 0 || !usage[0].PeriodStart.Equal(time.Date(2019, 4, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected usage: %+v", usage)
+	}
+}
+
+// Ensure client requests without a token are metered under the anonymous
+// token, and internal requests only under a token they pass on.
+func TestUsageToken(t *testing.T) {
+	ctx := context.Background()
+	if token := UsageToken(ctx); token != anonymousToken {
+		t.Fatalf("unexpected token without a token: %q", token)
+	} else if token := UsageToken(WithAPIToken(ctx, "a")); token != "a" {
+		t.Fatalf("unexpected token: %q", token)
+	} else if token := UsageToken(WithInternalRequest(ctx)); token != "" {
+		t.Fatalf("unexpected token of internal request: %q", token)
+	} else if token := UsageToken(WithInternalRequest(WithAPIToken(ctx, "a"))); token != "a" {
+		t.Fatalf("unexpected token of internal request passing one on: %q", token)
+	}
+}
+
+func TestUsageMeter_Namespace(t *testing.T) {
+	namespaces, err := newNamespaceTokens([]Namespace{{Name: "acme", Tokens: []string{"a", "b"}, Quota: Quota{ImportBytes: 100}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := newUsageMeter(Quota{})
+	m.namespaces = namespaces
+
+	// The tokens of a namespace share its quota.
+	if err := m.charge("a", Usage{ImportBytes: 60}); err != nil {
+		t.Fatal(err)
+	} else if err := m.charge("b", Usage{ImportBytes: 60}); err != nil {
+		t.Fatal(err)
+	} else if err := m.charge("a", Usage{ImportBytes: 1}); errors.Cause(err) != ErrQuotaExceeded {
+		t.Fatalf("unexpected error: %v", err)
+	} else if err := m.charge("c", Usage{ImportBytes: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if usage := m.all(); len(usage) != 4 || usage[0].Namespace != "acme" || usage[0].ImportBytes != 120 || usage[1].Token != "a" || usage[1].ImportBytes != 60 {
+		t.Fatalf("unexpected usage: %+v", usage)
+	}
+
+	// Namespaces are validated, and tokens bound to one namespace.
+	if _, err := newNamespaceTokens([]Namespace{{Name: "Acme"}}); errors.Cause(err) != ErrName {
+		t.Fatalf("unexpected error: %v", err)
+	} else if _, err := newNamespaceTokens([]Namespace{{Name: "x", Tokens: []string{"a"}}, {Name: "y", Tokens: []string{"a"}}}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestUsageMeter_Days(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pilosa-usage-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, usageFile)
+
+	namespaces, err := newNamespaceTokens([]Namespace{{Name: "acme", Tokens: []string{"a"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2019, 3, 15, 12, 0, 0, 0, time.UTC)
+	m := newUsageMeter(Quota{})
+	m.namespaces = namespaces
+	m.now = func() time.Time { return now }
+	if err := m.open(path, logger.NopLogger); err != nil {
+		t.Fatal(err)
+	}
+
+	m.add("a", Usage{Queries: 1, CPUTime: 10})
+	m.add("b", Usage{NetworkBytes: 100})
+	m.recordStorage(map[string]int64{"acme": 2000})
+	now = now.AddDate(0, 0, 1)
+	m.add("a", Usage{Queries: 2})
+	m.recordStorage(map[string]int64{"acme": 1000})
+	m.recordStorage(map[string]int64{"acme": 3000})
+	if err := m.close(); err != nil {
+		t.Fatal(err)
+	}
+
+	m = newUsageMeter(Quota{})
+	m.namespaces = namespaces
+	m.now = func() time.Time { return now }
+	if err := m.open(path, logger.NopLogger); err != nil {
+		t.Fatal(err)
+	}
+	if days := m.report(UsageReportRequest{}); !reflect.DeepEqual(days, []UsageDay{
+		{Date: "2019-03-15", Token: "b", NetworkBytes: 100},
+		{Date: "2019-03-15", Namespace: "acme", Queries: 1, CPUTime: 10, StorageBytes: 2000},
+		{Date: "2019-03-15", Token: "a", Namespace: "acme", Queries: 1, CPUTime: 10},
+		{Date: "2019-03-16", Namespace: "acme", Queries: 2, StorageBytes: 3000},
+		{Date: "2019-03-16", Token: "a", Namespace: "acme", Queries: 2},
+	}) {
+		t.Fatalf("unexpected days: %+v", days)
+	}
+	if days := m.report(UsageReportRequest{From: "2019-03-16", Token: "a"}); len(days) != 1 || days[0].Queries != 2 {
+		t.Fatalf("unexpected days: %+v", days)
+	} else if days := m.report(UsageReportRequest{To: "2019-03-15", Namespace: "acme"}); len(days) != 2 {
+		t.Fatalf("unexpected days: %+v", days)
+	}
+
+	// Days are dropped once no longer retained.
+	now = now.AddDate(0, 0, usageDaysRetained-1)
+	if err := m.close(); err != nil {
+		t.Fatal(err)
+	} else if days := m.report(UsageReportRequest{}); len(days) != 2 || days[0].Date != "2019-03-16" {
+		t.Fatalf("unexpected days: %+v", days)
+	}
+}
@@ -0,0 +1,265 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// usageDateFormat is the layout of the dates of daily usage.
+const usageDateFormat = "2006-01-02"
+
+// usageDaysFile is the file in the data directory which records the daily
+// usage of each API token and namespace.
+const usageDaysFile = ".usage-days"
+
+// usageDaysRetained is the number of days of daily usage kept.
+const usageDaysRetained = 92
+
+// usageStorageInterval is the interval at which the storage used by each
+// namespace is measured.
+const usageStorageInterval = time.Hour
+
+// UsageDay is the usage of an API token, or the combined usage of the
+// tokens of a namespace, on a node during a day (UTC). A token bound to a
+// namespace has both its token and namespace set, while the combined usage
+// of a namespace has no token.
+type UsageDay struct {
+	Date         string `json:"date"`
+	Token        string `json:"token,omitempty"`
+	Namespace    string `json:"namespace,omitempty"`
+	Queries      int64  `json:"queries"`
+	Containers   int64  `json:"containers"`
+	ImportBytes  int64  `json:"importBytes"`
+	CPUTime      int64  `json:"cpuTime"`
+	NetworkBytes int64  `json:"networkBytes"`
+
+	// StorageBytes is the largest size of the indexes of a namespace on
+	// the node during the day. It is only set on the combined usage of
+	// namespaces.
+	StorageBytes int64 `json:"storageBytes,omitempty"`
+}
+
+// add adds delta to d.
+func (d *UsageDay) add(delta Usage) {
+	d.Queries += delta.Queries
+	d.Containers += delta.Containers
+	d.ImportBytes += delta.ImportBytes
+	d.CPUTime += delta.CPUTime
+	d.NetworkBytes += delta.NetworkBytes
+}
+
+type usageDayKey struct {
+	date, token, namespace string
+}
+
+// unprotectedDay returns the usage of a token or namespace on a date.
+func (m *usageMeter) unprotectedDay(date, token, namespace string) *UsageDay {
+	key := usageDayKey{date: date, token: token, namespace: namespace}
+	d := m.days[key]
+	if d == nil {
+		d = &UsageDay{Date: date, Token: token, Namespace: namespace}
+		m.days[key] = d
+	}
+	return d
+}
+
+// daysPath returns the path of the usage days file.
+func (m *usageMeter) daysPath() string {
+	return filepath.Join(filepath.Dir(m.path), usageDaysFile)
+}
+
+// unprotectedOpenDays loads the daily usage recorded in the usage days file.
+func (m *usageMeter) unprotectedOpenDays() error {
+	buf, err := ioutil.ReadFile(m.daysPath())
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "reading usage days file")
+	}
+	var a []UsageDay
+	if err := json.Unmarshal(buf, &a); err != nil {
+		return errors.Wrap(err, "decoding usage days file")
+	}
+	for i := range a {
+		m.days[usageDayKey{date: a[i].Date, token: a[i].Token, namespace: a[i].Namespace}] = &a[i]
+	}
+	return nil
+}
+
+// unprotectedSaveDays writes the daily usage to the usage days file, after
+// dropping the days no longer retained.
+func (m *usageMeter) unprotectedSaveDays() error {
+	oldest := m.now().UTC().AddDate(0, 0, -usageDaysRetained+1).Format(usageDateFormat)
+	for key := range m.days {
+		if key.date < oldest {
+			delete(m.days, key)
+		}
+	}
+
+	buf, err := json.Marshal(m.unprotectedReport(UsageReportRequest{}))
+	if err != nil {
+		return errors.Wrap(err, "encoding usage days file")
+	}
+	path := m.daysPath()
+	if err := ioutil.WriteFile(path+tempExt, buf, 0666); err != nil {
+		return errors.Wrap(err, "writing usage days file")
+	}
+	return errors.Wrap(os.Rename(path+tempExt, path), "renaming usage days file")
+}
+
+// recordStorage records the current size of the indexes of each namespace.
+func (m *usageMeter) recordStorage(sizes map[string]int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	date := m.now().UTC().Format(usageDateFormat)
+	for namespace, size := range sizes {
+		if d := m.unprotectedDay(date, "", namespace); size > d.StorageBytes {
+			d.StorageBytes = size
+		}
+	}
+}
+
+// report returns the daily usage matching req.
+func (m *usageMeter) report(req UsageReportRequest) []UsageDay {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.unprotectedReport(req)
+}
+
+func (m *usageMeter) unprotectedReport(req UsageReportRequest) []UsageDay {
+	a := make([]UsageDay, 0, len(m.days))
+	for key, d := range m.days {
+		if req.From != "" && key.date < req.From {
+			continue
+		} else if req.To != "" && key.date > req.To {
+			continue
+		} else if req.Token != "" && key.token != req.Token {
+			continue
+		} else if req.Namespace != "" && key.namespace != req.Namespace {
+			continue
+		}
+		a = append(a, *d)
+	}
+	sort.Slice(a, func(i, j int) bool {
+		if a[i].Date != a[j].Date {
+			return a[i].Date < a[j].Date
+		} else if a[i].Namespace != a[j].Namespace {
+			return a[i].Namespace < a[j].Namespace
+		}
+		return a[i].Token < a[j].Token
+	})
+	return a
+}
+
+// namespaceSizes returns the number of bytes in the directory of each
+// namespace. Errors are logged, and the namespace skipped.
+func (h *Holder) namespaceSizes() map[string]int64 {
+	fis, err := ioutil.ReadDir(h.Path)
+	if err != nil {
+		h.Logger.Printf("reading data directory: %s", err)
+		return nil
+	}
+
+	sizes := make(map[string]int64)
+	for _, fi := range fis {
+		if !fi.IsDir() || !strings.HasPrefix(fi.Name(), namespaceDirPrefix) {
+			continue
+		}
+		var size int64
+		if err := filepath.Walk(filepath.Join(h.Path, fi.Name()), func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			} else if info.Mode().IsRegular() {
+				size += info.Size()
+			}
+			return nil
+		}); err != nil {
+			h.Logger.Printf("measuring namespace %s: %s", strings.TrimPrefix(fi.Name(), namespaceDirPrefix), err)
+			continue
+		}
+		sizes[strings.TrimPrefix(fi.Name(), namespaceDirPrefix)] = size
+	}
+	return sizes
+}
+
+// monitorUsageStorage periodically records the storage used by each
+// namespace.
+func (s *Server) monitorUsageStorage() {
+	ticker := time.NewTicker(usageStorageInterval)
+	defer ticker.Stop()
+
+	s.usage.recordStorage(s.holder.namespaceSizes())
+	for {
+		select {
+		case <-s.closing:
+			return
+		case <-ticker.C:
+			s.usage.recordStorage(s.holder.namespaceSizes())
+		}
+	}
+}
+
+// UsageReportRequest selects the daily usage returned by UsageReport. Each
+// empty field matches all usage.
+type UsageReportRequest struct {
+	// From and To are the first and last dates, such as "2019-03-15".
+	From string
+	To   string
+
+	Token     string
+	Namespace string
+}
+
+// UsageReport returns the daily usage on this node of each API token, and
+// of the tokens of each namespace, matching req. The usage of a request
+// whose API token is bound to a namespace is limited to the namespace.
+func (api *API) UsageReport(ctx context.Context, req UsageReportRequest) ([]UsageDay, error) {
+	if err := api.validate(apiUsage); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+	for _, date := range []string{req.From, req.To} {
+		if date == "" {
+			continue
+		} else if _, err := time.Parse(usageDateFormat, date); err != nil {
+			return nil, NewBadRequestError(errors.Wrap(err, "parsing date"))
+		}
+	}
+	if ns := api.TokenNamespace(ctx); ns != "" {
+		if req.Namespace != "" && req.Namespace != ns {
+			return nil, errors.Wrapf(ErrNamespaceForbidden, "namespace %s", req.Namespace)
+		}
+		req.Namespace = ns
+	}
+
+	// Measure storage now, so that today's usage is current.
+	api.server.usage.recordStorage(api.holder.namespaceSizes())
+	return api.server.usage.report(req), nil
+}
+
+// AddNetworkUsage adds the bytes of a request and its response to the usage
+// of the request's API token.
+func (api *API) AddNetworkUsage(ctx context.Context, n int64) {
+	api.server.usage.add(UsageToken(ctx), Usage{NetworkBytes: n})
+}
@@ -0,0 +1,394 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"sort"
+
+	"github.com/pilosa/pilosa/v2/pql"
+	"github.com/pkg/errors"
+)
+
+// Severities of query diagnostics.
+const (
+	// The query would fail.
+	DiagnosticError = "error"
+
+	// The query would succeed, but likely not as intended, such as a
+	// condition outside of a field's range, which matches nothing.
+	DiagnosticWarning = "warning"
+)
+
+// QueryDiagnostic describes a problem with a query, found by validating it
+// against the schema. Its code, message, index and field are those of the
+// error the query would fail with.
+type QueryDiagnostic struct {
+	Severity string `json:"severity"`
+
+	// The position of the call in the query: the index of the top-level
+	// call, followed by the index of each child call down to it. Calls
+	// passed as arguments, such as GroupBy()'s filter, have the position of
+	// the call they're passed to.
+	Path []int `json:"path"`
+
+	// The call, in PQL.
+	Call string `json:"call"`
+
+	ResponseError
+}
+
+// knownCalls are the names of the calls the executor implements, other than
+// user-defined functions.
+var knownCalls = map[string]bool{
+	"Row": true, "Range": true, "Union": true, "Intersect": true,
+	"Difference": true, "Xor": true, "Not": true, "Shift": true,
+	"Index": true, "Count": true, "Sum": true, "Min": true, "Max": true,
+	"MinRow": true, "MaxRow": true, "TopN": true, "Rows": true,
+	"GroupBy": true, "Options": true, "Sample": true, "Set": true,
+	"Clear": true, "ClearRow": true, "Store": true, "SetRowAttrs": true,
+	"SetColumnAttrs": true, "Materialized": true, "Limit": true,
+}
+
+// writeCalls are the calls which write to an index.
+var writeCalls = map[string]bool{
+	"Set": true, "Clear": true, "ClearRow": true, "Store": true,
+	"SetRowAttrs": true, "SetColumnAttrs": true,
+}
+
+// queryValidator checks the calls of a query against the schema, without
+// executing them or changing any data.
+type queryValidator struct {
+	holder     *Holder
+	customCall func(name string) bool
+	diags      []QueryDiagnostic
+}
+
+func (v *queryValidator) report(severity string, path []int, c *pql.Call, err error) {
+	v.diags = append(v.diags, QueryDiagnostic{
+		Severity:      severity,
+		Path:          append([]int(nil), path...),
+		Call:          c.String(),
+		ResponseError: *NewResponseError(err),
+	})
+}
+
+func (v *queryValidator) errorf(path []int, c *pql.Call, format string, a ...interface{}) {
+	v.report(DiagnosticError, path, c, NewBadRequestError(errors.Errorf(format, a...)))
+}
+
+// validate checks a call executed on idx, and its children.
+func (v *queryValidator) validate(idx *Index, c *pql.Call, path []int) {
+	if !knownCalls[c.Name] && !v.customCall(c.Name) {
+		v.errorf(path, c, "unknown call: %s", c.Name)
+		return
+	}
+	if writeCalls[c.Name] && idx.ReadOnly() {
+		v.report(DiagnosticError, path, c, ErrIndexReadOnly)
+	}
+
+	switch c.Name {
+	case "Row", "Range":
+		v.validateRow(idx, c, path)
+	case "Set", "Clear":
+		v.validateColumn(idx, c, path, "_"+columnLabel)
+		if f := v.field(idx, c, path, fieldArgName(c)); f != nil {
+			v.validateRowValue(f, c, path, f.Name())
+		}
+	case "ClearRow", "Store":
+		if f := v.field(idx, c, path, fieldArgName(c)); f != nil {
+			v.validateRowValue(f, c, path, f.Name())
+		}
+	case "SetRowAttrs":
+		if f := v.field(idx, c, path, callArgString(c, "_field")); f != nil {
+			v.validateRowValue(f, c, path, "_"+rowLabel)
+		}
+	case "SetColumnAttrs":
+		v.validateColumn(idx, c, path, "_"+columnLabel)
+	case "TopN", "Rows":
+		f := v.field(idx, c, path, callArgString(c, "_field"))
+		if f != nil && c.Name == "TopN" && f.Type() == FieldTypeInt {
+			v.errorf(path, c, "cannot compute TopN() on integer field: %q", f.Name())
+		}
+		if f != nil && c.Name == "Rows" {
+			v.validateRowValue(f, c, path, "previous")
+			v.validateColumn(idx, c, path, "column")
+		}
+		v.validateUints(c, path, "n", "limit")
+	case "Sum", "Min", "Max", "MinRow", "MaxRow":
+		f := v.field(idx, c, path, callArgString(c, "field"))
+		if f != nil && (c.Name == "Sum" || c.Name == "Min" || c.Name == "Max") && f.Type() != FieldTypeInt {
+			v.report(DiagnosticError, path, c, errors.Wrapf(ErrBSIGroupNotFound, "%s() requires an int field, %q is a %s field", c.Name, f.Name(), f.Type()))
+		}
+		v.validateChildren(c, path, 0, 1)
+	case "Count", "Options":
+		v.validateChildren(c, path, 1, 1)
+		if c.Name == "Count" {
+			v.validateUints(c, path, "limit")
+		}
+	case "Not":
+		v.validateChildren(c, path, 1, 1)
+		if !idx.trackExistence {
+			v.errorf(path, c, "index does not support existence tracking: %s", idx.Name())
+		}
+	case "Shift":
+		v.validateChildren(c, path, 1, 1)
+		if _, _, err := c.IntArg("n"); err != nil {
+			v.errorf(path, c, "Shift() n: %s", err)
+		}
+	case "Sample":
+		v.validateChildren(c, path, 1, 1)
+		if n, ok, err := c.UintArg("n"); err != nil {
+			v.errorf(path, c, "Sample() n: %s", err)
+		} else if !ok || n == 0 {
+			v.errorf(path, c, "Sample() requires n greater than zero")
+		}
+	case "Limit":
+		v.validateChildren(c, path, 1, 1)
+		if limit, ok, err := c.UintArg("limit"); err != nil {
+			v.errorf(path, c, "Limit() limit: %s", err)
+		} else if !ok || limit == 0 {
+			v.errorf(path, c, "Limit() requires limit greater than zero")
+		}
+		v.validateUints(c, path, "after")
+		if idx.Keys() {
+			v.errorf(path, c, "Limit() is not supported on indexes with keys")
+		}
+	case "GroupBy":
+		for _, child := range c.Children {
+			if child.Name != "Rows" {
+				v.errorf(path, c, "GroupBy() only accepts Rows() calls, got %s()", child.Name)
+			}
+		}
+		v.validateUints(c, path, "limit")
+	case "Materialized":
+		name := callArgString(c, "name")
+		if name == "" {
+			v.errorf(path, c, "Materialized(): name required")
+		} else if idx.materializedView(name) == nil {
+			v.report(DiagnosticError, path, c, newNotFoundError(ErrMaterializedViewNotFound, name))
+		}
+	case "Index":
+		name, _ := c.Args["name"].(string)
+		if name == "" {
+			v.errorf(path, c, "Index(): name required")
+			return
+		} else if len(c.Children) != 1 {
+			v.errorf(path, c, "Index(): exactly one row query required")
+			return
+		}
+		other := v.holder.Index(name)
+		if other == nil {
+			v.report(DiagnosticError, path, c, newNotFoundError(ErrIndexNotFound, name))
+			return
+		} else if idx.Keys() || other.Keys() {
+			v.errorf(path, c, "Index(): indexes with column keys do not share column IDs")
+		}
+		v.validate(other, c.Children[0], append(path, 0))
+		return
+	}
+
+	for i, child := range c.Children {
+		v.validate(idx, child, append(path[:len(path):len(path)], i))
+	}
+	for _, name := range argNames(c) {
+		if child, ok := c.Args[name].(*pql.Call); ok {
+			v.validate(idx, child, path)
+		}
+	}
+}
+
+// validateRow checks a Row() or Range() call, which either selects a row of
+// a field, or compares an int field with a condition.
+func (v *queryValidator) validateRow(idx *Index, c *pql.Call, path []int) {
+	if c.HasConditionArg() {
+		for _, name := range argNames(c) {
+			cond, ok := c.Args[name].(*pql.Condition)
+			if !ok {
+				continue
+			}
+			f := v.field(idx, c, path, name)
+			if f == nil {
+				continue
+			} else if f.Type() != FieldTypeInt {
+				v.report(DiagnosticError, path, c, errors.Wrapf(ErrBSIGroupNotFound, "conditions require an int field, %q is a %s field", name, f.Type()))
+				continue
+			}
+			v.validateCondition(f, c, path, cond)
+		}
+		return
+	}
+
+	f := v.field(idx, c, path, fieldArgName(c))
+	if f == nil {
+		return
+	} else if f.Type() == FieldTypeInt {
+		v.report(DiagnosticWarning, path, c, NewBadRequestError(errors.Errorf("int field %q is compared with a condition, such as %s == 1, and has no rows", f.Name(), f.Name())))
+		return
+	}
+	v.validateRowValue(f, c, path, f.Name())
+
+	from, to, err := callTimeRange(c, idx.Location())
+	if err != nil {
+		v.errorf(path, c, "%s", err)
+	} else if (!from.IsZero() || !to.IsZero()) && f.TimeQuantum() == "" {
+		v.report(DiagnosticWarning, path, c, NewBadRequestError(errors.Errorf("field %q has no time quantum, so a time range matches nothing", f.Name())))
+	}
+}
+
+// validateCondition warns of conditions which can't match any value of an
+// int field, since they are outside its range.
+func (v *queryValidator) validateCondition(f *Field, c *pql.Call, path []int, cond *pql.Condition) {
+	if cond.Value == nil {
+		if cond.Op != pql.NEQ {
+			v.errorf(path, c, "only != null is supported")
+		}
+		return
+	}
+	var values []int64
+	if cond.Op == pql.BETWEEN {
+		var err error
+		if values, err = cond.IntSliceValue(); err != nil {
+			v.errorf(path, c, "getting condition value: %s", err)
+			return
+		} else if len(values) != 2 {
+			v.errorf(path, c, "Row(): BETWEEN condition requires exactly two integer values")
+			return
+		}
+	} else if n, ok := cond.Value.(int64); ok {
+		values = []int64{n}
+	} else {
+		v.errorf(path, c, "Row(): conditions only support integer values")
+		return
+	}
+
+	opt := f.Options()
+	lo, hi := values[0], values[len(values)-1]
+	var never bool
+	switch cond.Op {
+	case pql.EQ:
+		never = lo < opt.Min || lo > opt.Max
+	case pql.LT:
+		never = lo <= opt.Min
+	case pql.LTE:
+		never = lo < opt.Min
+	case pql.GT:
+		never = lo >= opt.Max
+	case pql.GTE:
+		never = lo > opt.Max
+	case pql.BETWEEN:
+		never = hi < opt.Min || lo > opt.Max || lo > hi
+	}
+	if never {
+		v.report(DiagnosticWarning, path, c, NewBadRequestError(errors.Errorf("condition %s matches nothing, since field %q is between %d and %d", cond, f.Name(), opt.Min, opt.Max)))
+	}
+}
+
+// validateRowValue checks the type of the row argument key of a call on f,
+// if it is given, and that a value set in an int field is within its range.
+func (v *queryValidator) validateRowValue(f *Field, c *pql.Call, path []int, key string) {
+	val, ok := c.Args[key]
+	if !ok || val == nil {
+		return
+	}
+	switch f.Type() {
+	case FieldTypeBool:
+		if _, ok := val.(bool); !ok {
+			v.errorf(path, c, "bool field %q requires true or false, got %v", f.Name(), val)
+		}
+	case FieldTypeInt:
+		n, ok := val.(int64)
+		if !ok {
+			v.errorf(path, c, "int field %q requires an integer, got %v", f.Name(), val)
+		} else if opt := f.Options(); n < opt.Min {
+			v.report(DiagnosticError, path, c, ErrBSIGroupValueTooLow)
+		} else if n > opt.Max {
+			v.report(DiagnosticError, path, c, ErrBSIGroupValueTooHigh)
+		}
+	default:
+		if f.keys() && !isString(val) {
+			v.errorf(path, c, "row value must be a string when field 'keys' option enabled")
+		} else if !f.keys() && isString(val) {
+			v.errorf(path, c, "string 'row' value not allowed unless field 'keys' option enabled")
+		} else if _, _, err := c.UintArg(key); !f.keys() && err != nil {
+			v.errorf(path, c, "%s", err)
+		}
+	}
+}
+
+// validateColumn checks the type of the column argument key of a call on
+// idx, if it is given.
+func (v *queryValidator) validateColumn(idx *Index, c *pql.Call, path []int, key string) {
+	val, ok := c.Args[key]
+	if !ok || val == nil {
+		return
+	}
+	if idx.Keys() && !isString(val) {
+		v.errorf(path, c, "column value must be a string when index 'keys' option enabled")
+	} else if !idx.Keys() && isString(val) {
+		v.errorf(path, c, "string 'col' value not allowed unless index 'keys' option enabled")
+	} else if _, _, err := c.UintArg(key); !idx.Keys() && err != nil {
+		v.errorf(path, c, "%s", err)
+	}
+}
+
+// validateUints checks that the arguments given of names are non-negative
+// integers.
+func (v *queryValidator) validateUints(c *pql.Call, path []int, names ...string) {
+	for _, name := range names {
+		if _, _, err := c.UintArg(name); err != nil {
+			v.errorf(path, c, "%s() %s: %s", c.Name, name, err)
+		}
+	}
+}
+
+// validateChildren checks the number of a call's children.
+func (v *queryValidator) validateChildren(c *pql.Call, path []int, min, max int) {
+	if n := len(c.Children); n < min {
+		v.errorf(path, c, "%s() requires an input row", c.Name)
+	} else if n > max {
+		v.errorf(path, c, "%s() only accepts a single row input", c.Name)
+	}
+}
+
+// field returns the named field of idx, reporting it if it doesn't exist.
+func (v *queryValidator) field(idx *Index, c *pql.Call, path []int, name string) *Field {
+	if name == "" {
+		v.errorf(path, c, "%s() argument required: field", c.Name)
+		return nil
+	}
+	f := idx.Field(name)
+	if f == nil {
+		v.report(DiagnosticError, path, c, newNotFoundError(ErrFieldNotFound, name))
+	}
+	return f
+}
+
+// argNames returns the names of a call's arguments in order, so that they're
+// reported in the same order each time.
+func argNames(c *pql.Call) []string {
+	names := make([]string, 0, len(c.Args))
+	for name := range c.Args {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// fieldArgName returns the name of the field a call such as Row(f=1) or
+// Set(1, f=1) is on.
+func fieldArgName(c *pql.Call) string {
+	name, _ := c.FieldArg()
+	return name
+}
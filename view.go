@@ -37,9 +37,23 @@ import (
 const (
 	viewStandard = "standard"
 
+	// viewInverse holds the standard view transposed, for fields with the
+	// inverse option. See inverse.go for its layout.
+	viewInverse = "inverse"
+
 	viewBSIGroupPrefix = "bsig_"
 )
 
+const (
+	// createShardAttempts is the number of times a new shard is broadcast
+	// before it is left to the gossiped node status.
+	createShardAttempts = 3
+
+	// createShardRetryDelay is the delay before the first retry of a new
+	// shard broadcast, which doubles for each retry after it.
+	createShardRetryDelay = 100 * time.Millisecond
+)
+
 // view represents a container for field data.
 type view struct {
 	mu    sync.RWMutex
@@ -52,6 +66,9 @@ type view struct {
 	cacheType string
 	cacheSize uint32
 
+	// In-memory views have no directory, and their fragments no files.
+	inMemory bool
+
 	// Fragments by shard.
 	fragments map[uint64]*fragment
 
@@ -60,6 +77,26 @@ type view struct {
 	rowAttrStore  AttrStore
 	logger        logger.Logger
 	snapshotQueue chan *fragment
+
+	// If lazyFragments is set, the fragments found when the view is opened
+	// are not opened until they are first accessed.
+	lazyFragments bool
+
+	// Counts the fragments opened while the holder opens.
+	progress *openProgress
+
+	filePool *filePool
+
+	// Passed to fragments, to group their writes and flush their files.
+	groupCommitWindow time.Duration
+	fsyncer           *fsyncer
+
+	// Directory corrupt fragment files are moved to.
+	quarantine *quarantine
+
+	// Passed to fragments, to record their changes for change data capture.
+	changes   *changeLog
+	valueBase int64
 }
 
 // newView returns a new instance of View.
@@ -90,6 +127,10 @@ func (v *view) open() error {
 		v.cacheType = CacheTypeNone
 	}
 
+	if v.inMemory {
+		return nil
+	}
+
 	if err := func() error {
 		// Ensure the view's path exists.
 		v.logger.Debugf("ensure view path exists: %s", v.path)
@@ -153,6 +194,18 @@ fileLoop:
 				continue
 			}
 
+			// Fragments opened lazily are only registered here.
+			if v.lazyFragments {
+				frag := v.newFragment(v.fragmentPath(shard), shard)
+				frag.unopened = 1
+				frag.RowAttrStore = v.rowAttrStore
+				mu.Lock()
+				v.fragments[frag.shard] = frag
+				mu.Unlock()
+				v.progress.fragmentOpened()
+				continue
+			}
+
 			workQueue <- struct{}{}
 			v.logger.Debugf("open index/field/view/fragment: %s/%s/%s/%d", v.index, v.field, v.name, shard)
 			eg.Go(func() error {
@@ -161,13 +214,21 @@ fileLoop:
 				}()
 				frag := v.newFragment(v.fragmentPath(shard), shard)
 				if err := frag.Open(); err != nil {
-					return fmt.Errorf("open fragment: shard=%d, err=%s", frag.shard, err)
+					if !v.canQuarantine(err) {
+						return fmt.Errorf("open fragment: shard=%d, err=%s", frag.shard, err)
+					}
+					if qerr := v.quarantineFragment(frag, err); qerr != nil {
+						return fmt.Errorf("open fragment: shard=%d, err=%s, quarantining also failed: %s", frag.shard, err, qerr)
+					}
+					v.progress.fragmentOpened()
+					return nil
 				}
 				frag.RowAttrStore = v.rowAttrStore
 				v.logger.Debugf("add index/field/view/fragment to view.fragments: %s/%s/%s/%d", v.index, v.field, v.name, shard)
 				mu.Lock()
 				v.fragments[frag.shard] = frag
 				mu.Unlock()
+				v.progress.fragmentOpened()
 				return nil
 			})
 		}
@@ -236,18 +297,75 @@ func (v *view) fragmentPath(shard uint64) string {
 // Fragment returns a fragment in the view by shard.
 func (v *view) Fragment(shard uint64) *fragment {
 	v.mu.RLock()
-	defer v.mu.RUnlock()
-	return v.fragments[shard]
+	frag := v.fragments[shard]
+	v.mu.RUnlock()
+	if frag == nil || !v.openFragment(frag) {
+		return nil
+	}
+	frag.touch()
+	return frag
 }
 
-// allFragments returns a list of all fragments in the view.
+// openFragment loads the data of a fragment which was opened lazily, if it
+// hasn't been loaded yet. Returns false if it can't be loaded.
+func (v *view) openFragment(frag *fragment) bool {
+	if err := frag.ensureOpen(); err != nil {
+		if v.canQuarantine(err) {
+			v.mu.Lock()
+			defer v.mu.Unlock()
+			if v.fragments[frag.shard] != frag {
+				// Already quarantined by another caller.
+				return false
+			}
+			qerr := v.quarantineFragment(frag, err)
+			if qerr == nil {
+				delete(v.fragments, frag.shard)
+				return false
+			}
+			err = fmt.Errorf("%s, quarantining also failed: %s", err, qerr)
+		}
+		v.logger.Printf("opening fragment: index=%s, field=%s, view=%s, shard=%d, err=%s", v.index, v.field, v.name, frag.shard, err)
+		return false
+	}
+	return true
+}
+
+// canQuarantine returns true if a fragment which failed to open with err
+// should be quarantined.
+func (v *view) canQuarantine(err error) bool {
+	return v.quarantine != nil && isCorruptFragment(err)
+}
+
+// allFragments returns a list of all fragments in the view, loading those
+// which were opened lazily.
 func (v *view) allFragments() []*fragment {
+	v.mu.Lock()
+	frags := make([]*fragment, 0, len(v.fragments))
+	for _, fragment := range v.fragments {
+		frags = append(frags, fragment)
+	}
+	v.mu.Unlock()
+
+	other := frags[:0]
+	for _, fragment := range frags {
+		if v.openFragment(fragment) {
+			other = append(other, fragment)
+		}
+	}
+	return other
+}
+
+// loadedFragments returns a list of the fragments in the view whose data
+// has been loaded.
+func (v *view) loadedFragments() []*fragment {
 	v.mu.Lock()
 	defer v.mu.Unlock()
 
 	other := make([]*fragment, 0, len(v.fragments))
 	for _, fragment := range v.fragments {
-		other = append(other, fragment)
+		if fragment.loaded() {
+			other = append(other, fragment)
+		}
 	}
 	return other
 }
@@ -259,48 +377,72 @@ func (v *view) recalculateCaches() {
 	}
 }
 
-// CreateFragmentIfNotExists returns a fragment in the view by shard.
+// CreateFragmentIfNotExists returns a fragment in the view by shard. If the
+// fragment is created, the new shard is broadcast to the cluster before
+// returning, so that the write which created it is visible to queries on
+// other nodes once it completes.
 func (v *view) CreateFragmentIfNotExists(shard uint64) (*fragment, error) {
+	frag, created, err := v.createFragmentIfNotExists(shard)
+	if err != nil {
+		return nil, err
+	} else if created {
+		v.broadcastCreateShard(shard)
+	}
+	return frag, nil
+}
+
+func (v *view) createFragmentIfNotExists(shard uint64) (*fragment, bool, error) {
 	v.mu.Lock()
 	defer v.mu.Unlock()
 	// Find fragment in cache first.
 	if frag := v.fragments[shard]; frag != nil {
-		return frag, nil
+		err := frag.ensureOpen()
+		if err == nil {
+			frag.touch()
+			return frag, false, nil
+		} else if !v.canQuarantine(err) {
+			return nil, false, errors.Wrap(err, "opening fragment")
+		}
+		// Replace a corrupt fragment with a new one.
+		if qerr := v.quarantineFragment(frag, err); qerr != nil {
+			return nil, false, errors.Wrapf(err, "opening fragment, quarantining also failed: %s", qerr)
+		}
+		delete(v.fragments, shard)
 	}
 
 	// Initialize and open fragment.
 	frag := v.newFragment(v.fragmentPath(shard), shard)
 	if err := frag.Open(); err != nil {
-		return nil, errors.Wrap(err, "opening fragment")
+		return nil, false, errors.Wrap(err, "opening fragment")
 	}
 	frag.RowAttrStore = v.rowAttrStore
 
 	v.fragments[shard] = frag
-	broadcastChan := make(chan struct{})
+	return frag, true, nil
+}
 
-	go func() {
-		msg := &CreateShardMessage{
-			Index: v.index,
-			Field: v.field,
-			Shard: shard,
-		}
-		// Broadcast a message that a new max shard was just created.
+// broadcastCreateShard tells the cluster that shard was just created,
+// retrying if the broadcast fails. Should every attempt fail, other nodes
+// still learn of the shard from the available shards in this node's gossiped
+// status, only later.
+func (v *view) broadcastCreateShard(shard uint64) {
+	msg := &CreateShardMessage{
+		Index: v.index,
+		Field: v.field,
+		Shard: shard,
+	}
+	delay := createShardRetryDelay
+	for i := 1; ; i++ {
 		err := v.broadcaster.SendSync(msg)
-		if err != nil {
-			v.logger.Printf("broadcasting create shard: %v", err)
+		if err == nil {
+			return
+		} else if i == createShardAttempts {
+			v.logger.Printf("broadcasting create shard %d for %s/%s after %d attempts: %v", shard, v.index, v.field, i, err)
+			return
 		}
-		close(broadcastChan)
-	}()
-
-	// We want to wait until the broadcast is complete, but what if it
-	// takes a really long time? So we time out.
-	select {
-	case <-broadcastChan:
-	case <-time.After(50 * time.Millisecond):
-		v.logger.Debugf("broadcasting create shard took >50ms")
+		time.Sleep(delay)
+		delay *= 2
 	}
-
-	return frag, nil
 }
 
 func (v *view) newFragment(path string, shard uint64) *fragment {
@@ -310,6 +452,12 @@ func (v *view) newFragment(path string, shard uint64) *fragment {
 	frag.Logger = v.logger
 	frag.stats = v.stats
 	frag.snapshotQueue = v.snapshotQueue
+	frag.filePool = v.filePool
+	frag.groupCommitWindow = v.groupCommitWindow
+	frag.fsyncer = v.fsyncer
+	frag.inMemory = v.inMemory
+	frag.changes = v.changes
+	frag.valueBase = v.valueBase
 	if v.fieldType == FieldTypeMutex {
 		frag.mutexVector = newRowsVector(frag)
 	} else if v.fieldType == FieldTypeBool {
@@ -334,14 +482,15 @@ func (v *view) deleteFragment(shard uint64) error {
 		return errors.Wrap(err, "closing fragment")
 	}
 
-	// Delete fragment file.
-	if err := os.Remove(fragment.path); err != nil {
-		return errors.Wrap(err, "deleting fragment file")
-	}
-
-	// Delete fragment cache file.
-	if err := os.Remove(fragment.cachePath()); err != nil {
-		v.logger.Printf("no cache file to delete for shard %d", shard)
+	// Delete fragment file and cache file.
+	if !fragment.inMemory {
+		if err := os.Remove(fragment.path); err != nil {
+			return errors.Wrap(err, "deleting fragment file")
+		}
+		if err := os.Remove(fragment.cachePath()); err != nil {
+			v.logger.Printf("no cache file to delete for shard %d", shard)
+		}
+		_ = os.Remove(fragment.statsPath())
 	}
 
 	delete(v.fragments, shard)
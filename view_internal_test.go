@@ -19,6 +19,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/pkg/errors"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -38,9 +39,7 @@ func mustOpenView(index, field, name string) *view {
 	if err := v.open(); err != nil {
 		panic(err)
 	}
-	v.rowAttrStore = &memAttrStore{
-		store: make(map[uint64]map[string]interface{}),
-	}
+	v.rowAttrStore = newMemAttrStore("")
 	return v
 }
 
@@ -115,3 +114,43 @@ func (d delayBroadcaster) SendSync(Message) error {
 	time.Sleep(d.delay)
 	return nil
 }
+
+// Ensure a new shard is broadcast before the fragment is returned, retrying
+// failed broadcasts.
+func TestView_CreateFragmentBroadcastRetry(t *testing.T) {
+	v := mustOpenView("i", "f", "v")
+	defer v.close()
+
+	b := &failBroadcaster{failures: 2}
+	v.broadcaster = b
+
+	if _, err := v.CreateFragmentIfNotExists(3); err != nil {
+		t.Fatal(err)
+	} else if b.sends != 3 {
+		t.Fatalf("unexpected broadcasts: %d", b.sends)
+	}
+
+	// Existing fragments are not broadcast again.
+	if _, err := v.CreateFragmentIfNotExists(3); err != nil {
+		t.Fatal(err)
+	} else if b.sends != 3 {
+		t.Fatalf("unexpected broadcasts: %d", b.sends)
+	}
+}
+
+// failBroadcaster is a nopBroadcaster whose first sends fail.
+type failBroadcaster struct {
+	nopBroadcaster
+	failures int
+	sends    int
+}
+
+// SendSync is an implementation of Broadcaster SendSync which fails until
+// it has been called more than the configured number of failures.
+func (b *failBroadcaster) SendSync(Message) error {
+	b.sends++
+	if b.sends <= b.failures {
+		return errors.New("broadcast failed")
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+;; colsum.wasm: a map/reduce UDF which sums column ids.
+(module
+  (memory (export "memory") 1)
+
+  ;; alloc grows memory to hold $n bytes and returns offset 0.
+  (func (export "alloc") (param $n i32) (result i32) (local $delta i32)
+    (local.set $delta
+      (i32.sub
+        (i32.shr_u (i32.add (local.get $n) (i32.const 65535)) (i32.const 16))
+        (memory.size)))
+    (if (i32.gt_s (local.get $delta) (i32.const 0))
+      (then (drop (memory.grow (local.get $delta)))))
+    (i32.const 0))
+
+  (func (export "map") (param $ptr i32) (param $n i32) (result i64)
+    (local $i i32) (local $sum i64)
+    (block $done
+      (loop $next
+        (br_if $done (i32.ge_u (local.get $i) (local.get $n)))
+        (local.set $sum
+          (i64.add (local.get $sum)
+            (i64.load (i32.add (local.get $ptr) (i32.mul (local.get $i) (i32.const 8))))))
+        (local.set $i (i32.add (local.get $i) (i32.const 1)))
+        (br $next)))
+    (local.get $sum))
+
+  (func (export "reduce") (param $a i64) (param $b i64) (result i64)
+    (i64.add (local.get $a) (local.get $b))))
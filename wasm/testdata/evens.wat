@@ -0,0 +1,4 @@
+;; evens.wasm: a filter UDF which keeps even column ids.
+(module
+  (func (export "filter") (param $col i64) (result i32)
+    (i64.eqz (i64.rem_u (local.get $col) (i64.const 2)))))
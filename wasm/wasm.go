@@ -0,0 +1,227 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package wasm implements pilosa.UDFRuntime using WebAssembly.
+//
+// Modules are sandboxed: they may not import any host functions, their
+// memory is limited, and execution is aborted when the query's context is
+// done. A module must export one of the following sets of functions:
+//
+//	filter(column i64) i32
+//	    Returns non-zero if the column should be kept.
+//
+//	memory
+//	alloc(size i32) i32
+//	    Returns a pointer to size bytes of memory.
+//	map(ptr i32, n i32) i64
+//	    Computes a value from n little-endian uint64 column ids at ptr.
+//	reduce(a i64, b i64) i64
+//	    Merges two values returned by map or reduce.
+package wasm
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/pilosa/pilosa/v2"
+	"github.com/pkg/errors"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+)
+
+// DefaultMemoryLimitPages is the default maximum number of 64KiB pages of
+// memory available to a module instance. This is enough to hold the column
+// ids of a full shard.
+const DefaultMemoryLimitPages = 1024
+
+// Ensure Runtime implements interface.
+var _ pilosa.UDFRuntime = &Runtime{}
+
+// Runtime compiles WebAssembly modules.
+type Runtime struct {
+	rt wazero.Runtime
+}
+
+// NewRuntime returns a new Runtime which limits module instances to
+// memoryLimitPages pages of memory.
+func NewRuntime(memoryLimitPages uint32) *Runtime {
+	cfg := wazero.NewRuntimeConfig().
+		WithMemoryLimitPages(memoryLimitPages).
+		WithCloseOnContextDone(true)
+	return &Runtime{rt: wazero.NewRuntimeWithConfig(context.Background(), cfg)}
+}
+
+// Close releases all resources held by the runtime and its modules.
+func (r *Runtime) Close() error {
+	return r.rt.Close(context.Background())
+}
+
+// Compile compiles and validates a WebAssembly module.
+func (r *Runtime) Compile(ctx context.Context, code []byte) (pilosa.UDFModule, error) {
+	compiled, err := r.rt.CompileModule(ctx, code)
+	if err != nil {
+		return nil, errors.Wrap(err, "compiling")
+	}
+
+	m := &module{rt: r.rt, compiled: compiled}
+	if err := m.validate(); err != nil {
+		compiled.Close(ctx)
+		return nil, err
+	}
+	return m, nil
+}
+
+// module is a compiled module. A new instance is created for each call so
+// that calls cannot share state and may run concurrently.
+type module struct {
+	rt       wazero.Runtime
+	compiled wazero.CompiledModule
+	filter   bool
+}
+
+// signatures of the functions a module may export.
+var (
+	filterSignature = signature{[]api.ValueType{api.ValueTypeI64}, []api.ValueType{api.ValueTypeI32}}
+	allocSignature  = signature{[]api.ValueType{api.ValueTypeI32}, []api.ValueType{api.ValueTypeI32}}
+	mapSignature    = signature{[]api.ValueType{api.ValueTypeI32, api.ValueTypeI32}, []api.ValueType{api.ValueTypeI64}}
+	reduceSignature = signature{[]api.ValueType{api.ValueTypeI64, api.ValueTypeI64}, []api.ValueType{api.ValueTypeI64}}
+)
+
+type signature struct {
+	params  []api.ValueType
+	results []api.ValueType
+}
+
+// matches returns true if def has the signature.
+func (s signature) matches(def api.FunctionDefinition) bool {
+	return string(def.ParamTypes()) == string(s.params) && string(def.ResultTypes()) == string(s.results)
+}
+
+// validate checks the module's imports and exports.
+func (m *module) validate() error {
+	if imports := m.compiled.ImportedFunctions(); len(imports) > 0 {
+		mod, name, _ := imports[0].Import()
+		return fmt.Errorf("module may not import functions: %s.%s", mod, name)
+	}
+
+	fns := m.compiled.ExportedFunctions()
+	if def, ok := fns["filter"]; ok {
+		if !filterSignature.matches(def) {
+			return errors.New("filter must have signature (i64) -> i32")
+		}
+		m.filter = true
+		return nil
+	}
+
+	for name, sig := range map[string]signature{
+		"alloc":  allocSignature,
+		"map":    mapSignature,
+		"reduce": reduceSignature,
+	} {
+		def, ok := fns[name]
+		if !ok {
+			return errors.New("module must export either filter or alloc, map, and reduce")
+		} else if !sig.matches(def) {
+			return fmt.Errorf("%s has an invalid signature", name)
+		}
+	}
+	if _, ok := m.compiled.ExportedMemories()["memory"]; !ok {
+		return errors.New("module must export memory")
+	}
+	return nil
+}
+
+// instantiate returns a new, anonymous instance of the module.
+func (m *module) instantiate(ctx context.Context) (api.Module, error) {
+	inst, err := m.rt.InstantiateModule(ctx, m.compiled, wazero.NewModuleConfig().WithName(""))
+	if err != nil {
+		return nil, errors.Wrap(err, "instantiating")
+	}
+	return inst, nil
+}
+
+// IsFilter returns true if the module exports filter.
+func (m *module) IsFilter() bool { return m.filter }
+
+// Filter calls the module's filter function for each column.
+func (m *module) Filter(ctx context.Context, columns []uint64) ([]uint64, error) {
+	inst, err := m.instantiate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer inst.Close(ctx)
+
+	fn := inst.ExportedFunction("filter")
+	var a []uint64
+	for _, col := range columns {
+		res, err := fn.Call(ctx, col)
+		if err != nil {
+			return nil, errors.Wrap(err, "calling filter")
+		}
+		if uint32(res[0]) != 0 {
+			a = append(a, col)
+		}
+	}
+	return a, nil
+}
+
+// Map copies columns into the module's memory and calls its map function.
+func (m *module) Map(ctx context.Context, columns []uint64) (int64, error) {
+	inst, err := m.instantiate(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer inst.Close(ctx)
+
+	buf := make([]byte, 8*len(columns))
+	for i, col := range columns {
+		binary.LittleEndian.PutUint64(buf[i*8:], col)
+	}
+
+	res, err := inst.ExportedFunction("alloc").Call(ctx, uint64(len(buf)))
+	if err != nil {
+		return 0, errors.Wrap(err, "calling alloc")
+	}
+	ptr := uint32(res[0])
+	if !inst.Memory().Write(ptr, buf) {
+		return 0, fmt.Errorf("alloc returned out of range pointer: %d", ptr)
+	}
+
+	res, err = inst.ExportedFunction("map").Call(ctx, uint64(ptr), uint64(len(columns)))
+	if err != nil {
+		return 0, errors.Wrap(err, "calling map")
+	}
+	return int64(res[0]), nil
+}
+
+// Reduce calls the module's reduce function.
+func (m *module) Reduce(ctx context.Context, a, b int64) (int64, error) {
+	inst, err := m.instantiate(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer inst.Close(ctx)
+
+	res, err := inst.ExportedFunction("reduce").Call(ctx, uint64(a), uint64(b))
+	if err != nil {
+		return 0, errors.Wrap(err, "calling reduce")
+	}
+	return int64(res[0]), nil
+}
+
+// Close releases the compiled module.
+func (m *module) Close(ctx context.Context) error {
+	return m.compiled.Close(ctx)
+}
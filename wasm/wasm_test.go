@@ -0,0 +1,115 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wasm_test
+
+import (
+	"context"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/pilosa/pilosa/v2"
+	"github.com/pilosa/pilosa/v2/wasm"
+)
+
+func TestRuntime_Filter(t *testing.T) {
+	ctx := context.Background()
+	r := wasm.NewRuntime(wasm.DefaultMemoryLimitPages)
+	defer r.Close()
+
+	mod := mustCompile(t, r, "testdata/evens.wasm")
+	defer mod.Close(ctx)
+
+	if !mod.IsFilter() {
+		t.Fatal("expected filter module")
+	}
+	if a, err := mod.Filter(ctx, []uint64{1, 2, 3, 4, 1 << 40}); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(a, []uint64{2, 4, 1 << 40}) {
+		t.Fatalf("unexpected columns: %v", a)
+	}
+}
+
+func TestRuntime_MapReduce(t *testing.T) {
+	ctx := context.Background()
+	r := wasm.NewRuntime(wasm.DefaultMemoryLimitPages)
+	defer r.Close()
+
+	mod := mustCompile(t, r, "testdata/colsum.wasm")
+	defer mod.Close(ctx)
+
+	if mod.IsFilter() {
+		t.Fatal("unexpected filter module")
+	}
+
+	// Use enough columns to require growing memory.
+	columns := make([]uint64, 20000)
+	var sum int64
+	for i := range columns {
+		columns[i] = uint64(i * 3)
+		sum += int64(i * 3)
+	}
+	if v, err := mod.Map(ctx, columns); err != nil {
+		t.Fatal(err)
+	} else if v != sum {
+		t.Fatalf("unexpected map value: %d", v)
+	}
+
+	if v, err := mod.Reduce(ctx, 10, 32); err != nil {
+		t.Fatal(err)
+	} else if v != 42 {
+		t.Fatalf("unexpected reduce value: %d", v)
+	}
+
+	// Map should fail once the memory limit is exceeded.
+	r2 := wasm.NewRuntime(1)
+	defer r2.Close()
+	mod2 := mustCompile(t, r2, "testdata/colsum.wasm")
+	defer mod2.Close(ctx)
+	if _, err := mod2.Map(ctx, columns); err == nil {
+		t.Fatal("expected memory limit error")
+	}
+}
+
+func TestRuntime_Compile_Invalid(t *testing.T) {
+	r := wasm.NewRuntime(wasm.DefaultMemoryLimitPages)
+	defer r.Close()
+
+	t.Run("NotWASM", func(t *testing.T) {
+		if _, err := r.Compile(context.Background(), []byte("not wasm")); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+
+	// An empty module exports neither filter nor map/reduce.
+	t.Run("NoExports", func(t *testing.T) {
+		if _, err := r.Compile(context.Background(), []byte("\x00asm\x01\x00\x00\x00")); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+func mustCompile(tb testing.TB, r *wasm.Runtime, path string) pilosa.UDFModule {
+	tb.Helper()
+	code, err := ioutil.ReadFile(path)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	mod, err := r.Compile(context.Background(), code)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return mod
+}
@@ -0,0 +1,115 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pilosa/pilosa/v2/logger"
+	"github.com/pkg/errors"
+)
+
+// schemaWebhookQueueSize is the number of notifications which may be pending
+// delivery before new notifications are dropped.
+const schemaWebhookQueueSize = 1000
+
+// schemaWebhooks is an EventHandler which posts schema events as JSON to a
+// set of URLs. Each node sends its own notification when it applies the
+// change, so receivers will see one event per node. Delivery is asynchronous
+// and best-effort; failures are logged.
+type schemaWebhooks struct {
+	urls   []string
+	queue  chan SchemaEvent
+	client *http.Client
+
+	Logger logger.Logger
+}
+
+// newSchemaWebhooks returns a notifier which posts to urls. It returns nil
+// if no urls are given.
+func newSchemaWebhooks(urls []string) *schemaWebhooks {
+	if len(urls) == 0 {
+		return nil
+	}
+	return &schemaWebhooks{
+		urls:   urls,
+		queue:  make(chan SchemaEvent, schemaWebhookQueueSize),
+		client: &http.Client{Timeout: 10 * time.Second},
+		Logger: logger.NopLogger,
+	}
+}
+
+// HandleEvent implements EventHandler. Schema events are queued for
+// delivery without blocking; all other events are ignored.
+func (w *schemaWebhooks) HandleEvent(e Event) {
+	ev, ok := e.(SchemaEvent)
+	if !ok {
+		return
+	}
+	if ev.Time.IsZero() {
+		ev.Time = time.Now().UTC()
+	}
+	select {
+	case w.queue <- ev:
+	default:
+		w.Logger.Printf("schema webhook queue full, dropping %s event for %s", ev.Type, ev.Index)
+	}
+}
+
+// run delivers queued notifications until closing is closed.
+func (w *schemaWebhooks) run(closing <-chan struct{}) {
+	if w == nil {
+		return
+	}
+	for {
+		select {
+		case <-closing:
+			return
+		case ev := <-w.queue:
+			for _, u := range w.urls {
+				if err := postJSON(w.client, u, ev); err != nil {
+					w.Logger.Printf("schema webhook error: url=%s, err=%s", u, err)
+				}
+			}
+		}
+	}
+}
+
+// postJSON posts v as JSON to u.
+func postJSON(client *http.Client, u string, v interface{}) error {
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return errors.Wrap(err, "encoding")
+	}
+	req, err := http.NewRequest("POST", u, bytes.NewReader(buf))
+	if err != nil {
+		return errors.Wrap(err, "making new request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "posting")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("http: status=%d", resp.StatusCode)
+	}
+	return nil
+}